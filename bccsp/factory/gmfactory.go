@@ -1,8 +1,11 @@
 package factory
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/gm"
@@ -34,7 +37,20 @@ func (f *GMFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 	if gmOpts.Ephemeral == true {
 		ks = gm.NewDummyKeyStore()
 	} else if gmOpts.FileKeystore != nil {
-		fks, err := gm.NewFileBasedKeyStore(nil, gmOpts.FileKeystore.KeyStorePath, false)
+		pwd, err := keyStorePassword(gmOpts.FileKeystore)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load gm key store password: %s", err)
+		}
+		if gmOpts.FileKeystore.Migrate {
+			if len(pwd) == 0 {
+				return nil, errors.New("Key store migration requires a password")
+			}
+			err := gm.ReencryptFileBasedKeyStore(gmOpts.FileKeystore.KeyStorePath, nil, pwd)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to migrate gm key store: %s", err)
+			}
+		}
+		fks, err := gm.NewFileBasedKeyStore(pwd, gmOpts.FileKeystore.KeyStorePath, false)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to initialize gm software key store: %s", err)
 		}
@@ -46,3 +62,30 @@ func (f *GMFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 
 	return gm.New(gmOpts.SecLevel, gmOpts.HashFamily, ks)
 }
+
+// keyStorePassword returns the password configured for the key store, or
+// nil when the key store is not encrypted
+func keyStorePassword(opts *FileKeystoreOpts) ([]byte, error) {
+	switch {
+	case opts.PasswordEnv != "" && opts.PasswordFile != "":
+		return nil, errors.New("PasswordEnv and PasswordFile are mutually exclusive")
+	case opts.PasswordEnv != "":
+		pwd, ok := os.LookupEnv(opts.PasswordEnv)
+		if !ok || pwd == "" {
+			return nil, fmt.Errorf("environment variable %s is not set", opts.PasswordEnv)
+		}
+		return []byte(pwd), nil
+	case opts.PasswordFile != "":
+		raw, err := ioutil.ReadFile(opts.PasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		pwd := bytes.TrimRight(raw, "\r\n")
+		if len(pwd) == 0 {
+			return nil, fmt.Errorf("password file %s is empty", opts.PasswordFile)
+		}
+		return pwd, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/gm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyStorePassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gmfactory")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pwd, err := keyStorePassword(&FileKeystoreOpts{})
+	assert.NoError(t, err)
+	assert.Nil(t, pwd)
+
+	os.Setenv("GMFACTORY_TEST_PASSWORD", "from-env")
+	defer os.Unsetenv("GMFACTORY_TEST_PASSWORD")
+	pwd, err = keyStorePassword(&FileKeystoreOpts{PasswordEnv: "GMFACTORY_TEST_PASSWORD"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("from-env"), pwd)
+
+	_, err = keyStorePassword(&FileKeystoreOpts{PasswordEnv: "GMFACTORY_TEST_UNSET"})
+	assert.EqualError(t, err, "environment variable GMFACTORY_TEST_UNSET is not set")
+
+	pwdFile := filepath.Join(dir, "password")
+	require.NoError(t, ioutil.WriteFile(pwdFile, []byte("from-file\n"), 0600))
+	pwd, err = keyStorePassword(&FileKeystoreOpts{PasswordFile: pwdFile})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("from-file"), pwd)
+
+	_, err = keyStorePassword(&FileKeystoreOpts{PasswordFile: filepath.Join(dir, "missing")})
+	assert.Error(t, err)
+
+	_, err = keyStorePassword(&FileKeystoreOpts{PasswordEnv: "GMFACTORY_TEST_PASSWORD", PasswordFile: pwdFile})
+	assert.EqualError(t, err, "PasswordEnv and PasswordFile are mutually exclusive")
+}
+
+func TestGMFactoryMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gmfactory")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Populate a plain keystore
+	ks, err := gm.NewFileBasedKeyStore(nil, dir, false)
+	require.NoError(t, err)
+	csp, err := gm.New(256, "GMSM3", ks)
+	require.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{})
+	require.NoError(t, err)
+
+	os.Setenv("GMFACTORY_TEST_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("GMFACTORY_TEST_PASSWORD")
+	opts := &FactoryOpts{
+		SwOpts: &SwOpts{
+			SecLevel:   256,
+			HashFamily: "GMSM3",
+			FileKeystore: &FileKeystoreOpts{
+				KeyStorePath: dir,
+				PasswordEnv:  "GMFACTORY_TEST_PASSWORD",
+				Migrate:      true,
+			},
+		},
+	}
+	csp, err = (&GMFactory{}).Get(opts)
+	require.NoError(t, err)
+
+	loaded, err := csp.GetKey(k.SKI())
+	require.NoError(t, err)
+	assert.Equal(t, k.SKI(), loaded.SKI())
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	raw, err := ioutil.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+	assert.Equal(t, "ENCRYPTED PRIVATE KEY", block.Type)
+
+	opts.SwOpts.FileKeystore.PasswordEnv = ""
+	_, err = (&GMFactory{}).Get(opts)
+	assert.EqualError(t, err, "Key store migration requires a password")
+}
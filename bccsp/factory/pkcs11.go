@@ -53,7 +53,7 @@ func initFactories(config *FactoryOpts) error {
 
 	// Software-Based BCCSP
 	// if config.ProviderName == "SW" && config.SwOpts != nil {
-	if config.ProviderName == "SW" && config.SwOpts != nil {
+	if (config.ProviderName == "SW" || config.ProviderName == "GM") && config.SwOpts != nil {
 		// f := &SWFactory{}
 		var f BCCSPFactory
 		switch config.ProviderName {
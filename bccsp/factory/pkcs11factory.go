@@ -10,6 +10,7 @@ package factory
 
 import (
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/gm"
 	"github.com/hyperledger/fabric/bccsp/pkcs11"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/pkg/errors"
@@ -37,6 +38,9 @@ func (f *PKCS11Factory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 
 	p11Opts := config.Pkcs11Opts
 	ks := sw.NewDummyKeyStore()
+	if p11Opts.HashFamily == "GMSM3" {
+		ks = gm.NewDummyKeyStore()
+	}
 
 	return pkcs11.New(*p11Opts, ks)
 }
@@ -79,6 +79,15 @@ type SwOpts struct {
 // Pluggable Keystores, could add JKS, P12, etc..
 type FileKeystoreOpts struct {
 	KeyStorePath string `mapstructure:"keystore" yaml:"KeyStore"`
+
+	// Password options of the GM keystore. The password is read from the
+	// environment variable named by PasswordEnv or from PasswordFile; at
+	// most one of them may be set.
+	PasswordEnv  string `mapstructure:"passwordenv,omitempty" yaml:"PasswordEnv,omitempty"`
+	PasswordFile string `mapstructure:"passwordfile,omitempty" yaml:"PasswordFile,omitempty"`
+	// Migrate re-encrypts the plain keys of the keystore with the password
+	// when the BCCSP is initialized
+	Migrate bool `mapstructure:"migrate,omitempty" yaml:"Migrate,omitempty"`
 }
 
 type DummyKeystoreOpts struct{}
@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestECDSAP256InGMProvider(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "gmks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ecdsa"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	require.NoError(t, err)
+	cert, err := gmx509.ParseCertificate(certDER)
+	require.NoError(t, err)
+
+	ks, err := NewFileBasedKeyStore(nil, dir, false)
+	require.NoError(t, err)
+	csp, err := New(256, "GMSM3", ks)
+	require.NoError(t, err)
+
+	pk, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+
+	// The PKCS#8 key written by other tools is found by its SKI
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	skPath := filepath.Join(dir, hex.EncodeToString(pk.SKI())+"_sk")
+	err = ioutil.WriteFile(skPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	require.NoError(t, err)
+	sk, err := csp.GetKey(pk.SKI())
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("hybrid"))
+	sig, err := csp.Sign(sk, digest[:], nil)
+	require.NoError(t, err)
+
+	// Signatures are plain low-S ECDSA
+	r, s, err := utils.UnmarshalECDSASignature(sig)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.Verify(&priv.PublicKey, digest[:], r, s))
+	lowS, err := utils.IsLowS(&priv.PublicKey, s)
+	require.NoError(t, err)
+	assert.True(t, lowS)
+
+	valid, err := csp.Verify(pk, sig, digest[:], nil)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(pk, sig, []byte("tampered digest of the right size"), nil)
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	highS, err := utils.MarshalECDSASignature(r, new(big.Int).Sub(elliptic.P256().Params().N, s))
+	require.NoError(t, err)
+	_, err = csp.Verify(pk, highS, digest[:], nil)
+	assert.Error(t, err)
+}
+
+func TestSM2CertificateKeyWithUserID(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "GMSM3", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	sk, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	sm2Key := sk.(*gmsm2PrivateKey).privKey
+
+	// X.509 parsing turns SM2 keys into ecdsa keys on the SM2 curve
+	pk := &ecdsaPublicKey{&ecdsa.PublicKey{Curve: sm2.P256Sm2(), X: sm2Key.X, Y: sm2Key.Y}}
+
+	msg := []byte("hybrid")
+	sig, err := csp.Sign(sk, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+
+	valid, err := csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{UID: []byte("ALICE123@YAHOO.COM")})
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
@@ -17,7 +17,9 @@ package gm
 
 import (
 	"bytes"
+	"encoding/asn1"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -30,7 +32,6 @@ import (
 	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/littlegirlpppp/gmsm/sm2"
 	gmx509 "github.com/littlegirlpppp/gmsm/x509"
-	"github.com/littlegirlpppp/gmsm/sm4"
 )
 //todo：国密：增加gm
 // NewFileBasedKeyStore instantiated a file-based key store at a given position.
@@ -133,12 +134,7 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (k bccsp.Key, err error) {
 			return nil, fmt.Errorf("Failed loading secret key [%x] [%s]", ski, err)
 		}
 
-		switch key.(type) {
-		case *sm2.PrivateKey:
-			return &gmsm2PrivateKey{key.(*sm2.PrivateKey)}, nil
-		default:
-			return nil, errors.New("Secret key type not recognized")
-		}
+		return privateKeyToBCCSPKey(key)
 	case "pk":
 		// Load the public key
 		key, err := ks.loadPublicKey(hex.EncodeToString(ski))
@@ -176,6 +172,14 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 			return fmt.Errorf("Failed storing GMSM2 private key [%s]", err)
 		}
 
+	case *ecdsaPrivateKey:
+		kk := k.(*ecdsaPrivateKey)
+
+		err = ks.storePrivateKey(hex.EncodeToString(k.SKI()), kk.privKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing ECDSA private key [%s]", err)
+		}
+
 	case *gmsm2PublicKey:
 		kk := k.(*gmsm2PublicKey)
 
@@ -211,15 +215,13 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 			continue
 		}
 
-		key, err := utils.PEMtoPrivateKey(raw, ks.pwd)
+		key, err := pemToPrivateKey(raw, ks.pwd)
 		if err != nil {
 			continue
 		}
 
-		switch key.(type) {
-		case *sm2.PrivateKey:
-			k = &gmsm2PrivateKey{key.(*sm2.PrivateKey)}
-		default:
+		k, err = privateKeyToBCCSPKey(key)
+		if err != nil {
 			continue
 		}
 
@@ -252,7 +254,7 @@ func (ks *fileBasedKeyStore) getSuffix(alias string) string {
 }
 
 func (ks *fileBasedKeyStore) storePrivateKey(alias string, privateKey interface{}) error {
-	rawKey, err := utils.PrivateKeyToPEM(privateKey, ks.pwd)
+	rawKey, err := privateKeyToPEM(privateKey, ks.pwd)
 	if err != nil {
 		logger.Errorf("Failed converting private key to PEM [%s]: [%s]", alias, err)
 		return err
@@ -267,8 +269,9 @@ func (ks *fileBasedKeyStore) storePrivateKey(alias string, privateKey interface{
 	return nil
 }
 
+// Public keys are not confidential and are always stored in clear
 func (ks *fileBasedKeyStore) storePublicKey(alias string, publicKey interface{}) error {
-	rawKey, err := utils.PublicKeyToPEM(publicKey, ks.pwd)
+	rawKey, err := utils.PublicKeyToPEM(publicKey, nil)
 	if err != nil {
 		logger.Errorf("Failed converting public key to PEM [%s]: [%s]", alias, err)
 		return err
@@ -284,13 +287,7 @@ func (ks *fileBasedKeyStore) storePublicKey(alias string, publicKey interface{})
 }
 
 func (ks *fileBasedKeyStore) storeKey(alias string, key []byte) error {
-	//pem, err := utils.AEStoEncryptedPEM(key, ks.pwd)
-
-	if len(ks.pwd) == 0 {
-		ks.pwd = nil
-	}
-
-	pem, err := sm4.WriteKeyToPem(key, ks.pwd)
+	pem, err := sm4KeyToPEM(key, ks.pwd)
 	if err != nil {
 		logger.Errorf("Failed converting key to PEM [%s]: [%s]", alias, err)
 		return err
@@ -317,8 +314,7 @@ func (ks *fileBasedKeyStore) loadPrivateKey(alias string) (interface{}, error) {
 		return nil, err
 	}
 
-	// privateKey, err := utils.PEMtoPrivateKey(raw, ks.pwd)
-	privateKey, err := gmx509.ReadPrivateKeyFromPem(raw, nil)
+	privateKey, err := pemToPrivateKey(raw, ks.pwd)
 	if err != nil {
 		logger.Errorf("Failed parsing private key [%s]: [%s].", alias, err.Error())
 
@@ -362,11 +358,7 @@ func (ks *fileBasedKeyStore) loadKey(alias string) ([]byte, error) {
 		return nil, err
 	}
 
-	//key, err := utils.PEMtoAES(pem, ks.pwd)
-	if len(ks.pwd) == 0 {
-		ks.pwd = nil
-	}
-	key, err := sm4.ReadKeyFromPem(pem, ks.pwd)
+	key, err := pemToSM4Key(pem, ks.pwd)
 	if err != nil {
 		logger.Errorf("Failed parsing key [%s]: [%s]", alias, err)
 
@@ -418,3 +410,100 @@ func (ks *fileBasedKeyStore) openKeyStore() error {
 func (ks *fileBasedKeyStore) getPathForAlias(alias, suffix string) string {
 	return filepath.Join(ks.path, alias+"_"+suffix)
 }
+
+// ReencryptFileBasedKeyStore rewrites the private and SM4 keys found in the
+// keystore at path so that they are protected by newPwd. Keys are read with
+// oldPwd, which is nil when migrating a plain keystore. Keys that are
+// already protected by newPwd are left untouched, so the migration can be
+// run again after an interruption. An empty newPwd decrypts the keystore.
+// Public keys and any other file are ignored.
+func ReencryptFileBasedKeyStore(path string, oldPwd, newPwd []byte) error {
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return fmt.Errorf("Failed reading KeyStore [%s]: [%s]", path, err)
+	}
+
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		file := filepath.Join(path, f.Name())
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("Failed reading [%s]: [%s]", file, err)
+		}
+
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			continue
+		}
+
+		var reencrypted []byte
+		switch block.Type {
+		case pemTypePrivateKey, pemTypeEncryptedPrivateKey:
+			if isProtectedBy(block, newPwd) {
+				continue
+			}
+			key, err := pemToPrivateKey(raw, oldPwd)
+			if err != nil {
+				return fmt.Errorf("Failed loading private key [%s]: [%s]", file, err)
+			}
+			reencrypted, err = privateKeyToPEM(key, newPwd)
+			if err != nil {
+				return fmt.Errorf("Failed encrypting private key [%s]: [%s]", file, err)
+			}
+		case pemTypeSM4Key, pemTypeEncryptedSM4Key, pemTypeLegacySM4Key:
+			if isProtectedBy(block, newPwd) {
+				continue
+			}
+			key, err := pemToSM4Key(raw, oldPwd)
+			if err != nil {
+				return fmt.Errorf("Failed loading key [%s]: [%s]", file, err)
+			}
+			reencrypted, err = sm4KeyToPEM(key, newPwd)
+			if err != nil {
+				return fmt.Errorf("Failed encrypting key [%s]: [%s]", file, err)
+			}
+		default:
+			continue
+		}
+
+		// Write next to the original and rename so that a failure never
+		// leaves a truncated key behind
+		tmp := file + ".tmp"
+		if err := ioutil.WriteFile(tmp, reencrypted, f.Mode().Perm()); err != nil {
+			return fmt.Errorf("Failed writing [%s]: [%s]", tmp, err)
+		}
+		if err := os.Rename(tmp, file); err != nil {
+			os.Remove(tmp)
+			return fmt.Errorf("Failed replacing [%s]: [%s]", file, err)
+		}
+		logger.Infof("Re-encrypted key [%s]", file)
+	}
+
+	return nil
+}
+
+// isProtectedBy tells whether block is already in the format the keystore
+// would write with pwd
+func isProtectedBy(block *pem.Block, pwd []byte) bool {
+	switch block.Type {
+	case pemTypeEncryptedPrivateKey, pemTypeEncryptedSM4Key:
+		if len(pwd) == 0 {
+			return false
+		}
+		info := &encryptedPrivateKeyInfo{}
+		if _, err := asn1.Unmarshal(block.Bytes, info); err != nil {
+			return false
+		}
+		if _, ok := isSM4PBES2(info); !ok {
+			return false
+		}
+		_, err := decryptPKCS8(block.Bytes, pwd)
+		return err == nil
+	case pemTypePrivateKey, pemTypeSM4Key:
+		return len(pwd) == 0
+	default:
+		return false
+	}
+}
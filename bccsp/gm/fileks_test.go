@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pemType(t *testing.T, path string) string {
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+	return block.Type
+}
+
+func TestEncryptedFileKeyStore(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "gmks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pwd := []byte("s3cr3t")
+	ks, err := NewFileBasedKeyStore(pwd, dir, false)
+	require.NoError(t, err)
+	csp, err := New(256, "GMSM3", ks)
+	require.NoError(t, err)
+
+	sk, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{})
+	require.NoError(t, err)
+	k4, err := csp.KeyGen(&bccsp.GMSM4KeyGenOpts{})
+	require.NoError(t, err)
+
+	skPath := filepath.Join(dir, hex.EncodeToString(sk.SKI())+"_sk")
+	k4Path := filepath.Join(dir, hex.EncodeToString(k4.SKI())+"_key")
+	assert.Equal(t, "ENCRYPTED PRIVATE KEY", pemType(t, skPath))
+	assert.Equal(t, "ENCRYPTED SM4 KEY", pemType(t, k4Path))
+
+	// Reopening with the password gives back the same keys
+	ks, err = NewFileBasedKeyStore(pwd, dir, true)
+	require.NoError(t, err)
+	loaded, err := ks.GetKey(sk.SKI())
+	require.NoError(t, err)
+	assert.Equal(t, sk.SKI(), loaded.SKI())
+	loaded, err = ks.GetKey(k4.SKI())
+	require.NoError(t, err)
+	ct, err := csp.Encrypt(k4, []byte("data"), &bccsp.SM4CBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	pt, err := csp.Decrypt(loaded, ct, &bccsp.SM4CBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), pt)
+
+	// A wrong or missing password is rejected
+	ks, err = NewFileBasedKeyStore([]byte("wrong"), dir, true)
+	require.NoError(t, err)
+	_, err = ks.GetKey(sk.SKI())
+	assert.Error(t, err)
+	_, err = ks.GetKey(k4.SKI())
+	assert.Error(t, err)
+	ks, err = NewFileBasedKeyStore(nil, dir, true)
+	require.NoError(t, err)
+	_, err = ks.GetKey(sk.SKI())
+	assert.Error(t, err)
+}
+
+func TestReencryptFileKeyStore(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "gmks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ks, err := NewFileBasedKeyStore(nil, dir, false)
+	require.NoError(t, err)
+	csp, err := New(256, "GMSM3", ks)
+	require.NoError(t, err)
+
+	sk, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{})
+	require.NoError(t, err)
+	k4, err := csp.KeyGen(&bccsp.GMSM4KeyGenOpts{})
+	require.NoError(t, err)
+	pk, err := sk.PublicKey()
+	require.NoError(t, err)
+	require.NoError(t, ks.StoreKey(pk))
+
+	skPath := filepath.Join(dir, hex.EncodeToString(sk.SKI())+"_sk")
+	pkPath := filepath.Join(dir, hex.EncodeToString(sk.SKI())+"_pk")
+	k4Path := filepath.Join(dir, hex.EncodeToString(k4.SKI())+"_key")
+	assert.Equal(t, "PRIVATE KEY", pemType(t, skPath))
+	assert.Equal(t, "SM4 KEY", pemType(t, k4Path))
+
+	pwd := []byte("s3cr3t")
+	require.NoError(t, ReencryptFileBasedKeyStore(dir, nil, pwd))
+	assert.Equal(t, "ENCRYPTED PRIVATE KEY", pemType(t, skPath))
+	assert.Equal(t, "ENCRYPTED SM4 KEY", pemType(t, k4Path))
+	assert.Equal(t, "PUBLIC KEY", pemType(t, pkPath))
+
+	// Running the migration again is a no-op
+	before, err := ioutil.ReadFile(skPath)
+	require.NoError(t, err)
+	require.NoError(t, ReencryptFileBasedKeyStore(dir, nil, pwd))
+	after, err := ioutil.ReadFile(skPath)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	ks, err = NewFileBasedKeyStore(pwd, dir, true)
+	require.NoError(t, err)
+	loaded, err := ks.GetKey(sk.SKI())
+	require.NoError(t, err)
+	assert.Equal(t, sk.SKI(), loaded.SKI())
+
+	// Password rotation
+	newPwd := []byte("n3w")
+	assert.Error(t, ReencryptFileBasedKeyStore(dir, []byte("wrong"), newPwd))
+	require.NoError(t, ReencryptFileBasedKeyStore(dir, pwd, newPwd))
+	ks, err = NewFileBasedKeyStore(newPwd, dir, true)
+	require.NoError(t, err)
+	_, err = ks.GetKey(k4.SKI())
+	require.NoError(t, err)
+
+	// Decryption back to a plain keystore
+	require.NoError(t, ReencryptFileBasedKeyStore(dir, newPwd, nil))
+	assert.Equal(t, "PRIVATE KEY", pemType(t, skPath))
+	assert.Equal(t, "SM4 KEY", pemType(t, k4Path))
+}
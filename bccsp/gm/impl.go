@@ -67,11 +67,13 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	// Set the encryptors
 	encryptors := make(map[reflect.Type]Encryptor)
 	encryptors[reflect.TypeOf(&gmsm4PrivateKey{})] = &gmsm4Encryptor{} //sm4 加密选项
+	encryptors[reflect.TypeOf(&gmsm2PublicKey{})] = &gmsm2PublicKeyEncryptor{}   //sm2 公钥加密
+	encryptors[reflect.TypeOf(&gmsm2PrivateKey{})] = &gmsm2PrivateKeyEncryptor{} //sm2 使用私钥对应的公钥加密
 
 	// Set the decryptors
 	decryptors := make(map[reflect.Type]Decryptor)
 	decryptors[reflect.TypeOf(&gmsm4PrivateKey{})] = &gmsm4Decryptor{} //sm4 解密选项
-	decryptors[reflect.TypeOf(&gmsm2PrivateKey{})] = &gmsm2Decryptor{} //sm4 解密选项
+	decryptors[reflect.TypeOf(&gmsm2PrivateKey{})] = &gmsm2Decryptor{} //sm2 解密选项
 
 	// Set the signers
 	signers := make(map[reflect.Type]Signer)
@@ -106,11 +108,14 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	// Set the key generators
 	keyGenerators := make(map[reflect.Type]KeyGenerator)
 	keyGenerators[reflect.TypeOf(&bccsp.GMSM2KeyGenOpts{})] = &gmsm2KeyGenerator{}
-	keyGenerators[reflect.TypeOf(&bccsp.GMSM4KeyGenOpts{})] = &gmsm4KeyGenerator{length: 32}
+	keyGenerators[reflect.TypeOf(&bccsp.GMSM4KeyGenOpts{})] = &gmsm4KeyGenerator{length: 16}
 	impl.keyGenerators = keyGenerators
 
 	// Set the key derivers
 	keyDerivers := make(map[reflect.Type]KeyDeriver)
+	keyDerivers[reflect.TypeOf(&gmsm2PrivateKey{})] = &gmsm2PrivateKeyKeyDeriver{}
+	keyDerivers[reflect.TypeOf(&gmsm2PublicKey{})] = &gmsm2PublicKeyKeyDeriver{}
+	keyDerivers[reflect.TypeOf(&gmsm4PrivateKey{})] = &gmsm4PrivateKeyKeyDeriver{}
 	impl.keyDerivers = keyDerivers
 
 	// Set the key importers
@@ -16,16 +16,143 @@ limitations under the License.
 package gm
 
 import (
+	"crypto/hmac"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"math/big"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/littlegirlpppp/gmsm/sm3"
+	"github.com/littlegirlpppp/gmsm/sm4"
 )
-//todo：国密：增加gm
-//定义国密 Key的驱动 ，实现 KeyDeriver 接口
-type smPublicKeyKeyDeriver struct{}
 
-func (kd *smPublicKeyKeyDeriver) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, err error) {
+// reRandExpansion maps the expansion value to a scalar in [1, n-1]
+func reRandExpansion(expansion []byte, n *big.Int) *big.Int {
+	k := new(big.Int).SetBytes(expansion)
+	nMinusOne := new(big.Int).Sub(n, one)
+	k.Mod(k, nMinusOne)
+	return k.Add(k, one)
+}
+
+type gmsm2PublicKeyKeyDeriver struct{}
+
+func (kd *gmsm2PublicKeyKeyDeriver) KeyDeriv(key bccsp.Key, opts bccsp.KeyDerivOpts) (bccsp.Key, error) {
+	// Validate opts
+	if opts == nil {
+		return nil, errors.New("Invalid opts parameter. It must not be nil.")
+	}
+
+	sm2K := key.(*gmsm2PublicKey)
+
+	// Re-randomized a GMSM2 public key
+	reRandOpts, ok := opts.(*bccsp.GMSM2ReRandKeyOpts)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported 'KeyDerivOpts' provided [%v]", opts)
+	}
+
+	curve := sm2K.pubKey.Curve
+	k := reRandExpansion(reRandOpts.ExpansionValue(), curve.Params().N)
+
+	// Compute temporary public key
+	tempX, tempY := curve.ScalarBaseMult(k.Bytes())
+	tempPK := &sm2.PublicKey{Curve: curve}
+	tempPK.X, tempPK.Y = curve.Add(sm2K.pubKey.X, sm2K.pubKey.Y, tempX, tempY)
+
+	// Verify temporary public key is a valid point on the reference curve
+	if !curve.IsOnCurve(tempPK.X, tempPK.Y) {
+		return nil, errors.New("Failed temporary public key IsOnCurve check.")
+	}
+
+	return &gmsm2PublicKey{tempPK}, nil
+}
+
+type gmsm2PrivateKeyKeyDeriver struct{}
+
+func (kd *gmsm2PrivateKeyKeyDeriver) KeyDeriv(key bccsp.Key, opts bccsp.KeyDerivOpts) (bccsp.Key, error) {
+	// Validate opts
+	if opts == nil {
+		return nil, errors.New("Invalid opts parameter. It must not be nil.")
+	}
+
+	sm2K := key.(*gmsm2PrivateKey)
+
+	// Re-randomized a GMSM2 private key
+	reRandOpts, ok := opts.(*bccsp.GMSM2ReRandKeyOpts)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported 'KeyDerivOpts' provided [%v]", opts)
+	}
 
-	return nil, errors.New("Not implemented")
+	curve := sm2K.privKey.Curve
+	n := curve.Params().N
+	k := reRandExpansion(reRandOpts.ExpansionValue(), n)
+
+	tempSK := &sm2.PrivateKey{D: new(big.Int)}
+	tempSK.Curve = curve
+	tempSK.D.Add(sm2K.privKey.D, k)
+	tempSK.D.Mod(tempSK.D, n)
+	if tempSK.D.Sign() == 0 {
+		return nil, errors.New("Failed re-randomizing key: derived scalar is zero")
+	}
+
+	// Compute temporary public key
+	tempX, tempY := curve.ScalarBaseMult(k.Bytes())
+	tempSK.X, tempSK.Y = curve.Add(sm2K.privKey.X, sm2K.privKey.Y, tempX, tempY)
+
+	// Verify temporary public key is a valid point on the reference curve
+	if !curve.IsOnCurve(tempSK.X, tempSK.Y) {
+		return nil, errors.New("Failed temporary public key IsOnCurve check.")
+	}
+
+	return &gmsm2PrivateKey{tempSK}, nil
+}
+
+type gmsm4PrivateKeyKeyDeriver struct{}
+
+func (kd *gmsm4PrivateKeyKeyDeriver) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (bccsp.Key, error) {
+	// Validate opts
+	if opts == nil {
+		return nil, errors.New("Invalid opts parameter. It must not be nil.")
+	}
+
+	sm4K := k.(*gmsm4PrivateKey)
+
+	switch o := opts.(type) {
+	case *bccsp.GMSM4HMACDeriveKeyOpts:
+		mac := hmac.New(sm3.New, sm4K.privKey)
+		mac.Write(o.Argument())
+		return &gmsm4PrivateKey{mac.Sum(nil)[:sm4.BlockSize], false}, nil
+
+	case *bccsp.HMACDeriveKeyOpts:
+		// the HMAC-SM3 output is longer than an SM4 key
+		mac := hmac.New(sm3.New, sm4K.privKey)
+		mac.Write(o.Argument())
+		return &gmsm4PrivateKey{mac.Sum(nil)[:sm4.BlockSize], true}, nil
+
+	case *bccsp.GMSM3KDFDeriveKeyOpts:
+		return &gmsm4PrivateKey{SM3KDF(sm4.BlockSize, sm4K.privKey, o.Argument()), false}, nil
+
+	default:
+		return nil, fmt.Errorf("Unsupported 'KeyDerivOpts' provided [%v]", opts)
+	}
+}
 
+// SM3KDF is the key derivation function of GM/T 0003.3. It returns length
+// bytes derived from the concatenation of the z values.
+func SM3KDF(length int, z ...[]byte) []byte {
+	var ct [4]byte
+	h := sm3.New()
+	out := make([]byte, 0, length+h.Size())
+	for i := uint32(1); len(out) < length; i++ {
+		h.Reset()
+		for _, zz := range z {
+			h.Write(zz)
+		}
+		binary.BigEndian.PutUint32(ct[:], i)
+		h.Write(ct[:])
+		// sm3.Sum hashes its argument rather than appending to it
+		out = append(out, h.Sum(nil)...)
+	}
+	return out[:length]
 }
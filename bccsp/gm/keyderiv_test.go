@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSM3KDFKnownAnswer(t *testing.T) {
+	t.Parallel()
+
+	// The key stream of the GM/T 0003.5-2012 encryption example is
+	// t = KDF(x2 || y2, klen) = C2 xor M
+	d, _ := new(big.Int).SetString("3945208f7b2144b13f36e38ac6d39f95889393692860b51a42fb81ef4df7c5b8", 16)
+	k, _ := new(big.Int).SetString("59276e27d506861a16680f3ad9c02dccef3cc1fa3cdbe4ce6d54b80deac1bc21", 16)
+	msg := []byte("encryption standard")
+	c2 := mustDecodeHex("21886ca989ca9c7d58087307ca93092d651efa")
+
+	curve := sm2.P256Sm2()
+	pbX, pbY := curve.ScalarBaseMult(d.Bytes())
+	x2, y2 := curve.ScalarMult(pbX, pbY, k.Bytes())
+
+	expected := make([]byte, len(msg))
+	for i := range msg {
+		expected[i] = msg[i] ^ c2[i]
+	}
+	assert.Equal(t, expected, SM3KDF(len(msg), x2.Bytes(), y2.Bytes()))
+
+	// Longer outputs extend shorter ones
+	long := SM3KDF(100, []byte("z"))
+	assert.Len(t, long, 100)
+	assert.Equal(t, SM3KDF(40, []byte("z")), long[:40])
+	assert.Equal(t, SM3KDF(40, []byte("z")), SM3KDF(40, []byte{}, []byte("z")))
+}
+
+func TestSM2KeyReRandomization(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	sk, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	pk, err := sk.PublicKey()
+	require.NoError(t, err)
+
+	opts := &bccsp.GMSM2ReRandKeyOpts{Temporary: true, Expansion: []byte{1, 2, 3}}
+	assert.Equal(t, bccsp.GMSM2ReRand, opts.Algorithm())
+
+	reRandSK, err := csp.KeyDeriv(sk, opts)
+	require.NoError(t, err)
+	reRandPK, err := csp.KeyDeriv(pk, opts)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, sk.SKI(), reRandSK.SKI())
+	// Deriving the public key yields the public part of the derived private key
+	derivedPK, err := reRandSK.PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, derivedPK.SKI(), reRandPK.SKI())
+
+	msg := []byte("per-transaction anonymous key")
+	sig, err := csp.Sign(reRandSK, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	valid, err := csp.Verify(reRandPK, sig, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = csp.KeyDeriv(sk, &bccsp.ECDSAReRandKeyOpts{Temporary: true})
+	assert.Error(t, err)
+	_, err = csp.KeyDeriv(pk, &bccsp.HMACDeriveKeyOpts{Temporary: true})
+	assert.Error(t, err)
+}
+
+func TestSM4KeyDerivation(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	k, err := csp.KeyImport(sm4KATKey, &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	require.NoError(t, err)
+
+	// HMAC-SM3 of "session" under the SM4 known-answer key
+	expectedKey := mustDecodeHex("cedcf82cf0bf4f31b910310920944d84")
+
+	// HMAC-SM3 truncated to an SM4 key
+	dk, err := csp.KeyDeriv(k, &bccsp.GMSM4HMACDeriveKeyOpts{Temporary: true, Arg: []byte("session")})
+	require.NoError(t, err)
+	assert.True(t, dk.Symmetric())
+	_, err = dk.Bytes()
+	assert.Error(t, err)
+	ct, err := csp.Encrypt(dk, []byte("data"), &bccsp.SM4GCMModeOpts{})
+	require.NoError(t, err)
+	plain, err := SM4GCMDecrypt(expectedKey, ct, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), plain)
+
+	// Exportable HMAC-SM3 output, also truncated to an SM4 key
+	dk, err = csp.KeyDeriv(k, &bccsp.HMACDeriveKeyOpts{Temporary: true, Arg: []byte("session")})
+	require.NoError(t, err)
+	raw, err := dk.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, expectedKey, raw)
+	ct, err = csp.Encrypt(dk, []byte("data"), &bccsp.SM4CBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	plain, err = SM4CBCPKCS7Decrypt(expectedKey, ct)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), plain)
+
+	// GM/T 0003.3 KDF
+	dk, err = csp.KeyDeriv(k, &bccsp.GMSM3KDFDeriveKeyOpts{Temporary: true, Arg: []byte("session")})
+	require.NoError(t, err)
+	ct, err = csp.Encrypt(dk, []byte("data"), &bccsp.SM4CBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	plain, err = SM4CBCPKCS7Decrypt(SM3KDF(16, sm4KATKey, []byte("session")), ct)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), plain)
+
+	_, err = csp.KeyDeriv(k, &bccsp.GMSM2ReRandKeyOpts{Temporary: true})
+	assert.Error(t, err)
+
+	_, err = csp.KeyDeriv(k, &bccsp.GMSM3KDFDeriveKeyOpts{Arg: []byte("session")})
+	assert.Error(t, err, "the dummy keystore cannot store the derived key")
+}
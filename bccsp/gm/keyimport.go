@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/littlegirlpppp/gmsm/sm4"
 	"reflect"
 
 	"github.com/hyperledger/fabric/bccsp"
@@ -41,6 +42,10 @@ func (*gmsm4ImportKeyOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyI
 		return nil, errors.New("Invalid raw material. It must not be nil.")
 	}
 
+	if len(sm4Raw) != sm4.BlockSize {
+		return nil, fmt.Errorf("Invalid SM4 key length [%d]. Must be 16 bytes", len(sm4Raw))
+	}
+
 	return &gmsm4PrivateKey{utils.Clone(sm4Raw), false}, nil
 }
 
@@ -0,0 +1,298 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/littlegirlpppp/gmsm/sm3"
+	"github.com/littlegirlpppp/gmsm/sm4"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Keys are encrypted at rest as PKCS#8 EncryptedPrivateKeyInfo (RFC 5958)
+// using PBES2 (RFC 8018) with PBKDF2 over HMAC-SM3 and SM4 in CBC mode.
+// SM4 keys use the same envelope around the raw key bytes.
+var (
+	oidPBES2       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSM3 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 401, 2}
+	oidSM4CBC      = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 104, 2}
+
+	oidNamedCurveSM2 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+const (
+	pbkdf2Iterations = 10000
+	pbkdf2SaltSize   = 16
+
+	pemTypePrivateKey          = "PRIVATE KEY"
+	pemTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+	pemTypeSM4Key              = "SM4 KEY"
+	pemTypeEncryptedSM4Key     = "ENCRYPTED SM4 KEY"
+	// pemTypeLegacySM4Key is written by sm4.WriteKeyToPem when a password
+	// is given, using the OpenSSL PEM encryption with AES-256
+	pemTypeLegacySM4Key = "SM4 ENCRYPTED KEY"
+)
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type privateKeyInfo struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// encryptPKCS8 wraps plain into an EncryptedPrivateKeyInfo protected by pwd
+func encryptPKCS8(plain, pwd []byte) ([]byte, error) {
+	salt := make([]byte, pbkdf2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	iv := make([]byte, sm4.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	key := pbkdf2.Key(pwd, salt, pbkdf2Iterations, sm4.BlockSize, sm3.New)
+	ciphertext, err := SM4CBCPKCS7EncryptWithIV(iv, key, plain)
+	if err != nil {
+		return nil, err
+	}
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iterations,
+		KeyLength:      sm4.BlockSize,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSM3, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidSM4CBC, Parameters: asn1.RawValue{FullBytes: ivParams}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		// The IV travels in the algorithm parameters
+		EncryptedData: ciphertext[sm4.BlockSize:],
+	})
+}
+
+// isSM4PBES2 tells whether info was produced by encryptPKCS8
+func isSM4PBES2(info *encryptedPrivateKeyInfo) (*pbes2Params, bool) {
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, false
+	}
+	params := &pbes2Params{}
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, params); err != nil {
+		return nil, false
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) || !params.EncryptionScheme.Algorithm.Equal(oidSM4CBC) {
+		return nil, false
+	}
+	return params, true
+}
+
+// decryptPKCS8 reverses encryptPKCS8
+func decryptPKCS8(der, pwd []byte) ([]byte, error) {
+	info := &encryptedPrivateKeyInfo{}
+	if _, err := asn1.Unmarshal(der, info); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling EncryptedPrivateKeyInfo [%s]", err)
+	}
+	params, ok := isSM4PBES2(info)
+	if !ok {
+		return nil, errors.New("Unsupported encryption scheme. Expected PBES2 with SM4-CBC")
+	}
+
+	kdfParams := &pbkdf2Params{}
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, kdfParams); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling PBKDF2 parameters [%s]", err)
+	}
+	if !kdfParams.PRF.Algorithm.Equal(oidHMACWithSM3) {
+		return nil, fmt.Errorf("Unsupported PBKDF2 PRF [%s]", kdfParams.PRF.Algorithm)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling SM4-CBC parameters [%s]", err)
+	}
+	if len(iv) != sm4.BlockSize {
+		return nil, fmt.Errorf("Invalid IV. It must have length [%d]", sm4.BlockSize)
+	}
+
+	key := pbkdf2.Key(pwd, kdfParams.Salt, kdfParams.IterationCount, sm4.BlockSize, sm3.New)
+	plain, err := SM4CBCPKCS7Decrypt(key, append(append([]byte{}, iv...), info.EncryptedData...))
+	if err != nil {
+		return nil, errors.New("Failed decrypting key. The password may be wrong")
+	}
+	return plain, nil
+}
+
+// privateKeyToPEM encodes k as PKCS#8, encrypted when pwd is not empty.
+// k is either an SM2 or an ECDSA private key.
+func privateKeyToPEM(k interface{}, pwd []byte) ([]byte, error) {
+	var der []byte
+	var err error
+	switch k := k.(type) {
+	case *sm2.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid sm2 private key. It must be different from nil.")
+		}
+		der, err = gmx509.MarshalSm2UnecryptedPrivateKey(k)
+	case *ecdsa.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid ecdsa private key. It must be different from nil.")
+		}
+		der, err = x509.MarshalPKCS8PrivateKey(k)
+	default:
+		return nil, errors.New("Invalid key type. It must be *sm2.PrivateKey or *ecdsa.PrivateKey")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(pwd) == 0 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+	}
+
+	der, err = encryptPKCS8(der, pwd)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedPrivateKey, Bytes: der}), nil
+}
+
+// pemToPrivateKey decodes a private key written by privateKeyToPEM.
+// Encrypted keys written by gmx509.WritePrivateKeyToPem are accepted as well.
+func pemToPrivateKey(raw, pwd []byte) (interface{}, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("Failed decoding PEM. Block must be different from nil.")
+	}
+
+	switch block.Type {
+	case pemTypePrivateKey:
+		return parsePKCS8PrivateKey(block.Bytes)
+	case pemTypeEncryptedPrivateKey:
+		if len(pwd) == 0 {
+			return nil, errors.New("Encrypted Key. Need a password")
+		}
+		info := &encryptedPrivateKeyInfo{}
+		if _, err := asn1.Unmarshal(block.Bytes, info); err != nil {
+			return nil, fmt.Errorf("Failed unmarshalling EncryptedPrivateKeyInfo [%s]", err)
+		}
+		if _, ok := isSM4PBES2(info); !ok {
+			return gmx509.ParsePKCS8EcryptedPrivateKey(block.Bytes, pwd)
+		}
+		der, err := decryptPKCS8(block.Bytes, pwd)
+		if err != nil {
+			return nil, err
+		}
+		return parsePKCS8PrivateKey(der)
+	default:
+		return nil, fmt.Errorf("Unexpected PEM type [%s]", block.Type)
+	}
+}
+
+// parsePKCS8PrivateKey parses an unencrypted PKCS#8 EC private key.
+// gmx509 reads every EC key as an SM2 key, so keys whose named curve is
+// not SM2 are handed to the standard library instead.
+func parsePKCS8PrivateKey(der []byte) (interface{}, error) {
+	info := &privateKeyInfo{}
+	if _, err := asn1.Unmarshal(der, info); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling PrivateKeyInfo [%s]", err)
+	}
+
+	curve := asn1.ObjectIdentifier{}
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve); err == nil && !curve.Equal(oidNamedCurveSM2) {
+		return x509.ParsePKCS8PrivateKey(der)
+	}
+	return gmx509.ParsePKCS8UnecryptedPrivateKey(der)
+}
+
+// privateKeyToBCCSPKey wraps a key returned by pemToPrivateKey
+func privateKeyToBCCSPKey(key interface{}) (bccsp.Key, error) {
+	switch key := key.(type) {
+	case *sm2.PrivateKey:
+		return &gmsm2PrivateKey{key}, nil
+	case *ecdsa.PrivateKey:
+		return &ecdsaPrivateKey{key}, nil
+	default:
+		return nil, errors.New("Secret key type not recognized")
+	}
+}
+
+// sm4KeyToPEM encodes an SM4 key, encrypted when pwd is not empty
+func sm4KeyToPEM(key, pwd []byte) ([]byte, error) {
+	if len(pwd) == 0 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemTypeSM4Key, Bytes: key}), nil
+	}
+
+	der, err := encryptPKCS8(key, pwd)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedSM4Key, Bytes: der}), nil
+}
+
+// pemToSM4Key decodes an SM4 key written by sm4KeyToPEM or sm4.WriteKeyToPem
+func pemToSM4Key(raw, pwd []byte) ([]byte, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("Failed decoding PEM. Block must be different from nil.")
+	}
+
+	switch block.Type {
+	case pemTypeSM4Key:
+		return block.Bytes, nil
+	case pemTypeEncryptedSM4Key:
+		if len(pwd) == 0 {
+			return nil, errors.New("Encrypted Key. Need a password")
+		}
+		return decryptPKCS8(block.Bytes, pwd)
+	case pemTypeLegacySM4Key:
+		if len(pwd) == 0 {
+			return nil, errors.New("Encrypted Key. Need a password")
+		}
+		return sm4.ReadKeyFromPem(raw, pwd)
+	default:
+		return nil, fmt.Errorf("Unexpected PEM type [%s]", block.Type)
+	}
+}
@@ -22,16 +22,20 @@ import (
 	"encoding/asn1"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/littlegirlpppp/gmsm/sm3"
 )
 //todo：国密：增加gm
 type SM2Signature struct {
 	R, S *big.Int
 }
 
+var one = big.NewInt(1)
+
 var (
 	// curveHalfOrders contains the precomputed curve group orders halved.
 	// It is used to ensure that signature' S value is lower or equal to the
@@ -89,62 +93,86 @@ func verifyGMSM2(k *sm2.PublicKey, signature, digest []byte, opts bccsp.SignerOp
 type gmsm2Signer struct{}
 
 func (s *gmsm2Signer) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	if uid, ok := sm2UserID(opts); ok {
+		return signGMSM2WithUserID(k.(*gmsm2PrivateKey).privKey, uid, digest, nil)
+	}
 	return signGMSM2(k.(*gmsm2PrivateKey).privKey, digest, opts)
 }
 
 type ecdsaPrivateKeySigner struct{}
 
 func (s *ecdsaPrivateKeySigner) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
-	puk := k.(*ecdsaPrivateKey).privKey.PublicKey
-	sm2pk := sm2.PublicKey{
-		Curve: puk.Curve,
-		X:     puk.X,
-		Y:     puk.Y,
-	}
-
 	privKey := k.(*ecdsaPrivateKey).privKey
-	sm2privKey := sm2.PrivateKey{
-		D:         privKey.D,
-		PublicKey: sm2pk,
+	if privKey.Curve != sm2.P256Sm2() {
+		return signECDSA(privKey, digest, opts)
 	}
 
-	return signGMSM2(&sm2privKey, digest, opts)
+	sm2privKey := toSM2PrivateKey(privKey)
+	if uid, ok := sm2UserID(opts); ok {
+		return signGMSM2WithUserID(sm2privKey, uid, digest, nil)
+	}
+	return signGMSM2(sm2privKey, digest, opts)
 }
 
 type gmsm2PrivateKeyVerifier struct{}
 
 func (v *gmsm2PrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	if uid, ok := sm2UserID(opts); ok {
+		return verifyGMSM2WithUserID(&(k.(*gmsm2PrivateKey).privKey.PublicKey), uid, signature, digest)
+	}
 	return verifyGMSM2(&(k.(*gmsm2PrivateKey).privKey.PublicKey), signature, digest, opts)
 }
 
 type gmsm2PublicKeyKeyVerifier struct{}
 
 func (v *gmsm2PublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	if uid, ok := sm2UserID(opts); ok {
+		return verifyGMSM2WithUserID(k.(*gmsm2PublicKey).pubKey, uid, signature, digest)
+	}
 	return verifyGMSM2(k.(*gmsm2PublicKey).pubKey, signature, digest, opts)
 }
 
 type ecdsaPrivateKeyVerifier struct{}
 
 func (v *ecdsaPrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
-	puk := k.(*ecdsaPrivateKey).privKey.PublicKey
-	sm2pk := sm2.PublicKey{
-		Curve: puk.Curve,
-		X:     puk.X,
-		Y:     puk.Y,
-	}
-	return verifyGMSM2(&sm2pk, signature, digest, opts)
+	return verifyECDSAOrGMSM2(&k.(*ecdsaPrivateKey).privKey.PublicKey, signature, digest, opts)
 }
 
 type ecdsaPublicKeyKeyVerifier struct{}
 
 func (v *ecdsaPublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
-	puk := k.(*ecdsaPublicKey).pubKey
-	sm2pk := sm2.PublicKey{
+	return verifyECDSAOrGMSM2(k.(*ecdsaPublicKey).pubKey, signature, digest, opts)
+}
+
+// verifyECDSAOrGMSM2 verifies signature with SM2 when puk lies on the SM2
+// curve, as X.509 parsing yields ecdsa keys for SM2 certificates, and with
+// ECDSA otherwise
+func verifyECDSAOrGMSM2(puk *ecdsa.PublicKey, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	if puk.Curve != sm2.P256Sm2() {
+		return verifyECDSA(puk, signature, digest, opts)
+	}
+
+	sm2pk := &sm2.PublicKey{
 		Curve: puk.Curve,
 		X:     puk.X,
 		Y:     puk.Y,
 	}
-	return verifyGMSM2(&sm2pk, signature, digest, opts)
+	if uid, ok := sm2UserID(opts); ok {
+		return verifyGMSM2WithUserID(sm2pk, uid, signature, digest)
+	}
+	return verifyGMSM2(sm2pk, signature, digest, opts)
+}
+
+// toSM2PrivateKey converts an ecdsa key on the SM2 curve to its sm2 form
+func toSM2PrivateKey(privKey *ecdsa.PrivateKey) *sm2.PrivateKey {
+	return &sm2.PrivateKey{
+		PublicKey: sm2.PublicKey{
+			Curve: privKey.Curve,
+			X:     privKey.X,
+			Y:     privKey.Y,
+		},
+		D: privKey.D,
+	}
 }
 
 func SignatureToLowS(k *ecdsa.PublicKey, signature []byte) ([]byte, error) {
@@ -191,3 +219,87 @@ func IsLowS(k *ecdsa.PublicKey, s *big.Int) (bool, error) {
 	return s.Cmp(halfOrder) != 1, nil
 
 }
+
+// sm2UserID returns the distinguishing identifier carried by opts, if any
+func sm2UserID(opts bccsp.SignerOpts) ([]byte, bool) {
+	o, ok := opts.(*bccsp.SM2SignerOpts)
+	if !ok || o == nil {
+		return nil, false
+	}
+	if len(o.UID) == 0 {
+		return bccsp.DefaultSM2UserID, true
+	}
+	return o.UID, true
+}
+
+// sm2MessageDigest computes e = SM3(Z_A || msg)
+func sm2MessageDigest(pub *sm2.PublicKey, uid, msg []byte) (*big.Int, error) {
+	za, err := sm2.ZA(pub, uid)
+	if err != nil {
+		return nil, err
+	}
+	h := sm3.New()
+	h.Write(za)
+	h.Write(msg)
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// signGMSM2WithUserID signs msg as specified in GM/T 0003.2, computing Z_A from uid
+func signGMSM2WithUserID(k *sm2.PrivateKey, uid, msg []byte, prng io.Reader) ([]byte, error) {
+	e, err := sm2MessageDigest(&k.PublicKey, uid, msg)
+	if err != nil {
+		return nil, err
+	}
+	if prng == nil {
+		prng = rand.Reader
+	}
+
+	n := k.Curve.Params().N
+	dInv := new(big.Int).Add(k.D, one)
+	dInv.ModInverse(dInv, n)
+
+	for {
+		rnd, err := randScalar(n, prng)
+		if err != nil {
+			return nil, err
+		}
+
+		x1, _ := k.Curve.ScalarBaseMult(rnd.Bytes())
+		r := new(big.Int).Add(e, x1)
+		r.Mod(r, n)
+		if r.Sign() == 0 || new(big.Int).Add(r, rnd).Cmp(n) == 0 {
+			continue
+		}
+
+		s := new(big.Int).Mul(r, k.D)
+		s.Sub(rnd, s)
+		s.Mul(s, dInv)
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		return MarshalSM2Signature(r, s)
+	}
+}
+
+// verifyGMSM2WithUserID verifies a signature produced by signGMSM2WithUserID
+func verifyGMSM2WithUserID(k *sm2.PublicKey, uid, signature, msg []byte) (bool, error) {
+	r, s, err := UnmarshalSM2Signature(signature)
+	if err != nil {
+		return false, err
+	}
+	return sm2.Sm2Verify(k, msg, uid, r, s), nil
+}
+
+// randScalar samples a scalar uniformly in [1, n-1]
+func randScalar(n *big.Int, prng io.Reader) (*big.Int, error) {
+	b := make([]byte, n.BitLen()/8+8)
+	if _, err := io.ReadFull(prng, b); err != nil {
+		return nil, err
+	}
+	k := new(big.Int).SetBytes(b)
+	nMinusOne := new(big.Int).Sub(n, one)
+	k.Mod(k, nMinusOne)
+	return k.Add(k, one), nil
+}
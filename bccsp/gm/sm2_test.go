@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSM2SignWithUserIDKnownAnswer(t *testing.T) {
+	t.Parallel()
+
+	// Example from GB/T 32918.5-2017 over the recommended curve
+	d, _ := new(big.Int).SetString("3945208f7b2144b13f36e38ac6d39f95889393692860b51a42fb81ef4df7c5b8", 16)
+	k, _ := new(big.Int).SetString("59276e27d506861a16680f3ad9c02dccef3cc1fa3cdbe4ce6d54b80deac1bc21", 16)
+	expectedR, _ := new(big.Int).SetString("f5a03b0648d2c4630eeac513e1bb81a15944da3827d5b74143ac7eaceee720b3", 16)
+	expectedS, _ := new(big.Int).SetString("b1b6aa29df212fd8763182bc0d421ca1bb9038fd1f7f42d4840b69c485bbc1aa", 16)
+	uid := bccsp.DefaultSM2UserID
+	msg := []byte("message digest")
+
+	curve := sm2.P256Sm2()
+	priv := &sm2.PrivateKey{D: d}
+	priv.Curve = curve
+	priv.X, priv.Y = curve.ScalarBaseMult(d.Bytes())
+
+	sig, err := signGMSM2WithUserID(priv, uid, msg, fixedScalarReader(k))
+	require.NoError(t, err)
+
+	r, s, err := UnmarshalSM2Signature(sig)
+	require.NoError(t, err)
+	assert.Equal(t, expectedR, r)
+	assert.Equal(t, expectedS, s)
+
+	valid, err := verifyGMSM2WithUserID(&priv.PublicKey, uid, sig, msg)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifyGMSM2WithUserID(&priv.PublicKey, []byte("ALICE123@YAHOO.COM"), sig, msg)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestSM2SignVerifyWithSignerOpts(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	sk, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	pk, err := sk.PublicKey()
+	require.NoError(t, err)
+
+	msg := []byte("a message which is not hashed by the caller")
+
+	sig, err := csp.Sign(sk, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+
+	valid, err := csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{UID: bccsp.DefaultSM2UserID})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(sk, sig, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// The default user ID is the one the legacy code path uses implicitly
+	valid, err = csp.Verify(pk, sig, msg, nil)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{UID: []byte("another id")})
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	sig, err = csp.Sign(sk, msg, &bccsp.SM2SignerOpts{UID: []byte("another id")})
+	require.NoError(t, err)
+	valid, err = csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{UID: []byte("another id")})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = csp.Verify(pk, []byte("garbage"), msg, &bccsp.SM2SignerOpts{})
+	assert.Error(t, err)
+}
+
+func TestSM2SignVerifyWithSignerOptsOnECDSAKeys(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	// X.509 parsing yields SM2 keys as ecdsa keys on the SM2 curve
+	priv, err := sm2.GenerateKey(nil)
+	require.NoError(t, err)
+	sk := &ecdsaPrivateKey{&ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: priv.Curve, X: priv.X, Y: priv.Y},
+		D:         priv.D,
+	}}
+	pk, err := sk.PublicKey()
+	require.NoError(t, err)
+
+	msg := []byte("a message which is not hashed by the caller")
+
+	sig, err := csp.Sign(sk, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	r, s, err := UnmarshalSM2Signature(sig)
+	require.NoError(t, err)
+	assert.True(t, sm2.Sm2Verify(&priv.PublicKey, msg, bccsp.DefaultSM2UserID, r, s))
+
+	valid, err := csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(sk, sig, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(pk, sig, msg, &bccsp.SM2SignerOpts{UID: []byte("another id")})
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
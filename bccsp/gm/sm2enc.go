@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/littlegirlpppp/gmsm/sm2"
+)
+
+const (
+	// sm2C1Len is the length of an uncompressed curve point (04 || x || y)
+	sm2C1Len = 65
+	// sm2C3Len is the length of the SM3 digest
+	sm2C3Len = 32
+)
+
+// SM2Encrypt encrypts plaintext for pub as specified in GM/T 0003.4.
+// If opts.PRNG is nil crypto/rand is used to sample the ephemeral key.
+func SM2Encrypt(pub *sm2.PublicKey, plaintext []byte, opts *bccsp.SM2EncrypterOpts) ([]byte, error) {
+	if pub == nil {
+		return nil, errors.New("Invalid public key. It must be different from nil.")
+	}
+	if len(plaintext) == 0 {
+		return nil, errors.New("Invalid plaintext. It must not be empty.")
+	}
+	if opts == nil {
+		opts = &bccsp.SM2EncrypterOpts{}
+	}
+
+	// The underlying implementation produces C1 || C3 || C2
+	ct, err := sm2.Encrypt(pub, plaintext, opts.PRNG)
+	if err != nil {
+		return nil, fmt.Errorf("Failed encrypting [%s]", err)
+	}
+
+	if opts.ASN1 {
+		return sm2.CipherMarshal(ct)
+	}
+
+	switch opts.Order {
+	case bccsp.SM2CipherC1C3C2:
+		return ct, nil
+	case bccsp.SM2CipherC1C2C3:
+		return sm2C1C3C2ToC1C2C3(ct), nil
+	default:
+		return nil, fmt.Errorf("Invalid ciphertext order [%d]", opts.Order)
+	}
+}
+
+// SM2Decrypt decrypts a ciphertext produced by SM2Encrypt with the same options
+func SM2Decrypt(priv *sm2.PrivateKey, ciphertext []byte, opts *bccsp.SM2EncrypterOpts) ([]byte, error) {
+	if priv == nil {
+		return nil, errors.New("Invalid private key. It must be different from nil.")
+	}
+	if opts == nil {
+		opts = &bccsp.SM2EncrypterOpts{}
+	}
+
+	ct := ciphertext
+	if opts.ASN1 {
+		var err error
+		ct, err = sm2.CipherUnmarshal(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("Failed unmarshalling ciphertext [%s]", err)
+		}
+	}
+
+	if len(ct) <= sm2C1Len+sm2C3Len || ct[0] != 0x04 {
+		return nil, errors.New("Invalid ciphertext. It must start with an uncompressed point and carry a non-empty payload")
+	}
+	if err := checkSM2C1(priv, ct[:sm2C1Len]); err != nil {
+		return nil, err
+	}
+
+	if !opts.ASN1 {
+		switch opts.Order {
+		case bccsp.SM2CipherC1C3C2:
+		case bccsp.SM2CipherC1C2C3:
+			ct = sm2C1C2C3ToC1C3C2(ct)
+		default:
+			return nil, fmt.Errorf("Invalid ciphertext order [%d]", opts.Order)
+		}
+	}
+
+	plaintext, err := sm2.Decrypt(priv, ct)
+	if err != nil {
+		return nil, fmt.Errorf("Failed decrypting [%s]", err)
+	}
+	return plaintext, nil
+}
+
+// checkSM2C1 verifies that the ephemeral point C1 is a valid point of the
+// curve of priv, so that no invalid-curve point reaches the decryption.
+func checkSM2C1(priv *sm2.PrivateKey, c1 []byte) error {
+	x := new(big.Int).SetBytes(c1[1 : 1+(sm2C1Len-1)/2])
+	y := new(big.Int).SetBytes(c1[1+(sm2C1Len-1)/2:])
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return errors.New("Invalid ciphertext. C1 must not be the point at infinity")
+	}
+	// The optimized sm2P256 curve reports every off-curve point as on the
+	// curve, hence the check is done on the generic parameters (a = -3)
+	params := priv.Curve.Params()
+	if x.Cmp(params.P) >= 0 || y.Cmp(params.P) >= 0 || !params.IsOnCurve(x, y) {
+		return errors.New("Invalid ciphertext. C1 is not on the curve")
+	}
+	return nil
+}
+
+func sm2C1C3C2ToC1C2C3(ct []byte) []byte {
+	res := make([]byte, 0, len(ct))
+	res = append(res, ct[:sm2C1Len]...)
+	res = append(res, ct[sm2C1Len+sm2C3Len:]...)
+	return append(res, ct[sm2C1Len:sm2C1Len+sm2C3Len]...)
+}
+
+func sm2C1C2C3ToC1C3C2(ct []byte) []byte {
+	c3 := len(ct) - sm2C3Len
+	res := make([]byte, 0, len(ct))
+	res = append(res, ct[:sm2C1Len]...)
+	res = append(res, ct[c3:]...)
+	return append(res, ct[sm2C1Len:c3]...)
+}
+
+func sm2EncrypterOpts(opts interface{}) (*bccsp.SM2EncrypterOpts, error) {
+	switch o := opts.(type) {
+	case nil:
+		return nil, nil
+	case *bccsp.SM2EncrypterOpts:
+		return o, nil
+	case bccsp.SM2EncrypterOpts:
+		return &o, nil
+	default:
+		return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+	}
+}
+
+type gmsm2PublicKeyEncryptor struct{}
+
+func (*gmsm2PublicKeyEncryptor) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) ([]byte, error) {
+	o, err := sm2EncrypterOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	return SM2Encrypt(k.(*gmsm2PublicKey).pubKey, plaintext, o)
+}
+
+type gmsm2PrivateKeyEncryptor struct{}
+
+func (*gmsm2PrivateKeyEncryptor) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) ([]byte, error) {
+	o, err := sm2EncrypterOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	return SM2Encrypt(&k.(*gmsm2PrivateKey).privKey.PublicKey, plaintext, o)
+}
+
+type gmsm2Decryptor struct{}
+
+func (*gmsm2Decryptor) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) ([]byte, error) {
+	o, err := sm2EncrypterOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	return SM2Decrypt(k.(*gmsm2PrivateKey).privKey, ciphertext, o)
+}
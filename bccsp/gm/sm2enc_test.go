@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedScalarReader returns a reader that makes the SM2 implementation
+// sample exactly k as its ephemeral scalar.
+func fixedScalarReader(k *big.Int) *bytes.Reader {
+	b := make([]byte, 40)
+	km := new(big.Int).Sub(k, big.NewInt(1)).Bytes()
+	copy(b[len(b)-len(km):], km)
+	return bytes.NewReader(b)
+}
+
+func TestSM2EncryptKnownAnswer(t *testing.T) {
+	t.Parallel()
+
+	// Example from GM/T 0003.5-2012 Appendix C
+	d, _ := new(big.Int).SetString("3945208f7b2144b13f36e38ac6d39f95889393692860b51a42fb81ef4df7c5b8", 16)
+	k, _ := new(big.Int).SetString("59276e27d506861a16680f3ad9c02dccef3cc1fa3cdbe4ce6d54b80deac1bc21", 16)
+	curve := sm2.P256Sm2()
+	priv := &sm2.PrivateKey{D: d}
+	priv.Curve = curve
+	priv.X, priv.Y = curve.ScalarBaseMult(d.Bytes())
+
+	c1 := mustDecodeHex("04" +
+		"04ebfc718e8d1798620432268e77feb6415e2ede0e073c0f4f640ecd2e149a73" +
+		"e858f9d81e5430a57b36daab8f950a3c64e6ee6a63094d99283aff767e124df0")
+	c2 := mustDecodeHex("21886ca989ca9c7d58087307ca93092d651efa")
+	c3 := mustDecodeHex("59983c18f809e262923c53aec295d30383b54e39d609d160afcb1908d0bd8766")
+	msg := []byte("encryption standard")
+
+	ct, err := SM2Encrypt(&priv.PublicKey, msg, &bccsp.SM2EncrypterOpts{PRNG: fixedScalarReader(k)})
+	require.NoError(t, err)
+	assert.Equal(t, append(append(append([]byte{}, c1...), c3...), c2...), ct)
+
+	ct, err = SM2Encrypt(&priv.PublicKey, msg, &bccsp.SM2EncrypterOpts{Order: bccsp.SM2CipherC1C2C3, PRNG: fixedScalarReader(k)})
+	require.NoError(t, err)
+	assert.Equal(t, append(append(append([]byte{}, c1...), c2...), c3...), ct)
+
+	pt, err := SM2Decrypt(priv, ct, &bccsp.SM2EncrypterOpts{Order: bccsp.SM2CipherC1C2C3})
+	require.NoError(t, err)
+	assert.Equal(t, msg, pt)
+}
+
+func TestSM2EncryptDecryptWithOpts(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	sk, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	pk, err := sk.PublicKey()
+	require.NoError(t, err)
+
+	msg := []byte("envelope key material for an organization")
+
+	for _, opts := range []interface{}{
+		nil,
+		&bccsp.SM2EncrypterOpts{},
+		bccsp.SM2EncrypterOpts{Order: bccsp.SM2CipherC1C2C3},
+		&bccsp.SM2EncrypterOpts{ASN1: true},
+	} {
+		ct, err := csp.Encrypt(pk, msg, opts)
+		require.NoError(t, err, "opts %v", opts)
+
+		pt, err := csp.Decrypt(sk, ct, opts)
+		require.NoError(t, err, "opts %v", opts)
+		assert.Equal(t, msg, pt)
+	}
+
+	// The private key encrypts to its own public key
+	ct, err := csp.Encrypt(sk, msg, &bccsp.SM2EncrypterOpts{})
+	require.NoError(t, err)
+	pt, err := csp.Decrypt(sk, ct, &bccsp.SM2EncrypterOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, msg, pt)
+
+	// Mismatching order is detected by the C3 check
+	ct, err = csp.Encrypt(pk, msg, &bccsp.SM2EncrypterOpts{Order: bccsp.SM2CipherC1C2C3})
+	require.NoError(t, err)
+	_, err = csp.Decrypt(sk, ct, &bccsp.SM2EncrypterOpts{})
+	assert.Error(t, err)
+
+	_, err = csp.Decrypt(sk, []byte{0x04, 0x01}, nil)
+	assert.EqualError(t, err, "Failed decrypting with opts [<nil>]: Invalid ciphertext. It must start with an uncompressed point and carry a non-empty payload")
+
+	_, err = csp.Decrypt(sk, []byte("not asn1"), &bccsp.SM2EncrypterOpts{ASN1: true})
+	assert.Error(t, err)
+
+	_, err = csp.Encrypt(pk, msg, &bccsp.SM4CBCPKCS7ModeOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Mode not recognized")
+
+	_, err = csp.Encrypt(pk, nil, nil)
+	assert.EqualError(t, err, "Invalid plaintext. It must not be empty.")
+}
+
+func TestSM2DecryptInvalidC1(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	sk, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	pk, err := sk.PublicKey()
+	require.NoError(t, err)
+
+	msg := []byte("envelope key material for an organization")
+
+	for _, opts := range []*bccsp.SM2EncrypterOpts{
+		{},
+		{Order: bccsp.SM2CipherC1C2C3},
+	} {
+		ct, err := csp.Encrypt(pk, msg, opts)
+		require.NoError(t, err)
+
+		// C1 off the curve
+		offCurve := append([]byte{}, ct...)
+		offCurve[sm2C1Len-1] ^= 0x01
+		_, err = csp.Decrypt(sk, offCurve, opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid ciphertext. C1 is not on the curve")
+
+		// C1 coordinates outside of the field
+		outOfField := append([]byte{}, ct...)
+		for i := 1; i < sm2C1Len; i++ {
+			outOfField[i] = 0xff
+		}
+		_, err = csp.Decrypt(sk, outOfField, opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid ciphertext. C1 is not on the curve")
+
+		// C1 at infinity
+		infinity := append([]byte{}, ct...)
+		for i := 1; i < sm2C1Len; i++ {
+			infinity[i] = 0
+		}
+		_, err = csp.Decrypt(sk, infinity, opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid ciphertext. C1 must not be the point at infinity")
+	}
+}
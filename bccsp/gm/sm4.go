@@ -16,14 +16,21 @@ limitations under the License.
 package gm
 
 import (
+	"bytes"
+	"crypto/cipher"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/littlegirlpppp/gmsm/sm4"
 )
-//todo：国密：增加gm
+
+// sm4GCMNonceSize is the standard nonce length used by SM4-GCM
+const sm4GCMNonceSize = 12
+
+// todo：国密：增加gm
 // GetRandomBytes returns len random looking bytes
 func GetRandomBytes(len int) ([]byte, error) {
 	if len < 0 {
@@ -43,50 +50,261 @@ func GetRandomBytes(len int) ([]byte, error) {
 	return buffer, nil
 }
 
-// AESCBCPKCS7Encrypt combines CBC encryption and PKCS7 padding
-func SM4Encrypt(key, src []byte) ([]byte, error) {
-	dst := make([]byte, len(src))
-	cipher, err := sm4.NewCipher(key)
+func pkcs7Padding(src []byte) []byte {
+	padding := sm4.BlockSize - len(src)%sm4.BlockSize
+	padtext := bytes.Repeat([]byte{byte(padding)}, padding)
+	return append(src, padtext...)
+}
+
+func pkcs7UnPadding(src []byte) ([]byte, error) {
+	length := len(src)
+	if length == 0 {
+		return nil, errors.New("Invalid pkcs7 padding (empty input)")
+	}
+	unpadding := int(src[length-1])
+
+	if unpadding > sm4.BlockSize || unpadding == 0 {
+		return nil, errors.New("Invalid pkcs7 padding (unpadding > sm4.BlockSize || unpadding == 0)")
+	}
+
+	pad := src[len(src)-unpadding:]
+	for i := 0; i < unpadding; i++ {
+		if pad[i] != byte(unpadding) {
+			return nil, errors.New("Invalid pkcs7 padding (pad[i] != unpadding)")
+		}
+	}
+
+	return src[:(length - unpadding)], nil
+}
+
+// sampleIV returns the IV to be used: the passed one if set, otherwise one
+// read from prng, or from crypto/rand if prng is nil as well.
+func sampleIV(IV []byte, prng io.Reader, size int) ([]byte, error) {
+	if len(IV) != 0 && prng != nil {
+		return nil, errors.New("Invalid options. Either IV or PRNG should be different from nil, or both nil.")
+	}
+
+	if len(IV) != 0 {
+		if len(IV) != size {
+			return nil, fmt.Errorf("Invalid IV. It must have length [%d]", size)
+		}
+		return IV, nil
+	}
+
+	if prng == nil {
+		prng = rand.Reader
+	}
+	iv := make([]byte, size)
+	if _, err := io.ReadFull(prng, iv); err != nil {
+		return nil, err
+	}
+	return iv, nil
+}
+
+func sm4CBCEncrypt(IV []byte, key, s []byte) ([]byte, error) {
+	if len(s)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid plaintext. It must be a multiple of the block size")
+	}
+
+	block, err := sm4.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
-	cipher.Encrypt(dst, src)
-	return dst, nil
+
+	ciphertext := make([]byte, sm4.BlockSize+len(s))
+	copy(ciphertext[:sm4.BlockSize], IV)
+
+	mode := cipher.NewCBCEncrypter(block, IV)
+	mode.CryptBlocks(ciphertext[sm4.BlockSize:], s)
+
+	return ciphertext, nil
 }
 
-// AESCBCPKCS7Decrypt combines CBC decryption and PKCS7 unpadding
-func SM4Decrypt(key, src []byte) ([]byte, error) {
-	cipher, err := sm4.NewCipher(key)
+func sm4CBCDecrypt(key, src []byte) ([]byte, error) {
+	block, err := sm4.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
-	dst := make([]byte, len(src))
-	cipher.Decrypt(dst, src)
-	return dst, nil
+
+	if len(src) < sm4.BlockSize {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+	iv := src[:sm4.BlockSize]
+	src = src[sm4.BlockSize:]
+
+	if len(src)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+
+	plaintext := make([]byte, len(src))
+	mode := cipher.NewCBCDecrypter(block, iv)
+	mode.CryptBlocks(plaintext, src)
+
+	return plaintext, nil
 }
 
-type gmsm4Encryptor struct{}
+// SM4CBCPKCS7Encrypt combines CBC encryption and PKCS7 padding. The IV is
+// sampled from crypto/rand and prepended to the ciphertext.
+func SM4CBCPKCS7Encrypt(key, src []byte) ([]byte, error) {
+	return SM4CBCPKCS7EncryptWithRand(rand.Reader, key, src)
+}
 
-//实现 Encryptor 接口
-func (*gmsm4Encryptor) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
+// SM4CBCPKCS7EncryptWithRand combines CBC encryption and PKCS7 padding using as prng the passed to the function
+func SM4CBCPKCS7EncryptWithRand(prng io.Reader, key, src []byte) ([]byte, error) {
+	iv, err := sampleIV(nil, prng, sm4.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	return sm4CBCEncrypt(iv, key, pkcs7Padding(src))
+}
 
-	return SM4Encrypt(k.(*gmsm4PrivateKey).privKey, plaintext)
-	//return AESCBCPKCS7Encrypt(k.(*sm4PrivateKey).privKey, plaintext)
+// SM4CBCPKCS7EncryptWithIV combines CBC encryption and PKCS7 padding, the IV used is the one passed to the function
+func SM4CBCPKCS7EncryptWithIV(IV []byte, key, src []byte) ([]byte, error) {
+	if len(IV) != sm4.BlockSize {
+		return nil, errors.New("Invalid IV. It must have length the block size")
+	}
+	return sm4CBCEncrypt(IV, key, pkcs7Padding(src))
+}
 
-	// key := k.(*gmsm4PrivateKey).privKey
-	// var en = make([]byte, 16)
-	// sms4(plaintext, 16, key, en, 1)
-	// return en, nil
+// SM4CBCPKCS7Decrypt combines CBC decryption and PKCS7 unpadding
+func SM4CBCPKCS7Decrypt(key, src []byte) ([]byte, error) {
+	pt, err := sm4CBCDecrypt(key, src)
+	if err != nil {
+		return nil, err
+	}
+	return pkcs7UnPadding(pt)
+}
+
+// SM4CTREncrypt encrypts src in CTR mode. The counter block is prepended to
+// the ciphertext.
+func SM4CTREncrypt(IV []byte, key, src []byte) ([]byte, error) {
+	if len(IV) != sm4.BlockSize {
+		return nil, errors.New("Invalid IV. It must have length the block size")
+	}
+
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := make([]byte, sm4.BlockSize+len(src))
+	copy(ciphertext[:sm4.BlockSize], IV)
+
+	stream := cipher.NewCTR(block, IV)
+	stream.XORKeyStream(ciphertext[sm4.BlockSize:], src)
+
+	return ciphertext, nil
+}
+
+// SM4CTRDecrypt decrypts a ciphertext produced by SM4CTREncrypt
+func SM4CTRDecrypt(key, src []byte) ([]byte, error) {
+	if len(src) < sm4.BlockSize {
+		return nil, errors.New("Invalid ciphertext. It must be at least as long as the block size")
+	}
+
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(src)-sm4.BlockSize)
+	stream := cipher.NewCTR(block, src[:sm4.BlockSize])
+	stream.XORKeyStream(plaintext, src[sm4.BlockSize:])
+
+	return plaintext, nil
+}
+
+// SM4GCMEncrypt seals src in GCM mode authenticating additionalData as well.
+// The nonce is prepended to the ciphertext.
+func SM4GCMEncrypt(nonce []byte, key, src, additionalData []byte) ([]byte, error) {
+	if len(nonce) != sm4GCMNonceSize {
+		return nil, fmt.Errorf("Invalid nonce. It must have length [%d]", sm4GCMNonceSize)
+	}
+
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := make([]byte, len(nonce), len(nonce)+len(src)+aead.Overhead())
+	copy(dst, nonce)
+	return aead.Seal(dst, nonce, src, additionalData), nil
+}
+
+// SM4GCMDecrypt opens a ciphertext produced by SM4GCMEncrypt
+func SM4GCMDecrypt(key, src, additionalData []byte) ([]byte, error) {
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(src) < sm4GCMNonceSize+aead.Overhead() {
+		return nil, errors.New("Invalid ciphertext. It is too short")
+	}
+
+	return aead.Open(nil, src[:sm4GCMNonceSize], src[sm4GCMNonceSize:], additionalData)
+}
+
+type gmsm4Encryptor struct{}
+
+// 实现 Encryptor 接口
+func (e *gmsm4Encryptor) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
+	key := k.(*gmsm4PrivateKey).privKey
+
+	switch o := opts.(type) {
+	case *bccsp.SM4CBCPKCS7ModeOpts:
+		iv, err := sampleIV(o.IV, o.PRNG, sm4.BlockSize)
+		if err != nil {
+			return nil, err
+		}
+		return SM4CBCPKCS7EncryptWithIV(iv, key, plaintext)
+	case bccsp.SM4CBCPKCS7ModeOpts:
+		return e.Encrypt(k, plaintext, &o)
+	case *bccsp.SM4CTRModeOpts:
+		iv, err := sampleIV(o.IV, o.PRNG, sm4.BlockSize)
+		if err != nil {
+			return nil, err
+		}
+		return SM4CTREncrypt(iv, key, plaintext)
+	case bccsp.SM4CTRModeOpts:
+		return e.Encrypt(k, plaintext, &o)
+	case *bccsp.SM4GCMModeOpts:
+		nonce, err := sampleIV(o.Nonce, o.PRNG, sm4GCMNonceSize)
+		if err != nil {
+			return nil, err
+		}
+		return SM4GCMEncrypt(nonce, key, plaintext, o.AdditionalData)
+	case bccsp.SM4GCMModeOpts:
+		return e.Encrypt(k, plaintext, &o)
+	default:
+		return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+	}
 }
 
 type gmsm4Decryptor struct{}
 
-//实现 Decryptor 接口
+// 实现 Decryptor 接口
 func (*gmsm4Decryptor) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) (plaintext []byte, err error) {
+	key := k.(*gmsm4PrivateKey).privKey
 
-	return SM4Decrypt(k.(*gmsm4PrivateKey).privKey, ciphertext)
-	// var dc = make([]byte, 16)
-	// key := k.(*gmsm4PrivateKey).privKey
-	// sms4(ciphertext, 16, key, dc, 0)
-	// return dc, nil
+	switch o := opts.(type) {
+	case *bccsp.SM4CBCPKCS7ModeOpts, bccsp.SM4CBCPKCS7ModeOpts:
+		return SM4CBCPKCS7Decrypt(key, ciphertext)
+	case *bccsp.SM4CTRModeOpts, bccsp.SM4CTRModeOpts:
+		return SM4CTRDecrypt(key, ciphertext)
+	case *bccsp.SM4GCMModeOpts:
+		return SM4GCMDecrypt(key, ciphertext, o.AdditionalData)
+	case bccsp.SM4GCMModeOpts:
+		return SM4GCMDecrypt(key, ciphertext, o.AdditionalData)
+	default:
+		return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+	}
 }
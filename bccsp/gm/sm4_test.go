@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Example 1 of GB/T 32907-2016 Appendix A
+var (
+	sm4KATKey        = mustDecodeHex("0123456789abcdeffedcba9876543210")
+	sm4KATPlaintext  = mustDecodeHex("0123456789abcdeffedcba9876543210")
+	sm4KATCiphertext = mustDecodeHex("681edf34d206965e86b3e94f536e4246")
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestSM4CBCPKCS7KnownAnswer(t *testing.T) {
+	t.Parallel()
+
+	// With a zero IV the first CBC block is the raw block cipher output
+	iv := make([]byte, 16)
+	ct, err := SM4CBCPKCS7EncryptWithIV(iv, sm4KATKey, sm4KATPlaintext)
+	require.NoError(t, err)
+	require.Len(t, ct, 16+32)
+	assert.Equal(t, iv, ct[:16])
+	assert.Equal(t, sm4KATCiphertext, ct[16:32])
+
+	pt, err := SM4CBCPKCS7Decrypt(sm4KATKey, ct)
+	require.NoError(t, err)
+	assert.Equal(t, sm4KATPlaintext, pt)
+}
+
+func TestSM4CBCPKCS7Lengths(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 16)
+	rand.Read(key)
+
+	for _, l := range []int{0, 1, 15, 16, 17, 31, 32, 33, 1000} {
+		msg := make([]byte, l)
+		rand.Read(msg)
+
+		ct, err := SM4CBCPKCS7Encrypt(key, msg)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(ct)%16)
+		assert.True(t, len(ct) > l+16-1)
+
+		pt, err := SM4CBCPKCS7Decrypt(key, ct)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(msg, pt), "round trip failed for length %d", l)
+	}
+}
+
+func TestSM4CBCPKCS7DecryptInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := SM4CBCPKCS7Decrypt(sm4KATKey, []byte{1, 2, 3})
+	assert.Error(t, err)
+
+	_, err = SM4CBCPKCS7Decrypt(sm4KATKey, make([]byte, 16+17))
+	assert.Error(t, err)
+
+	_, err = SM4CBCPKCS7Decrypt(make([]byte, 32), make([]byte, 32))
+	assert.EqualError(t, err, "SM4: invalid key size 32")
+}
+
+func TestSM4CTRKnownAnswer(t *testing.T) {
+	t.Parallel()
+
+	// With a zero counter block the key stream starts with E(0), so
+	// encrypting E(0) yields a zero first block.
+	iv := make([]byte, 16)
+	zero, err := SM4CTREncrypt(iv, sm4KATKey, make([]byte, 16))
+	require.NoError(t, err)
+	ct, err := SM4CTREncrypt(iv, sm4KATKey, zero[16:])
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 16), ct[16:])
+
+	msg := []byte("hello, world! this message is not block aligned")
+	ct, err = SM4CTREncrypt(iv, sm4KATKey, msg)
+	require.NoError(t, err)
+	assert.Len(t, ct, 16+len(msg))
+	pt, err := SM4CTRDecrypt(sm4KATKey, ct)
+	require.NoError(t, err)
+	assert.Equal(t, msg, pt)
+}
+
+func TestSM4GCMKnownAnswer(t *testing.T) {
+	t.Parallel()
+
+	// Test vector from RFC 8998 Appendix A.1
+	nonce := mustDecodeHex("00001234567800000000abcd")
+	aad := mustDecodeHex("feedfacedeadbeeffeedfacedeadbeefabaddad2")
+	pt := mustDecodeHex("aaaaaaaaaaaaaaaabbbbbbbbbbbbbbbbccccccccccccccccdddddddddddddddd" +
+		"eeeeeeeeeeeeeeeeffffffffffffffffeeeeeeeeeeeeeeeeaaaaaaaaaaaaaaaa")
+	expectedCT := mustDecodeHex("17f399f08c67d5ee19d0dc9969c4bb7d5fd46fd3756489069157b282bb200735" +
+		"d82710ca5c22f0ccfa7cbf93d496ac15a56834cbcf98c397b4024a2691233b8d")
+	expectedTag := mustDecodeHex("83de3541e4c2b58177e065a9bf7b62ec")
+
+	ct, err := SM4GCMEncrypt(nonce, sm4KATKey, pt, aad)
+	require.NoError(t, err)
+	assert.Equal(t, nonce, ct[:12])
+	assert.Equal(t, expectedCT, ct[12:len(ct)-16])
+	assert.Equal(t, expectedTag, ct[len(ct)-16:])
+
+	res, err := SM4GCMDecrypt(sm4KATKey, ct, aad)
+	require.NoError(t, err)
+	assert.Equal(t, pt, res)
+
+	_, err = SM4GCMDecrypt(sm4KATKey, ct, []byte("wrong aad"))
+	assert.Error(t, err)
+
+	ct[20] ^= 0xff
+	_, err = SM4GCMDecrypt(sm4KATKey, ct, aad)
+	assert.Error(t, err)
+}
+
+func TestSM4EncryptDecryptWithOpts(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.GMSM4KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+
+	msg := []byte("private data payload that spans more than a single SM4 block")
+
+	for _, opts := range []interface{}{
+		&bccsp.SM4CBCPKCS7ModeOpts{},
+		bccsp.SM4CBCPKCS7ModeOpts{},
+		&bccsp.SM4CBCPKCS7ModeOpts{IV: make([]byte, 16)},
+		&bccsp.SM4CBCPKCS7ModeOpts{PRNG: rand.Reader},
+		&bccsp.SM4CTRModeOpts{},
+		bccsp.SM4CTRModeOpts{PRNG: rand.Reader},
+		&bccsp.SM4GCMModeOpts{AdditionalData: []byte("aad")},
+		bccsp.SM4GCMModeOpts{Nonce: make([]byte, 12)},
+	} {
+		ct, err := csp.Encrypt(k, msg, opts)
+		require.NoError(t, err, "opts %T", opts)
+
+		pt, err := csp.Decrypt(k, ct, opts)
+		require.NoError(t, err, "opts %T", opts)
+		assert.Equal(t, msg, pt, "opts %T", opts)
+	}
+
+	_, err = csp.Encrypt(k, msg, &bccsp.SM4CBCPKCS7ModeOpts{IV: make([]byte, 16), PRNG: rand.Reader})
+	assert.EqualError(t, err, "Invalid options. Either IV or PRNG should be different from nil, or both nil.")
+
+	_, err = csp.Encrypt(k, msg, &bccsp.SM4CTRModeOpts{IV: make([]byte, 8)})
+	assert.EqualError(t, err, "Invalid IV. It must have length [16]")
+
+	_, err = csp.Encrypt(k, msg, &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Mode not recognized")
+
+	_, err = csp.Decrypt(k, msg, nil)
+	assert.Error(t, err)
+}
+
+func TestSM4KeyImportLength(t *testing.T) {
+	t.Parallel()
+
+	csp, err := New(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	_, err = csp.KeyImport(make([]byte, 32), &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	assert.Error(t, err)
+
+	k, err := csp.KeyImport(sm4KATKey, &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	require.NoError(t, err)
+
+	ct, err := csp.Encrypt(k, sm4KATPlaintext, &bccsp.SM4CBCPKCS7ModeOpts{IV: make([]byte, 16)})
+	require.NoError(t, err)
+	assert.Equal(t, sm4KATCiphertext, ct[16:32])
+}
@@ -13,6 +13,12 @@ const (
 	GMSM3 = "GMSM3"
 	// GMSM2
 	GMSM2 = "GMSM2"
+	// GMSM2ReRand GMSM2 key re-randomization
+	GMSM2ReRand = "GMSM2_RERAND"
+	// GMSM3HMAC HMAC based on the GMSM3 hash function
+	GMSM3HMAC = "GMSM3_HMAC"
+	// GMSM3KDF key derivation function of GM/T 0003.3
+	GMSM3KDF = "GMSM3_KDF"
 
 	// ECDSA Elliptic Curve Digital Signature Algorithm (key gen, import, sign, verify),
 	// at default security level.
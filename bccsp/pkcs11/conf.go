@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"hash"
 
+	"github.com/littlegirlpppp/gmsm/sm3"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -28,6 +29,8 @@ func (conf *config) setSecurityLevel(securityLevel int, hashFamily string) (err
 		err = conf.setSecurityLevelSHA2(securityLevel)
 	case "SHA3":
 		err = conf.setSecurityLevelSHA3(securityLevel)
+	case "GMSM3":
+		err = conf.setSecurityLevelGMSM3(securityLevel)
 	default:
 		err = fmt.Errorf("Hash Family not supported [%s]", hashFamily)
 	}
@@ -66,6 +69,18 @@ func (conf *config) setSecurityLevelSHA3(level int) (err error) {
 	return
 }
 
+func (conf *config) setSecurityLevelGMSM3(level int) (err error) {
+	switch level {
+	case 256:
+		conf.ellipticCurve = oidNamedCurveSM2
+		conf.hashFunction = sm3.New
+		conf.aesBitLength = 16
+	default:
+		err = fmt.Errorf("Security level not supported [%d]", level)
+	}
+	return
+}
+
 // PKCS11Opts contains options for the P11Factory
 type PKCS11Opts struct {
 	// Default algorithms when not specified (Deprecated?)
@@ -81,4 +96,39 @@ type PKCS11Opts struct {
 	Pin        string `mapstructure:"pin" json:"pin"`
 	SoftVerify bool   `mapstructure:"softwareverify,omitempty" json:"softwareverify,omitempty"`
 	Immutable  bool   `mapstructure:"immutable,omitempty" json:"immutable,omitempty"`
+
+	// GM holds the vendor mechanisms used for the GM/T algorithms
+	GM *GMOpts `mapstructure:"gm,omitempty" json:"gm,omitempty"`
+}
+
+// GMOpts contains the vendor defined PKCS#11 identifiers of the GM/T
+// algorithms. PKCS#11 does not standardize SM2, SM3 and SM4, so every
+// token assigns them its own values in the CKM_VENDOR_DEFINED and
+// CKK_VENDOR_DEFINED ranges.
+// A mechanism left to zero, or not advertised by the token, is carried
+// out by the software GM BCCSP instead.
+// The software GM BCCSP uses the KeyStore passed to New, which the
+// factory sets to a dummy KeyStore: keys it generates cannot be stored,
+// so only temporary SM2 and SM4 keys can be generated without the
+// corresponding token mechanisms.
+// The vendor mechanisms are not covered by the tests, as SoftHSM does
+// not implement them; check them against the token before use.
+type GMOpts struct {
+	// SM2KeyType is the CKA_KEY_TYPE of SM2 key pairs. When zero, CKK_EC
+	// is used together with the SM2 curve OID in CKA_EC_PARAMS.
+	SM2KeyType uint `mapstructure:"sm2keytype,omitempty" json:"sm2keytype,omitempty"`
+	// SM2KeyGen generates SM2 key pairs
+	SM2KeyGen uint `mapstructure:"sm2keygen,omitempty" json:"sm2keygen,omitempty"`
+	// SM2Sign signs and verifies the 32 byte SM3 digest e = SM3(Z_A || M).
+	// Signatures are returned as r || s.
+	SM2Sign uint `mapstructure:"sm2sign,omitempty" json:"sm2sign,omitempty"`
+	// SM3Digest computes SM3 digests
+	SM3Digest uint `mapstructure:"sm3digest,omitempty" json:"sm3digest,omitempty"`
+	// SM4KeyType is the CKA_KEY_TYPE of SM4 secret keys
+	SM4KeyType uint `mapstructure:"sm4keytype,omitempty" json:"sm4keytype,omitempty"`
+	// SM4KeyGen generates SM4 secret keys
+	SM4KeyGen uint `mapstructure:"sm4keygen,omitempty" json:"sm4keygen,omitempty"`
+	// SM4Encrypt is the SM4 CBC mechanism without padding. It takes the
+	// IV as parameter; padding is applied in software.
+	SM4Encrypt uint `mapstructure:"sm4encrypt,omitempty" json:"sm4encrypt,omitempty"`
 }
@@ -9,6 +9,7 @@ package pkcs11
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/asn1"
@@ -21,8 +22,11 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/gm"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 	"github.com/miekg/pkcs11"
 	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
@@ -45,6 +49,7 @@ type impl struct {
 	conf       *config
 	softVerify bool
 	immutable  bool
+	gmMechs    GMOpts
 
 	sessLock sync.Mutex
 	sessPool chan pkcs11.SessionHandle
@@ -65,11 +70,23 @@ func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
 		return nil, errors.Wrapf(err, "Failed initializing configuration")
 	}
 
-	swCSP, err := sw.NewWithParams(opts.SecLevel, opts.HashFamily, keyStore)
+	// The GM family falls back to the software GM BCCSP for the
+	// mechanisms the token does not provide
+	var swCSP bccsp.BCCSP
+	if opts.HashFamily == "GMSM3" {
+		swCSP, err = gm.New(opts.SecLevel, opts.HashFamily, keyStore)
+	} else {
+		swCSP, err = sw.NewWithParams(opts.SecLevel, opts.HashFamily, keyStore)
+	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed initializing fallback SW BCCSP")
 	}
 
+	var gmMechs GMOpts
+	if opts.GM != nil {
+		gmMechs = *opts.GM
+	}
+
 	var sessPool chan pkcs11.SessionHandle
 	if sessionCacheSize > 0 {
 		sessPool = make(chan pkcs11.SessionHandle, sessionCacheSize)
@@ -84,6 +101,7 @@ func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
 		softVerify:  opts.SoftVerify,
 		keyCache:    map[string]bccsp.Key{},
 		immutable:   opts.Immutable,
+		gmMechs:     gmMechs,
 	}
 
 	return csp.initialize(opts)
@@ -124,6 +142,10 @@ func (csp *impl) initialize(opts PKCS11Opts) (*impl, error) {
 		}
 
 		csp.returnSession(session)
+
+		if err := csp.checkGMMechanisms(); err != nil {
+			return nil, err
+		}
 		return csp, nil
 	}
 
@@ -162,6 +184,28 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
 
+	case *bccsp.GMSM2KeyGenOpts:
+		if csp.gmMechs.SM2KeyGen == 0 {
+			return csp.BCCSP.KeyGen(opts)
+		}
+		ski, pub, err := csp.generateSM2Key(opts.Ephemeral())
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed generating SM2 key")
+		}
+
+		k = &sm2PrivateKey{ski, sm2PublicKey{ski, pub}}
+
+	case *bccsp.GMSM4KeyGenOpts:
+		if csp.gmMechs.SM4KeyGen == 0 {
+			return csp.BCCSP.KeyGen(opts)
+		}
+		ski, err := csp.generateSM4Key(opts.Ephemeral())
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed generating SM4 key")
+		}
+
+		k = &sm4Key{ski}
+
 	default:
 		return csp.BCCSP.KeyGen(opts)
 	}
@@ -184,6 +228,10 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 	switch opts.(type) {
 
 	case *bccsp.X509PublicKeyImportOpts:
+		if _, ok := raw.(*gmx509.Certificate); ok {
+			return csp.BCCSP.KeyImport(raw, opts)
+		}
+
 		x509Cert, ok := raw.(*x509.Certificate)
 		if !ok {
 			return nil, errors.New("[X509PublicKeyImportOpts] Invalid raw material. Expected *x509.Certificate")
@@ -226,13 +274,27 @@ func (csp *impl) GetKey(ski []byte) (bccsp.Key, error) {
 
 	pubKey, isPriv, err := csp.getECKey(ski)
 	if err != nil {
+		if csp.gmMechs.SM4KeyGen != 0 && csp.hasSecretKey(ski) {
+			key := &sm4Key{ski}
+			csp.cacheKey(ski, key)
+			return key, nil
+		}
 		logger.Debugf("Key not found using PKCS11: %v", err)
 		return csp.BCCSP.GetKey(ski)
 	}
 
-	var key bccsp.Key = &ecdsaPublicKey{ski, pubKey}
-	if isPriv {
+	var key bccsp.Key
+	switch {
+	case pubKey.Curve == sm2.P256Sm2():
+		sm2Pub := &sm2.PublicKey{Curve: pubKey.Curve, X: pubKey.X, Y: pubKey.Y}
+		key = &sm2PublicKey{ski, sm2Pub}
+		if isPriv {
+			key = &sm2PrivateKey{ski, sm2PublicKey{ski, sm2Pub}}
+		}
+	case isPriv:
 		key = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pubKey}}
+	default:
+		key = &ecdsaPublicKey{ski, pubKey}
 	}
 
 	csp.cacheKey(ski, key)
@@ -258,6 +320,8 @@ func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte
 	switch key := k.(type) {
 	case *ecdsaPrivateKey:
 		return csp.signECDSA(*key, digest, opts)
+	case *sm2PrivateKey:
+		return csp.signSM2(*key, digest, opts)
 	default:
 		return csp.BCCSP.Sign(key, digest, opts)
 	}
@@ -282,22 +346,104 @@ func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.Signer
 		return csp.verifyECDSA(key.pub, signature, digest, opts)
 	case *ecdsaPublicKey:
 		return csp.verifyECDSA(*key, signature, digest, opts)
+	case *sm2PrivateKey:
+		return csp.verifySM2(key.pub, signature, digest, opts)
+	case *sm2PublicKey:
+		return csp.verifySM2(*key, signature, digest, opts)
 	default:
 		return csp.BCCSP.Verify(k, signature, digest, opts)
 	}
 }
 
+// Hash hashes messages msg using options opts.
+// SM3 digests are computed by the token when it provides the mechanism.
+func (csp *impl) Hash(msg []byte, opts bccsp.HashOpts) ([]byte, error) {
+	if _, ok := opts.(*bccsp.GMSM3Opts); ok && csp.gmMechs.SM3Digest != 0 {
+		return csp.digestP11(csp.gmMechs.SM3Digest, msg)
+	}
+	return csp.BCCSP.Hash(msg, opts)
+}
+
 // Encrypt encrypts plaintext using key k.
 // The opts argument should be appropriate for the primitive used.
 func (csp *impl) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) ([]byte, error) {
-	// TODO: Add PKCS11 support for encryption, when fabric starts requiring it
-	return csp.BCCSP.Encrypt(k, plaintext, opts)
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil")
+	}
+
+	switch key := k.(type) {
+	case *sm4Key:
+		return csp.encryptSM4(*key, plaintext, opts)
+	default:
+		// TODO: Add PKCS11 support for AES encryption, when fabric starts requiring it
+		return csp.BCCSP.Encrypt(k, plaintext, opts)
+	}
 }
 
 // Decrypt decrypts ciphertext using key k.
 // The opts argument should be appropriate for the primitive used.
 func (csp *impl) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) ([]byte, error) {
-	return csp.BCCSP.Decrypt(k, ciphertext, opts)
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil")
+	}
+
+	switch key := k.(type) {
+	case *sm4Key:
+		return csp.decryptSM4(*key, ciphertext, opts)
+	default:
+		return csp.BCCSP.Decrypt(k, ciphertext, opts)
+	}
+}
+
+// checkGMMechanisms drops the configured GM mechanisms the token does not
+// advertise so that the corresponding operations run in software.
+func (csp *impl) checkGMMechanisms() error {
+	if csp.gmMechs == (GMOpts{}) {
+		return nil
+	}
+
+	mechs, err := csp.ctx.GetMechanismList(csp.slot)
+	if err != nil {
+		return errors.Wrap(err, "pkcs11: get mechanism list")
+	}
+	supported := map[uint]bool{}
+	for _, m := range mechs {
+		supported[m.Mechanism] = true
+	}
+
+	for _, m := range []struct {
+		name string
+		mech *uint
+	}{
+		{"SM2 key generation", &csp.gmMechs.SM2KeyGen},
+		{"SM2 signature", &csp.gmMechs.SM2Sign},
+		{"SM3 digest", &csp.gmMechs.SM3Digest},
+		{"SM4 key generation", &csp.gmMechs.SM4KeyGen},
+		{"SM4 encryption", &csp.gmMechs.SM4Encrypt},
+	} {
+		if *m.mech != 0 && !supported[*m.mech] {
+			logger.Warningf("Token does not support %s mechanism 0x%x, falling back to software", m.name, *m.mech)
+			*m.mech = 0
+		}
+	}
+
+	if csp.gmMechs.SM4KeyGen != 0 && csp.gmMechs.SM4KeyType == 0 {
+		return errors.New("pkcs11: SM4 key type must be set to generate SM4 keys on the token")
+	}
+
+	// Keys generated by the token cannot be used in software
+	if csp.gmMechs.SM2Sign == 0 && csp.gmMechs.SM2KeyGen != 0 {
+		logger.Warningf("SM2 signature not available on token, generating SM2 keys in software")
+		csp.gmMechs.SM2KeyGen = 0
+	}
+	if csp.gmMechs.SM4Encrypt == 0 && csp.gmMechs.SM4KeyGen != 0 {
+		logger.Warningf("SM4 encryption not available on token, generating SM4 keys in software")
+		csp.gmMechs.SM4KeyGen = 0
+	}
+
+	return nil
 }
 
 func (csp *impl) getSession() (session pkcs11.SessionHandle, err error) {
@@ -435,6 +581,11 @@ var (
 	oidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
 )
 
+// GM/T 0006, SM2 elliptic curve
+//
+// sm2p256v1 OBJECT IDENTIFIER ::= { 1 2 156 10197 1 301 }
+var oidNamedCurveSM2 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+
 func namedCurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
 	switch {
 	case oid.Equal(oidNamedCurveP224):
@@ -445,11 +596,53 @@ func namedCurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
 		return elliptic.P384()
 	case oid.Equal(oidNamedCurveP521):
 		return elliptic.P521()
+	case oid.Equal(oidNamedCurveSM2):
+		return sm2.P256Sm2()
 	}
 	return nil
 }
 
 func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
+	ski, ecpt, err := csp.generateKeyPair(pkcs11.CKK_EC, pkcs11.CKM_EC_KEY_PAIR_GEN, curve, ephemeral)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nistCurve := namedCurveFromOID(curve)
+	if nistCurve == nil {
+		return nil, nil, fmt.Errorf("Cound not recognize Curve from OID")
+	}
+	x, y := elliptic.Unmarshal(nistCurve, ecpt)
+	if x == nil {
+		return nil, nil, fmt.Errorf("Failed Unmarshaling Public Key")
+	}
+
+	pubGoKey := &ecdsa.PublicKey{Curve: nistCurve, X: x, Y: y}
+	return ski, pubGoKey, nil
+}
+
+func (csp *impl) generateSM2Key(ephemeral bool) (ski []byte, pubKey *sm2.PublicKey, err error) {
+	keyType := csp.gmMechs.SM2KeyType
+	if keyType == 0 {
+		keyType = pkcs11.CKK_EC
+	}
+
+	ski, ecpt, err := csp.generateKeyPair(keyType, csp.gmMechs.SM2KeyGen, oidNamedCurveSM2, ephemeral)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	x, y := elliptic.Unmarshal(sm2.P256Sm2(), ecpt)
+	if x == nil {
+		return nil, nil, fmt.Errorf("Failed Unmarshaling Public Key")
+	}
+
+	return ski, &sm2.PublicKey{Curve: sm2.P256Sm2(), X: x, Y: y}, nil
+}
+
+// generateKeyPair generates an elliptic curve key pair on the token and
+// returns its SKI together with the uncompressed public point.
+func (csp *impl) generateKeyPair(keyType, mech uint, curve asn1.ObjectIdentifier, ephemeral bool) (ski, ecpt []byte, err error) {
 	session, err := csp.getSession()
 	if err != nil {
 		return nil, nil, err
@@ -466,7 +659,7 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 	}
 
 	pubkeyT := []*pkcs11.Attribute{
-		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, keyType),
 		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
 		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
 		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
@@ -477,7 +670,7 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 	}
 
 	prvkeyT := []*pkcs11.Attribute{
-		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, keyType),
 		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
 		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
 		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
@@ -491,7 +684,7 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 	}
 
 	pub, prv, err := csp.ctx.GenerateKeyPair(session,
-		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(mech, nil)},
 		pubkeyT,
 		prvkeyT,
 	)
@@ -499,7 +692,7 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 		return nil, nil, fmt.Errorf("P11: keypair generate failed [%s]", err)
 	}
 
-	ecpt, _, err := csp.ecPoint(session, pub)
+	ecpt, _, err = csp.ecPoint(session, pub)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Error querying EC-point: [%s]", err)
 	}
@@ -549,23 +742,12 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 		}
 	}
 
-	nistCurve := namedCurveFromOID(curve)
-	if curve == nil {
-		return nil, nil, fmt.Errorf("Cound not recognize Curve from OID")
-	}
-	x, y := elliptic.Unmarshal(nistCurve, ecpt)
-	if x == nil {
-		return nil, nil, fmt.Errorf("Failed Unmarshaling Public Key")
-	}
-
-	pubGoKey := &ecdsa.PublicKey{Curve: nistCurve, X: x, Y: y}
-
 	if logger.IsEnabledFor(zapcore.DebugLevel) {
 		listAttrs(csp.ctx, session, prv)
 		listAttrs(csp.ctx, session, pub)
 	}
 
-	return ski, pubGoKey, nil
+	return ski, ecpt, nil
 }
 
 func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
@@ -641,11 +823,200 @@ func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize
 	return true, nil
 }
 
+func (csp *impl) signP11SM2(ski []byte, e []byte) (R, S *big.Int, err error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	privateKey, err := csp.findKeyPairFromSKI(session, ski, privateKeyType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Private key not found [%s]", err)
+	}
+
+	err = csp.ctx.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(csp.gmMechs.SM2Sign, nil)}, privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Sign-initialize  failed [%s]", err)
+	}
+
+	var sig []byte
+
+	sig, err = csp.ctx.Sign(session, e)
+	if err != nil {
+		return nil, nil, fmt.Errorf("P11: sign failed [%s]", err)
+	}
+
+	R = new(big.Int).SetBytes(sig[0 : len(sig)/2])
+	S = new(big.Int).SetBytes(sig[len(sig)/2:])
+
+	return R, S, nil
+}
+
+func (csp *impl) verifyP11SM2(ski []byte, e []byte, R, S *big.Int) (bool, error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return false, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	logger.Debugf("Verify SM2\n")
+
+	publicKey, err := csp.findKeyPairFromSKI(session, ski, publicKeyType)
+	if err != nil {
+		return false, fmt.Errorf("Public key not found [%s]", err)
+	}
+
+	r := R.Bytes()
+	s := S.Bytes()
+	if len(r) > 32 || len(s) > 32 {
+		return false, nil
+	}
+
+	// Pad front of R and S with Zeroes if needed
+	sig := make([]byte, 64)
+	copy(sig[32-len(r):32], r)
+	copy(sig[64-len(s):], s)
+
+	err = csp.ctx.VerifyInit(
+		session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(csp.gmMechs.SM2Sign, nil)},
+		publicKey,
+	)
+	if err != nil {
+		return false, fmt.Errorf("PKCS11: Verify-initialize [%s]", err)
+	}
+	err = csp.ctx.Verify(session, e, sig)
+	if err == pkcs11.Error(pkcs11.CKR_SIGNATURE_INVALID) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("PKCS11: Verify failed [%s]", err)
+	}
+
+	return true, nil
+}
+
+func (csp *impl) digestP11(mech uint, msg []byte) (digest []byte, err error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	err = csp.ctx.DigestInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(mech, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("PKCS11: Digest-initialize [%s]", err)
+	}
+
+	digest, err = csp.ctx.Digest(session, msg)
+	if err != nil {
+		return nil, fmt.Errorf("P11: digest failed [%s]", err)
+	}
+
+	return digest, nil
+}
+
+// generateSM4Key generates a non extractable SM4 key on the token. Secret
+// keys have no public part to derive the SKI from, so a random one is
+// assigned to CKA_ID.
+func (csp *impl) generateSM4Key(ephemeral bool) (ski []byte, err error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	ski = make([]byte, sha256.Size)
+	if _, err = rand.Read(ski); err != nil {
+		return nil, fmt.Errorf("Failed generating SKI [%s]", err)
+	}
+
+	keyT := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, csp.gmMechs.SM4KeyType),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, 16),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, hex.EncodeToString(ski)),
+
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+	}
+	if csp.immutable {
+		keyT = append(keyT, pkcs11.NewAttribute(pkcs11.CKA_MODIFIABLE, false))
+	}
+
+	key, err := csp.ctx.GenerateKey(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(csp.gmMechs.SM4KeyGen, nil)}, keyT)
+	if err != nil {
+		return nil, fmt.Errorf("P11: key generate failed [%s]", err)
+	}
+
+	logger.Infof("Generated new P11 key, SKI %x\n", ski)
+	if logger.IsEnabledFor(zapcore.DebugLevel) {
+		listAttrs(csp.ctx, session, key)
+	}
+
+	return ski, nil
+}
+
+func (csp *impl) hasSecretKey(ski []byte) bool {
+	session, err := csp.getSession()
+	if err != nil {
+		return false
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	_, err = csp.findKeyPairFromSKI(session, ski, secretKeyType)
+	return err == nil
+}
+
+// cryptP11SM4 runs the SM4 CBC mechanism over data, which must already
+// be a multiple of the block size.
+func (csp *impl) cryptP11SM4(ski, iv, data []byte, encrypt bool) (out []byte, err error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	key, err := csp.findKeyPairFromSKI(session, ski, secretKeyType)
+	if err != nil {
+		return nil, fmt.Errorf("Secret key not found [%s]", err)
+	}
+
+	mech := []*pkcs11.Mechanism{pkcs11.NewMechanism(csp.gmMechs.SM4Encrypt, iv)}
+	if encrypt {
+		if err = csp.ctx.EncryptInit(session, mech, key); err != nil {
+			return nil, fmt.Errorf("PKCS11: Encrypt-initialize [%s]", err)
+		}
+		out, err = csp.ctx.Encrypt(session, data)
+		if err != nil {
+			return nil, fmt.Errorf("P11: encrypt failed [%s]", err)
+		}
+		return out, nil
+	}
+
+	if err = csp.ctx.DecryptInit(session, mech, key); err != nil {
+		return nil, fmt.Errorf("PKCS11: Decrypt-initialize [%s]", err)
+	}
+	out, err = csp.ctx.Decrypt(session, data)
+	if err != nil {
+		return nil, fmt.Errorf("P11: decrypt failed [%s]", err)
+	}
+	return out, nil
+}
+
 type keyType int8
 
 const (
 	publicKeyType keyType = iota
 	privateKeyType
+	secretKeyType
 )
 
 func (csp *impl) cachedHandle(keyType keyType, ski []byte) (pkcs11.ObjectHandle, bool) {
@@ -679,8 +1050,11 @@ func (csp *impl) findKeyPairFromSKI(session pkcs11.SessionHandle, ski []byte, ke
 	}
 
 	ktype := pkcs11.CKO_PUBLIC_KEY
-	if keyType == privateKeyType {
+	switch keyType {
+	case privateKeyType:
 		ktype = pkcs11.CKO_PRIVATE_KEY
+	case secretKeyType:
+		ktype = pkcs11.CKO_SECRET_KEY
 	}
 
 	template := []*pkcs11.Attribute{
@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/signer"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/littlegirlpppp/gmsm/sm2"
 	"github.com/miekg/pkcs11"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1294,6 +1295,10 @@ func TestNamedCurveFromOID(t *testing.T) {
 	namedCurve = namedCurveFromOID(oidNamedCurveP521)
 	assert.Equal(t, elliptic.P521(), namedCurve, "Did not receive expected named curved for oidNamedCurveP521")
 
+	// Test for valid SM2 elliptic curve
+	namedCurve = namedCurveFromOID(oidNamedCurveSM2)
+	assert.Equal(t, sm2.P256Sm2(), namedCurve, "Did not receive expected named curve for oidNamedCurveSM2")
+
 	testAsn1Value := asn1.ObjectIdentifier{4, 9, 15, 1}
 	namedCurve = namedCurveFromOID(testAsn1Value)
 	if namedCurve != nil {
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/gm"
+	"github.com/littlegirlpppp/gmsm/sm2"
+)
+
+// sm2UserID returns the user identity bound into Z_A. Signatures produced
+// without SM2SignerOpts use the default identity, as the software GM
+// BCCSP does.
+func sm2UserID(opts bccsp.SignerOpts) []byte {
+	if o, ok := opts.(*bccsp.SM2SignerOpts); ok && o != nil && len(o.UID) != 0 {
+		return o.UID
+	}
+	return bccsp.DefaultSM2UserID
+}
+
+// sm2Digest computes the 32 byte value e = SM3(Z_A || msg) the token signs
+func sm2Digest(pub *sm2.PublicKey, msg []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	e, err := pub.Sm3Digest(msg, sm2UserID(opts))
+	if err != nil {
+		return nil, fmt.Errorf("Failed computing SM2 digest [%s]", err)
+	}
+
+	// Pad front of e with zeroes if needed
+	digest := make([]byte, 32)
+	copy(digest[32-len(e):], e)
+	return digest, nil
+}
+
+func (csp *impl) signSM2(k sm2PrivateKey, msg []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	e, err := sm2Digest(k.pub.pub, msg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r, s, err := csp.signP11SM2(k.ski, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return gm.MarshalSM2Signature(r, s)
+}
+
+func (csp *impl) verifySM2(k sm2PublicKey, signature, msg []byte, opts bccsp.SignerOpts) (bool, error) {
+	r, s, err := gm.UnmarshalSM2Signature(signature)
+	if err != nil {
+		return false, err
+	}
+
+	if csp.softVerify {
+		return sm2.Sm2Verify(k.pub, msg, sm2UserID(opts), r, s), nil
+	}
+
+	e, err := sm2Digest(k.pub, msg, opts)
+	if err != nil {
+		return false, err
+	}
+
+	return csp.verifyP11SM2(k.ski, e, r, s)
+}
@@ -0,0 +1,166 @@
+// +build pkcs11
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/gm"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	"github.com/littlegirlpppp/gmsm/sm3"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/require"
+)
+
+// newGMCSP returns a GMSM3 instance whose vendor mechanisms are not
+// offered by SoftHSM, so every GM operation falls back to software.
+func newGMCSP(t *testing.T) *impl {
+	lib, pin, label := FindPKCS11Lib()
+	opts := PKCS11Opts{
+		HashFamily: "GMSM3",
+		SecLevel:   256,
+		Library:    lib,
+		Label:      label,
+		Pin:        pin,
+		GM: &GMOpts{
+			SM2KeyGen:  pkcs11.CKM_VENDOR_DEFINED | 0x1,
+			SM2Sign:    pkcs11.CKM_VENDOR_DEFINED | 0x2,
+			SM3Digest:  pkcs11.CKM_VENDOR_DEFINED | 0x3,
+			SM4KeyType: pkcs11.CKK_VENDOR_DEFINED | 0x1,
+			SM4KeyGen:  pkcs11.CKM_VENDOR_DEFINED | 0x4,
+			SM4Encrypt: pkcs11.CKM_VENDOR_DEFINED | 0x5,
+		},
+	}
+
+	csp, err := New(opts, gm.NewDummyKeyStore())
+	require.NoError(t, err)
+	return csp.(*impl)
+}
+
+func TestGMMechanismsFallback(t *testing.T) {
+	csp := newGMCSP(t)
+	require.Equal(t, GMOpts{SM4KeyType: pkcs11.CKK_VENDOR_DEFINED | 0x1}, csp.gmMechs)
+
+	msg := []byte("Hello World")
+
+	k, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	_, isToken := k.(*sm2PrivateKey)
+	require.False(t, isToken, "SM2 key should have been generated in software")
+
+	signature, err := csp.Sign(k, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	valid, err := csp.Verify(k, signature, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	digest, err := csp.Hash(msg, &bccsp.GMSM3Opts{})
+	require.NoError(t, err)
+	expected := sm3.Sm3Sum(msg)
+	require.Equal(t, expected[:], digest)
+
+	k, err = csp.KeyGen(&bccsp.GMSM4KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	_, isToken = k.(*sm4Key)
+	require.False(t, isToken, "SM4 key should have been generated in software")
+
+	ct, err := csp.Encrypt(k, msg, &bccsp.SM4CBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	pt, err := csp.Decrypt(k, ct, &bccsp.SM4CBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	require.Equal(t, msg, pt)
+}
+
+func TestGMSoftwareKeysNotStored(t *testing.T) {
+	csp := newGMCSP(t)
+
+	_, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: false})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Cannot store key. This is a dummy read-only KeyStore")
+
+	_, err = csp.KeyGen(&bccsp.GMSM4KeyGenOpts{Temporary: false})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Cannot store key. This is a dummy read-only KeyStore")
+
+	k, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	_, err = csp.GetKey(k.SKI())
+	require.Error(t, err)
+}
+
+func TestGMMechanismsInvalidConfig(t *testing.T) {
+	lib, pin, label := FindPKCS11Lib()
+	opts := PKCS11Opts{
+		HashFamily: "GMSM3",
+		SecLevel:   256,
+		Library:    lib,
+		Label:      label,
+		Pin:        pin,
+		GM:         &GMOpts{SM4KeyGen: pkcs11.CKM_AES_KEY_GEN, SM4Encrypt: pkcs11.CKM_AES_CBC},
+	}
+
+	_, err := New(opts, gm.NewDummyKeyStore())
+	require.EqualError(t, err, "pkcs11: SM4 key type must be set to generate SM4 keys on the token")
+}
+
+func TestSM2Digest(t *testing.T) {
+	priv, err := sm2.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	msg := []byte("message digest")
+	uid := []byte("ALICE123@YAHOO.COM")
+
+	za, err := sm2.ZA(&priv.PublicKey, uid)
+	require.NoError(t, err)
+	expected := sm3.Sm3Sum(append(za, msg...))
+
+	e, err := sm2Digest(&priv.PublicKey, msg, &bccsp.SM2SignerOpts{UID: uid})
+	require.NoError(t, err)
+	require.Equal(t, expected[:], e)
+
+	za, err = sm2.ZA(&priv.PublicKey, bccsp.DefaultSM2UserID)
+	require.NoError(t, err)
+	expected = sm3.Sm3Sum(append(za, msg...))
+
+	e, err = sm2Digest(&priv.PublicKey, msg, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected[:], e)
+}
+
+func TestSM2SoftVerify(t *testing.T) {
+	csp := newGMCSP(t)
+	csp.softVerify = true
+
+	swCSP, err := gm.New(256, "GMSM3", gm.NewDummyKeyStore())
+	require.NoError(t, err)
+	k, err := swCSP.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	raw, err := pk.Bytes()
+	require.NoError(t, err)
+	sm2Pub, err := gmx509.ParseSm2PublicKey(raw)
+	require.NoError(t, err)
+
+	msg := []byte("Hello World")
+	uid := []byte("ALICE123@YAHOO.COM")
+	signature, err := swCSP.Sign(k, msg, &bccsp.SM2SignerOpts{UID: uid})
+	require.NoError(t, err)
+
+	pub := &sm2PublicKey{pk.SKI(), sm2Pub}
+	valid, err := csp.Verify(pub, signature, msg, &bccsp.SM2SignerOpts{UID: uid})
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = csp.Verify(pub, signature, msg, &bccsp.SM2SignerOpts{})
+	require.NoError(t, err)
+	require.False(t, valid)
+}
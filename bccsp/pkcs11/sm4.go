@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/littlegirlpppp/gmsm/sm4"
+)
+
+// SM4 keys held by the token only support CBC with PKCS7 padding. The
+// ciphertext layout is the same as the one of the software GM BCCSP,
+// the IV followed by the encrypted blocks.
+func (csp *impl) encryptSM4(k sm4Key, plaintext []byte, opts bccsp.EncrypterOpts) ([]byte, error) {
+	var o *bccsp.SM4CBCPKCS7ModeOpts
+	switch opts := opts.(type) {
+	case *bccsp.SM4CBCPKCS7ModeOpts:
+		o = opts
+	case bccsp.SM4CBCPKCS7ModeOpts:
+		o = &opts
+	default:
+		return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+	}
+
+	if len(o.IV) != 0 && o.PRNG != nil {
+		return nil, errors.New("Invalid options. Either IV or PRNG should be different from nil, or both nil.")
+	}
+
+	iv := o.IV
+	if len(iv) == 0 {
+		prng := o.PRNG
+		if prng == nil {
+			prng = rand.Reader
+		}
+		iv = make([]byte, sm4.BlockSize)
+		if _, err := io.ReadFull(prng, iv); err != nil {
+			return nil, fmt.Errorf("Failed sampling IV [%s]", err)
+		}
+	}
+	if len(iv) != sm4.BlockSize {
+		return nil, fmt.Errorf("Invalid IV. It must have length [%d]", sm4.BlockSize)
+	}
+
+	padding := sm4.BlockSize - len(plaintext)%sm4.BlockSize
+	padded := append(append([]byte{}, plaintext...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	ct, err := csp.cryptP11SM4(k.ski, iv, padded, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, iv...), ct...), nil
+}
+
+func (csp *impl) decryptSM4(k sm4Key, ciphertext []byte, opts bccsp.DecrypterOpts) ([]byte, error) {
+	switch opts.(type) {
+	case *bccsp.SM4CBCPKCS7ModeOpts, bccsp.SM4CBCPKCS7ModeOpts:
+	default:
+		return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+	}
+
+	if len(ciphertext) < 2*sm4.BlockSize || len(ciphertext)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size and contain the IV")
+	}
+
+	pt, err := csp.cryptP11SM4(k.ski, ciphertext[:sm4.BlockSize], ciphertext[sm4.BlockSize:], false)
+	if err != nil {
+		return nil, err
+	}
+
+	padding := int(pt[len(pt)-1])
+	if padding == 0 || padding > sm4.BlockSize || !bytes.Equal(pt[len(pt)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.New("Invalid pkcs7 padding")
+	}
+
+	return pt[:len(pt)-padding], nil
+}
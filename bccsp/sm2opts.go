@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bccsp
+
+import (
+	"crypto"
+	"io"
+)
+
+// SM2CipherOrder selects how the C1, C2 and C3 components of an SM2
+// ciphertext are concatenated.
+type SM2CipherOrder int
+
+const (
+	// SM2CipherC1C3C2 is the order mandated by GM/T 0003.4-2012 and the default.
+	SM2CipherC1C3C2 SM2CipherOrder = iota
+	// SM2CipherC1C2C3 is the legacy order used by the 2010 draft of the standard
+	// and by a number of older toolkits.
+	SM2CipherC1C2C3
+)
+
+// SM2EncrypterOpts contains options for SM2 public-key encryption and
+// decryption (GM/T 0003.4).
+// The same options must be passed to Encrypt and Decrypt.
+type SM2EncrypterOpts struct {
+	// Order is the order of the ciphertext components when raw encoding
+	// is used. It is ignored when ASN1 is true.
+	Order SM2CipherOrder
+	// ASN1 selects the DER encoding of the SM2Cipher structure defined
+	// in GM/T 0009 instead of the raw concatenation of the components.
+	ASN1 bool
+	// PRNG is an instance of a PRNG to be used to sample the ephemeral key.
+	// It is used only if different from nil.
+	PRNG io.Reader
+}
+
+// DefaultSM2UserID is the distinguishing identifier that GM/T 0009 mandates
+// when the signer and the verifier have not agreed on a different one.
+var DefaultSM2UserID = []byte("1234567812345678")
+
+// SM2SignerOpts contains options for SM2 signing and verification with the
+// user identity preprocessing of GM/T 0003.2 and GM/T 0009.
+// When these options are passed, the digest argument of Sign and Verify is the
+// message M itself and the implementation signs e = SM3(Z_A || M), where Z_A is
+// derived from UID, the curve parameters and the signer's public key.
+type SM2SignerOpts struct {
+	// UID is the distinguishing identifier of the signer.
+	// If empty, DefaultSM2UserID is used.
+	UID []byte
+}
+
+// HashFunc returns an identifier for the hash function used to produce
+// the message passed to Signer.Sign. SM3 is applied internally, hence zero
+// is returned.
+func (opts *SM2SignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+// GMSM2ReRandKeyOpts contains options for GMSM2 key re-randomization.
+type GMSM2ReRandKeyOpts struct {
+	Temporary bool
+	Expansion []byte
+}
+
+// Algorithm returns the key derivation algorithm identifier (to be used).
+func (opts *GMSM2ReRandKeyOpts) Algorithm() string {
+	return GMSM2ReRand
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *GMSM2ReRandKeyOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// ExpansionValue returns the re-randomization factor
+func (opts *GMSM2ReRandKeyOpts) ExpansionValue() []byte {
+	return opts.Expansion
+}
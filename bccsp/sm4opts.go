@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bccsp
+
+import "io"
+
+// SM4CBCPKCS7ModeOpts contains options for SM4 encryption in CBC mode
+// with PKCS7 padding.
+// Notice that both IV and PRNG can be nil. In that case, the BCCSP implementation
+// is supposed to sample the IV using a cryptographic secure PRNG.
+// Notice also that either IV or PRNG can be different from nil.
+// The ciphertext is the IV followed by the encrypted blocks.
+type SM4CBCPKCS7ModeOpts struct {
+	// IV is the initialization vector to be used by the underlying cipher.
+	// The length of IV must be the same as the Block's block size.
+	// It is used only if different from nil.
+	IV []byte
+	// PRNG is an instance of a PRNG to be used by the underlying cipher.
+	// It is used only if different from nil.
+	PRNG io.Reader
+}
+
+// SM4CTRModeOpts contains options for SM4 encryption in CTR mode.
+// IV and PRNG follow the same rules as in SM4CBCPKCS7ModeOpts.
+// The ciphertext is the IV followed by the encrypted stream and has
+// the same length as the plaintext plus the block size.
+type SM4CTRModeOpts struct {
+	// IV is the initial counter block to be used by the underlying cipher.
+	// The length of IV must be the same as the Block's block size.
+	// It is used only if different from nil.
+	IV []byte
+	// PRNG is an instance of a PRNG to be used by the underlying cipher.
+	// It is used only if different from nil.
+	PRNG io.Reader
+}
+
+// SM4GCMModeOpts contains options for SM4 authenticated encryption in
+// GCM mode.
+// Nonce and PRNG follow the same rules as IV and PRNG in SM4CBCPKCS7ModeOpts.
+// The ciphertext is the nonce followed by the sealed data and the
+// authentication tag.
+type SM4GCMModeOpts struct {
+	// Nonce is the nonce to be used by the underlying AEAD. It must be
+	// 12 bytes long and must never be reused with the same key.
+	// It is used only if different from nil.
+	Nonce []byte
+	// AdditionalData is authenticated but not encrypted. The same value
+	// must be passed when decrypting.
+	AdditionalData []byte
+	// PRNG is an instance of a PRNG to be used to sample the nonce.
+	// It is used only if different from nil.
+	PRNG io.Reader
+}
+
+// GMSM4HMACDeriveKeyOpts contains options for deriving a GMSM4 key
+// from another GMSM4 key with HMAC-SM3 truncated at 128 bits.
+type GMSM4HMACDeriveKeyOpts struct {
+	Temporary bool
+	Arg       []byte
+}
+
+// Algorithm returns the key derivation algorithm identifier (to be used).
+func (opts *GMSM4HMACDeriveKeyOpts) Algorithm() string {
+	return GMSM3HMAC
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *GMSM4HMACDeriveKeyOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// Argument returns the argument to be passed to the HMAC
+func (opts *GMSM4HMACDeriveKeyOpts) Argument() []byte {
+	return opts.Arg
+}
+
+// GMSM3KDFDeriveKeyOpts contains options for deriving a GMSM4 key
+// with the SM3-based key derivation function of GM/T 0003.3.
+// The shared secret Z is the source key followed by Arg.
+type GMSM3KDFDeriveKeyOpts struct {
+	Temporary bool
+	Arg       []byte
+}
+
+// Algorithm returns the key derivation algorithm identifier (to be used).
+func (opts *GMSM3KDFDeriveKeyOpts) Algorithm() string {
+	return GMSM3KDF
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *GMSM3KDFDeriveKeyOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// Argument returns the additional input of the KDF
+func (opts *GMSM3KDFDeriveKeyOpts) Argument() []byte {
+	return opts.Arg
+}
@@ -70,6 +70,7 @@ type OrgSpec struct {
 	Name          string       `yaml:"Name"`
 	Domain        string       `yaml:"Domain"`
 	EnableNodeOUs bool         `yaml:"EnableNodeOUs"`
+	KeyAlgorithm  string       `yaml:"KeyAlgorithm"`
 	CA            NodeSpec     `yaml:"CA"`
 	Template      NodeTemplate `yaml:"Template"`
 	Specs         []NodeSpec   `yaml:"Specs"`
@@ -92,6 +93,7 @@ OrdererOrgs:
   - Name: Orderer
     Domain: example.com
     EnableNodeOUs: false
+    KeyAlgorithm: sm2
 
     # ---------------------------------------------------------------------------
     # "Specs" - See PeerOrgs below for complete description
@@ -110,6 +112,20 @@ PeerOrgs:
     Domain: org1.example.com
     EnableNodeOUs: false
 
+    # ---------------------------------------------------------------------------
+    # "KeyAlgorithm"
+    # ---------------------------------------------------------------------------
+    # The algorithm of the keys generated for the CA, the TLS CA and all the
+    # nodes and users of this organization. One of:
+    #   - sm2:        (Default) SM2 keys and GM certificates signed with SM3.
+    #                 TLS material includes a separate encryption key pair.
+    #   - ecdsa-p256: ECDSA keys on the P-256 curve and standard certificates,
+    #                 compatible with stock Hyperledger Fabric.
+    #   - ecdsa-p384: ECDSA keys on the P-384 curve and standard certificates.
+    #   - ed25519:    Reserved, not supported by the MSP yet.
+    # ---------------------------------------------------------------------------
+    KeyAlgorithm: sm2
+
     # ---------------------------------------------------------------------------
     # "CA"
     # ---------------------------------------------------------------------------
@@ -193,6 +209,7 @@ PeerOrgs:
   - Name: Org2
     Domain: org2.example.com
     EnableNodeOUs: false
+    KeyAlgorithm: sm2
     Template:
       Count: 1
     Users:
@@ -265,6 +282,13 @@ func getConfig() (*Config, error) {
 		return nil, fmt.Errorf("Error Unmarshaling YAML: %s", err)
 	}
 
+	for _, orgSpec := range append(config.PeerOrgs, config.OrdererOrgs...) {
+		err = csp.ValidateKeyAlgorithm(orgSpec.KeyAlgorithm)
+		if err != nil {
+			return nil, fmt.Errorf("Error in organization %s: %s", orgSpec.Name, err)
+		}
+	}
+
 	return config, nil
 }
 
@@ -525,13 +549,13 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -642,13 +666,13 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -723,17 +747,27 @@ func printVersion() {
 }
 
 func getCA(caDir string, spec OrgSpec, name string) *ca.CA {
-	priv, _ := csp.LoadPrivateKey(caDir)
-	cert, _ := ca.LoadCertificateGMSM2(caDir)
-	return &ca.CA{
+	signCA := &ca.CA{
 		Name:               name,
-		SignSm2Cert:        cert,
-		Sm2Key:             priv,
 		Country:            spec.CA.Country,
 		Province:           spec.CA.Province,
 		Locality:           spec.CA.Locality,
 		OrganizationalUnit: spec.CA.OrganizationalUnit,
 		StreetAddress:      spec.CA.StreetAddress,
 		PostalCode:         spec.CA.PostalCode,
+		KeyAlgorithm:       spec.KeyAlgorithm,
+	}
+
+	if !csp.IsSM2(spec.KeyAlgorithm) {
+		priv, _ := csp.LoadECDSAPrivateKey(caDir)
+		if priv != nil {
+			signCA.Signer = &csp.ECDSASigner{PrivateKey: priv}
+		}
+		signCA.SignCert, _ = ca.LoadCertificateECDSA(caDir)
+		return signCA
 	}
+
+	signCA.Sm2Key, _ = csp.LoadPrivateKey(caDir)
+	signCA.SignSm2Cert, _ = ca.LoadCertificateGMSM2(caDir)
+	return signCA
 }
@@ -0,0 +1,358 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/osnadmin"
+	"github.com/hyperledger/fabric/protoutil"
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Exit codes of the osnadmin command. A response from the orderer that carries an
+// error status is mapped to a dedicated exit code, so that scripts can tell the
+// failures of the channel participation API apart without parsing the output.
+const (
+	exitOK               = 0
+	exitClientError      = 1
+	exitBadRequest       = 2
+	exitForbidden        = 3
+	exitNotFound         = 4
+	exitMethodNotAllowed = 5
+	exitUnavailable      = 6
+	exitServerError      = 7
+)
+
+func main() {
+	kingpin.Version("0.0.1")
+
+	output, exit, err := executeForArgs(os.Args[1:])
+	if err != nil {
+		kingpin.Fatalf("parsing arguments: %s. Try --help", err)
+	}
+	fmt.Print(output)
+	os.Exit(exit)
+}
+
+func executeForArgs(args []string) (output string, exit int, err error) {
+	//
+	// command line flags
+	//
+	app := kingpin.New("osnadmin", "Orderer Service Node (OSN) administration")
+	orderer := app.Flag("orderer-address", "Admin endpoint of the OSN").Short('o').Required().String()
+	caFile := app.Flag("ca-file", "Path to file containing PEM-encoded TLS CA certificate(s) for the OSN").String()
+	clientCert := app.Flag("client-cert", "Path to file containing PEM-encoded X509 public key to use for mutual TLS communication with the OSN").String()
+	clientKey := app.Flag("client-key", "Path to file containing PEM-encoded private key to use for mutual TLS communication with the OSN").String()
+
+	channel := app.Command("channel", "Channel actions")
+
+	join := channel.Command("join", "Join an Ordering Service Node (OSN) to a channel. If the channel does not yet exist, it will be created.")
+	joinChannelID := join.Flag("channelID", "Channel ID; must match the channel ID of the config block, if specified").Short('c').String()
+	configBlockPath := join.Flag("config-block", "Path to the file containing an up-to-date config block for the channel").Short('b').Required().String()
+
+	list := channel.Command("list", "List channel information for an Ordering Service Node (OSN). If the channelID flag is set, more detailed information will be provided for that channel.")
+	listChannelID := list.Flag("channelID", "Channel ID").Short('c').String()
+
+	remove := channel.Command("remove", "Remove an Ordering Service Node (OSN) from a channel.")
+	removeChannelID := remove.Flag("channelID", "Channel ID").Short('c').Required().String()
+
+	fetch := channel.Command("fetch", "Fetch a block of a channel from an Ordering Service Node (OSN).")
+	fetchChannelID := fetch.Flag("channelID", "Channel ID").Short('c').Required().String()
+	fetchBlock := fetch.Arg("block", "Block to fetch: a block number, newest or config").Required().String()
+	fetchOutputFile := fetch.Arg("outputFile", "Path to the file to write the block to; defaults to <channelID>_<block>.block").String()
+
+	update := channel.Command("update", "Submit a signed config update of a channel to an Ordering Service Node (OSN).")
+	updateChannelID := update.Flag("channelID", "Channel ID; must match the channel ID of the config update, if specified").Short('c').String()
+	configUpdatePath := update.Flag("file", "Path to the file containing the signed config update envelope").Short('f').Required().String()
+
+	promote := channel.Command("promote", "Promote a non-voting consenter (learner) of a channel to a voting consenter, once it has caught up with the cluster.")
+	promoteChannelID := promote.Flag("channelID", "Channel ID").Short('c').Required().String()
+	promoteConsenter := promote.Flag("consenter", "Endpoint (host:port) of the learner, as listed in the consenters of the channel").Required().String()
+
+	command, err := app.Parse(args)
+	if err != nil {
+		return "", exitClientError, err
+	}
+
+	//
+	// flag validation
+	//
+	tlsConfig, err := tlsConfigFromFlags(*caFile, *clientCert, *clientKey)
+	if err != nil {
+		return "", exitClientError, err
+	}
+
+	osnURL := fmt.Sprintf("http://%s", *orderer)
+	if tlsConfig != nil {
+		osnURL = fmt.Sprintf("https://%s", *orderer)
+	}
+
+	var marshaledConfigBlock []byte
+	if *configBlockPath != "" {
+		marshaledConfigBlock, err = ioutil.ReadFile(*configBlockPath)
+		if err != nil {
+			return "", exitClientError, errors.WithMessage(err, "reading config block")
+		}
+
+		channelID, err := channelIDFromBlock(marshaledConfigBlock)
+		if err != nil {
+			return "", exitClientError, err
+		}
+		if *joinChannelID == "" {
+			*joinChannelID = channelID
+		}
+		if *joinChannelID != channelID {
+			return "", exitClientError, errors.Errorf("specified --channelID %s does not match channel ID %s in config block", *joinChannelID, channelID)
+		}
+	}
+
+	var marshaledConfigUpdate []byte
+	if *configUpdatePath != "" {
+		marshaledConfigUpdate, err = ioutil.ReadFile(*configUpdatePath)
+		if err != nil {
+			return "", exitClientError, errors.WithMessage(err, "reading config update")
+		}
+
+		channelID, err := channelIDFromEnvelope(marshaledConfigUpdate)
+		if err != nil {
+			return "", exitClientError, err
+		}
+		if *updateChannelID == "" {
+			*updateChannelID = channelID
+		}
+		if *updateChannelID != channelID {
+			return "", exitClientError, errors.Errorf("specified --channelID %s does not match channel ID %s in config update", *updateChannelID, channelID)
+		}
+	}
+
+	//
+	// call the underlying implementations
+	//
+	var resp *http.Response
+
+	switch command {
+	case join.FullCommand():
+		resp, err = osnadmin.Join(osnURL, *joinChannelID, marshaledConfigBlock, tlsConfig)
+	case list.FullCommand():
+		if *listChannelID != "" {
+			resp, err = osnadmin.ListSingleChannel(osnURL, *listChannelID, tlsConfig)
+			break
+		}
+		resp, err = osnadmin.ListAllChannels(osnURL, tlsConfig)
+	case remove.FullCommand():
+		resp, err = osnadmin.Remove(osnURL, *removeChannelID, tlsConfig)
+	case fetch.FullCommand():
+		resp, err = osnadmin.Fetch(osnURL, *fetchChannelID, *fetchBlock, tlsConfig)
+	case update.FullCommand():
+		resp, err = osnadmin.Update(osnURL, *updateChannelID, marshaledConfigUpdate, tlsConfig)
+	case promote.FullCommand():
+		resp, err = osnadmin.Promote(osnURL, *promoteChannelID, *promoteConsenter, tlsConfig)
+	}
+	if err != nil {
+		return errorOutput(err), exitClientError, nil
+	}
+
+	if command == fetch.FullCommand() && resp.StatusCode == http.StatusOK {
+		if *fetchOutputFile == "" {
+			*fetchOutputFile = fmt.Sprintf("%s_%s.block", *fetchChannelID, *fetchBlock)
+		}
+		output, err = blockOutput(resp, *fetchOutputFile)
+		if err != nil {
+			return errorOutput(err), exitClientError, nil
+		}
+		return output, exitOK, nil
+	}
+
+	output, err = responseOutput(resp)
+	if err != nil {
+		return errorOutput(err), exitClientError, nil
+	}
+
+	return output, exitCode(resp.StatusCode), nil
+}
+
+// tlsConfigFromFlags returns the TLS configuration of the client, or nil when no CA
+// file is given and the admin endpoint is reached over plain HTTP. The gmtls package
+// handshakes with SM2 certificates only in GM TLS, hence a CA that issues SM2
+// certificates switches the connection to GM TLS.
+func tlsConfigFromFlags(caFile, clientCert, clientKey string) (*tls.Config, error) {
+	if caFile == "" {
+		if clientCert != "" || clientKey != "" {
+			return nil, errors.New("--ca-file is required when a client certificate or key is specified")
+		}
+		return nil, nil
+	}
+
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading orderer CA certificate")
+	}
+	caCerts, err := parseCertificates(caPEM)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "parsing orderer CA certificate %s", caFile)
+	}
+	if len(caCerts) == 0 {
+		return nil, errors.Errorf("no PEM-encoded certificates found in %s", caFile)
+	}
+
+	caCertPool := gmx509.NewCertPool()
+	tlsConfig := &tls.Config{
+		RootCAs: caCertPool,
+	}
+	for _, caCert := range caCerts {
+		caCertPool.AddCert(caCert)
+		if isSM2PublicKey(caCert.PublicKey) {
+			tlsConfig.GMSupport = &tls.GMSupport{}
+		}
+	}
+
+	if clientCert == "" && clientKey == "" {
+		return tlsConfig, nil
+	}
+	if clientCert == "" || clientKey == "" {
+		return nil, errors.New("both --client-cert and --client-key are required for mutual TLS")
+	}
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, "loading client cert/key pair")
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+
+	return tlsConfig, nil
+}
+
+// isSM2PublicKey returns true if pk is an SM2 public key. gmx509 returns
+// SM2 keys as *ecdsa.PublicKey on the SM2 curve.
+func isSM2PublicKey(pk interface{}) bool {
+	switch pk := pk.(type) {
+	case *sm2.PublicKey:
+		return true
+	case *ecdsa.PublicKey:
+		return pk.Curve == sm2.P256Sm2()
+	default:
+		return false
+	}
+}
+
+func parseCertificates(certsPEM []byte) ([]*gmx509.Certificate, error) {
+	var certs []*gmx509.Certificate
+	for len(certsPEM) > 0 {
+		var block *pem.Block
+		block, certsPEM = pem.Decode(certsPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := gmx509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+func channelIDFromBlock(marshaledConfigBlock []byte) (string, error) {
+	block := &common.Block{}
+	if err := proto.Unmarshal(marshaledConfigBlock, block); err != nil {
+		return "", errors.WithMessage(err, "unmarshaling config block")
+	}
+	channelID, err := protoutil.GetChannelIDFromBlock(block)
+	if err != nil {
+		return "", errors.WithMessage(err, "getting channel ID from config block")
+	}
+	return channelID, nil
+}
+
+func channelIDFromEnvelope(marshaledEnvelope []byte) (string, error) {
+	env, err := protoutil.UnmarshalEnvelope(marshaledEnvelope)
+	if err != nil {
+		return "", errors.WithMessage(err, "unmarshaling config update envelope")
+	}
+	chdr, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		return "", errors.WithMessage(err, "getting channel ID from config update envelope")
+	}
+	return chdr.ChannelId, nil
+}
+
+// exitCode maps the status of a channel participation API response to an exit code.
+func exitCode(statusCode int) int {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return exitOK
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusNotAcceptable:
+		return exitBadRequest
+	case statusCode == http.StatusForbidden:
+		return exitForbidden
+	case statusCode == http.StatusNotFound:
+		return exitNotFound
+	case statusCode == http.StatusMethodNotAllowed:
+		return exitMethodNotAllowed
+	case statusCode == http.StatusServiceUnavailable:
+		return exitUnavailable
+	default:
+		return exitServerError
+	}
+}
+
+func responseOutput(resp *http.Response) (string, error) {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.WithMessage(err, "reading response body")
+	}
+
+	buffer := bytes.NewBufferString(fmt.Sprintf("Status: %d\n", resp.StatusCode))
+	if len(body) == 0 {
+		return buffer.String(), nil
+	}
+
+	body = bytes.TrimSpace(body)
+	if err := json.Indent(buffer, body, "", "\t"); err != nil {
+		// not a JSON document, print it as it is
+		buffer.Write(body)
+	}
+	buffer.WriteString("\n")
+
+	return buffer.String(), nil
+}
+
+// blockOutput writes the marshaled block of a fetch response to the output file.
+func blockOutput(resp *http.Response, outputFile string) (string, error) {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.WithMessage(err, "reading response body")
+	}
+
+	if err := ioutil.WriteFile(outputFile, body, 0644); err != nil {
+		return "", errors.WithMessage(err, "writing block")
+	}
+
+	return fmt.Sprintf("Status: %d\nBlock written to %s\n", resp.StatusCode, outputFile), nil
+}
+
+func errorOutput(err error) string {
+	return fmt.Sprintf("Error: %s\n", err)
+}
@@ -0,0 +1,506 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/protoutil"
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	address string
+	server  *http.Server
+}
+
+func (ts *testServer) stop() {
+	ts.server.Close()
+}
+
+func startServer(t *testing.T, handler http.Handler, tlsConfig *tls.Config) *testServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	server := &http.Server{Handler: handler}
+	go server.Serve(listener)
+
+	return &testServer{address: listener.Addr().String(), server: server}
+}
+
+type testCrypto struct {
+	dir    string
+	ca     tlsgen.CA
+	server *tlsgen.CertKeyPair
+	caFile string
+	client []string
+}
+
+func newTestCrypto(t *testing.T) *testCrypto {
+	dir, err := ioutil.TempDir("", "osnadmin")
+	require.NoError(t, err)
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	server, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	client, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	writeFile := func(name string, content []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, content, 0600))
+		return path
+	}
+
+	return &testCrypto{
+		dir:    dir,
+		ca:     ca,
+		server: server,
+		caFile: writeFile("ca.pem", ca.CertBytes()),
+		client: []string{writeFile("client.pem", client.Cert), writeFile("client.key", client.Key)},
+	}
+}
+
+func (tc *testCrypto) cleanup() {
+	os.RemoveAll(tc.dir)
+}
+
+// serverTLSConfig returns a GM TLS configuration that signs with the server certificate
+// and encrypts with a second key pair, as the operations endpoint of an orderer does.
+func (tc *testCrypto) serverTLSConfig(t *testing.T) *tls.Config {
+	cert, err := tls.X509KeyPair(tc.server.Cert, tc.server.Key)
+	require.NoError(t, err)
+	encPair, err := tc.ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	encCert, err := tls.X509KeyPair(encPair.Cert, encPair.Key)
+	require.NoError(t, err)
+	clientCAs := gmx509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(tc.ca.CertBytes()))
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert, encCert},
+		GMSupport:    &tls.GMSupport{},
+		CipherSuites: comm.DefaultTLSCipherSuites,
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+}
+
+func configUpdateFile(t *testing.T, dir, channelID string) string {
+	env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, nil, &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: protoutil.MarshalOrPanic(&cb.ConfigUpdate{ChannelId: channelID}),
+	}, 0, 0)
+	require.NoError(t, err)
+
+	updatePath := filepath.Join(dir, channelID+".pb")
+	require.NoError(t, ioutil.WriteFile(updatePath, protoutil.MarshalOrPanic(env), 0600))
+	return updatePath
+}
+
+func configBlockFile(t *testing.T, dir, channelID string) string {
+	block := &cb.Block{
+		Data: &cb.BlockData{
+			Data: [][]byte{
+				protoutil.MarshalOrPanic(&cb.Envelope{
+					Payload: protoutil.MarshalOrPanic(&cb.Payload{
+						Data: protoutil.MarshalOrPanic(&cb.ConfigEnvelope{
+							Config: &cb.Config{
+								ChannelGroup: &cb.ConfigGroup{
+									Groups: map[string]*cb.ConfigGroup{
+										"Application": {},
+									},
+									Values: map[string]*cb.ConfigValue{
+										"HashingAlgorithm": {
+											Value: protoutil.MarshalOrPanic(&cb.HashingAlgorithm{
+												Name: bccsp.SHA256,
+											}),
+										},
+										"BlockDataHashingStructure": {
+											Value: protoutil.MarshalOrPanic(&cb.BlockDataHashingStructure{
+												Width: math.MaxUint32,
+											}),
+										},
+										"OrdererAddresses": {
+											Value: protoutil.MarshalOrPanic(&cb.OrdererAddresses{
+												Addresses: []string{"localhost"},
+											}),
+										},
+									},
+								},
+							},
+						}),
+						Header: &cb.Header{
+							ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+								Type:      int32(cb.HeaderType_CONFIG),
+								ChannelId: channelID,
+							}),
+						},
+					}),
+				}),
+			},
+		},
+	}
+
+	path := filepath.Join(dir, channelID+".block")
+	require.NoError(t, ioutil.WriteFile(path, protoutil.MarshalOrPanic(block), 0600))
+	return path
+}
+
+func TestOSNAdmin(t *testing.T) {
+	tc := newTestCrypto(t)
+	defer tc.cleanup()
+
+	fakeManager := &mocks.ChannelManagement{}
+	handler := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{Enabled: true}, fakeManager)
+
+	ts := startServer(t, handler, tc.serverTLSConfig(t))
+	defer ts.stop()
+
+	tlsArgs := func(args ...string) []string {
+		return append([]string{
+			"--orderer-address", ts.address,
+			"--ca-file", tc.caFile,
+			"--client-cert", tc.client[0],
+			"--client-key", tc.client[1],
+		}, args...)
+	}
+
+	t.Run("list all channels", func(t *testing.T) {
+		fakeManager.ChannelListReturns(types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "mychannel"}},
+		})
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "list"))
+		require.NoError(t, err)
+		require.Equal(t, exitOK, exit)
+		require.Equal(t, "Status: 200\n"+
+			"{\n"+
+			"\t\"systemChannel\": null,\n"+
+			"\t\"channels\": [\n"+
+			"\t\t{\n"+
+			"\t\t\t\"name\": \"mychannel\",\n"+
+			"\t\t\t\"url\": \"/participation/v1/channels/mychannel\"\n"+
+			"\t\t}\n"+
+			"\t]\n"+
+			"}\n", output)
+	})
+
+	t.Run("list a single channel", func(t *testing.T) {
+		fakeManager.ChannelInfoReturns(types.ChannelInfo{
+			Name:            "mychannel",
+			ClusterRelation: types.ClusterRelationMember,
+			Status:          types.StatusActive,
+			Height:          3,
+		}, nil)
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "list", "--channelID", "mychannel"))
+		require.NoError(t, err)
+		require.Equal(t, exitOK, exit)
+		require.Contains(t, output, "Status: 200\n")
+		require.Contains(t, output, "\t\"clusterRelation\": \"member\",\n")
+		require.Contains(t, output, "\t\"height\": 3\n")
+	})
+
+	t.Run("list a missing channel", func(t *testing.T) {
+		fakeManager.ChannelInfoReturns(types.ChannelInfo{}, types.ErrChannelNotExist)
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "list", "--channelID", "missing"))
+		require.NoError(t, err)
+		require.Equal(t, exitNotFound, exit)
+		require.Equal(t, "Status: 404\n{\n\t\"error\": \"channel does not exist\"\n}\n", output)
+	})
+
+	t.Run("join a channel", func(t *testing.T) {
+		fakeManager.JoinChannelReturns(types.ChannelInfo{
+			Name:            "mychannel",
+			ClusterRelation: types.ClusterRelationMember,
+			Status:          types.StatusOnBoarding,
+		}, nil)
+		blockPath := configBlockFile(t, tc.dir, "mychannel")
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "join", "--config-block", blockPath))
+		require.NoError(t, err)
+		require.Equal(t, exitOK, exit)
+		require.Contains(t, output, "Status: 201\n")
+		require.Contains(t, output, "\t\"status\": \"onboarding\",\n")
+
+		channelID, block, isAppChannel := fakeManager.JoinChannelArgsForCall(fakeManager.JoinChannelCallCount() - 1)
+		require.Equal(t, "mychannel", channelID)
+		require.Equal(t, uint64(0), block.Header.GetNumber())
+		require.True(t, isAppChannel)
+	})
+
+	t.Run("join an existing channel", func(t *testing.T) {
+		fakeManager.JoinChannelReturns(types.ChannelInfo{}, types.ErrChannelAlreadyExists)
+		blockPath := configBlockFile(t, tc.dir, "mychannel")
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "join", "--channelID", "mychannel", "--config-block", blockPath))
+		require.NoError(t, err)
+		require.Equal(t, exitMethodNotAllowed, exit)
+		require.Equal(t, "Status: 405\n{\n\t\"error\": \"cannot join: channel already exists\"\n}\n", output)
+	})
+
+	t.Run("join with mismatched channel ID", func(t *testing.T) {
+		blockPath := configBlockFile(t, tc.dir, "mychannel")
+
+		_, _, err := executeForArgs(tlsArgs("channel", "join", "--channelID", "other", "--config-block", blockPath))
+		require.EqualError(t, err, "specified --channelID other does not match channel ID mychannel in config block")
+	})
+
+	t.Run("join with an invalid config block", func(t *testing.T) {
+		blockPath := filepath.Join(tc.dir, "garbage.block")
+		require.NoError(t, ioutil.WriteFile(blockPath, []byte{1, 2, 3, 4}, 0600))
+
+		_, _, err := executeForArgs(tlsArgs("channel", "join", "--config-block", blockPath))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshaling config block")
+	})
+
+	t.Run("join when application channels exist", func(t *testing.T) {
+		fakeManager.JoinChannelReturns(types.ChannelInfo{}, types.ErrAppChannelsAlreadyExists)
+		blockPath := configBlockFile(t, tc.dir, "mychannel")
+
+		_, exit, err := executeForArgs(tlsArgs("channel", "join", "--config-block", blockPath))
+		require.NoError(t, err)
+		require.Equal(t, exitForbidden, exit)
+	})
+
+	t.Run("remove a channel", func(t *testing.T) {
+		fakeManager.RemoveChannelReturns(nil)
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "remove", "--channelID", "mychannel"))
+		require.NoError(t, err)
+		require.Equal(t, exitOK, exit)
+		require.Equal(t, "Status: 204\n", output)
+	})
+
+	t.Run("remove a missing channel", func(t *testing.T) {
+		fakeManager.RemoveChannelReturns(types.ErrChannelNotExist)
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "remove", "--channelID", "missing"))
+		require.NoError(t, err)
+		require.Equal(t, exitNotFound, exit)
+		require.Equal(t, "Status: 404\n{\n\t\"error\": \"cannot remove: channel does not exist\"\n}\n", output)
+	})
+
+	t.Run("fetch a block", func(t *testing.T) {
+		block := protoutil.NewBlock(5, []byte("previous"))
+		fakeManager.ChannelBlockReturns(block, nil)
+		outputFile := filepath.Join(tc.dir, "fetched.block")
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "fetch", "--channelID", "mychannel", "5", outputFile))
+		require.NoError(t, err)
+		require.Equal(t, exitOK, exit)
+		require.Equal(t, "Status: 200\nBlock written to "+outputFile+"\n", output)
+
+		channelID, number := fakeManager.ChannelBlockArgsForCall(fakeManager.ChannelBlockCallCount() - 1)
+		require.Equal(t, "mychannel", channelID)
+		require.Equal(t, uint64(5), number)
+		blockBytes, err := ioutil.ReadFile(outputFile)
+		require.NoError(t, err)
+		require.Equal(t, protoutil.MarshalOrPanic(block), blockBytes)
+	})
+
+	t.Run("fetch a missing block", func(t *testing.T) {
+		fakeManager.ChannelBlockReturns(nil, types.ErrBlockNotExist)
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "fetch", "--channelID", "mychannel", "7"))
+		require.NoError(t, err)
+		require.Equal(t, exitNotFound, exit)
+		require.Equal(t, "Status: 404\n{\n\t\"error\": \"cannot fetch block: block does not exist\"\n}\n", output)
+	})
+
+	t.Run("submit a config update", func(t *testing.T) {
+		fakeManager.SubmitConfigUpdateReturns(nil)
+		updatePath := configUpdateFile(t, tc.dir, "mychannel")
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "update", "--file", updatePath))
+		require.NoError(t, err)
+		require.Equal(t, exitOK, exit)
+		require.Equal(t, "Status: 202\n", output)
+
+		channelID, env := fakeManager.SubmitConfigUpdateArgsForCall(fakeManager.SubmitConfigUpdateCallCount() - 1)
+		require.Equal(t, "mychannel", channelID)
+		chdr, err := protoutil.ChannelHeader(env)
+		require.NoError(t, err)
+		require.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+	})
+
+	t.Run("submit a forbidden config update", func(t *testing.T) {
+		fakeManager.SubmitConfigUpdateReturns(errors.WithMessage(msgprocessor.ErrPermissionDenied, "config update rejected"))
+		updatePath := configUpdateFile(t, tc.dir, "mychannel")
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "update", "--file", updatePath))
+		require.NoError(t, err)
+		require.Equal(t, exitForbidden, exit)
+		require.Equal(t, "Status: 403\n{\n\t\"error\": \"cannot update config: config update rejected: permission denied\"\n}\n", output)
+	})
+
+	t.Run("submit a config update with mismatched channel ID", func(t *testing.T) {
+		updatePath := configUpdateFile(t, tc.dir, "mychannel")
+
+		_, _, err := executeForArgs(tlsArgs("channel", "update", "--channelID", "other", "--file", updatePath))
+		require.EqualError(t, err, "specified --channelID other does not match channel ID mychannel in config update")
+	})
+
+	t.Run("promote a learner", func(t *testing.T) {
+		fakeManager.PromoteLearnerReturns(nil)
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "promote", "--channelID", "mychannel", "--consenter", "orderer4.example.com:7050"))
+		require.NoError(t, err)
+		require.Equal(t, exitOK, exit)
+		require.Equal(t, "Status: 202\n", output)
+
+		channelID, consenter := fakeManager.PromoteLearnerArgsForCall(fakeManager.PromoteLearnerCallCount() - 1)
+		require.Equal(t, "mychannel", channelID)
+		require.Equal(t, "orderer4.example.com:7050", consenter)
+	})
+
+	t.Run("promote a learner that has not caught up", func(t *testing.T) {
+		fakeManager.PromoteLearnerReturns(errors.WithMessage(types.ErrLearnerNotCaughtUp, "learner 4 (orderer4.example.com:7050) lags behind the leader"))
+
+		output, exit, err := executeForArgs(tlsArgs("channel", "promote", "--channelID", "mychannel", "--consenter", "orderer4.example.com:7050"))
+		require.NoError(t, err)
+		require.Equal(t, exitUnavailable, exit)
+		require.Equal(t, "Status: 503\n{\n\t\"error\": \"cannot promote: learner 4 (orderer4.example.com:7050) lags behind the leader: learner has not caught up\"\n}\n", output)
+	})
+
+	t.Run("without a client certificate", func(t *testing.T) {
+		output, exit, err := executeForArgs([]string{
+			"--orderer-address", ts.address,
+			"--ca-file", tc.caFile,
+			"channel", "list",
+		})
+		require.NoError(t, err)
+		require.Equal(t, exitClientError, exit)
+		require.Contains(t, output, "Error: ")
+	})
+}
+
+func TestOSNAdminWithoutTLS(t *testing.T) {
+	fakeManager := &mocks.ChannelManagement{}
+	handler := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{Enabled: false}, fakeManager)
+
+	ts := startServer(t, handler, nil)
+	defer ts.stop()
+
+	output, exit, err := executeForArgs([]string{"--orderer-address", ts.address, "channel", "list"})
+	require.NoError(t, err)
+	require.Equal(t, exitUnavailable, exit)
+	require.Equal(t, "Status: 503\n{\n\t\"error\": \"channel participation API is disabled\"\n}\n", output)
+
+	ts.stop()
+	output, exit, err = executeForArgs([]string{"--orderer-address", ts.address, "channel", "list"})
+	require.NoError(t, err)
+	require.Equal(t, exitClientError, exit)
+	require.Contains(t, output, "Error: ")
+}
+
+func TestFlagErrors(t *testing.T) {
+	tc := newTestCrypto(t)
+	defer tc.cleanup()
+
+	garbagePath := filepath.Join(tc.dir, "garbage.pb")
+	require.NoError(t, ioutil.WriteFile(garbagePath, []byte{1, 2, 3, 4}, 0600))
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "missing orderer address",
+			args:        []string{"channel", "list"},
+			expectedErr: "required flag --orderer-address not provided",
+		},
+		{
+			name:        "missing channel ID",
+			args:        []string{"-o", "127.0.0.1:9443", "channel", "remove"},
+			expectedErr: "required flag --channelID not provided",
+		},
+		{
+			name:        "client certificate without CA",
+			args:        []string{"-o", "127.0.0.1:9443", "--client-cert", tc.client[0], "--client-key", tc.client[1], "channel", "list"},
+			expectedErr: "--ca-file is required when a client certificate or key is specified",
+		},
+		{
+			name:        "missing CA file",
+			args:        []string{"-o", "127.0.0.1:9443", "--ca-file", filepath.Join(tc.dir, "missing.pem"), "channel", "list"},
+			expectedErr: "reading orderer CA certificate: open " + filepath.Join(tc.dir, "missing.pem") + ": no such file or directory",
+		},
+		{
+			name:        "CA file without certificates",
+			args:        []string{"-o", "127.0.0.1:9443", "--ca-file", tc.client[1], "channel", "list"},
+			expectedErr: "no PEM-encoded certificates found in " + tc.client[1],
+		},
+		{
+			name:        "client certificate without key",
+			args:        []string{"-o", "127.0.0.1:9443", "--ca-file", tc.caFile, "--client-cert", tc.client[0], "channel", "list"},
+			expectedErr: "both --client-cert and --client-key are required for mutual TLS",
+		},
+		{
+			name:        "mismatched client key",
+			args:        []string{"-o", "127.0.0.1:9443", "--ca-file", tc.caFile, "--client-cert", tc.client[0], "--client-key", tc.caFile, "channel", "list"},
+			expectedErr: "loading client cert/key pair: tls: found a certificate rather than a key in the PEM for the private key",
+		},
+		{
+			name:        "missing config block",
+			args:        []string{"-o", "127.0.0.1:9443", "channel", "join", "--config-block", filepath.Join(tc.dir, "missing.block")},
+			expectedErr: "reading config block: open " + filepath.Join(tc.dir, "missing.block") + ": no such file or directory",
+		},
+		{
+			name:        "missing block to fetch",
+			args:        []string{"-o", "127.0.0.1:9443", "channel", "fetch", "--channelID", "mychannel"},
+			expectedErr: "required argument 'block' not provided",
+		},
+		{
+			name:        "missing consenter to promote",
+			args:        []string{"-o", "127.0.0.1:9443", "channel", "promote", "--channelID", "mychannel"},
+			expectedErr: "required flag --consenter not provided",
+		},
+		{
+			name:        "missing config update",
+			args:        []string{"-o", "127.0.0.1:9443", "channel", "update", "--file", filepath.Join(tc.dir, "missing.pb")},
+			expectedErr: "reading config update: open " + filepath.Join(tc.dir, "missing.pb") + ": no such file or directory",
+		},
+		{
+			name:        "invalid config update",
+			args:        []string{"-o", "127.0.0.1:9443", "channel", "update", "--file", garbagePath},
+			expectedErr: "unmarshaling config update envelope: error unmarshaling Envelope: proto: common.Envelope: illegal tag 0 (wire type 1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, exit, err := executeForArgs(tt.args)
+			require.EqualError(t, err, tt.expectedErr)
+			require.Equal(t, exitClientError, exit)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	"github.com/pkg/errors"
+)
+
+// ConsensusType is the value of ConsensusType.Type in the orderer config of the channels
+// that are ordered by the BFT consensus
+const ConsensusType = "BFT"
+
+// DefaultOptions returns the options that apply to the options which are not set
+// in the config metadata of a channel
+func DefaultOptions() *Options {
+	return &Options{
+		RequestForwardTimeout:    "2s",
+		RequestComplainTimeout:   "20s",
+		RequestAutoRemoveTimeout: "3m",
+		ViewChangeResendInterval: "5s",
+		ViewChangeTimeout:        "20s",
+		LeaderHeartbeatTimeout:   "1m",
+		LeaderHeartbeatCount:     10,
+		DecisionsPerLeader:       3,
+		RequestPoolSize:          400,
+	}
+}
+
+// OptionsWithDefaults returns a copy of the given options in which the options that are not
+// set assume their default value
+func OptionsWithDefaults(options *Options) *Options {
+	o := DefaultOptions()
+	if options == nil {
+		return o
+	}
+	if options.RequestForwardTimeout != "" {
+		o.RequestForwardTimeout = options.RequestForwardTimeout
+	}
+	if options.RequestComplainTimeout != "" {
+		o.RequestComplainTimeout = options.RequestComplainTimeout
+	}
+	if options.RequestAutoRemoveTimeout != "" {
+		o.RequestAutoRemoveTimeout = options.RequestAutoRemoveTimeout
+	}
+	if options.ViewChangeResendInterval != "" {
+		o.ViewChangeResendInterval = options.ViewChangeResendInterval
+	}
+	if options.ViewChangeTimeout != "" {
+		o.ViewChangeTimeout = options.ViewChangeTimeout
+	}
+	if options.LeaderHeartbeatTimeout != "" {
+		o.LeaderHeartbeatTimeout = options.LeaderHeartbeatTimeout
+	}
+	if options.LeaderHeartbeatCount != 0 {
+		o.LeaderHeartbeatCount = options.LeaderHeartbeatCount
+	}
+	if options.DecisionsPerLeader != 0 {
+		o.DecisionsPerLeader = options.DecisionsPerLeader
+	}
+	if options.RequestPoolSize != 0 {
+		o.RequestPoolSize = options.RequestPoolSize
+	}
+	return o
+}
+
+// MetadataFromOrdererConfig returns the BFT config metadata of the orderer config of a channel,
+// or nil if the channel is not ordered by the BFT consensus
+func MetadataFromOrdererConfig(oc channelconfig.Orderer) (*ConfigMetadata, error) {
+	if oc == nil || oc.ConsensusType() != ConsensusType {
+		return nil, nil
+	}
+	m := &ConfigMetadata{}
+	if err := proto.Unmarshal(oc.ConsensusMetadata(), m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal BFT config metadata")
+	}
+	return m, nil
+}
+
+// ValidateConfigMetadata checks that the BFT config metadata of a channel is well formed
+func ValidateConfigMetadata(metadata *ConfigMetadata) error {
+	if metadata == nil {
+		return errors.New("nil BFT config metadata")
+	}
+	if len(metadata.Consenters) == 0 {
+		return errors.New("empty consenter set")
+	}
+
+	ids := make(map[uint64]struct{})
+	endpoints := make(map[string]struct{})
+	certs := make(map[string]struct{})
+	for _, consenter := range metadata.Consenters {
+		if consenter == nil {
+			return errors.New("metadata has nil consenter")
+		}
+		if consenter.Id == 0 {
+			return errors.Errorf("consenter %s:%d has a zero id", consenter.Host, consenter.Port)
+		}
+		if _, exists := ids[consenter.Id]; exists {
+			return errors.Errorf("duplicate consenter id: %d", consenter.Id)
+		}
+		ids[consenter.Id] = struct{}{}
+
+		endpoint := fmt.Sprintf("%s:%d", consenter.Host, consenter.Port)
+		if _, exists := endpoints[endpoint]; exists {
+			return errors.Errorf("duplicate consenter endpoint: %s", endpoint)
+		}
+		endpoints[endpoint] = struct{}{}
+
+		if consenter.MspId == "" {
+			return errors.Errorf("consenter %d has an empty MSP ID", consenter.Id)
+		}
+		for _, cert := range []struct {
+			role string
+			pem  []byte
+		}{
+			{"server TLS", consenter.ServerTlsCert},
+			{"client TLS", consenter.ClientTlsCert},
+			{"identity", consenter.Identity},
+		} {
+			if err := validateCert(cert.pem, cert.role); err != nil {
+				return errors.WithMessagef(err, "consenter %d", consenter.Id)
+			}
+		}
+		for _, cert := range [][]byte{consenter.ServerTlsCert, consenter.ClientTlsCert} {
+			if _, exists := certs[string(cert)]; exists {
+				return errors.Errorf("duplicate consenter TLS certificate: %s", string(cert))
+			}
+			certs[string(cert)] = struct{}{}
+		}
+	}
+
+	options := OptionsWithDefaults(metadata.Options)
+	for _, timeout := range []struct {
+		name  string
+		value string
+	}{
+		{"RequestForwardTimeout", options.RequestForwardTimeout},
+		{"RequestComplainTimeout", options.RequestComplainTimeout},
+		{"RequestAutoRemoveTimeout", options.RequestAutoRemoveTimeout},
+		{"ViewChangeResendInterval", options.ViewChangeResendInterval},
+		{"ViewChangeTimeout", options.ViewChangeTimeout},
+		{"LeaderHeartbeatTimeout", options.LeaderHeartbeatTimeout},
+	} {
+		d, err := time.ParseDuration(timeout.value)
+		if err != nil {
+			return errors.Errorf("failed to parse %s (%s) to time duration: %s", timeout.name, timeout.value, err)
+		}
+		if d <= 0 {
+			return errors.Errorf("%s (%s) must be positive", timeout.name, timeout.value)
+		}
+	}
+
+	return nil
+}
+
+func validateCert(pemData []byte, certRole string) error {
+	bl, _ := pem.Decode(pemData)
+	if bl == nil {
+		return errors.Errorf("%s certificate is not PEM encoded: %s", certRole, string(pemData))
+	}
+	if _, err := gmx509.ParseCertificate(bl.Bytes); err != nil {
+		return errors.Errorf("%s certificate has invalid ASN1 structure, %v: %s", certRole, err, string(pemData))
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/stretchr/testify/require"
+)
+
+type ordererConfig struct {
+	channelconfig.Orderer
+	consensusType     string
+	consensusMetadata []byte
+}
+
+func (oc *ordererConfig) ConsensusType() string {
+	return oc.consensusType
+}
+
+func (oc *ordererConfig) ConsensusMetadata() []byte {
+	return oc.consensusMetadata
+}
+
+func newConsenters(t *testing.T, n int) []*Consenter {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+
+	var consenters []*Consenter
+	for i := 1; i <= n; i++ {
+		host := fmt.Sprintf("orderer%d", i)
+		server, err := ca.NewServerCertKeyPair(host)
+		require.NoError(t, err)
+		client, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		identity, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		consenters = append(consenters, &Consenter{
+			Host:          host,
+			Port:          7050,
+			ServerTlsCert: server.Cert,
+			ClientTlsCert: client.Cert,
+			Id:            uint64(i),
+			MspId:         "OrdererMSP",
+			Identity:      identity.Cert,
+		})
+	}
+	return consenters
+}
+
+func TestOptionsWithDefaults(t *testing.T) {
+	require.Equal(t, DefaultOptions(), OptionsWithDefaults(nil))
+
+	options := OptionsWithDefaults(&Options{
+		ViewChangeTimeout:  "1m",
+		DecisionsPerLeader: 1,
+	})
+	expected := DefaultOptions()
+	expected.ViewChangeTimeout = "1m"
+	expected.DecisionsPerLeader = 1
+	require.Equal(t, expected, options)
+}
+
+func TestMetadataFromOrdererConfig(t *testing.T) {
+	metadata := &ConfigMetadata{Consenters: newConsenters(t, 1)}
+
+	oc := &ordererConfig{consensusType: "etcdraft"}
+	m, err := MetadataFromOrdererConfig(oc)
+	require.NoError(t, err)
+	require.Nil(t, m)
+
+	oc.consensusType = ConsensusType
+	oc.consensusMetadata = protoMarshal(t, metadata)
+	m, err = MetadataFromOrdererConfig(oc)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(metadata, m))
+
+	oc.consensusMetadata = []byte{1, 2, 3}
+	_, err = MetadataFromOrdererConfig(oc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to unmarshal BFT config metadata")
+}
+
+func TestValidateConfigMetadata(t *testing.T) {
+	for _, testCase := range []struct {
+		name        string
+		mutate      func(m *ConfigMetadata)
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(m *ConfigMetadata) {},
+		},
+		{
+			name:        "no consenters",
+			mutate:      func(m *ConfigMetadata) { m.Consenters = nil },
+			expectedErr: "empty consenter set",
+		},
+		{
+			name:        "zero id",
+			mutate:      func(m *ConfigMetadata) { m.Consenters[1].Id = 0 },
+			expectedErr: "consenter orderer2:7050 has a zero id",
+		},
+		{
+			name:        "duplicate id",
+			mutate:      func(m *ConfigMetadata) { m.Consenters[1].Id = 1 },
+			expectedErr: "duplicate consenter id: 1",
+		},
+		{
+			name:        "duplicate endpoint",
+			mutate:      func(m *ConfigMetadata) { m.Consenters[1].Host = "orderer1" },
+			expectedErr: "duplicate consenter endpoint: orderer1:7050",
+		},
+		{
+			name:        "empty MSP ID",
+			mutate:      func(m *ConfigMetadata) { m.Consenters[2].MspId = "" },
+			expectedErr: "consenter 3 has an empty MSP ID",
+		},
+		{
+			name:        "bad identity",
+			mutate:      func(m *ConfigMetadata) { m.Consenters[0].Identity = []byte("identity") },
+			expectedErr: "consenter 1: identity certificate is not PEM encoded: identity",
+		},
+		{
+			name:        "bad timeout",
+			mutate:      func(m *ConfigMetadata) { m.Options = &Options{ViewChangeTimeout: "10"} },
+			expectedErr: "failed to parse ViewChangeTimeout (10) to time duration: time: missing unit in duration",
+		},
+		{
+			name:        "negative timeout",
+			mutate:      func(m *ConfigMetadata) { m.Options = &Options{LeaderHeartbeatTimeout: "-1s"} },
+			expectedErr: "LeaderHeartbeatTimeout (-1s) must be positive",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			metadata := &ConfigMetadata{Consenters: newConsenters(t, 4)}
+			testCase.mutate(metadata)
+			err := ValidateConfigMetadata(metadata)
+			if testCase.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), testCase.expectedErr)
+		})
+	}
+
+	metadata := &ConfigMetadata{Consenters: newConsenters(t, 2)}
+	metadata.Consenters[1].ClientTlsCert = metadata.Consenters[0].ClientTlsCert
+	require.EqualError(t, ValidateConfigMetadata(metadata), "duplicate consenter TLS certificate: "+string(metadata.Consenters[0].ClientTlsCert))
+	require.EqualError(t, ValidateConfigMetadata(nil), "nil BFT config metadata")
+}
+
+func protoMarshal(t *testing.T, m proto.Message) []byte {
+	bytes, err := proto.Marshal(m)
+	require.NoError(t, err)
+	return bytes
+}
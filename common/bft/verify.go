@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"encoding/pem"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// MaxFaults returns the number of faulty consenters that a cluster of n consenters tolerates
+func MaxFaults(n int) int {
+	return (n - 1) / 3
+}
+
+// Quorum returns the number of consenters of a cluster of n consenters whose agreement is required,
+// such that any two quorums intersect in more consenters than the cluster tolerates to be faulty
+func Quorum(n int) int {
+	f := MaxFaults(n)
+	return int(math.Ceil(float64(n+f+1) / 2))
+}
+
+// ConsenterOfIdentity returns the consenter whose MSP ID and certificate are those of the given
+// serialized identity, or nil if the identity is not the identity of any of the consenters
+func ConsenterOfIdentity(consenters []*Consenter, serializedIdentity []byte) *Consenter {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		return nil
+	}
+	certDER := pemToDER(sID.IdBytes)
+	if certDER == nil {
+		return nil
+	}
+	for _, consenter := range consenters {
+		if consenter.MspId == sID.Mspid && bytes.Equal(pemToDER(consenter.Identity), certDER) {
+			return consenter
+		}
+	}
+	return nil
+}
+
+// VerifyBlockSignatures checks that the signatures of the block include valid signatures of a quorum of the
+// given consenters. The function verify is invoked to verify the signature of each of the consenters, and the
+// signatures that are not valid are not counted towards the quorum
+func VerifyBlockSignatures(block *cb.Block, consenters []*Consenter, verify func(*protoutil.SignedData) error) error {
+	if block == nil || block.Header == nil {
+		return errors.New("nil block or nil header")
+	}
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_SIGNATURES) {
+		return errors.Errorf("block [%d] has no signatures", block.Header.Number)
+	}
+	md := &cb.Metadata{}
+	if err := proto.Unmarshal(block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES], md); err != nil {
+		return errors.Wrapf(err, "error unmarshalling the signatures of block [%d]", block.Header.Number)
+	}
+
+	signers := make(map[uint64]struct{})
+	var invalidSignatureErr error
+	headerBytes := protoutil.BlockHeaderBytes(block.Header)
+	for _, metadataSignature := range md.Signatures {
+		signatureHeader, err := protoutil.UnmarshalSignatureHeader(metadataSignature.SignatureHeader)
+		if err != nil {
+			return errors.Wrapf(err, "error unmarshalling a signature header of block [%d]", block.Header.Number)
+		}
+		consenter := ConsenterOfIdentity(consenters, signatureHeader.Creator)
+		if consenter == nil {
+			continue
+		}
+		if _, exists := signers[consenter.Id]; exists {
+			continue
+		}
+		err = verify(&protoutil.SignedData{
+			Identity:  signatureHeader.Creator,
+			Data:      util.ConcatenateBytes(md.Value, metadataSignature.SignatureHeader, headerBytes),
+			Signature: metadataSignature.Signature,
+		})
+		if err != nil {
+			invalidSignatureErr = errors.WithMessagef(err, "invalid signature of consenter %d", consenter.Id)
+			continue
+		}
+		signers[consenter.Id] = struct{}{}
+	}
+
+	if quorum := Quorum(len(consenters)); len(signers) < quorum {
+		if invalidSignatureErr != nil {
+			return errors.WithMessagef(invalidSignatureErr, "block [%d] is signed by %d of the %d consenters of the channel, while a quorum of %d is required",
+				block.Header.Number, len(signers), len(consenters), quorum)
+		}
+		return errors.Errorf("block [%d] is signed by %d of the %d consenters of the channel, while a quorum of %d is required",
+			block.Header.Number, len(signers), len(consenters), quorum)
+	}
+	return nil
+}
+
+func pemToDER(pemBytes []byte) []byte {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil
+	}
+	return bl.Bytes
+}
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorum(t *testing.T) {
+	for _, testCase := range []struct {
+		n, f, q int
+	}{
+		{n: 1, f: 0, q: 1},
+		{n: 2, f: 0, q: 2},
+		{n: 3, f: 0, q: 2},
+		{n: 4, f: 1, q: 3},
+		{n: 5, f: 1, q: 4},
+		{n: 6, f: 1, q: 4},
+		{n: 7, f: 2, q: 5},
+		{n: 10, f: 3, q: 7},
+	} {
+		require.Equal(t, testCase.f, MaxFaults(testCase.n), "faults of %d consenters", testCase.n)
+		require.Equal(t, testCase.q, Quorum(testCase.n), "quorum of %d consenters", testCase.n)
+	}
+}
+
+func TestConsenterOfIdentity(t *testing.T) {
+	consenters := newConsenters(t, 3)
+
+	sID := protoMarshal(t, &msp.SerializedIdentity{Mspid: "OrdererMSP", IdBytes: consenters[1].Identity})
+	require.Equal(t, consenters[1], ConsenterOfIdentity(consenters, sID))
+
+	sID = protoMarshal(t, &msp.SerializedIdentity{Mspid: "OtherMSP", IdBytes: consenters[1].Identity})
+	require.Nil(t, ConsenterOfIdentity(consenters, sID))
+
+	sID = protoMarshal(t, &msp.SerializedIdentity{Mspid: "OrdererMSP", IdBytes: consenters[1].ClientTlsCert})
+	require.Nil(t, ConsenterOfIdentity(consenters, sID))
+
+	require.Nil(t, ConsenterOfIdentity(consenters, []byte{1, 2, 3}))
+}
+
+func TestVerifyBlockSignatures(t *testing.T) {
+	consenters := newConsenters(t, 4)
+	block := protoutil.NewBlock(5, []byte("previous hash"))
+	value := []byte("value")
+
+	signature := func(c *Consenter) []byte {
+		return append([]byte("signature of "), c.Identity...)
+	}
+	verify := func(sd *protoutil.SignedData) error {
+		sID := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(sd.Identity, sID); err != nil {
+			return err
+		}
+		if !bytes.Equal(sd.Signature, append([]byte("signature of "), sID.IdBytes...)) {
+			return errors.New("bad signature")
+		}
+		if !bytes.HasPrefix(sd.Data, value) || !bytes.HasSuffix(sd.Data, protoutil.BlockHeaderBytes(block.Header)) {
+			return errors.New("bad data")
+		}
+		return nil
+	}
+	sign := func(signers ...*Consenter) {
+		md := &cb.Metadata{Value: value}
+		for _, c := range signers {
+			md.Signatures = append(md.Signatures, &cb.MetadataSignature{
+				SignatureHeader: protoMarshal(t, &cb.SignatureHeader{
+					Creator: protoMarshal(t, &msp.SerializedIdentity{Mspid: c.MspId, IdBytes: c.Identity}),
+				}),
+				Signature: signature(c),
+			})
+		}
+		block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = protoMarshal(t, md)
+	}
+
+	t.Run("quorum", func(t *testing.T) {
+		sign(consenters[0], consenters[2], consenters[3])
+		require.NoError(t, VerifyBlockSignatures(block, consenters, verify))
+	})
+
+	t.Run("duplicate signer", func(t *testing.T) {
+		sign(consenters[0], consenters[2], consenters[2])
+		require.EqualError(t, VerifyBlockSignatures(block, consenters, verify),
+			"block [5] is signed by 2 of the 4 consenters of the channel, while a quorum of 3 is required")
+	})
+
+	t.Run("not a consenter", func(t *testing.T) {
+		others := newConsenters(t, 1)
+		sign(consenters[0], consenters[1], others[0])
+		require.EqualError(t, VerifyBlockSignatures(block, consenters, verify),
+			"block [5] is signed by 2 of the 4 consenters of the channel, while a quorum of 3 is required")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		sign(consenters[0], consenters[1], consenters[2])
+		md := &cb.Metadata{}
+		require.NoError(t, proto.Unmarshal(block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES], md))
+		md.Signatures[2].Signature = []byte("forged")
+		block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = protoMarshal(t, md)
+		require.EqualError(t, VerifyBlockSignatures(block, consenters, verify),
+			"block [5] is signed by 2 of the 4 consenters of the channel, while a quorum of 3 is required: invalid signature of consenter 3: bad signature")
+	})
+
+	t.Run("no signatures", func(t *testing.T) {
+		block.Metadata.Metadata = nil
+		require.EqualError(t, VerifyBlockSignatures(block, consenters, verify), "block [5] has no signatures")
+		require.EqualError(t, VerifyBlockSignatures(nil, consenters, verify), "nil block or nil header")
+	})
+}
@@ -28,6 +28,10 @@ const (
 	// ApplicationV2_0 is the capabilities string for standard new non-backwards compatible fabric v2.0 application capabilities.
 	ApplicationV2_0 = "V2_0"
 
+	// ApplicationV2_0_PvtDataPurge is the capabilities string for the purge of private data by chaincode, which
+	// deletes all the historical values of a private data key from the private data store of the peers.
+	ApplicationV2_0_PvtDataPurge = "V2_0_PvtDataPurge"
+
 	// ApplicationPvtDataExperimental is the capabilities string for private data using the experimental feature of collections/sideDB.
 	ApplicationPvtDataExperimental = "V1_1_PVTDATA_EXPERIMENTAL"
 
@@ -44,6 +48,7 @@ type ApplicationProvider struct {
 	v142                   bool
 	v20                    bool
 	v11PvtDataExperimental bool
+	pvtDataPurge           bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -56,6 +61,7 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v142 = capabilities[ApplicationV1_4_2]
 	_, ap.v20 = capabilities[ApplicationV2_0]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
+	_, ap.pvtDataPurge = capabilities[ApplicationV2_0_PvtDataPurge]
 	return ap
 }
 
@@ -139,6 +145,12 @@ func (ap *ApplicationProvider) StorePvtDataOfInvalidTx() bool {
 	return ap.v142 || ap.v20
 }
 
+// PurgePvtData returns true if the chaincodes on this channel may purge private data,
+// in addition to deleting it.
+func (ap *ApplicationProvider) PurgePvtData() bool {
+	return ap.pvtDataPurge
+}
+
 // HasCapability returns true if the capability is supported by this binary.
 func (ap *ApplicationProvider) HasCapability(capability string) bool {
 	switch capability {
@@ -153,6 +165,8 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationV2_0:
 		return true
+	case ApplicationV2_0_PvtDataPurge:
+		return true
 	case ApplicationPvtDataExperimental:
 		return true
 	case ApplicationResourcesTreeExperimental:
@@ -28,6 +28,14 @@ const (
 
 	// ChannelV2_0 is the capabilities string for standard new non-backwards compatible fabric v2.0 channel capabilities.
 	ChannelV2_0 = "V2_0"
+
+	// ChannelV2_0_SM2ZA is the capabilities string for SM2 signatures computed over SM3(Z_A || M) as
+	// required by GM/T 0009, rather than over the SM3 digest of the message.
+	ChannelV2_0_SM2ZA = "V2_0_SM2ZA"
+
+	// ChannelV2_0_HashingAlgorithm is the capabilities string for computing block header hashes, block
+	// data hashes and transaction IDs with the channel HashingAlgorithm rather than always with SHA-256.
+	ChannelV2_0_HashingAlgorithm = "V2_0_HashingAlgorithm"
 )
 
 // ChannelProvider provides capabilities information for channel level config.
@@ -38,6 +46,8 @@ type ChannelProvider struct {
 	v142 bool
 	v143 bool
 	v20  bool
+	sm2z bool
+	hash bool
 }
 
 // NewChannelProvider creates a channel capabilities provider.
@@ -49,6 +59,8 @@ func NewChannelProvider(capabilities map[string]*cb.Capability) *ChannelProvider
 	_, cp.v142 = capabilities[ChannelV1_4_2]
 	_, cp.v143 = capabilities[ChannelV1_4_3]
 	_, cp.v20 = capabilities[ChannelV2_0]
+	_, cp.sm2z = capabilities[ChannelV2_0_SM2ZA]
+	_, cp.hash = capabilities[ChannelV2_0_HashingAlgorithm]
 	return cp
 }
 
@@ -61,6 +73,10 @@ func (cp *ChannelProvider) Type() string {
 func (cp *ChannelProvider) HasCapability(capability string) bool {
 	switch capability {
 	// Add new capability names here
+	case ChannelV2_0_HashingAlgorithm:
+		return true
+	case ChannelV2_0_SM2ZA:
+		return true
 	case ChannelV2_0:
 		return true
 	case ChannelV1_4_3:
@@ -79,6 +95,8 @@ func (cp *ChannelProvider) HasCapability(capability string) bool {
 // MSPVersion returns the level of MSP support required by this channel.
 func (cp *ChannelProvider) MSPVersion() msp.MSPVersion {
 	switch {
+	case cp.sm2z:
+		return msp.MSPv2_0_SM2ZA
 	case cp.v143 || cp.v20:
 		return msp.MSPv1_4_3
 	case cp.v13 || cp.v142:
@@ -99,3 +117,9 @@ func (cp *ChannelProvider) ConsensusTypeMigration() bool {
 func (cp *ChannelProvider) OrgSpecificOrdererEndpoints() bool {
 	return cp.v142 || cp.v143 || cp.v20
 }
+
+// ConfigurableHashingAlgorithm returns true if block header hashes, block data hashes and
+// transaction IDs are computed with the channel HashingAlgorithm.
+func (cp *ChannelProvider) ConfigurableHashingAlgorithm() bool {
+	return cp.hash
+}
@@ -100,6 +100,32 @@ func TestChannelV20(t *testing.T) {
 	assert.True(t, cp.OrgSpecificOrdererEndpoints())
 }
 
+func TestChannelV20SM2ZA(t *testing.T) {
+	cp := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV2_0:       {},
+		ChannelV2_0_SM2ZA: {},
+	})
+	assert.NoError(t, cp.Supported())
+	assert.True(t, cp.MSPVersion() == msp.MSPv2_0_SM2ZA)
+	assert.True(t, cp.ConsensusTypeMigration())
+	assert.True(t, cp.OrgSpecificOrdererEndpoints())
+}
+
+func TestChannelV20HashingAlgorithm(t *testing.T) {
+	cp := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV2_0: {},
+	})
+	assert.False(t, cp.ConfigurableHashingAlgorithm())
+
+	cp = NewChannelProvider(map[string]*cb.Capability{
+		ChannelV2_0:                  {},
+		ChannelV2_0_HashingAlgorithm: {},
+	})
+	assert.NoError(t, cp.Supported())
+	assert.True(t, cp.MSPVersion() == msp.MSPv1_4_3)
+	assert.True(t, cp.ConfigurableHashingAlgorithm())
+}
+
 func TestChannelNotSupported(t *testing.T) {
 	cp := NewChannelProvider(map[string]*cb.Capability{
 		ChannelV1_1:           {},
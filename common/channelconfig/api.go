@@ -106,6 +106,10 @@ type Orderer interface {
 	// BatchTimeout returns the amount of time to wait before creating a batch
 	BatchTimeout() time.Duration
 
+	// AdaptiveBatching returns the bounds within which the batch size and timeout
+	// are tuned to the arrival rate, and false if adaptive batching is disabled
+	AdaptiveBatching() (AdaptiveBatching, bool)
+
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
@@ -135,6 +139,10 @@ type ChannelCapabilities interface {
 
 	// OrgSpecificOrdererEndpoints return true if the channel config processing allows orderer orgs to specify their own endpoints
 	OrgSpecificOrdererEndpoints() bool
+
+	// ConfigurableHashingAlgorithm returns true if block header hashes, block data hashes and transaction IDs
+	// are computed with the channel HashingAlgorithm rather than with SHA-256.
+	ConfigurableHashingAlgorithm() bool
 }
 
 // ApplicationCapabilities defines the capabilities for the application portion of a channel
@@ -176,6 +184,10 @@ type ApplicationCapabilities interface {
 	// invalid transactions (as introduced in v142).
 	StorePvtDataOfInvalidTx() bool
 
+	// PurgePvtData returns true if the chaincodes on this channel may purge private data,
+	// which deletes all the historical values of a key from the private data store.
+	PurgePvtData() bool
+
 	// V2_0Validation returns true if this channel supports transaction validation
 	// as introduced in v2.0. This includes:
 	//  - new chaincode lifecycle
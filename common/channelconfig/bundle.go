@@ -79,6 +79,15 @@ func (b *Bundle) ConfigtxValidator() configtx.Validator {
 // ValidateNew checks if a new bundle's contained configuration is valid to be derived from the current bundle.
 // This allows checks of the nature "Make sure that the consensus type did not change".
 func (b *Bundle) ValidateNew(nb Resources) error {
+	// The hashes already written to the chain cannot be recomputed, so the block
+	// hashing algorithm is fixed when the channel is created.
+	if ncc, ok := nb.ChannelConfig().(*ChannelConfig); ok {
+		current, proposed := b.channelConfig.blockHashingAlgorithmName(), ncc.blockHashingAlgorithmName()
+		if current != proposed {
+			return errors.Errorf("attempted to change block hashing algorithm from %s to %s", current, proposed)
+		}
+	}
+
 	if oc, ok := b.OrdererConfig(); ok {
 		noc, ok := nb.OrdererConfig()
 		if !ok {
@@ -243,6 +243,36 @@ func TestValidateNew(t *testing.T) {
 		assert.Error(t, err)
 		assert.Regexp(t, "consortium consortium1 org org3 attempted to change MSP ID from", err.Error())
 	})
+
+	t.Run("BlockHashingAlgorithmChange", func(t *testing.T) {
+		hashingCapabilities := &cb.Capabilities{
+			Capabilities: map[string]*cb.Capability{
+				cc.ChannelV2_0_HashingAlgorithm: {},
+			},
+		}
+		currb := &Bundle{
+			channelConfig: &ChannelConfig{
+				protos: &ChannelProtos{
+					HashingAlgorithm: &cb.HashingAlgorithm{Name: "SHA256"},
+					Capabilities:     &cb.Capabilities{},
+				},
+			},
+		}
+
+		nb := &Bundle{
+			channelConfig: &ChannelConfig{
+				protos: &ChannelProtos{
+					HashingAlgorithm: &cb.HashingAlgorithm{Name: "SHA256"},
+					Capabilities:     hashingCapabilities,
+				},
+			},
+		}
+		assert.NoError(t, currb.ValidateNew(nb))
+
+		nb.channelConfig.protos.HashingAlgorithm.Name = "GMSM3"
+		err := currb.ValidateNew(nb)
+		assert.EqualError(t, err, "attempted to change block hashing algorithm from SHA256 to GMSM3")
+	})
 }
 
 func TestValidateNewWithConsensusMigration(t *testing.T) {
@@ -13,8 +13,10 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
@@ -143,6 +145,96 @@ func (cc *ChannelConfig) HashingAlgorithm() func(input []byte) []byte {
 	return cc.hashingAlgorithm
 }
 
+// BlockHashingAlgorithm returns the hash function used for the block header hashes, block data
+// hashes and transaction IDs of the channel. The channel HashingAlgorithm is only honored when the
+// ConfigurableHashingAlgorithm channel capability is enabled, SHA-256 is used otherwise.
+func BlockHashingAlgorithm(cc Channel) func(input []byte) []byte {
+	if cc.Capabilities().ConfigurableHashingAlgorithm() {
+		return cc.HashingAlgorithm()
+	}
+	return util.ComputeSHA256
+}
+
+// blockHashingAlgorithmName returns the name of the algorithm returned by BlockHashingAlgorithm.
+func (cc *ChannelConfig) blockHashingAlgorithmName() string {
+	if cc.protos == nil || cc.protos.Capabilities == nil {
+		return bccsp.SHA256
+	}
+	if !capabilities.NewChannelProvider(cc.protos.Capabilities.Capabilities).ConfigurableHashingAlgorithm() {
+		return bccsp.SHA256
+	}
+	return cc.protos.HashingAlgorithm.GetName()
+}
+
+// BlockHashingAlgorithmFromConfigBlock returns the BlockHashingAlgorithm of the channel
+// configuration carried by the given config block.
+func BlockHashingAlgorithmFromConfigBlock(block *cb.Block) (func(input []byte) []byte, error) {
+	name, err := BlockHashingAlgorithmNameFromConfigBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	return HashingAlgorithmByName(name)
+}
+
+// BlockHashingAlgorithmNameFromConfigBlock returns the name of the BlockHashingAlgorithm of the
+// channel configuration carried by the given config block.
+func BlockHashingAlgorithmNameFromConfigBlock(block *cb.Block) (string, error) {
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to extract envelope from config block")
+	}
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to extract payload from config block")
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to extract config envelope from config block")
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return "", errors.New("config block does not carry a channel group")
+	}
+	return BlockHashingAlgorithmNameFromChannelGroup(configEnv.Config.ChannelGroup)
+}
+
+// BlockHashingAlgorithmFromChannelGroup returns the BlockHashingAlgorithm of the channel
+// configuration rooted at the given channel group.
+func BlockHashingAlgorithmFromChannelGroup(channelGroup *cb.ConfigGroup) (func(input []byte) []byte, error) {
+	name, err := BlockHashingAlgorithmNameFromChannelGroup(channelGroup)
+	if err != nil {
+		return nil, err
+	}
+	return HashingAlgorithmByName(name)
+}
+
+// BlockHashingAlgorithmNameFromChannelGroup returns the name of the BlockHashingAlgorithm of the
+// channel configuration rooted at the given channel group.
+func BlockHashingAlgorithmNameFromChannelGroup(channelGroup *cb.ConfigGroup) (string, error) {
+	cc := &ChannelConfig{protos: &ChannelProtos{}}
+	if err := DeserializeProtoValuesFromGroup(channelGroup, cc.protos); err != nil {
+		return "", errors.Wrap(err, "failed to deserialize values")
+	}
+	name := cc.blockHashingAlgorithmName()
+	if _, err := HashingAlgorithmByName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// HashingAlgorithmByName returns the hash function of the named hashing algorithm.
+func HashingAlgorithmByName(name string) (func(input []byte) []byte, error) {
+	switch name {
+	case bccsp.SHA256:
+		return util.ComputeSHA256, nil
+	case bccsp.SHA3_256:
+		return util.ComputeSHA3256, nil
+	case bccsp.GMSM3:
+		return util.ComputeGMSM3, nil
+	default:
+		return nil, fmt.Errorf("Unknown hashing algorithm type: %s", name)
+	}
+}
+
 // BlockDataHashingStructure returns the width to use when forming the block data hashing structure
 func (cc *ChannelConfig) BlockDataHashingStructureWidth() uint32 {
 	return cc.protos.BlockDataHashingStructure.Width
@@ -185,15 +277,11 @@ func (cc *ChannelConfig) Validate(channelCapabilities ChannelCapabilities) error
 }
 
 func (cc *ChannelConfig) validateHashingAlgorithm() error {
-	switch cc.protos.HashingAlgorithm.Name {
-	case bccsp.SHA256:
-		cc.hashingAlgorithm = util.ComputeSHA256
-	case bccsp.SHA3_256:
-		cc.hashingAlgorithm = util.ComputeSHA3256
-	default:
-		return fmt.Errorf("Unknown hashing algorithm type: %s", cc.protos.HashingAlgorithm.Name)
+	hashingAlgorithm, err := HashingAlgorithmByName(cc.protos.HashingAlgorithm.Name)
+	if err != nil {
+		return err
 	}
-
+	cc.hashingAlgorithm = hashingAlgorithm
 	return nil
 }
 
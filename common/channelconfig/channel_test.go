@@ -14,7 +14,9 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,6 +54,61 @@ func TestHashingAlgorithm(t *testing.T) {
 
 	assert.Equal(t, reflect.ValueOf(util.ComputeSHA3256).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
 		"Unexpected hashing algorithm returned")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.GMSM3}}}
+	assert.NoError(t, cc.validateHashingAlgorithm(), "Allowed hashing algorith GMSM3 supplied")
+
+	assert.Equal(t, reflect.ValueOf(util.ComputeGMSM3).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
+		"Unexpected hashing algorithm returned")
+}
+
+func TestBlockHashingAlgorithm(t *testing.T) {
+	cc := &ChannelConfig{protos: &ChannelProtos{
+		HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.GMSM3},
+		Capabilities:     &cb.Capabilities{},
+	}}
+	assert.NoError(t, cc.validateHashingAlgorithm())
+	assert.Equal(t, reflect.ValueOf(util.ComputeSHA256).Pointer(), reflect.ValueOf(BlockHashingAlgorithm(cc)).Pointer(),
+		"Channel HashingAlgorithm must be ignored without the capability")
+
+	cc.protos.Capabilities.Capabilities = map[string]*cb.Capability{capabilities.ChannelV2_0_HashingAlgorithm: {}}
+	assert.Equal(t, reflect.ValueOf(util.ComputeGMSM3).Pointer(), reflect.ValueOf(BlockHashingAlgorithm(cc)).Pointer(),
+		"Channel HashingAlgorithm must be honored with the capability")
+}
+
+func TestBlockHashingAlgorithmNameFromChannelGroup(t *testing.T) {
+	channelGroup := &cb.ConfigGroup{Values: map[string]*cb.ConfigValue{
+		HashingAlgorithmKey: {Value: protoutil.MarshalOrPanic(&cb.HashingAlgorithm{Name: bccsp.GMSM3})},
+	}}
+	name, err := BlockHashingAlgorithmNameFromChannelGroup(channelGroup)
+	assert.NoError(t, err)
+	assert.Equal(t, bccsp.SHA256, name, "Channel HashingAlgorithm must be ignored without the capability")
+
+	channelGroup.Values[CapabilitiesKey] = &cb.ConfigValue{Value: protoutil.MarshalOrPanic(&cb.Capabilities{
+		Capabilities: map[string]*cb.Capability{capabilities.ChannelV2_0_HashingAlgorithm: {}},
+	})}
+	name, err = BlockHashingAlgorithmNameFromChannelGroup(channelGroup)
+	assert.NoError(t, err)
+	assert.Equal(t, bccsp.GMSM3, name, "Channel HashingAlgorithm must be honored with the capability")
+
+	channelGroup.Values[HashingAlgorithmKey].Value = protoutil.MarshalOrPanic(&cb.HashingAlgorithm{Name: "MD5"})
+	_, err = BlockHashingAlgorithmNameFromChannelGroup(channelGroup)
+	assert.EqualError(t, err, "Unknown hashing algorithm type: MD5")
+}
+
+func TestHashingAlgorithmByName(t *testing.T) {
+	for name, expected := range map[string]func([]byte) []byte{
+		bccsp.SHA256:   util.ComputeSHA256,
+		bccsp.SHA3_256: util.ComputeSHA3256,
+		bccsp.GMSM3:    util.ComputeGMSM3,
+	} {
+		hashingAlgorithm, err := HashingAlgorithmByName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, reflect.ValueOf(expected).Pointer(), reflect.ValueOf(hashingAlgorithm).Pointer())
+	}
+
+	_, err := HashingAlgorithmByName("MD5")
+	assert.EqualError(t, err, "Unknown hashing algorithm type: MD5")
 }
 
 func TestBlockDataHashingStructure(t *testing.T) {
@@ -59,7 +59,19 @@ type OrdererConfig struct {
 	protos *OrdererProtos
 	orgs   map[string]OrdererOrg
 
-	batchTimeout time.Duration
+	batchTimeout     time.Duration
+	adaptiveBatching *AdaptiveBatching
+}
+
+// AdaptiveBatching holds the bounds within which the block cutter tunes the
+// message count and the timeout of batches to the arrival rate of messages,
+// so that blocks are cut close to the target latency.
+type AdaptiveBatching struct {
+	MinMessageCount uint32
+	MaxMessageCount uint32
+	MinTimeout      time.Duration
+	MaxTimeout      time.Duration
+	TargetLatency   time.Duration
 }
 
 // OrdererOrgProtos are deserialized from the Orderer org config values
@@ -168,6 +180,15 @@ func (oc *OrdererConfig) BatchTimeout() time.Duration {
 	return oc.batchTimeout
 }
 
+// AdaptiveBatching returns the bounds of adaptive batching, and false if
+// adaptive batching is not enabled for the channel.
+func (oc *OrdererConfig) AdaptiveBatching() (AdaptiveBatching, bool) {
+	if oc.adaptiveBatching == nil {
+		return AdaptiveBatching{}, false
+	}
+	return *oc.adaptiveBatching, true
+}
+
 // KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 // Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 // used for ordering.
@@ -194,6 +215,7 @@ func (oc *OrdererConfig) Validate() error {
 	for _, validator := range []func() error{
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
+		oc.validateAdaptiveBatching,
 		oc.validateKafkaBrokers,
 	} {
 		if err := validator(); err != nil {
@@ -217,6 +239,9 @@ func (oc *OrdererConfig) validateBatchSize() error {
 	if oc.protos.BatchSize.PreferredMaxBytes > oc.protos.BatchSize.AbsoluteMaxBytes {
 		return fmt.Errorf("Attempted to set the batch size preferred max bytes (%v) greater than the absolute max bytes (%v).", oc.protos.BatchSize.PreferredMaxBytes, oc.protos.BatchSize.AbsoluteMaxBytes)
 	}
+	if oc.protos.BatchSize.MinMessageCount > oc.protos.BatchSize.MaxMessageCount {
+		return fmt.Errorf("Attempted to set the batch size min message count (%v) greater than the max message count (%v).", oc.protos.BatchSize.MinMessageCount, oc.protos.BatchSize.MaxMessageCount)
+	}
 	return nil
 }
 
@@ -232,6 +257,61 @@ func (oc *OrdererConfig) validateBatchTimeout() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateAdaptiveBatching() error {
+	oc.adaptiveBatching = nil
+	if oc.protos.BatchTimeout.TargetLatency == "" {
+		if oc.protos.BatchTimeout.MinTimeout != "" || oc.protos.BatchSize.MinMessageCount != 0 {
+			return fmt.Errorf("Attempted to set adaptive batching bounds without a batch target latency")
+		}
+		return nil
+	}
+
+	// With Kafka every orderer cuts the blocks of the channel on its own, so
+	// tuning the batches to the locally observed arrival rate would make the
+	// orderers disagree on the block content. BFT batches from its request
+	// pool rather than through the block cutter.
+	switch consensusType := oc.protos.ConsensusType.GetType(); consensusType {
+	case "kafka", "BFT":
+		return fmt.Errorf("Attempted to enable adaptive batching with consensus type %s, which does not support it", consensusType)
+	}
+
+	targetLatency, err := time.ParseDuration(oc.protos.BatchTimeout.TargetLatency)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the batch target latency to a invalid value: %s", err)
+	}
+	if targetLatency <= 0 {
+		return fmt.Errorf("Attempted to set the batch target latency to a non-positive value: %s", targetLatency)
+	}
+
+	var minTimeout time.Duration
+	if oc.protos.BatchTimeout.MinTimeout != "" {
+		minTimeout, err = time.ParseDuration(oc.protos.BatchTimeout.MinTimeout)
+		if err != nil {
+			return fmt.Errorf("Attempted to set the batch min timeout to a invalid value: %s", err)
+		}
+		if minTimeout <= 0 {
+			return fmt.Errorf("Attempted to set the batch min timeout to a non-positive value: %s", minTimeout)
+		}
+	}
+	if targetLatency < minTimeout || targetLatency > oc.batchTimeout {
+		return fmt.Errorf("Attempted to set the batch target latency (%s) outside of the batch min timeout (%s) and timeout (%s)", targetLatency, minTimeout, oc.batchTimeout)
+	}
+
+	minMessageCount := oc.protos.BatchSize.MinMessageCount
+	if minMessageCount == 0 {
+		minMessageCount = 1
+	}
+
+	oc.adaptiveBatching = &AdaptiveBatching{
+		MinMessageCount: minMessageCount,
+		MaxMessageCount: oc.protos.BatchSize.MaxMessageCount,
+		MinTimeout:      minTimeout,
+		MaxTimeout:      oc.batchTimeout,
+		TargetLatency:   targetLatency,
+	}
+	return nil
+}
+
 func (oc *OrdererConfig) validateKafkaBrokers() error {
 	for _, broker := range oc.protos.KafkaBrokers.Brokers {
 		if !brokerEntrySeemsValid(broker) {
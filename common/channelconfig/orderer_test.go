@@ -8,6 +8,7 @@ package channelconfig
 
 import (
 	"testing"
+	"time"
 
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +30,9 @@ func TestBatchSize(t *testing.T) {
 
 	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: &ab.BatchSize{MaxMessageCount: validMaxMessageCount, AbsoluteMaxBytes: validAbsoluteMaxBytes, PreferredMaxBytes: validAbsoluteMaxBytes + 1}}}
 	assert.Error(t, oc.validateBatchSize(), "PreferredMaxBytes larger to AbsoluteMaxBytes")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: &ab.BatchSize{MaxMessageCount: validMaxMessageCount, AbsoluteMaxBytes: validAbsoluteMaxBytes, PreferredMaxBytes: validPreferredMaxBytes, MinMessageCount: validMaxMessageCount + 1}}}
+	assert.Error(t, oc.validateBatchSize(), "MinMessageCount larger than MaxMessageCount")
 }
 
 func TestBatchTimeout(t *testing.T) {
@@ -42,6 +46,65 @@ func TestBatchTimeout(t *testing.T) {
 	assert.Error(t, oc.validateBatchTimeout(), "Zero batch timeout")
 }
 
+func TestAdaptiveBatching(t *testing.T) {
+	newConfig := func(consensusType string, minMessageCount uint32, minTimeout, targetLatency string) *OrdererConfig {
+		oc := &OrdererConfig{protos: &OrdererProtos{
+			ConsensusType: &ab.ConsensusType{Type: consensusType},
+			BatchSize:     &ab.BatchSize{MaxMessageCount: 100, MinMessageCount: minMessageCount},
+			BatchTimeout:  &ab.BatchTimeout{Timeout: "2s", MinTimeout: minTimeout, TargetLatency: targetLatency},
+		}}
+		assert.NoError(t, oc.validateBatchTimeout())
+		return oc
+	}
+
+	oc := newConfig("etcdraft", 0, "", "")
+	assert.NoError(t, oc.validateAdaptiveBatching(), "Adaptive batching disabled")
+	_, enabled := oc.AdaptiveBatching()
+	assert.False(t, enabled)
+
+	oc = newConfig("etcdraft", 10, "100ms", "500ms")
+	assert.NoError(t, oc.validateAdaptiveBatching(), "Valid adaptive batching")
+	bounds, enabled := oc.AdaptiveBatching()
+	assert.True(t, enabled)
+	assert.Equal(t, AdaptiveBatching{
+		MinMessageCount: 10,
+		MaxMessageCount: 100,
+		MinTimeout:      100 * time.Millisecond,
+		MaxTimeout:      2 * time.Second,
+		TargetLatency:   500 * time.Millisecond,
+	}, bounds)
+
+	oc = newConfig("solo", 0, "", "1s")
+	assert.NoError(t, oc.validateAdaptiveBatching(), "Valid adaptive batching without lower bounds")
+	bounds, _ = oc.AdaptiveBatching()
+	assert.Equal(t, uint32(1), bounds.MinMessageCount)
+	assert.Equal(t, time.Duration(0), bounds.MinTimeout)
+
+	for _, tc := range []struct {
+		name            string
+		consensusType   string
+		minMessageCount uint32
+		minTimeout      string
+		targetLatency   string
+	}{
+		{name: "Bounds without target latency", consensusType: "etcdraft", minMessageCount: 10},
+		{name: "Min timeout without target latency", consensusType: "etcdraft", minTimeout: "100ms"},
+		{name: "Kafka consensus type", consensusType: "kafka", targetLatency: "500ms"},
+		{name: "BFT consensus type", consensusType: "BFT", targetLatency: "500ms"},
+		{name: "Invalid target latency", consensusType: "etcdraft", targetLatency: "fast"},
+		{name: "Zero target latency", consensusType: "etcdraft", targetLatency: "0s"},
+		{name: "Invalid min timeout", consensusType: "etcdraft", minTimeout: "short", targetLatency: "500ms"},
+		{name: "Negative min timeout", consensusType: "etcdraft", minTimeout: "-1s", targetLatency: "500ms"},
+		{name: "Target latency below min timeout", consensusType: "etcdraft", minTimeout: "1s", targetLatency: "500ms"},
+		{name: "Target latency above timeout", consensusType: "etcdraft", targetLatency: "3s"},
+	} {
+		oc := newConfig(tc.consensusType, tc.minMessageCount, tc.minTimeout, tc.targetLatency)
+		assert.Error(t, oc.validateAdaptiveBatching(), tc.name)
+		_, enabled := oc.AdaptiveBatching()
+		assert.False(t, enabled, tc.name)
+	}
+}
+
 func TestKafkaBrokers(t *testing.T) {
 	oc := &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1:9092", "foo.bar:9092"}}}}
 	assert.NoError(t, oc.validateKafkaBrokers(), "Valid kafka brokers")
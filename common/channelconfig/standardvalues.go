@@ -21,7 +21,7 @@ func DeserializeProtoValuesFromGroup(group *cb.ConfigGroup, protosStructs ...int
 		logger.Panicf("This is a compile time bug only, the proto structures are somehow invalid: %s", err)
 	}
 
-	for key, value := range group.Values {
+	for key, value := range group.GetValues() {
 		if _, err := sv.Deserialize(key, value.Value); err != nil {
 			return err
 		}
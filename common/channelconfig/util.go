@@ -74,13 +74,21 @@ func ConsortiumValue(name string) *StandardConfigValue {
 	}
 }
 
-// HashingAlgorithm returns the only currently valid hashing algorithm.
+// HashingAlgorithm returns the default hashing algorithm.
 // It is a value for the /Channel group.
 func HashingAlgorithmValue() *StandardConfigValue {
+	return NamedHashingAlgorithmValue(defaultHashingAlgorithm)
+}
+
+// NamedHashingAlgorithmValue returns the config definition for the named hashing algorithm.
+// Block hashes and transaction IDs only use it when the V2_0_HashingAlgorithm channel
+// capability is enabled.
+// It is a value for the /Channel group.
+func NamedHashingAlgorithmValue(name string) *StandardConfigValue {
 	return &StandardConfigValue{
 		key: HashingAlgorithmKey,
 		value: &cb.HashingAlgorithm{
-			Name: defaultHashingAlgorithm,
+			Name: name,
 		},
 	}
 }
@@ -143,6 +151,35 @@ func BatchTimeoutValue(timeout string) *StandardConfigValue {
 	}
 }
 
+// AdaptiveBatchSizeValue returns the config definition for the orderer batch size
+// with the lower bound of the message count used by adaptive batching.
+// It is a value for the /Channel/Orderer group.
+func AdaptiveBatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes, minMessages uint32) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: BatchSizeKey,
+		value: &ab.BatchSize{
+			MaxMessageCount:   maxMessages,
+			AbsoluteMaxBytes:  absoluteMaxBytes,
+			PreferredMaxBytes: preferredMaxBytes,
+			MinMessageCount:   minMessages,
+		},
+	}
+}
+
+// AdaptiveBatchTimeoutValue returns the config definition for the orderer batch timeout
+// with the lower bound and the target latency used by adaptive batching.
+// It is a value for the /Channel/Orderer group.
+func AdaptiveBatchTimeoutValue(timeout, minTimeout, targetLatency string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: BatchTimeoutKey,
+		value: &ab.BatchTimeout{
+			Timeout:       timeout,
+			MinTimeout:    minTimeout,
+			TargetLatency: targetLatency,
+		},
+	}
+}
+
 // ChannelRestrictionsValue returns the config definition for the orderer channel restrictions.
 // It is a value for the /Channel/Orderer group.
 func ChannelRestrictionsValue(maxChannelCount uint64) *StandardConfigValue {
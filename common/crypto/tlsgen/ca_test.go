@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -82,3 +83,30 @@ func TestTLSCA(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "context deadline exceeded")
 }
+
+func TestCertKeyPairSignedByCA(t *testing.T) {
+	ca, err := NewCA()
+	assert.NoError(t, err)
+	caCert, err := gmx509.ReadCertificateFromMem(ca.CertBytes())
+	assert.NoError(t, err)
+
+	intermediateCA, err := ca.NewIntermediateCA()
+	assert.NoError(t, err)
+	intermediateCert, err := gmx509.ReadCertificateFromMem(intermediateCA.CertBytes())
+	assert.NoError(t, err)
+	assert.NoError(t, intermediateCert.CheckSignatureFrom(caCert))
+
+	kp, err := ca.NewClientCertKeyPair()
+	assert.NoError(t, err)
+	cert, err := gmx509.ReadCertificateFromMem(kp.Cert)
+	assert.NoError(t, err)
+	assert.Equal(t, gmx509.SM2WithSM3, cert.SignatureAlgorithm)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+
+	kp, err = ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+	cert, err = gmx509.ReadCertificateFromMem(kp.Cert)
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.Error(t, cert.CheckSignatureFrom(intermediateCert))
+}
@@ -37,11 +37,12 @@ func newCertTemplate() (gmx509.Certificate, error) {
 		return gmx509.Certificate{}, err
 	}
 	return gmx509.Certificate{
-		Subject:      pkix.Name{SerialNumber: sn.String()},
-		NotBefore:    time.Now().Add(time.Hour * (-24)),
-		NotAfter:     time.Now().Add(time.Hour * 24),
-		KeyUsage:     gmx509.KeyUsageKeyEncipherment | gmx509.KeyUsageDigitalSignature,
-		SerialNumber: sn,
+		Subject:            pkix.Name{SerialNumber: sn.String()},
+		NotBefore:          time.Now().Add(time.Hour * (-24)),
+		NotAfter:           time.Now().Add(time.Hour * 24),
+		KeyUsage:           gmx509.KeyUsageKeyEncipherment | gmx509.KeyUsageDigitalSignature,
+		SerialNumber:       sn,
+		SignatureAlgorithm: gmx509.SM2WithSM3,
 	}, nil
 }
 
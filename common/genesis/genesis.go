@@ -8,6 +8,7 @@ package genesis
 
 import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
@@ -44,7 +45,11 @@ func (f *factory) Block(channelID string) *cb.Block {
 
 	block := protoutil.NewBlock(0, nil)
 	block.Data = &cb.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(envelope)}}
-	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	hashingAlgorithm, err := channelconfig.BlockHashingAlgorithmFromChannelGroup(f.channelGroup)
+	if err != nil {
+		panic(err)
+	}
+	block.Header.DataHash = protoutil.BlockDataHashWith(block.Data, hashingAlgorithm)
 	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = protoutil.MarshalOrPanic(&cb.Metadata{
 		Value: protoutil.MarshalOrPanic(&cb.LastConfig{Index: 0}),
 	})
@@ -11,6 +11,10 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -45,3 +49,26 @@ func TestFactory(t *testing.T) {
 		assert.Equal(t, uint64(0), lastConfig.Index)
 	})
 }
+
+func TestFactoryBlockHashingAlgorithm(t *testing.T) {
+	channelGroup := protoutil.NewConfigGroup()
+	channelGroup.Values[channelconfig.HashingAlgorithmKey] = &cb.ConfigValue{
+		Value: protoutil.MarshalOrPanic(&cb.HashingAlgorithm{Name: bccsp.GMSM3}),
+	}
+
+	block := NewFactoryImpl(channelGroup).Block("testchannelid")
+	assert.Equal(t, protoutil.BlockDataHash(block.Data), block.Header.DataHash, "HashingAlgorithm must be ignored without the capability")
+
+	channelGroup.Values[channelconfig.CapabilitiesKey] = &cb.ConfigValue{
+		Value: protoutil.MarshalOrPanic(&cb.Capabilities{
+			Capabilities: map[string]*cb.Capability{capabilities.ChannelV2_0_HashingAlgorithm: {}},
+		}),
+	}
+
+	block = NewFactoryImpl(channelGroup).Block("testchannelid")
+	assert.Equal(t, protoutil.BlockDataHashWith(block.Data, util.ComputeGMSM3), block.Header.DataHash)
+
+	hashingAlgorithm, err := channelconfig.BlockHashingAlgorithmFromConfigBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeGMSM3([]byte("foo")), hashingAlgorithm([]byte("foo")))
+}
@@ -0,0 +1,552 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	archivedBlockfilesInfoFile     = "archivedBlockfiles.info"
+	archivedBlockfilesInfoTempFile = "archivedBlockfilesTemp.info"
+	compressedBlockfileSuffix      = ".gz"
+)
+
+// ArchiveStore stores the block files that are moved out of the local block storage by the block archiver.
+// The objects are addressed by the ledger id and the name of the block file. The implementations
+// are expected to make an object visible to `Open` only after `Put` returns successfully
+type ArchiveStore interface {
+	// Put stores the content under the given name, replacing the existing object, if any
+	Put(ledgerID, name string, content io.Reader) error
+	// Open opens the object with the given name for reading
+	Open(ledgerID, name string) (ArchivedObject, error)
+	// Delete deletes the object with the given name. It is not an error if the object does not exist
+	Delete(ledgerID, name string) error
+}
+
+// ArchivedObject is the content of an object in an ArchiveStore
+type ArchivedObject interface {
+	io.ReaderAt
+	io.Closer
+	Size() int64
+}
+
+// ArchiveConf encapsulates the configurations for archiving the block files
+type ArchiveConf struct {
+	// Store is the store where the block files are archived
+	Store ArchiveStore
+	// RetentionBlocks is the number of the most recent blocks that are retained in the local block storage.
+	// A block file is archived only when all the blocks in the file are older than the retained blocks
+	RetentionBlocks uint64
+	// Compress specifies whether the block files are gzip compressed when archived
+	Compress bool
+}
+
+// FilesystemArchiveStore is an ArchiveStore that keeps the objects as files in a directory,
+// typically a mount point of a slower and cheaper storage than the one used by the peer
+type FilesystemArchiveStore struct {
+	rootDir string
+}
+
+// NewFilesystemArchiveStore constructs a FilesystemArchiveStore that keeps the objects of a ledger
+// in the sub-directory, named by the ledger id, of the given directory
+func NewFilesystemArchiveStore(rootDir string) (*FilesystemArchiveStore, error) {
+	if err := os.MkdirAll(rootDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "error while creating the archive dir [%s]", rootDir)
+	}
+	return &FilesystemArchiveStore{rootDir: rootDir}, nil
+}
+
+// Put implements the method in the interface ArchiveStore
+func (s *FilesystemArchiveStore) Put(ledgerID, name string, content io.Reader) error {
+	dir := filepath.Join(s.rootDir, ledgerID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "error while creating the archive dir [%s]", dir)
+	}
+	tempFilePath := filepath.Join(dir, name+".tmp")
+	if err := os.Remove(tempFilePath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error while removing the file [%s]", tempFilePath)
+	}
+	file, err := os.OpenFile(tempFilePath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating the file [%s]", tempFilePath)
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "error while writing to the file [%s]", tempFilePath)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "error while synching the file [%s]", tempFilePath)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "error while closing the file [%s]", tempFilePath)
+	}
+	if err := os.Rename(tempFilePath, filepath.Join(dir, name)); err != nil {
+		return errors.WithStack(err)
+	}
+	return syncDir(dir)
+}
+
+// Open implements the method in the interface ArchiveStore
+func (s *FilesystemArchiveStore) Open(ledgerID, name string) (ArchivedObject, error) {
+	filePath := filepath.Join(s.rootDir, ledgerID, name)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the file [%s]", filePath)
+	}
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "error while getting the stat of the file [%s]", filePath)
+	}
+	return &archivedFile{File: file, size: fileInfo.Size()}, nil
+}
+
+// Delete implements the method in the interface ArchiveStore
+func (s *FilesystemArchiveStore) Delete(ledgerID, name string) error {
+	filePath := filepath.Join(s.rootDir, ledgerID, name)
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error while removing the file [%s]", filePath)
+	}
+	return nil
+}
+
+type archivedFile struct {
+	*os.File
+	size int64
+}
+
+func (f *archivedFile) Size() int64 {
+	return f.size
+}
+
+// blockfileArchive tracks the block files of a ledger that are moved to the archive store.
+// The block files are archived in the order of the file numbers and hence, the archived files
+// are always the files numbered from zero to `numArchivedFiles() - 1`. All the methods are
+// safe to invoke on a nil `blockfileArchive`, which represents a ledger with no archived files
+type blockfileArchive struct {
+	ledgerID  string
+	ledgerDir string
+	conf      *ArchiveConf
+
+	mutex sync.RWMutex
+	info  *archivedBlockfilesInfo
+
+	// the content of the most recently read compressed block file is cached, as the
+	// blocks are mostly read sequentially by the deliver service
+	cacheMutex    sync.Mutex
+	cachedFileNum int
+	cachedContent []byte
+}
+
+// openBlockfileArchive loads the information about the archived block files of a ledger. This
+// function returns a nil `blockfileArchive` if archiving is not configured and none of the block
+// files of the ledger is archived
+func openBlockfileArchive(ledgerID, ledgerDir string, conf *ArchiveConf) (*blockfileArchive, error) {
+	info, err := loadArchivedBlockfilesInfo(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	if conf == nil {
+		if info.numArchivedFiles() > 0 {
+			return nil, errors.Errorf(
+				"[%d] block files of the ledger [%s] are archived but the block archive is not configured",
+				info.numArchivedFiles(), ledgerID,
+			)
+		}
+		return nil, nil
+	}
+	a := &blockfileArchive{
+		ledgerID:      ledgerID,
+		ledgerDir:     ledgerDir,
+		conf:          conf,
+		info:          info,
+		cachedFileNum: -1,
+	}
+	return a, nil
+}
+
+// removeLocalCopies removes the local copies of the archived block files, which may be left over
+// if a crash takes place after recording a file as archived and before removing the local file
+func (a *blockfileArchive) removeLocalCopies() error {
+	removed := false
+	for fileNum := 0; fileNum < a.info.numArchivedFiles(); fileNum++ {
+		err := os.Remove(deriveBlockfilePath(a.ledgerDir, fileNum))
+		switch {
+		case err == nil:
+			logger.Infof("Removed the local copy of the archived block file number [%d] of the ledger [%s]", fileNum, a.ledgerID)
+			removed = true
+		case !os.IsNotExist(err):
+			return errors.Wrapf(err, "error while removing the local copy of the archived block file number [%d]", fileNum)
+		}
+	}
+	if !removed {
+		return nil
+	}
+	return syncDir(a.ledgerDir)
+}
+
+func (a *blockfileArchive) numArchivedFiles() int {
+	if a == nil {
+		return 0
+	}
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.info.numArchivedFiles()
+}
+
+func (a *blockfileArchive) isArchived(fileNum int) bool {
+	return fileNum < a.numArchivedFiles()
+}
+
+// archiveBlockfile moves the given block file, which is expected to be the next file to archive, to the
+// archive store. The file is first stored in the archive store, then recorded as archived and finally,
+// removed from the local block storage. A crash at any point leaves either the local file or the archived one
+// accessible to the readers
+func (a *blockfileArchive) archiveBlockfile(fileNum int) error {
+	if fileNum != a.numArchivedFiles() {
+		return errors.Errorf("block file number [%d] cannot be archived, the next file to archive is [%d]", fileNum, a.numArchivedFiles())
+	}
+	filePath := deriveBlockfilePath(a.ledgerDir, fileNum)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "error while opening the block file [%s]", filePath)
+	}
+	defer file.Close()
+
+	var content io.Reader = file
+	name := archivedBlockfileName(fileNum, a.conf.Compress)
+	if a.conf.Compress {
+		pipeReader, pipeWriter := io.Pipe()
+		defer pipeReader.Close()
+		go func() {
+			gzipWriter := gzip.NewWriter(pipeWriter)
+			_, err := io.Copy(gzipWriter, file)
+			if err == nil {
+				err = gzipWriter.Close()
+			}
+			pipeWriter.CloseWithError(err)
+		}()
+		content = pipeReader
+	}
+	if err := a.conf.Store.Put(a.ledgerID, name, content); err != nil {
+		return errors.WithMessagef(err, "error while storing the block file [%s] in the archive", filePath)
+	}
+
+	a.mutex.RLock()
+	info := &archivedBlockfilesInfo{compressed: append(append([]bool{}, a.info.compressed...), a.conf.Compress)}
+	a.mutex.RUnlock()
+	if err := saveArchivedBlockfilesInfo(a.ledgerDir, info); err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	a.info = info
+	a.mutex.Unlock()
+
+	if err := os.Remove(filePath); err != nil {
+		return errors.Wrapf(err, "error while removing the archived block file [%s]", filePath)
+	}
+	logger.Infof("Archived the block file number [%d] of the ledger [%s]", fileNum, a.ledgerID)
+	return syncDir(a.ledgerDir)
+}
+
+// open opens an archived block file for reading
+func (a *blockfileArchive) open(fileNum int) (blockfileSource, error) {
+	a.mutex.RLock()
+	compressed := a.info.compressed[fileNum]
+	a.mutex.RUnlock()
+
+	name := archivedBlockfileName(fileNum, compressed)
+	if !compressed {
+		obj, err := a.conf.Store.Open(a.ledgerID, name)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error opening the archived block file [%s] of the ledger [%s]", name, a.ledgerID)
+		}
+		return &archivedBlockfile{obj}, nil
+	}
+
+	a.cacheMutex.Lock()
+	defer a.cacheMutex.Unlock()
+	if a.cachedFileNum != fileNum {
+		content, err := a.decompress(name)
+		if err != nil {
+			return nil, err
+		}
+		a.cachedFileNum, a.cachedContent = fileNum, content
+	}
+	return &decompressedBlockfile{bytes.NewReader(a.cachedContent)}, nil
+}
+
+func (a *blockfileArchive) decompress(name string) ([]byte, error) {
+	obj, err := a.conf.Store.Open(a.ledgerID, name)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error opening the archived block file [%s] of the ledger [%s]", name, a.ledgerID)
+	}
+	defer obj.Close()
+	gzipReader, err := gzip.NewReader(io.NewSectionReader(obj, 0, obj.Size()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decompressing the archived block file [%s] of the ledger [%s]", name, a.ledgerID)
+	}
+	content, err := ioutil.ReadAll(gzipReader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decompressing the archived block file [%s] of the ledger [%s]", name, a.ledgerID)
+	}
+	return content, nil
+}
+
+// startArchiver starts the goroutine that archives the block files of the ledger. The archiver is
+// signalled after each block is added and once at the start, for archiving the files that became
+// eligible while the peer was down
+func (mgr *blockfileMgr) startArchiver() {
+	mgr.archiverSignal = make(chan struct{}, 1)
+	mgr.archiverStop = make(chan struct{})
+	mgr.archiverDone = make(chan struct{})
+	go func() {
+		defer close(mgr.archiverDone)
+		for {
+			select {
+			case <-mgr.archiverStop:
+				return
+			case <-mgr.archiverSignal:
+				if err := mgr.archiveEligibleBlockfiles(); err != nil {
+					logger.Errorf("Error while archiving the block files of the ledger [%s]: %s", mgr.archive.ledgerID, err)
+				}
+			}
+		}
+	}()
+	mgr.signalArchiver()
+}
+
+func (mgr *blockfileMgr) signalArchiver() {
+	if mgr.archiverSignal == nil {
+		return
+	}
+	select {
+	case mgr.archiverSignal <- struct{}{}:
+	default:
+		// the archiver is already signalled
+	}
+}
+
+// archiveEligibleBlockfiles archives the block files in which all the blocks are older than the
+// retained blocks. The block file being written to and the one before it are never archived, as
+// these two files are scanned when the block files info is constructed from the block files
+func (mgr *blockfileMgr) archiveEligibleBlockfiles() error {
+	for {
+		select {
+		case <-mgr.archiverStop:
+			return nil
+		default:
+		}
+
+		mgr.blkfilesInfoCond.L.Lock()
+		latestFileNum := mgr.blockfilesInfo.latestFileNumber
+		mgr.blkfilesInfoCond.L.Unlock()
+		height := mgr.getBlockchainInfo().Height
+
+		fileNum := mgr.archive.numArchivedFiles()
+		if fileNum > latestFileNum-2 {
+			return nil
+		}
+		// the last block in a file precedes the first block in the next file
+		nextFileFirstBlockNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, nil, fileNum+1)
+		if err != nil {
+			return err
+		}
+		if nextFileFirstBlockNum+mgr.archive.conf.RetentionBlocks > height {
+			return nil
+		}
+		if err := mgr.archive.archiveBlockfile(fileNum); err != nil {
+			return err
+		}
+	}
+}
+
+// deleteArchivedBlockfiles deletes the archived block files of a ledger from the archive store
+func deleteArchivedBlockfiles(ledgerID, ledgerDir string, conf *ArchiveConf) error {
+	info, err := loadArchivedBlockfilesInfo(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if info.numArchivedFiles() == 0 {
+		return nil
+	}
+	if conf == nil {
+		logger.Warningf("[%d] block files of the ledger [%s] are archived but the block archive is not configured, leaving them in the archive",
+			info.numArchivedFiles(), ledgerID)
+		return nil
+	}
+	for fileNum, compressed := range info.compressed {
+		if err := conf.Store.Delete(ledgerID, archivedBlockfileName(fileNum, compressed)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func archivedBlockfileName(fileNum int, compressed bool) string {
+	name := filepath.Base(deriveBlockfilePath("", fileNum))
+	if compressed {
+		name += compressedBlockfileSuffix
+	}
+	return name
+}
+
+// archivedBlockfilesInfo is persisted in the ledger dir, instead of the index db, so that the information
+// remains available when the index is dropped and rebuilt from the block files
+type archivedBlockfilesInfo struct {
+	// compressed records, for each archived file, whether the file is compressed in the archive
+	compressed []bool
+}
+
+func (i *archivedBlockfilesInfo) numArchivedFiles() int {
+	return len(i.compressed)
+}
+
+func (i *archivedBlockfilesInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(len(i.compressed))); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the number of archived files [%d]", len(i.compressed))
+	}
+	for _, compressed := range i.compressed {
+		var compressedMarker uint64
+		if compressed {
+			compressedMarker = 1
+		}
+		if err := buffer.EncodeVarint(compressedMarker); err != nil {
+			return nil, errors.Wrapf(err, "error encoding the compressed marker [%d]", compressedMarker)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *archivedBlockfilesInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	numFiles, err := buffer.DecodeVarint()
+	if err != nil {
+		return errors.Wrap(err, "error decoding the number of archived files")
+	}
+	i.compressed = make([]bool, numFiles)
+	for n := range i.compressed {
+		compressedMarker, err := buffer.DecodeVarint()
+		if err != nil {
+			return errors.Wrap(err, "error decoding the compressed marker")
+		}
+		i.compressed[n] = compressedMarker == 1
+	}
+	return nil
+}
+
+func loadArchivedBlockfilesInfo(ledgerDir string) (*archivedBlockfilesInfo, error) {
+	b, err := ioutil.ReadFile(filepath.Join(ledgerDir, archivedBlockfilesInfoFile))
+	if os.IsNotExist(err) {
+		return &archivedBlockfilesInfo{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the archived block files info from dir [%s]", ledgerDir)
+	}
+	info := &archivedBlockfilesInfo{}
+	if err := info.unmarshal(b); err != nil {
+		return nil, errors.WithMessagef(err, "error while unmarshalling the archived block files info from dir [%s]", ledgerDir)
+	}
+	return info, nil
+}
+
+func saveArchivedBlockfilesInfo(ledgerDir string, info *archivedBlockfilesInfo) error {
+	b, err := info.marshal()
+	if err != nil {
+		return err
+	}
+	// a temp file may be left over by a crash during a previous attempt
+	if err := os.Remove(filepath.Join(ledgerDir, archivedBlockfilesInfoTempFile)); err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	return createAndSyncFileAtomically(ledgerDir, archivedBlockfilesInfoTempFile, archivedBlockfilesInfoFile, b)
+}
+
+// blockfileSource is the content of a block file that is either present in the local block storage
+// or archived in the archive store
+type blockfileSource interface {
+	io.ReaderAt
+	io.Closer
+	size() (int64, error)
+}
+
+// openBlockfile opens the given block file of a ledger for reading, from the archive if the file is archived
+func openBlockfile(rootDir string, archive *blockfileArchive, fileNum int) (blockfileSource, error) {
+	if archive.isArchived(fileNum) {
+		return archive.open(fileNum)
+	}
+	filePath := deriveBlockfilePath(rootDir, fileNum)
+	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+	if err != nil {
+		if os.IsNotExist(err) && archive.isArchived(fileNum) {
+			// the file got archived after the check above
+			return archive.open(fileNum)
+		}
+		return nil, errors.Wrapf(err, "error opening block file %s", filePath)
+	}
+	return &localBlockfile{file}, nil
+}
+
+type localBlockfile struct {
+	*os.File
+}
+
+func (f *localBlockfile) size() (int64, error) {
+	// the size is not cached as the last block file grows while being read
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return 0, errors.Wrapf(err, "error getting block file stat")
+	}
+	return fileInfo.Size(), nil
+}
+
+type archivedBlockfile struct {
+	ArchivedObject
+}
+
+func (f *archivedBlockfile) size() (int64, error) {
+	return f.Size(), nil
+}
+
+type decompressedBlockfile struct {
+	*bytes.Reader
+}
+
+func (f *decompressedBlockfile) size() (int64, error) {
+	return f.Size(), nil
+}
+
+func (f *decompressedBlockfile) Close() error {
+	return nil
+}
+
+// blockfileSourceReader reads a blockfileSource sequentially, starting at the given offset
+type blockfileSourceReader struct {
+	source blockfileSource
+	offset int64
+}
+
+func (r *blockfileSourceReader) Read(p []byte) (int, error) {
+	n, err := r.source.ReadAt(p, r.offset)
+	r.offset += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockfileArchiving(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		compress := compress
+		name := "uncompressed"
+		if compress {
+			name = "compressed"
+		}
+		t.Run(name, func(t *testing.T) {
+			testBlockfileArchiving(t, compress)
+		})
+	}
+}
+
+func testBlockfileArchiving(t *testing.T, compress bool) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	archiveDir := filepath.Join(path, "archive")
+	archiveStore, err := NewFilesystemArchiveStore(archiveDir)
+	require.NoError(t, err)
+	conf := NewConfWithArchive(filepath.Join(path, "blockstore"), 0, &ArchiveConf{
+		Store:           archiveStore,
+		RetentionBlocks: 8,
+		Compress:        compress,
+	})
+	ledgerDir := conf.getLedgerBlockDir("testLedger")
+
+	// the blocks are stored three per block file, i.e., the files [0] to [6] contain the blocks
+	// [0-2], [3-5], [6-8], [9-11], [12-14], [15-17], and [18-19]. As the most recent eight blocks are
+	// retained, the files [0] to [3] are archived
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	env := newTestEnv(t, conf)
+	w := newTestBlockfileWrapper(env, "testLedger")
+	for i, b := range blocks {
+		require.NoError(t, w.blockfileMgr.addBlock(b))
+		if i%3 == 2 {
+			w.blockfileMgr.moveToNextFile()
+		}
+	}
+	require.Eventually(t, func() bool { return w.blockfileMgr.archive.numArchivedFiles() == 4 }, 10*time.Second, 10*time.Millisecond)
+
+	for fileNum := 0; fileNum < 7; fileNum++ {
+		_, err := os.Stat(deriveBlockfilePath(ledgerDir, fileNum))
+		require.Equal(t, fileNum < 4, os.IsNotExist(err), "block file number [%d]", fileNum)
+		_, err = os.Stat(filepath.Join(archiveDir, "testLedger", archivedBlockfileName(fileNum, compress)))
+		require.Equal(t, fileNum >= 4, os.IsNotExist(err), "block file number [%d]", fileNum)
+	}
+
+	verifyBlocks := func(w *testBlockfileMgrWrapper) {
+		w.testGetBlockByNumber(blocks, 0, nil)
+		w.testGetBlockByHash(blocks, nil)
+		w.testGetBlockByTxID(blocks, nil)
+		txID, err := protoutil.GetOrComputeTxIDFromEnvelope(blocks[1].Data.Data[0])
+		require.NoError(t, err)
+		w.testGetTransactionByTxID(txID, blocks[1].Data.Data[0], nil)
+
+		itr, err := w.blockfileMgr.retrieveBlocks(0)
+		require.NoError(t, err)
+		defer itr.Close()
+		for _, expectedBlock := range blocks {
+			b, err := itr.Next()
+			require.NoError(t, err)
+			require.Equal(t, expectedBlock, b.(*common.Block))
+		}
+	}
+	verifyBlocks(w)
+	w.close()
+	env.provider.Close()
+
+	t.Run("reopen-after-index-rebuild", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(conf.getIndexDir()))
+		env := newTestEnv(t, conf)
+		defer env.provider.Close()
+		w := newTestBlockfileWrapper(env, "testLedger")
+		defer w.close()
+		verifyBlocks(w)
+	})
+
+	t.Run("leftover-local-copy-removed", func(t *testing.T) {
+		leftoverFile := deriveBlockfilePath(ledgerDir, 3)
+		require.NoError(t, ioutil.WriteFile(leftoverFile, []byte("leftover-local-copy"), 0o644))
+		env := newTestEnv(t, conf)
+		defer env.provider.Close()
+		w := newTestBlockfileWrapper(env, "testLedger")
+		defer w.close()
+		_, err := os.Stat(leftoverFile)
+		require.True(t, os.IsNotExist(err))
+		verifyBlocks(w)
+	})
+
+	t.Run("archive-not-configured", func(t *testing.T) {
+		env := newTestEnv(t, NewConf(conf.blockStorageDir, 0))
+		defer env.provider.Close()
+		_, err := env.provider.Open("testLedger")
+		require.EqualError(t, err, "[4] block files of the ledger [testLedger] are archived but the block archive is not configured")
+	})
+
+	t.Run("verify-block-store", func(t *testing.T) {
+		result, err := VerifyBlockStore(conf, "testLedger", &IndexConfig{AttrsToIndex: attrsToIndex}, nil)
+		require.NoError(t, err)
+		require.Equal(t, &VerificationResult{FirstBlockNum: 0, Height: 20}, result)
+	})
+
+	t.Run("offline-truncation-not-supported", func(t *testing.T) {
+		expectedErr := "the operation is not supported as [4] block files of the ledger [testLedger] are archived"
+		require.EqualError(t, Rollback(conf.blockStorageDir, "testLedger", 5, &IndexConfig{AttrsToIndex: attrsToIndex}), expectedErr)
+		require.EqualError(t, ResetBlockStore(conf.blockStorageDir), expectedErr)
+	})
+
+	t.Run("remove-ledger", func(t *testing.T) {
+		env := newTestEnv(t, conf)
+		defer env.provider.Close()
+		require.NoError(t, env.provider.Remove("testLedger"))
+		archivedFiles, err := ioutil.ReadDir(filepath.Join(archiveDir, "testLedger"))
+		require.NoError(t, err)
+		require.Empty(t, archivedFiles)
+	})
+}
+
+func TestArchivedBlockfilesInfo(t *testing.T) {
+	dir := testPath()
+	defer os.RemoveAll(dir)
+
+	info, err := loadArchivedBlockfilesInfo(dir)
+	require.NoError(t, err)
+	require.Equal(t, 0, info.numArchivedFiles())
+
+	info = &archivedBlockfilesInfo{compressed: []bool{false, true, true}}
+	require.NoError(t, saveArchivedBlockfilesInfo(dir, info))
+	loadedInfo, err := loadArchivedBlockfilesInfo(dir)
+	require.NoError(t, err)
+	require.Equal(t, info, loadedInfo)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, archivedBlockfilesInfoFile), []byte{0xff}, 0o644))
+	_, err = loadArchivedBlockfilesInfo(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while unmarshalling the archived block files info from dir")
+}
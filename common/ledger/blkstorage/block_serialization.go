@@ -17,6 +17,7 @@ type serializedBlockInfo struct {
 	blockHeader *common.BlockHeader
 	txOffsets   []*txindexInfo
 	metadata    *common.BlockMetadata
+	data        *common.BlockData
 }
 
 //The order of the transactions must be maintained for history
@@ -31,6 +32,7 @@ func serializeBlock(block *common.Block) ([]byte, *serializedBlockInfo, error) {
 	info := &serializedBlockInfo{}
 	info.blockHeader = block.Header
 	info.metadata = block.Metadata
+	info.data = block.Data
 	if err = addHeaderBytes(block.Header, buf); err != nil {
 		return nil, nil, err
 	}
@@ -67,7 +69,7 @@ func extractSerializedBlockInfo(serializedBlockBytes []byte) (*serializedBlockIn
 	if err != nil {
 		return nil, err
 	}
-	_, info.txOffsets, err = extractData(b)
+	info.data, info.txOffsets, err = extractData(b)
 	if err != nil {
 		return nil, err
 	}
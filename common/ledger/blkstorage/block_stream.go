@@ -10,7 +10,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
@@ -25,7 +24,8 @@ var ErrUnexpectedEndOfBlockfile = errors.New("unexpected end of blockfile")
 // It starts from the given offset and can traverse till the end of the file
 type blockfileStream struct {
 	fileNum       int
-	file          *os.File
+	file          blockfileSource
+	format        blockfileFormat
 	reader        *bufio.Reader
 	currentOffset int64
 }
@@ -35,6 +35,7 @@ type blockfileStream struct {
 // file segment until the end of the last segment (`endFileNum`)
 type blockStream struct {
 	rootDir           string
+	archive           *blockfileArchive
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
@@ -46,28 +47,28 @@ type blockPlacementInfo struct {
 	fileNum          int
 	blockStartOffset int64
 	blockBytesOffset int64
+	compressed       bool
 }
 
 ///////////////////////////////////
 // blockfileStream functions
 ////////////////////////////////////
-func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockfileStream, error) {
-	filePath := deriveBlockfilePath(rootDir, fileNum)
-	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", filePath, startOffset)
-	var file *os.File
-	var err error
-	if file, err = os.OpenFile(filePath, os.O_RDONLY, 0600); err != nil {
-		return nil, errors.Wrapf(err, "error opening block file %s", filePath)
+func newBlockfileStream(rootDir string, archive *blockfileArchive, fileNum int, startOffset int64) (*blockfileStream, error) {
+	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", deriveBlockfilePath(rootDir, fileNum), startOffset)
+	file, err := openBlockfile(rootDir, archive, fileNum)
+	if err != nil {
+		return nil, err
 	}
-	var newPosition int64
-	if newPosition, err = file.Seek(startOffset, 0); err != nil {
-		return nil, errors.Wrapf(err, "error seeking block file [%s] to startOffset [%d]", filePath, startOffset)
+	format, headerLen, err := readBlockfileFormat(file)
+	if err != nil {
+		file.Close()
+		return nil, errors.WithMessagef(err, "error reading block file %s", deriveBlockfilePath(rootDir, fileNum))
 	}
-	if newPosition != startOffset {
-		panic(fmt.Sprintf("Could not seek block file [%s] to startOffset [%d]. New position = [%d]",
-			filePath, startOffset, newPosition))
+	if startOffset < headerLen {
+		startOffset = headerLen
 	}
-	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
+	reader := bufio.NewReader(&blockfileSourceReader{source: file, offset: startOffset})
+	s := &blockfileStream{fileNum, file, format, reader, startOffset}
 	return s, nil
 }
 
@@ -83,17 +84,17 @@ func (s *blockfileStream) nextBlockBytes() ([]byte, error) {
 func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacementInfo, error) {
 	var lenBytes []byte
 	var err error
-	var fileInfo os.FileInfo
+	var fileSize int64
 	moreContentAvailable := true
 
-	if fileInfo, err = s.file.Stat(); err != nil {
-		return nil, nil, errors.Wrapf(err, "error getting block file stat")
+	if fileSize, err = s.file.size(); err != nil {
+		return nil, nil, err
 	}
-	if s.currentOffset == fileInfo.Size() {
+	if s.currentOffset == fileSize {
 		logger.Debugf("Finished reading file number [%d]", s.fileNum)
 		return nil, nil, nil
 	}
-	remainingBytes := fileInfo.Size() - s.currentOffset
+	remainingBytes := fileSize - s.currentOffset
 	// Peek 8 or smaller number of bytes (if remaining bytes are less than 8)
 	// Assumption is that a block size would be small enough to be represented in 8 bytes varint
 	peekBytes := 8
@@ -124,15 +125,21 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 	if _, err = s.reader.Discard(n); err != nil {
 		return nil, nil, errors.Wrapf(err, "error discarding [%d] bytes", n)
 	}
-	blockBytes := make([]byte, length)
-	if _, err = io.ReadAtLeast(s.reader, blockBytes, int(length)); err != nil {
+	encodedBlockBytes := make([]byte, length)
+	if _, err = io.ReadAtLeast(s.reader, encodedBlockBytes, int(length)); err != nil {
 		logger.Errorf("Error reading [%d] bytes from file number [%d], error: %s", length, s.fileNum, err)
 		return nil, nil, errors.Wrapf(err, "error reading [%d] bytes from file number [%d]", length, s.fileNum)
 	}
+	blockBytes, err := s.format.decodeBlock(encodedBlockBytes)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "error decoding the block at offset [%d] in file number [%d]", s.currentOffset, s.fileNum)
+	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
-		blockBytesOffset: s.currentOffset + int64(n)}
+		blockBytesOffset: s.currentOffset + int64(n),
+		compressed:       s.format.isCompressed(),
+	}
 	s.currentOffset += int64(n) + int64(length)
 	logger.Debugf("Returning blockbytes - length=[%d], placementInfo={%s}", len(blockBytes), blockPlacementInfo)
 	return blockBytes, blockPlacementInfo, nil
@@ -145,12 +152,12 @@ func (s *blockfileStream) close() error {
 ///////////////////////////////////
 // blockStream functions
 ////////////////////////////////////
-func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
-	startFileStream, err := newBlockfileStream(rootDir, startFileNum, startOffset)
+func newBlockStream(rootDir string, archive *blockfileArchive, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	startFileStream, err := newBlockfileStream(rootDir, archive, startFileNum, startOffset)
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{rootDir, archive, startFileNum, endFileNum, startFileStream}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -159,7 +166,7 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
-	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
+	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.archive, s.currentFileNum, 0); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// These are the supported values for the compression of the blocks in the block files
+const (
+	// BlockfileCompressionNone stores the serialized blocks as is
+	BlockfileCompressionNone = "none"
+	// BlockfileCompressionSnappy compresses each serialized block with snappy
+	BlockfileCompressionSnappy = "snappy"
+)
+
+// blockfileFormat identifies how the blocks are framed in a block file
+type blockfileFormat uint8
+
+const (
+	// blockfileFormatRaw is the format of the block files written by the previous versions. Each block is stored as the
+	// varint encoded length of the serialized block followed by the serialized block. A file in this format has no header
+	blockfileFormatRaw blockfileFormat = iota
+	// blockfileFormatSnappy stores each block as the varint encoded length of the snappy compressed
+	// serialized block followed by the compressed bytes
+	blockfileFormatSnappy
+)
+
+// A block file in a format other than `blockfileFormatRaw` starts with a header that consists of the
+// `blockfileHeaderMarker` followed by the format. As the length of a serialized block is never zero,
+// a block file in the raw format never starts with this byte
+const (
+	blockfileHeaderMarker byte = 0x00
+	blockfileHeaderLen         = 2
+)
+
+func blockfileFormatForCompression(compression string) (blockfileFormat, error) {
+	switch compression {
+	case "", BlockfileCompressionNone:
+		return blockfileFormatRaw, nil
+	case BlockfileCompressionSnappy:
+		return blockfileFormatSnappy, nil
+	default:
+		return 0, errors.Errorf("unsupported block file compression [%s], supported values are [%s] and [%s]",
+			compression, BlockfileCompressionNone, BlockfileCompressionSnappy)
+	}
+}
+
+func (f blockfileFormat) header() []byte {
+	if f == blockfileFormatRaw {
+		return nil
+	}
+	return []byte{blockfileHeaderMarker, byte(f)}
+}
+
+// isCompressed returns true if the blocks are compressed in the block files of this format. The transactions in a
+// compressed block are located by the offsets in the uncompressed block bytes instead of the offsets in the block file
+func (f blockfileFormat) isCompressed() bool {
+	return f != blockfileFormatRaw
+}
+
+// encodeBlock returns the bytes that represent the serialized block in a block file of this format
+func (f blockfileFormat) encodeBlock(blockBytes []byte) []byte {
+	if f == blockfileFormatSnappy {
+		return snappy.Encode(nil, blockBytes)
+	}
+	return blockBytes
+}
+
+// decodeBlock returns the serialized block represented by the given bytes in a block file of this format
+func (f blockfileFormat) decodeBlock(b []byte) ([]byte, error) {
+	if f == blockfileFormatSnappy {
+		blockBytes, err := snappy.Decode(nil, b)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decompressing the block")
+		}
+		return blockBytes, nil
+	}
+	return b, nil
+}
+
+func (f blockfileFormat) String() string {
+	switch f {
+	case blockfileFormatRaw:
+		return "raw"
+	case blockfileFormatSnappy:
+		return BlockfileCompressionSnappy
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(f))
+	}
+}
+
+// readBlockfileFormat reads the header of the block file and returns the format and the length of the header
+func readBlockfileFormat(file blockfileSource) (blockfileFormat, int64, error) {
+	size, err := file.size()
+	if err != nil {
+		return 0, 0, err
+	}
+	if size == 0 {
+		return blockfileFormatRaw, 0, nil
+	}
+	header := make([]byte, blockfileHeaderLen)
+	if size < blockfileHeaderLen {
+		header = header[:size]
+	}
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return 0, 0, errors.Wrap(err, "error reading the block file header")
+	}
+	if header[0] != blockfileHeaderMarker {
+		return blockfileFormatRaw, 0, nil
+	}
+	if len(header) < blockfileHeaderLen {
+		return 0, 0, errors.Wrap(ErrUnexpectedEndOfBlockfile, "incomplete block file header")
+	}
+	format := blockfileFormat(header[1])
+	if format != blockfileFormatSnappy {
+		return 0, 0, errors.Errorf("unsupported block file format [%d]", header[1])
+	}
+	return format, blockfileHeaderLen, nil
+}
@@ -0,0 +1,253 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockfileFormatForCompression(t *testing.T) {
+	for compression, expectedFormat := range map[string]blockfileFormat{
+		"":                         blockfileFormatRaw,
+		BlockfileCompressionNone:   blockfileFormatRaw,
+		BlockfileCompressionSnappy: blockfileFormatSnappy,
+	} {
+		format, err := blockfileFormatForCompression(compression)
+		require.NoError(t, err)
+		require.Equal(t, expectedFormat, format)
+	}
+
+	_, err := NewConf(testPath(), 0).WithBlockfileCompression("zip")
+	require.EqualError(t, err, "unsupported block file compression [zip], supported values are [none] and [snappy]")
+}
+
+func TestCompressedBlockfiles(t *testing.T) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	conf, err := NewConf(path, 0).WithBlockfileCompression(BlockfileCompressionSnappy)
+	require.NoError(t, err)
+	ledgerDir := conf.getLedgerBlockDir("testLedger")
+
+	allBlocks := testutil.ConstructTestBlocks(t, 11)
+	blocks := allBlocks[:10]
+	env := newTestEnv(t, conf)
+	w := newTestBlockfileWrapper(env, "testLedger")
+	addBlocksToBlockfiles(t, w, blocks, 4)
+	verifyBlocksInBlockfiles(t, w, blocks)
+	w.close()
+	env.provider.Close()
+
+	for fileNum := 0; fileNum < 3; fileNum++ {
+		require.Equal(t, blockfileFormatSnappy, retrieveBlockfileFormatForTest(t, ledgerDir, fileNum))
+	}
+
+	t.Run("reopen-after-index-rebuild", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(conf.getIndexDir()))
+		env := newTestEnv(t, conf)
+		defer env.provider.Close()
+		w := newTestBlockfileWrapper(env, "testLedger")
+		defer w.close()
+		verifyBlocksInBlockfiles(t, w, blocks)
+	})
+
+	t.Run("verify-block-store", func(t *testing.T) {
+		result, err := VerifyBlockStore(conf, "testLedger", &IndexConfig{AttrsToIndex: attrsToIndex}, nil)
+		require.NoError(t, err)
+		require.Equal(t, &VerificationResult{FirstBlockNum: 0, Height: 10}, result)
+	})
+
+	t.Run("partially-written-block", func(t *testing.T) {
+		// simulate a crash after writing a part of the next block
+		lastFile := deriveBlockfilePath(ledgerDir, 2)
+		content, err := ioutil.ReadFile(lastFile)
+		require.NoError(t, err)
+		partialBlock := append(proto.EncodeVarint(100), make([]byte, 10)...)
+		require.NoError(t, ioutil.WriteFile(lastFile, append(content, partialBlock...), 0o644))
+
+		env := newTestEnv(t, conf)
+		defer env.provider.Close()
+		w := newTestBlockfileWrapper(env, "testLedger")
+		defer w.close()
+		require.Equal(t, uint64(10), w.blockfileMgr.getBlockchainInfo().Height)
+		verifyBlocksInBlockfiles(t, w, blocks)
+
+		require.NoError(t, w.blockfileMgr.addBlock(allBlocks[10]))
+		verifyBlocksInBlockfiles(t, w, allBlocks)
+	})
+}
+
+func TestMixedBlockfileFormats(t *testing.T) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	rawConf := NewConf(path, 0)
+	snappyConf, err := rawConf.WithBlockfileCompression(BlockfileCompressionSnappy)
+	require.NoError(t, err)
+	ledgerDir := rawConf.getLedgerBlockDir("testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 12)
+
+	// the blocks [0-3] are written in the raw format, the blocks [4-7] in the snappy format, and
+	// the blocks [8-11] in the raw format again. The format changes only with the next block file,
+	// except for the empty current file, which is switched to the newly configured format
+	for i, conf := range []*Conf{rawConf, snappyConf, rawConf} {
+		env := newTestEnv(t, conf)
+		w := newTestBlockfileWrapper(env, "testLedger")
+		addBlocksToBlockfiles(t, w, blocks[i*4:(i+1)*4], 4)
+		verifyBlocksInBlockfiles(t, w, blocks[:(i+1)*4])
+		w.close()
+		env.provider.Close()
+	}
+
+	expectedFormats := []blockfileFormat{blockfileFormatRaw, blockfileFormatSnappy, blockfileFormatRaw}
+	for fileNum, expectedFormat := range expectedFormats {
+		require.Equal(t, expectedFormat, retrieveBlockfileFormatForTest(t, ledgerDir, fileNum))
+	}
+
+	require.NoError(t, os.RemoveAll(rawConf.getIndexDir()))
+	env := newTestEnv(t, snappyConf)
+	defer env.provider.Close()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	verifyBlocksInBlockfiles(t, w, blocks)
+}
+
+func TestConvertBlockfiles(t *testing.T) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	conf := NewConf(path, 0)
+	ledgerDir := conf.getLedgerBlockDir("testLedger")
+
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	env := newTestEnv(t, conf)
+	w := newTestBlockfileWrapper(env, "testLedger")
+	addBlocksToBlockfiles(t, w, blocks, 4)
+	w.close()
+	env.provider.Close()
+
+	for _, compression := range []string{BlockfileCompressionSnappy, BlockfileCompressionNone} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			require.NoError(t, DeleteBlockStoreIndex(path))
+			require.NoError(t, ConvertBlockfiles(path, compression))
+			expectedFormat, err := blockfileFormatForCompression(compression)
+			require.NoError(t, err)
+			for fileNum := 0; fileNum < 3; fileNum++ {
+				require.Equal(t, expectedFormat, retrieveBlockfileFormatForTest(t, ledgerDir, fileNum))
+			}
+			_, err = os.Stat(deriveBlockfilePath(ledgerDir, 3))
+			require.True(t, os.IsNotExist(err))
+
+			conf, err := NewConf(path, 0).WithBlockfileCompression(compression)
+			require.NoError(t, err)
+			env := newTestEnv(t, conf)
+			defer env.provider.Close()
+			w := newTestBlockfileWrapper(env, "testLedger")
+			defer w.close()
+			verifyBlocksInBlockfiles(t, w, blocks)
+		})
+	}
+
+	t.Run("no-ledgers", func(t *testing.T) {
+		require.NoError(t, ConvertBlockfiles(testPath(), BlockfileCompressionSnappy))
+	})
+
+	t.Run("unsupported-compression", func(t *testing.T) {
+		require.EqualError(t, ConvertBlockfiles(path, "zip"), "unsupported block file compression [zip], supported values are [none] and [snappy]")
+	})
+}
+
+func TestBlockfilesInfoBackwardCompatibility(t *testing.T) {
+	// the blockfiles info written by the previous versions does not contain the format of the latest file
+	buffer := proto.NewBuffer([]byte{})
+	for _, v := range []uint64{3, 1024, 20, 0} {
+		require.NoError(t, buffer.EncodeVarint(v))
+	}
+	info := &blockfilesInfo{}
+	require.NoError(t, info.unmarshal(buffer.Bytes()))
+	require.Equal(t, &blockfilesInfo{
+		latestFileNumber:   3,
+		latestFileSize:     1024,
+		lastPersistedBlock: 20,
+		latestFileFormat:   blockfileFormatRaw,
+	}, info)
+
+	info.latestFileFormat = blockfileFormatSnappy
+	b, err := info.marshal()
+	require.NoError(t, err)
+	unmarshalledInfo := &blockfilesInfo{}
+	require.NoError(t, unmarshalledInfo.unmarshal(b))
+	require.Equal(t, info, unmarshalledInfo)
+}
+
+func TestFileLocPointerBackwardCompatibility(t *testing.T) {
+	// the file location pointers written by the previous versions do not contain the offset of the transaction in the block
+	buffer := proto.NewBuffer([]byte{})
+	for _, v := range []uint64{2, 100, 50} {
+		require.NoError(t, buffer.EncodeVarint(v))
+	}
+	flp := &fileLocPointer{}
+	require.NoError(t, flp.unmarshal(buffer.Bytes()))
+	require.Equal(t, &fileLocPointer{fileSuffixNum: 2, locPointer: locPointer{offset: 100, bytesLength: 50}}, flp)
+
+	blockFLP := &fileLocPointer{fileSuffixNum: 2, locPointer: locPointer{offset: 100}}
+	txFLP := newTxFileLocationPointer(blockFLP, true, &locPointer{offset: 20, bytesLength: 40})
+	b, err := txFLP.marshal()
+	require.NoError(t, err)
+	unmarshalledFLP := &fileLocPointer{}
+	require.NoError(t, unmarshalledFLP.unmarshal(b))
+	require.Equal(t, txFLP, unmarshalledFLP)
+	require.Equal(t, blockFLP, unmarshalledFLP.blockLoc())
+}
+
+// addBlocksToBlockfiles adds the blocks and moves to the next block file after every `blocksPerFile` blocks
+func addBlocksToBlockfiles(t *testing.T, w *testBlockfileMgrWrapper, blocks []*common.Block, blocksPerFile int) {
+	for i, b := range blocks {
+		require.NoError(t, w.blockfileMgr.addBlock(b))
+		if i%blocksPerFile == blocksPerFile-1 {
+			w.blockfileMgr.moveToNextFile()
+		}
+	}
+}
+
+func verifyBlocksInBlockfiles(t *testing.T, w *testBlockfileMgrWrapper, blocks []*common.Block) {
+	w.testGetBlockByNumber(blocks, 0, nil)
+	w.testGetBlockByHash(blocks, nil)
+	w.testGetBlockByTxID(blocks, nil)
+	for _, b := range blocks {
+		for tranNum, envBytes := range b.Data.Data {
+			txID, err := protoutil.GetOrComputeTxIDFromEnvelope(envBytes)
+			require.NoError(t, err)
+			w.testGetTransactionByTxID(txID, envBytes, nil)
+
+			env, err := w.blockfileMgr.retrieveTransactionByBlockNumTranNum(b.Header.Number, uint64(tranNum))
+			require.NoError(t, err)
+			require.Equal(t, envBytes, protoutil.MarshalOrPanic(env))
+		}
+	}
+
+	itr, err := w.blockfileMgr.retrieveBlocks(0)
+	require.NoError(t, err)
+	defer itr.Close()
+	for _, expectedBlock := range blocks {
+		b, err := itr.Next()
+		require.NoError(t, err)
+		require.Equal(t, expectedBlock, b.(*common.Block))
+	}
+}
+
+func retrieveBlockfileFormatForTest(t *testing.T, ledgerDir string, fileNum int) blockfileFormat {
+	format, err := retrieveBlockfileFormat(ledgerDir, fileNum)
+	require.NoError(t, err)
+	return format
+}
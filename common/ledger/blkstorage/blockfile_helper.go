@@ -56,6 +56,11 @@ func constructBlockfilesInfo(rootDir string) (*blockfilesInfo, error) {
 		return nil, err
 	}
 
+	lastFileFormat, err := retrieveBlockfileFormat(rootDir, lastFileNum)
+	if err != nil {
+		return nil, err
+	}
+
 	if numBlocksInFile == 0 && lastFileNum > 0 {
 		secondLastFileNum := lastFileNum - 1
 		fileInfo := getFileInfoOrPanic(rootDir, secondLastFileNum)
@@ -79,6 +84,7 @@ func constructBlockfilesInfo(rootDir string) (*blockfilesInfo, error) {
 		latestFileSize:     int(endOffsetLastBlock),
 		latestFileNumber:   lastFileNum,
 		noBlockFiles:       lastFileNum == 0 && numBlocksInFile == 0,
+		latestFileFormat:   lastFileFormat,
 	}
 	logger.Debugf("blockfilesInfo constructed from file system = %s", spew.Sdump(blkfilesInfo))
 	return blkfilesInfo, nil
@@ -98,7 +104,7 @@ func binarySearchFileNumForBlock(rootDir string, blockNum uint64) (int, error) {
 
 	for endFile != beginFile {
 		searchFile := beginFile + (endFile-beginFile)/2 + 1
-		n, err := retrieveFirstBlockNumFromFile(rootDir, searchFile)
+		n, err := retrieveFirstBlockNumFromFile(rootDir, nil, searchFile)
 		if err != nil {
 			return -1, err
 		}
@@ -114,8 +120,8 @@ func binarySearchFileNumForBlock(rootDir string, blockNum uint64) (int, error) {
 	return beginFile, nil
 }
 
-func retrieveFirstBlockNumFromFile(rootDir string, fileNum int) (uint64, error) {
-	s, err := newBlockfileStream(rootDir, fileNum, 0)
+func retrieveFirstBlockNumFromFile(rootDir string, archive *blockfileArchive, fileNum int) (uint64, error) {
+	s, err := newBlockfileStream(rootDir, archive, fileNum, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -131,6 +137,15 @@ func retrieveFirstBlockNumFromFile(rootDir string, fileNum int) (uint64, error)
 	return blockInfo.blockHeader.Number, nil
 }
 
+func retrieveBlockfileFormat(rootDir string, fileNum int) (blockfileFormat, error) {
+	s, err := newBlockfileStream(rootDir, nil, fileNum, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer s.close()
+	return s.format, nil
+}
+
 func retrieveLastFileSuffix(rootDir string) (int, error) {
 	logger.Debugf("retrieveLastFileSuffix()")
 	biggestFileNum := -1
@@ -9,16 +9,21 @@ package blkstorage
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"math"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
@@ -43,6 +48,12 @@ type blockfileMgr struct {
 	blkfilesInfoCond          *sync.Cond
 	currentFileWriter         *blockfileWriter
 	bcInfo                    atomic.Value
+	hashingAlgorithmName      string
+	hashingAlgorithm          func([]byte) []byte
+	archive                   *blockfileArchive
+	archiverSignal            chan struct{}
+	archiverStop              chan struct{}
+	archiverDone              chan struct{}
 }
 
 /*
@@ -94,6 +105,14 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 		panic(fmt.Sprintf("Error creating block storage root dir [%s]: %s", rootDir, err))
 	}
 	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	if mgr.archive, err = openBlockfileArchive(id, rootDir, conf.archiveConf); err != nil {
+		return nil, err
+	}
+	if mgr.archive != nil {
+		if err := mgr.archive.removeLocalCopies(); err != nil {
+			return nil, err
+		}
+	}
 
 	blockfilesInfo, err := mgr.loadBlkfilesInfo()
 	if err != nil {
@@ -122,6 +141,18 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 	if err != nil {
 		panic(fmt.Sprintf("Could not truncate current file to known size in db: %s", err))
 	}
+	if blockfilesInfo.latestFileSize == len(blockfilesInfo.latestFileFormat.header()) &&
+		blockfilesInfo.latestFileFormat != conf.blockfileFormat {
+		// the current file contains no block and hence, is switched to the configured format
+		if err := currentFileWriter.reset(conf.blockfileFormat); err != nil {
+			panic(fmt.Sprintf("Could not write the header to current file: %s", err))
+		}
+		blockfilesInfo.latestFileSize = len(conf.blockfileFormat.header())
+		blockfilesInfo.latestFileFormat = conf.blockfileFormat
+		if err := mgr.saveBlkfilesInfo(blockfilesInfo, true); err != nil {
+			panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
+		}
+	}
 	if mgr.index, err = newBlockIndex(indexConfig, indexStore); err != nil {
 		panic(fmt.Sprintf("error in block index: %s", err))
 	}
@@ -135,6 +166,17 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 	mgr.currentFileWriter = currentFileWriter
 	mgr.blkfilesInfoCond = sync.NewCond(&sync.Mutex{})
 
+	// The block hashing algorithm is fixed by the genesis block of the channel.
+	// Until the genesis block is added, SHA-256 is assumed.
+	if mgr.hashingAlgorithmName, err = blockHashingAlgorithmOfLedger(
+		rootDir, mgr.archive, mgr.bootstrappingSnapshotInfo, blockfilesInfo.noBlockFiles,
+	); err != nil {
+		return nil, errors.WithMessage(err, "error while determining the block hashing algorithm")
+	}
+	if mgr.hashingAlgorithm, err = channelconfig.HashingAlgorithmByName(mgr.hashingAlgorithmName); err != nil {
+		return nil, errors.WithMessage(err, "error while determining the block hashing algorithm")
+	}
+
 	if err := mgr.syncIndex(); err != nil {
 		return nil, err
 	}
@@ -145,6 +187,9 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 		bcInfo.Height = mgr.bootstrappingSnapshotInfo.LastBlockNum + 1
 		bcInfo.CurrentBlockHash = mgr.bootstrappingSnapshotInfo.LastBlockHash
 		bcInfo.PreviousBlockHash = mgr.bootstrappingSnapshotInfo.PreviousBlockHash
+		bcInfo.BootstrappingSnapshotInfo = &common.BootstrappingSnapshotInfo{
+			LastBlockInSnapshot: mgr.bootstrappingSnapshotInfo.LastBlockNum,
+		}
 	}
 
 	if !blockfilesInfo.noBlockFiles {
@@ -152,14 +197,19 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 		if err != nil {
 			panic(fmt.Sprintf("Could not retrieve header of the last block form file: %s", err))
 		}
-		lastBlockHash := protoutil.BlockHeaderHash(lastBlockHeader)
+		lastBlockHash := protoutil.BlockHeaderHashWith(lastBlockHeader, mgr.hashingAlgorithm)
 		previousBlockHash := lastBlockHeader.PreviousHash
 		bcInfo = &common.BlockchainInfo{
-			Height:            blockfilesInfo.lastPersistedBlock + 1,
-			CurrentBlockHash:  lastBlockHash,
-			PreviousBlockHash: previousBlockHash}
+			Height:                    blockfilesInfo.lastPersistedBlock + 1,
+			CurrentBlockHash:          lastBlockHash,
+			PreviousBlockHash:         previousBlockHash,
+			BootstrappingSnapshotInfo: bcInfo.BootstrappingSnapshotInfo,
+		}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	if mgr.archive != nil {
+		mgr.startArchiver()
+	}
 	return mgr, nil
 }
 
@@ -179,9 +229,10 @@ func bootstrapFromSnapshottedTxIDs(
 	}
 
 	bsi := &BootstrappingSnapshotInfo{
-		LastBlockNum:      snapshotInfo.LastBlockNum,
-		LastBlockHash:     snapshotInfo.LastBlockHash,
-		PreviousBlockHash: snapshotInfo.PreviousBlockHash,
+		LastBlockNum:          snapshotInfo.LastBlockNum,
+		LastBlockHash:         snapshotInfo.LastBlockHash,
+		PreviousBlockHash:     snapshotInfo.PreviousBlockHash,
+		BlockHashingAlgorithm: snapshotInfo.BlockHashingAlgorithm,
 	}
 
 	bsiBytes, err := proto.Marshal(bsi)
@@ -239,19 +290,86 @@ func syncBlockfilesInfoFromFS(rootDir string, blkfilesInfo *blockfilesInfo) {
 	logger.Debugf("blockfilesInfo after updates by scanning the last file segment:%s", blkfilesInfo)
 }
 
+// blockHashingAlgorithmOfLedger returns the name of the block hashing algorithm of the ledger whose block files are
+// in rootDir. As the genesis block is not available for a ledger bootstrapped from a snapshot, the algorithm recorded
+// in the bootstrapping snapshot info is used for such a ledger. SHA-256 is assumed for a ledger without any block and
+// for a snapshot that does not record the algorithm
+func blockHashingAlgorithmOfLedger(
+	rootDir string,
+	archive *blockfileArchive,
+	bsi *BootstrappingSnapshotInfo,
+	noBlockFiles bool,
+) (string, error) {
+	switch {
+	case bsi != nil:
+		return snapshotBlockHashingAlgorithm(bsi), nil
+	case noBlockFiles:
+		return bccsp.SHA256, nil
+	default:
+		return blockHashingAlgorithmFromBlockfiles(rootDir, archive)
+	}
+}
+
+// snapshotBlockHashingAlgorithm returns the name of the block hashing algorithm recorded in the bootstrapping
+// snapshot info, or SHA-256 for a snapshot that does not record the algorithm
+func snapshotBlockHashingAlgorithm(bsi *BootstrappingSnapshotInfo) string {
+	if bsi.BlockHashingAlgorithm == "" {
+		return bccsp.SHA256
+	}
+	return bsi.BlockHashingAlgorithm
+}
+
+// blockHashingAlgorithmFromBlockfiles returns the name of the block hashing algorithm configured
+// by the genesis block stored at the start of the first block file in rootDir.
+func blockHashingAlgorithmFromBlockfiles(rootDir string, archive *blockfileArchive) (string, error) {
+	stream, err := newBlockfileStream(rootDir, archive, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return "", err
+	}
+	if blockBytes == nil {
+		return "", errors.Errorf("no block found in the first block file in %s", rootDir)
+	}
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		return "", err
+	}
+	return blockHashingAlgorithmFromGenesisBlock(block)
+}
+
+// blockHashingAlgorithmFromGenesisBlock returns the name of the block hashing algorithm configured by the given
+// genesis block. SHA-256 is assumed if the genesis block is not a config block, which is the case for some of the
+// ledgers created by the tests and tools
+func blockHashingAlgorithmFromGenesisBlock(block *common.Block) (string, error) {
+	if !protoutil.IsConfigBlock(block) {
+		return bccsp.SHA256, nil
+	}
+	return channelconfig.BlockHashingAlgorithmNameFromConfigBlock(block)
+}
+
 func deriveBlockfilePath(rootDir string, suffixNum int) string {
 	return rootDir + "/" + blockfilePrefix + fmt.Sprintf("%06d", suffixNum)
 }
 
 func (mgr *blockfileMgr) close() {
+	if mgr.archiverStop != nil {
+		close(mgr.archiverStop)
+		<-mgr.archiverDone
+	}
 	mgr.currentFileWriter.close()
 }
 
 func (mgr *blockfileMgr) moveToNextFile() {
 	blkfilesInfo := &blockfilesInfo{
 		latestFileNumber:   mgr.blockfilesInfo.latestFileNumber + 1,
-		latestFileSize:     0,
-		lastPersistedBlock: mgr.blockfilesInfo.lastPersistedBlock}
+		latestFileSize:     len(mgr.conf.blockfileFormat.header()),
+		lastPersistedBlock: mgr.blockfilesInfo.lastPersistedBlock,
+		latestFileFormat:   mgr.conf.blockfileFormat,
+	}
 
 	nextFileWriter, err := newBlockfileWriter(
 		deriveBlockfilePath(mgr.rootDir, blkfilesInfo.latestFileNumber))
@@ -259,6 +377,9 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	if err != nil {
 		panic(fmt.Sprintf("Could not open writer to next file: %s", err))
 	}
+	if err := nextFileWriter.reset(blkfilesInfo.latestFileFormat); err != nil {
+		panic(fmt.Sprintf("Could not write the header to next file: %s", err))
+	}
 	mgr.currentFileWriter.close()
 	err = mgr.saveBlkfilesInfo(blkfilesInfo, true)
 	if err != nil {
@@ -287,30 +408,47 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 			bcInfo.CurrentBlockHash, block.Header.PreviousHash,
 		)
 	}
+	hashingAlgorithmName, hashingAlgorithm := mgr.hashingAlgorithmName, mgr.hashingAlgorithm
+	if block.Header.Number == 0 {
+		var err error
+		if hashingAlgorithmName, err = blockHashingAlgorithmFromGenesisBlock(block); err != nil {
+			return errors.WithMessage(err, "error while determining the block hashing algorithm from the genesis block")
+		}
+		if hashingAlgorithm, err = channelconfig.HashingAlgorithmByName(hashingAlgorithmName); err != nil {
+			return errors.WithMessage(err, "error while determining the block hashing algorithm from the genesis block")
+		}
+	}
 	blockBytes, info, err := serializeBlock(block)
 	if err != nil {
 		return errors.WithMessage(err, "error serializing block")
 	}
-	blockHash := protoutil.BlockHeaderHash(block.Header)
+	blockHash := protoutil.BlockHeaderHashWith(block.Header, hashingAlgorithm)
 	//Get the location / offset where each transaction starts in the block and where the block ends
 	txOffsets := info.txOffsets
 	currentOffset := mgr.blockfilesInfo.latestFileSize
 
-	blockBytesLen := len(blockBytes)
-	blockBytesEncodedLen := proto.EncodeVarint(uint64(blockBytesLen))
-	totalBytesToAppend := blockBytesLen + len(blockBytesEncodedLen)
+	fileFormat := mgr.blockfilesInfo.latestFileFormat
+	encodedBlockBytes := fileFormat.encodeBlock(blockBytes)
+	blockBytesEncodedLen := proto.EncodeVarint(uint64(len(encodedBlockBytes)))
+	totalBytesToAppend := len(encodedBlockBytes) + len(blockBytesEncodedLen)
 
 	//Determine if we need to start a new file since the size of this block
 	//exceeds the amount of space left in the current file
 	if currentOffset+totalBytesToAppend > mgr.conf.maxBlockfileSize {
 		mgr.moveToNextFile()
-		currentOffset = 0
+		currentOffset = mgr.blockfilesInfo.latestFileSize
+		if fileFormat != mgr.blockfilesInfo.latestFileFormat {
+			fileFormat = mgr.blockfilesInfo.latestFileFormat
+			encodedBlockBytes = fileFormat.encodeBlock(blockBytes)
+			blockBytesEncodedLen = proto.EncodeVarint(uint64(len(encodedBlockBytes)))
+			totalBytesToAppend = len(encodedBlockBytes) + len(blockBytesEncodedLen)
+		}
 	}
 	//append blockBytesEncodedLen to the file
 	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
 	if err == nil {
 		//append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		err = mgr.currentFileWriter.append(encodedBlockBytes, true)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.blockfilesInfo.latestFileSize)
@@ -326,7 +464,9 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 		latestFileNumber:   currentBlkfilesInfo.latestFileNumber,
 		latestFileSize:     currentBlkfilesInfo.latestFileSize + totalBytesToAppend,
 		noBlockFiles:       false,
-		lastPersistedBlock: block.Header.Number}
+		lastPersistedBlock: block.Header.Number,
+		latestFileFormat:   currentBlkfilesInfo.latestFileFormat,
+	}
 	//save the blockfilesInfo in the database
 	if err = mgr.saveBlkfilesInfo(newBlkfilesInfo, false); err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(currentBlkfilesInfo.latestFileSize)
@@ -339,20 +479,26 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//Index block file location pointer updated with file suffex and offset for the new block
 	blockFLP := &fileLocPointer{fileSuffixNum: newBlkfilesInfo.latestFileNumber}
 	blockFLP.offset = currentOffset
-	// shift the txoffset because we prepend length of bytes before block bytes
-	for _, txOffset := range txOffsets {
-		txOffset.loc.offset += len(blockBytesEncodedLen)
+	// shift the txoffset because we prepend length of bytes before block bytes. The txoffsets
+	// in a compressed block remain relative to the uncompressed block bytes
+	if !fileFormat.isCompressed() {
+		for _, txOffset := range txOffsets {
+			txOffset.loc.offset += len(blockBytesEncodedLen)
+		}
 	}
 	//save the index in the database
 	if err = mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
-		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata}); err != nil {
+		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata,
+		data: block.Data, compressed: fileFormat.isCompressed()}); err != nil {
 		return err
 	}
 
 	//update the blockfilesInfo (for storage) and the blockchain info (for APIs) in the manager
+	mgr.hashingAlgorithmName, mgr.hashingAlgorithm = hashingAlgorithmName, hashingAlgorithm
 	mgr.updateBlockfilesInfo(newBlkfilesInfo)
 	mgr.updateBlockchainInfo(blockHash, block)
+	mgr.signalArchiver()
 	return nil
 }
 
@@ -391,7 +537,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 	skipFirstBlock := false
 	endFileNum := mgr.blockfilesInfo.latestFileNumber
 
-	firstAvailableBlkNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, 0)
+	firstAvailableBlkNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, mgr.archive, 0)
 	if err != nil {
 		return err
 	}
@@ -411,7 +557,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = newBlockStream(mgr.rootDir, mgr.archive, startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -445,18 +591,22 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
 		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		if !blockPlacementInfo.compressed {
+			numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+			for _, offset := range info.txOffsets {
+				offset.loc.offset += numBytesToShift
+			}
 		}
 
 		//Update the blockIndexInfo with what was actually stored in file system
-		blockIdxInfo.blockHash = protoutil.BlockHeaderHash(info.blockHeader)
+		blockIdxInfo.blockHash = protoutil.BlockHeaderHashWith(info.blockHeader, mgr.hashingAlgorithm)
 		blockIdxInfo.blockNum = info.blockHeader.Number
 		blockIdxInfo.flp = &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.data = info.data
+		blockIdxInfo.compressed = blockPlacementInfo.compressed
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -485,9 +635,11 @@ func (mgr *blockfileMgr) updateBlockfilesInfo(blkfilesInfo *blockfilesInfo) {
 func (mgr *blockfileMgr) updateBlockchainInfo(latestBlockHash []byte, latestBlock *common.Block) {
 	currentBCInfo := mgr.getBlockchainInfo()
 	newBCInfo := &common.BlockchainInfo{
-		Height:            currentBCInfo.Height + 1,
-		CurrentBlockHash:  latestBlockHash,
-		PreviousBlockHash: latestBlock.Header.PreviousHash}
+		Height:                    currentBCInfo.Height + 1,
+		CurrentBlockHash:          latestBlockHash,
+		PreviousBlockHash:         latestBlock.Header.PreviousHash,
+		BootstrappingSnapshotInfo: currentBCInfo.BootstrappingSnapshotInfo,
+	}
 
 	mgr.bcInfo.Store(newBCInfo)
 }
@@ -583,9 +735,9 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 	logger.Debugf("retrieveTransactionByID() - txId = [%s]", txID)
 	loc, err := mgr.index.getTxLoc(txID)
 	if err == errNilValue {
-		return nil, errors.Errorf(
+		return nil, ledger.TxDetailsNotAvailableErr(fmt.Sprintf(
 			"details for the TXID [%s] not available. Ledger bootstrapped from a snapshot. First available block = [%d]",
-			txID, mgr.firstPossibleBlockNumberInBlockFiles())
+			txID, mgr.firstPossibleBlockNumberInBlockFiles()))
 	}
 	if err != nil {
 		return nil, err
@@ -608,6 +760,47 @@ func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, t
 	return mgr.fetchTransactionEnvelope(loc)
 }
 
+func (mgr *blockfileMgr) retrieveTransactionsByChaincodeEvent(chaincodeName, eventName, bookmark string, limit int) ([]*peer.ProcessedTransaction, string, error) {
+	logger.Debugf("retrieveTransactionsByChaincodeEvent() - chaincodeName = [%s], eventName = [%s], bookmark = [%s], limit = [%d]",
+		chaincodeName, eventName, bookmark, limit)
+	vals, nextBookmark, err := mgr.index.getTxIndexValsByChaincodeEvent(chaincodeName, eventName, bookmark, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	processedTxs := make([]*peer.ProcessedTransaction, len(vals))
+	for i, val := range vals {
+		loc := &fileLocPointer{}
+		if err := loc.unmarshal(val.TxLocation); err != nil {
+			return nil, "", err
+		}
+		txEnvelope, err := mgr.fetchTransactionEnvelope(loc)
+		if err != nil {
+			return nil, "", err
+		}
+		processedTxs[i] = &peer.ProcessedTransaction{
+			TransactionEnvelope: txEnvelope,
+			ValidationCode:      val.TxValidationCode,
+		}
+	}
+	return processedTxs, nextBookmark, nil
+}
+
+func (mgr *blockfileMgr) retrieveBlocksByTimestamp(startTime, endTime time.Time, bookmark string, limit int) ([]*common.Block, string, error) {
+	logger.Debugf("retrieveBlocksByTimestamp() - startTime = [%s], endTime = [%s], bookmark = [%s], limit = [%d]",
+		startTime, endTime, bookmark, limit)
+	locs, nextBookmark, err := mgr.index.getBlockLocsByTimestamp(startTime, endTime, bookmark, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	blocks := make([]*common.Block, len(locs))
+	for i, loc := range locs {
+		if blocks[i], err = mgr.fetchBlock(loc); err != nil {
+			return nil, "", err
+		}
+	}
+	return blocks, nextBookmark, nil
+}
+
 func (mgr *blockfileMgr) fetchBlock(lp *fileLocPointer) (*common.Block, error) {
 	blockBytes, err := mgr.fetchBlockBytes(lp)
 	if err != nil {
@@ -632,7 +825,7 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
-	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
+	stream, err := newBlockfileStream(mgr.rootDir, mgr.archive, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
 	}
@@ -645,8 +838,17 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
-	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
-	reader, err := newBlockfileReader(filePath)
+	if lp.inCompressedBlock {
+		blockBytes, err := mgr.fetchBlockBytes(lp.blockLoc())
+		if err != nil {
+			return nil, err
+		}
+		if lp.txOffsetInBlock+lp.bytesLength > len(blockBytes) {
+			return nil, errors.Errorf("the transaction location [%s] is beyond the block bytes of length [%d]", lp, len(blockBytes))
+		}
+		return blockBytes[lp.txOffsetInBlock : lp.txOffsetInBlock+lp.bytesLength], nil
+	}
+	reader, err := newBlockfileReader(mgr.rootDir, mgr.archive, lp.fileSuffixNum)
 	if err != nil {
 		return nil, err
 	}
@@ -701,7 +903,7 @@ func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64)
 	//scan the passed file number suffix starting from the passed offset to find the last completed block
 	numBlocks := 0
 	var lastBlockBytes []byte
-	blockStream, errOpen := newBlockfileStream(rootDir, fileNum, startingOffset)
+	blockStream, errOpen := newBlockfileStream(rootDir, nil, fileNum, startingOffset)
 	if errOpen != nil {
 		return nil, 0, 0, errOpen
 	}
@@ -732,6 +934,9 @@ type blockfilesInfo struct {
 	latestFileSize     int
 	noBlockFiles       bool
 	lastPersistedBlock uint64
+	// latestFileFormat is the format of the blocks in the latest file. This is not
+	// present in the data written by the previous versions, which use the raw format
+	latestFileFormat blockfileFormat
 }
 
 func (i *blockfilesInfo) marshal() ([]byte, error) {
@@ -753,6 +958,9 @@ func (i *blockfilesInfo) marshal() ([]byte, error) {
 	if err = buffer.EncodeVarint(noBlockFilesMarker); err != nil {
 		return nil, errors.Wrapf(err, "error encoding noBlockFiles [%d]", noBlockFilesMarker)
 	}
+	if err = buffer.EncodeVarint(uint64(i.latestFileFormat)); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the latestFileFormat [%s]", i.latestFileFormat)
+	}
 	return buffer.Bytes(), nil
 }
 
@@ -780,10 +988,18 @@ func (i *blockfilesInfo) unmarshal(b []byte) error {
 		return err
 	}
 	i.noBlockFiles = noBlockFilesMarker == 1
+	switch val, err = buffer.DecodeVarint(); err {
+	case nil:
+		i.latestFileFormat = blockfileFormat(val)
+	case io.ErrUnexpectedEOF:
+		i.latestFileFormat = blockfileFormatRaw
+	default:
+		return err
+	}
 	return nil
 }
 
 func (i *blockfilesInfo) String() string {
-	return fmt.Sprintf("latestFileNumber=[%d], latestFileSize=[%d], noBlockFiles=[%t], lastPersistedBlock=[%d]",
-		i.latestFileNumber, i.latestFileSize, i.noBlockFiles, i.lastPersistedBlock)
+	return fmt.Sprintf("latestFileNumber=[%d], latestFileSize=[%d], noBlockFiles=[%t], lastPersistedBlock=[%d], latestFileFormat=[%s]",
+		i.latestFileNumber, i.latestFileSize, i.noBlockFiles, i.lastPersistedBlock, i.latestFileFormat)
 }
@@ -15,7 +15,14 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/genesis"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
@@ -47,6 +54,73 @@ func TestAddBlockWithWrongHash(t *testing.T) {
 	t.Logf("err = %s", err)
 }
 
+func TestBlockfileMgrBlockHashingAlgorithm(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+
+	blocks := constructGMSM3TestBlocks(t, "testLedger", 5)
+	lastBlockHash := protoutil.BlockHeaderHashWith(blocks[4].Header, util.ComputeGMSM3)
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	verify := func(mgr *blockfileMgr) {
+		for _, block := range blocks {
+			b, err := mgr.retrieveBlockByHash(protoutil.BlockHeaderHashWith(block.Header, util.ComputeGMSM3))
+			require.NoError(t, err)
+			require.True(t, proto.Equal(block, b))
+		}
+		require.Equal(t, lastBlockHash, mgr.getBlockchainInfo().CurrentBlockHash)
+		require.Equal(t, bccsp.GMSM3, mgr.hashingAlgorithmName)
+	}
+	verify(blkfileMgrWrapper.blockfileMgr)
+
+	// the algorithm is derived from the genesis block when the block files are reopened
+	blkfileMgrWrapper.close()
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	verify(blkfileMgrWrapper.blockfileMgr)
+}
+
+// constructGMSM3TestBlocks constructs a chain of blocks starting with a genesis block
+// that configures GMSM3 as the block hashing algorithm
+func constructGMSM3TestBlocks(t *testing.T, ledgerID string, numBlocks int) []*common.Block {
+	profile := genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile, configtest.GetDevConfigDir())
+	profile.HashingAlgorithm = bccsp.GMSM3
+	profile.Capabilities[capabilities.ChannelV2_0_HashingAlgorithm] = true
+	channelGroup, err := encoder.NewChannelGroup(profile)
+	require.NoError(t, err)
+	gb := genesis.NewFactoryImpl(channelGroup).Block(ledgerID)
+	gb.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txflags.NewWithValues(len(gb.Data.Data), peer.TxValidationCode_VALID)
+
+	blocks := []*common.Block{gb}
+	previousHash := protoutil.BlockHeaderHashWith(gb.Header, util.ComputeGMSM3)
+	for i := 1; i < numBlocks; i++ {
+		block := testutil.ConstructBlock(t, uint64(i), previousHash, [][]byte{[]byte(fmt.Sprintf("value%d", i))}, false)
+		block.Header.DataHash = protoutil.BlockDataHashWith(block.Data, util.ComputeGMSM3)
+		previousHash = protoutil.BlockHeaderHashWith(block.Header, util.ComputeGMSM3)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func TestBlockfileMgrNonConfigGenesisBlock(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+
+	// SHA-256 is assumed for a genesis block that is not a config block
+	blocks := []*common.Block{testutil.ConstructBlock(t, 0, nil, [][]byte{[]byte("value0")}, false)}
+	blocks = append(blocks, testutil.ConstructBlock(t, 1, protoutil.BlockHeaderHash(blocks[0].Header), [][]byte{[]byte("value1")}, false))
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+
+	blkfileMgrWrapper.close()
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+	require.Equal(t, protoutil.BlockHeaderHash(blocks[1].Header), blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().CurrentBlockHash)
+}
+
 func TestBlockfileMgrCrashDuringWriting(t *testing.T) {
 	testBlockfileMgrCrashDuringWriting(t, 10, 2, 1000, 10, false)
 	testBlockfileMgrCrashDuringWriting(t, 10, 2, 1000, 1, false)
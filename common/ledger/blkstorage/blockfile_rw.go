@@ -34,6 +34,18 @@ func (w *blockfileWriter) truncateFile(targetSize int) error {
 	return nil
 }
 
+// reset truncates the file to zero size and writes the header for the given format
+func (w *blockfileWriter) reset(format blockfileFormat) error {
+	if err := w.file.Truncate(0); err != nil {
+		return errors.Wrapf(err, "error truncating the file [%s]", w.filePath)
+	}
+	header := format.header()
+	if len(header) == 0 {
+		return nil
+	}
+	return w.append(header, true)
+}
+
 func (w *blockfileWriter) append(b []byte, sync bool) error {
 	_, err := w.file.Write(b)
 	if err != nil {
@@ -60,13 +72,13 @@ func (w *blockfileWriter) close() error {
 
 ////  READER ////
 type blockfileReader struct {
-	file *os.File
+	file blockfileSource
 }
 
-func newBlockfileReader(filePath string) (*blockfileReader, error) {
-	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+func newBlockfileReader(rootDir string, archive *blockfileArchive, fileNum int) (*blockfileReader, error) {
+	file, err := openBlockfile(rootDir, archive, fileNum)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error opening block file reader for file %s", filePath)
+		return nil, errors.WithMessagef(err, "error opening block file reader for file number %d", fileNum)
 	}
 	reader := &blockfileReader{file}
 	return reader, nil
@@ -8,8 +8,11 @@ package blkstorage
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"path/filepath"
+	"time"
 	"unicode/utf8"
 
 	"github.com/golang/protobuf/proto"
@@ -19,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
@@ -27,6 +31,8 @@ const (
 	blockHashIdxKeyPrefix       = 'h'
 	txIDIdxKeyPrefix            = 't'
 	blockNumTranNumIdxKeyPrefix = 'a'
+	chaincodeEventIdxKeyPrefix  = 'e'
+	blockTimestampIdxKeyPrefix  = 's'
 	indexSavePointKeyStr        = "indexCheckpointKey"
 
 	snapshotFileFormat       = byte(1)
@@ -47,6 +53,12 @@ type blockIdxInfo struct {
 	flp       *fileLocPointer
 	txOffsets []*txindexInfo
 	metadata  *common.BlockMetadata
+	// data is used for indexing the attributes derived from the contents of the
+	// transactions, i.e., the chaincode events and the block timestamp
+	data *common.BlockData
+	// compressed indicates that the block is compressed in the block file and
+	// hence, the txOffsets are the offsets in the uncompressed block bytes
+	compressed bool
 }
 
 type blockIndex struct {
@@ -110,7 +122,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index3 Used to find a transaction by its transaction id
 	if index.isAttributeIndexed(IndexableAttrTxID) {
 		for i, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxFileLocationPointer(flp, blockIdxInfo.compressed, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to txid-index", txFlp, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -136,7 +148,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index4 - Store BlockNumTranNum will be used to query history data
 	if index.isAttributeIndexed(IndexableAttrBlockNumTranNum) {
 		for i, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxFileLocationPointer(flp, blockIdxInfo.compressed, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx number:[%d] ID: [%s] to blockNumTranNum index", txFlp, i, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -146,6 +158,43 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 		}
 	}
 
+	//Index5 - Used to find the transactions by the chaincode event emitted
+	if index.isAttributeIndexed(IndexableAttrChaincodeEvent) && blockIdxInfo.data != nil {
+		for i, txoffset := range txOffsets {
+			event := extractChaincodeEvent(blockIdxInfo.data.Data[i])
+			if event == nil {
+				continue
+			}
+			txFlp := newTxFileLocationPointer(flp, blockIdxInfo.compressed, txoffset.loc)
+			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to chaincode event index for event [%s] of chaincode [%s]",
+				txFlp, txoffset.txID, event.EventName, event.ChaincodeId)
+			txFlpBytes, marshalErr := txFlp.marshal()
+			if marshalErr != nil {
+				return marshalErr
+			}
+			indexVal := &TxIDIndexValue{
+				BlkLocation:      flpBytes,
+				TxLocation:       txFlpBytes,
+				TxValidationCode: int32(txsfltr.Flag(i)),
+			}
+			indexValBytes, err := proto.Marshal(indexVal)
+			if err != nil {
+				return errors.Wrap(err, "unexpected error while marshaling TxIDIndexValProto message")
+			}
+			batch.Put(
+				constructChaincodeEventKey(event.ChaincodeId, event.EventName, blkNum, uint64(i)),
+				indexValBytes,
+			)
+		}
+	}
+
+	//Index6 - Used to find the blocks by timestamp
+	if index.isAttributeIndexed(IndexableAttrBlockTimestamp) {
+		if timestamp, ok := extractBlockTimestamp(blockIdxInfo.data); ok {
+			batch.Put(constructBlockTimestampKey(timestamp, blkNum), flpBytes)
+		}
+	}
+
 	batch.Put(indexSavePointKey, encodeBlockNum(blockIdxInfo.blockNum))
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
 	if err := index.db.WriteBatch(batch, true); err != nil {
@@ -268,6 +317,86 @@ func (index *blockIndex) getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint
 	return txFLP, nil
 }
 
+// getTxIndexValsByChaincodeEvent returns the index values of at most `limit` transactions that emitted the given
+// event of the given chaincode, starting at the transaction identified by the bookmark, and the bookmark for the
+// next transaction, which is empty if there are no more transactions
+func (index *blockIndex) getTxIndexValsByChaincodeEvent(chaincodeName, eventName, bookmark string, limit int) ([]*TxIDIndexValue, string, error) {
+	if !index.isAttributeIndexed(IndexableAttrChaincodeEvent) {
+		return nil, "", ErrAttrNotIndexed
+	}
+	valsBytes, nextBookmark, err := index.getPage(constructChaincodeEventRangeScan(chaincodeName, eventName), bookmark, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	vals := make([]*TxIDIndexValue, len(valsBytes))
+	for i, valBytes := range valsBytes {
+		vals[i] = &TxIDIndexValue{}
+		if err := proto.Unmarshal(valBytes, vals[i]); err != nil {
+			return nil, "", errors.Wrapf(err, "unexpected error while unmarshaling bytes [%#v] into TxIDIndexValProto", valBytes)
+		}
+	}
+	return vals, nextBookmark, nil
+}
+
+// getBlockLocsByTimestamp returns the locations of at most `limit` blocks with a timestamp in the range
+// [startTime, endTime), starting at the block identified by the bookmark, and the bookmark for the next
+// block, which is empty if there are no more blocks
+func (index *blockIndex) getBlockLocsByTimestamp(startTime, endTime time.Time, bookmark string, limit int) ([]*fileLocPointer, string, error) {
+	if !index.isAttributeIndexed(IndexableAttrBlockTimestamp) {
+		return nil, "", ErrAttrNotIndexed
+	}
+	rangeScan := &rangeScan{
+		startKey: constructBlockTimestampRangeKey(startTime),
+		stopKey:  constructBlockTimestampRangeKey(endTime),
+	}
+	valsBytes, nextBookmark, err := index.getPage(rangeScan, bookmark, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	blkLocs := make([]*fileLocPointer, len(valsBytes))
+	for i, valBytes := range valsBytes {
+		blkLocs[i] = &fileLocPointer{}
+		if err := blkLocs[i].unmarshal(valBytes); err != nil {
+			return nil, "", err
+		}
+	}
+	return blkLocs, nextBookmark, nil
+}
+
+// getPage returns the values of at most `limit` entries in the given range, starting at the entry whose key is
+// encoded in the bookmark, and the bookmark that encodes the key of the next entry in the range. An empty bookmark
+// starts at the beginning of the range and an empty next bookmark indicates that there are no more entries
+func (index *blockIndex) getPage(rangeScan *rangeScan, bookmark string, limit int) ([][]byte, string, error) {
+	if limit <= 0 {
+		return nil, "", errors.Errorf("invalid limit [%d], the limit must be greater than zero", limit)
+	}
+	startKey := rangeScan.startKey
+	if bookmark != "" {
+		bookmarkKey, err := hex.DecodeString(bookmark)
+		if err != nil || bytes.Compare(bookmarkKey, rangeScan.startKey) < 0 || bytes.Compare(bookmarkKey, rangeScan.stopKey) >= 0 {
+			return nil, "", errors.Errorf("invalid bookmark [%s]", bookmark)
+		}
+		startKey = bookmarkKey
+	}
+	itr, err := index.db.GetIterator(startKey, rangeScan.stopKey)
+	if err != nil {
+		return nil, "", err
+	}
+	defer itr.Release()
+
+	var vals [][]byte
+	for itr.Next() {
+		if len(vals) == limit {
+			return vals, hex.EncodeToString(itr.Key()), nil
+		}
+		vals = append(vals, append([]byte(nil), itr.Value()...))
+	}
+	if err := itr.Error(); err != nil {
+		return nil, "", errors.Wrap(err, "internal leveldb error while iterating over the index")
+	}
+	return vals, "", nil
+}
+
 func (index *blockIndex) exportUniqueTxIDs(dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
 	if !index.isAttributeIndexed(IndexableAttrTxID) {
 		return nil, ErrAttrNotIndexed
@@ -444,6 +573,82 @@ func constructBlockNumTranNumKey(blockNum uint64, txNum uint64) []byte {
 	return append([]byte{blockNumTranNumIdxKeyPrefix}, key...)
 }
 
+func constructChaincodeEventKey(chaincodeName, eventName string, blkNum, txNum uint64) []byte {
+	k := constructChaincodeEventRangeScan(chaincodeName, eventName).startKey
+	k = append(k, util.EncodeOrderPreservingVarUint64(blkNum)...)
+	return append(k, util.EncodeOrderPreservingVarUint64(txNum)...)
+}
+
+func constructChaincodeEventRangeScan(chaincodeName, eventName string) *rangeScan {
+	sk := append(
+		[]byte{chaincodeEventIdxKeyPrefix},
+		util.EncodeOrderPreservingVarUint64(uint64(len(chaincodeName)))...,
+	)
+	sk = append(sk, chaincodeName...)
+	sk = append(sk, util.EncodeOrderPreservingVarUint64(uint64(len(eventName)))...)
+	sk = append(sk, eventName...)
+	return &rangeScan{
+		startKey: sk,
+		stopKey:  append(append([]byte(nil), sk...), 0xff),
+	}
+}
+
+func constructBlockTimestampKey(timestamp time.Time, blkNum uint64) []byte {
+	return append(constructBlockTimestampRangeKey(timestamp), util.EncodeOrderPreservingVarUint64(blkNum)...)
+}
+
+// constructBlockTimestampRangeKey returns the key that precedes the keys of all the blocks with the given
+// or a later timestamp. The timestamps before the unix epoch are treated as the unix epoch
+func constructBlockTimestampRangeKey(timestamp time.Time) []byte {
+	nanos := timestamp.UnixNano()
+	if nanos < 0 {
+		nanos = 0
+	}
+	return append([]byte{blockTimestampIdxKeyPrefix}, util.EncodeOrderPreservingVarUint64(uint64(nanos))...)
+}
+
+// extractChaincodeEvent returns the chaincode event emitted by the given transaction,
+// or nil if the transaction is not an endorser transaction that emitted an event
+func extractChaincodeEvent(txEnvelopeBytes []byte) *peer.ChaincodeEvent {
+	env, err := protoutil.GetEnvelopeFromBlock(txEnvelopeBytes)
+	if err != nil {
+		return nil
+	}
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil || payload.Header == nil {
+		return nil
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil
+	}
+	action, err := protoutil.GetActionFromEnvelopeMsg(env)
+	if err != nil || len(action.Events) == 0 {
+		return nil
+	}
+	event, err := protoutil.UnmarshalChaincodeEvents(action.Events)
+	if err != nil || event.EventName == "" {
+		return nil
+	}
+	return event
+}
+
+// extractBlockTimestamp returns the timestamp in the channel header of the first transaction in the block
+func extractBlockTimestamp(data *common.BlockData) (time.Time, bool) {
+	if data == nil || len(data.Data) == 0 {
+		return time.Time{}, false
+	}
+	env, err := protoutil.GetEnvelopeFromBlock(data.Data[0])
+	if err != nil {
+		return time.Time{}, false
+	}
+	chdr, err := protoutil.ChannelHeader(env)
+	if err != nil || chdr.Timestamp == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos)), true
+}
+
 func encodeBlockNum(blockNum uint64) []byte {
 	return proto.EncodeVarint(blockNum)
 }
@@ -467,6 +672,11 @@ func (lp *locPointer) String() string {
 type fileLocPointer struct {
 	fileSuffixNum int
 	locPointer
+	// inCompressedBlock indicates that the pointer locates a transaction in a compressed block.
+	// In this case, the offset locates the block in the file and the txOffsetInBlock locates
+	// the transaction in the uncompressed block bytes
+	inCompressedBlock bool
+	txOffsetInBlock   int
 }
 
 func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *locPointer) *fileLocPointer {
@@ -476,6 +686,25 @@ func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *
 	return flp
 }
 
+// newTxFileLocationPointer returns the location of a transaction, given the location of the block and the
+// location of the transaction relative to the block
+func newTxFileLocationPointer(blockFLP *fileLocPointer, compressedBlock bool, relativeLP *locPointer) *fileLocPointer {
+	if !compressedBlock {
+		return newFileLocationPointer(blockFLP.fileSuffixNum, blockFLP.offset, relativeLP)
+	}
+	return &fileLocPointer{
+		fileSuffixNum:     blockFLP.fileSuffixNum,
+		locPointer:        locPointer{offset: blockFLP.offset, bytesLength: relativeLP.bytesLength},
+		inCompressedBlock: true,
+		txOffsetInBlock:   relativeLP.offset,
+	}
+}
+
+// blockLoc returns the location of the block that contains the transaction located by a pointer with inCompressedBlock set
+func (flp *fileLocPointer) blockLoc() *fileLocPointer {
+	return &fileLocPointer{fileSuffixNum: flp.fileSuffixNum, locPointer: locPointer{offset: flp.offset}}
+}
+
 func (flp *fileLocPointer) marshal() ([]byte, error) {
 	buffer := proto.NewBuffer([]byte{})
 	e := buffer.EncodeVarint(uint64(flp.fileSuffixNum))
@@ -490,6 +719,12 @@ func (flp *fileLocPointer) marshal() ([]byte, error) {
 	if e != nil {
 		return nil, errors.Wrapf(e, "unexpected error while marshaling fileLocPointer [%s]", flp)
 	}
+	if flp.inCompressedBlock {
+		e = buffer.EncodeVarint(uint64(flp.txOffsetInBlock))
+		if e != nil {
+			return nil, errors.Wrapf(e, "unexpected error while marshaling fileLocPointer [%s]", flp)
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
@@ -511,10 +746,23 @@ func (flp *fileLocPointer) unmarshal(b []byte) error {
 		return errors.Wrapf(e, "unexpected error while unmarshaling bytes [%#v] into fileLocPointer", b)
 	}
 	flp.bytesLength = int(i)
+	// the offset of the transaction in a compressed block is present only for a transaction in a compressed block
+	i, e = buffer.DecodeVarint()
+	switch {
+	case e == io.ErrUnexpectedEOF:
+		return nil
+	case e != nil:
+		return errors.Wrapf(e, "unexpected error while unmarshaling bytes [%#v] into fileLocPointer", b)
+	}
+	flp.inCompressedBlock = true
+	flp.txOffsetInBlock = int(i)
 	return nil
 }
 
 func (flp *fileLocPointer) String() string {
+	if flp.inCompressedBlock {
+		return fmt.Sprintf("fileSuffixNum=%d, %s, txOffsetInBlock=%d", flp.fileSuffixNum, flp.locPointer.String(), flp.txOffsetInBlock)
+	}
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
@@ -14,8 +14,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/ledger/util"
@@ -450,3 +453,211 @@ func verifyTxIDKeyDecodable(t *testing.T, txIDKey []byte, expectedTxID string, e
 	require.Equal(t, expectedTxNum, txNum)
 	require.Len(t, txIDKey, firstIndexTxNum+n)
 }
+
+func TestChaincodeEventIndex(t *testing.T) {
+	indexItems := []IndexableAttr{IndexableAttrBlockNum, IndexableAttrTxID, IndexableAttrChaincodeEvent}
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), indexItems, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	// after the genesis block, each block contains a transaction that emits "event1" of "cc1", a transaction that
+	// emits "event2" of "cc1", a transaction that emits "event1" of "cc2", and a transaction without event
+	blocks := testutil.ConstructTestBlocks(t, 1)
+	require.NoError(t, blkfileMgr.addBlock(blocks[0]))
+	var expectedTxs []*peer.ProcessedTransaction
+	for blockNum := uint64(1); blockNum <= 3; blockNum++ {
+		block := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+			BlockNum:     blockNum,
+			PreviousHash: protoutil.BlockHeaderHash(blocks[blockNum-1].Header),
+			Txs: []*testutil.TxDetails{
+				{ChaincodeName: "cc1", Events: marshalChaincodeEvent(t, "cc1", "event1"), Type: common.HeaderType_ENDORSER_TRANSACTION},
+				{ChaincodeName: "cc1", Events: marshalChaincodeEvent(t, "cc1", "event2"), Type: common.HeaderType_ENDORSER_TRANSACTION},
+				{ChaincodeName: "cc2", Events: marshalChaincodeEvent(t, "cc2", "event1"), Type: common.HeaderType_ENDORSER_TRANSACTION},
+				{ChaincodeName: "cc1", Type: common.HeaderType_ENDORSER_TRANSACTION},
+			},
+		}, false)
+		// the first transaction in the block [2] is invalid
+		if blockNum == 2 {
+			txsFilter := txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+			txsFilter.SetFlag(0, peer.TxValidationCode_MVCC_READ_CONFLICT)
+		}
+		require.NoError(t, blkfileMgr.addBlock(block))
+		blocks = append(blocks, block)
+
+		txEnvelope, err := protoutil.GetEnvelopeFromBlock(block.Data.Data[0])
+		require.NoError(t, err)
+		validationCode := peer.TxValidationCode_VALID
+		if blockNum == 2 {
+			validationCode = peer.TxValidationCode_MVCC_READ_CONFLICT
+		}
+		expectedTxs = append(expectedTxs, &peer.ProcessedTransaction{TransactionEnvelope: txEnvelope, ValidationCode: int32(validationCode)})
+	}
+
+	verifyTxsByChaincodeEvent := func(blkfileMgr *blockfileMgr) {
+		txs, bookmark, err := blkfileMgr.retrieveTransactionsByChaincodeEvent("cc1", "event1", "", 10)
+		require.NoError(t, err)
+		require.Equal(t, "", bookmark)
+		require.Len(t, txs, 3)
+		for i, tx := range txs {
+			require.True(t, proto.Equal(expectedTxs[i], tx), "transaction [%d]", i)
+		}
+
+		txs, bookmark, err = blkfileMgr.retrieveTransactionsByChaincodeEvent("cc1", "event1", "", 2)
+		require.NoError(t, err)
+		require.NotEqual(t, "", bookmark)
+		require.Len(t, txs, 2)
+		require.True(t, proto.Equal(expectedTxs[1], txs[1]))
+		txs, bookmark, err = blkfileMgr.retrieveTransactionsByChaincodeEvent("cc1", "event1", bookmark, 2)
+		require.NoError(t, err)
+		require.Equal(t, "", bookmark)
+		require.Len(t, txs, 1)
+		require.True(t, proto.Equal(expectedTxs[2], txs[0]))
+
+		for _, chaincodeAndEvent := range [][2]string{{"cc1", "event2"}, {"cc2", "event1"}} {
+			txs, _, err = blkfileMgr.retrieveTransactionsByChaincodeEvent(chaincodeAndEvent[0], chaincodeAndEvent[1], "", 10)
+			require.NoError(t, err)
+			require.Len(t, txs, 3)
+		}
+		txs, bookmark, err = blkfileMgr.retrieveTransactionsByChaincodeEvent("cc2", "event2", "", 10)
+		require.NoError(t, err)
+		require.Empty(t, txs)
+		require.Equal(t, "", bookmark)
+	}
+	verifyTxsByChaincodeEvent(blkfileMgr)
+
+	t.Run("invalid-inputs", func(t *testing.T) {
+		_, _, err := blkfileMgr.retrieveTransactionsByChaincodeEvent("cc1", "event1", "", 0)
+		require.EqualError(t, err, "invalid limit [0], the limit must be greater than zero")
+		_, _, err = blkfileMgr.retrieveTransactionsByChaincodeEvent("cc1", "event1", "not-hex", 10)
+		require.EqualError(t, err, "invalid bookmark [not-hex]")
+		// a bookmark for one event cannot be used for another event
+		_, bookmark, err := blkfileMgr.retrieveTransactionsByChaincodeEvent("cc1", "event1", "", 1)
+		require.NoError(t, err)
+		_, _, err = blkfileMgr.retrieveTransactionsByChaincodeEvent("cc2", "event1", bookmark, 10)
+		require.EqualError(t, err, fmt.Sprintf("invalid bookmark [%s]", bookmark))
+	})
+
+	t.Run("index-rebuild", func(t *testing.T) {
+		blkfileMgrWrapper.close()
+		env.provider.Close()
+		require.NoError(t, os.RemoveAll(env.provider.conf.getIndexDir()))
+		env := newTestEnvSelectiveIndexing(t, env.provider.conf, indexItems, &disabled.Provider{})
+		defer env.provider.Close()
+		blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+		defer blkfileMgrWrapper.close()
+		verifyTxsByChaincodeEvent(blkfileMgrWrapper.blockfileMgr)
+	})
+
+	t.Run("not-indexed", func(t *testing.T) {
+		env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), []IndexableAttr{IndexableAttrTxID}, &disabled.Provider{})
+		defer env.Cleanup()
+		blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+		defer blkfileMgrWrapper.close()
+		blkfileMgrWrapper.addBlocks(blocks)
+		_, _, err := blkfileMgrWrapper.blockfileMgr.retrieveTransactionsByChaincodeEvent("cc1", "event1", "", 10)
+		require.Equal(t, ErrAttrNotIndexed, err)
+	})
+}
+
+func TestBlockTimestampIndex(t *testing.T) {
+	indexItems := []IndexableAttr{IndexableAttrBlockNum, IndexableAttrBlockTimestamp}
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), indexItems, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	// the timestamps of the transactions are the time of their construction
+	blocks := testutil.ConstructTestBlocks(t, 1)
+	var timestamps []time.Time
+	for i := 0; i < 5; i++ {
+		time.Sleep(time.Millisecond)
+		block := blocks[0]
+		if i > 0 {
+			block = testutil.ConstructBlock(t, uint64(i), protoutil.BlockHeaderHash(blocks[i-1].Header), [][]byte{[]byte("simulation-results")}, false)
+			blocks = append(blocks, block)
+		}
+		timestamp, ok := extractBlockTimestamp(block.Data)
+		require.True(t, ok)
+		require.NoError(t, blkfileMgr.addBlock(block))
+		timestamps = append(timestamps, timestamp)
+	}
+
+	retrievedBlocks, bookmark, err := blkfileMgr.retrieveBlocksByTimestamp(timestamps[1], timestamps[4], "", 10)
+	require.NoError(t, err)
+	require.Equal(t, "", bookmark)
+	require.Equal(t, blocks[1:4], retrievedBlocks)
+
+	retrievedBlocks, bookmark, err = blkfileMgr.retrieveBlocksByTimestamp(time.Unix(0, 0), time.Now(), "", 3)
+	require.NoError(t, err)
+	require.NotEqual(t, "", bookmark)
+	require.Equal(t, blocks[:3], retrievedBlocks)
+	retrievedBlocks, bookmark, err = blkfileMgr.retrieveBlocksByTimestamp(time.Unix(0, 0), time.Now(), bookmark, 3)
+	require.NoError(t, err)
+	require.Equal(t, "", bookmark)
+	require.Equal(t, blocks[3:], retrievedBlocks)
+
+	retrievedBlocks, _, err = blkfileMgr.retrieveBlocksByTimestamp(timestamps[4].Add(time.Nanosecond), time.Now().Add(time.Hour), "", 3)
+	require.NoError(t, err)
+	require.Empty(t, retrievedBlocks)
+
+	// the bookmark must be in the queried range
+	_, bookmark, err = blkfileMgr.retrieveBlocksByTimestamp(timestamps[0], timestamps[4], "", 1)
+	require.NoError(t, err)
+	_, _, err = blkfileMgr.retrieveBlocksByTimestamp(timestamps[2], timestamps[4], bookmark, 1)
+	require.EqualError(t, err, fmt.Sprintf("invalid bookmark [%s]", bookmark))
+}
+
+func TestRollbackRemovesOptionalIndexEntries(t *testing.T) {
+	indexItems := []IndexableAttr{IndexableAttrBlockNum, IndexableAttrChaincodeEvent, IndexableAttrBlockTimestamp}
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), indexItems, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	defer blkfileMgrWrapper.close()
+	genesisBlock := testutil.ConstructTestBlocks(t, 1)[0]
+	require.NoError(t, blkfileMgrWrapper.blockfileMgr.addBlock(genesisBlock))
+	block := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+		BlockNum:     1,
+		PreviousHash: protoutil.BlockHeaderHash(genesisBlock.Header),
+		Txs: []*testutil.TxDetails{
+			{ChaincodeName: "cc1", Events: marshalChaincodeEvent(t, "cc1", "event1"), Type: common.HeaderType_ENDORSER_TRANSACTION},
+		},
+	}, false)
+	require.NoError(t, blkfileMgrWrapper.blockfileMgr.addBlock(block))
+	index := blkfileMgrWrapper.blockfileMgr.index
+	timestamp, ok := extractBlockTimestamp(block.Data)
+	require.True(t, ok)
+	keys := [][]byte{
+		constructChaincodeEventKey("cc1", "event1", 1, 0),
+		constructBlockTimestampKey(timestamp, 1),
+	}
+	for _, key := range keys {
+		val, err := index.db.Get(key)
+		require.NoError(t, err)
+		require.NotNil(t, val)
+	}
+
+	blockBytes, _, err := serializeBlock(block)
+	require.NoError(t, err)
+	blockInfo, err := extractSerializedBlockInfo(blockBytes)
+	require.NoError(t, err)
+	batch := index.db.NewUpdateBatch()
+	require.NoError(t, addIndexEntriesToBeDeleted(batch, blockInfo, index, blkfileMgrWrapper.blockfileMgr.hashingAlgorithm))
+	require.NoError(t, index.db.WriteBatch(batch, true))
+	for _, key := range keys {
+		val, err := index.db.Get(key)
+		require.NoError(t, err)
+		require.Nil(t, val)
+	}
+}
+
+func marshalChaincodeEvent(t *testing.T, chaincodeName, eventName string) []byte {
+	eventBytes, err := proto.Marshal(&peer.ChaincodeEvent{
+		ChaincodeId: chaincodeName,
+		EventName:   eventName,
+		Payload:     []byte("payload"),
+	})
+	require.NoError(t, err)
+	return eventBytes
+}
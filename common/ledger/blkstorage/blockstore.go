@@ -57,6 +57,11 @@ func (store *BlockStore) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 	return store.fileMgr.getBlockchainInfo(), nil
 }
 
+// BlockHashingAlgorithm returns the name of the algorithm used for hashing the blocks of the ledger
+func (store *BlockStore) BlockHashingAlgorithm() string {
+	return store.fileMgr.hashingAlgorithmName
+}
+
 // RetrieveBlocks returns an iterator that can be used for iterating over a range of blocks
 func (store *BlockStore) RetrieveBlocks(startNum uint64) (ledger.ResultsIterator, error) {
 	return store.fileMgr.retrieveBlocks(startNum)
@@ -92,6 +97,23 @@ func (store *BlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// RetrieveTxsByChaincodeEvent returns at most `limit` transactions that emitted the given event of the given
+// chaincode, in the order of their commit, starting at the transaction identified by the bookmark. An empty
+// bookmark starts at the first such transaction. The returned bookmark identifies the transaction that follows
+// the returned transactions and is empty if there are no more transactions.
+// This requires `IndexableAttrChaincodeEvent` to be indexed
+func (store *BlockStore) RetrieveTxsByChaincodeEvent(chaincodeName, eventName, bookmark string, limit int) ([]*peer.ProcessedTransaction, string, error) {
+	return store.fileMgr.retrieveTransactionsByChaincodeEvent(chaincodeName, eventName, bookmark, limit)
+}
+
+// RetrieveBlocksByTimestamp returns at most `limit` blocks with a timestamp in the range [startTime, endTime), in the
+// order of their timestamps, starting at the block identified by the bookmark. An empty bookmark starts at the first
+// block in the range. The returned bookmark identifies the block that follows the returned blocks and is empty if
+// there are no more blocks in the range. This requires `IndexableAttrBlockTimestamp` to be indexed
+func (store *BlockStore) RetrieveBlocksByTimestamp(startTime, endTime time.Time, bookmark string, limit int) ([]*common.Block, string, error) {
+	return store.fileMgr.retrieveBlocksByTimestamp(startTime, endTime, bookmark, limit)
+}
+
 // ExportTxIds creates two files in the specified dir and returns a map that contains
 // the mapping between the names of the files and their hashes.
 // Technically, the TxIDs appear in the sort order of radix-sort/shortlex. However,
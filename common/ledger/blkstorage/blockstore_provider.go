@@ -29,6 +29,12 @@ const (
 	IndexableAttrBlockHash       = IndexableAttr("BlockHash")
 	IndexableAttrTxID            = IndexableAttr("TxID")
 	IndexableAttrBlockNumTranNum = IndexableAttr("BlockNumTranNum")
+	// IndexableAttrChaincodeEvent indexes the transactions by the chaincode name and the
+	// name of the chaincode event emitted by the transaction
+	IndexableAttrChaincodeEvent = IndexableAttr("ChaincodeEvent")
+	// IndexableAttrBlockTimestamp indexes the blocks by the block timestamp, i.e., the
+	// timestamp in the channel header of the first transaction in the block
+	IndexableAttrBlockTimestamp = IndexableAttr("BlockTimestamp")
 )
 
 // IndexConfig - a configuration that includes a list of attributes that should be indexed
@@ -38,10 +44,11 @@ type IndexConfig struct {
 
 // SnapshotInfo captures some of the details about the snapshot
 type SnapshotInfo struct {
-	LedgerID          string
-	LastBlockNum      uint64
-	LastBlockHash     []byte
-	PreviousBlockHash []byte
+	LedgerID              string
+	LastBlockNum          uint64
+	LastBlockHash         []byte
+	PreviousBlockHash     []byte
+	BlockHashingAlgorithm string
 }
 
 // Contains returns true iff the supplied parameter is present in the IndexConfig.AttrsToIndex
@@ -142,6 +149,9 @@ func (p *BlockStoreProvider) Remove(ledgerid string) error {
 		return err
 	}
 	dbHandle.Close()
+	if err := deleteArchivedBlockfiles(ledgerid, p.conf.getLedgerBlockDir(ledgerid), p.conf.archiveConf); err != nil {
+		return err
+	}
 	if err := os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid)); err != nil {
 		return err
 	}
@@ -20,15 +20,40 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConf      *ArchiveConf
+	blockfileFormat  blockfileFormat
 }
 
 // NewConf constructs new `Conf`.
 // blockStorageDir is the top level folder under which `BlockStore` manages its data
 func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
+	return NewConfWithArchive(blockStorageDir, maxBlockfileSize, nil)
+}
+
+// NewConfWithArchive constructs new `Conf` that enables archiving of the block files.
+// A nil archiveConf disables the archiving
+func NewConfWithArchive(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{
+		blockStorageDir:  blockStorageDir,
+		maxBlockfileSize: maxBlockfileSize,
+		archiveConf:      archiveConf,
+	}
+}
+
+// WithBlockfileCompression returns a copy of the `Conf` that uses the given compression, either
+// BlockfileCompressionNone or BlockfileCompressionSnappy, for the blocks in the new block files.
+// The existing block files remain in the format in which they were written
+func (conf *Conf) WithBlockfileCompression(compression string) (*Conf, error) {
+	format, err := blockfileFormatForCompression(compression)
+	if err != nil {
+		return nil, err
+	}
+	c := *conf
+	c.blockfileFormat = format
+	return &c, nil
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+)
+
+const convertingBlockfileTempFile = "convertingBlockfile.tmp"
+
+// ConvertBlockfiles rewrites the block files of all the ledgers in the format that uses the given compression,
+// either BlockfileCompressionNone or BlockfileCompressionSnappy. The archived block files are not converted.
+// As the locations of the blocks in the block files change, the block store index must be dropped before
+// invoking this function so that the index is rebuilt when the peer starts.
+// This function is intended to be invoked on a stopped peer.
+func ConvertBlockfiles(blockStorageDir, compression string) error {
+	format, err := blockfileFormatForCompression(compression)
+	if err != nil {
+		return err
+	}
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	chainsDirExists, err := pathExists(conf.getChainsDir())
+	if err != nil || !chainsDirExists {
+		return err
+	}
+	ledgerIDs, err := util.ListSubdirs(conf.getChainsDir())
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		if err := convertLedgerBlockfiles(conf.getLedgerBlockDir(ledgerID), format); err != nil {
+			return errors.WithMessagef(err, "error while converting the block files of the ledger [%s]", ledgerID)
+		}
+	}
+	return nil
+}
+
+func convertLedgerBlockfiles(ledgerDir string, format blockfileFormat) error {
+	archivedInfo, err := loadArchivedBlockfilesInfo(ledgerDir)
+	if err != nil {
+		return err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+	if err != nil {
+		return err
+	}
+	for fileNum := archivedInfo.numArchivedFiles(); fileNum <= lastFileNum; fileNum++ {
+		if err := convertBlockfile(ledgerDir, fileNum, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertBlockfile writes the blocks of the given block file to a temporary file in the given format and replaces
+// the block file with the temporary file. A partially written block at the end of the file is discarded, as the
+// peer discards such a block on start as well
+func convertBlockfile(ledgerDir string, fileNum int, format blockfileFormat) error {
+	stream, err := newBlockfileStream(ledgerDir, nil, fileNum, 0)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	if stream.format == format {
+		return nil
+	}
+
+	filePath := deriveBlockfilePath(ledgerDir, fileNum)
+	logger.Infof("Converting the block file [%s] from format [%s] to format [%s]", filePath, stream.format, format)
+	tempFilePath := filepath.Join(ledgerDir, convertingBlockfileTempFile)
+	writer, err := newBlockfileWriter(tempFilePath)
+	if err != nil {
+		return err
+	}
+	defer writer.close()
+	if err := writer.reset(format); err != nil {
+		return err
+	}
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err == ErrUnexpectedEndOfBlockfile {
+			logger.Warningf("Discarding the partially written block at the end of the block file [%s]", filePath)
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		encodedBlockBytes := format.encodeBlock(blockBytes)
+		if err := writer.append(proto.EncodeVarint(uint64(len(encodedBlockBytes))), false); err != nil {
+			return errors.Wrapf(err, "error writing to the file [%s]", tempFilePath)
+		}
+		if err := writer.append(encodedBlockBytes, false); err != nil {
+			return errors.Wrapf(err, "error writing to the file [%s]", tempFilePath)
+		}
+	}
+	if err := writer.file.Sync(); err != nil {
+		return errors.Wrapf(err, "error while synching the file [%s]", tempFilePath)
+	}
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		return errors.WithStack(err)
+	}
+	return syncDir(ledgerDir)
+}
@@ -15,14 +15,18 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
 )
 
 // ResetBlockStore drops the block storage index and truncates the blocks files for all channels/ledgers to genesis blocks
 func ResetBlockStore(blockStorageDir string) error {
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	if err := assertNoLedgerHasArchivedBlockfiles(conf); err != nil {
+		return err
+	}
 	if err := DeleteBlockStoreIndex(blockStorageDir); err != nil {
 		return err
 	}
-	conf := &Conf{blockStorageDir: blockStorageDir}
 	chainsDir := conf.getChainsDir()
 	chainsDirExists, err := pathExists(chainsDir)
 	if err != nil {
@@ -53,6 +57,36 @@ func ResetBlockStore(blockStorageDir string) error {
 	return nil
 }
 
+func assertNoLedgerHasArchivedBlockfiles(conf *Conf) error {
+	chainsDirExists, err := pathExists(conf.getChainsDir())
+	if err != nil || !chainsDirExists {
+		return err
+	}
+	ledgerIDs, err := util.ListSubdirs(conf.getChainsDir())
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		if err := assertNoArchivedBlockfiles(ledgerID, conf.getLedgerBlockDir(ledgerID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assertNoArchivedBlockfiles returns an error if any of the block files of the ledger is archived.
+// The offline operations that truncate the block files are not supported for such a ledger
+func assertNoArchivedBlockfiles(ledgerID, ledgerDir string) error {
+	info, err := loadArchivedBlockfilesInfo(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if info.numArchivedFiles() > 0 {
+		return errors.Errorf("the operation is not supported as [%d] block files of the ledger [%s] are archived", info.numArchivedFiles(), ledgerID)
+	}
+	return nil
+}
+
 // DeleteBlockStoreIndex deletes block store index file
 func DeleteBlockStoreIndex(blockStorageDir string) error {
 	conf := &Conf{blockStorageDir: blockStorageDir}
@@ -89,7 +123,7 @@ func resetToGenesisBlk(ledgerDir string) error {
 
 func retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir string) (string, int64, error) {
 	blockfilePath := deriveBlockfilePath(ledgerDir, 0)
-	blockfileStream, err := newBlockfileStream(ledgerDir, 0, 0)
+	blockfileStream, err := newBlockfileStream(ledgerDir, nil, 0, 0)
 	if err != nil {
 		return "", -1, err
 	}
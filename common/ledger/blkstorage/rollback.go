@@ -9,6 +9,7 @@ package blkstorage
 import (
 	"os"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/protoutil"
@@ -16,12 +17,13 @@ import (
 )
 
 type rollbackMgr struct {
-	ledgerID       string
-	ledgerDir      string
-	indexDir       string
-	dbProvider     *leveldbhelper.Provider
-	indexStore     *blockIndex
-	targetBlockNum uint64
+	ledgerID         string
+	ledgerDir        string
+	indexDir         string
+	dbProvider       *leveldbhelper.Provider
+	indexStore       *blockIndex
+	targetBlockNum   uint64
+	hashingAlgorithm func([]byte) []byte
 }
 
 // Rollback reverts changes made to the block store beyond a given block number.
@@ -32,6 +34,9 @@ func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConf
 	}
 	defer r.dbProvider.Close()
 
+	if err := assertNoArchivedBlockfiles(ledgerID, r.ledgerDir); err != nil {
+		return err
+	}
 	if err := recordHeightIfGreaterThanPreviousRecording(r.ledgerDir); err != nil {
 		return err
 	}
@@ -82,6 +87,18 @@ func (r *rollbackMgr) rollbackBlockIndex() error {
 		return err
 	}
 
+	bsi, err := loadBootstrappingSnapshotInfo(r.ledgerDir)
+	if err != nil {
+		return err
+	}
+	hashingAlgorithmName, err := blockHashingAlgorithmOfLedger(r.ledgerDir, nil, bsi, false)
+	if err != nil {
+		return err
+	}
+	if r.hashingAlgorithm, err = channelconfig.HashingAlgorithmByName(hashingAlgorithmName); err != nil {
+		return err
+	}
+
 	// we remove index associated with only 10 blocks at a time
 	// to avoid overuse of memory occupied by the leveldb batch.
 	// If we assume a block size of 2000 transactions and 4 indices
@@ -119,7 +136,7 @@ func (r *rollbackMgr) deleteIndexEntriesRange(startBlkNum, endBlkNum uint64) err
 		return err
 	}
 
-	stream, err := newBlockStream(r.ledgerDir, lp.fileSuffixNum, int64(lp.offset), -1)
+	stream, err := newBlockStream(r.ledgerDir, nil, lp.fileSuffixNum, int64(lp.offset), -1)
 	if err != nil {
 		return err
 	}
@@ -135,7 +152,7 @@ func (r *rollbackMgr) deleteIndexEntriesRange(startBlkNum, endBlkNum uint64) err
 		if err != nil {
 			return err
 		}
-		addIndexEntriesToBeDeleted(batch, blockInfo, r.indexStore)
+		addIndexEntriesToBeDeleted(batch, blockInfo, r.indexStore, r.hashingAlgorithm)
 		numberOfBlocksToRetrieve--
 	}
 
@@ -143,9 +160,9 @@ func (r *rollbackMgr) deleteIndexEntriesRange(startBlkNum, endBlkNum uint64) err
 	return r.indexStore.db.WriteBatch(batch, true)
 }
 
-func addIndexEntriesToBeDeleted(batch *leveldbhelper.UpdateBatch, blockInfo *serializedBlockInfo, indexStore *blockIndex) error {
+func addIndexEntriesToBeDeleted(batch *leveldbhelper.UpdateBatch, blockInfo *serializedBlockInfo, indexStore *blockIndex, hashingAlgorithm func([]byte) []byte) error {
 	if indexStore.isAttributeIndexed(IndexableAttrBlockHash) {
-		batch.Delete(constructBlockHashKey(protoutil.BlockHeaderHash(blockInfo.blockHeader)))
+		batch.Delete(constructBlockHashKey(protoutil.BlockHeaderHashWith(blockInfo.blockHeader, hashingAlgorithm)))
 	}
 
 	if indexStore.isAttributeIndexed(IndexableAttrBlockNum) {
@@ -163,6 +180,20 @@ func addIndexEntriesToBeDeleted(batch *leveldbhelper.UpdateBatch, blockInfo *ser
 			batch.Delete(constructTxIDKey(txOffset.txID, blockInfo.blockHeader.Number, uint64(i)))
 		}
 	}
+
+	if indexStore.isAttributeIndexed(IndexableAttrChaincodeEvent) {
+		for i, txEnvelopeBytes := range blockInfo.data.Data {
+			if event := extractChaincodeEvent(txEnvelopeBytes); event != nil {
+				batch.Delete(constructChaincodeEventKey(event.ChaincodeId, event.EventName, blockInfo.blockHeader.Number, uint64(i)))
+			}
+		}
+	}
+
+	if indexStore.isAttributeIndexed(IndexableAttrBlockTimestamp) {
+		if timestamp, ok := extractBlockTimestamp(blockInfo.data); ok {
+			batch.Delete(constructBlockTimestampKey(timestamp, blockInfo.blockHeader.Number))
+		}
+	}
 	return nil
 }
 
@@ -206,7 +237,7 @@ func (r *rollbackMgr) rollbackBlockFiles() error {
 }
 
 func calculateEndOffSet(ledgerDir string, targetBlkFileNum int, blockNum uint64) (int64, error) {
-	stream, err := newBlockfileStream(ledgerDir, targetBlkFileNum, 0)
+	stream, err := newBlockfileStream(ledgerDir, nil, targetBlkFileNum, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -14,10 +14,13 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
@@ -138,6 +141,9 @@ func TestBootstrapFromSnapshot(t *testing.T) {
 				Height:            snapshotInfo.LastBlockNum + 1,
 				CurrentBlockHash:  snapshotInfo.LastBlockHash,
 				PreviousBlockHash: snapshotInfo.PreviousBlockHash,
+				BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+					LastBlockInSnapshot: snapshotInfo.LastBlockNum,
+				},
 			},
 			blocksDetailsBeforeSnapshot,
 			blocksBeforeSnapshot,
@@ -161,6 +167,9 @@ func TestBootstrapFromSnapshot(t *testing.T) {
 			Height:            finalBlock.Header.Number + 1,
 			CurrentBlockHash:  protoutil.BlockHeaderHash(finalBlock.Header),
 			PreviousBlockHash: finalBlock.Header.PreviousHash,
+			BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+				LastBlockInSnapshot: snapshotInfo.LastBlockNum,
+			},
 		}
 		verifyQueriesOnBlocksPriorToSnapshot(t,
 			bootstrappedBlockStore,
@@ -188,6 +197,9 @@ func TestBootstrapFromSnapshot(t *testing.T) {
 				Height:            snapshotInfo.LastBlockNum + 1,
 				CurrentBlockHash:  snapshotInfo.LastBlockHash,
 				PreviousBlockHash: snapshotInfo.PreviousBlockHash,
+				BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+					LastBlockInSnapshot: snapshotInfo.LastBlockNum,
+				},
 			},
 			blocksDetailsBeforeSnapshot,
 			blocksBeforeSnapshot,
@@ -203,6 +215,9 @@ func TestBootstrapFromSnapshot(t *testing.T) {
 			Height:            finalBlock.Header.Number + 1,
 			CurrentBlockHash:  protoutil.BlockHeaderHash(finalBlock.Header),
 			PreviousBlockHash: finalBlock.Header.PreviousHash,
+			BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+				LastBlockInSnapshot: snapshotInfo.LastBlockNum,
+			},
 		}
 		verifyQueriesOnBlocksAddedAfterBootstrapping(t,
 			bootstrappedBlockStore,
@@ -274,6 +289,9 @@ func TestBootstrapFromSnapshot(t *testing.T) {
 					Height:            finalBlock.Header.Number + 1,
 					CurrentBlockHash:  protoutil.BlockHeaderHash(finalBlock.Header),
 					PreviousBlockHash: finalBlock.Header.PreviousHash,
+					BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+						LastBlockInSnapshot: snapshotInfo.LastBlockNum,
+					},
 				},
 				blockDetails,
 				blocks,
@@ -297,6 +315,58 @@ func TestBootstrapFromSnapshot(t *testing.T) {
 	})
 }
 
+func TestBootstrapFromSnapshotBlockHashingAlgorithm(t *testing.T) {
+	testDir := testPath()
+	env := newTestEnv(t, NewConf(testDir, 0))
+	defer func() { env.Cleanup() }()
+	snapshotDir := filepath.Join(testDir, "snapshot")
+	require.NoError(t, os.Mkdir(snapshotDir, 0755))
+
+	blocks := constructGMSM3TestBlocks(t, "originalLedger", 4)
+	originalBlockStore, err := env.provider.Open("originalLedger")
+	require.NoError(t, err)
+	for _, b := range blocks[:3] {
+		require.NoError(t, originalBlockStore.AddBlock(b))
+	}
+	require.Equal(t, bccsp.GMSM3, originalBlockStore.BlockHashingAlgorithm())
+	_, err = originalBlockStore.ExportTxIds(snapshotDir, testNewHashFunc)
+	require.NoError(t, err)
+
+	lastBlockInSnapshot := blocks[2]
+	bootstrappedBlockStore, err := env.provider.BootstrapFromSnapshottedTxIDs(
+		snapshotDir,
+		&SnapshotInfo{
+			LedgerID:              "bootstrappedLedger",
+			LastBlockNum:          lastBlockInSnapshot.Header.Number,
+			LastBlockHash:         protoutil.BlockHeaderHashWith(lastBlockInSnapshot.Header, util.ComputeGMSM3),
+			PreviousBlockHash:     lastBlockInSnapshot.Header.PreviousHash,
+			BlockHashingAlgorithm: originalBlockStore.BlockHashingAlgorithm(),
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, bootstrappedBlockStore.AddBlock(blocks[3]))
+
+	// the blocks added after the snapshot are hashed with the algorithm of the channel,
+	// which is recorded in the bootstrapping snapshot info, as the genesis block is not available
+	verify := func(store *BlockStore) {
+		require.Equal(t, bccsp.GMSM3, store.BlockHashingAlgorithm())
+		blockHash := protoutil.BlockHeaderHashWith(blocks[3].Header, util.ComputeGMSM3)
+		bcInfo, err := store.GetBlockchainInfo()
+		require.NoError(t, err)
+		require.Equal(t, blockHash, bcInfo.CurrentBlockHash)
+		b, err := store.RetrieveBlockByHash(blockHash)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(blocks[3], b))
+	}
+	verify(bootstrappedBlockStore)
+
+	env.provider.Close()
+	env = newTestEnv(t, env.provider.conf)
+	bootstrappedBlockStore, err = env.provider.Open("bootstrappedLedger")
+	require.NoError(t, err)
+	verify(bootstrappedBlockStore)
+}
+
 func TestBootstrapFromSnapshotErrorPaths(t *testing.T) {
 	testPath := testPath()
 	env := newTestEnv(t, NewConf(testPath, 0))
@@ -76,12 +76,13 @@ func (m *TxIDIndexValue) GetTxValidationCode() int32 {
 }
 
 type BootstrappingSnapshotInfo struct {
-	LastBlockNum         uint64   `protobuf:"varint,1,opt,name=lastBlockNum,proto3" json:"lastBlockNum,omitempty"`
-	LastBlockHash        []byte   `protobuf:"bytes,2,opt,name=lastBlockHash,proto3" json:"lastBlockHash,omitempty"`
-	PreviousBlockHash    []byte   `protobuf:"bytes,3,opt,name=previousBlockHash,proto3" json:"previousBlockHash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LastBlockNum          uint64   `protobuf:"varint,1,opt,name=lastBlockNum,proto3" json:"lastBlockNum,omitempty"`
+	LastBlockHash         []byte   `protobuf:"bytes,2,opt,name=lastBlockHash,proto3" json:"lastBlockHash,omitempty"`
+	PreviousBlockHash     []byte   `protobuf:"bytes,3,opt,name=previousBlockHash,proto3" json:"previousBlockHash,omitempty"`
+	BlockHashingAlgorithm string   `protobuf:"bytes,4,opt,name=blockHashingAlgorithm,proto3" json:"blockHashingAlgorithm,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *BootstrappingSnapshotInfo) Reset()         { *m = BootstrappingSnapshotInfo{} }
@@ -130,6 +131,13 @@ func (m *BootstrappingSnapshotInfo) GetPreviousBlockHash() []byte {
 	return nil
 }
 
+func (m *BootstrappingSnapshotInfo) GetBlockHashingAlgorithm() string {
+	if m != nil {
+		return m.BlockHashingAlgorithm
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TxIDIndexValue)(nil), "msgs.txIDIndexValue")
 	proto.RegisterType((*BootstrappingSnapshotInfo)(nil), "msgs.bootstrappingSnapshotInfo")
@@ -138,22 +146,24 @@ func init() {
 func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 271 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x41, 0x4b, 0xf3, 0x40,
-	0x10, 0x86, 0xc9, 0xd7, 0x7e, 0x1e, 0xa6, 0xa9, 0xe8, 0x9e, 0xea, 0xc9, 0x1a, 0x3c, 0xf4, 0x50,
-	0x9a, 0x83, 0x20, 0x9e, 0xab, 0x07, 0x03, 0xe2, 0x21, 0x82, 0x07, 0x2f, 0x61, 0x77, 0xb3, 0x4d,
-	0x96, 0x6c, 0x76, 0x96, 0xdd, 0x49, 0x89, 0x57, 0xff, 0x82, 0x7f, 0x58, 0x0c, 0xa1, 0xa5, 0x78,
-	0x9c, 0xe7, 0x7d, 0x60, 0x5e, 0x5e, 0x98, 0x07, 0x42, 0xcf, 0x2b, 0xb5, 0x71, 0x1e, 0x09, 0xd9,
-	0xb4, 0x0d, 0x55, 0x48, 0xbe, 0x22, 0x38, 0xa7, 0x3e, 0x7b, 0xca, 0x6c, 0xa9, 0xfa, 0x77, 0x6e,
-	0x3a, 0xc5, 0x6e, 0x20, 0x16, 0xa6, 0x29, 0x0c, 0x4a, 0x4e, 0x1a, 0xed, 0x22, 0x5a, 0x46, 0xab,
-	0x38, 0x9f, 0x09, 0xd3, 0xbc, 0x8c, 0x88, 0x5d, 0xc3, 0x8c, 0xfa, 0xa3, 0xf1, 0x6f, 0x30, 0x80,
-	0xfa, 0x83, 0xb0, 0x06, 0x46, 0x7d, 0xb1, 0xe7, 0x46, 0x97, 0x03, 0x28, 0x24, 0x96, 0x6a, 0x31,
-	0x59, 0x46, 0xab, 0xff, 0xf9, 0x05, 0xfd, 0x3e, 0x1a, 0x83, 0x47, 0x2c, 0x55, 0xf2, 0x1d, 0xc1,
-	0x95, 0x40, 0xa4, 0x40, 0x9e, 0x3b, 0xa7, 0x6d, 0xf5, 0x66, 0xb9, 0x0b, 0x35, 0x52, 0x66, 0x77,
-	0xc8, 0x12, 0x88, 0x0d, 0x0f, 0xb4, 0x35, 0x28, 0x9b, 0xd7, 0xae, 0x1d, 0xfa, 0x4c, 0xf3, 0x13,
-	0xc6, 0x6e, 0x61, 0x7e, 0xb8, 0x9f, 0x79, 0xa8, 0xc7, 0x4a, 0xa7, 0x90, 0xad, 0xe1, 0xd2, 0x79,
-	0xb5, 0xd7, 0xd8, 0x85, 0xa3, 0x39, 0x19, 0xcc, 0xbf, 0xc1, 0xf6, 0xe1, 0xe3, 0xbe, 0xd2, 0x54,
-	0x77, 0x62, 0x23, 0xb1, 0x4d, 0xeb, 0x4f, 0xa7, 0xbc, 0x51, 0x65, 0xa5, 0x7c, 0xba, 0xe3, 0xc2,
-	0x6b, 0x99, 0x4a, 0x6c, 0x5b, 0xb4, 0xe9, 0x08, 0x85, 0x69, 0xc6, 0x81, 0xc5, 0xd9, 0xb0, 0xf0,
-	0xdd, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x44, 0x15, 0xf9, 0x72, 0x01, 0x00, 0x00,
+	// 290 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xb1, 0x4b, 0x3b, 0x31,
+	0x1c, 0xc5, 0xb9, 0x5f, 0xfb, 0x13, 0x4c, 0x5b, 0xd1, 0x80, 0x50, 0x27, 0x6b, 0x71, 0xe8, 0x50,
+	0x7a, 0x83, 0x22, 0xae, 0x56, 0x07, 0x0b, 0xe2, 0x70, 0x42, 0x07, 0x97, 0x92, 0xdc, 0xa5, 0xb9,
+	0x70, 0x49, 0xbe, 0x21, 0xf9, 0x5e, 0x89, 0xab, 0xff, 0x9d, 0xff, 0x95, 0x70, 0x9c, 0x57, 0x8a,
+	0x8e, 0xef, 0xf3, 0x3e, 0xc3, 0x83, 0x47, 0x46, 0x01, 0xc1, 0x33, 0x29, 0x16, 0xce, 0x03, 0x02,
+	0xed, 0x9b, 0x20, 0xc3, 0xf4, 0x33, 0x21, 0x27, 0x18, 0x57, 0x4f, 0x2b, 0x5b, 0x88, 0xb8, 0x66,
+	0xba, 0x16, 0xf4, 0x8a, 0x0c, 0xb9, 0xae, 0x36, 0x1a, 0x72, 0x86, 0x0a, 0xec, 0x38, 0x99, 0x24,
+	0xb3, 0x61, 0x36, 0xe0, 0xba, 0x7a, 0x69, 0x11, 0xbd, 0x24, 0x03, 0x8c, 0x7b, 0xe3, 0x5f, 0x63,
+	0x10, 0x8c, 0x9d, 0x30, 0x27, 0x14, 0xe3, 0x66, 0xc7, 0xb4, 0x2a, 0x1a, 0xb0, 0xc9, 0xa1, 0x10,
+	0xe3, 0xde, 0x24, 0x99, 0xfd, 0xcf, 0x4e, 0x31, 0xae, 0xbb, 0xe2, 0x11, 0x0a, 0x31, 0xfd, 0x4a,
+	0xc8, 0x05, 0x07, 0xc0, 0x80, 0x9e, 0x39, 0xa7, 0xac, 0x7c, 0xb3, 0xcc, 0x85, 0x12, 0x70, 0x65,
+	0xb7, 0x40, 0xa7, 0x64, 0xa8, 0x59, 0xc0, 0xa5, 0x86, 0xbc, 0x7a, 0xad, 0x4d, 0xb3, 0xa7, 0x9f,
+	0x1d, 0x30, 0x7a, 0x4d, 0x46, 0x5d, 0x7e, 0x66, 0xa1, 0x6c, 0x27, 0x1d, 0x42, 0x3a, 0x27, 0x67,
+	0xce, 0x8b, 0x9d, 0x82, 0x3a, 0xec, 0xcd, 0x5e, 0x63, 0xfe, 0x2e, 0xe8, 0x2d, 0x39, 0xe7, 0x3f,
+	0x41, 0x59, 0xf9, 0xa0, 0x25, 0x78, 0x85, 0xa5, 0x19, 0xf7, 0x27, 0xc9, 0xec, 0x38, 0xfb, 0xbb,
+	0x5c, 0xde, 0xbf, 0xdf, 0x49, 0x85, 0x65, 0xcd, 0x17, 0x39, 0x98, 0xb4, 0xfc, 0x70, 0xc2, 0x6b,
+	0x51, 0x48, 0xe1, 0xd3, 0x2d, 0xe3, 0x5e, 0xe5, 0x69, 0x0e, 0xc6, 0x80, 0x4d, 0x5b, 0xc8, 0x75,
+	0xd5, 0xde, 0xc2, 0x8f, 0x9a, 0x5f, 0x6e, 0xbe, 0x07, 0x00, 0xd0, 0x41, 0x7a, 0xc4, 0xa8, 0x01,
+	0x00, 0x00,
 }
@@ -0,0 +1,350 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	fabricutil "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// These are the names of the checks performed by VerifyBlockStore
+const (
+	CheckBlockFiles  = "block_files"
+	CheckBlockNumber = "block_number"
+	CheckHashChain   = "hash_chain"
+	CheckDataHash    = "data_hash"
+	CheckBlockIndex  = "block_index"
+)
+
+// IntegrityIssue describes an inconsistency found in the block files or in the block index of a ledger
+type IntegrityIssue struct {
+	BlockNum uint64
+	Check    string
+	Details  string
+}
+
+// VerificationResult is returned by VerifyBlockStore
+type VerificationResult struct {
+	// FirstBlockNum is the number of the first block expected in the block files. This is
+	// non-zero for a ledger that is bootstrapped from a snapshot
+	FirstBlockNum uint64
+	// Height is one more than the number of the last block read from the block files
+	Height uint64
+	// Issues lists the inconsistencies found, in the increasing order of the block numbers
+	Issues []*IntegrityIssue
+}
+
+// VerifyBlockStore reads the block files of a ledger sequentially and checks that the blocks are
+// numbered consecutively, that each block refers to the hash of the previous block, and that the
+// data hash in each block header matches the block data. For the blocks that are already indexed,
+// the entries in the block index are cross-checked against the location of the blocks and the
+// transactions in the block files. The hashes are computed with the block hashing algorithm configured
+// by the genesis block or, for a ledger bootstrapped from a snapshot, recorded in the bootstrapping
+// snapshot info. The function `blockHandler` is invoked for each block, in order,
+// until the first issue is found. The archived block files are read from the archive configured in
+// `conf`. This function is intended to be invoked on a stopped peer.
+func VerifyBlockStore(conf *Conf, ledgerID string, indexConfig *IndexConfig, blockHandler func(*common.Block) error) (*VerificationResult, error) {
+	ledgerDir := conf.getLedgerBlockDir(ledgerID)
+	if _, err := os.Stat(ledgerDir); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the block storage of the ledger [%s]", ledgerID)
+	}
+	archive, err := openBlockfileArchive(ledgerID, ledgerDir, conf.archiveConf)
+	if err != nil {
+		return nil, err
+	}
+
+	dbProvider, err := leveldbhelper.NewProvider(
+		&leveldbhelper.Conf{
+			DBPath:         conf.getIndexDir(),
+			ExpectedFormat: dataFormatVersion(indexConfig),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer dbProvider.Close()
+	indexDB := dbProvider.GetDBHandle(ledgerID)
+	index, err := newBlockIndex(indexConfig, indexDB)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &blockStoreVerifier{
+		ledgerDir:        ledgerDir,
+		archive:          archive,
+		index:            index,
+		hashingAlgorithm: fabricutil.ComputeSHA256,
+		result:           &VerificationResult{},
+		blockHandler:     blockHandler,
+	}
+	if v.bsi, err = loadBootstrappingSnapshotInfo(ledgerDir); err != nil {
+		return nil, err
+	}
+	if v.bsi != nil {
+		v.result.FirstBlockNum = v.bsi.LastBlockNum + 1
+		v.previousBlockHash = v.bsi.LastBlockHash
+		// the genesis block is not available for a ledger bootstrapped from a snapshot
+		if v.hashingAlgorithm, err = channelconfig.HashingAlgorithmByName(snapshotBlockHashingAlgorithm(v.bsi)); err != nil {
+			return nil, errors.WithMessagef(err, "error while determining the block hashing algorithm of the ledger [%s]", ledgerID)
+		}
+	}
+	v.result.Height = v.result.FirstBlockNum
+
+	v.lastBlockIndexed, err = index.getLastBlockIndexed()
+	switch {
+	case err == errIndexSavePointKeyNotPresent:
+		v.indexEmpty = true
+	case err != nil:
+		return nil, err
+	}
+
+	if err := v.verifyBlockfiles(); err != nil {
+		return nil, err
+	}
+	if err := v.verifyBlockfilesInfo(indexDB); err != nil {
+		return nil, err
+	}
+	return v.result, nil
+}
+
+type blockStoreVerifier struct {
+	ledgerDir         string
+	archive           *blockfileArchive
+	index             *blockIndex
+	bsi               *BootstrappingSnapshotInfo
+	lastBlockIndexed  uint64
+	indexEmpty        bool
+	hashingAlgorithm  func([]byte) []byte
+	previousBlockHash []byte
+	result            *VerificationResult
+	blockHandler      func(*common.Block) error
+}
+
+func (v *blockStoreVerifier) verifyBlockfiles() error {
+	lastFileNum, err := retrieveLastFileSuffix(v.ledgerDir)
+	if err != nil {
+		return err
+	}
+	if lastFileNum < 0 {
+		return nil
+	}
+	stream, err := newBlockStream(v.ledgerDir, v.archive, 0, 0, lastFileNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	for {
+		expectedBlockNum := v.result.Height
+		blockBytes, placementInfo, err := nextBlockBytesAndPlacementInfo(stream)
+		if err != nil {
+			v.addIssue(expectedBlockNum, CheckBlockFiles, "error while reading the block from the block files: %s", err)
+			return nil
+		}
+		if blockBytes == nil {
+			return nil
+		}
+		block, err := deserializeBlock(blockBytes)
+		if err != nil {
+			v.addIssue(expectedBlockNum, CheckBlockFiles, "error while deserializing the block at [%s]: %s", placementInfo, err)
+			return nil
+		}
+		if block.Header.Number != expectedBlockNum {
+			v.addIssue(expectedBlockNum, CheckBlockNumber,
+				"unexpected block number at [%s]. Expected block number = [%d], actual block number = [%d]",
+				placementInfo, expectedBlockNum, block.Header.Number)
+			return nil
+		}
+		v.result.Height++
+
+		if err := v.verifyBlock(block, blockBytes, placementInfo); err != nil {
+			return err
+		}
+		if len(v.result.Issues) == 0 && v.blockHandler != nil {
+			if err := v.blockHandler(block); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (v *blockStoreVerifier) verifyBlock(block *common.Block, blockBytes []byte, placementInfo *blockPlacementInfo) error {
+	blockNum := block.Header.Number
+	if blockNum == 0 {
+		hashingAlgorithmName, err := blockHashingAlgorithmFromGenesisBlock(block)
+		var hashingAlgorithm func([]byte) []byte
+		if err == nil {
+			hashingAlgorithm, err = channelconfig.HashingAlgorithmByName(hashingAlgorithmName)
+		}
+		if err != nil {
+			v.addIssue(blockNum, CheckHashChain, "error while determining the block hashing algorithm from the genesis block: %s", err)
+		} else {
+			v.hashingAlgorithm = hashingAlgorithm
+		}
+	}
+
+	if (blockNum != 0 || v.bsi != nil) && !bytes.Equal(block.Header.PreviousHash, v.previousBlockHash) {
+		v.addIssue(blockNum, CheckHashChain,
+			"previous block hash mismatch. Expected previous hash = [%x], previous hash in the block = [%x]",
+			v.previousBlockHash, block.Header.PreviousHash)
+	}
+	if block.Data == nil {
+		v.addIssue(blockNum, CheckDataHash, "the block does not contain data")
+	} else if dataHash := protoutil.BlockDataHashWith(block.Data, v.hashingAlgorithm); !bytes.Equal(block.Header.DataHash, dataHash) {
+		v.addIssue(blockNum, CheckDataHash,
+			"data hash mismatch. Data hash in the block header = [%x], hash of the block data = [%x]",
+			block.Header.DataHash, dataHash)
+	}
+	blockHash := protoutil.BlockHeaderHashWith(block.Header, v.hashingAlgorithm)
+	v.previousBlockHash = blockHash
+
+	if v.indexEmpty || blockNum > v.lastBlockIndexed {
+		// the index may lag behind the block files and is synced when the peer starts
+		return nil
+	}
+	details, err := v.verifyIndexEntries(block, blockHash, blockBytes, placementInfo)
+	if err != nil {
+		return err
+	}
+	if details != "" {
+		v.addIssue(blockNum, CheckBlockIndex, "%s", details)
+	}
+	return nil
+}
+
+// verifyIndexEntries returns the details of the first index entry of the block that does not
+// match with the block files, or an empty string if all the index entries of the block match
+func (v *blockStoreVerifier) verifyIndexEntries(block *common.Block, blockHash, blockBytes []byte, placementInfo *blockPlacementInfo) (string, error) {
+	blockNum := block.Header.Number
+	blockLoc := &fileLocPointer{
+		fileSuffixNum: placementInfo.fileNum,
+		locPointer:    locPointer{offset: int(placementInfo.blockStartOffset)},
+	}
+
+	if v.index.isAttributeIndexed(IndexableAttrBlockNum) {
+		flp, err := v.index.getBlockLocByBlockNum(blockNum)
+		if details, err := compareBlockLoc("block number", blockLoc, flp, err); details != "" || err != nil {
+			return details, err
+		}
+	}
+	if v.index.isAttributeIndexed(IndexableAttrBlockHash) {
+		flp, err := v.index.getBlockLocByHash(blockHash)
+		if details, err := compareBlockLoc("block hash", blockLoc, flp, err); details != "" || err != nil {
+			return details, err
+		}
+	}
+
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return "", err
+	}
+	txsFilter := txflags.ValidationFlags(block.Metadata.GetMetadata()[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	numBytesToShift := int(placementInfo.blockBytesOffset - placementInfo.blockStartOffset)
+	for txNum, txOffset := range info.txOffsets {
+		txLoc := newTxFileLocationPointer(blockLoc, true, txOffset.loc)
+		if !placementInfo.compressed {
+			txLoc = newFileLocationPointer(blockLoc.fileSuffixNum, blockLoc.offset+numBytesToShift, txOffset.loc)
+		}
+
+		if v.index.isAttributeIndexed(IndexableAttrBlockNumTranNum) {
+			flp, err := v.index.getTXLocByBlockNumTranNum(blockNum, uint64(txNum))
+			switch {
+			case err == ErrNotFoundInIndex:
+				return fmt.Sprintf("the transaction number [%d] is missing in the index", txNum), nil
+			case err != nil:
+				return "", err
+			case *flp != *txLoc:
+				return fmt.Sprintf("the index entry for the transaction number [%d] points to [%s] instead of [%s]", txNum, flp, txLoc), nil
+			}
+		}
+
+		if v.index.isAttributeIndexed(IndexableAttrTxID) {
+			valBytes, err := v.index.db.Get(constructTxIDKey(txOffset.txID, blockNum, uint64(txNum)))
+			if err != nil {
+				return "", err
+			}
+			if valBytes == nil {
+				return fmt.Sprintf("the transaction ID [%s] of the transaction number [%d] is missing in the index", txOffset.txID, txNum), nil
+			}
+			val := &TxIDIndexValue{}
+			if err := proto.Unmarshal(valBytes, val); err != nil {
+				return fmt.Sprintf("the index entry for the transaction ID [%s] cannot be unmarshaled: %s", txOffset.txID, err), nil
+			}
+			indexedTxLoc := &fileLocPointer{}
+			if err := indexedTxLoc.unmarshal(val.TxLocation); err != nil {
+				return fmt.Sprintf("the index entry for the transaction ID [%s] cannot be unmarshaled: %s", txOffset.txID, err), nil
+			}
+			if *indexedTxLoc != *txLoc {
+				return fmt.Sprintf("the index entry for the transaction ID [%s] points to [%s] instead of [%s]", txOffset.txID, indexedTxLoc, txLoc), nil
+			}
+			if validationCode := int32(txsFilter.Flag(txNum)); val.TxValidationCode != validationCode {
+				return fmt.Sprintf("the index entry for the transaction ID [%s] has the validation code [%d] instead of [%d]",
+					txOffset.txID, val.TxValidationCode, validationCode), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// verifyBlockfilesInfo checks that the block files contain all the blocks recorded as persisted in the index db
+func (v *blockStoreVerifier) verifyBlockfilesInfo(indexDB *leveldbhelper.DBHandle) error {
+	b, err := indexDB.Get(blkMgrInfoKey)
+	if err != nil || b == nil {
+		return err
+	}
+	info := &blockfilesInfo{}
+	if err := info.unmarshal(b); err != nil {
+		return err
+	}
+	if !info.noBlockFiles && info.lastPersistedBlock >= v.result.Height {
+		v.addIssue(v.result.Height, CheckBlockFiles,
+			"the blocks [%d] to [%d] are recorded as persisted but are missing in the block files",
+			v.result.Height, info.lastPersistedBlock)
+	}
+	return nil
+}
+
+func (v *blockStoreVerifier) addIssue(blockNum uint64, check string, format string, args ...interface{}) {
+	v.result.Issues = append(v.result.Issues, &IntegrityIssue{
+		BlockNum: blockNum,
+		Check:    check,
+		Details:  fmt.Sprintf(format, args...),
+	})
+}
+
+func compareBlockLoc(indexName string, expected, actual *fileLocPointer, err error) (string, error) {
+	switch {
+	case err == ErrNotFoundInIndex:
+		return fmt.Sprintf("the block is missing in the %s index", indexName), nil
+	case err != nil:
+		return "", err
+	case actual.fileSuffixNum != expected.fileSuffixNum || actual.offset != expected.offset:
+		return fmt.Sprintf("the %s index points to [%s] instead of [%s]", indexName, actual, expected), nil
+	}
+	return "", nil
+}
+
+// nextBlockBytesAndPlacementInfo converts the panic raised by the block stream on a corrupted
+// block length into an error, so that the verification can report it
+func nextBlockBytesAndPlacementInfo(stream *blockStream) (blockBytes []byte, placementInfo *blockPlacementInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%v", r)
+		}
+	}()
+	return stream.nextBlockBytesAndPlacementInfo()
+}
@@ -0,0 +1,223 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockStore(t *testing.T) {
+	indexConfig := &IndexConfig{AttrsToIndex: attrsToIndex}
+
+	// setup stores the blocks in the ledger "testLedger" in a new block storage, with five blocks per
+	// block file. The function `tamper` is invoked on the blockfileMgr before closing the store
+	setup := func(t *testing.T, blocks []*common.Block, tamper func(mgr *blockfileMgr)) string {
+		path := testPath()
+		env := newTestEnv(t, NewConf(path, 0))
+		defer env.provider.Close()
+		w := newTestBlockfileWrapper(env, "testLedger")
+		defer w.close()
+		for i, b := range blocks {
+			require.NoError(t, w.blockfileMgr.addBlock(b))
+			if i != 0 && i%5 == 0 {
+				w.blockfileMgr.moveToNextFile()
+			}
+		}
+		if tamper != nil {
+			tamper(w.blockfileMgr)
+		}
+		return path
+	}
+
+	verify := func(t *testing.T, path string) (*VerificationResult, []uint64) {
+		var handledBlocks []uint64
+		result, err := VerifyBlockStore(NewConf(path, 0), "testLedger", indexConfig, func(b *common.Block) error {
+			handledBlocks = append(handledBlocks, b.Header.Number)
+			return nil
+		})
+		require.NoError(t, err)
+		return result, handledBlocks
+	}
+
+	t.Run("intact-store", func(t *testing.T) {
+		blocks := testutil.ConstructTestBlocks(t, 12)
+		path := setup(t, blocks, nil)
+		defer os.RemoveAll(path)
+
+		result, handledBlocks := verify(t, path)
+		require.Equal(t, &VerificationResult{FirstBlockNum: 0, Height: 12}, result)
+		require.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, handledBlocks)
+	})
+
+	t.Run("data-hash-mismatch", func(t *testing.T) {
+		blocks := testutil.ConstructTestBlocks(t, 8)
+		blocks[3].Header.DataHash = []byte("tampered-data-hash")
+		// recompute the hash chain so that only the data hash check fails
+		for i := 4; i < len(blocks); i++ {
+			blocks[i].Header.PreviousHash = protoutil.BlockHeaderHash(blocks[i-1].Header)
+		}
+		path := setup(t, blocks, nil)
+		defer os.RemoveAll(path)
+
+		result, handledBlocks := verify(t, path)
+		require.Equal(t, uint64(8), result.Height)
+		require.Len(t, result.Issues, 1)
+		require.Equal(t, uint64(3), result.Issues[0].BlockNum)
+		require.Equal(t, CheckDataHash, result.Issues[0].Check)
+		require.Contains(t, result.Issues[0].Details, "data hash mismatch")
+		require.Equal(t, []uint64{0, 1, 2}, handledBlocks)
+	})
+
+	t.Run("missing-index-entry", func(t *testing.T) {
+		blocks := testutil.ConstructTestBlocks(t, 8)
+		path := setup(t, blocks, func(mgr *blockfileMgr) {
+			require.NoError(t, mgr.index.db.Delete(constructBlockNumTranNumKey(6, 0), true))
+		})
+		defer os.RemoveAll(path)
+
+		result, handledBlocks := verify(t, path)
+		require.Equal(t, []*IntegrityIssue{
+			{
+				BlockNum: 6,
+				Check:    CheckBlockIndex,
+				Details:  "the transaction number [0] is missing in the index",
+			},
+		}, result.Issues)
+		require.Equal(t, []uint64{0, 1, 2, 3, 4, 5}, handledBlocks)
+	})
+
+	t.Run("index-points-to-wrong-location", func(t *testing.T) {
+		blocks := testutil.ConstructTestBlocks(t, 8)
+		path := setup(t, blocks, func(mgr *blockfileMgr) {
+			flpBytes, err := mgr.index.db.Get(constructBlockNumKey(1))
+			require.NoError(t, err)
+			require.NoError(t, mgr.index.db.Put(constructBlockNumKey(2), flpBytes, true))
+		})
+		defer os.RemoveAll(path)
+
+		result, _ := verify(t, path)
+		require.Len(t, result.Issues, 1)
+		require.Equal(t, uint64(2), result.Issues[0].BlockNum)
+		require.Equal(t, CheckBlockIndex, result.Issues[0].Check)
+		require.Contains(t, result.Issues[0].Details, "the block number index points to")
+	})
+
+	t.Run("truncated-block-file", func(t *testing.T) {
+		blocks := testutil.ConstructTestBlocks(t, 8)
+		path := setup(t, blocks, nil)
+		defer os.RemoveAll(path)
+
+		lastFile := deriveBlockfilePath((&Conf{blockStorageDir: path}).getLedgerBlockDir("testLedger"), 1)
+		fileInfo, err := os.Stat(lastFile)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(lastFile, fileInfo.Size()-10))
+
+		result, handledBlocks := verify(t, path)
+		require.Equal(t, uint64(7), result.Height)
+		require.Len(t, result.Issues, 2)
+		require.Equal(t, &IntegrityIssue{
+			BlockNum: 7,
+			Check:    CheckBlockFiles,
+			Details:  "error while reading the block from the block files: unexpected end of blockfile",
+		}, result.Issues[0])
+		require.Equal(t, &IntegrityIssue{
+			BlockNum: 7,
+			Check:    CheckBlockFiles,
+			Details:  "the blocks [7] to [7] are recorded as persisted but are missing in the block files",
+		}, result.Issues[1])
+		require.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6}, handledBlocks)
+	})
+
+	t.Run("broken-hash-chain", func(t *testing.T) {
+		blocks := testutil.ConstructTestBlocks(t, 8)
+		path := setup(t, blocks, nil)
+		defer os.RemoveAll(path)
+
+		// flip a byte of the previous hash of the block 6 in place, so that the block offsets do not change
+		blockfile := deriveBlockfilePath((&Conf{blockStorageDir: path}).getLedgerBlockDir("testLedger"), 1)
+		content, err := ioutil.ReadFile(blockfile)
+		require.NoError(t, err)
+		previousHash := blocks[6].Header.PreviousHash
+		i := bytes.Index(content, previousHash)
+		require.True(t, i >= 0)
+		content[i] ^= 0xff
+		require.NoError(t, ioutil.WriteFile(blockfile, content, 0o644))
+
+		result, handledBlocks := verify(t, path)
+		require.Equal(t, uint64(8), result.Height)
+		require.Equal(t, uint64(6), result.Issues[0].BlockNum)
+		require.Equal(t, CheckHashChain, result.Issues[0].Check)
+		require.Contains(t, result.Issues[0].Details, fmt.Sprintf("Expected previous hash = [%x]", previousHash))
+		require.Equal(t, []uint64{0, 1, 2, 3, 4, 5}, handledBlocks)
+	})
+
+	t.Run("bootstrapped-store", func(t *testing.T) {
+		path := testPath()
+		defer os.RemoveAll(path)
+		snapshotDir := filepath.Join(path, "snapshot")
+		require.NoError(t, os.MkdirAll(snapshotDir, 0o755))
+
+		// the blocks are hashed with GMSM3, which is configured by the genesis block in the snapshot only
+		blocks := constructGMSM3TestBlocks(t, "testLedger", 6)
+		env := newTestEnv(t, NewConf(path, 0))
+		originalBlockStore, err := env.provider.Open("originalLedger")
+		require.NoError(t, err)
+		for _, b := range blocks[:3] {
+			require.NoError(t, originalBlockStore.AddBlock(b))
+		}
+		_, err = originalBlockStore.ExportTxIds(snapshotDir, testNewHashFunc)
+		require.NoError(t, err)
+		bootstrappedBlockStore, err := env.provider.BootstrapFromSnapshottedTxIDs(snapshotDir, &SnapshotInfo{
+			LedgerID:              "testLedger",
+			LastBlockNum:          2,
+			LastBlockHash:         protoutil.BlockHeaderHashWith(blocks[2].Header, util.ComputeGMSM3),
+			PreviousBlockHash:     blocks[2].Header.PreviousHash,
+			BlockHashingAlgorithm: bccsp.GMSM3,
+		})
+		require.NoError(t, err)
+		for _, b := range blocks[3:] {
+			require.NoError(t, bootstrappedBlockStore.AddBlock(b))
+		}
+		env.provider.Close()
+
+		result, handledBlocks := verify(t, path)
+		require.Equal(t, &VerificationResult{FirstBlockNum: 3, Height: 6}, result)
+		require.Equal(t, []uint64{3, 4, 5}, handledBlocks)
+	})
+
+	t.Run("ledger-does-not-exist", func(t *testing.T) {
+		path := testPath()
+		defer os.RemoveAll(path)
+		_, err := VerifyBlockStore(NewConf(path, 0), "non-existent-ledger", indexConfig, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while reading the block storage of the ledger [non-existent-ledger]")
+	})
+
+	t.Run("handler-error", func(t *testing.T) {
+		blocks := testutil.ConstructTestBlocks(t, 3)
+		path := setup(t, blocks, nil)
+		defer os.RemoveAll(path)
+
+		_, err := VerifyBlockStore(NewConf(path, 0), "testLedger", indexConfig, func(b *common.Block) error {
+			return errors.New("handler-error")
+		})
+		require.EqualError(t, err, "handler-error")
+	})
+}
@@ -67,7 +67,14 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 	var startingBlockNumber uint64
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
-		startingBlockNumber = 0
+		// the oldest block of a ledger bootstrapped from a snapshot is the first block after the snapshot
+		info, err := fl.blockStore.GetBlockchainInfo()
+		if err != nil {
+			logger.Panic(err)
+		}
+		if info.BootstrappingSnapshotInfo != nil {
+			startingBlockNumber = info.BootstrappingSnapshotInfo.LastBlockInSnapshot + 1
+		}
 	case *ab.SeekPosition_Newest:
 		info, err := fl.blockStore.GetBlockchainInfo()
 		if err != nil {
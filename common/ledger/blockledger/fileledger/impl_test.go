@@ -71,6 +71,7 @@ type mockBlockStore struct {
 	defaultError               error
 	getBlockchainInfoError     error
 	retrieveBlockByNumberError error
+	retrieveBlocksStartNum     uint64
 }
 
 func (mbs *mockBlockStore) AddBlock(block *cb.Block) error {
@@ -82,6 +83,7 @@ func (mbs *mockBlockStore) GetBlockchainInfo() (*cb.BlockchainInfo, error) {
 }
 
 func (mbs *mockBlockStore) RetrieveBlocks(startNum uint64) (cl.ResultsIterator, error) {
+	mbs.retrieveBlocksStartNum = startNum
 	return mbs.resultsIterator, mbs.defaultError
 }
 
@@ -215,6 +217,28 @@ func TestRetrieval(t *testing.T) {
 		"Expected to successfully retrieve the second block but got block number %d", block.Header.Number)
 }
 
+func TestRetrievalOnBootstrappedLedger(t *testing.T) {
+	resultsIterator := &mockBlockStoreIterator{}
+	resultsIterator.On("Close").Return()
+	blockStore := &mockBlockStore{
+		blockchainInfo: &cb.BlockchainInfo{
+			Height:                    11,
+			BootstrappingSnapshotInfo: &cb.BootstrappingSnapshotInfo{LastBlockInSnapshot: 9},
+		},
+		resultsIterator: resultsIterator,
+	}
+	fl := NewFileLedger(blockStore)
+
+	it, num := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	defer it.Close()
+	assert.Equal(t, uint64(10), num, "Expected the oldest block to be the first block after the snapshot")
+	assert.Equal(t, uint64(10), blockStore.retrieveBlocksStartNum)
+
+	blockStore.defaultError = errors.New("cannot serve block [5]. The ledger is bootstrapped from a snapshot. First available block = [10]")
+	it, _ = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 5}}})
+	assert.IsType(t, &blockledger.NotFoundErrorIterator{}, it, "Expected Not Found Error for a block in the snapshot")
+}
+
 func TestBlockedRetrieval(t *testing.T) {
 	tev, fl := initialize(t)
 	defer tev.tearDown()
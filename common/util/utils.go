@@ -35,6 +35,14 @@ func ComputeSHA3256(data []byte) (hash []byte) {
 	return
 }
 
+// ComputeGMSM3 returns SM3 on data
+func ComputeGMSM3(data []byte) (hash []byte) {
+	hash, err := factory.GetDefault().Hash(data, &bccsp.GMSM3Opts{})
+	if err != nil {
+		panic(fmt.Errorf("Failed computing GMSM3 on [% x]", data))
+	}
+	return
+}
 
 // GenerateBytesUUID returns a UUID based on RFC 4122 returning the generated bytes
 func GenerateBytesUUID() []byte {
@@ -40,6 +40,15 @@ func TestComputeSHA3256(t *testing.T) {
 	}
 }
 
+func TestComputeGMSM3(t *testing.T) {
+	if !bytes.Equal(ComputeGMSM3([]byte("foobar")), ComputeGMSM3([]byte("foobar"))) {
+		t.Fatalf("Expected hashes to match, but they did not match")
+	}
+	if bytes.Equal(ComputeGMSM3([]byte("foobar")), ComputeSHA256([]byte("foobar"))) {
+		t.Fatalf("Expected SM3 and SHA256 hashes to be different, but they match")
+	}
+}
+
 func TestUUIDGeneration(t *testing.T) {
 	uuid := GenerateUUID()
 	if len(uuid) != 36 {
@@ -17,4 +17,9 @@ type ACLProvider interface {
 	//idinfo. idinfo is an object such as SignedProposal from which an
 	//id can be extracted for testing against a policy
 	CheckACL(resName string, channelID string, idinfo interface{}) error
+
+	//CheckACLNoChannel checks the ACL for a peer wide resource against the
+	//local MSP using the idinfo. idinfo is an object such as SignedProposal
+	//or SignedData from which an id can be extracted for testing against a policy
+	CheckACLNoChannel(resName string, idinfo interface{}) error
 }
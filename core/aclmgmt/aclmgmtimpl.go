@@ -27,6 +27,12 @@ func (am *aclMgmtImpl) CheckACL(resName string, channelID string, idinfo interfa
 	return am.rescfgProvider.CheckACL(resName, channelID, idinfo)
 }
 
+//CheckACLNoChannel checks the ACL for a peer wide resource using the
+//idinfo against the local MSP
+func (am *aclMgmtImpl) CheckACLNoChannel(resName string, idinfo interface{}) error {
+	return am.rescfgProvider.CheckACLNoChannel(resName, idinfo)
+}
+
 //ACLProvider consists of two providers, supplied one and a default one (1.0 ACL management
 //using ChannelReaders and ChannelWriters). If supplied provider is nil, a resource based
 //ACL provider is created.
@@ -83,10 +83,14 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetCommitHash] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByEvent] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetBlockByTimestamp] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
 	d.pResourcePolicyMap[resources.Cscc_JoinChain] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Cscc_JoinChainBySnapshot] = mgmt.Admins
 	d.pResourcePolicyMap[resources.Cscc_GetChannels] = mgmt.Members
 
 	//c resources
@@ -101,6 +105,11 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Event_Block] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Event_FilteredBlock] = CHANNELREADERS
 
+	//Snapshot resources
+	d.pResourcePolicyMap[resources.Snapshot_submitrequest] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Snapshot_cancelrequest] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Snapshot_listpending] = mgmt.Admins
+
 	return d
 }
 
@@ -139,3 +148,28 @@ func (d *defaultACLProviderImpl) CheckACL(resName string, channelID string, idin
 		return fmt.Errorf("Unknown id on checkACL %s", resName)
 	}
 }
+
+// CheckACLNoChannel checks the ACL of a peer wide resource against the local MSP.
+func (d *defaultACLProviderImpl) CheckACLNoChannel(resName string, idinfo interface{}) error {
+	policy := d.pResourcePolicyMap[resName]
+	if policy == "" {
+		aclLogger.Errorf("Unmapped policy for %s", resName)
+		return fmt.Errorf("Unmapped policy for %s", resName)
+	}
+
+	switch typedData := idinfo.(type) {
+	case *pb.SignedProposal:
+		return d.policyChecker.CheckPolicyNoChannel(policy, typedData)
+	case *common.Envelope:
+		sd, err := protoutil.EnvelopeAsSignedData(typedData)
+		if err != nil {
+			return err
+		}
+		return d.policyChecker.CheckPolicyNoChannelBySignedData(policy, sd)
+	case []*protoutil.SignedData:
+		return d.policyChecker.CheckPolicyNoChannelBySignedData(policy, typedData)
+	default:
+		aclLogger.Errorf("Unmapped id on checkACLNoChannel %s", resName)
+		return fmt.Errorf("Unknown id on checkACLNoChannel %s", resName)
+	}
+}
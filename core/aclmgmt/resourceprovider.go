@@ -181,3 +181,9 @@ func (rp *resourceProvider) CheckACL(resName string, channelID string, idinfo in
 
 	return rp.defaultProvider.CheckACL(resName, channelID, idinfo)
 }
+
+//CheckACLNoChannel implements the ACL for peer wide resources, which are
+//not defined in the channel configuration
+func (rp *resourceProvider) CheckACLNoChannel(resName string, idinfo interface{}) error {
+	return rp.defaultProvider.CheckACLNoChannel(resName, idinfo)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protoutil"
@@ -107,6 +108,31 @@ func TestForceDefaultsForPType(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// test to ensure channelless checks are processed by default provider
+func TestCheckACLNoChannelUsesDefaults(t *testing.T) {
+	defAclProvider := &mocks.DefaultACLProvider{}
+	defAclProvider.CheckACLNoChannelReturns(fmt.Errorf("no way"))
+	rp := &resourceProvider{defaultProvider: defAclProvider}
+	err := rp.CheckACLNoChannel(resources.Snapshot_submitrequest, struct{}{})
+	assert.EqualError(t, err, "no way")
+	assert.Equal(t, 1, defAclProvider.CheckACLNoChannelCallCount())
+	resName, _ := defAclProvider.CheckACLNoChannelArgsForCall(0)
+	assert.Equal(t, resources.Snapshot_submitrequest, resName)
+}
+
+func TestDefaultCheckACLNoChannel(t *testing.T) {
+	d := newDefaultACLProvider(nil)
+	assert.True(t, d.IsPtypePolicy(resources.Snapshot_submitrequest))
+	assert.True(t, d.IsPtypePolicy(resources.Snapshot_cancelrequest))
+	assert.True(t, d.IsPtypePolicy(resources.Snapshot_listpending))
+
+	err := d.CheckACLNoChannel(resources.Qscc_GetChainInfo, []*protoutil.SignedData{})
+	assert.EqualError(t, err, "Unmapped policy for qscc/GetChainInfo")
+
+	err = d.CheckACLNoChannel(resources.Snapshot_listpending, struct{}{})
+	assert.EqualError(t, err, "Unknown id on checkACLNoChannel snapshot/listpending")
+}
+
 func init() {
 	// setup the MSP manager so that we can sign/verify
 	err := msptesttools.LoadMSPSetupForTesting()
@@ -35,16 +35,20 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo           = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber       = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash         = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID     = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID         = "qscc/GetBlockByTxID"
+	Qscc_GetCommitHash          = "qscc/GetCommitHash"
+	Qscc_GetTransactionsByEvent = "qscc/GetTransactionsByEvent"
+	Qscc_GetBlockByTimestamp    = "qscc/GetBlockByTimestamp"
 
 	//Cscc resources
-	Cscc_JoinChain      = "cscc/JoinChain"
-	Cscc_GetConfigBlock = "cscc/GetConfigBlock"
-	Cscc_GetChannels    = "cscc/GetChannels"
+	Cscc_JoinChain           = "cscc/JoinChain"
+	Cscc_JoinChainBySnapshot = "cscc/JoinChainBySnapshot"
+	Cscc_GetConfigBlock      = "cscc/GetConfigBlock"
+	Cscc_GetChannels         = "cscc/GetChannels"
 
 	//Peer resources
 	Peer_Propose              = "peer/Propose"
@@ -53,4 +57,9 @@ const (
 	//Events
 	Event_Block         = "event/Block"
 	Event_FilteredBlock = "event/FilteredBlock"
+
+	//Snapshot resources
+	Snapshot_submitrequest = "snapshot/submitrequest"
+	Snapshot_cancelrequest = "snapshot/cancelrequest"
+	Snapshot_listpending   = "snapshot/listpending"
 )
@@ -57,6 +57,9 @@ type ChannelResources interface {
 
 	// Capabilities defines the capabilities for the application portion of this channel
 	Capabilities() channelconfig.ApplicationCapabilities
+
+	// BlockHashingAlgorithm returns the hash function used for the transaction IDs of this channel
+	BlockHashingAlgorithm() func([]byte) []byte
 }
 
 // private interface to decouple tx validator
@@ -300,7 +303,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 		var txsChaincodeName *sysccprovider.ChaincodeInstance
 		var txsUpgradedChaincode *sysccprovider.ChaincodeInstance
 
-		if payload, txResult = validation.ValidateTransaction(env, v.CryptoProvider); txResult != peer.TxValidationCode_VALID {
+		if payload, txResult = validation.ValidateTransaction(env, v.CryptoProvider, v.ChannelResources.BlockHashingAlgorithm()); txResult != peer.TxValidationCode_VALID {
 			logger.Errorf("Invalid transaction with index %d", tIdx)
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
@@ -463,6 +466,14 @@ func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader,
 			tIdx:           tIdx,
 			validationCode: peer.TxValidationCode_DUPLICATE_TXID,
 		}
+	case ledger.TxDetailsNotAvailableErr:
+		// invalid case, returned error is of type TxDetailsNotAvailableErr. It means that there is already a tx
+		// in the ledger with the same id, which was included in the snapshot the ledger is bootstrapped from
+		logger.Error("Duplicate transaction found in the snapshot, ", txID, ", skipping")
+		return &blockValidationResult{
+			tIdx:           tIdx,
+			validationCode: peer.TxValidationCode_DUPLICATE_TXID,
+		}
 	case ledger.NotFoundInIndexErr:
 		// valid case, returned error is of type NotFoundInIndexErr.
 		// It means that no tx with the same id is found in the ledger
@@ -427,6 +427,32 @@ func testInvokeNOKDuplicateNs(t *testing.T, l ledger.PeerLedger, v txvalidator.V
 	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
 }
 
+func TestInvokeNOKPurgeNotEnabled(t *testing.T) {
+	// the V2_0_PvtDataPurge capability is not enabled
+	capabilities := v13Capabilities()
+	capabilities.On("PurgePvtData").Return(false)
+	l, v, cleanup := setupLedgerAndValidatorWithCapabilities(t, capabilities)
+	defer cleanup()
+
+	ccID := "mycc"
+
+	putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge(ccID, "mycollection", "key")
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	rwsetBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	tx := getEnv(ccID, nil, rwsetBytes, t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	err = v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+}
+
 func TestInvokeNoRWSet(t *testing.T) {
 	plugin := &mocks.Plugin{}
 	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -1528,6 +1554,31 @@ func (m *mockLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, e
 	return args.Get(0).(ledger.MissingPvtDataTracker), nil
 }
 
+func (m *mockLedger) SubmitSnapshotRequest(blockNumber uint64) error {
+	args := m.Called(blockNumber)
+	return args.Error(0)
+}
+
+func (m *mockLedger) CancelSnapshotRequest(blockNumber uint64) error {
+	args := m.Called(blockNumber)
+	return args.Error(0)
+}
+
+func (m *mockLedger) PendingSnapshotRequests() ([]uint64, error) {
+	args := m.Called()
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+func (m *mockLedger) GetTransactionsByChaincodeEvent(chaincodeName, eventName, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	args := m.Called(chaincodeName, eventName, bookmark, pageSize)
+	return args.Get(0).([]*peer.ProcessedTransaction), args.String(1), args.Error(2)
+}
+
+func (m *mockLedger) GetBlocksByTimestamp(startTime, endTime time.Time, bookmark string, pageSize int) ([]*common.Block, string, error) {
+	args := m.Called(startTime, endTime, bookmark, pageSize)
+	return args.Get(0).([]*common.Block), args.String(1), args.Error(2)
+}
+
 // mockQueryExecutor mock of the query executor,
 // needed to simulate inability to access state db, e.g.
 // the case where due to db failure it's not possible to
@@ -161,6 +161,11 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 	}
 
+	if txRWSet.ContainsPurgeMarker() && !v.cr.Capabilities().PurgePvtData() {
+		return errors.New("private data purge is not enabled, channel application capability of V2_0_PvtDataPurge is required"),
+			peer.TxValidationCode_ILLEGAL_WRITESET
+	}
+
 	// we've gathered all the info required to proceed to validation;
 	// validation will behave differently depending on the type of
 	// chaincode (system vs. application)
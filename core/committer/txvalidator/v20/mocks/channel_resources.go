@@ -30,6 +30,22 @@ func (_m *ChannelResources) Apply(configtx *common.ConfigEnvelope) error {
 	return r0
 }
 
+// BlockHashingAlgorithm provides a mock function with given fields:
+func (_m *ChannelResources) BlockHashingAlgorithm() func([]byte) []byte {
+	ret := _m.Called()
+
+	var r0 func([]byte) []byte
+	if rf, ok := ret.Get(0).(func() func([]byte) []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func([]byte) []byte)
+		}
+	}
+
+	return r0
+}
+
 // Capabilities provides a mock function with given fields:
 func (_m *ChannelResources) Capabilities() channelconfig.ApplicationCapabilities {
 	ret := _m.Called()
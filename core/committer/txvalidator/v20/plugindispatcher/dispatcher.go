@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
@@ -28,6 +29,9 @@ type ChannelResources interface {
 	// GetMSPIDs returns the IDs for the application MSPs
 	// that have been defined in the channel
 	GetMSPIDs() []string
+
+	// Capabilities defines the capabilities for the application portion of this channel
+	Capabilities() channelconfig.ApplicationCapabilities
 }
 
 // LedgerResources provides access to ledger artefacts or
@@ -183,6 +187,11 @@ func (v *dispatcherImpl) Dispatch(seq int, payload *common.Payload, envBytes []b
 		}
 	}
 
+	if txRWSet.ContainsPurgeMarker() && !v.cr.Capabilities().PurgePvtData() {
+		return errors.New("private data purge is not enabled, channel application capability of V2_0_PvtDataPurge is required"),
+			peer.TxValidationCode_ILLEGAL_WRITESET
+	}
+
 	// we've gathered all the info required to proceed to validation;
 	// validation will behave differently depending on the chaincode
 
@@ -299,6 +299,38 @@ func TestInvokeNOKDuplicateNs(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
 }
 
+func TestInvokeNOKPurgeNotEnabled(t *testing.T) {
+	ccID := "mycc"
+
+	v, mockQE, _, _ := setupValidator()
+
+	mockQE.On("GetState", "lscc", ccID).Return(protoutil.MarshalOrPanic(&ccp.ChaincodeData{
+		Name:    ccID,
+		Version: ccVersion,
+		Vscc:    "vscc",
+		Policy:  signedByAnyMember([]string{"SampleOrg"}),
+	}), nil)
+
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge(ccID, "mycollection", "key")
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	rwsetBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	tx := getEnv(ccID, nil, rwsetBytes, t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	// the V2_0_PvtDataPurge capability is not enabled
+	v20Capabilities := v20Capabilities()
+	v20Capabilities.On("PurgePvtData").Return(false)
+	v.ChannelResources.(*mocktxvalidator.Support).ACVal = v20Capabilities
+
+	err = v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+}
+
 func TestInvokeNoRWSet(t *testing.T) {
 	ccID := "mycc"
 
@@ -1059,6 +1091,34 @@ func TestDuplicateTxId(t *testing.T) {
 	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
 }
 
+func TestDuplicateTxIdInSnapshot(t *testing.T) {
+	ccID := "mycc"
+
+	v, _, _, _ := setupValidator()
+
+	mockLedger := &txvalidatormocks.LedgerResources{}
+	v.LedgerResources = mockLedger
+	mockLedger.On("GetTransactionByID", mock.Anything).Return(nil, ledger.TxDetailsNotAvailableErr("details not available"))
+
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+
+	b := &common.Block{
+		Data:   &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}},
+		Header: &common.BlockHeader{},
+	}
+
+	err := v.Validate(b)
+
+	assertion := assert.New(t)
+	// We expect no validation error because we simply mark the tx as invalid
+	assertion.NoError(err)
+
+	// We expect the tx to be invalid because the txid is present in the snapshot the ledger is bootstrapped from
+	txsfltr := txflags.ValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assertion.True(txsfltr.IsInvalid(0))
+	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
+}
+
 func TestValidationInvalidEndorsing(t *testing.T) {
 	ccID := "mycc"
 
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
@@ -134,7 +135,7 @@ func TestGoodPath(t *testing.T) {
 	// validate the transaction
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	payl, txResult := ValidateTransaction(tx, cryptoProvider)
+	payl, txResult := ValidateTransaction(tx, cryptoProvider, util.ComputeSHA256)
 	if txResult != peer.TxValidationCode_VALID {
 		t.Fatalf("ValidateTransaction failed, err %s", err)
 		return
@@ -168,6 +169,38 @@ func TestGoodPath(t *testing.T) {
 	}
 }
 
+func TestTXIDHashingAlgorithm(t *testing.T) {
+	// get a toy proposal, its transaction ID is computed with SHA-256
+	prop, err := getProposal("testchannelid")
+	if err != nil {
+		t.Fatalf("getProposal failed, err %s", err)
+		return
+	}
+
+	response := &peer.Response{Status: 200}
+	simRes := []byte("simulation_result")
+
+	// endorse it to get a proposal response
+	presp, err := protoutil.CreateProposalResponse(prop.Header, prop.Payload, response, simRes, nil, getChaincodeID(), signer)
+	if err != nil {
+		t.Fatalf("CreateProposalResponse failed, err %s", err)
+		return
+	}
+
+	// assemble a transaction from that proposal and endorsement
+	tx, err := protoutil.CreateSignedTx(prop, signer, presp)
+	if err != nil {
+		t.Fatalf("CreateSignedTx failed, err %s", err)
+		return
+	}
+
+	// validate the transaction on a channel hashing with SM3
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	_, txResult := ValidateTransaction(tx, cryptoProvider, util.ComputeGMSM3)
+	assert.Equal(t, peer.TxValidationCode_BAD_PROPOSAL_TXID, txResult)
+}
+
 func TestTXWithTwoActionsRejected(t *testing.T) {
 	// get a toy proposal
 	prop, err := getProposal("testchannelid")
@@ -196,7 +229,7 @@ func TestTXWithTwoActionsRejected(t *testing.T) {
 	// validate the transaction
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	_, txResult := ValidateTransaction(tx, cryptoProvider)
+	_, txResult := ValidateTransaction(tx, cryptoProvider, util.ComputeSHA256)
 	if txResult == peer.TxValidationCode_VALID {
 		t.Fatalf("ValidateTransaction should have failed")
 		return
@@ -242,7 +275,7 @@ func TestBadTx(t *testing.T) {
 		copy(paylCopy, paylOrig)
 		paylCopy[i] = byte(int(paylCopy[i]+1) % 255)
 		// validate the transaction it should fail
-		_, txResult := ValidateTransaction(&common.Envelope{Signature: tx.Signature, Payload: paylCopy}, cryptoProvider)
+		_, txResult := ValidateTransaction(&common.Envelope{Signature: tx.Signature, Payload: paylCopy}, cryptoProvider, util.ComputeSHA256)
 		if txResult == peer.TxValidationCode_VALID {
 			t.Fatal("ValidateTransaction should have failed")
 			return
@@ -260,7 +293,7 @@ func TestBadTx(t *testing.T) {
 	corrupt(tx.Signature)
 
 	// validate the transaction it should fail
-	_, txResult := ValidateTransaction(tx, cryptoProvider)
+	_, txResult := ValidateTransaction(tx, cryptoProvider, util.ComputeSHA256)
 	if txResult == peer.TxValidationCode_VALID {
 		t.Fatal("ValidateTransaction should have failed")
 		return
@@ -305,7 +338,7 @@ func Test2EndorsersAgree(t *testing.T) {
 	// validate the transaction
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	_, txResult := ValidateTransaction(tx, cryptoProvider)
+	_, txResult := ValidateTransaction(tx, cryptoProvider, util.ComputeSHA256)
 	if txResult != peer.TxValidationCode_VALID {
 		t.Fatalf("ValidateTransaction failed, err %s", err)
 		return
@@ -352,7 +385,7 @@ func TestInvocationsBadArgs(t *testing.T) {
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
 
-	_, code := ValidateTransaction(nil, cryptoProvider)
+	_, code := ValidateTransaction(nil, cryptoProvider, util.ComputeSHA256)
 	assert.Equal(t, code, peer.TxValidationCode_NIL_ENVELOPE)
 	err = validateEndorserTransaction(nil, nil)
 	assert.Error(t, err)
@@ -244,8 +244,9 @@ func validateEndorserTransaction(data []byte, hdr *common.Header) error {
 	return nil
 }
 
-// ValidateTransaction checks that the transaction envelope is properly formed
-func ValidateTransaction(e *common.Envelope, cryptoProvider bccsp.BCCSP) (*common.Payload, pb.TxValidationCode) {
+// ValidateTransaction checks that the transaction envelope is properly formed.
+// Transaction IDs are checked against the given block hashing algorithm of the channel.
+func ValidateTransaction(e *common.Envelope, cryptoProvider bccsp.BCCSP, hashingAlgorithm func([]byte) []byte) (*common.Payload, pb.TxValidationCode) {
 	putilsLogger.Debugf("ValidateTransactionEnvelope starts for envelope %p", e)
 
 	// check for nil argument
@@ -285,10 +286,11 @@ func ValidateTransaction(e *common.Envelope, cryptoProvider bccsp.BCCSP) (*commo
 		// Verify that the transaction ID has been computed properly.
 		// This check is needed to ensure that the lookup into the ledger
 		// for the same TxID catches duplicates.
-		err = protoutil.CheckTxID(
+		err = protoutil.CheckTxIDWith(
 			chdr.TxId,
 			shdr.Nonce,
-			shdr.Creator)
+			shdr.Creator,
+			hashingAlgorithm)
 
 		if err != nil {
 			putilsLogger.Errorf("CheckTxID returns err %s", err)
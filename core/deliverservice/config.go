@@ -147,6 +147,23 @@ func (c *DeliverServiceConfig) loadDeliverServiceConfig() {
 			panic(errors.WithMessagef(err, "unable to load cert at '%s'", certFile))
 		}
 		c.SecOpts.Certificate = certPEM
+
+		encCertFile := config.GetPath("peer.tls.clientEncCert.file")
+		encKeyFile := config.GetPath("peer.tls.clientEncKey.file")
+		if encCertFile == "" && encKeyFile == "" {
+			encCertFile = config.GetPath("peer.tls.encCert.file")
+			encKeyFile = config.GetPath("peer.tls.encKey.file")
+		}
+		if encCertFile != "" && encKeyFile != "" {
+			c.SecOpts.EncKey, err = ioutil.ReadFile(encKeyFile)
+			if err != nil {
+				panic(errors.WithMessagef(err, "unable to load key at '%s'", encKeyFile))
+			}
+			c.SecOpts.EncCertificate, err = ioutil.ReadFile(encCertFile)
+			if err != nil {
+				panic(errors.WithMessagef(err, "unable to load cert at '%s'", encCertFile))
+			}
+		}
 	}
 
 	overridesMap, err := LoadOverridesMap()
@@ -90,6 +90,9 @@ type ChannelFetcher interface {
 
 type Channel struct {
 	IdentityDeserializer msp.IdentityDeserializer
+	// HashingAlgorithm computes the transaction IDs of the channel.
+	// SHA-256 is used when it is nil.
+	HashingAlgorithm func(input []byte) []byte
 }
 
 // Endorser provides the Endorser service ProcessProposal
@@ -250,7 +253,12 @@ func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, chai
 func (e *Endorser) preProcess(up *UnpackedProposal, channel *Channel) error {
 	// at first, we check whether the message is valid
 
-	err := up.Validate(channel.IdentityDeserializer)
+	hashingAlgorithm := channel.HashingAlgorithm
+	if hashingAlgorithm == nil {
+		hashingAlgorithm = util.ComputeSHA256
+	}
+
+	err := up.Validate(channel.IdentityDeserializer, hashingAlgorithm)
 	if err != nil {
 		e.Metrics.ProposalValidationFailed.Add(1)
 		return errors.WithMessage(err, "error validating proposal")
@@ -121,7 +121,7 @@ func UnpackProposal(signedProp *pb.SignedProposal) (*UnpackedProposal, error) {
 	}, nil
 }
 
-func (up *UnpackedProposal) Validate(idDeserializer msp.IdentityDeserializer) error {
+func (up *UnpackedProposal) Validate(idDeserializer msp.IdentityDeserializer, hashingAlgorithm func([]byte) []byte) error {
 	logger := decorateLogger(endorserLogger, &ccprovider.TransactionParams{
 		ChannelID: up.ChannelHeader.ChannelId,
 		TxID:      up.TxID(),
@@ -154,7 +154,7 @@ func (up *UnpackedProposal) Validate(idDeserializer msp.IdentityDeserializer) er
 		return errors.New("creator is empty")
 	}
 
-	expectedTxID := protoutil.ComputeTxID(up.SignatureHeader.Nonce, up.SignatureHeader.Creator)
+	expectedTxID := protoutil.ComputeTxIDWith(up.SignatureHeader.Nonce, up.SignatureHeader.Creator, hashingAlgorithm)
 	if up.TxID() != expectedTxID {
 		return errors.Errorf("incorrectly computed txid '%s' -- expected '%s'", up.TxID(), expectedTxID)
 	}
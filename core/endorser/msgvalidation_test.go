@@ -15,6 +15,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	mspproto "github.com/hyperledger/fabric-protos-go/msp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/endorser/fake"
 	"github.com/hyperledger/fabric/protoutil"
@@ -335,7 +336,7 @@ var _ = Describe("Validate", func() {
 	})
 
 	It("validates the proposal", func() {
-		err := up.Validate(fakeIdentityDeserializer)
+		err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeIdentityDeserializer.DeserializeIdentityCallCount()).To(Equal(1))
@@ -361,7 +362,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("preserves buggy behavior and does not error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
@@ -375,7 +376,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns an error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("invalid header type MESSAGE"))
 		})
 	})
@@ -386,7 +387,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns an error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("empty signature bytes"))
 		})
 	})
@@ -397,7 +398,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns an error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("nonce is empty"))
 		})
 	})
@@ -408,7 +409,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns an error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("creator is empty"))
 		})
 	})
@@ -419,7 +420,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns an error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("epoch is non-zero"))
 		})
 	})
@@ -430,18 +431,25 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns an error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("incorrectly computed txid 'fake-txid' -- expected '876a1777b78e5e3a6d1aabf8b5a11b893c3838285b2f5eedca7d23e25365fcfd'"))
 		})
 	})
 
+	Context("when the txid is computed with another hashing algorithm than the channel's", func() {
+		It("returns an error", func() {
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeGMSM3)
+			Expect(err).To(MatchError(MatchRegexp("incorrectly computed txid '876a1777b78e5e3a6d1aabf8b5a11b893c3838285b2f5eedca7d23e25365fcfd' -- expected '[0-9a-f]{64}'")))
+		})
+	})
+
 	Context("when the proposal bytes are missing", func() {
 		BeforeEach(func() {
 			up.SignedProposal.ProposalBytes = nil
 		})
 
 		It("returns an error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("empty proposal bytes"))
 		})
 	})
@@ -453,7 +461,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns an auth error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("access denied: channel [channel-id] creator org unknown, creator is malformed"))
 		})
 	})
@@ -464,7 +472,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns a generic auth error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("access denied: channel [channel-id] creator org [mspid]"))
 		})
 	})
@@ -475,7 +483,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns a generic auth error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("access denied: channel [channel-id] creator org [mspid]"))
 		})
 	})
@@ -486,7 +494,7 @@ var _ = Describe("Validate", func() {
 		})
 
 		It("returns a generic auth error", func() {
-			err := up.Validate(fakeIdentityDeserializer)
+			err := up.Validate(fakeIdentityDeserializer, util.ComputeSHA256)
 			Expect(err).To(MatchError("access denied: channel [channel-id] creator org [mspid]"))
 		})
 	})
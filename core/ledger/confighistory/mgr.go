@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/pkg/errors"
 )
@@ -108,6 +109,9 @@ func (m *Mgr) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
 	return dbHandle.writeBatch(batch, true)
 }
 
+// ImportConfigHistory imports the collection config history from the snapshot files present in the dir.
+// The snapshot files are not present if the ledger from which the snapshot is generated had no collection
+// config history, in which case, there is nothing to import
 func (m *Mgr) ImportConfigHistory(ledgerID string, dir string) error {
 	db := m.dbProvider.getDB(ledgerID)
 	empty, err := db.isEmpty()
@@ -122,10 +126,19 @@ func (m *Mgr) ImportConfigHistory(ledgerID string, dir string) error {
 		))
 	}
 
+	dataFileExists, _, err := util.FileExists(filepath.Join(dir, snapshotDataFileName))
+	if err != nil {
+		return err
+	}
+	if !dataFileExists {
+		return nil
+	}
+
 	configMetadata, err := snapshot.OpenFile(filepath.Join(dir, snapshotMetadataFileName), snapshotFileFormat)
 	if err != nil {
 		return err
 	}
+	defer configMetadata.Close()
 	numCollectionConfigs, err := configMetadata.DecodeUVarInt()
 	if err != nil {
 		return err
@@ -134,6 +147,7 @@ func (m *Mgr) ImportConfigHistory(ledgerID string, dir string) error {
 	if err != nil {
 		return err
 	}
+	defer collectionConfigData.Close()
 
 	batch := db.NewUpdateBatch()
 	currentBatchSize := 0
@@ -153,6 +167,7 @@ func (m *Mgr) ImportConfigHistory(ledgerID string, dir string) error {
 				return err
 			}
 			batch = db.NewUpdateBatch()
+			currentBatchSize = 0
 		}
 	}
 	return db.WriteBatch(batch, true)
@@ -194,6 +209,17 @@ func (r *Retriever) MostRecentCollectionConfigBelow(blockNum uint64, chaincodeNa
 	return constructCollectionConfigInfo(compositeKV, implicitColls)
 }
 
+// MostRecentExplicitCollectionConfigBelow is the same as MostRecentCollectionConfigBelow except that the
+// implicit collections are not included. As the implicit collections are not looked up on the ledger,
+// this can be used on a stopped peer
+func (r *Retriever) MostRecentExplicitCollectionConfigBelow(blockNum uint64, chaincodeName string) (*ledger.CollectionConfigInfo, error) {
+	compositeKV, err := r.dbHandle.mostRecentEntryBelow(blockNum, collectionConfigNamespace, constructCollectionConfigKey(chaincodeName))
+	if err != nil {
+		return nil, err
+	}
+	return constructCollectionConfigInfo(compositeKV, nil)
+}
+
 // CollectionConfigAt implements function from the interface ledger.ConfigHistoryRetriever
 func (r *Retriever) CollectionConfigAt(blockNum uint64, chaincodeName string) (*ledger.CollectionConfigInfo, error) {
 	info, err := r.ledgerInfoRetriever.GetBlockchainInfo()
@@ -265,6 +265,19 @@ func TestWithImplicitColls(t *testing.T) {
 		require.True(t, proto.Equal(retrievedConfig.CollectionConfig, explicitAndImplicitCollections))
 	})
 
+	t.Run("MostRecentExplicitCollectionConfigBelow", func(t *testing.T) {
+		// the implicit collections are not included and the ledger is not queried
+		retriever := mgr.GetRetriever("ledger1", nil)
+		retrievedConfig, err := retriever.MostRecentExplicitCollectionConfigBelow(50, "chaincode1")
+		require.NoError(t, err)
+		require.True(t, proto.Equal(retrievedConfig.CollectionConfig, collConfigPackage))
+		require.Equal(t, uint64(20), retrievedConfig.CommittingBlockNum)
+
+		retrievedConfig, err = retriever.MostRecentExplicitCollectionConfigBelow(10, "chaincode1")
+		require.NoError(t, err)
+		require.Nil(t, retrievedConfig)
+	})
+
 }
 
 type testEnvForSnapshot struct {
@@ -468,8 +481,10 @@ func TestExportAndImportConfigHistory(t *testing.T) {
 		require.EqualError(t, err, expectedErrStr)
 
 		require.NoError(t, os.RemoveAll(filepath.Join(env.testSnapshotDir, snapshotDataFileName)))
-		err = env.mgr.ImportConfigHistory("ledger8", env.testSnapshotDir)
-		require.Contains(t, err.Error(), "confighistory.data: no such file or directory")
+		require.NoError(t, env.mgr.ImportConfigHistory("ledger7", env.testSnapshotDir))
+		empty, err := env.mgr.dbProvider.getDB("ledger7").isEmpty()
+		require.NoError(t, err)
+		require.True(t, empty)
 
 		dataFileWriter, err := snapshot.CreateFile(filepath.Join(env.testSnapshotDir, snapshotDataFileName), snapshotFileFormat, testNewHashFunc)
 		require.NoError(t, err)
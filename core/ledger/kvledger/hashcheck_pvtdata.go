@@ -14,12 +14,15 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
 // constructValidAndInvalidPvtData computes the valid pvt data and hash mismatch list
-// from a received pvt data list of old blocks.
-func constructValidAndInvalidPvtData(reconciledPvtdata []*ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore) (
+// from a received pvt data list of old blocks. The pvt data of a collection may miss the writes of the keys
+// that have been purged after the transaction, as recorded in the pvtdataStore
+func constructValidAndInvalidPvtData(reconciledPvtdata []*ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore,
+	pvtdataStore *pvtdatastorage.Store) (
 	map[uint64][]*ledger.TxPvtData, []*ledger.PvtdataHashMismatch, error,
 ) {
 	// for each block, for each transaction, retrieve the txEnvelope to
@@ -30,7 +33,7 @@ func constructValidAndInvalidPvtData(reconciledPvtdata []*ledger.ReconciledPvtda
 	var invalidPvtData []*ledger.PvtdataHashMismatch
 
 	for _, pvtdata := range reconciledPvtdata {
-		validData, invalidData, err := findValidAndInvalidPvtdata(pvtdata, blockStore)
+		validData, invalidData, err := findValidAndInvalidPvtdata(pvtdata, blockStore, pvtdataStore)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -42,7 +45,8 @@ func constructValidAndInvalidPvtData(reconciledPvtdata []*ledger.ReconciledPvtda
 	return validPvtData, invalidPvtData, nil
 }
 
-func findValidAndInvalidPvtdata(reconciledPvtdata *ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore) (
+func findValidAndInvalidPvtdata(reconciledPvtdata *ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore,
+	pvtdataStore *pvtdatastorage.Store) (
 	[]*ledger.TxPvtData, []*ledger.PvtdataHashMismatch, error,
 ) {
 	var validPvtData []*ledger.TxPvtData
@@ -58,7 +62,10 @@ func findValidAndInvalidPvtdata(reconciledPvtdata *ledger.ReconciledPvtdata, blo
 		// (2) validate passed pvtData against the pvtData hash in the tx rwset.
 		logger.Debugf("Constructing valid and invalid pvtData using rwset of blockNum:[%d], txNum:[%d]",
 			reconciledPvtdata.BlockNum, txPvtData.SeqInBlock)
-		validData, invalidData := findValidAndInvalidTxPvtData(txPvtData, txRWSet, reconciledPvtdata.BlockNum)
+		validData, invalidData, err := findValidAndInvalidTxPvtData(txPvtData, txRWSet, reconciledPvtdata.BlockNum, pvtdataStore)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		// (3) append validData to validPvtDataPvt list of this block and
 		// invalidData to invalidPvtData list
@@ -89,8 +96,9 @@ func retrieveRwsetForTx(blkNum uint64, txNum uint64, blockStore *blkstorage.Bloc
 	return txRWSet, nil
 }
 
-func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetutil.TxRwSet, blkNum uint64) (
-	*ledger.TxPvtData, []*ledger.PvtdataHashMismatch,
+func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetutil.TxRwSet, blkNum uint64,
+	pvtdataStore *pvtdatastorage.Store) (
+	*ledger.TxPvtData, []*ledger.PvtdataHashMismatch, error,
 ) {
 	var invalidPvtData []*ledger.PvtdataHashMismatch
 	var toDeleteNsColl []*nsColl
@@ -98,7 +106,10 @@ func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetuti
 	// find valid and invalid pvt data
 	for _, nsRwset := range txPvtData.WriteSet.NsPvtRwset {
 		txNum := txPvtData.SeqInBlock
-		invalidData, invalidNsColl := findInvalidNsPvtData(nsRwset, txRWSet, blkNum, txNum)
+		invalidData, invalidNsColl, err := findInvalidNsPvtData(nsRwset, txRWSet, blkNum, txNum, pvtdataStore)
+		if err != nil {
+			return nil, nil, err
+		}
 		invalidPvtData = append(invalidPvtData, invalidData...)
 		toDeleteNsColl = append(toDeleteNsColl, invalidNsColl...)
 	}
@@ -108,9 +119,9 @@ func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetuti
 	if len(txPvtData.WriteSet.NsPvtRwset) == 0 {
 		// denotes that all namespaces had
 		// invalid pvt data
-		return nil, invalidPvtData
+		return nil, invalidPvtData, nil
 	}
-	return txPvtData, invalidPvtData
+	return txPvtData, invalidPvtData, nil
 }
 
 // Remove removes the rwset for the given <ns, coll> tuple. If after this removal,
@@ -144,8 +155,9 @@ type nsColl struct {
 	ns, coll string
 }
 
-func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.TxRwSet, blkNum, txNum uint64) (
-	[]*ledger.PvtdataHashMismatch, []*nsColl,
+func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.TxRwSet, blkNum, txNum uint64,
+	pvtdataStore *pvtdatastorage.Store) (
+	[]*ledger.PvtdataHashMismatch, []*nsColl, error,
 ) {
 	var invalidPvtData []*ledger.PvtdataHashMismatch
 	var invalidNsColl []*nsColl
@@ -162,6 +174,17 @@ func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.T
 		}
 
 		if !bytes.Equal(util.ComputeSHA256(collPvtRwset.Rwset), rwsetHash) {
+			isPurged := func(keyHash []byte) (bool, error) {
+				return pvtdataStore.IsPurged(ns, coll, keyHash, blkNum, txNum)
+			}
+			isPartial, err := rwsetutil.IsPartialPvtRwSet(collPvtRwset.Rwset, txRWSet.GetCollHashedRwSet(ns, coll), isPurged)
+			if err != nil {
+				return nil, nil, err
+			}
+			if isPartial {
+				// the pvt data is sent by a peer that has purged some of the keys
+				continue
+			}
 			invalidPvtData = append(invalidPvtData, &ledger.PvtdataHashMismatch{
 				BlockNum:     blkNum,
 				TxNum:        txNum,
@@ -171,5 +194,5 @@ func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.T
 			invalidNsColl = append(invalidNsColl, &nsColl{ns, coll})
 		}
 	}
-	return invalidPvtData, invalidNsColl
+	return invalidPvtData, invalidNsColl, nil
 }
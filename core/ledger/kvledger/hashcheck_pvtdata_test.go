@@ -13,9 +13,13 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
 )
@@ -135,7 +139,7 @@ func TestConstructValidInvalidBlocksPvtData(t *testing.T) {
 		},
 	}
 
-	blocksValidPvtData, hashMismatched, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore)
+	blocksValidPvtData, hashMismatched, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore, lg.(*kvLedger).pvtdataStore)
 	require.NoError(t, err)
 	require.Equal(t, len(expectedValidBlocksPvtData), len(blocksValidPvtData))
 	require.ElementsMatch(t, expectedValidBlocksPvtData[1], blocksValidPvtData[1])
@@ -164,7 +168,7 @@ func TestConstructValidInvalidBlocksPvtData(t *testing.T) {
 		},
 	}
 
-	blocksValidPvtData, hashMismatches, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore)
+	blocksValidPvtData, hashMismatches, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore, lg.(*kvLedger).pvtdataStore)
 	require.NoError(t, err)
 	require.Len(t, blocksValidPvtData, 0)
 
@@ -245,3 +249,71 @@ type testNsColls struct {
 	ns    string
 	colls []string
 }
+
+func TestFindInvalidNsPvtDataWithPurgedKeys(t *testing.T) {
+	storeEnv := pvtdatastorage.NewTestStoreEnv(t, "testLedger", btltestutil.SampleBTLPolicy(map[[2]string]uint64{}),
+		&pvtdatastorage.PrivateDataConfig{
+			PrivateDataConfig: &ledger.PrivateDataConfig{
+				BatchesInterval: 1000,
+				MaxBatchSize:    5000,
+				PurgeInterval:   2,
+			},
+		},
+	)
+	defer storeEnv.Cleanup()
+	pvtdataStore := storeEnv.TestStore
+
+	// key2 is purged by the tx 1 in the block 2
+	require.NoError(t, pvtdataStore.Commit(0, nil, nil, nil))
+	require.NoError(t, pvtdataStore.Commit(1, nil, nil, nil))
+	require.NoError(t, pvtdataStore.Commit(2, nil, nil, []*pvtdatastorage.PurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key2"), BlkNum: 2, TxNum: 1},
+	}))
+
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToPvtAndHashedWriteSet("ns-1", "coll-1", "key1", []byte("value1"))
+	builder.AddToPvtAndHashedWriteSet("ns-1", "coll-1", "key2", []byte("value2"))
+	simRes, err := builder.GetTxSimulationResults()
+	require.NoError(t, err)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	require.NoError(t, err)
+
+	key1Only, err := proto.Marshal(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: "key1", Value: []byte("value1")}},
+	})
+	require.NoError(t, err)
+	nsRwset := &rwset.NsPvtReadWriteSet{
+		Namespace: "ns-1",
+		CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+			{CollectionName: "coll-1", Rwset: key1Only},
+		},
+	}
+
+	// the writes of key2 are missing from the pvt data of a transaction that precedes the purge
+	invalidPvtData, invalidNsColl, err := findInvalidNsPvtData(nsRwset, txRWSet, 1, 3, pvtdataStore)
+	require.NoError(t, err)
+	require.Empty(t, invalidPvtData)
+	require.Empty(t, invalidNsColl)
+
+	invalidPvtData, invalidNsColl, err = findInvalidNsPvtData(nsRwset, txRWSet, 2, 0, pvtdataStore)
+	require.NoError(t, err)
+	require.Empty(t, invalidPvtData)
+	require.Empty(t, invalidNsColl)
+
+	// the writes of key2 are missing from the pvt data of a transaction that follows the purge
+	invalidPvtData, invalidNsColl, err = findInvalidNsPvtData(nsRwset, txRWSet, 2, 2, pvtdataStore)
+	require.NoError(t, err)
+	require.Len(t, invalidPvtData, 1)
+	require.Equal(t, []*nsColl{{"ns-1", "coll-1"}}, invalidNsColl)
+
+	// the writes of key1 are missing while key1 has never been purged
+	key2Only, err := proto.Marshal(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: "key2", Value: []byte("value2")}},
+	})
+	require.NoError(t, err)
+	nsRwset.CollectionPvtRwset[0].Rwset = key2Only
+	invalidPvtData, invalidNsColl, err = findInvalidNsPvtData(nsRwset, txRWSet, 1, 3, pvtdataStore)
+	require.NoError(t, err)
+	require.Len(t, invalidPvtData, 1)
+	require.Equal(t, []*nsColl{{"ns-1", "coll-1"}}, invalidNsColl)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	protoutil "github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("history")
@@ -52,6 +53,21 @@ func (p *DBProvider) GetDBHandle(name string) (*DB, error) {
 		nil
 }
 
+// MarkStartingSavepoint creates the historydb for a ledger that is created from a snapshot. As the
+// history of the keys is not part of the snapshot, the historydb starts with the savepoint set to
+// the last block in the snapshot and maintains the history for the blocks committed afterwards
+func (p *DBProvider) MarkStartingSavepoint(name string, savepoint *version.Height) error {
+	db := p.leveldbProvider.GetDBHandle(name)
+	existingSavepoint, err := db.Get(savePointKey)
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.Errorf("historydb for ledger [%s] is not empty", name)
+	}
+	return db.Put(savePointKey, savepoint.ToBytes(), true)
+}
+
 // Close closes the underlying db
 func (p *DBProvider) Close() {
 	p.leveldbProvider.Close()
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,6 +96,27 @@ func TestSavepoint(t *testing.T) {
 	assert.Equal(t, uint64(3), blockNum)
 }
 
+func TestMarkStartingSavepoint(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	require.NoError(t, env.testHistoryDBProvider.MarkStartingSavepoint("ledgerFromSnapshot", version.NewHeight(10, 2)))
+	db, err := env.testHistoryDBProvider.GetDBHandle("ledgerFromSnapshot")
+	require.NoError(t, err)
+	savepoint, err := db.GetLastSavepoint()
+	require.NoError(t, err)
+	require.Equal(t, version.NewHeight(10, 2), savepoint)
+	status, blockNum, err := db.ShouldRecover(10)
+	require.NoError(t, err)
+	require.False(t, status)
+	require.Equal(t, uint64(11), blockNum)
+
+	require.EqualError(t,
+		env.testHistoryDBProvider.MarkStartingSavepoint("ledgerFromSnapshot", version.NewHeight(10, 2)),
+		"historydb for ledger [ledgerFromSnapshot] is not empty",
+	)
+}
+
 func TestHistory(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
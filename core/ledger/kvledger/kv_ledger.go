@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
@@ -54,8 +55,10 @@ type kvLedger struct {
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
 	commitHash             []byte
+	bootSnapshotCommitHash []byte
 	hashProvider           ledger.HashProvider
 	snapshotsConfig        *ledger.SnapshotsConfig
+	snapshotMgr            *snapshotMgr
 	// isPvtDataStoreAheadOfBlockStore is read during missing pvtData
 	// reconciliation and may be updated during a regular block commit.
 	// Hence, we use atomic value to ensure consistent read.
@@ -77,6 +80,7 @@ type lgrInitializer struct {
 	customTxProcessors       map[common.HeaderType]ledger.CustomTxProcessor
 	hashProvider             ledger.HashProvider
 	snapshotsConfig          *ledger.SnapshotsConfig
+	bootSnapshotInfo         []byte
 }
 
 func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
@@ -90,6 +94,11 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		hashProvider:    initializer.hashProvider,
 		snapshotsConfig: initializer.snapshotsConfig,
 		blockAPIsRWLock: &sync.RWMutex{},
+		snapshotMgr: &snapshotMgr{
+			snapshotRequestBookkeeper: &snapshotRequestBookkeeper{
+				dbHandle: initializer.bookkeeperProvider.GetDBHandle(ledgerID, bookkeeping.SnapshotRequest),
+			},
+		},
 	}
 
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, initializer.ccInfoProvider})
@@ -124,6 +133,9 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 	l.pvtdataStore.Init(btlPolicy)
 
 	var err error
+	if l.bootSnapshotCommitHash, err = bootSnapshotCommitHash(initializer.bootSnapshotInfo); err != nil {
+		return nil, err
+	}
 	l.commitHash, err = l.lastPersistedCommitHash()
 	if err != nil {
 		return nil, err
@@ -187,6 +199,12 @@ func (l *kvLedger) lastPersistedCommitHash() ([]byte, error) {
 		return nil, nil
 	}
 
+	if bcInfo.BootstrappingSnapshotInfo != nil && bcInfo.BootstrappingSnapshotInfo.LastBlockInSnapshot == bcInfo.Height-1 {
+		logger.Debugf("Ledger is bootstrapped from a snapshot and no block is committed after the snapshot." +
+			" Retrieving the currentCommitHash from the snapshot info")
+		return l.bootSnapshotCommitHash, nil
+	}
+
 	logger.Debugf("Fetching block [%d] to retrieve the currentCommitHash", bcInfo.Height-1)
 	block, err := l.GetBlockByNumber(bcInfo.Height - 1)
 	if err != nil {
@@ -411,6 +429,20 @@ func (l *kvLedger) GetBlockByTxID(txID string) (*common.Block, error) {
 	return block, err
 }
 
+// GetTransactionsByChaincodeEvent returns the transactions that emitted the given event of the given chaincode
+func (l *kvLedger) GetTransactionsByChaincodeEvent(chaincodeName, eventName, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	l.blockAPIsRWLock.RLock()
+	defer l.blockAPIsRWLock.RUnlock()
+	return l.blockStore.RetrieveTxsByChaincodeEvent(chaincodeName, eventName, bookmark, pageSize)
+}
+
+// GetBlocksByTimestamp returns the blocks with a timestamp in the range [startTime, endTime)
+func (l *kvLedger) GetBlocksByTimestamp(startTime, endTime time.Time, bookmark string, pageSize int) ([]*common.Block, string, error) {
+	l.blockAPIsRWLock.RLock()
+	defer l.blockAPIsRWLock.RUnlock()
+	return l.blockStore.RetrieveBlocksByTimestamp(startTime, endTime, bookmark, pageSize)
+}
+
 func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error) {
 	l.blockAPIsRWLock.RLock()
 	defer l.blockAPIsRWLock.RUnlock()
@@ -447,6 +479,9 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
 
+	l.snapshotMgr.lock.Lock()
+	defer l.snapshotMgr.lock.Unlock()
+
 	startBlockProcessing := time.Now()
 	if commitOpts.FetchPvtDataFromLedger {
 		// when we reach here, it means that the pvtdata store has the
@@ -486,8 +521,8 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 
 	logger.Debugf("[%s] Committing pvtdata and block [%d] to storage", l.ledgerID, blockNo)
 	l.blockAPIsRWLock.Lock()
-	defer l.blockAPIsRWLock.Unlock()
 	if err = l.commitToPvtAndBlockStore(pvtdataAndBlock); err != nil {
+		l.blockAPIsRWLock.Unlock()
 		return err
 	}
 	elapsedBlockstorageAndPvtdataCommit := time.Since(startBlockstorageAndPvtdataCommit)
@@ -507,6 +542,7 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 			panic(errors.WithMessage(err, "Error during commit to history db"))
 		}
 	}
+	l.blockAPIsRWLock.Unlock()
 
 	logger.Infof("[%s] Committed block [%d] with %d transaction(s) in %dms (state_validation=%dms block_and_pvtdata_commit=%dms state_commit=%dms)"+
 		" commitHash=[%x]",
@@ -523,7 +559,7 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 		elapsedCommitState,
 		txstatsInfo,
 	)
-	return nil
+	return l.processSnapshotRequest(blockNo)
 }
 
 func (l *kvLedger) commitToPvtAndBlockStore(blockAndPvtdata *ledger.BlockAndPvtData) error {
@@ -545,7 +581,11 @@ func (l *kvLedger) commitToPvtAndBlockStore(blockAndPvtdata *ledger.BlockAndPvtD
 		// too in the pvtdataStore as we do for the publicdata in the case of blockStore.
 		// Hence, we pass all pvtData present in the block to the pvtdataStore committer.
 		pvtData, missingPvtData := constructPvtDataAndMissingData(blockAndPvtdata)
-		if err := l.pvtdataStore.Commit(blockNum, pvtData, missingPvtData); err != nil {
+		purgeMarkers, err := extractPurgeMarkers(blockAndPvtdata.Block)
+		if err != nil {
+			return err
+		}
+		if err := l.pvtdataStore.Commit(blockNum, pvtData, missingPvtData, purgeMarkers); err != nil {
 			return err
 		}
 	} else {
@@ -673,7 +713,7 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(reconciledPvtdata []*ledger.Reconcil
 	logger.Debugf("[%s:] Comparing pvtData of [%d] old blocks against the hashes in transaction's rwset to find valid and invalid data",
 		l.ledgerID, len(reconciledPvtdata))
 
-	hashVerifiedPvtData, hashMismatches, err := constructValidAndInvalidPvtData(reconciledPvtdata, l.blockStore)
+	hashVerifiedPvtData, hashMismatches, err := constructValidAndInvalidPvtData(reconciledPvtdata, l.blockStore, l.pvtdataStore)
 	if err != nil {
 		return nil, err
 	}
@@ -833,3 +873,54 @@ func constructPvtDataAndMissingData(blockAndPvtData *ledger.BlockAndPvtData) ([]
 	}
 	return pvtData, missingPvtData
 }
+
+// extractPurgeMarkers returns the purges of private data keys by the valid endorser transactions in the block
+func extractPurgeMarkers(block *common.Block) ([]*pvtdatastorage.PurgeMarker, error) {
+	var purgeMarkers []*pvtdatastorage.PurgeMarker
+	txsFilter := txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txNum, envBytes := range block.Data.Data {
+		if !txsFilter.IsValid(txNum) {
+			continue
+		}
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := protoutil.ChannelHeader(env)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		// the results of a transaction that is marked valid are expected to be well formed, as the validation
+		// of such a transaction has parsed them. A transaction with malformed results cannot purge any key
+		respPayload, err := protoutil.GetActionFromEnvelopeMsg(env)
+		if err != nil {
+			logger.Debugf("Skipping the tx [%d] in the block [%d] while looking for purges: %s", txNum, block.Header.Number, err)
+			continue
+		}
+		txRWSet := &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+			logger.Debugf("Skipping the tx [%d] in the block [%d] while looking for purges: %s", txNum, block.Header.Number, err)
+			continue
+		}
+		for _, nsRwSet := range txRWSet.NsRwSets {
+			for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+				for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+					if !hashedWrite.IsPurge {
+						continue
+					}
+					purgeMarkers = append(purgeMarkers, &pvtdatastorage.PurgeMarker{
+						Namespace:  nsRwSet.NameSpace,
+						Collection: collHashedRwSet.CollectionName,
+						KeyHash:    hashedWrite.KeyHash,
+						BlkNum:     block.Header.Number,
+						TxNum:      uint64(txNum),
+					})
+				}
+			}
+		}
+	}
+	return purgeMarkers, nil
+}
@@ -48,6 +48,9 @@ var (
 	metadataKeyPrefix = []byte{'s'}
 	// metadataKeyStop is the end key when querying idStore db by metadata key
 	metadataKeyStop = []byte{'s' + 1}
+	// bootSnapshotInfoKeyPrefix is the prefix for the key that holds the additional info of the
+	// snapshot from which a ledger is created
+	bootSnapshotInfoKeyPrefix = []byte{'b'}
 
 	// formatKey
 	formatKey = []byte("f")
@@ -145,12 +148,13 @@ func (p *Provider) initLedgerIDInventory() error {
 }
 
 func (p *Provider) initBlockStoreProvider() error {
-	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	indexConfig := blockStoreIndexConfig(p.initializer.Config)
+	conf, err := blockStoreConf(p.initializer.Config)
+	if err != nil {
+		return err
+	}
 	blkStoreProvider, err := blkstorage.NewProvider(
-		blkstorage.NewConf(
-			BlockStorePath(p.initializer.Config.RootFSPath),
-			maxBlockFileSize,
-		),
+		conf,
 		indexConfig,
 		p.initializer.MetricsProvider,
 	)
@@ -161,6 +165,46 @@ func (p *Provider) initBlockStoreProvider() error {
 	return nil
 }
 
+// blockStoreIndexConfig returns the index configuration of the block store, which includes
+// the optional attributes if enabled
+func blockStoreIndexConfig(config *ledger.Config) *blkstorage.IndexConfig {
+	attrs := append([]blkstorage.IndexableAttr(nil), attrsToIndex...)
+	if blockStoreConfig := config.BlockStoreConfig; blockStoreConfig != nil {
+		if blockStoreConfig.IndexChaincodeEvents {
+			attrs = append(attrs, blkstorage.IndexableAttrChaincodeEvent)
+		}
+		if blockStoreConfig.IndexBlockTimestamps {
+			attrs = append(attrs, blkstorage.IndexableAttrBlockTimestamp)
+		}
+	}
+	return &blkstorage.IndexConfig{AttrsToIndex: attrs}
+}
+
+// blockStoreConf returns the configuration of the block store, which enables archiving and
+// compression of the block files if configured so
+func blockStoreConf(config *ledger.Config) (*blkstorage.Conf, error) {
+	conf := blkstorage.NewConf(BlockStorePath(config.RootFSPath), maxBlockFileSize)
+	if archiveConfig := config.BlockArchiveConfig; archiveConfig != nil && archiveConfig.Enabled {
+		archiveStore, err := blkstorage.NewFilesystemArchiveStore(archiveConfig.ArchiveDir)
+		if err != nil {
+			return nil, err
+		}
+		conf = blkstorage.NewConfWithArchive(
+			BlockStorePath(config.RootFSPath),
+			maxBlockFileSize,
+			&blkstorage.ArchiveConf{
+				Store:           archiveStore,
+				RetentionBlocks: archiveConfig.RetentionBlocks,
+				Compress:        archiveConfig.Compress,
+			},
+		)
+	}
+	if config.BlockStoreConfig == nil {
+		return conf, nil
+	}
+	return conf.WithBlockfileCompression(config.BlockStoreConfig.Compression)
+}
+
 func (p *Provider) initPvtDataStoreProvider() error {
 	privateDataConfig := &pvtdatastorage.PrivateDataConfig{
 		PrivateDataConfig: p.initializer.Config.PrivateDataConfig,
@@ -339,6 +383,11 @@ func (p *Provider) open(ledgerID string) (ledger.PeerLedger, error) {
 		return nil, err
 	}
 
+	bootSnapshotInfo, err := p.idStore.getBootSnapshotInfo(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the history database (index for history of values by key) for a chain/ledger
 	var historyDB *history.DB
 	if p.historydbProvider != nil {
@@ -363,6 +412,7 @@ func (p *Provider) open(ledgerID string) (ledger.PeerLedger, error) {
 		customTxProcessors:       p.initializer.CustomTxProcessors,
 		hashProvider:             p.initializer.HashProvider,
 		snapshotsConfig:          p.initializer.Config.SnapshotsConfig,
+		bootSnapshotInfo:         bootSnapshotInfo,
 	}
 
 	l, err := newKVLedger(initializer)
@@ -412,8 +462,9 @@ func (p *Provider) Close() {
 
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
 // if a crash had happened during creation of ledger and the ledger creation could have been left in intermediate
-// state. Recovery checks if the ledger was created and the genesis block was committed successfully then it completes
-// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
+// state. Recovery checks if the ledger was created and the genesis block was committed successfully (or, for a
+// ledger created from a snapshot, the block store was bootstrapped from the snapshot) then it completes the last
+// step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
 func (p *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
 	ledgerID, err := p.idStore.getUnderConstructionFlag()
@@ -429,6 +480,19 @@ func (p *Provider) recoverUnderConstructionLedger() {
 	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
 	ledger.Close()
 
+	if bcInfo.BootstrappingSnapshotInfo != nil {
+		if bcInfo.Height != bcInfo.BootstrappingSnapshotInfo.LastBlockInSnapshot+1 {
+			panic(errors.Errorf(
+				"data inconsistency: under construction flag is set for ledger [%s] while the height of the blockchain is [%d] and the last block in the snapshot is [%d]",
+				ledgerID, bcInfo.Height, bcInfo.BootstrappingSnapshotInfo.LastBlockInSnapshot))
+		}
+		logger.Infof("Block store was bootstrapped from the snapshot. Hence, marking the peer ledger as created")
+		bootSnapshotInfo, err := p.idStore.getBootSnapshotInfo(ledgerID)
+		panicOnErr(err, "Error while retrieving the boot snapshot info for ledger [%s]", ledgerID)
+		panicOnErr(p.idStore.createLedgerIDFromSnapshot(ledgerID, bootSnapshotInfo), "Error while adding ledgerID [%s] to created list", ledgerID)
+		return
+	}
+
 	switch bcInfo.Height {
 	case 0:
 		logger.Infof("Genesis block was not committed. Hence, the peer ledger not created. unsetting the under construction flag")
@@ -597,30 +661,52 @@ func (s *idStore) getUnderConstructionFlag() (string, error) {
 }
 
 func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
-	gbKey := s.encodeLedgerKey(ledgerID, ledgerKeyPrefix)
+	gbBytes, err := proto.Marshal(gb)
+	if err != nil {
+		return err
+	}
+	return s.addLedgerID(ledgerID, gbBytes)
+}
+
+// createLedgerIDFromSnapshot adds the ledger created from a snapshot to the list of created ledgers. As there is no
+// genesis block for such a ledger, the additional info of the snapshot is stored against the ledger key instead
+func (s *idStore) createLedgerIDFromSnapshot(ledgerID string, snapshotAdditionalInfo []byte) error {
+	return s.addLedgerID(ledgerID, snapshotAdditionalInfo)
+}
+
+func (s *idStore) addLedgerID(ledgerID string, ledgerKeyVal []byte) error {
+	ledgerKey := s.encodeLedgerKey(ledgerID, ledgerKeyPrefix)
 	metadataKey := s.encodeLedgerKey(ledgerID, metadataKeyPrefix)
 	var val []byte
 	var metadata []byte
 	var err error
-	if val, err = s.db.Get(gbKey); err != nil {
+	if val, err = s.db.Get(ledgerKey); err != nil {
 		return err
 	}
 	if val != nil {
 		return ErrLedgerIDExists
 	}
-	if val, err = proto.Marshal(gb); err != nil {
-		return err
-	}
 	if metadata, err = protoutil.Marshal(&msgs.LedgerMetadata{Status: msgs.Status_ACTIVE}); err != nil {
 		return err
 	}
 	batch := &leveldb.Batch{}
-	batch.Put(gbKey, val)
+	batch.Put(ledgerKey, ledgerKeyVal)
 	batch.Put(metadataKey, metadata)
 	batch.Delete(underConstructionLedgerKey)
 	return s.db.WriteBatch(batch, true)
 }
 
+// putBootSnapshotInfo persists the additional info of the snapshot from which the ledger is being created
+func (s *idStore) putBootSnapshotInfo(ledgerID string, snapshotAdditionalInfo []byte) error {
+	return s.db.Put(s.encodeLedgerKey(ledgerID, bootSnapshotInfoKeyPrefix), snapshotAdditionalInfo, true)
+}
+
+// getBootSnapshotInfo returns the additional info of the snapshot from which the ledger was created, or nil
+// if the ledger was created from a genesis block
+func (s *idStore) getBootSnapshotInfo(ledgerID string) ([]byte, error) {
+	return s.db.Get(s.encodeLedgerKey(ledgerID, bootSnapshotInfoKeyPrefix))
+}
+
 func (s *idStore) updateLedgerStatus(ledgerID string, newStatus msgs.Status) error {
 	metadata, err := s.getLedgerMetadata(ledgerID)
 	if err != nil {
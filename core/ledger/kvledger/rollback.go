@@ -33,7 +33,14 @@ func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64) error {
 	}
 
 	logger.Info("Rolling back ledger store")
-	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	// the optional attributes are included so that their index entries are removed, if present
+	indexConfig := &blkstorage.IndexConfig{
+		AttrsToIndex: append(
+			append([]blkstorage.IndexableAttr(nil), attrsToIndex...),
+			blkstorage.IndexableAttrChaincodeEvent,
+			blkstorage.IndexableAttrBlockTimestamp,
+		),
+	}
 	if err := blkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig); err != nil {
 		return err
 	}
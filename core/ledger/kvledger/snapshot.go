@@ -7,14 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"hash"
+	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/pkg/errors"
 )
 
@@ -28,10 +34,12 @@ const (
 // can be signed by the peer. Hashsum of the resultant JSON is intended to be used as a single
 // hash of the snapshot, if need be.
 type snapshotSignableMetadata struct {
-	ChannelName        string            `json:"channel_name"`
-	ChannelHeight      uint64            `json:"channel_height"`
-	LastBlockHashInHex string            `json:"last_block_hash"`
-	FilesAndHashes     map[string]string `json:"snapshot_files_raw_hashes"`
+	ChannelName            string            `json:"channel_name"`
+	ChannelHeight          uint64            `json:"channel_height"`
+	LastBlockHashInHex     string            `json:"last_block_hash"`
+	PreviousBlockHashInHex string            `json:"previous_block_hash"`
+	BlockHashingAlgorithm  string            `json:"block_hashing_algorithm"`
+	FilesAndHashes         map[string]string `json:"snapshot_files_raw_hashes"`
 }
 
 type snapshotAdditionalInfo struct {
@@ -115,10 +123,12 @@ func (l *kvLedger) generateSnapshotMetadataFiles(
 	}
 	metadata, err := json.MarshalIndent(
 		&snapshotSignableMetadata{
-			ChannelName:        l.ledgerID,
-			ChannelHeight:      bcInfo.Height,
-			LastBlockHashInHex: hex.EncodeToString(bcInfo.CurrentBlockHash),
-			FilesAndHashes:     filesAndHashes,
+			ChannelName:            l.ledgerID,
+			ChannelHeight:          bcInfo.Height,
+			LastBlockHashInHex:     hex.EncodeToString(bcInfo.CurrentBlockHash),
+			PreviousBlockHashInHex: hex.EncodeToString(bcInfo.PreviousBlockHash),
+			BlockHashingAlgorithm:  l.blockStore.BlockHashingAlgorithm(),
+			FilesAndHashes:         filesAndHashes,
 		},
 		"",
 		jsonFileIndent,
@@ -190,3 +200,188 @@ func syncDir(dirPath string) error {
 	}
 	return err
 }
+
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
+// This function creates a new ledger from the supplied snapshot. The snapshot metadata and the hashes of the
+// snapshot files are verified before importing any data. Similar to function `Create`, this function sets an
+// under construction flag before importing the data and, upon a successful import, removes the flag and adds
+// an entry into the created ledgers list (atomically). If a crash happens in between, the
+// 'recoverUnderConstructionLedger' function is invoked before declaring the provider to be usable
+func (p *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	metadata, additionalInfo, err := p.loadAndVerifySnapshotMetadata(snapshotDir)
+	if err != nil {
+		return nil, "", err
+	}
+	ledgerID := metadata.ChannelName
+	exists, err := p.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", ErrLedgerIDExists
+	}
+	if err = p.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, "", err
+	}
+	if err = p.idStore.putBootSnapshotInfo(ledgerID, additionalInfo); err != nil {
+		panicOnErr(p.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, "", err
+	}
+	if err = p.importFromSnapshot(snapshotDir, metadata); err != nil {
+		logger.Errorf("Error while importing the snapshot into a new ledger. Unsetting under construction flag. Error: %+v", err)
+		panicOnErr(p.runCleanup(ledgerID), "Error running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(p.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, "", errors.WithMessagef(err, "error while importing the snapshot into ledger [%s]", ledgerID)
+	}
+	lgr, err := p.open(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	panicOnErr(p.idStore.createLedgerIDFromSnapshot(ledgerID, additionalInfo), "Error while marking ledger as created")
+	return lgr, ledgerID, nil
+}
+
+// importFromSnapshot imports the data from the snapshot into the various stores of the ledger. The block store
+// is bootstrapped at the end so that the height of the block store can be used to detect whether the import
+// completed, in the event of a crash
+func (p *Provider) importFromSnapshot(snapshotDir string, metadata *snapshotSignableMetadata) error {
+	ledgerID := metadata.ChannelName
+	lastBlockNum := metadata.ChannelHeight - 1
+	savepoint := version.NewHeight(lastBlockNum, math.MaxUint64)
+
+	lastBlockHash, err := hex.DecodeString(metadata.LastBlockHashInHex)
+	if err != nil {
+		return errors.Wrap(err, "error while decoding the last block hash")
+	}
+	previousBlockHash, err := hex.DecodeString(metadata.PreviousBlockHashInHex)
+	if err != nil {
+		return errors.Wrap(err, "error while decoding the previous block hash")
+	}
+
+	if err := p.configHistoryMgr.ImportConfigHistory(ledgerID, snapshotDir); err != nil {
+		return err
+	}
+	if err := p.dbProvider.ImportFromSnapshot(ledgerID, savepoint, snapshotDir); err != nil {
+		return err
+	}
+	if p.historydbProvider != nil {
+		if err := p.historydbProvider.MarkStartingSavepoint(ledgerID, savepoint); err != nil {
+			return err
+		}
+	}
+	pvtdataStore, err := p.pvtdataStoreProvider.OpenStore(ledgerID)
+	if err != nil {
+		return err
+	}
+	if err := pvtdataStore.InitLastCommittedBlock(lastBlockNum); err != nil {
+		return err
+	}
+	_, err = p.blkStoreProvider.BootstrapFromSnapshottedTxIDs(
+		snapshotDir,
+		&blkstorage.SnapshotInfo{
+			LedgerID:              ledgerID,
+			LastBlockNum:          lastBlockNum,
+			LastBlockHash:         lastBlockHash,
+			PreviousBlockHash:     previousBlockHash,
+			BlockHashingAlgorithm: metadata.BlockHashingAlgorithm,
+		},
+	)
+	return err
+}
+
+// loadAndVerifySnapshotMetadata loads the metadata files present in the snapshotDir and verifies the hash of the
+// signable metadata as well as the hashes of the snapshot files listed in the signable metadata. In addition to the
+// signable metadata, the function returns the raw bytes of the additional info file
+func (p *Provider) loadAndVerifySnapshotMetadata(snapshotDir string) (*snapshotSignableMetadata, []byte, error) {
+	metadataJSON, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotMetadataFileName))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while reading the snapshot metadata file")
+	}
+	additionalInfoJSON, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotMetadataHashFileName))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while reading the snapshot additional info file")
+	}
+	metadata := &snapshotSignableMetadata{}
+	if err := json.Unmarshal(metadataJSON, metadata); err != nil {
+		return nil, nil, errors.Wrap(err, "error while unmarshalling the snapshot metadata")
+	}
+	additionalInfo := &snapshotAdditionalInfo{}
+	if err := json.Unmarshal(additionalInfoJSON, additionalInfo); err != nil {
+		return nil, nil, errors.Wrap(err, "error while unmarshalling the snapshot additional info")
+	}
+	if metadata.ChannelName == "" || metadata.ChannelHeight == 0 {
+		return nil, nil, errors.Errorf(
+			"invalid snapshot metadata: channel_name = [%s], channel_height = [%d]",
+			metadata.ChannelName, metadata.ChannelHeight,
+		)
+	}
+
+	metadataHash, err := p.computeSnapshotHash(bytes.NewReader(metadataJSON))
+	if err != nil {
+		return nil, nil, err
+	}
+	if hex.EncodeToString(metadataHash) != additionalInfo.SnapshotHashInHex {
+		return nil, nil, errors.Errorf(
+			"hash mismatch for the snapshot metadata file [%s]. Expected hash = [%s], actual hash = [%x]",
+			snapshotMetadataFileName, additionalInfo.SnapshotHashInHex, metadataHash,
+		)
+	}
+
+	for fileName, expectedHashInHex := range metadata.FilesAndHashes {
+		if err := p.verifySnapshotFileHash(filepath.Join(snapshotDir, fileName), expectedHashInHex); err != nil {
+			return nil, nil, err
+		}
+	}
+	return metadata, additionalInfoJSON, nil
+}
+
+func (p *Provider) verifySnapshotFileHash(filePath string, expectedHashInHex string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "error while opening the snapshot file")
+	}
+	defer file.Close()
+	hash, err := p.computeSnapshotHash(file)
+	if err != nil {
+		return errors.WithMessagef(err, "error while computing the hash of the snapshot file [%s]", filePath)
+	}
+	if hex.EncodeToString(hash) != expectedHashInHex {
+		return errors.Errorf(
+			"hash mismatch for the snapshot file [%s]. Expected hash = [%s], actual hash = [%x]",
+			filePath, expectedHashInHex, hash,
+		)
+	}
+	return nil
+}
+
+func (p *Provider) computeSnapshotHash(r io.Reader) ([]byte, error) {
+	hash, err := p.initializer.HashProvider.GetHash(snapshotHashOpts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(hash, r); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// bootSnapshotCommitHash returns the commit hash of the last block in the snapshot from the supplied
+// additional info, as persisted at the time of creating the ledger from the snapshot. A nil commit hash
+// is returned for a ledger that is not created from a snapshot
+func bootSnapshotCommitHash(additionalInfoJSON []byte) ([]byte, error) {
+	if additionalInfoJSON == nil {
+		return nil, nil
+	}
+	additionalInfo := &snapshotAdditionalInfo{}
+	if err := json.Unmarshal(additionalInfoJSON, additionalInfo); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling the snapshot additional info")
+	}
+	if additionalInfo.LastBlockCommitHashInHex == "" {
+		return nil, nil
+	}
+	commitHash, err := hex.DecodeString(additionalInfo.LastBlockCommitHashInHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decoding the last block commit hash")
+	}
+	return commitHash, nil
+}
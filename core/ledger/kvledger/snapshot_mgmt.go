@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+// snapshotMgr keeps track of the snapshot requests of a ledger and generates
+// the snapshots when the requested blocks are committed
+type snapshotMgr struct {
+	// lock serializes the block commits with the processing of the snapshot requests
+	// so that a snapshot always captures the state as of the last committed block
+	lock                      sync.Mutex
+	snapshotRequestBookkeeper *snapshotRequestBookkeeper
+}
+
+// SubmitSnapshotRequest submits a snapshot request for the specified block number.
+// When blockNumber is 0 or equal to the last committed block number, the snapshot
+// is generated immediately.
+func (l *kvLedger) SubmitSnapshotRequest(blockNumber uint64) error {
+	l.snapshotMgr.lock.Lock()
+	defer l.snapshotMgr.lock.Unlock()
+
+	lastCommittedBlock, err := l.lastCommittedBlockNumber()
+	if err != nil {
+		return err
+	}
+
+	if blockNumber == 0 {
+		blockNumber = lastCommittedBlock
+	}
+	if blockNumber < lastCommittedBlock {
+		return errors.Errorf("requested snapshot for block number %d cannot be less than the last committed block number %d", blockNumber, lastCommittedBlock)
+	}
+
+	exists, err := l.snapshotMgr.snapshotRequestBookkeeper.exist(blockNumber)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("duplicate snapshot request for block number %d", blockNumber)
+	}
+
+	if blockNumber == lastCommittedBlock {
+		logger.Infof("[%s] Generating snapshot for the last committed block [%d]", l.ledgerID, blockNumber)
+		return l.generateSnapshot()
+	}
+
+	return l.snapshotMgr.snapshotRequestBookkeeper.add(blockNumber)
+}
+
+// CancelSnapshotRequest cancels the previously submitted request for the
+// specified block number
+func (l *kvLedger) CancelSnapshotRequest(blockNumber uint64) error {
+	l.snapshotMgr.lock.Lock()
+	defer l.snapshotMgr.lock.Unlock()
+
+	exists, err := l.snapshotMgr.snapshotRequestBookkeeper.exist(blockNumber)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("no snapshot request exists for block number %d", blockNumber)
+	}
+	return l.snapshotMgr.snapshotRequestBookkeeper.delete(blockNumber)
+}
+
+// PendingSnapshotRequests returns the block numbers of the pending snapshot
+// requests in ascending order
+func (l *kvLedger) PendingSnapshotRequests() ([]uint64, error) {
+	return l.snapshotMgr.snapshotRequestBookkeeper.list()
+}
+
+// processSnapshotRequest generates the snapshot if one has been requested for the
+// block that has just been committed. A failure to generate the snapshot does not
+// fail the commit and the request is discarded.
+// This function should be invoked while holding the snapshotMgr lock.
+func (l *kvLedger) processSnapshotRequest(blockNumber uint64) error {
+	exists, err := l.snapshotMgr.snapshotRequestBookkeeper.exist(blockNumber)
+	if err != nil || !exists {
+		return err
+	}
+
+	logger.Infof("[%s] Generating snapshot for block [%d]", l.ledgerID, blockNumber)
+	if err := l.generateSnapshot(); err != nil {
+		logger.Errorf("[%s] Failed to generate snapshot for block [%d]: %s", l.ledgerID, blockNumber, err)
+	}
+	return l.snapshotMgr.snapshotRequestBookkeeper.delete(blockNumber)
+}
+
+func (l *kvLedger) lastCommittedBlockNumber() (uint64, error) {
+	bcInfo, err := l.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	if bcInfo.Height == 0 {
+		return 0, errors.New("ledger is empty")
+	}
+	return bcInfo.Height - 1, nil
+}
+
+// snapshotRequestBookkeeper persists the block numbers of the pending snapshot requests
+type snapshotRequestBookkeeper struct {
+	dbHandle *leveldbhelper.DBHandle
+}
+
+func (k *snapshotRequestBookkeeper) add(blockNumber uint64) error {
+	return k.dbHandle.Put(encodeSnapshotRequestKey(blockNumber), []byte{}, true)
+}
+
+func (k *snapshotRequestBookkeeper) delete(blockNumber uint64) error {
+	return k.dbHandle.Delete(encodeSnapshotRequestKey(blockNumber), true)
+}
+
+func (k *snapshotRequestBookkeeper) exist(blockNumber uint64) (bool, error) {
+	val, err := k.dbHandle.Get(encodeSnapshotRequestKey(blockNumber))
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (k *snapshotRequestBookkeeper) list() ([]uint64, error) {
+	itr, err := k.dbHandle.GetIterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	blockNumbers := []uint64{}
+	for itr.Next() {
+		blockNumber, _, err := util.DecodeOrderPreservingVarUint64(itr.Key())
+		if err != nil {
+			return nil, errors.Wrap(err, "error while decoding snapshot request key")
+		}
+		blockNumbers = append(blockNumbers, blockNumber)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over snapshot requests")
+	}
+	return blockNumbers, nil
+}
+
+func encodeSnapshotRequestKey(blockNumber uint64) []byte {
+	return util.EncodeOrderPreservingVarUint64(blockNumber)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRequests(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	snapshotRootDir := conf.SnapshotsConfig.RootDir
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+
+	blkGenerator, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	kvlgr := lgr.(*kvLedger)
+
+	// a request for the last committed block is served immediately
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(0))
+	requireSnapshotExists(t, snapshotRootDir, kvlgr.ledgerID, 1)
+	pending, err := kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(3))
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(2))
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(5))
+	require.EqualError(t, kvlgr.SubmitSnapshotRequest(2), "duplicate snapshot request for block number 2")
+	pending, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3, 5}, pending)
+
+	require.NoError(t, kvlgr.CancelSnapshotRequest(5))
+	require.EqualError(t, kvlgr.CancelSnapshotRequest(5), "no snapshot request exists for block number 5")
+	pending, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, pending)
+
+	// the pending requests survive a restart
+	lgr.Close()
+	provider.Close()
+	provider = testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+	lgr, err = provider.Open("testLedgerid")
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr = lgr.(*kvLedger)
+	pending, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, pending)
+
+	for i, blockNumber := range []uint64{1, 2} {
+		blockAndPvtdata := prepareNextBlockForTest(t, kvlgr, blkGenerator, "SimulateForBlk",
+			map[string]string{"key1": "value1", "key2": "value2"},
+			nil,
+		)
+		require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata, &ledger.CommitOptions{}))
+		require.Equal(t, blockNumber, blockAndPvtdata.Block.Header.Number, "block %d", i)
+	}
+	requireSnapshotExists(t, snapshotRootDir, kvlgr.ledgerID, 3)
+	pending, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3}, pending)
+
+	require.EqualError(t,
+		kvlgr.SubmitSnapshotRequest(1),
+		"requested snapshot for block number 1 cannot be less than the last committed block number 2",
+	)
+
+	blockAndPvtdata := prepareNextBlockForTest(t, kvlgr, blkGenerator, "SimulateForBlk3",
+		map[string]string{"key1": "value1"},
+		nil,
+	)
+	require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata, &ledger.CommitOptions{}))
+	requireSnapshotExists(t, snapshotRootDir, kvlgr.ledgerID, 4)
+	pending, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+func requireSnapshotExists(t *testing.T, snapshotRootDir, ledgerID string, height uint64) {
+	_, err := os.Stat(SnapshotDirForLedgerHeight(snapshotRootDir, ledgerID, height))
+	require.NoError(t, err, "snapshot at height %d", height)
+}
@@ -15,7 +15,10 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -56,6 +59,7 @@ func TestGenerateSnapshot(t *testing.T) {
 		kvlgr.ledgerID,
 		1,
 		protoutil.BlockHeaderHash(genesisBlk.Header),
+		nil,
 		kvlgr.commitHash,
 		"txids.data", "txids.metadata",
 	)
@@ -72,6 +76,7 @@ func TestGenerateSnapshot(t *testing.T) {
 		kvlgr.ledgerID,
 		2,
 		protoutil.BlockHeaderHash(blockAndPvtdata1.Block.Header),
+		protoutil.BlockHeaderHash(genesisBlk.Header),
 		kvlgr.commitHash,
 		"txids.data", "txids.metadata",
 		"public_state.data", "public_state.metadata",
@@ -89,6 +94,7 @@ func TestGenerateSnapshot(t *testing.T) {
 		kvlgr.ledgerID,
 		3,
 		protoutil.BlockHeaderHash(blockAndPvtdata2.Block.Header),
+		protoutil.BlockHeaderHash(blockAndPvtdata1.Block.Header),
 		kvlgr.commitHash,
 		"txids.data", "txids.metadata",
 		"public_state.data", "public_state.metadata",
@@ -108,6 +114,7 @@ func TestGenerateSnapshot(t *testing.T) {
 		kvlgr.ledgerID,
 		4,
 		protoutil.BlockHeaderHash(blockAndPvtdata3.Block.Header),
+		protoutil.BlockHeaderHash(blockAndPvtdata2.Block.Header),
 		kvlgr.commitHash,
 		"txids.data", "txids.metadata",
 		"public_state.data", "public_state.metadata",
@@ -288,12 +295,258 @@ func TestFileUtilFunctionsErrors(t *testing.T) {
 	})
 }
 
+func TestCreateFromSnapshot(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	nsCollBtlConfs := []*nsCollBtlConfig{
+		{
+			namespace: "ns",
+			btlConfig: map[string]uint64{"coll": 0},
+		},
+	}
+	provider := testutilNewProviderWithCollectionConfig(t, nsCollBtlConfs, conf)
+	defer provider.Close()
+
+	// create the source ledger with public, private, and config history data and generate the snapshot
+	blkGenerator, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr := lgr.(*kvLedger)
+	addDummyEntryInCollectionConfigHistory(t, provider, kvlgr.ledgerID)
+	blockAndPvtdata1 := prepareNextBlockForTest(t, kvlgr, blkGenerator, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1"},
+		map[string]string{"key1": "pvtValue1.1", "key2": "pvtValue2.1"},
+	)
+	require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata1, &ledger.CommitOptions{}))
+	require.NoError(t, kvlgr.generateSnapshot())
+	snapshotDir := SnapshotDirForLedgerHeight(conf.SnapshotsConfig.RootDir, kvlgr.ledgerID, 2)
+	sourceBCInfo, err := kvlgr.GetBlockchainInfo()
+	require.NoError(t, err)
+
+	// create a ledger from the snapshot in a separate provider
+	destConf, destCleanup := testConfig(t)
+	defer destCleanup()
+	destProvider := testutilNewProviderWithCollectionConfig(t, nsCollBtlConfs, destConf)
+	defer func() {
+		destProvider.Close()
+	}()
+	destLgr, ledgerID, err := destProvider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	require.Equal(t, "testLedgerid", ledgerID)
+	destKVLgr := destLgr.(*kvLedger)
+
+	verifyImportedLedger := func(destKVLgr *kvLedger) {
+		bcInfo, err := destKVLgr.GetBlockchainInfo()
+		require.NoError(t, err)
+		require.Equal(t, sourceBCInfo.Height, bcInfo.Height)
+		require.Equal(t, sourceBCInfo.CurrentBlockHash, bcInfo.CurrentBlockHash)
+		require.Equal(t, sourceBCInfo.PreviousBlockHash, bcInfo.PreviousBlockHash)
+		require.Equal(t, uint64(1), bcInfo.BootstrappingSnapshotInfo.LastBlockInSnapshot)
+		require.Equal(t, kvlgr.blockStore.BlockHashingAlgorithm(), destKVLgr.blockStore.BlockHashingAlgorithm())
+		require.Equal(t, kvlgr.commitHash, destKVLgr.commitHash)
+
+		qe, err := destKVLgr.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		val, err := qe.GetState("ns", "key1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1.1"), val)
+		hashedVal, err := qe.GetPrivateDataHash("ns", "coll", "key1")
+		require.NoError(t, err)
+		require.Equal(t, util.ComputeSHA256([]byte("pvtValue1.1")), hashedVal)
+
+		_, err = destKVLgr.GetBlockByNumber(1)
+		require.EqualError(t, err, "cannot serve block [1]. The ledger is bootstrapped from a snapshot. First available block = [2]")
+		txID, err := protoutil.GetOrComputeTxIDFromEnvelope(blockAndPvtdata1.Block.Data.Data[0])
+		require.NoError(t, err)
+		_, err = destKVLgr.GetTransactionByID(txID)
+		require.IsType(t, ledger.TxDetailsNotAvailableErr(""), err)
+		_, err = destKVLgr.GetTransactionByID("non-existing-txid")
+		require.IsType(t, ledger.NotFoundInIndexErr(""), err)
+	}
+	verifyImportedLedger(destKVLgr)
+
+	exists, err := destProvider.Exists(ledgerID)
+	require.NoError(t, err)
+	require.True(t, exists)
+	_, _, err = destProvider.CreateFromSnapshot(snapshotDir)
+	require.Equal(t, ErrLedgerIDExists, err)
+
+	// the imported ledger survives a restart
+	destLgr.Close()
+	destProvider.Close()
+	destProvider = testutilNewProviderWithCollectionConfig(t, nsCollBtlConfs, destConf)
+	destLgr, err = destProvider.Open(ledgerID)
+	require.NoError(t, err)
+	defer destLgr.Close()
+	destKVLgr = destLgr.(*kvLedger)
+	verifyImportedLedger(destKVLgr)
+
+	// commit the same block on both the ledgers and verify that the commit hashes match
+	blockAndPvtdata2 := prepareNextBlockForTest(t, kvlgr, blkGenerator, "SimulateForBlk2",
+		map[string]string{"key1": "value1.2"},
+		nil,
+	)
+	destBlockAndPvtdata2 := &ledger.BlockAndPvtData{
+		Block: proto.Clone(blockAndPvtdata2.Block).(*common.Block),
+	}
+	require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata2, &ledger.CommitOptions{}))
+	require.NoError(t, destKVLgr.CommitLegacy(destBlockAndPvtdata2, &ledger.CommitOptions{}))
+	require.Equal(t, kvlgr.commitHash, destKVLgr.commitHash)
+	sourceBCInfo, err = kvlgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	destBCInfo, err := destKVLgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, sourceBCInfo.CurrentBlockHash, destBCInfo.CurrentBlockHash)
+}
+
+func TestCreateFromSnapshotErrors(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	blkGenerator, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr := lgr.(*kvLedger)
+	blockAndPvtdata1 := prepareNextBlockForTest(t, kvlgr, blkGenerator, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1"},
+		nil,
+	)
+	require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata1, &ledger.CommitOptions{}))
+	require.NoError(t, kvlgr.generateSnapshot())
+	snapshotDir := SnapshotDirForLedgerHeight(conf.SnapshotsConfig.RootDir, kvlgr.ledgerID, 2)
+
+	copySnapshotDir := func(t *testing.T) string {
+		dir, err := ioutil.TempDir("", "snapshot")
+		require.NoError(t, err)
+		files, err := ioutil.ReadDir(snapshotDir)
+		require.NoError(t, err)
+		for _, f := range files {
+			content, err := ioutil.ReadFile(filepath.Join(snapshotDir, f.Name()))
+			require.NoError(t, err)
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, f.Name()), content, 0644))
+		}
+		return dir
+	}
+
+	newDestProvider := func(t *testing.T) (*Provider, func()) {
+		destConf, destCleanup := testConfig(t)
+		destProvider := testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+		return destProvider, func() {
+			destProvider.Close()
+			destCleanup()
+		}
+	}
+
+	t.Run("ledger exists", func(t *testing.T) {
+		_, _, err := provider.CreateFromSnapshot(snapshotDir)
+		require.Equal(t, ErrLedgerIDExists, err)
+	})
+
+	t.Run("missing metadata file", func(t *testing.T) {
+		dir := copySnapshotDir(t)
+		defer os.RemoveAll(dir)
+		require.NoError(t, os.Remove(filepath.Join(dir, snapshotMetadataFileName)))
+		destProvider, destCleanup := newDestProvider(t)
+		defer destCleanup()
+		_, _, err := destProvider.CreateFromSnapshot(dir)
+		require.Contains(t, err.Error(), "error while reading the snapshot metadata file")
+	})
+
+	t.Run("tampered metadata file", func(t *testing.T) {
+		dir := copySnapshotDir(t)
+		defer os.RemoveAll(dir)
+		metadataFile := filepath.Join(dir, snapshotMetadataFileName)
+		metadataJSON, err := ioutil.ReadFile(metadataFile)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(metadataFile, append(metadataJSON, ' '), 0644))
+		destProvider, destCleanup := newDestProvider(t)
+		defer destCleanup()
+		_, _, err = destProvider.CreateFromSnapshot(dir)
+		require.Contains(t, err.Error(), "hash mismatch for the snapshot metadata file [_snapshot_signable_metadata.json]")
+	})
+
+	t.Run("tampered data file", func(t *testing.T) {
+		dir := copySnapshotDir(t)
+		defer os.RemoveAll(dir)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "public_state.data"), []byte("junk"), 0644))
+		destProvider, destCleanup := newDestProvider(t)
+		defer destCleanup()
+		_, _, err := destProvider.CreateFromSnapshot(dir)
+		require.Contains(t, err.Error(), "hash mismatch for the snapshot file")
+		require.Contains(t, err.Error(), "public_state.data")
+		exists, err := destProvider.Exists("testLedgerid")
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("import fails", func(t *testing.T) {
+		destProvider, destCleanup := newDestProvider(t)
+		defer destCleanup()
+		destProvider.configHistoryMgr.Close()
+		_, _, err := destProvider.CreateFromSnapshot(snapshotDir)
+		require.Contains(t, err.Error(), "error while importing the snapshot into ledger [testLedgerid]")
+		underConstructionLedger, err := destProvider.idStore.getUnderConstructionFlag()
+		require.NoError(t, err)
+		require.Empty(t, underConstructionLedger)
+	})
+}
+
+func TestRecoverUnderConstructionLedgerFromSnapshot(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	_, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	require.NoError(t, lgr.(*kvLedger).generateSnapshot())
+	snapshotDir := SnapshotDirForLedgerHeight(conf.SnapshotsConfig.RootDir, "testLedgerid", 1)
+
+	destConf, destCleanup := testConfig(t)
+	defer destCleanup()
+	destProvider := testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+
+	// simulate a crash after the data is imported but before the ledger is marked as created
+	metadata, additionalInfo, err := destProvider.loadAndVerifySnapshotMetadata(snapshotDir)
+	require.NoError(t, err)
+	require.NoError(t, destProvider.idStore.setUnderConstructionFlag("testLedgerid"))
+	require.NoError(t, destProvider.idStore.putBootSnapshotInfo("testLedgerid", additionalInfo))
+	require.NoError(t, destProvider.importFromSnapshot(snapshotDir, metadata))
+	exists, err := destProvider.Exists("testLedgerid")
+	require.NoError(t, err)
+	require.False(t, exists)
+	destProvider.Close()
+
+	destProvider = testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer destProvider.Close()
+	exists, err = destProvider.Exists("testLedgerid")
+	require.NoError(t, err)
+	require.True(t, exists)
+	underConstructionLedger, err := destProvider.idStore.getUnderConstructionFlag()
+	require.NoError(t, err)
+	require.Empty(t, underConstructionLedger)
+	destLgr, err := destProvider.Open("testLedgerid")
+	require.NoError(t, err)
+	defer destLgr.Close()
+	bcInfo, err := destLgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), bcInfo.Height)
+}
+
 func verifySnapshotOutput(
 	t *testing.T,
 	snapshotRootDir string,
 	ledgerID string,
 	ledgerHeight uint64,
 	lastBlockHash []byte,
+	previousBlockHash []byte,
 	lastCommitHash []byte,
 	expectedBinaryFiles ...string,
 ) {
@@ -321,10 +574,12 @@ func verifySnapshotOutput(
 	require.NoError(t, json.Unmarshal(mJSON, m))
 	require.Equal(t,
 		&snapshotSignableMetadata{
-			ChannelName:        ledgerID,
-			ChannelHeight:      ledgerHeight,
-			LastBlockHashInHex: hex.EncodeToString(lastBlockHash),
-			FilesAndHashes:     filesAndHashes,
+			ChannelName:            ledgerID,
+			ChannelHeight:          ledgerHeight,
+			LastBlockHashInHex:     hex.EncodeToString(lastBlockHash),
+			PreviousBlockHashInHex: hex.EncodeToString(previousBlockHash),
+			BlockHashingAlgorithm:  bccsp.SHA256,
+			FilesAndHashes:         filesAndHashes,
 		},
 		m,
 	)
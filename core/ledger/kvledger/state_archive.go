@@ -0,0 +1,508 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	stateArchiveFormat        = "fabric-state-archive"
+	stateArchiveFormatVersion = 1
+	// maxStateArchiveLineSize limits the size of a line in a state archive, i.e., of an encoded key-value
+	maxStateArchiveLineSize = 256 * 1024 * 1024
+	// maxPvtStateRestoreBatchSize is the number of the private writes after which the restored private state is applied to the db
+	maxPvtStateRestoreBatchSize = 1000
+)
+
+// A state archive is a stream of JSON lines. The first line contains the header, which is followed by
+// a line for each entry of the public state and of the hashes of the private state. The last line
+// contains the trailer, which includes the number of the entries and the SHA-256 hash of all the
+// preceding lines. The binary values are encoded in base64, as per the JSON encoding of the byte arrays
+type stateArchiveLine struct {
+	Header  *stateArchiveHeader  `json:"header,omitempty"`
+	Record  *stateArchiveRecord  `json:"record,omitempty"`
+	Trailer *stateArchiveTrailer `json:"trailer,omitempty"`
+}
+
+type stateArchiveHeader struct {
+	Format         string   `json:"format"`
+	FormatVersion  int      `json:"format_version"`
+	LedgerID       string   `json:"ledger_id"`
+	StateDatabase  string   `json:"state_database"`
+	SavepointBlock uint64   `json:"savepoint_block_num"`
+	SavepointTx    uint64   `json:"savepoint_tx_num"`
+	Namespaces     []string `json:"namespaces,omitempty"`
+}
+
+type stateArchiveRecord struct {
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection,omitempty"`
+	Key        string `json:"key,omitempty"`
+	KeyHash    []byte `json:"key_hash,omitempty"`
+	Value      []byte `json:"value"`
+	Metadata   []byte `json:"metadata,omitempty"`
+	BlockNum   uint64 `json:"block_num"`
+	TxNum      uint64 `json:"tx_num"`
+}
+
+type stateArchiveTrailer struct {
+	NumRecords uint64 `json:"num_records"`
+	SHA256     string `json:"sha256"`
+}
+
+// ExportState writes the public state and the hashes of the private state of a ledger to the writer, in the
+// format of a state archive. If namespaces are specified, only the state of those namespaces is exported,
+// for instance for analytics, and such an archive cannot be imported. The private state itself is never
+// exported. The number of the exported entries is returned. This function is intended to be invoked on a stopped peer
+func ExportState(config *ledger.Config, ledgerID string, namespaces []string, w io.Writer) (uint64, error) {
+	fileLock, err := lockLedgerFiles(config.RootFSPath)
+	if err != nil {
+		return 0, err
+	}
+	defer fileLock.Unlock()
+
+	if err := checkLedgerActive(config.RootFSPath, ledgerID); err != nil {
+		return 0, err
+	}
+	db, closeDB, err := openStateDBForLedger(config, ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	defer closeDB()
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return 0, err
+	}
+	if savepoint == nil {
+		return 0, errors.Errorf("the state database for the ledger [%s] is empty", ledgerID)
+	}
+
+	var includeNamespace func(string) bool
+	if len(namespaces) > 0 {
+		included := map[string]bool{}
+		for _, ns := range namespaces {
+			included[ns] = true
+		}
+		includeNamespace = func(ns string) bool { return included[ns] }
+	}
+
+	writer := newStateArchiveWriter(w)
+	err = writer.writeLine(&stateArchiveLine{
+		Header: &stateArchiveHeader{
+			Format:         stateArchiveFormat,
+			FormatVersion:  stateArchiveFormatVersion,
+			LedgerID:       ledgerID,
+			StateDatabase:  stateDatabase(config),
+			SavepointBlock: savepoint.BlockNum,
+			SavepointTx:    savepoint.TxNum,
+			Namespaces:     namespaces,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var numRecords uint64
+	err = db.ExportPubAndHashedState(includeNamespace, func(r *privacyenabledstate.StateRecord) error {
+		if !utf8.ValidString(r.Key) {
+			return errors.Errorf("the key [%x] in the namespace [%s] is not a valid UTF-8 string and cannot be exported", r.Key, r.Namespace)
+		}
+		numRecords++
+		return writer.writeLine(&stateArchiveLine{
+			Record: &stateArchiveRecord{
+				Namespace:  r.Namespace,
+				Collection: r.Collection,
+				Key:        r.Key,
+				KeyHash:    r.KeyHash,
+				Value:      r.Value,
+				Metadata:   r.Metadata,
+				BlockNum:   r.Version.BlockNum,
+				TxNum:      r.Version.TxNum,
+			},
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := writer.writeTrailer(numRecords); err != nil {
+		return 0, err
+	}
+	logger.Infof("Exported [%d] entries of the state of the ledger [%s] at the savepoint [%s]", numRecords, ledgerID, savepoint)
+	return numRecords, nil
+}
+
+// ImportState populates the state database of a ledger, of the type configured for the peer, from a state
+// archive produced by the function ExportState. The state database of the ledger is required to be empty,
+// e.g., after the command `peer node rebuild-dbs`. The blocks committed after the savepoint of the archive
+// are applied to the state by the peer when it is started next. The number of the imported entries is returned.
+// This function is intended to be invoked on a stopped peer
+func ImportState(config *ledger.Config, ledgerID string, r io.Reader) (uint64, error) {
+	fileLock, err := lockLedgerFiles(config.RootFSPath)
+	if err != nil {
+		return 0, err
+	}
+	defer fileLock.Unlock()
+
+	if err := checkLedgerActive(config.RootFSPath, ledgerID); err != nil {
+		return 0, err
+	}
+	reader := newStateArchiveReader(r)
+	header, err := reader.readHeader()
+	if err != nil {
+		return 0, err
+	}
+	if header.LedgerID != ledgerID {
+		return 0, errors.Errorf("the state archive is for the ledger [%s], not for the ledger [%s]", header.LedgerID, ledgerID)
+	}
+	if len(header.Namespaces) > 0 {
+		return 0, errors.Errorf("the state archive contains only the namespaces %v and cannot be imported", header.Namespaces)
+	}
+	blockchainHeight, err := blockchainHeight(config, ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	if header.SavepointBlock >= blockchainHeight {
+		return 0, errors.Errorf("the savepoint block [%d] of the state archive is not in the block store of the ledger [%s], which has the height [%d]",
+			header.SavepointBlock, ledgerID, blockchainHeight)
+	}
+
+	db, closeDB, err := openStateDBForLedger(config, ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	defer closeDB()
+	if err := db.ImportPubAndHashedState(reader.nextRecord); err != nil {
+		return 0, errors.WithMessagef(err, "error while importing the state archive into the state database for the ledger [%s]", ledgerID)
+	}
+	savepoint := version.NewHeight(header.SavepointBlock, header.SavepointTx)
+	if err := restorePvtState(config, ledgerID, db, savepoint); err != nil {
+		return 0, errors.WithMessagef(err, "error while restoring the private state for the ledger [%s]", ledgerID)
+	}
+	logger.Infof("Imported [%d] entries into the state of the ledger [%s] at the savepoint [%d:%d]",
+		reader.numRecords, ledgerID, header.SavepointBlock, header.SavepointTx)
+	return reader.numRecords, nil
+}
+
+func lockLedgerFiles(rootFSPath string) (*leveldbhelper.FileLock, error) {
+	fileLock := leveldbhelper.NewFileLock(fileLockPath(rootFSPath))
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	return fileLock, nil
+}
+
+func checkLedgerActive(rootFSPath, ledgerID string) error {
+	idStore, err := openIDStore(LedgerProviderPath(rootFSPath))
+	if err != nil {
+		return err
+	}
+	defer idStore.close()
+	active, exists, err := idStore.ledgerIDActive(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("ledger [%s] does not exist", ledgerID)
+	}
+	if !active {
+		return errors.Errorf("ledger [%s] is not active", ledgerID)
+	}
+	return nil
+}
+
+func openStateDBForLedger(config *ledger.Config, ledgerID string) (*privacyenabledstate.DB, func(), error) {
+	bookkeepingProvider, err := bookkeeping.NewProvider(BookkeeperDBPath(config.RootFSPath))
+	if err != nil {
+		return nil, nil, err
+	}
+	dbProvider, err := privacyenabledstate.NewDBProvider(
+		bookkeepingProvider,
+		&disabled.Provider{},
+		nil,
+		&privacyenabledstate.StateDBConfig{
+			StateDBConfig: config.StateDBConfig,
+			LevelDBPath:   StateDBPath(config.RootFSPath),
+		},
+		nil,
+	)
+	if err != nil {
+		bookkeepingProvider.Close()
+		return nil, nil, err
+	}
+	closeFunc := func() {
+		dbProvider.Close()
+		bookkeepingProvider.Close()
+	}
+	db, err := dbProvider.GetDBHandle(ledgerID, nil)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+	return db, closeFunc, nil
+}
+
+// restorePvtState restores the private state, which is not included in a state archive, from the private data
+// store and records the savepoint. The private writes of the transactions up to the savepoint are scanned and
+// a private value is restored if its hash matches the hash of the value in the imported state. The private data
+// that is missing from the private data store, e.g., because it has been purged, is not restored
+func restorePvtState(config *ledger.Config, ledgerID string, db *privacyenabledstate.DB, savepoint *version.Height) error {
+	pvtdataStore, closePvtdataStore, err := openPvtdataStoreForLedger(config, ledgerID)
+	if err != nil {
+		return err
+	}
+	defer closePvtdataStore()
+	pvtdataStoreHeight, err := pvtdataStore.LastCommittedBlockHeight()
+	if err != nil {
+		return err
+	}
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batchSize := 0
+	numRestored := 0
+	for blockNum := uint64(0); blockNum <= savepoint.BlockNum && blockNum < pvtdataStoreHeight; blockNum++ {
+		pvtdata, err := pvtdataStore.GetPvtDataByBlockNum(blockNum, nil)
+		if err != nil {
+			return err
+		}
+		for _, txPvtdata := range pvtdata {
+			if blockNum == savepoint.BlockNum && txPvtdata.SeqInBlock > savepoint.TxNum {
+				break
+			}
+			txPvtRWSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(txPvtdata.WriteSet)
+			if err != nil {
+				return err
+			}
+			for _, nsPvtRWSet := range txPvtRWSet.NsPvtRwSet {
+				for _, collPvtRWSet := range nsPvtRWSet.CollPvtRwSets {
+					for _, write := range collPvtRWSet.KvRwSet.Writes {
+						if write.IsDelete {
+							continue
+						}
+						ns, coll := nsPvtRWSet.NameSpace, collPvtRWSet.CollectionName
+						vv, err := db.GetValueHash(ns, coll, util.ComputeStringHash(write.Key))
+						if err != nil {
+							return err
+						}
+						if vv == nil || !bytes.Equal(vv.Value, util.ComputeHash(write.Value)) {
+							continue
+						}
+						batch.PvtUpdates.PutValAndMetadata(ns, coll, write.Key, write.Value, vv.Metadata, vv.Version)
+						batchSize++
+						numRestored++
+					}
+				}
+			}
+		}
+		if batchSize >= maxPvtStateRestoreBatchSize {
+			if err := db.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+				return err
+			}
+			batch = privacyenabledstate.NewUpdateBatch()
+			batchSize = 0
+		}
+	}
+	logger.Infof("Restored [%d] private writes into the state of the ledger [%s]", numRestored, ledgerID)
+	return db.ApplyPrivacyAwareUpdates(batch, savepoint)
+}
+
+func openPvtdataStoreForLedger(config *ledger.Config, ledgerID string) (*pvtdatastorage.Store, func(), error) {
+	provider, err := pvtdatastorage.NewProvider(
+		&pvtdatastorage.PrivateDataConfig{
+			PrivateDataConfig: config.PrivateDataConfig,
+			StorePath:         PvtDataStorePath(config.RootFSPath),
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := provider.OpenStore(ledgerID)
+	if err != nil {
+		provider.Close()
+		return nil, nil, err
+	}
+	// the private data is retrieved irrespective of the block-to-live of the collections, which is not
+	// known to the private data store on a stopped peer. The expired private data is retrieved only if not yet purged
+	store.Init(&noExpiryBTLPolicy{})
+	return store, provider.Close, nil
+}
+
+func blockchainHeight(config *ledger.Config, ledgerID string) (uint64, error) {
+	blkStoreConf, err := blockStoreConf(config)
+	if err != nil {
+		return 0, err
+	}
+	provider, err := blkstorage.NewProvider(blkStoreConf, blockStoreIndexConfig(config), &disabled.Provider{})
+	if err != nil {
+		return 0, err
+	}
+	defer provider.Close()
+	blockStore, err := provider.Open(ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	info, err := blockStore.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.Height, nil
+}
+
+func stateDatabase(config *ledger.Config) string {
+	if config.StateDBConfig == nil || config.StateDBConfig.StateDatabase == "" {
+		return "goleveldb"
+	}
+	return config.StateDBConfig.StateDatabase
+}
+
+type stateArchiveWriter struct {
+	w    *bufio.Writer
+	hash hash.Hash
+}
+
+func newStateArchiveWriter(w io.Writer) *stateArchiveWriter {
+	return &stateArchiveWriter{w: bufio.NewWriter(w), hash: sha256.New()}
+}
+
+func (w *stateArchiveWriter) writeLine(line *stateArchiveLine) error {
+	b, err := json.Marshal(line)
+	if err != nil {
+		return errors.Wrap(err, "error while marshalling a line of the state archive")
+	}
+	b = append(b, '\n')
+	w.hash.Write(b)
+	if _, err := w.w.Write(b); err != nil {
+		return errors.Wrap(err, "error while writing the state archive")
+	}
+	return nil
+}
+
+func (w *stateArchiveWriter) writeTrailer(numRecords uint64) error {
+	err := w.writeLine(&stateArchiveLine{
+		Trailer: &stateArchiveTrailer{
+			NumRecords: numRecords,
+			SHA256:     hex.EncodeToString(w.hash.Sum(nil)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(w.w.Flush(), "error while writing the state archive")
+}
+
+type stateArchiveReader struct {
+	scanner    *bufio.Scanner
+	hash       hash.Hash
+	lineNum    int
+	numRecords uint64
+	done       bool
+}
+
+func newStateArchiveReader(r io.Reader) *stateArchiveReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxStateArchiveLineSize)
+	return &stateArchiveReader{scanner: scanner, hash: sha256.New()}
+}
+
+func (r *stateArchiveReader) readLine() (*stateArchiveLine, []byte, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return nil, nil, errors.Wrap(err, "error while reading the state archive")
+		}
+		return nil, nil, errors.New("unexpected end of the state archive, the trailer is missing")
+	}
+	r.lineNum++
+	b := r.scanner.Bytes()
+	line := &stateArchiveLine{}
+	if err := json.Unmarshal(b, line); err != nil {
+		return nil, nil, errors.Wrapf(err, "error while unmarshalling the line [%d] of the state archive", r.lineNum)
+	}
+	return line, b, nil
+}
+
+func (r *stateArchiveReader) readHeader() (*stateArchiveHeader, error) {
+	line, b, err := r.readLine()
+	if err != nil {
+		return nil, err
+	}
+	header := line.Header
+	if header == nil || header.Format != stateArchiveFormat {
+		return nil, errors.New("the input is not a state archive, the header is missing")
+	}
+	if header.FormatVersion != stateArchiveFormatVersion {
+		return nil, errors.Errorf("unsupported version [%d] of the state archive format, the supported version is [%d]",
+			header.FormatVersion, stateArchiveFormatVersion)
+	}
+	r.hash.Write(b)
+	r.hash.Write([]byte{'\n'})
+	return header, nil
+}
+
+// nextRecord returns the next record in the archive, or nil after the trailer is read and verified
+func (r *stateArchiveReader) nextRecord() (*privacyenabledstate.StateRecord, error) {
+	if r.done {
+		return nil, nil
+	}
+	line, b, err := r.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if trailer := line.Trailer; trailer != nil {
+		r.done = true
+		if trailer.NumRecords != r.numRecords {
+			return nil, errors.Errorf("the state archive is corrupted, the trailer specifies [%d] records while [%d] records are found",
+				trailer.NumRecords, r.numRecords)
+		}
+		if checksum := hex.EncodeToString(r.hash.Sum(nil)); trailer.SHA256 != checksum {
+			return nil, errors.Errorf("the state archive is corrupted, the checksum [%s] does not match the checksum [%s] in the trailer",
+				checksum, trailer.SHA256)
+		}
+		if r.scanner.Scan() || r.scanner.Err() != nil {
+			return nil, errors.New("the state archive is corrupted, unexpected data after the trailer")
+		}
+		return nil, nil
+	}
+	record := line.Record
+	if record == nil || record.Namespace == "" || (record.Collection != "" && len(record.KeyHash) == 0) {
+		return nil, errors.Errorf("the state archive is corrupted, invalid record at the line [%d]", r.lineNum)
+	}
+	r.hash.Write(b)
+	r.hash.Write([]byte{'\n'})
+	r.numRecords++
+	value := record.Value
+	if value == nil {
+		value = []byte{}
+	}
+	return &privacyenabledstate.StateRecord{
+		Namespace:  record.Namespace,
+		Collection: record.Collection,
+		Key:        record.Key,
+		KeyHash:    record.KeyHash,
+		Value:      value,
+		Metadata:   record.Metadata,
+		Version:    version.NewHeight(record.BlockNum, record.TxNum),
+	}, nil
+}
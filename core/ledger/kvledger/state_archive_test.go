@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportState(t *testing.T) {
+	collConfigs := []*nsCollBtlConfig{
+		{
+			namespace: "ns",
+			btlConfig: map[string]uint64{"coll": 0},
+		},
+	}
+	setup := func(t *testing.T) (*lgr.Config, *testutil.BlockGenerator, func()) {
+		conf, cleanup := testConfig(t)
+		provider := testutilNewProviderWithCollectionConfig(t, collConfigs, conf)
+		defer provider.Close()
+
+		bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+		l, err := provider.Create(gb)
+		require.NoError(t, err)
+		defer l.Close()
+
+		blkAndPvtdata1 := prepareNextBlockForTest(t, l, bg, "SimulateForBlk1",
+			map[string]string{"key1": "value1.1", "key2": "value2.1"},
+			map[string]string{"key1": "pvtValue1.1"},
+		)
+		require.NoError(t, l.CommitLegacy(blkAndPvtdata1, &lgr.CommitOptions{}))
+		blkAndPvtdata2 := prepareNextBlockForTest(t, l, bg, "SimulateForBlk2",
+			map[string]string{"key1": "value1.2"},
+			map[string]string{"key1": "pvtValue1.2", "key2": "pvtValue2.2"},
+		)
+		require.NoError(t, l.CommitLegacy(blkAndPvtdata2, &lgr.CommitOptions{}))
+		return conf, bg, cleanup
+	}
+
+	export := func(t *testing.T, conf *lgr.Config, namespaces []string) []byte {
+		buffer := &bytes.Buffer{}
+		_, err := ExportState(conf, "testLedger", namespaces, buffer)
+		require.NoError(t, err)
+		return buffer.Bytes()
+	}
+
+	t.Run("export", func(t *testing.T) {
+		conf, _, cleanup := setup(t)
+		defer cleanup()
+
+		archive := export(t, conf, nil)
+		var lines []*stateArchiveLine
+		scanner := bufio.NewScanner(bytes.NewReader(archive))
+		for scanner.Scan() {
+			line := &stateArchiveLine{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), line))
+			lines = append(lines, line)
+		}
+		require.Equal(t, &stateArchiveHeader{
+			Format:         "fabric-state-archive",
+			FormatVersion:  1,
+			LedgerID:       "testLedger",
+			StateDatabase:  "goleveldb",
+			SavepointBlock: 2,
+			SavepointTx:    0,
+		}, lines[0].Header)
+
+		pubRecords := map[string]*stateArchiveRecord{}
+		numHashedRecords := 0
+		for _, line := range lines[1 : len(lines)-1] {
+			require.NotNil(t, line.Record)
+			switch {
+			case line.Record.Collection != "":
+				require.Equal(t, "ns", line.Record.Namespace)
+				require.Equal(t, "coll", line.Record.Collection)
+				require.Len(t, line.Record.KeyHash, 32)
+				numHashedRecords++
+			case line.Record.Namespace == "ns":
+				pubRecords[line.Record.Key] = line.Record
+			}
+		}
+		require.Equal(t, &stateArchiveRecord{Namespace: "ns", Key: "key1", Value: []byte("value1.2"), BlockNum: 2}, pubRecords["key1"])
+		require.Equal(t, &stateArchiveRecord{Namespace: "ns", Key: "key2", Value: []byte("value2.1"), BlockNum: 1}, pubRecords["key2"])
+		require.Equal(t, 2, numHashedRecords)
+		require.Equal(t, uint64(len(lines)-2), lines[len(lines)-1].Trailer.NumRecords)
+		require.NotContains(t, string(archive), "pvtValue")
+
+		// only the specified namespaces are exported
+		archive = export(t, conf, []string{"ns"})
+		require.Equal(t, len(lines), strings.Count(string(archive), "\n"))
+		archive = export(t, conf, []string{"non-existent-ns"})
+		require.Equal(t, 2, strings.Count(string(archive), "\n"))
+	})
+
+	t.Run("import-into-another-state-database-type", func(t *testing.T) {
+		conf, bg, cleanup := setup(t)
+		defer cleanup()
+		archive := export(t, conf, nil)
+
+		// a block is committed after the export
+		provider := testutilNewProviderWithCollectionConfig(t, collConfigs, conf)
+		l, err := provider.Open("testLedger")
+		require.NoError(t, err)
+		blkAndPvtdata3 := prepareNextBlockForTest(t, l, bg, "SimulateForBlk3",
+			map[string]string{"key2": "value2.3"},
+			map[string]string{"key2": "pvtValue2.3"},
+		)
+		require.NoError(t, l.CommitLegacy(blkAndPvtdata3, &lgr.CommitOptions{}))
+		l.Close()
+		provider.Close()
+
+		require.NoError(t, dropStateLevelDB(conf.RootFSPath))
+		conf.StateDBConfig.StateDatabase = "JSONDB"
+		numRecords, err := ImportState(conf, "testLedger", bytes.NewReader(archive))
+		require.NoError(t, err)
+		require.Equal(t, uint64(strings.Count(string(archive), "\n")-2), numRecords)
+
+		// the block committed after the export is applied when the ledger is opened. As for
+		// CouchDB, the JSONDB based statedb registers for the chaincode lifecycle events
+		cceventmgmt.Initialize(nil)
+		provider = testutilNewProviderWithCollectionConfig(t, collConfigs, conf)
+		provider.initializer.ChaincodeLifecycleEventProvider = &mock.ChaincodeLifecycleEventProvider{}
+		defer provider.Close()
+		l, err = provider.Open("testLedger")
+		require.NoError(t, err)
+		defer l.Close()
+		qe, err := l.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		for key, expectedValue := range map[string]string{"key1": "value1.2", "key2": "value2.3"} {
+			value, err := qe.GetState("ns", key)
+			require.NoError(t, err)
+			require.Equal(t, expectedValue, string(value))
+		}
+		for key, expectedValue := range map[string]string{"key1": "pvtValue1.2", "key2": "pvtValue2.3"} {
+			value, err := qe.GetPrivateData("ns", "coll", key)
+			require.NoError(t, err)
+			require.Equal(t, expectedValue, string(value))
+		}
+
+		// the import requires an empty state database
+		l.Close()
+		provider.Close()
+		_, err = ImportState(conf, "testLedger", bytes.NewReader(archive))
+		require.EqualError(t, err, "error while importing the state archive into the state database for the ledger [testLedger]: the state database is not empty, import is supported only on an empty state database")
+	})
+
+	t.Run("import-errors", func(t *testing.T) {
+		conf, _, cleanup := setup(t)
+		defer cleanup()
+		archive := string(export(t, conf, nil))
+		nsArchive := string(export(t, conf, []string{"ns"}))
+		require.NoError(t, dropStateLevelDB(conf.RootFSPath))
+
+		_, err := ImportState(conf, "non-existent-ledger", strings.NewReader(archive))
+		require.EqualError(t, err, "ledger [non-existent-ledger] does not exist")
+
+		_, err = ImportState(conf, "testLedger", strings.NewReader("not a state archive\n"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while unmarshalling the line [1] of the state archive")
+
+		_, err = ImportState(conf, "testLedger", strings.NewReader(`{"header":{"format":"fabric-state-archive","format_version":2}}`))
+		require.EqualError(t, err, "unsupported version [2] of the state archive format, the supported version is [1]")
+
+		_, err = ImportState(conf, "testLedger", strings.NewReader(strings.Replace(archive, `"ledger_id":"testLedger"`, `"ledger_id":"anotherLedger"`, 1)))
+		require.EqualError(t, err, "the state archive is for the ledger [anotherLedger], not for the ledger [testLedger]")
+
+		_, err = ImportState(conf, "testLedger", strings.NewReader(nsArchive))
+		require.EqualError(t, err, "the state archive contains only the namespaces [ns] and cannot be imported")
+
+		_, err = ImportState(conf, "testLedger", strings.NewReader(strings.Replace(archive, `"savepoint_block_num":2`, `"savepoint_block_num":3`, 1)))
+		require.EqualError(t, err, "the savepoint block [3] of the state archive is not in the block store of the ledger [testLedger], which has the height [3]")
+
+		tamperedArchive := strings.Replace(archive, `"block_num":1`, `"block_num":2`, 1)
+		_, err = ImportState(conf, "testLedger", strings.NewReader(tamperedArchive))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the state archive is corrupted, the checksum")
+
+		truncatedArchive := archive[:strings.LastIndex(archive[:len(archive)-1], "\n")+1]
+		require.NoError(t, dropStateLevelDB(conf.RootFSPath))
+		_, err = ImportState(conf, "testLedger", strings.NewReader(truncatedArchive))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected end of the state archive, the trailer is missing")
+	})
+
+	t.Run("another-command-is-executing", func(t *testing.T) {
+		conf, _, cleanup := setup(t)
+		defer cleanup()
+
+		provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+		defer provider.Close()
+		_, err := ExportState(conf, "testLedger", nil, &bytes.Buffer{})
+		require.EqualError(t, err, "as another peer node command is executing, wait for that command to complete its execution or terminate it before retrying: lock is already acquired on file "+fileLockPath(conf.RootFSPath))
+	})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/pkg/errors"
+)
+
+// maxImportBatchSize is the number of records that are applied to the db in a single batch during import
+var maxImportBatchSize = 1000
+
+// StateRecord is an entry of the public state or of the hashes of the private state, in a form that
+// is independent of the type of the underlying db. For an entry of the hashes of the private state, the
+// Collection and the KeyHash are set and the Value is the hash of the private value
+type StateRecord struct {
+	Namespace  string
+	Collection string
+	Key        string
+	KeyHash    []byte
+	Value      []byte
+	Metadata   []byte
+	Version    *version.Height
+}
+
+// ExportPubAndHashedState invokes the function `emit` for each entry of the public state and of the hashes
+// of the private state, in the order of the namespaces. If the function `includeNamespace` is not nil, only the
+// entries of the namespaces for which it returns true are exported. The private state itself is never exported
+func (s *DB) ExportPubAndHashedState(includeNamespace func(string) bool, emit func(*StateRecord) error) error {
+	itr, _, err := s.GetFullScanIterator(
+		func(namespace string) bool {
+			if isPvtdataNs(namespace) {
+				return true
+			}
+			return includeNamespace != nil && !includeNamespace(strings.SplitN(namespace, nsJoiner, 2)[0])
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	for {
+		ck, _, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if ck == nil {
+			return nil
+		}
+		// the value is read through the db so that its encoding, which is specific to the db type, is decoded
+		vv, err := s.GetState(ck.Namespace, ck.Key)
+		if err != nil {
+			return err
+		}
+		if vv == nil {
+			continue
+		}
+		record := &StateRecord{
+			Namespace: ck.Namespace,
+			Key:       ck.Key,
+			Value:     vv.Value,
+			Metadata:  vv.Metadata,
+			Version:   vv.Version,
+		}
+		if isHashedDataNs(ck.Namespace) {
+			nsColl := strings.SplitN(ck.Namespace, nsJoiner+hashDataPrefix, 2)
+			keyHash := []byte(ck.Key)
+			if !s.BytesKeySupported() {
+				if keyHash, err = base64.StdEncoding.DecodeString(ck.Key); err != nil {
+					return errors.Wrapf(err, "error while decoding the key hash [%s]", ck.Key)
+				}
+			}
+			record.Namespace, record.Collection, record.Key, record.KeyHash = nsColl[0], nsColl[1], "", keyHash
+		}
+		if err := emit(record); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportPubAndHashedState populates an empty db with the records returned by the function `nextRecord`,
+// until it returns a nil record. The savepoint is not recorded and the caller is expected to record it once
+// the import is complete, so that a failed import leaves the db without a savepoint and the import can be
+// retried after the db is dropped
+func (s *DB) ImportPubAndHashedState(nextRecord func() (*StateRecord, error)) error {
+	existingSavepoint, err := s.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.New("the state database is not empty, import is supported only on an empty state database")
+	}
+
+	batch := NewUpdateBatch()
+	batchSize := 0
+	for {
+		record, err := nextRecord()
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			break
+		}
+		if record.Version == nil {
+			return errors.Errorf("missing version for the key [%s] in the namespace [%s]", record.Key, record.Namespace)
+		}
+		if record.Collection == "" {
+			batch.PubUpdates.PutValAndMetadata(record.Namespace, record.Key, record.Value, record.Metadata, record.Version)
+		} else {
+			batch.HashUpdates.PutValHashAndMetadata(record.Namespace, record.Collection, record.KeyHash, record.Value, record.Metadata, record.Version)
+		}
+		batchSize++
+		if batchSize >= maxImportBatchSize {
+			if err := s.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+				return err
+			}
+			batch = NewUpdateBatch()
+			batchSize = 0
+		}
+	}
+	return s.ApplyPrivacyAwareUpdates(batch, nil)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statejsondb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/pkg/errors"
@@ -28,17 +29,19 @@ import (
 var logger = flogging.MustGetLogger("privacyenabledstate")
 
 const (
-	nsJoiner       = "$$"
-	pvtDataPrefix  = "p"
-	hashDataPrefix = "h"
-	couchDB        = "CouchDB"
+	nsJoiner           = "$$"
+	pvtDataPrefix      = "p"
+	hashDataPrefix     = "h"
+	keyHashIndexPrefix = "k"
+	couchDB            = "CouchDB"
+	jsonDB             = "JSONDB"
 )
 
 // StateDBConfig encapsulates the configuration for stateDB on the ledger.
 type StateDBConfig struct {
 	// ledger.StateDBConfig is used to configure the stateDB for the ledger.
 	*ledger.StateDBConfig
-	// LevelDBPath is the filesystem path when statedb type is "goleveldb".
+	// LevelDBPath is the filesystem path when statedb type is "goleveldb" or "JSONDB".
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.StateDBConfig and not exposed to other components.
 	LevelDBPath string
@@ -68,6 +71,10 @@ func NewDBProvider(
 		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(stateDBConf.CouchDB, metricsProvider, sysNamespaces); err != nil {
 			return nil, err
 		}
+	} else if stateDBConf != nil && stateDBConf.StateDatabase == jsonDB {
+		if vdbProvider, err = statejsondb.NewVersionedDBProvider(stateDBConf.LevelDBPath); err != nil {
+			return nil, err
+		}
 	} else {
 		if vdbProvider, err = stateleveldb.NewVersionedDBProvider(stateDBConf.LevelDBPath); err != nil {
 			return nil, err
@@ -186,6 +193,20 @@ func (s *DB) GetPrivateData(namespace, collection, key string) (*statedb.Version
 	return s.GetState(derivePvtDataNs(namespace, collection), key)
 }
 
+// GetPrivateDataKey gets the key of a private data item identified by a tuple <namespace, collection, keyHash>.
+// An empty key is returned if the private data item is not present
+func (s *DB) GetPrivateDataKey(namespace, collection string, keyHash []byte) (string, error) {
+	keyHashStr := string(keyHash)
+	if !s.BytesKeySupported() {
+		keyHashStr = base64.StdEncoding.EncodeToString(keyHash)
+	}
+	vv, err := s.GetState(deriveKeyHashIndexNs(namespace, collection), keyHashStr)
+	if err != nil || vv == nil {
+		return "", err
+	}
+	return string(vv.Value), nil
+}
+
 // GetPrivateDataHash gets the hash of the value of a private data item identified by a tuple <namespace, collection, key>
 func (s *DB) GetPrivateDataHash(namespace, collection, key string) (*statedb.VersionedValue, error) {
 	return s.GetValueHash(namespace, collection, util.ComputeStringHash(key))
@@ -249,7 +270,7 @@ func (s *DB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) er
 func (s *DB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error {
 	// combinedUpdates includes both updates to public db and private db, which are partitioned by a separate namespace
 	combinedUpdates := updates.PubUpdates
-	addPvtUpdates(combinedUpdates, updates.PvtUpdates)
+	addPvtUpdates(combinedUpdates, updates.PvtUpdates, !s.BytesKeySupported())
 	addHashedUpdates(combinedUpdates, updates.HashUpdates, !s.BytesKeySupported())
 	s.metadataHint.setMetadataUsedFlag(updates)
 	return s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height)
@@ -354,19 +375,40 @@ func deriveHashedDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + hashDataPrefix + collection
 }
 
+// deriveKeyHashIndexNs returns the namespace of the index that maps the hashes of the keys of the private data
+// items in a collection to the keys, which allows the purge of a private data item to be applied while the private
+// write-set of the purging transaction, and hence the key, is missing. The index is populated as the private data
+// items are written, so the state db of a peer that holds the private data written by a prior release must be
+// rebuilt, as with `peer node upgrade-dbs`, for the purges of that private data to be applied in such a case
+func deriveKeyHashIndexNs(namespace, collection string) string {
+	return namespace + nsJoiner + keyHashIndexPrefix + collection
+}
+
+// isPvtdataNs returns true for the namespaces of the private data, including the index of the keys of the
+// private data, as both are confidential and never leave the peer
 func isPvtdataNs(namespace string) bool {
-	return strings.Contains(namespace, nsJoiner+pvtDataPrefix)
+	return strings.Contains(namespace, nsJoiner+pvtDataPrefix) || strings.Contains(namespace, nsJoiner+keyHashIndexPrefix)
 }
 
 func isHashedDataNs(namespace string) bool {
 	return strings.Contains(namespace, nsJoiner+hashDataPrefix)
 }
 
-func addPvtUpdates(pubUpdateBatch *PubUpdateBatch, pvtUpdateBatch *PvtUpdateBatch) {
+func addPvtUpdates(pubUpdateBatch *PubUpdateBatch, pvtUpdateBatch *PvtUpdateBatch, base64Key bool) {
 	for ns, nsBatch := range pvtUpdateBatch.UpdateMap {
 		for _, coll := range nsBatch.GetCollectionNames() {
 			for key, vv := range nsBatch.GetUpdates(coll) {
 				pubUpdateBatch.Update(derivePvtDataNs(ns, coll), key, vv)
+
+				keyHash := string(util.ComputeStringHash(key))
+				if base64Key {
+					keyHash = base64.StdEncoding.EncodeToString([]byte(keyHash))
+				}
+				if vv.Value == nil {
+					pubUpdateBatch.Delete(deriveKeyHashIndexNs(ns, coll), keyHash, vv.Version)
+				} else {
+					pubUpdateBatch.Put(deriveKeyHashIndexNs(ns, coll), keyHash, []byte(key), vv.Version)
+				}
 			}
 		}
 	}
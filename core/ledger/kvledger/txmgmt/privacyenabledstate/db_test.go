@@ -251,6 +251,60 @@ func testGetStateRangeScanIterator(t *testing.T, env TestEnv) {
 	testItr(t, pvtItr4, []string{"key5", "key6"})
 }
 
+func TestGetPrivateDataKey(t *testing.T) {
+	for _, env := range testEnvs {
+		t.Run(env.GetName(), func(t *testing.T) {
+			testGetPrivateDataKey(t, env)
+		})
+	}
+}
+
+func testGetPrivateDataKey(t *testing.T, env TestEnv) {
+	env.Init(t)
+	defer env.Cleanup()
+	db := env.GetDBHandle(generateLedgerID(t))
+
+	updates := NewUpdateBatch()
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key2", []byte("pvt_value2"), version.NewHeight(1, 2))
+	putPvtUpdates(t, updates, "ns1", "coll2", "key3", []byte("pvt_value3"), version.NewHeight(1, 3))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 3)))
+
+	key, err := db.GetPrivateDataKey("ns1", "coll1", util.ComputeStringHash("key1"))
+	require.NoError(t, err)
+	require.Equal(t, "key1", key)
+	key, err = db.GetPrivateDataKey("ns1", "coll2", util.ComputeStringHash("key3"))
+	require.NoError(t, err)
+	require.Equal(t, "key3", key)
+	key, err = db.GetPrivateDataKey("ns1", "coll1", util.ComputeStringHash("key3"))
+	require.NoError(t, err)
+	require.Empty(t, key)
+
+	// the index entry is removed along with the private data item
+	updates = NewUpdateBatch()
+	deletePvtUpdates(t, updates, "ns1", "coll1", "key1", version.NewHeight(2, 1))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 1)))
+	key, err = db.GetPrivateDataKey("ns1", "coll1", util.ComputeStringHash("key1"))
+	require.NoError(t, err)
+	require.Empty(t, key)
+	key, err = db.GetPrivateDataKey("ns1", "coll1", util.ComputeStringHash("key2"))
+	require.NoError(t, err)
+	require.Equal(t, "key2", key)
+
+	// the index is private data, hence it is skipped by the scans of the public state and the private state hashes
+	itr, _, err := db.GetFullScanIterator(isPvtdataNs)
+	require.NoError(t, err)
+	defer itr.Close()
+	for {
+		ck, _, err := itr.Next()
+		require.NoError(t, err)
+		if ck == nil {
+			break
+		}
+		require.True(t, isHashedDataNs(ck.Namespace), "unexpected namespace %s", ck.Namespace)
+	}
+}
+
 func TestQueryOnCouchDB(t *testing.T) {
 	for _, env := range testEnvs {
 		_, ok := env.(*CouchDBTestEnv)
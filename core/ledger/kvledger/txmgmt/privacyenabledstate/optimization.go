@@ -45,6 +45,18 @@ func (h *metadataHint) setMetadataUsedFlag(updates *UpdateBatch) {
 	h.bookkeeper.WriteBatch(batch, true)
 }
 
+func (h *metadataHint) setMetadataUsedFlagForNamespaces(namespaces []string) error {
+	batch := h.bookkeeper.NewUpdateBatch()
+	for _, ns := range namespaces {
+		if h.cache[ns] {
+			continue
+		}
+		h.cache[ns] = true
+		batch.Put([]byte(ns), []byte{})
+	}
+	return h.bookkeeper.WriteBatch(batch, true)
+}
+
 func filterNamespacesThatHasMetadata(updates *UpdateBatch) map[string]bool {
 	namespaces := map[string]bool{}
 	pubUpdates, hashUpdates := updates.PubUpdates, updates.HashUpdates
@@ -8,10 +8,15 @@ package privacyenabledstate
 
 import (
 	"hash"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -193,3 +198,239 @@ func (w *snapshotWriter) close() {
 	w.dataFile.Close()
 	w.metadataFile.Close()
 }
+
+// ImportFromSnapshot imports the public state and the private state hashes from the snapshot files, generated by the
+// function `ExportPubStateAndPvtStateHashes`, present in the snapshotDir into the statedb for the ledger and sets the
+// savepoint of the statedb to the given height. The statedb for the ledger is expected to be empty.
+func (p *DBProvider) ImportFromSnapshot(id string, savepoint *version.Height, snapshotDir string) error {
+	worldStateReader, err := newWorldStateSnapshotReader(snapshotDir)
+	if err != nil {
+		return err
+	}
+	defer worldStateReader.Close()
+
+	dbValueFormat := worldStateReader.dbValueFormat
+	if worldStateReader.isEmpty() {
+		// the snapshot contains no state, the native format of the statedb is used for importing the empty state
+		if dbValueFormat, err = p.nativeDBValueFormat(id); err != nil {
+			return err
+		}
+	}
+	if err := p.VersionedDBProvider.ImportFromSnapshot(id, savepoint, worldStateReader, dbValueFormat); err != nil {
+		return errors.WithMessagef(err, "error while importing the state for ledger [%s] from the snapshot", id)
+	}
+
+	// the metadata is not decoded during the import and hence the metadata hint is set conservatively
+	// for all the imported namespaces
+	metadataHint, err := newMetadataHint(p.bookkeepingProvider.GetDBHandle(id, bookkeeping.MetadataPresenceIndicator))
+	if err != nil {
+		return err
+	}
+	return metadataHint.setMetadataUsedFlagForNamespaces(worldStateReader.chaincodeNamespaces())
+}
+
+func (p *DBProvider) nativeDBValueFormat(id string) (byte, error) {
+	vdb, err := p.VersionedDBProvider.GetDBHandle(id, nil)
+	if err != nil {
+		return 0, err
+	}
+	itr, dbValueFormat, err := vdb.GetFullScanIterator(func(string) bool { return true })
+	if err != nil {
+		return 0, err
+	}
+	if itr != nil {
+		itr.Close()
+	}
+	return dbValueFormat, nil
+}
+
+// worldStateSnapshotReader implements the interface statedb.FullScanIterator. It returns the public state
+// followed by the private state hashes from the snapshot files
+type worldStateSnapshotReader struct {
+	pubState       *snapshotReader
+	pvtStateHashes *snapshotReader
+	dbValueFormat  byte
+}
+
+func newWorldStateSnapshotReader(dir string) (*worldStateSnapshotReader, error) {
+	var pubState, pvtStateHashes *snapshotReader
+	var err error
+	defer func() {
+		if err != nil {
+			pubState.close()
+			pvtStateHashes.close()
+		}
+	}()
+
+	pubState, err = newSnapshotReader(
+		filepath.Join(dir, pubStateDataFileName),
+		filepath.Join(dir, pubStateMetadataFileName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	pvtStateHashes, err = newSnapshotReader(
+		filepath.Join(dir, pvtStateHashesFileName),
+		filepath.Join(dir, pvtStateHashesMetadataFileName),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &worldStateSnapshotReader{
+		pubState:       pubState,
+		pvtStateHashes: pvtStateHashes,
+	}
+	switch {
+	case pubState != nil && pvtStateHashes != nil && pubState.dbValueFormat != pvtStateHashes.dbValueFormat:
+		err = errors.Errorf(
+			"mismatched db value formats in the snapshot files, public state format [%d], private state hashes format [%d]",
+			pubState.dbValueFormat, pvtStateHashes.dbValueFormat,
+		)
+		return nil, err
+	case pubState != nil:
+		r.dbValueFormat = pubState.dbValueFormat
+	case pvtStateHashes != nil:
+		r.dbValueFormat = pvtStateHashes.dbValueFormat
+	}
+	return r, nil
+}
+
+// Next implements the function in the interface statedb.FullScanIterator
+func (r *worldStateSnapshotReader) Next() (*statedb.CompositeKey, []byte, error) {
+	for _, reader := range []*snapshotReader{r.pubState, r.pvtStateHashes} {
+		if reader == nil {
+			continue
+		}
+		compositeKey, dbValue, err := reader.next()
+		if err != nil || compositeKey != nil {
+			return compositeKey, dbValue, err
+		}
+	}
+	return nil, nil, nil
+}
+
+// Close implements the function in the interface statedb.FullScanIterator
+func (r *worldStateSnapshotReader) Close() {
+	r.pubState.close()
+	r.pvtStateHashes.close()
+}
+
+func (r *worldStateSnapshotReader) isEmpty() bool {
+	return r.pubState == nil && r.pvtStateHashes == nil
+}
+
+// chaincodeNamespaces returns the chaincode namespaces present in the public state and the private state hashes
+func (r *worldStateSnapshotReader) chaincodeNamespaces() []string {
+	namespaces := []string{}
+	for _, reader := range []*snapshotReader{r.pubState, r.pvtStateHashes} {
+		if reader == nil {
+			continue
+		}
+		for _, row := range reader.metadata {
+			namespaces = append(namespaces, strings.Split(row.namespace, nsJoiner)[0])
+		}
+	}
+	return namespaces
+}
+
+// snapshotReader reads the data file and the metadata file generated by the snapshotWriter
+type snapshotReader struct {
+	dataFile      *snapshot.FileReader
+	dbValueFormat byte
+	metadata      []*metadataRow
+	// cursor in the metadata, i.e., the current namespace and the number of kvs read for it
+	nsIndex    int
+	numKVsRead uint64
+}
+
+type metadataRow struct {
+	namespace string
+	kvCounts  uint64
+}
+
+// newSnapshotReader returns a nil reader if the files do not exist, which is the case when the
+// exported data did not contain any kv
+func newSnapshotReader(dataFilePath, metadataFilePath string) (*snapshotReader, error) {
+	if _, err := os.Stat(metadataFilePath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	metadata, err := loadSnapshotMetadata(metadataFilePath)
+	if err != nil {
+		return nil, err
+	}
+	dataFile, err := snapshot.OpenFile(dataFilePath, snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	dbValueFormat, err := dataFile.DecodeBytes()
+	if err != nil {
+		dataFile.Close()
+		return nil, err
+	}
+	if len(dbValueFormat) != 1 {
+		dataFile.Close()
+		return nil, errors.Errorf("invalid db value format in the snapshot file: %s", dataFilePath)
+	}
+	return &snapshotReader{
+		dataFile:      dataFile,
+		dbValueFormat: dbValueFormat[0],
+		metadata:      metadata,
+	}, nil
+}
+
+func loadSnapshotMetadata(metadataFilePath string) ([]*metadataRow, error) {
+	metadataFile, err := snapshot.OpenFile(metadataFilePath, snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer metadataFile.Close()
+
+	numNamespaces, err := metadataFile.DecodeUVarInt()
+	if err != nil {
+		return nil, err
+	}
+	metadata := make([]*metadataRow, 0, numNamespaces)
+	for i := uint64(0); i < numNamespaces; i++ {
+		namespace, err := metadataFile.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		kvCounts, err := metadataFile.DecodeUVarInt()
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, &metadataRow{namespace: namespace, kvCounts: kvCounts})
+	}
+	return metadata, nil
+}
+
+func (r *snapshotReader) next() (*statedb.CompositeKey, []byte, error) {
+	for r.nsIndex < len(r.metadata) {
+		row := r.metadata[r.nsIndex]
+		if r.numKVsRead == row.kvCounts {
+			r.nsIndex++
+			r.numKVsRead = 0
+			continue
+		}
+		key, err := r.dataFile.DecodeString()
+		if err != nil {
+			return nil, nil, err
+		}
+		dbValue, err := r.dataFile.DecodeBytes()
+		if err != nil {
+			return nil, nil, err
+		}
+		r.numKVsRead++
+		return &statedb.CompositeKey{Namespace: row.namespace, Key: key}, dbValue, nil
+	}
+	return nil, nil, nil
+}
+
+func (r *snapshotReader) close() {
+	if r == nil {
+		return
+	}
+	r.dataFile.Close()
+}
@@ -164,6 +164,38 @@ func testSnapshotWithSampleData(t *testing.T, env TestEnv,
 		require.Equal(t, pvtStateHashes, pvtStateHashesFromSnapshot)
 	}
 	require.Len(t, filesAndHashes, numFilesExpected)
+
+	// import the snapshot files into another ledger and verify the imported state
+	importedLedgerID := generateLedgerID(t)
+	savepoint := version.NewHeight(10, 5)
+	require.NoError(t, env.GetProvider().ImportFromSnapshot(importedLedgerID, savepoint, snapshotDir))
+	importedDB := env.GetDBHandle(importedLedgerID)
+	importedSavepoint, err := importedDB.GetLatestSavePoint()
+	require.NoError(t, err)
+	require.Equal(t, savepoint, importedSavepoint)
+	for _, s := range publicState {
+		vv, err := importedDB.GetState(s.Namespace, s.Key)
+		require.NoError(t, err)
+		require.Equal(t, &s.VersionedValue, vv)
+		metadata, err := importedDB.GetStateMetadata(s.Namespace, s.Key)
+		require.NoError(t, err)
+		require.Equal(t, s.Metadata, metadata)
+	}
+	for _, s := range pvtStateHashes {
+		nsColl := strings.Split(s.Namespace, nsJoiner+hashDataPrefix)
+		vv, err := importedDB.GetValueHash(nsColl[0], nsColl[1], []byte(s.Key))
+		require.NoError(t, err)
+		require.Equal(t, &s.VersionedValue, vv)
+		metadata, err := importedDB.GetPrivateDataMetadataByHash(nsColl[0], nsColl[1], []byte(s.Key))
+		require.NoError(t, err)
+		require.Equal(t, s.Metadata, metadata)
+	}
+	for _, s := range pvtState {
+		nsColl := strings.Split(s.Namespace, nsJoiner+pvtDataPrefix)
+		vv, err := importedDB.GetPrivateData(nsColl[0], nsColl[1], s.Key)
+		require.NoError(t, err)
+		require.Nil(t, vv)
+	}
 }
 
 func sha256ForFileForTest(t *testing.T, file string) []byte {
@@ -216,6 +248,49 @@ func loadSnapshotDataForTest(
 	return data
 }
 
+func TestImportFromSnapshotErrorPropagation(t *testing.T) {
+	dbEnv := &LevelDBTestEnv{}
+	dbEnv.Init(t)
+	defer dbEnv.Cleanup()
+	db := dbEnv.GetDBHandle(generateLedgerID(t))
+	updateBatch := NewUpdateBatch()
+	updateBatch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updateBatch.HashUpdates.Put("ns1", "coll1", []byte("key1"), []byte("value1"), version.NewHeight(1, 1))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 1)))
+
+	snapshotDir, err := ioutil.TempDir("", "testsnapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	_, err = db.ExportPubStateAndPvtStateHashes(snapshotDir, testNewHashFunc)
+	require.NoError(t, err)
+
+	t.Run("statedb-not-empty", func(t *testing.T) {
+		ledgerID := generateLedgerID(t)
+		require.NoError(t, dbEnv.provider.ImportFromSnapshot(ledgerID, version.NewHeight(1, 1), snapshotDir))
+		err := dbEnv.provider.ImportFromSnapshot(ledgerID, version.NewHeight(1, 1), snapshotDir)
+		require.EqualError(t, err, fmt.Sprintf(
+			"error while importing the state for ledger [%s] from the snapshot: "+
+				"statedb for ledger [%s] is not empty. Import is supported only on an empty statedb",
+			ledgerID, ledgerID,
+		))
+	})
+
+	t.Run("corrupted-metadata-file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "testsnapshot")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, pubStateMetadataFileName), []byte{snapshotFileFormat}, 0644))
+		err = dbEnv.provider.ImportFromSnapshot(generateLedgerID(t), version.NewHeight(1, 1), dir)
+		require.Contains(t, err.Error(), "error while reading from snapshot file")
+	})
+
+	t.Run("missing-data-file", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(snapshotDir, pvtStateHashesFileName)))
+		err := dbEnv.provider.ImportFromSnapshot(generateLedgerID(t), version.NewHeight(1, 1), snapshotDir)
+		require.Contains(t, err.Error(), "error while opening the snapshot file: "+filepath.Join(snapshotDir, pvtStateHashesFileName))
+	})
+}
+
 func TestSnapshotErrorPropagation(t *testing.T) {
 	var dbEnv *LevelDBTestEnv
 	var snapshotDir string
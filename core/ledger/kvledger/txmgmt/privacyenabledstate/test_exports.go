@@ -28,6 +28,7 @@ type TestEnv interface {
 	StartExternalResource()
 	Init(t testing.TB)
 	GetDBHandle(id string) *DB
+	GetProvider() *DBProvider
 	GetName() string
 	DBValueFormat() byte
 	DecodeDBValue(dbVal []byte) statedb.VersionedValue
@@ -89,6 +90,11 @@ func (env *LevelDBTestEnv) GetDBHandle(id string) *DB {
 	return db
 }
 
+// GetProvider implements corresponding function from interface TestEnv
+func (env *LevelDBTestEnv) GetProvider() *DBProvider {
+	return env.provider
+}
+
 // GetName implements corresponding function from interface TestEnv
 func (env *LevelDBTestEnv) GetName() string {
 	return "levelDBTestEnv"
@@ -190,6 +196,11 @@ func (env *CouchDBTestEnv) GetDBHandle(id string) *DB {
 	return db
 }
 
+// GetProvider implements corresponding function from interface TestEnv
+func (env *CouchDBTestEnv) GetProvider() *DBProvider {
+	return env.provider
+}
+
 // GetName implements corresponding function from interface TestEnv
 func (env *CouchDBTestEnv) GetName() string {
 	return "couchDBTestEnv"
@@ -0,0 +1,251 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/pkg/errors"
+)
+
+// ExpectedState accumulates the public state and the hashes of the private state that a db
+// is expected to contain, as computed by replaying the write-sets of the valid transactions.
+// In order to keep the memory footprint low, only the hashes of the public values are retained
+type ExpectedState struct {
+	pub    map[statedb.CompositeKey]*expectedValue
+	hashed map[hashedCompositeKey]*expectedValue
+}
+
+type hashedCompositeKey struct {
+	namespace, collection, keyHash string
+}
+
+type expectedValue struct {
+	valueHash []byte
+	version   *version.Height
+	isDelete  bool
+	// expired is set for a private key that the ledger purges on the expiry of the block-to-live of the collection
+	expired bool
+}
+
+// NewExpectedState constructs an empty ExpectedState
+func NewExpectedState() *ExpectedState {
+	return &ExpectedState{
+		pub:    map[statedb.CompositeKey]*expectedValue{},
+		hashed: map[hashedCompositeKey]*expectedValue{},
+	}
+}
+
+// ApplyWrite records a write, or a delete, of a public key
+func (e *ExpectedState) ApplyWrite(ns, key string, value []byte, isDelete bool, ver *version.Height) {
+	ev := &expectedValue{version: ver, isDelete: isDelete}
+	if !isDelete {
+		ev.valueHash = util.ComputeHash(value)
+	}
+	e.pub[statedb.CompositeKey{Namespace: ns, Key: key}] = ev
+}
+
+// ApplyHashedWrite records a write, or a delete, of a private key in the form of the hashes
+func (e *ExpectedState) ApplyHashedWrite(ns, coll string, keyHash, valueHash []byte, isDelete bool, ver *version.Height) {
+	ev := &expectedValue{version: ver, isDelete: isDelete}
+	if !isDelete {
+		ev.valueHash = valueHash
+	}
+	e.hashed[hashedCompositeKey{ns, coll, string(keyHash)}] = ev
+}
+
+// ApplyMetadataWrite records a metadata write on a public key. The ledger applies a metadata
+// write only to an existing key, which causes the version of the key to change
+func (e *ExpectedState) ApplyMetadataWrite(ns, key string, ver *version.Height) {
+	if ev, ok := e.pub[statedb.CompositeKey{Namespace: ns, Key: key}]; ok && !ev.isDelete {
+		ev.version = ver
+	}
+}
+
+// ApplyHashedMetadataWrite records a metadata write on a private key
+func (e *ExpectedState) ApplyHashedMetadataWrite(ns, coll string, keyHash []byte, ver *version.Height) {
+	if ev, ok := e.hashed[hashedCompositeKey{ns, coll, string(keyHash)}]; ok && !ev.isDelete {
+		ev.version = ver
+	}
+}
+
+// ExpireHashedKeys marks the private keys that are expected to be purged from the db on the expiry of the
+// block-to-live of their collections. The function `isExpired` is invoked with the block number of the version
+// of each of the private keys that are expected to exist. A key so marked is allowed to be missing from the db
+func (e *ExpectedState) ExpireHashedKeys(isExpired func(ns, coll string, committingBlock uint64) (bool, error)) error {
+	for hk, ev := range e.hashed {
+		if ev.isDelete {
+			continue
+		}
+		expired, err := isExpired(hk.namespace, hk.collection, ev.version.BlockNum)
+		if err != nil {
+			return err
+		}
+		ev.expired = expired
+	}
+	return nil
+}
+
+// StateDivergence describes a key for which the state in the db does not match the expected state.
+// For a private key, the Collection and the KeyHash are set and the Key is empty.
+// A nil ExpectedVersion means that the key is not expected to exist and a nil
+// ActualVersion means that the key does not exist in the db
+type StateDivergence struct {
+	Namespace       string
+	Collection      string
+	Key             string
+	KeyHash         []byte
+	ExpectedVersion *version.Height
+	ActualVersion   *version.Height
+	Reason          string
+}
+
+// VerifyPubAndHashedState compares the public state and the hashes of the private state in the db
+// with the expected state. The keys that are present in the db but not in the expected state are reported
+// as divergences as well, unless the function `acceptUnexpected` returns true for the version of the key.
+// The returned divergences are sorted by namespace, collection, and key
+func (s *DB) VerifyPubAndHashedState(expected *ExpectedState, acceptUnexpected func(*version.Height) bool) ([]*StateDivergence, error) {
+	var divergences []*StateDivergence
+
+	for ck, ev := range expected.pub {
+		vv, err := s.GetState(ck.Namespace, ck.Key)
+		if err != nil {
+			return nil, err
+		}
+		var actualValueHash []byte
+		if vv != nil {
+			actualValueHash = util.ComputeHash(vv.Value)
+		}
+		if d := compareWithExpected(ev, vv, actualValueHash); d != nil {
+			d.Namespace, d.Key = ck.Namespace, ck.Key
+			divergences = append(divergences, d)
+		}
+	}
+
+	for hk, ev := range expected.hashed {
+		vv, err := s.GetValueHash(hk.namespace, hk.collection, []byte(hk.keyHash))
+		if err != nil {
+			return nil, err
+		}
+		var actualValueHash []byte
+		if vv != nil {
+			actualValueHash = vv.Value
+		}
+		if d := compareWithExpected(ev, vv, actualValueHash); d != nil {
+			d.Namespace, d.Collection, d.KeyHash = hk.namespace, hk.collection, []byte(hk.keyHash)
+			divergences = append(divergences, d)
+		}
+	}
+
+	unexpected, err := s.unexpectedKeys(expected, acceptUnexpected)
+	if err != nil {
+		return nil, err
+	}
+	divergences = append(divergences, unexpected...)
+
+	sort.Slice(divergences, func(i, j int) bool {
+		di, dj := divergences[i], divergences[j]
+		if di.Namespace != dj.Namespace {
+			return di.Namespace < dj.Namespace
+		}
+		if di.Collection != dj.Collection {
+			return di.Collection < dj.Collection
+		}
+		if di.Key != dj.Key {
+			return di.Key < dj.Key
+		}
+		return bytes.Compare(di.KeyHash, dj.KeyHash) < 0
+	})
+	return divergences, nil
+}
+
+func (s *DB) unexpectedKeys(expected *ExpectedState, acceptUnexpected func(*version.Height) bool) ([]*StateDivergence, error) {
+	itr, _, err := s.GetFullScanIterator(isPvtdataNs)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var divergences []*StateDivergence
+	for {
+		ck, _, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if ck == nil {
+			break
+		}
+
+		d := &StateDivergence{Reason: "the key is not written by any valid transaction"}
+		if isHashedDataNs(ck.Namespace) {
+			nsColl := strings.SplitN(ck.Namespace, nsJoiner+hashDataPrefix, 2)
+			keyHash := []byte(ck.Key)
+			if !s.BytesKeySupported() {
+				if keyHash, err = base64.StdEncoding.DecodeString(ck.Key); err != nil {
+					return nil, errors.Wrapf(err, "error while decoding the key hash [%s]", ck.Key)
+				}
+			}
+			if _, ok := expected.hashed[hashedCompositeKey{nsColl[0], nsColl[1], string(keyHash)}]; ok {
+				continue
+			}
+			d.Namespace, d.Collection, d.KeyHash = nsColl[0], nsColl[1], keyHash
+		} else {
+			if _, ok := expected.pub[*ck]; ok {
+				continue
+			}
+			d.Namespace, d.Key = ck.Namespace, ck.Key
+		}
+
+		if d.ActualVersion, err = s.GetVersion(ck.Namespace, ck.Key); err != nil {
+			return nil, err
+		}
+		if acceptUnexpected != nil && acceptUnexpected(d.ActualVersion) {
+			continue
+		}
+		divergences = append(divergences, d)
+	}
+	return divergences, nil
+}
+
+func compareWithExpected(ev *expectedValue, actual *statedb.VersionedValue, actualValueHash []byte) *StateDivergence {
+	switch {
+	case ev.isDelete && actual == nil:
+		return nil
+	case ev.isDelete:
+		return &StateDivergence{
+			ActualVersion: actual.Version,
+			Reason:        fmt.Sprintf("the key is expected to be deleted at version [%s]", ev.version),
+		}
+	case actual == nil && ev.expired:
+		return nil
+	case actual == nil:
+		return &StateDivergence{
+			ExpectedVersion: ev.version,
+			Reason:          "the key is missing",
+		}
+	case ev.version.Compare(actual.Version) != 0:
+		return &StateDivergence{
+			ExpectedVersion: ev.version,
+			ActualVersion:   actual.Version,
+			Reason:          "the version of the key does not match",
+		}
+	case !bytes.Equal(ev.valueHash, actualValueHash):
+		return &StateDivergence{
+			ExpectedVersion: ev.version,
+			ActualVersion:   actual.Version,
+			Reason:          "the value of the key does not match",
+		}
+	}
+	return nil
+}
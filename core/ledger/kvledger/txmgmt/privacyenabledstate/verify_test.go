@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPubAndHashedState(t *testing.T) {
+	env := &LevelDBTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	db := env.GetDBHandle(generateLedgerID(t))
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updates.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	updates.PubUpdates.Put("ns1", "key3", []byte("value3"), version.NewHeight(2, 1))
+	updates.PubUpdates.Put("ns1", "key4", []byte("value4"), version.NewHeight(2, 2))
+	updates.PubUpdates.Put("ns2", "key5", []byte("value5"), version.NewHeight(3, 0))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 3))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key2", []byte("pvt_value2"), version.NewHeight(2, 3))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(3, 0)))
+
+	expected := NewExpectedState()
+	expected.ApplyWrite("ns1", "key1", []byte("value1"), false, version.NewHeight(1, 1))
+	expected.ApplyWrite("ns1", "key2", []byte("value2"), false, version.NewHeight(1, 1))
+	expected.ApplyMetadataWrite("ns1", "key2", version.NewHeight(1, 2))
+	expected.ApplyWrite("ns1", "key3", []byte("another-value3"), false, version.NewHeight(2, 1))
+	expected.ApplyWrite("ns1", "key4", nil, true, version.NewHeight(2, 2))
+	expected.ApplyWrite("ns1", "key6", []byte("value6"), false, version.NewHeight(2, 4))
+	expected.ApplyHashedWrite("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("pvt_value1"), false, version.NewHeight(1, 3))
+	expected.ApplyHashedWrite("ns1", "coll1", util.ComputeStringHash("key2"), util.ComputeStringHash("pvt_value2"), false, version.NewHeight(2, 2))
+
+	t.Run("all-unexpected-keys-reported", func(t *testing.T) {
+		divergences, err := db.VerifyPubAndHashedState(expected, nil)
+		require.NoError(t, err)
+		require.Equal(t, []*StateDivergence{
+			{
+				Namespace:       "ns1",
+				Key:             "key3",
+				ExpectedVersion: version.NewHeight(2, 1),
+				ActualVersion:   version.NewHeight(2, 1),
+				Reason:          "the value of the key does not match",
+			},
+			{
+				Namespace:     "ns1",
+				Key:           "key4",
+				ActualVersion: version.NewHeight(2, 2),
+				Reason:        "the key is expected to be deleted at version [{BlockNum: 2, TxNum: 2}]",
+			},
+			{
+				Namespace:       "ns1",
+				Key:             "key6",
+				ExpectedVersion: version.NewHeight(2, 4),
+				Reason:          "the key is missing",
+			},
+			{
+				Namespace:       "ns1",
+				Collection:      "coll1",
+				KeyHash:         util.ComputeStringHash("key2"),
+				ExpectedVersion: version.NewHeight(2, 2),
+				ActualVersion:   version.NewHeight(2, 3),
+				Reason:          "the version of the key does not match",
+			},
+			{
+				Namespace:     "ns2",
+				Key:           "key5",
+				ActualVersion: version.NewHeight(3, 0),
+				Reason:        "the key is not written by any valid transaction",
+			},
+		}, divergences)
+	})
+
+	t.Run("unexpected-keys-accepted", func(t *testing.T) {
+		divergences, err := db.VerifyPubAndHashedState(expected, func(h *version.Height) bool {
+			return h.BlockNum == 3
+		})
+		require.NoError(t, err)
+		require.Len(t, divergences, 4)
+		for _, d := range divergences {
+			require.NotEqual(t, "key5", d.Key)
+		}
+	})
+	t.Run("expired-keys", func(t *testing.T) {
+		expected := NewExpectedState()
+		expected.ApplyHashedWrite("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("pvt_value1"), false, version.NewHeight(1, 3))
+		expected.ApplyHashedWrite("ns1", "coll1", util.ComputeStringHash("key2"), util.ComputeStringHash("pvt_value2"), false, version.NewHeight(2, 3))
+		expected.ApplyHashedWrite("ns1", "coll1", util.ComputeStringHash("key3"), util.ComputeStringHash("pvt_value3"), false, version.NewHeight(1, 4))
+		expected.ApplyHashedWrite("ns1", "coll2", util.ComputeStringHash("key3"), util.ComputeStringHash("pvt_value3"), false, version.NewHeight(1, 4))
+		require.NoError(t, expected.ExpireHashedKeys(func(ns, coll string, committingBlock uint64) (bool, error) {
+			return coll == "coll1" && committingBlock == 1, nil
+		}))
+
+		divergences, err := db.VerifyPubAndHashedState(expected, func(h *version.Height) bool {
+			return true
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*StateDivergence{
+			{
+				Namespace:       "ns1",
+				Collection:      "coll2",
+				KeyHash:         util.ComputeStringHash("key3"),
+				ExpectedVersion: version.NewHeight(1, 4),
+				Reason:          "the key is missing",
+			},
+		}, divergences)
+
+		require.EqualError(t, expected.ExpireHashedKeys(func(ns, coll string, committingBlock uint64) (bool, error) {
+			return false, errors.New("error while retrieving the block-to-live")
+		}), "error while retrieving the block-to-live")
+	})
+}
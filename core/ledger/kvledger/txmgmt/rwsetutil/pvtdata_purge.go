@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/core/ledger/util"
+)
+
+// GetCollHashedRwSet returns the hashed rwset for a given namespace and collection
+func (txRwSet *TxRwSet) GetCollHashedRwSet(ns, coll string) *CollHashedRwSet {
+	for _, nsRwSet := range txRwSet.NsRwSets {
+		if nsRwSet.NameSpace != ns {
+			continue
+		}
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName == coll {
+				return collHashedRwSet
+			}
+		}
+	}
+	return nil
+}
+
+// ContainsPurgeMarker returns true if any of the hashed writes of the transaction is a purge of a private data key
+func (txRwSet *TxRwSet) ContainsPurgeMarker() bool {
+	for _, nsRwSet := range txRwSet.NsRwSets {
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+				if hashedWrite.IsPurge {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// IsPartialPvtRwSet returns true if the serialized private rwset of a collection is the private rwset of the
+// collection in the transaction from which the writes of some of the keys have been removed, as the private data
+// store does when the keys are purged. As the hash of the private rwset of the collection in the transaction
+// does not match with such a private rwset, each of its writes is instead matched with a write in the hashed rwset,
+// and each key whose writes are missing must have been purged after the transaction, as reported by `isPurged`
+func IsPartialPvtRwSet(pvtRwSetBytes []byte, collHashedRwSet *CollHashedRwSet, isPurged func(keyHash []byte) (bool, error)) (bool, error) {
+	if collHashedRwSet == nil || collHashedRwSet.HashedRwSet == nil {
+		return false, nil
+	}
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(pvtRwSetBytes, kvRWSet); err != nil {
+		return false, nil
+	}
+	hashedRwSet := collHashedRwSet.HashedRwSet
+
+	hashedWrites := map[string]*kvrwset.KVWriteHash{}
+	for _, hashedWrite := range hashedRwSet.HashedWrites {
+		hashedWrites[string(hashedWrite.KeyHash)] = hashedWrite
+	}
+	presentWrites := map[string]bool{}
+	for _, kvWrite := range kvRWSet.Writes {
+		keyHash := string(util.ComputeStringHash(kvWrite.Key))
+		hashedWrite, ok := hashedWrites[keyHash]
+		if !ok || hashedWrite.IsDelete != kvWrite.IsDelete {
+			return false, nil
+		}
+		if !kvWrite.IsDelete && !bytes.Equal(hashedWrite.ValueHash, util.ComputeHash(kvWrite.Value)) {
+			return false, nil
+		}
+		presentWrites[keyHash] = true
+	}
+
+	hashedMetadataWrites := map[string]bool{}
+	for _, metadataWrite := range hashedRwSet.MetadataWrites {
+		hashedMetadataWrites[string(metadataWrite.KeyHash)] = true
+	}
+	presentMetadataWrites := map[string]bool{}
+	for _, metadataWrite := range kvRWSet.MetadataWrites {
+		keyHash := string(util.ComputeStringHash(metadataWrite.Key))
+		if !hashedMetadataWrites[keyHash] {
+			return false, nil
+		}
+		presentMetadataWrites[keyHash] = true
+	}
+
+	// a purge removes both the write and the metadata write of a key,
+	// hence a key is either present in the private rwset or missing altogether
+	var missingKeyHashes [][]byte
+	for _, keyHash := range writtenKeyHashes(hashedRwSet) {
+		writePresent, metadataWritePresent := presentWrites[string(keyHash)], presentMetadataWrites[string(keyHash)]
+		switch {
+		case writePresent == (hashedWrites[string(keyHash)] != nil) &&
+			metadataWritePresent == hashedMetadataWrites[string(keyHash)]:
+			continue
+		case !writePresent && !metadataWritePresent:
+			missingKeyHashes = append(missingKeyHashes, keyHash)
+		default:
+			return false, nil
+		}
+	}
+	if len(missingKeyHashes) == 0 {
+		return false, nil
+	}
+	for _, keyHash := range missingKeyHashes {
+		purged, err := isPurged(keyHash)
+		if err != nil || !purged {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// writtenKeyHashes returns the distinct hashes of the keys written in the hashed rwset
+func writtenKeyHashes(hashedRwSet *kvrwset.HashedRWSet) [][]byte {
+	var keyHashes [][]byte
+	added := map[string]bool{}
+	add := func(keyHash []byte) {
+		if !added[string(keyHash)] {
+			added[string(keyHash)] = true
+			keyHashes = append(keyHashes, keyHash)
+		}
+	}
+	for _, hashedWrite := range hashedRwSet.HashedWrites {
+		add(hashedWrite.KeyHash)
+	}
+	for _, metadataWrite := range hashedRwSet.MetadataWrites {
+		add(metadataWrite.KeyHash)
+	}
+	return keyHashes
+}
@@ -1076,6 +1076,59 @@ func TestFullScanIterator(
 	}
 }
 
+// TestImportFromSnapshot tests that the data exported via the FullScanIterator of one db can be imported into another db
+func TestImportFromSnapshot(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	sourceDB, err := dbProvider.GetDBHandle("test-import-source", nil)
+	require.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte{}, version.NewHeight(1, 2))
+	batch.PutValAndMetadata("ns2", "key1", []byte("value1"), []byte("metadata1"), version.NewHeight(2, 1))
+	require.NoError(t, sourceDB.ApplyUpdates(batch, version.NewHeight(2, 1)))
+
+	fullScanItr, valueFormat, err := sourceDB.GetFullScanIterator(func(string) bool { return false })
+	require.NoError(t, err)
+	defer fullScanItr.Close()
+	savepoint := version.NewHeight(10, 5)
+	require.NoError(t, dbProvider.ImportFromSnapshot("test-import-target", savepoint, fullScanItr, valueFormat))
+
+	targetDB, err := dbProvider.GetDBHandle("test-import-target", nil)
+	require.NoError(t, err)
+	actualSavepoint, err := targetDB.GetLatestSavePoint()
+	require.NoError(t, err)
+	require.Equal(t, savepoint, actualSavepoint)
+	for _, kv := range []*statedb.VersionedKV{
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+			VersionedValue: statedb.VersionedValue{Value: []byte{}, Version: version.NewHeight(1, 2)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns2", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Metadata: []byte("metadata1"), Version: version.NewHeight(2, 1)},
+		},
+	} {
+		vv, err := targetDB.GetState(kv.Namespace, kv.Key)
+		require.NoError(t, err)
+		require.Equal(t, &kv.VersionedValue, vv)
+	}
+
+	fullScanItr, valueFormat, err = sourceDB.GetFullScanIterator(func(string) bool { return false })
+	require.NoError(t, err)
+	defer fullScanItr.Close()
+	require.EqualError(t,
+		dbProvider.ImportFromSnapshot("test-import-target", savepoint, fullScanItr, valueFormat),
+		"statedb for ledger [test-import-target] is not empty. Import is supported only on an empty statedb",
+	)
+	err = dbProvider.ImportFromSnapshot("test-import-another-target", savepoint, fullScanItr, valueFormat+1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected db value format")
+}
+
 type stringset []string
 
 func (universe stringset) contains(str string) bool {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/pkg/errors"
 )
 
@@ -60,3 +61,25 @@ func decodeValueVersionMetadata(encodedMsg []byte) (*ValueVersionMetadata, error
 	}
 	return val, nil
 }
+
+// decodeFullScanValue decodes the value bytes returned by the dbsScanner
+func decodeFullScanValue(dbValue []byte) (*statedb.VersionedValue, error) {
+	v, err := decodeValueVersionMetadata(dbValue)
+	if err != nil {
+		return nil, err
+	}
+	ver, metadata, err := decodeVersionAndMetadata(string(v.VersionAndMetadata))
+	if err != nil {
+		return nil, err
+	}
+	val := v.Value
+	// protobuf always makes an empty byte array as nil
+	if val == nil {
+		val = []byte{}
+	}
+	return &statedb.VersionedValue{
+		Value:    val,
+		Version:  ver,
+		Metadata: metadata,
+	}, nil
+}
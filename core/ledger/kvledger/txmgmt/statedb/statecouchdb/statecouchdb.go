@@ -36,7 +36,7 @@ const (
 	fabricInternalDBName = "fabric__internal"
 	// dataformatVersionDocID is used as a key for maintaining version of the data format (maintained in fabric internal db)
 	dataformatVersionDocID      = "dataformatVersion"
-	fullScanIteratorValueFormat = byte(1)
+	fullScanIteratorValueFormat = byte(2)
 )
 
 // VersionedDBProvider implements interface VersionedDBProvider
@@ -148,6 +148,59 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string, nsProvider state
 	return vdb, nil
 }
 
+// ImportFromSnapshot implements method in VersionedDBProvider interface
+func (provider *VersionedDBProvider) ImportFromSnapshot(
+	dbName string,
+	savepoint *version.Height,
+	itr statedb.FullScanIterator,
+	dbValueFormat byte,
+) error {
+	if dbValueFormat != fullScanIteratorValueFormat {
+		return errors.Errorf("unexpected db value format [%d], the snapshot cannot be imported into a CouchDB based statedb", dbValueFormat)
+	}
+	db, err := provider.GetDBHandle(dbName, nil)
+	if err != nil {
+		return err
+	}
+	vdb := db.(*VersionedDB)
+	existingSavepoint, err := vdb.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.Errorf("statedb for ledger [%s] is not empty. Import is supported only on an empty statedb", dbName)
+	}
+
+	maxBatchSize := provider.couchInstance.maxBatchUpdateSize()
+	batch := statedb.NewUpdateBatch()
+	batchSize := 0
+	for {
+		compositeKey, dbValue, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if compositeKey == nil {
+			break
+		}
+		vv, err := decodeFullScanValue(dbValue)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to decode the value of key [%s] in namespace [%s]", compositeKey.Key, compositeKey.Namespace)
+		}
+		batch.PutValAndMetadata(compositeKey.Namespace, compositeKey.Key, vv.Value, vv.Metadata, vv.Version)
+		batchSize++
+		if batchSize >= maxBatchSize {
+			// a nil height does not record the savepoint, which is recorded only
+			// after the last batch is committed
+			if err := vdb.ApplyUpdates(batch, nil); err != nil {
+				return err
+			}
+			batch = statedb.NewUpdateBatch()
+			batchSize = 0
+		}
+	}
+	return vdb.ApplyUpdates(batch, savepoint)
+}
+
 // Close closes the underlying db instance
 func (provider *VersionedDBProvider) Close() {
 	// No close needed on Couch
@@ -1701,25 +1701,15 @@ func TestFullScanIterator(t *testing.T) {
 	commontests.TestFullScanIterator(
 		t,
 		vdbEnv.DBProvider,
-		byte(1),
-		constructVersionedValueForTest,
+		byte(2),
+		decodeFullScanValue,
 	)
 }
 
-func constructVersionedValueForTest(dbVal []byte) (*statedb.VersionedValue, error) {
-	v, err := decodeValueVersionMetadata(dbVal)
-	if err != nil {
-		return nil, err
-	}
-	ver, meta, err := decodeVersionAndMetadata(string(v.VersionAndMetadata))
-	if err != nil {
-		return nil, err
-	}
-	return &statedb.VersionedValue{
-		Value:    v.Value,
-		Version:  ver,
-		Metadata: meta,
-	}, nil
+func TestImportFromSnapshot(t *testing.T) {
+	vdbEnv.init(t, nil)
+	defer vdbEnv.cleanup()
+	commontests.TestImportFromSnapshot(t, vdbEnv.DBProvider)
 }
 
 func TestFullScanIteratorDeterministicJSONOutput(t *testing.T) {
@@ -1868,7 +1858,7 @@ func verifyFullScanIterator(
 		if ck == nil {
 			break
 		}
-		val, err := constructVersionedValueForTest(valBytes)
+		val, err := decodeFullScanValue(valBytes)
 		require.NoError(t, err)
 		results = append(results, &statedb.VersionedKV{CompositeKey: *ck, VersionedValue: *val})
 	}
@@ -21,6 +21,11 @@ import (
 type VersionedDBProvider interface {
 	// GetDBHandle returns a handle to a VersionedDB
 	GetDBHandle(id string, namespaceProvider NamespaceProvider) (VersionedDB, error)
+	// ImportFromSnapshot loads the initial state of the db identified by id from the key-values returned by the
+	// FullScanIterator and sets the savepoint of the db to the given height. The dbValueFormat specifies the
+	// format of the value bytes returned by the iterator, as returned by the function `GetFullScanIterator`
+	// of the VersionedDB that exported the data. The import is expected to be invoked on an empty db only.
+	ImportFromSnapshot(id string, savepoint *version.Height, itr FullScanIterator, dbValueFormat byte) error
 	// Close closes all the VersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -104,7 +109,7 @@ type IndexCapable interface {
 }
 
 // FullScanIterator provides a mean to iterate over entire statedb. The intended use of this iterator
-// is to generate the snapshot files for the statedb
+// is to generate the snapshot files for the statedb and to load the statedb from the snapshot files
 type FullScanIterator interface {
 	// Next returns the key-values in the lexical order of <Namespace, key>
 	// A particular statedb implementation is free to chose any deterministic bytes representation for the <version, value, metadata>
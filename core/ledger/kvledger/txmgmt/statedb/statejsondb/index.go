@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// index entry values are encoded such that their byte order follows the collation order
+// null < false < true < numbers < strings < arrays < objects
+const (
+	nullTag byte = iota + 1
+	falseTag
+	trueTag
+	numberTag
+	stringTag
+	arrayTag
+	objectTag
+)
+
+var (
+	// entryValuesSep separates the encoded field values from the key in an index entry
+	entryValuesSep = []byte{0x00}
+	// entryKeyStopper is greater than any byte that follows the encoded field values of an index entry
+	entryKeyStopper = byte(0xff)
+)
+
+// indexDefinition is a JSON index on one or more fields of the documents in a namespace. An index
+// entry is maintained for each document that contains all the fields of the index. The entries are
+// ordered by the values of the fields, followed by the key of the document
+type indexDefinition struct {
+	DesignDoc string   `json:"ddoc"`
+	Name      string   `json:"name"`
+	Fields    []string `json:"fields"`
+}
+
+// parseIndexDefinition parses an index definition in the format of the CouchDB JSON index, e.g.,
+// {"index":{"fields":["docType","owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+// The sort direction of the fields is ignored as the index can be used for sorting in either direction
+func parseIndexDefinition(indexDefBytes []byte) (*indexDefinition, error) {
+	indexDefMap, err := unmarshalJSONObject(indexDefBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid index definition")
+	}
+	if indexType, ok := indexDefMap["type"]; ok && indexType != "json" {
+		return nil, errors.Errorf("invalid index definition, unsupported index type [%v], only json indexes are supported", indexType)
+	}
+	index, ok := indexDefMap["index"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid index definition, the index must be a JSON object")
+	}
+	if _, ok := index["partial_filter_selector"]; ok {
+		return nil, errors.New("invalid index definition, partial indexes are not supported")
+	}
+	fields, ok := index["fields"].([]interface{})
+	if !ok || len(fields) == 0 {
+		return nil, errors.New("invalid index definition, the index fields must be a non-empty array")
+	}
+	indexDef := &indexDefinition{}
+	for _, field := range fields {
+		fieldName, _, err := parseFieldAndDirection(field)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid index definition")
+		}
+		indexDef.Fields = append(indexDef.Fields, fieldName)
+	}
+	if indexDef.Name, err = optionalString(indexDefMap, "name"); err != nil {
+		return nil, err
+	}
+	if indexDef.DesignDoc, err = optionalString(indexDefMap, "ddoc"); err != nil {
+		return nil, err
+	}
+	indexDef.DesignDoc = trimDesignDocPrefix(indexDef.DesignDoc)
+	if indexDef.Name == "" {
+		fieldsHash := sha256.Sum256([]byte(strings.Join(indexDef.Fields, "\x00")))
+		indexDef.Name = hex.EncodeToString(fieldsHash[:])
+	}
+	if indexDef.DesignDoc == "" {
+		indexDef.DesignDoc = indexDef.Name
+	}
+	if strings.ContainsRune(indexDef.DesignDoc, 0) || strings.ContainsRune(indexDef.Name, 0) {
+		return nil, errors.New("invalid index definition, the design document and the name must not contain a null character")
+	}
+	return indexDef, nil
+}
+
+func optionalString(m map[string]interface{}, key string) (string, error) {
+	value, ok := m[key]
+	if !ok {
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", errors.Errorf("invalid index definition, the %s must be a string", key)
+	}
+	return s, nil
+}
+
+func unmarshalIndexDefinition(b []byte) (*indexDefinition, error) {
+	index := &indexDefinition{}
+	if err := json.Unmarshal(b, index); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling the index definition")
+	}
+	return index, nil
+}
+
+func (index *indexDefinition) marshal() ([]byte, error) {
+	b, err := json.Marshal(index)
+	return b, errors.Wrap(err, "error while marshalling the index definition")
+}
+
+func (index *indexDefinition) equal(other *indexDefinition) bool {
+	if index.DesignDoc != other.DesignDoc || index.Name != other.Name || len(index.Fields) != len(other.Fields) {
+		return false
+	}
+	for i, field := range index.Fields {
+		if other.Fields[i] != field {
+			return false
+		}
+	}
+	return true
+}
+
+// entryKeyStarter returns the prefix of all the entries of the index
+func (index *indexDefinition) entryKeyStarter(ns string) []byte {
+	k := append([]byte{}, indexEntryKeyPrefix...)
+	k = append(k, []byte(ns)...)
+	k = append(k, nsKeySep...)
+	k = append(k, []byte(index.DesignDoc)...)
+	k = append(k, nsKeySep...)
+	k = append(k, []byte(index.Name)...)
+	return append(k, nsKeySep...)
+}
+
+// entryKeyStopper returns a key that is greater than all the entries of the index
+func (index *indexDefinition) entryKeyStopper(ns string) []byte {
+	return append(index.entryKeyStarter(ns), entryKeyStopper)
+}
+
+// entryKey returns the key of the index entry for the document. A nil value is returned
+// if the document does not contain all the fields of the index
+func (index *indexDefinition) entryKey(ns, key string, doc map[string]interface{}) []byte {
+	if doc == nil {
+		return nil
+	}
+	k := index.entryKeyStarter(ns)
+	for _, field := range index.Fields {
+		value, ok := lookupField(doc, splitFieldName(field))
+		if !ok {
+			return nil
+		}
+		k = appendIndexValue(k, value)
+	}
+	k = append(k, entryValuesSep...)
+	return append(k, []byte(key)...)
+}
+
+// appendIndexValue appends the encoding of the JSON value, which preserves the collation order
+// for the values other than arrays and objects. The arrays and objects are encoded as their JSON
+func appendIndexValue(b []byte, value interface{}) []byte {
+	switch value := value.(type) {
+	case nil:
+		return append(b, nullTag)
+	case bool:
+		if value {
+			return append(b, trueTag)
+		}
+		return append(b, falseTag)
+	case json.Number:
+		f, _ := numberValue(value).Float64()
+		if f == 0 {
+			// -0 and 0 are equal and hence, share the same encoding
+			f = 0
+		}
+		bits := math.Float64bits(f)
+		if bits&(1<<63) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		b = append(b, numberTag)
+		return append(b, uint64ToBytes(bits)...)
+	case string:
+		return appendEscapedBytes(append(b, stringTag), []byte(value))
+	case []interface{}:
+		jsonBytes, _ := json.Marshal(value)
+		return appendEscapedBytes(append(b, arrayTag), jsonBytes)
+	default:
+		jsonBytes, _ := json.Marshal(value)
+		return appendEscapedBytes(append(b, objectTag), jsonBytes)
+	}
+}
+
+// appendEscapedBytes appends the bytes with each 0x00 escaped as 0x00 0xff, followed by the
+// terminator 0x00 0x01, so that a shorter value is ordered before the values it is a prefix of
+func appendEscapedBytes(b, value []byte) []byte {
+	for _, c := range value {
+		if c == 0x00 {
+			b = append(b, 0x00, 0xff)
+			continue
+		}
+		b = append(b, c)
+	}
+	return append(b, 0x00, 0x01)
+}
+
+func uint64ToBytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
@@ -0,0 +1,286 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIndexDefinition(t *testing.T) {
+	index, err := parseIndexDefinition([]byte(`{"index":{"fields":["docType",{"owner":"desc"}]},"ddoc":"_design/indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	require.NoError(t, err)
+	require.Equal(t, &indexDefinition{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"docType", "owner"}}, index)
+
+	index, err = parseIndexDefinition([]byte(`{"index":{"fields":["owner"]}}`))
+	require.NoError(t, err)
+	require.Len(t, index.Name, 64)
+	require.Equal(t, index.Name, index.DesignDoc)
+
+	for indexDef, expectedErr := range map[string]string{
+		`{"index":{"fields":["owner"]},"type":"text"}`: "invalid index definition, unsupported index type [text], only json indexes are supported",
+		`{"index":{"fields":[]}}`:                      "invalid index definition, the index fields must be a non-empty array",
+		`{"index":"owner"}`:                            "invalid index definition, the index must be a JSON object",
+		`{"index":{"fields":["owner"],"partial_filter_selector":{"docType":"car"}}}`: "invalid index definition, partial indexes are not supported",
+		`{"index":{"fields":["owner"]},"name":1}`:                                    "invalid index definition, the name must be a string",
+	} {
+		_, err := parseIndexDefinition([]byte(indexDef))
+		require.EqualError(t, err, expectedErr, indexDef)
+	}
+}
+
+func TestIndexMaintenance(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexmaintenance", nil)
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"owner":"jerry","size":2}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"size":3}`), version.NewHeight(1, 3))
+	batch.Put("ns2", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+
+	// the invalid index definitions are ignored
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"indexOwner.json":   []byte(`{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`),
+		"indexInvalid.json": []byte(`not a JSON value`),
+	}))
+	require.Equal(t, map[string]string{"tom": "key1", "jerry": "key2"}, indexEntries(t, vdb, "ns1", "indexOwnerDoc", "indexOwner"))
+	require.Empty(t, indexEntries(t, vdb, "ns2", "indexOwnerDoc", "indexOwner"))
+
+	// the index entries follow the updates and deletes
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"fred","size":1}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte(`{"owner":"mary","size":3}`), version.NewHeight(2, 3))
+	batch.Put("ns1", "key4", []byte(`not a JSON value`), version.NewHeight(2, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	require.Equal(t, map[string]string{"fred": "key1", "mary": "key3"}, indexEntries(t, vdb, "ns1", "indexOwnerDoc", "indexOwner"))
+
+	// the indexes are loaded from the db by a new handle
+	env.DBProvider.Close()
+	env.DBProvider, err = NewVersionedDBProvider(env.dbPath)
+	require.NoError(t, err)
+	db, err = env.DBProvider.GetDBHandle("testindexmaintenance", nil)
+	require.NoError(t, err)
+	vdb = db.(*versionedDB)
+	indexes, err := vdb.getIndexes("ns1")
+	require.NoError(t, err)
+	require.Equal(t, []*indexDefinition{{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"owner"}}}, indexes)
+
+	// redefining an index rebuilds its entries
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"indexOwner.json": []byte(`{"index":{"fields":["owner","size"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`),
+	}))
+	indexes, err = vdb.getIndexes("ns1")
+	require.NoError(t, err)
+	require.Equal(t, []*indexDefinition{{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"owner", "size"}}}, indexes)
+	require.Len(t, indexEntries(t, vdb, "ns1", "indexOwnerDoc", "indexOwner"), 2)
+}
+
+func TestQueryWithIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquerywithindexes", nil)
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"indexSize.json":       []byte(`{"index":{"fields":["size"]},"ddoc":"indexSizeDoc","name":"indexSize","type":"json"}`),
+		"indexColorSize.json":  []byte(`{"index":{"fields":["color","size"]},"ddoc":"indexColorSizeDoc","name":"indexColorSize","type":"json"}`),
+		"indexOwnerColor.json": []byte(`{"index":{"fields":["owner.name","color"]},"ddoc":"indexOwnerColorDoc","type":"json"}`),
+	}))
+	batch := statedb.NewUpdateBatch()
+	for i := 1; i <= 10; i++ {
+		color := "blue"
+		if i%2 == 0 {
+			color = "red"
+		}
+		value := fmt.Sprintf(`{"color":"%s","size":%d,"owner":{"name":"owner%d"}}`, color, 11-i, i%3)
+		batch.Put("ns1", fmt.Sprintf("key%02d", i), []byte(value), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns1", "key11", []byte(`{"color":"blue"}`), version.NewHeight(1, 11))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 11)))
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedIndex string
+		expectedKeys  []string
+	}{
+		{
+			name:          "sort ascending",
+			query:         `{"selector":{"size":{"$gt":0}},"sort":["size"]}`,
+			expectedIndex: "indexSize",
+			expectedKeys:  []string{"key10", "key09", "key08", "key07", "key06", "key05", "key04", "key03", "key02", "key01"},
+		},
+		{
+			name:          "sort descending with a range",
+			query:         `{"selector":{"size":{"$gt":3,"$lte":6}},"sort":[{"size":"desc"}]}`,
+			expectedIndex: "indexSize",
+			expectedKeys:  []string{"key05", "key06", "key07"},
+		},
+		{
+			name:          "sort following an equality",
+			query:         `{"selector":{"color":"red","size":{"$exists":true}},"sort":[{"size":"desc"}]}`,
+			expectedIndex: "indexColorSize",
+			expectedKeys:  []string{"key02", "key04", "key06", "key08", "key10"},
+		},
+		{
+			name:          "sort on the equality fields",
+			query:         `{"selector":{"color":"blue","size":{"$lt":5}},"sort":["color","size"]}`,
+			expectedIndex: "indexColorSize",
+			expectedKeys:  []string{"key09", "key07"},
+		},
+		{
+			name:          "index with more equalities is preferred",
+			query:         `{"selector":{"color":"blue","size":{"$gte":5}}}`,
+			expectedIndex: "indexColorSize",
+			expectedKeys:  []string{"key05", "key03", "key01"},
+		},
+		{
+			name:          "nested field",
+			query:         `{"selector":{"owner":{"name":"owner1"},"color":{"$gt":"a"}}}`,
+			expectedIndex: "652b48e7941662855e6acc38a9ad0f55e1ba704ab91ef73a90071b720dd418d3",
+			expectedKeys:  []string{"key01", "key07", "key04", "key10"},
+		},
+		{
+			name:          "use_index",
+			query:         `{"selector":{"color":"blue","size":{"$gte":5}},"use_index":["_design/indexSizeDoc","indexSize"]}`,
+			expectedIndex: "indexSize",
+			expectedKeys:  []string{"key05", "key03", "key01"},
+		},
+		{
+			name:         "no usable index",
+			query:        `{"selector":{"$or":[{"color":"blue"},{"size":2}]}}`,
+			expectedKeys: []string{"key01", "key03", "key05", "key07", "key09", "key11"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			q, err := parseQuery(testCase.query)
+			require.NoError(t, err)
+			indexes, err := vdb.getIndexes("ns1")
+			require.NoError(t, err)
+			plan, err := planQuery("ns1", q, indexes)
+			require.NoError(t, err)
+			if testCase.expectedIndex == "" {
+				require.Nil(t, plan.index)
+			} else {
+				require.NotNil(t, plan.index)
+				require.Equal(t, testCase.expectedIndex, plan.index.Name)
+			}
+			require.Equal(t, testCase.expectedKeys, queryKeys(t, db, "ns1", testCase.query))
+		})
+	}
+
+	t.Run("sort without an index", func(t *testing.T) {
+		_, err := db.ExecuteQuery("ns1", `{"selector":{"color":"blue"},"sort":["owner.name"]}`)
+		require.EqualError(t, err, "no index exists for this sort, try indexing by the sort fields")
+	})
+}
+
+func TestPaginatedQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testpaginatedquery", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"indexSize.json": []byte(`{"index":{"fields":["size"]},"ddoc":"indexSizeDoc","name":"indexSize","type":"json"}`),
+	}))
+	batch := statedb.NewUpdateBatch()
+	for i := 1; i <= 7; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf(`{"size":%d}`, i)), version.NewHeight(1, uint64(i)))
+	}
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 7)))
+
+	testCases := []struct {
+		query         string
+		expectedPages [][]string
+	}{
+		{`{"selector":{"size":{"$gt":1}}}`, [][]string{{"key2", "key3", "key4"}, {"key5", "key6", "key7"}}},
+		{`{"selector":{"size":{"$gt":0}},"sort":[{"size":"desc"}]}`, [][]string{{"key7", "key6", "key5"}, {"key4", "key3", "key2"}, {"key1"}}},
+		{`{"selector":{"size":{"$mod":[2,0]}},"skip":1}`, [][]string{{"key4", "key6"}}},
+		{`{"selector":{"size":{"$type":"string"}}}`, [][]string{nil}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.query, func(t *testing.T) {
+			bookmark := ""
+			for i, expectedPage := range testCase.expectedPages {
+				itr, err := db.ExecuteQueryWithPagination("ns1", testCase.query, bookmark, 3)
+				require.NoError(t, err)
+				var keys []string
+				for {
+					result, err := itr.Next()
+					require.NoError(t, err)
+					if result == nil {
+						break
+					}
+					keys = append(keys, result.(*statedb.VersionedKV).Key)
+				}
+				require.Equal(t, expectedPage, keys)
+				bookmark = itr.GetBookmarkAndClose()
+				if i == len(testCase.expectedPages)-1 {
+					require.Empty(t, bookmark)
+				} else {
+					require.NotEmpty(t, bookmark)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid bookmark", func(t *testing.T) {
+		itr, err := db.ExecuteQueryWithPagination("ns1", `{"selector":{"size":{"$gt":0}},"sort":["size"]}`, "", 1)
+		require.NoError(t, err)
+		_, err = itr.Next()
+		require.NoError(t, err)
+		bookmark := itr.GetBookmarkAndClose()
+
+		// a bookmark outside the range of the query is rejected
+		_, err = db.ExecuteQueryWithPagination("ns1", `{"selector":{"size":{"$gt":5}}}`, bookmark, 1)
+		require.EqualError(t, err, fmt.Sprintf("invalid bookmark [%s]", bookmark))
+		_, err = db.ExecuteQueryWithPagination("ns1", `{"selector":{"size":{"$gt":0}}}`, "not a bookmark", 1)
+		require.EqualError(t, err, "invalid bookmark [not a bookmark]")
+	})
+}
+
+func TestIndexValueEncodingEscapesNullCharacters(t *testing.T) {
+	require.True(t, string(appendIndexValue(nil, "a")) < string(appendIndexValue(nil, "a\x00")))
+	require.True(t, string(appendIndexValue(nil, "a\x00")) < string(appendIndexValue(nil, "a\x00b")))
+	require.True(t, string(appendIndexValue(nil, "a\x00b")) < string(appendIndexValue(nil, "ab")))
+}
+
+// indexEntries returns the keys of the documents in the index mapped by the value of the first index field
+func indexEntries(t *testing.T, vdb *versionedDB, ns, designDoc, name string) map[string]string {
+	index := &indexDefinition{DesignDoc: designDoc, Name: name}
+	dbItr, err := vdb.db.GetIterator(index.entryKeyStarter(ns), index.entryKeyStopper(ns))
+	require.NoError(t, err)
+	defer dbItr.Release()
+	entries := map[string]string{}
+	for dbItr.Next() {
+		encodedValues := dbItr.Key()[len(index.entryKeyStarter(ns)):]
+		require.Equal(t, stringTag, encodedValues[0])
+		value := encodedValues[1:]
+		for i := 0; i < len(value)-1; i++ {
+			if value[i] == 0x00 && value[i+1] == 0x01 {
+				value = value[:i]
+				break
+			}
+		}
+		entries[string(value)] = string(dbItr.Value())
+	}
+	require.NoError(t, dbItr.Error())
+	return entries
+}
@@ -0,0 +1,646 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// query is a parsed rich query. The supported queries are a subset of the CouchDB Mango queries:
+// a selector with the combination operators $and, $or, $nor, $not, the condition operators
+// $eq, $ne, $lt, $lte, $gt, $gte, $exists, $type, $in, $nin, $size, $mod, $regex and the array
+// operators $all, $elemMatch, $allMatch, along with the query options fields, sort, skip and
+// use_index. As for CouchDB, the limit and the bookmark in the query are overridden by the page
+// size and the bookmark of the ledger query
+type query struct {
+	selector       condition
+	fields         [][]string
+	sort           []string
+	sortDescending bool
+	skip           int
+	useIndex       *indexName
+}
+
+type indexName struct {
+	designDoc string
+	name      string
+}
+
+// parseQuery parses the JSON query string
+func parseQuery(queryString string) (*query, error) {
+	queryMap, err := unmarshalJSONObject([]byte(queryString))
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid query")
+	}
+	selectorMap, ok := queryMap["selector"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid query, the selector must be a JSON object")
+	}
+	q := &query{}
+	if q.selector, err = parseSelector(selectorMap, nil, false); err != nil {
+		return nil, err
+	}
+	for option, value := range queryMap {
+		switch option {
+		case "selector", "limit", "bookmark":
+		case "fields":
+			if q.fields, err = parseFields(value); err != nil {
+				return nil, err
+			}
+		case "sort":
+			if q.sort, q.sortDescending, err = parseSort(value); err != nil {
+				return nil, err
+			}
+		case "skip":
+			skip, ok := value.(json.Number)
+			if !ok {
+				return nil, errors.New("invalid query, the skip must be a non-negative integer")
+			}
+			n, err := skip.Int64()
+			if err != nil || n < 0 {
+				return nil, errors.New("invalid query, the skip must be a non-negative integer")
+			}
+			q.skip = int(n)
+		case "use_index":
+			if q.useIndex, err = parseUseIndex(value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Errorf("invalid query, unsupported query option [%s]", option)
+		}
+	}
+	return q, nil
+}
+
+func parseFields(value interface{}) ([][]string, error) {
+	fields, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("fields definition must be an array")
+	}
+	var fieldPaths [][]string
+	for _, field := range fields {
+		fieldName, ok := field.(string)
+		if !ok {
+			return nil, errors.New("fields definition must be an array of strings")
+		}
+		fieldPaths = append(fieldPaths, splitFieldName(fieldName))
+	}
+	return fieldPaths, nil
+}
+
+// parseSort parses the sort fields, which are either field names or objects that map a field name
+// to a direction. As for CouchDB, all the fields must be sorted in the same direction
+func parseSort(value interface{}) ([]string, bool, error) {
+	sortFields, ok := value.([]interface{})
+	if !ok || len(sortFields) == 0 {
+		return nil, false, errors.New("sort definition must be a non-empty array")
+	}
+	var fieldNames []string
+	directions := map[string]bool{}
+	for _, sortField := range sortFields {
+		fieldName, direction, err := parseFieldAndDirection(sortField)
+		if err != nil {
+			return nil, false, errors.WithMessage(err, "invalid sort definition")
+		}
+		fieldNames = append(fieldNames, fieldName)
+		directions[direction] = true
+	}
+	if len(directions) > 1 {
+		return nil, false, errors.New("sorts currently only support a single direction for all fields")
+	}
+	return fieldNames, directions["desc"], nil
+}
+
+// parseFieldAndDirection parses a field of a sort or of an index definition, which is either
+// a field name or an object with a single field name mapped to "asc" or "desc"
+func parseFieldAndDirection(field interface{}) (string, string, error) {
+	switch field := field.(type) {
+	case string:
+		return field, "asc", nil
+	case map[string]interface{}:
+		if len(field) == 1 {
+			for fieldName, direction := range field {
+				if direction == "asc" || direction == "desc" {
+					return fieldName, direction.(string), nil
+				}
+			}
+		}
+	}
+	return "", "", errors.New(`a field must be either a field name or an object of a field name mapped to "asc" or "desc"`)
+}
+
+func parseUseIndex(value interface{}) (*indexName, error) {
+	switch value := value.(type) {
+	case string:
+		return &indexName{designDoc: trimDesignDocPrefix(value)}, nil
+	case []interface{}:
+		if len(value) == 1 || len(value) == 2 {
+			designDoc, ok := value[0].(string)
+			name := ""
+			if len(value) == 2 {
+				name, _ = value[1].(string)
+			}
+			if ok && (len(value) == 1 || name != "") {
+				return &indexName{designDoc: trimDesignDocPrefix(designDoc), name: name}, nil
+			}
+		}
+	}
+	return nil, errors.New("use_index must be either a design document name or an array of a design document name and an index name")
+}
+
+// condition is a parsed selector that is evaluated against a JSON value
+type condition interface {
+	matches(value interface{}) bool
+}
+
+type andCondition []condition
+
+func (c andCondition) matches(value interface{}) bool {
+	for _, child := range c {
+		if !child.matches(value) {
+			return false
+		}
+	}
+	return true
+}
+
+type orCondition []condition
+
+func (c orCondition) matches(value interface{}) bool {
+	for _, child := range c {
+		if child.matches(value) {
+			return true
+		}
+	}
+	return false
+}
+
+type norCondition []condition
+
+func (c norCondition) matches(value interface{}) bool {
+	return !orCondition(c).matches(value)
+}
+
+type notCondition struct {
+	condition
+}
+
+func (c *notCondition) matches(value interface{}) bool {
+	return !c.condition.matches(value)
+}
+
+// fieldCondition applies an operator to a field of the value
+type fieldCondition struct {
+	path      []string
+	operator  string
+	argument  interface{}
+	regex     *regexp.Regexp
+	condition condition
+}
+
+// fieldName returns the field name in the dotted notation used by the sort and index definitions
+func (c *fieldCondition) fieldName() string {
+	return strings.Join(c.path, ".")
+}
+
+// impliesExistence returns true if the condition can only match when the field exists
+func (c *fieldCondition) impliesExistence() bool {
+	return c.operator != "$exists" || c.argument == true
+}
+
+func (c *fieldCondition) matches(value interface{}) bool {
+	fieldValue, exists := lookupField(value, c.path)
+	if !exists {
+		return c.operator == "$exists" && c.argument == false
+	}
+	switch c.operator {
+	case "$eq":
+		return compareJSON(fieldValue, c.argument) == 0
+	case "$ne":
+		return compareJSON(fieldValue, c.argument) != 0
+	case "$lt":
+		return compareJSON(fieldValue, c.argument) < 0
+	case "$lte":
+		return compareJSON(fieldValue, c.argument) <= 0
+	case "$gt":
+		return compareJSON(fieldValue, c.argument) > 0
+	case "$gte":
+		return compareJSON(fieldValue, c.argument) >= 0
+	case "$exists":
+		return c.argument == true
+	case "$type":
+		return jsonTypeName(fieldValue) == c.argument
+	case "$in":
+		return containsJSON(c.argument.([]interface{}), fieldValue)
+	case "$nin":
+		return !containsJSON(c.argument.([]interface{}), fieldValue)
+	case "$size":
+		array, ok := fieldValue.([]interface{})
+		return ok && int64(len(array)) == c.argument.(int64)
+	case "$mod":
+		n, ok := fieldValue.(json.Number)
+		if !ok {
+			return false
+		}
+		dividend, err := n.Int64()
+		if err != nil {
+			return false
+		}
+		divisorAndRemainder := c.argument.([]int64)
+		return dividend%divisorAndRemainder[0] == divisorAndRemainder[1]
+	case "$regex":
+		s, ok := fieldValue.(string)
+		return ok && c.regex.MatchString(s)
+	case "$all":
+		array, ok := fieldValue.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, element := range c.argument.([]interface{}) {
+			if !containsJSON(array, element) {
+				return false
+			}
+		}
+		return true
+	case "$elemMatch":
+		array, ok := fieldValue.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, element := range array {
+			if c.condition.matches(element) {
+				return true
+			}
+		}
+		return false
+	case "$allMatch":
+		array, ok := fieldValue.([]interface{})
+		if !ok || len(array) == 0 {
+			return false
+		}
+		for _, element := range array {
+			if !c.condition.matches(element) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// parseSelector parses the selector that applies to the field at the given path. The operators
+// other than the combination operators are allowed only when the selector applies to a field
+func parseSelector(selector map[string]interface{}, path []string, inField bool) (condition, error) {
+	var conditions andCondition
+	for _, key := range sortedKeys(selector) {
+		value := selector[key]
+		switch {
+		case key == "$and" || key == "$or" || key == "$nor":
+			items, ok := value.([]interface{})
+			if !ok || len(items) == 0 {
+				return nil, errors.Errorf("invalid selector, the argument of the operator [%s] must be a non-empty array of selectors", key)
+			}
+			var children []condition
+			for _, item := range items {
+				itemSelector, ok := item.(map[string]interface{})
+				if !ok {
+					return nil, errors.Errorf("invalid selector, the argument of the operator [%s] must be a non-empty array of selectors", key)
+				}
+				child, err := parseSelector(itemSelector, path, inField)
+				if err != nil {
+					return nil, err
+				}
+				children = append(children, child)
+			}
+			switch key {
+			case "$and":
+				conditions = append(conditions, andCondition(children))
+			case "$or":
+				conditions = append(conditions, orCondition(children))
+			default:
+				conditions = append(conditions, norCondition(children))
+			}
+		case key == "$not":
+			notSelector, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("invalid selector, the argument of the operator [$not] must be a selector")
+			}
+			child, err := parseSelector(notSelector, path, inField)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, &notCondition{child})
+		case strings.HasPrefix(key, "$"):
+			if !inField {
+				return nil, errors.Errorf("invalid selector, the operator [%s] must be applied to a field", key)
+			}
+			c, err := parseOperator(path, key, value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, c)
+		default:
+			fieldPath := append(append([]string{}, path...), splitFieldName(key)...)
+			if fieldSelector, ok := value.(map[string]interface{}); ok && len(fieldSelector) > 0 {
+				c, err := parseSelector(fieldSelector, fieldPath, true)
+				if err != nil {
+					return nil, err
+				}
+				conditions = append(conditions, c)
+				continue
+			}
+			conditions = append(conditions, &fieldCondition{path: fieldPath, operator: "$eq", argument: value})
+		}
+	}
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return conditions, nil
+}
+
+var jsonTypes = map[string]bool{"null": true, "boolean": true, "number": true, "string": true, "array": true, "object": true}
+
+func parseOperator(path []string, operator string, argument interface{}) (*fieldCondition, error) {
+	c := &fieldCondition{path: path, operator: operator, argument: argument}
+	switch operator {
+	case "$eq", "$ne", "$lt", "$lte", "$gt", "$gte":
+	case "$exists":
+		if _, ok := argument.(bool); !ok {
+			return nil, errors.New("invalid selector, the argument of the operator [$exists] must be a boolean")
+		}
+	case "$type":
+		if typeName, ok := argument.(string); !ok || !jsonTypes[typeName] {
+			return nil, errors.New("invalid selector, the argument of the operator [$type] must be one of null, boolean, number, string, array and object")
+		}
+	case "$in", "$nin", "$all":
+		if _, ok := argument.([]interface{}); !ok {
+			return nil, errors.Errorf("invalid selector, the argument of the operator [%s] must be an array", operator)
+		}
+	case "$size":
+		n, ok := argument.(json.Number)
+		size, err := n.Int64()
+		if !ok || err != nil || size < 0 {
+			return nil, errors.New("invalid selector, the argument of the operator [$size] must be a non-negative integer")
+		}
+		c.argument = size
+	case "$mod":
+		divisorAndRemainder, err := parseIntegers(argument)
+		if err != nil || len(divisorAndRemainder) != 2 || divisorAndRemainder[0] == 0 {
+			return nil, errors.New("invalid selector, the argument of the operator [$mod] must be an array of a non-zero divisor and a remainder")
+		}
+		c.argument = divisorAndRemainder
+	case "$regex":
+		pattern, ok := argument.(string)
+		if !ok {
+			return nil, errors.New("invalid selector, the argument of the operator [$regex] must be a string")
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid selector, the argument of the operator [$regex] must be a valid regular expression")
+		}
+		c.regex = regex
+	case "$elemMatch", "$allMatch":
+		elementSelector, ok := argument.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("invalid selector, the argument of the operator [%s] must be a selector", operator)
+		}
+		elementCondition, err := parseSelector(elementSelector, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		c.condition = elementCondition
+	default:
+		return nil, errors.Errorf("invalid selector, unsupported operator [%s]", operator)
+	}
+	return c, nil
+}
+
+func parseIntegers(value interface{}) ([]int64, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("not an array")
+	}
+	var integers []int64
+	for _, item := range items {
+		n, ok := item.(json.Number)
+		if !ok {
+			return nil, errors.New("not a number")
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, err
+		}
+		integers = append(integers, i)
+	}
+	return integers, nil
+}
+
+// topLevelFieldConditions returns the field conditions that must be satisfied by all the matching documents
+func topLevelFieldConditions(c condition) []*fieldCondition {
+	switch c := c.(type) {
+	case *fieldCondition:
+		return []*fieldCondition{c}
+	case andCondition:
+		var conditions []*fieldCondition
+		for _, child := range c {
+			conditions = append(conditions, topLevelFieldConditions(child)...)
+		}
+		return conditions
+	}
+	return nil
+}
+
+// splitFieldName splits a field name in the dotted notation into the path of the field. A dot
+// that is escaped by a backslash is a part of the field name
+func splitFieldName(fieldName string) []string {
+	var path []string
+	var current strings.Builder
+	for i := 0; i < len(fieldName); i++ {
+		switch {
+		case fieldName[i] == '\\' && i+1 < len(fieldName) && fieldName[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case fieldName[i] == '.':
+			path = append(path, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(fieldName[i])
+		}
+	}
+	return append(path, current.String())
+}
+
+func trimDesignDocPrefix(designDoc string) string {
+	return strings.TrimPrefix(designDoc, "_design/")
+}
+
+// lookupField returns the value of the field at the given path within the value
+func lookupField(value interface{}, path []string) (interface{}, bool) {
+	for _, field := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[field]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// project returns the document with only the given fields
+func project(doc map[string]interface{}, fields [][]string) map[string]interface{} {
+	projected := map[string]interface{}{}
+	for _, path := range fields {
+		value, ok := lookupField(doc, path)
+		if !ok {
+			continue
+		}
+		target := projected
+		for _, field := range path[:len(path)-1] {
+			child, ok := target[field].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				target[field] = child
+			}
+			target = child
+		}
+		target[path[len(path)-1]] = value
+	}
+	return projected
+}
+
+// unmarshalDocument returns the value as a document if it is a JSON object. The values that are
+// not JSON objects are not matched by any query, as for CouchDB
+func unmarshalDocument(value []byte) map[string]interface{} {
+	doc, err := unmarshalJSONObject(value)
+	if err != nil {
+		return nil
+	}
+	return doc
+}
+
+func unmarshalJSONObject(b []byte) (map[string]interface{}, error) {
+	var object map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+	if object == nil {
+		return nil, errors.New("not a JSON object")
+	}
+	return object, nil
+}
+
+// jsonTypeRank returns the rank of the type of the value in the collation order
+// null < false < true < numbers < strings < arrays < objects
+func jsonTypeRank(value interface{}) int {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if !value {
+			return 1
+		}
+		return 2
+	case json.Number:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	default:
+		return 6
+	}
+}
+
+func jsonTypeName(value interface{}) string {
+	return [...]string{"null", "boolean", "boolean", "number", "string", "array", "object"}[jsonTypeRank(value)]
+}
+
+// compareJSON compares two JSON values in the collation order of the types. The strings
+// are compared by their bytes, which differs from the unicode collation of CouchDB
+func compareJSON(a, b interface{}) int {
+	rankA, rankB := jsonTypeRank(a), jsonTypeRank(b)
+	if rankA != rankB {
+		return compareInts(rankA, rankB)
+	}
+	switch a := a.(type) {
+	case json.Number:
+		return numberValue(a).Cmp(numberValue(b.(json.Number)))
+	case string:
+		return strings.Compare(a, b.(string))
+	case []interface{}:
+		arrayB := b.([]interface{})
+		for i := 0; i < len(a) && i < len(arrayB); i++ {
+			if c := compareJSON(a[i], arrayB[i]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(a), len(arrayB))
+	case map[string]interface{}:
+		objectB := b.(map[string]interface{})
+		keysA, keysB := sortedKeys(a), sortedKeys(objectB)
+		for i := 0; i < len(keysA) && i < len(keysB); i++ {
+			if c := strings.Compare(keysA[i], keysB[i]); c != 0 {
+				return c
+			}
+			if c := compareJSON(a[keysA[i]], objectB[keysB[i]]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(keysA), len(keysB))
+	}
+	return 0
+}
+
+func containsJSON(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if compareJSON(v, value) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func numberValue(n json.Number) *big.Float {
+	f, _, err := big.ParseFloat(string(n), 10, 256, big.ToNearestEven)
+	if err != nil {
+		return new(big.Float)
+	}
+	return f
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,350 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// queryPlan specifies the range of the db keys that are scanned for a query. The range covers either
+// the entries of an index or, if no index is used, all the data keys of the namespace
+type queryPlan struct {
+	index      *indexDefinition
+	startKey   []byte
+	endKey     []byte
+	descending bool
+}
+
+// planQuery selects the index to use for the query. An index is used only if the selector requires
+// all the fields of the index to exist, as the index does not contain the documents that miss a field.
+// A query with a sort requires an index that orders the documents by the sort fields, after
+// the fields that the selector fixes to a single value, as for CouchDB
+func planQuery(ns string, q *query, indexes []*indexDefinition) (*queryPlan, error) {
+	conditions := map[string][]*fieldCondition{}
+	for _, c := range topLevelFieldConditions(q.selector) {
+		conditions[c.fieldName()] = append(conditions[c.fieldName()], c)
+	}
+
+	var bestIndex *indexDefinition
+	bestScore := 0
+	for _, index := range orderIndexes(indexes, q.useIndex) {
+		if !requiresAllFields(index, conditions) {
+			continue
+		}
+		numEqualities := 0
+		for numEqualities < len(index.Fields) && equalityArgument(conditions[index.Fields[numEqualities]]) != nil {
+			numEqualities++
+		}
+		if q.sort != nil {
+			if supportsSort(index.Fields, numEqualities, q.sort) {
+				return indexPlan(ns, index, conditions, numEqualities, q.sortDescending), nil
+			}
+			continue
+		}
+		if isPreferred(index, q.useIndex) {
+			return indexPlan(ns, index, conditions, numEqualities, false), nil
+		}
+		score := 2 * numEqualities
+		if numEqualities < len(index.Fields) {
+			if lower, upper := rangeArguments(conditions[index.Fields[numEqualities]]); lower != nil || upper != nil {
+				score++
+			}
+		}
+		if score > bestScore {
+			bestIndex, bestScore = index, score
+		}
+	}
+	if q.useIndex != nil {
+		logger.Warningf("the index [%s] is not used as it does not exist or cannot be used for the query", q.useIndex.designDoc)
+	}
+	if q.sort != nil {
+		return nil, errors.New("no index exists for this sort, try indexing by the sort fields")
+	}
+	if bestIndex != nil {
+		numEqualities := bestScore / 2
+		return indexPlan(ns, bestIndex, conditions, numEqualities, false), nil
+	}
+	return &queryPlan{
+		startKey: encodeDataKey(ns, ""),
+		endKey:   dataKeyStarterForNextNamespace(ns),
+	}, nil
+}
+
+// indexPlan returns the plan that scans the entries of the index with the values of the leading
+// fields that are fixed by the selector and, if the selector bounds the next field, within the bounds
+func indexPlan(ns string, index *indexDefinition, conditions map[string][]*fieldCondition, numEqualities int, descending bool) *queryPlan {
+	prefix := index.entryKeyStarter(ns)
+	for _, field := range index.Fields[:numEqualities] {
+		prefix = appendIndexValue(prefix, *equalityArgument(conditions[field]))
+	}
+	plan := &queryPlan{
+		index:      index,
+		startKey:   prefix,
+		endKey:     append(append([]byte{}, prefix...), entryKeyStopper),
+		descending: descending,
+	}
+	if numEqualities == len(index.Fields) {
+		return plan
+	}
+	// the bounds are always inclusive as the encoding of the numbers in the index may lose precision.
+	// The documents are filtered by the selector anyway
+	lower, upper := rangeArguments(conditions[index.Fields[numEqualities]])
+	if lower != nil {
+		plan.startKey = appendIndexValue(append([]byte{}, prefix...), *lower)
+	}
+	if upper != nil {
+		plan.endKey = append(appendIndexValue(append([]byte{}, prefix...), *upper), entryKeyStopper)
+	}
+	return plan
+}
+
+// orderIndexes returns the indexes in the order of the design document and the name,
+// with the index specified by the query, if any, at the front
+func orderIndexes(indexes []*indexDefinition, useIndex *indexName) []*indexDefinition {
+	ordered := append([]*indexDefinition{}, indexes...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if isPreferred(ordered[i], useIndex) != isPreferred(ordered[j], useIndex) {
+			return isPreferred(ordered[i], useIndex)
+		}
+		if ordered[i].DesignDoc != ordered[j].DesignDoc {
+			return ordered[i].DesignDoc < ordered[j].DesignDoc
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+	return ordered
+}
+
+func isPreferred(index *indexDefinition, useIndex *indexName) bool {
+	return useIndex != nil && index.DesignDoc == useIndex.designDoc && (useIndex.name == "" || index.Name == useIndex.name)
+}
+
+func requiresAllFields(index *indexDefinition, conditions map[string][]*fieldCondition) bool {
+	for _, field := range index.Fields {
+		required := false
+		for _, c := range conditions[field] {
+			required = required || c.impliesExistence()
+		}
+		if !required {
+			return false
+		}
+	}
+	return true
+}
+
+// supportsSort returns true if the sort fields follow some or all of the fields that are fixed by the selector
+func supportsSort(indexFields []string, numEqualities int, sortFields []string) bool {
+	for start := 0; start <= numEqualities && start+len(sortFields) <= len(indexFields); start++ {
+		matches := true
+		for i, sortField := range sortFields {
+			matches = matches && indexFields[start+i] == sortField
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// equalityArgument returns a pointer to the argument of the first $eq condition, if any.
+// Only the scalar arguments are considered as the index encoding of the arrays and objects
+// is not canonical with respect to the comparison of the values
+func equalityArgument(conditions []*fieldCondition) *interface{} {
+	for _, c := range conditions {
+		if c.operator == "$eq" && isScalar(c.argument) {
+			return &c.argument
+		}
+	}
+	return nil
+}
+
+// rangeArguments returns the greatest lower bound and the least upper bound of the conditions, if any.
+// As for the equality, only the scalar arguments are considered
+func rangeArguments(conditions []*fieldCondition) (*interface{}, *interface{}) {
+	var lower, upper *interface{}
+	for _, c := range conditions {
+		if !isScalar(c.argument) {
+			continue
+		}
+		switch c.operator {
+		case "$gt", "$gte":
+			if lower == nil || compareJSON(c.argument, *lower) > 0 {
+				lower = &c.argument
+			}
+		case "$lt", "$lte":
+			if upper == nil || compareJSON(c.argument, *upper) < 0 {
+				upper = &c.argument
+			}
+		}
+	}
+	return lower, upper
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case []interface{}, map[string]interface{}:
+		return false
+	}
+	return true
+}
+
+// queryScanner returns the documents within the range of the query plan that match the selector
+type queryScanner struct {
+	vdb                  *versionedDB
+	namespace            string
+	query                *query
+	plan                 *queryPlan
+	dbItr                *leveldbhelper.Iterator
+	positioned           bool
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+func newQueryScanner(vdb *versionedDB, namespace string, q *query, plan *queryPlan, bookmark string, requestedLimit int32) (*queryScanner, error) {
+	startKey, endKey := plan.startKey, plan.endKey
+	if bookmark != "" {
+		// the bookmark is the db key of the next result, which must be within the range of the query plan
+		bookmarkKey, err := base64.RawURLEncoding.DecodeString(bookmark)
+		if err != nil || bytes.Compare(bookmarkKey, startKey) < 0 || bytes.Compare(bookmarkKey, endKey) >= 0 {
+			return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+		}
+		if plan.descending {
+			endKey = append(bookmarkKey, 0x00)
+		} else {
+			startKey = bookmarkKey
+		}
+	}
+	dbItr, err := vdb.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	scanner := &queryScanner{
+		vdb:            vdb,
+		namespace:      namespace,
+		query:          q,
+		plan:           plan,
+		dbItr:          dbItr,
+		requestedLimit: requestedLimit,
+	}
+	// the skip applies to the first page only
+	if bookmark == "" {
+		for i := 0; i < q.skip; i++ {
+			kv, _, err := scanner.nextMatch()
+			if err != nil {
+				scanner.Close()
+				return nil, err
+			}
+			if kv == nil {
+				break
+			}
+		}
+	}
+	return scanner, nil
+}
+
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	kv, _, err := scanner.nextMatch()
+	if err != nil || kv == nil {
+		return nil, err
+	}
+	scanner.totalRecordsReturned++
+	return kv, nil
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+// GetBookmarkAndClose returns the bookmark for the next page, which is empty if there are no more results
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	kv, dbKey, err := scanner.nextMatch()
+	if err != nil {
+		logger.Errorf("error while retrieving the bookmark: %s", err)
+		return ""
+	}
+	if kv == nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(dbKey)
+}
+
+// nextMatch returns the next document that matches the selector, along with its db key in the scanned range
+func (scanner *queryScanner) nextMatch() (*statedb.VersionedKV, []byte, error) {
+	for scanner.advance() {
+		dbKey := append([]byte{}, scanner.dbItr.Key()...)
+		var key string
+		var dbVal []byte
+		if scanner.plan.index == nil {
+			_, key = decodeDataKey(dbKey)
+			dbVal = append([]byte{}, scanner.dbItr.Value()...)
+		} else {
+			key = string(scanner.dbItr.Value())
+			var err error
+			if dbVal, err = scanner.vdb.db.Get(encodeDataKey(scanner.namespace, key)); err != nil {
+				return nil, nil, err
+			}
+			if dbVal == nil {
+				continue
+			}
+		}
+		vv, err := decodeValue(dbVal)
+		if err != nil {
+			return nil, nil, err
+		}
+		doc := unmarshalDocument(vv.Value)
+		if doc == nil || !scanner.query.selector.matches(doc) {
+			continue
+		}
+		if scanner.query.fields != nil {
+			if vv.Value, err = marshalDocument(project(doc, scanner.query.fields)); err != nil {
+				return nil, nil, err
+			}
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+			VersionedValue: *vv,
+		}, dbKey, nil
+	}
+	if err := scanner.dbItr.Error(); err != nil {
+		return nil, nil, errors.Wrap(err, "internal leveldb error while retrieving data from db iterator")
+	}
+	return nil, nil, nil
+}
+
+func (scanner *queryScanner) advance() bool {
+	if !scanner.positioned {
+		scanner.positioned = true
+		if scanner.plan.descending {
+			return scanner.dbItr.Last()
+		}
+		return scanner.dbItr.Next()
+	}
+	if scanner.plan.descending {
+		return scanner.dbItr.Prev()
+	}
+	return scanner.dbItr.Next()
+}
+
+func marshalDocument(doc map[string]interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, errors.Wrap(err, "error while marshalling the projected document")
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorOperators(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testselectoroperators", nil)
+	require.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"color":"blue","size":1,"owner":{"name":"tom"},"tags":["a","b"]}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"color":"red","size":2.5,"owner":{"name":"jerry"},"tags":["b"]}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"color":"green","size":3,"owner":null,"tags":[]}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte(`{"color":"blue","size":"large","a.b":true}`), version.NewHeight(1, 4))
+	batch.Put("ns1", "key5", []byte(`not a JSON value`), version.NewHeight(1, 5))
+	batch.Put("ns1", "key6", []byte(`["not","an","object"]`), version.NewHeight(1, 6))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 6)))
+
+	testCases := []struct {
+		selector     string
+		expectedKeys []string
+	}{
+		{`{}`, []string{"key1", "key2", "key3", "key4"}},
+		{`{"color":"blue"}`, []string{"key1", "key4"}},
+		{`{"color":{"$ne":"blue"}}`, []string{"key2", "key3"}},
+		{`{"size":{"$gt":1}}`, []string{"key2", "key3", "key4"}},
+		{`{"size":{"$gte":1,"$lt":3}}`, []string{"key1", "key2"}},
+		{`{"size":{"$lte":2.5}}`, []string{"key1", "key2"}},
+		{`{"size":{"$type":"number"}}`, []string{"key1", "key2", "key3"}},
+		{`{"owner":{"$exists":false}}`, []string{"key4"}},
+		{`{"owner":{"$type":"null"}}`, []string{"key3"}},
+		{`{"owner.name":"tom"}`, []string{"key1"}},
+		{`{"owner":{"name":{"$regex":"^j"}}}`, []string{"key2"}},
+		{`{"a\\.b":true}`, []string{"key4"}},
+		{`{"color":{"$in":["red","green"]}}`, []string{"key2", "key3"}},
+		{`{"color":{"$nin":["red","green"]}}`, []string{"key1", "key4"}},
+		{`{"tags":{"$size":1}}`, []string{"key2"}},
+		{`{"tags":{"$all":["a","b"]}}`, []string{"key1"}},
+		{`{"tags":{"$elemMatch":{"$eq":"b"}}}`, []string{"key1", "key2"}},
+		{`{"tags":{"$allMatch":{"$eq":"b"}}}`, []string{"key2"}},
+		{`{"size":{"$mod":[2,1]}}`, []string{"key1", "key3"}},
+		{`{"$or":[{"color":"red"},{"size":3}]}`, []string{"key2", "key3"}},
+		{`{"$nor":[{"color":"red"},{"size":3}]}`, []string{"key1", "key4"}},
+		{`{"$not":{"color":"blue"}}`, []string{"key2", "key3"}},
+		{`{"color":"blue","$and":[{"size":{"$type":"string"}}]}`, []string{"key4"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.selector, func(t *testing.T) {
+			require.Equal(t, testCase.expectedKeys, queryKeys(t, db, "ns1", `{"selector":`+testCase.selector+`}`))
+		})
+	}
+}
+
+func TestQueryOptions(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testqueryoptions", nil)
+	require.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"color":"blue","size":1,"owner":{"name":"tom","age":30}}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"color":"blue","size":2,"owner":{"name":"jerry","age":40}}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"color":"blue","size":3}`), version.NewHeight(1, 3))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)))
+
+	t.Run("fields", func(t *testing.T) {
+		itr, err := db.ExecuteQuery("ns1", `{"selector":{"size":1},"fields":["size","owner.name","missing"]}`)
+		require.NoError(t, err)
+		defer itr.Close()
+		result, err := itr.Next()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"size":1,"owner":{"name":"tom"}}`, string(result.(*statedb.VersionedKV).Value))
+		require.Equal(t, version.NewHeight(1, 1), result.(*statedb.VersionedKV).Version)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		require.Equal(t, []string{"key3"}, queryKeys(t, db, "ns1", `{"selector":{"color":"blue"},"skip":2}`))
+	})
+
+	t.Run("limit in the query is ignored", func(t *testing.T) {
+		require.Equal(t, []string{"key1", "key2", "key3"}, queryKeys(t, db, "ns1", `{"selector":{"color":"blue"},"limit":1}`))
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		for query, expectedErr := range map[string]string{
+			`{"selector":{"$gt":1}}`:                  "invalid selector, the operator [$gt] must be applied to a field",
+			`{"selector":{"size":{"$size":"one"}}}`:   "invalid selector, the argument of the operator [$size] must be a non-negative integer",
+			`{"selector":{"size":{"$in":1}}}`:         "invalid selector, the argument of the operator [$in] must be an array",
+			`{"selector":{"size":{"$exists":"yes"}}}`: "invalid selector, the argument of the operator [$exists] must be a boolean",
+			`{"selector":{"$or":[]}}`:                 "invalid selector, the argument of the operator [$or] must be a non-empty array of selectors",
+			`{"selector":{"size":1},"sort":["size"]}`: "no index exists for this sort, try indexing by the sort fields",
+		} {
+			_, err := db.ExecuteQuery("ns1", query)
+			require.Error(t, err, query)
+			require.Contains(t, err.Error(), expectedErr, query)
+		}
+		_, err := db.ExecuteQuery("ns1", `{"selector":{"size":1},"unknown":true}`)
+		require.Error(t, err)
+		_, err = db.ExecuteQuery("ns1", `not a JSON query`)
+		require.Error(t, err)
+	})
+}
+
+func TestCompareJSON(t *testing.T) {
+	values := []string{`null`, `false`, `true`, `-10`, `-1.5`, `0`, `2`, `10`, `""`, `"A"`, `"a"`, `"aa"`, `[]`, `[1]`, `[1,2]`, `[2]`, `{}`, `{"a":1}`}
+	var parsed []interface{}
+	for _, value := range values {
+		doc, err := unmarshalJSONObject([]byte(`{"v":` + value + `}`))
+		require.NoError(t, err)
+		parsed = append(parsed, doc["v"])
+	}
+	for i := range parsed {
+		for j := range parsed {
+			expected := compareInts(i, j)
+			require.Equal(t, expected, compareJSON(parsed[i], parsed[j]), "%s vs %s", values[i], values[j])
+			if isScalar(parsed[i]) && isScalar(parsed[j]) && expected != 0 {
+				// the index encoding of the scalar values preserves the collation order
+				require.Equal(t, expected < 0, string(appendIndexValue(nil, parsed[i])) < string(appendIndexValue(nil, parsed[j])), "%s vs %s", values[i], values[j])
+			}
+		}
+	}
+}
+
+func queryKeys(t *testing.T, db statedb.VersionedDB, ns, query string) []string {
+	itr, err := db.ExecuteQuery(ns, query)
+	require.NoError(t, err)
+	defer itr.Close()
+	var keys []string
+	for {
+		result, err := itr.Next()
+		require.NoError(t, err)
+		if result == nil {
+			return keys
+		}
+		keys = append(keys, result.(*statedb.VersionedKV).Key)
+	}
+}
@@ -0,0 +1,638 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/dataformat"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+var logger = flogging.MustGetLogger("statejsondb")
+
+var (
+	dataKeyPrefix       = []byte{'d'}
+	dataKeyStopper      = []byte{'e'}
+	indexDefKeyPrefix   = []byte{'x'}
+	indexEntryKeyPrefix = []byte{'i'}
+	nsKeySep            = []byte{0x00}
+	lastKeyIndicator    = byte(0x01)
+	savePointKey        = []byte{'s'}
+	// the values are stored in the same format as in the goleveldb based statedb
+	// and hence, the snapshots are interchangeable between the two
+	fullScanIteratorValueFormat = byte(1)
+	maxDataImportBatchSize      = 4 * 1024 * 1024
+	// dbFormat differs from the format of the goleveldb based statedb so that a statedb created
+	// by one implementation is not opened by the other one. Switching between the two requires
+	// the statedb to be dropped and rebuilt
+	dbFormat = dataformat.CurrentFormat + "-jsondb"
+)
+
+// VersionedDBProvider implements interface VersionedDBProvider
+type VersionedDBProvider struct {
+	dbProvider *leveldbhelper.Provider
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider
+func NewVersionedDBProvider(dbPath string) (*VersionedDBProvider, error) {
+	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
+	dbProvider, err := leveldbhelper.NewProvider(
+		&leveldbhelper.Conf{
+			DBPath:         dbPath,
+			ExpectedFormat: dbFormat,
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &VersionedDBProvider{dbProvider}, nil
+}
+
+// GetDBHandle gets the handle to a named database
+func (provider *VersionedDBProvider) GetDBHandle(dbName string, namespaceProvider statedb.NamespaceProvider) (statedb.VersionedDB, error) {
+	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+}
+
+// ImportFromSnapshot implements method in VersionedDBProvider interface
+func (provider *VersionedDBProvider) ImportFromSnapshot(
+	dbName string,
+	savepoint *version.Height,
+	itr statedb.FullScanIterator,
+	dbValueFormat byte,
+) error {
+	if dbValueFormat != fullScanIteratorValueFormat {
+		return errors.Errorf("unexpected db value format [%d], the snapshot cannot be imported into a JSONDB based statedb", dbValueFormat)
+	}
+	vdb := newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName)
+	existingSavepoint, err := vdb.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.Errorf("statedb for ledger [%s] is not empty. Import is supported only on an empty statedb", dbName)
+	}
+
+	dbBatch := vdb.db.NewUpdateBatch()
+	batchSize := 0
+	for {
+		compositeKey, dbValue, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if compositeKey == nil {
+			break
+		}
+		dataKey := encodeDataKey(compositeKey.Namespace, compositeKey.Key)
+		dbBatch.Put(dataKey, dbValue)
+		batchSize += len(dataKey) + len(dbValue)
+		if batchSize >= maxDataImportBatchSize {
+			if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			batchSize = 0
+			dbBatch = vdb.db.NewUpdateBatch()
+		}
+	}
+	dbBatch.Put(savePointKey, savepoint.ToBytes())
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// Close closes the underlying db
+func (provider *VersionedDBProvider) Close() {
+	provider.dbProvider.Close()
+}
+
+// versionedDB implements VersionedDB interface. In addition to the key-values, it maintains
+// the JSON indexes that are defined for a namespace and uses them for executing the rich queries
+type versionedDB struct {
+	db     *leveldbhelper.DBHandle
+	dbName string
+
+	// indexesLock serializes the updates of the data with the creation of the indexes
+	// so that the index entries are consistent with the data
+	indexesLock sync.Mutex
+	indexes     map[string][]*indexDefinition
+}
+
+// newVersionedDB constructs an instance of VersionedDB
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
+	return &versionedDB{
+		db:      db,
+		dbName:  dbName,
+		indexes: map[string][]*indexDefinition{},
+	}
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because shared db is used
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because shared db is used
+}
+
+// ValidateKeyValue implements method in VersionedDB interface
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	return nil
+}
+
+// BytesKeySupported implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySupported() bool {
+	return true
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	dbVal, err := vdb.db.Get(encodeDataKey(namespace, key))
+	if err != nil {
+		return nil, err
+	}
+	if dbVal == nil {
+		return nil, nil
+	}
+	return decodeValue(dbVal)
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	versionedValue, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if versionedValue == nil {
+		return nil, nil
+	}
+	return versionedValue.Version, nil
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	for i, key := range keys {
+		val, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	// pageSize = 0 denotes unlimited page size
+	return vdb.GetStateRangeScanIteratorWithPagination(namespace, startKey, endKey, 0)
+}
+
+// GetStateRangeScanIteratorWithPagination implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (statedb.QueryResultsIterator, error) {
+	dataStartKey := encodeDataKey(namespace, startKey)
+	dataEndKey := encodeDataKey(namespace, endKey)
+	if endKey == "" {
+		dataEndKey[len(dataEndKey)-1] = lastKeyIndicator
+	}
+	dbItr, err := vdb.db.GetIterator(dataStartKey, dataEndKey)
+	if err != nil {
+		return nil, err
+	}
+	return newKVScanner(namespace, dbItr, pageSize), nil
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.ExecuteQueryWithPagination(namespace, query, "", 0)
+}
+
+// ExecuteQueryWithPagination implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQueryWithPagination(namespace, query, bookmark string, pageSize int32) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering ExecuteQueryWithPagination namespace: %s,  query: %s,  bookmark: %s, pageSize: %d", namespace, query, bookmark, pageSize)
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := vdb.getIndexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planQuery(namespace, q, indexes)
+	if err != nil {
+		return nil, err
+	}
+	return newQueryScanner(vdb, namespace, q, plan, bookmark, pageSize)
+}
+
+// ApplyUpdates implements method in VersionedDB interface
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+
+	dbBatch := vdb.db.NewUpdateBatch()
+	namespaces := batch.GetUpdatedNamespaces()
+	for _, ns := range namespaces {
+		indexes, err := vdb.loadIndexes(ns)
+		if err != nil {
+			return err
+		}
+		updates := batch.GetUpdates(ns)
+		for k, vv := range updates {
+			dataKey := encodeDataKey(ns, k)
+			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(dataKey), dataKey)
+
+			if len(indexes) > 0 {
+				if err := vdb.addIndexUpdates(dbBatch, ns, k, vv, indexes); err != nil {
+					return err
+				}
+			}
+			if vv.Value == nil {
+				dbBatch.Delete(dataKey)
+			} else {
+				encodedVal, err := encodeValue(vv)
+				if err != nil {
+					return err
+				}
+				dbBatch.Put(dataKey, encodedVal)
+			}
+		}
+	}
+	// Record a savepoint at a given height
+	// If a given height is nil, it denotes that we are committing pvt data of old blocks.
+	// In this case, we should not store a savepoint for recovery. The lastUpdatedOldBlockList
+	// in the pvtstore acts as a savepoint for pvt data.
+	if height != nil {
+		dbBatch.Put(savePointKey, height.ToBytes())
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// addIndexUpdates adds to the batch the removal of the index entries of the committed value of the key
+// and the index entries of the new value of the key
+func (vdb *versionedDB) addIndexUpdates(
+	dbBatch *leveldbhelper.UpdateBatch,
+	ns, key string,
+	vv *statedb.VersionedValue,
+	indexes []*indexDefinition,
+) error {
+	committedVal, err := vdb.GetState(ns, key)
+	if err != nil {
+		return err
+	}
+	if committedVal != nil {
+		committedDoc := unmarshalDocument(committedVal.Value)
+		for _, index := range indexes {
+			if entryKey := index.entryKey(ns, key, committedDoc); entryKey != nil {
+				dbBatch.Delete(entryKey)
+			}
+		}
+	}
+	if vv.Value == nil {
+		return nil
+	}
+	doc := unmarshalDocument(vv.Value)
+	for _, index := range indexes {
+		if entryKey := index.entryKey(ns, key, doc); entryKey != nil {
+			dbBatch.Put(entryKey, []byte(key))
+		}
+	}
+	return nil
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	versionBytes, err := vdb.db.Get(savePointKey)
+	if err != nil {
+		return nil, err
+	}
+	if versionBytes == nil {
+		return nil, nil
+	}
+	version, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// GetFullScanIterator implements method in VersionedDB interface. This function returns a
+// FullScanIterator that can be used to iterate over entire data in the statedb for a channel.
+// The index entries are not included as they are derived from the data.
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.FullScanIterator, byte, error) {
+	return newFullDBScanner(vdb.db, skipNamespace)
+}
+
+// ProcessIndexesForChaincodeDeploy creates the indexes for a specified namespace. The index
+// definitions are in the format of the CouchDB JSON indexes.
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, indexFilesData map[string][]byte) error {
+	// As for CouchDB, all valid indexes are processed and the index files are processed in the order
+	// of the file names so that all peers end up with the same indexes
+	var indexFilesName []string
+	for fileName := range indexFilesData {
+		indexFilesName = append(indexFilesName, fileName)
+	}
+	sort.Strings(indexFilesName)
+	for _, fileName := range indexFilesName {
+		index, err := parseIndexDefinition(indexFilesData[fileName])
+		if err == nil {
+			err = vdb.createIndex(namespace, index)
+		}
+		switch {
+		case err != nil:
+			logger.Errorf("error creating index from file [%s] for chaincode [%s] on channel [%s]: %+v",
+				fileName, namespace, vdb.dbName, err)
+		default:
+			logger.Infof("successfully created index present in the file [%s] for chaincode [%s] on channel [%s]",
+				fileName, namespace, vdb.dbName)
+		}
+	}
+	return nil
+}
+
+// GetDBType returns the type of the index definitions that are processed by this statedb. The
+// index definitions for CouchDB are used so that the existing chaincode packages can be used as is
+func (vdb *versionedDB) GetDBType() string {
+	return "couchdb"
+}
+
+// getIndexes returns the indexes defined for the namespace
+func (vdb *versionedDB) getIndexes(ns string) ([]*indexDefinition, error) {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+	return vdb.loadIndexes(ns)
+}
+
+// loadIndexes returns the indexes defined for the namespace, which are loaded from the db
+// on the first use. The caller is expected to hold the indexesLock
+func (vdb *versionedDB) loadIndexes(ns string) ([]*indexDefinition, error) {
+	if indexes, ok := vdb.indexes[ns]; ok {
+		return indexes, nil
+	}
+	dbItr, err := vdb.db.GetIterator(encodeIndexDefKey(ns, "", ""), indexDefKeyStarterForNextNamespace(ns))
+	if err != nil {
+		return nil, err
+	}
+	defer dbItr.Release()
+	var indexes []*indexDefinition
+	for dbItr.Next() {
+		index, err := unmarshalIndexDefinition(dbItr.Value())
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	if err := dbItr.Error(); err != nil {
+		return nil, errors.Wrap(err, "internal leveldb error while retrieving data from db iterator")
+	}
+	vdb.indexes[ns] = indexes
+	return indexes, nil
+}
+
+// createIndex builds the index entries for the existing data in the namespace and records
+// the index definition. An existing index with the same design document and name is replaced
+func (vdb *versionedDB) createIndex(ns string, index *indexDefinition) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+
+	existingIndexes, err := vdb.loadIndexes(ns)
+	if err != nil {
+		return err
+	}
+	for _, existingIndex := range existingIndexes {
+		if existingIndex.equal(index) {
+			logger.Infof("index [%s] of design document [%s] already exists for chaincode [%s] on channel [%s]",
+				index.Name, index.DesignDoc, ns, vdb.dbName)
+			return nil
+		}
+	}
+	// clear any existing entries of the index before building the index afresh
+	if err := vdb.deleteRange(index.entryKeyStarter(ns), index.entryKeyStopper(ns)); err != nil {
+		return err
+	}
+
+	dataItr, err := vdb.db.GetIterator(encodeDataKey(ns, ""), dataKeyStarterForNextNamespace(ns))
+	if err != nil {
+		return err
+	}
+	defer dataItr.Release()
+	dbBatch := vdb.db.NewUpdateBatch()
+	batchSize := 0
+	for dataItr.Next() {
+		_, key := decodeDataKey(dataItr.Key())
+		vv, err := decodeValue(dataItr.Value())
+		if err != nil {
+			return err
+		}
+		entryKey := index.entryKey(ns, key, unmarshalDocument(vv.Value))
+		if entryKey == nil {
+			continue
+		}
+		dbBatch.Put(entryKey, []byte(key))
+		batchSize += len(entryKey) + len(key)
+		if batchSize >= maxDataImportBatchSize {
+			if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			batchSize = 0
+			dbBatch = vdb.db.NewUpdateBatch()
+		}
+	}
+	if err := dataItr.Error(); err != nil {
+		return errors.Wrap(err, "internal leveldb error while retrieving data from db iterator")
+	}
+	indexDefBytes, err := index.marshal()
+	if err != nil {
+		return err
+	}
+	dbBatch.Put(encodeIndexDefKey(ns, index.DesignDoc, index.Name), indexDefBytes)
+	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+
+	var indexes []*indexDefinition
+	for _, existingIndex := range existingIndexes {
+		if existingIndex.DesignDoc != index.DesignDoc || existingIndex.Name != index.Name {
+			indexes = append(indexes, existingIndex)
+		}
+	}
+	vdb.indexes[ns] = append(indexes, index)
+	return nil
+}
+
+// deleteRange deletes all the keys between the startKey (inclusive) and the endKey (exclusive)
+func (vdb *versionedDB) deleteRange(startKey, endKey []byte) error {
+	dbItr, err := vdb.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer dbItr.Release()
+	dbBatch := vdb.db.NewUpdateBatch()
+	batchSize := 0
+	for dbItr.Next() {
+		key := dbItr.Key()
+		dbBatch.Delete(key)
+		batchSize += len(key)
+		if batchSize >= maxDataImportBatchSize {
+			if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			batchSize = 0
+			dbBatch = vdb.db.NewUpdateBatch()
+		}
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrap(err, "internal leveldb error while retrieving data from db iterator")
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+func encodeDataKey(ns, key string) []byte {
+	k := append([]byte{}, dataKeyPrefix...)
+	k = append(k, []byte(ns)...)
+	k = append(k, nsKeySep...)
+	return append(k, []byte(key)...)
+}
+
+func decodeDataKey(encodedDataKey []byte) (string, string) {
+	split := bytes.SplitN(encodedDataKey, nsKeySep, 2)
+	return string(split[0][1:]), string(split[1])
+}
+
+func dataKeyStarterForNextNamespace(ns string) []byte {
+	k := append([]byte{}, dataKeyPrefix...)
+	k = append(k, []byte(ns)...)
+	return append(k, lastKeyIndicator)
+}
+
+func encodeIndexDefKey(ns, designDoc, name string) []byte {
+	k := append([]byte{}, indexDefKeyPrefix...)
+	k = append(k, []byte(ns)...)
+	if designDoc == "" && name == "" {
+		return append(k, nsKeySep...)
+	}
+	k = append(k, nsKeySep...)
+	k = append(k, []byte(designDoc)...)
+	k = append(k, nsKeySep...)
+	return append(k, []byte(name)...)
+}
+
+func indexDefKeyStarterForNextNamespace(ns string) []byte {
+	k := append([]byte{}, indexDefKeyPrefix...)
+	k = append(k, []byte(ns)...)
+	return append(k, lastKeyIndicator)
+}
+
+type kvScanner struct {
+	namespace            string
+	dbItr                iterator.Iterator
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+func newKVScanner(namespace string, dbItr iterator.Iterator, requestedLimit int32) *kvScanner {
+	return &kvScanner{namespace, dbItr, requestedLimit, 0}
+}
+
+func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	if !scanner.dbItr.Next() {
+		return nil, nil
+	}
+
+	dbKey := scanner.dbItr.Key()
+	dbVal := scanner.dbItr.Value()
+	dbValCopy := make([]byte, len(dbVal))
+	copy(dbValCopy, dbVal)
+	_, key := decodeDataKey(dbKey)
+	vv, err := decodeValue(dbValCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner.totalRecordsReturned++
+	return &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+		VersionedValue: *vv,
+	}, nil
+}
+
+func (scanner *kvScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+func (scanner *kvScanner) GetBookmarkAndClose() string {
+	retval := ""
+	if scanner.dbItr.Next() {
+		dbKey := scanner.dbItr.Key()
+		_, key := decodeDataKey(dbKey)
+		retval = key
+	}
+	scanner.Close()
+	return retval
+}
+
+type fullDBScanner struct {
+	db     *leveldbhelper.DBHandle
+	dbItr  iterator.Iterator
+	toSkip func(namespace string) bool
+}
+
+func newFullDBScanner(db *leveldbhelper.DBHandle, skipNamespace func(namespace string) bool) (*fullDBScanner, byte, error) {
+	dbItr, err := db.GetIterator(dataKeyPrefix, dataKeyStopper)
+	if err != nil {
+		return nil, byte(0), err
+	}
+	return &fullDBScanner{
+			db:     db,
+			dbItr:  dbItr,
+			toSkip: skipNamespace,
+		},
+		fullScanIteratorValueFormat,
+		nil
+}
+
+// Next returns the key-values in the lexical order of <Namespace, key>
+// The bytes returned for the <version, value, metadata> are the same as they are stored in the db
+func (s *fullDBScanner) Next() (*statedb.CompositeKey, []byte, error) {
+	for s.dbItr.Next() {
+		dbKey := s.dbItr.Key()
+		dbVal := s.dbItr.Value()
+		ns, key := decodeDataKey(dbKey)
+		compositeKey := &statedb.CompositeKey{
+			Namespace: ns,
+			Key:       key,
+		}
+
+		switch {
+		case !s.toSkip(ns):
+			return compositeKey, dbVal, nil
+		default:
+			s.dbItr.Seek(dataKeyStarterForNextNamespace(ns))
+			s.dbItr.Prev()
+		}
+	}
+	return nil, nil, errors.Wrap(s.dbItr.Error(), "internal leveldb error while retrieving data from db iterator")
+}
+
+func (s *fullDBScanner) Close() {
+	if s == nil {
+		return
+	}
+	s.dbItr.Release()
+}
@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statejsondb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBasicRW(t, env.DBProvider)
+}
+
+func TestMultiDBBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestMultiDBBasicRW(t, env.DBProvider)
+}
+
+func TestDeletes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestDeletes(t, env.DBProvider)
+}
+
+func TestIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestIterator(t, env.DBProvider)
+}
+
+func TestQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestQuery(t, env.DBProvider)
+}
+
+func TestGetStateMultipleKeys(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetStateMultipleKeys(t, env.DBProvider)
+}
+
+func TestGetVersion(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetVersion(t, env.DBProvider)
+}
+
+func TestSmallBatchSize(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestSmallBatchSize(t, env.DBProvider)
+}
+
+func TestBatchWithIndividualRetry(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBatchWithIndividualRetry(t, env.DBProvider)
+}
+
+func TestUtilityFunctions(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+
+	db, err := env.DBProvider.GetDBHandle("testutilityfunctions", nil)
+	require.NoError(t, err)
+
+	// BytesKeySupported should be true as for goleveldb
+	require.True(t, db.BytesKeySupported())
+
+	// ValidateKeyValue should return nil for a valid key and value
+	require.NoError(t, db.ValidateKeyValue("testKey", []byte("testValue")), "JSONDB should accept all key-values")
+
+	// the CouchDB index definitions packaged with the chaincodes are used
+	require.Equal(t, "couchdb", db.(statedb.IndexCapable).GetDBType())
+}
+
+func TestValueAndMetadataWrites(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestValueAndMetadataWrites(t, env.DBProvider)
+}
+
+func TestPaginatedRangeQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestPaginatedRangeQuery(t, env.DBProvider)
+}
+
+func TestRangeQuerySpecialCharacters(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestRangeQuerySpecialCharacters(t, env.DBProvider)
+}
+
+func TestApplyUpdatesWithNilHeight(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
+}
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestFullScanIterator(
+		t,
+		env.DBProvider,
+		byte(1),
+		func(dbVal []byte) (*statedb.VersionedValue, error) {
+			return decodeValue(dbVal)
+		},
+	)
+}
+
+func TestFullScanIteratorSkipsIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testfullscanindexes", nil)
+	require.NoError(t, err)
+	require.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"indexOwner.json": []byte(`{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`),
+	}))
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 1))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+
+	itr, format, err := db.GetFullScanIterator(func(string) bool { return false })
+	require.NoError(t, err)
+	defer itr.Close()
+	require.Equal(t, byte(1), format)
+	compositeKey, _, err := itr.Next()
+	require.NoError(t, err)
+	require.Equal(t, &statedb.CompositeKey{Namespace: "ns1", Key: "key1"}, compositeKey)
+	compositeKey, _, err = itr.Next()
+	require.NoError(t, err)
+	require.Nil(t, compositeKey)
+}
+
+func TestImportFromSnapshot(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestImportFromSnapshot(t, env.DBProvider)
+}
+
+func TestImportFromGoleveldbSnapshot(t *testing.T) {
+	sourceEnv := stateleveldb.NewTestVDBEnv(t)
+	defer sourceEnv.Cleanup()
+	sourceDB, err := sourceEnv.DBProvider.GetDBHandle("sourceledger", nil)
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"owner":"jerry"}`), version.NewHeight(1, 2))
+	require.NoError(t, sourceDB.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	itr, format, err := sourceDB.GetFullScanIterator(func(string) bool { return false })
+	require.NoError(t, err)
+	defer itr.Close()
+
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	require.NoError(t, env.DBProvider.ImportFromSnapshot("destledger", version.NewHeight(1, 2), itr, format))
+	db, err := env.DBProvider.GetDBHandle("destledger", nil)
+	require.NoError(t, err)
+	require.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"indexOwner.json": []byte(`{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`),
+	}))
+	require.Equal(t, []string{"key2"}, queryKeys(t, db, "ns1", `{"selector":{"owner":"jerry"},"use_index":"indexOwnerDoc"}`))
+
+	err = env.DBProvider.ImportFromSnapshot("otherledger", version.NewHeight(1, 2), itr, byte(2))
+	require.EqualError(t, err, "unexpected db value format [2], the snapshot cannot be imported into a JSONDB based statedb")
+}
+
+func TestFormatMismatchWithGoleveldb(t *testing.T) {
+	dbPath, err := ioutil.TempDir("", "statejsondb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dbPath)
+
+	levelDBProvider, err := stateleveldb.NewVersionedDBProvider(dbPath)
+	require.NoError(t, err)
+	levelDB, err := levelDBProvider.GetDBHandle("testledger", nil)
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	require.NoError(t, levelDB.ApplyUpdates(batch, version.NewHeight(1, 1)))
+	levelDBProvider.Close()
+
+	_, err = NewVersionedDBProvider(dbPath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected format")
+}
+
+func TestDataKeyEncoding(t *testing.T) {
+	for _, k := range []struct{ ns, key string }{{"ns", "key"}, {"ns", ""}} {
+		ns, key := decodeDataKey(encodeDataKey(k.ns, k.key))
+		require.Equal(t, k.ns, ns)
+		require.Equal(t, k.key, key)
+	}
+}
@@ -27,6 +27,7 @@ var (
 	lastKeyIndicator            = byte(0x01)
 	savePointKey                = []byte{'s'}
 	fullScanIteratorValueFormat = byte(1)
+	maxDataImportBatchSize      = 4 * 1024 * 1024
 )
 
 // VersionedDBProvider implements interface VersionedDBProvider
@@ -53,6 +54,52 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string, namespaceProvide
 	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
 }
 
+// ImportFromSnapshot implements method in VersionedDBProvider interface
+func (provider *VersionedDBProvider) ImportFromSnapshot(
+	dbName string,
+	savepoint *version.Height,
+	itr statedb.FullScanIterator,
+	dbValueFormat byte,
+) error {
+	if dbValueFormat != fullScanIteratorValueFormat {
+		return errors.Errorf("unexpected db value format [%d], the snapshot cannot be imported into a goleveldb based statedb", dbValueFormat)
+	}
+	vdb := newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName)
+	existingSavepoint, err := vdb.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.Errorf("statedb for ledger [%s] is not empty. Import is supported only on an empty statedb", dbName)
+	}
+
+	dbBatch := vdb.db.NewUpdateBatch()
+	batchSize := 0
+	for {
+		compositeKey, dbValue, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if compositeKey == nil {
+			break
+		}
+		// the values exported by the fullDBScanner are in the same format as stored in the
+		// leveldb and hence can be loaded as is
+		dataKey := encodeDataKey(compositeKey.Namespace, compositeKey.Key)
+		dbBatch.Put(dataKey, dbValue)
+		batchSize += len(dataKey) + len(dbValue)
+		if batchSize >= maxDataImportBatchSize {
+			if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			batchSize = 0
+			dbBatch = vdb.db.NewUpdateBatch()
+		}
+	}
+	dbBatch.Put(savePointKey, savepoint.ToBytes())
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
 // Close closes the underlying db
 func (provider *VersionedDBProvider) Close() {
 	provider.dbProvider.Close()
@@ -147,6 +147,12 @@ func TestFullScanIterator(t *testing.T) {
 	)
 }
 
+func TestImportFromSnapshot(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestImportFromSnapshot(t, env.DBProvider)
+}
+
 func TestFullScanIteratorErrorPropagation(t *testing.T) {
 	var env *TestVDBEnv
 	var cleanup func()
@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
@@ -1194,6 +1195,37 @@ func TestTxSimulatorMissingPvtdata(t *testing.T) {
 	require.True(t, testPvtValueEqual(t, txMgr, "ns1", "coll4", "key4", nil))
 }
 
+func TestTxSimulatorPurgePrivateData(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "TestTxSimulatorPurgePrivateData", nil)
+	defer testEnv.cleanup()
+
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr, []collConfigkey{{"ns1", "coll1"}}, version.NewHeight(1, 1))
+
+	simulator, err := txMgr.NewTxSimulator("txid1")
+	require.NoError(t, err)
+	require.NoError(t, simulator.PurgePrivateData("ns1", "coll1", "key1"))
+	require.EqualError(t, simulator.PurgePrivateData("ns1", "coll2", "key1"), "collection [coll2] not defined in the collection config for chaincode [ns1]")
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	require.NoError(t, err)
+	hashedWrites := txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedWrites
+	require.Equal(t,
+		[]*kvrwset.KVWriteHash{{KeyHash: util.ComputeStringHash("key1"), IsDelete: true, IsPurge: true}},
+		hashedWrites,
+	)
+	txPvtRWSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(simRes.PvtSimulationResults)
+	require.NoError(t, err)
+	require.Equal(t,
+		[]*kvrwset.KVWrite{{Key: "key1", IsDelete: true}},
+		txPvtRWSet.NsPvtRwSet[0].CollPvtRwSets[0].KvRwSet.Writes,
+	)
+}
+
 func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 	ledgerid := "TestTxSimulatorMissingPvtdataExpiry"
 	btlPolicy := btltestutil.SampleBTLPolicy(
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -129,6 +130,7 @@ func validateAndPreparePvtBatch(
 
 	pvtUpdates := privacyenabledstate.NewPvtUpdateBatch()
 	metadataUpdates := metadataUpdates{}
+	purges := findPurgesInBlock(blk)
 	for _, tx := range blk.txs {
 		if tx.validationCode != peer.TxValidationCode_VALID {
 			continue
@@ -136,22 +138,24 @@ func validateAndPreparePvtBatch(
 		if !tx.containsPvtWrites() {
 			continue
 		}
-		txPvtdata := pvtdata[uint64(tx.indexInBlock)]
-		if txPvtdata == nil {
-			continue
-		}
-		if requiresPvtdataValidation(txPvtdata) {
-			if err := validatePvtdata(tx, txPvtdata); err != nil {
+		txVersion := version.NewHeight(blk.num, uint64(tx.indexInBlock))
+		var pvtRWSet *rwsetutil.TxPvtRwSet
+		if txPvtdata := pvtdata[uint64(tx.indexInBlock)]; txPvtdata != nil {
+			if requiresPvtdataValidation(txPvtdata) {
+				if err := validatePvtdata(tx, txPvtdata, purges); err != nil {
+					return nil, err
+				}
+			}
+			var err error
+			if pvtRWSet, err = rwsetutil.TxPvtRwSetFromProtoMsg(txPvtdata.WriteSet); err != nil {
 				return nil, err
 			}
+			addPvtRWSetToPvtUpdateBatch(pvtRWSet, pvtUpdates, txVersion)
+			addEntriesToMetadataUpdates(metadataUpdates, pvtRWSet)
 		}
-		var pvtRWSet *rwsetutil.TxPvtRwSet
-		var err error
-		if pvtRWSet, err = rwsetutil.TxPvtRwSetFromProtoMsg(txPvtdata.WriteSet); err != nil {
+		if err := addPurgesToPvtUpdateBatch(tx.rwset, pvtRWSet, pvtUpdates, db, txVersion); err != nil {
 			return nil, err
 		}
-		addPvtRWSetToPvtUpdateBatch(pvtRWSet, pvtUpdates, version.NewHeight(blk.num, uint64(tx.indexInBlock)))
-		addEntriesToMetadataUpdates(metadataUpdates, pvtRWSet)
 	}
 	if err := incrementPvtdataVersionIfNeeded(metadataUpdates, pvtUpdates, pubAndHashUpdates, db); err != nil {
 		return nil, err
@@ -168,8 +172,10 @@ func requiresPvtdataValidation(tx *ledger.TxPvtData) bool {
 }
 
 // validPvtdata returns true if hashes of all the collections writeset present in the pvt data
-// match with the corresponding hashes present in the public read-write set
-func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData) error {
+// match with the corresponding hashes present in the public read-write set. The pvt data of a collection
+// may miss the writes of the keys that are purged by a later transaction in the block, as in the pvt data
+// served by a peer that has already committed the block
+func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData, purges purgesInBlock) error {
 	if pvtdata.WriteSet == nil {
 		return nil
 	}
@@ -179,6 +185,19 @@ func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData) error {
 			collPvtdataHash := util.ComputeHash(collPvtdata.Rwset)
 			hashInPubdata := tx.retrieveHash(nsPvtdata.Namespace, collPvtdata.CollectionName)
 			if !bytes.Equal(collPvtdataHash, hashInPubdata) {
+				ns, coll := nsPvtdata.Namespace, collPvtdata.CollectionName
+				isPurged := func(keyHash []byte) (bool, error) {
+					purgingTxIndex, ok := purges[purgedKey{ns, coll, string(keyHash)}]
+					return ok && purgingTxIndex > tx.indexInBlock, nil
+				}
+				isPartial, err := rwsetutil.IsPartialPvtRwSet(collPvtdata.Rwset, tx.rwset.GetCollHashedRwSet(ns, coll), isPurged)
+				if err != nil {
+					return err
+				}
+				if isPartial {
+					// the writes of the keys that have been purged by a later transaction are missing
+					continue
+				}
 				return errors.Errorf(`hash of pvt data for collection [%s:%s] does not match with the corresponding hash in the public data. public hash = [%#v], pvt data hash = [%#v]`,
 					nsPvtdata.Namespace, collPvtdata.CollectionName, hashInPubdata, collPvtdataHash)
 			}
@@ -187,6 +206,32 @@ func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData) error {
 	return nil
 }
 
+type purgedKey struct {
+	ns, coll, keyHash string
+}
+
+// purgesInBlock maps the keys purged by the valid transactions in a block to the index of the last purging transaction
+type purgesInBlock map[purgedKey]int
+
+func findPurgesInBlock(blk *block) purgesInBlock {
+	purges := purgesInBlock{}
+	for _, tx := range blk.txs {
+		if tx.validationCode != peer.TxValidationCode_VALID {
+			continue
+		}
+		for _, nsRwSet := range tx.rwset.NsRwSets {
+			for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+				for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+					if hashedWrite.IsPurge {
+						purges[purgedKey{nsRwSet.NameSpace, collHashedRwSet.CollectionName, string(hashedWrite.KeyHash)}] = tx.indexInBlock
+					}
+				}
+			}
+		}
+	}
+	return purges
+}
+
 // preprocessProtoBlock parses the proto instance of block into 'Block' structure.
 // The returned 'Block' structure contains only transactions that are endorser transactions and are not already marked as invalid
 func preprocessProtoBlock(postOrderSimulatorProvider PostOrderSimulatorProvider,
@@ -316,6 +361,13 @@ func processNonEndorserTx(
 
 func validateWriteset(txRWSet *rwsetutil.TxRwSet, validateKVFunc func(key string, value []byte) error) error {
 	for _, nsRwSet := range txRWSet.NsRwSets {
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+				if hashedWrite.IsPurge && (!hashedWrite.IsDelete || len(hashedWrite.ValueHash) != 0) {
+					return errors.Errorf("purge of a key in the collection [%s:%s] is not a delete", nsRwSet.NameSpace, collHashedRwSet.CollectionName)
+				}
+			}
+		}
 		pubWriteset := nsRwSet.KvRwSet
 		if pubWriteset == nil {
 			continue
@@ -352,6 +404,92 @@ func addPvtRWSetToPvtUpdateBatch(pvtRWSet *rwsetutil.TxPvtRwSet, pvtUpdateBatch
 	}
 }
 
+// addPurgesToPvtUpdateBatch adds a delete to the pvt update batch for each key that is purged by the transaction but
+// is not present in the pvt rwset of the transaction, which is the case if the pvt data of the transaction is missing.
+// As the purge must remove the private value even if this peer missed the pvt data of the transaction,
+// the key is resolved from its hash by looking up the keys of the collection in the batch and in the db
+func addPurgesToPvtUpdateBatch(
+	txRWSet *rwsetutil.TxRwSet,
+	pvtRWSet *rwsetutil.TxPvtRwSet,
+	pvtUpdateBatch *privacyenabledstate.PvtUpdateBatch,
+	db *privacyenabledstate.DB,
+	ver *version.Height) error {
+
+	for _, nsRwSet := range txRWSet.NsRwSets {
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			ns, coll := nsRwSet.NameSpace, collHashedRwSet.CollectionName
+			purgedKeyHashes := map[string]bool{}
+			for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+				if hashedWrite.IsPurge {
+					purgedKeyHashes[string(hashedWrite.KeyHash)] = true
+				}
+			}
+			for _, kvWrite := range pvtWrites(pvtRWSet, ns, coll) {
+				delete(purgedKeyHashes, string(util.ComputeStringHash(kvWrite.Key)))
+			}
+			if len(purgedKeyHashes) == 0 {
+				continue
+			}
+			keys, err := resolvePurgedKeys(ns, coll, purgedKeyHashes, pvtUpdateBatch, db)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				pvtUpdateBatch.Delete(ns, coll, key, ver)
+			}
+		}
+	}
+	return nil
+}
+
+func pvtWrites(pvtRWSet *rwsetutil.TxPvtRwSet, ns, coll string) []*kvrwset.KVWrite {
+	if pvtRWSet == nil {
+		return nil
+	}
+	for _, nsPvtRwSet := range pvtRWSet.NsPvtRwSet {
+		if nsPvtRwSet.NameSpace != ns {
+			continue
+		}
+		for _, collPvtRwSet := range nsPvtRwSet.CollPvtRwSets {
+			if collPvtRwSet.CollectionName == coll {
+				return collPvtRwSet.KvRwSet.Writes
+			}
+		}
+	}
+	return nil
+}
+
+// resolvePurgedKeys returns the keys of the collection, either present in the pvt update batch or in the db,
+// whose hashes are present in `keyHashes`. The keys present in the db are looked up by their hashes
+func resolvePurgedKeys(ns, coll string, keyHashes map[string]bool,
+	pvtUpdateBatch *privacyenabledstate.PvtUpdateBatch, db *privacyenabledstate.DB) ([]string, error) {
+
+	var keys []string
+	resolved := map[string]bool{}
+	if nsBatch, ok := pvtUpdateBatch.UpdateMap[ns]; ok {
+		for key := range nsBatch.GetUpdates(coll) {
+			keyHash := string(util.ComputeStringHash(key))
+			if keyHashes[keyHash] {
+				keys = append(keys, key)
+				resolved[keyHash] = true
+			}
+		}
+	}
+	for keyHash := range keyHashes {
+		if resolved[keyHash] {
+			continue
+		}
+		key, err := db.GetPrivateDataKey(ns, coll, []byte(keyHash))
+		if err != nil {
+			return nil, err
+		}
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
 // incrementPvtdataVersionIfNeeded changes the versions of the private data keys if the version of the corresponding hashed key has
 // been upgraded. A metadata-update-only type of transaction may have caused the version change of the existing value in the hashed space.
 // Iterate through all the metadata writes and try to get these keys and increment the version in the private writes to be the same as of the hashed key version - if the latest
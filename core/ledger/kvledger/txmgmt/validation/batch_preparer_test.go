@@ -241,6 +241,33 @@ func TestPreprocessProtoBlockInvalidWriteset(t *testing.T) {
 	require.Equal(t, internalBlock.txs[0].indexInBlock, 1)
 }
 
+func TestPreprocessProtoBlockInvalidPurgeMarker(t *testing.T) {
+	rwSetBuilder := rwsetutil.NewRWSetBuilder()
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key", []byte("value"))
+	txRWSet := rwSetBuilder.GetTxReadWriteSet()
+	txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedWrites[0].IsPurge = true // purge marker with a value
+	simulation1Bytes, err := txRWSet.ToProtoBytes()
+	require.NoError(t, err)
+
+	rwSetBuilder = rwsetutil.NewRWSetBuilder()
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns", "coll", "key")
+	simulation2, err := rwSetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+	simulation2Bytes, err := simulation2.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	blk := testutil.ConstructBlock(t, 1, testutil.ConstructRandomBytes(t, 32),
+		[][]byte{simulation1Bytes, simulation2Bytes}, false)
+	txfilter := txflags.ValidationFlags(blk.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+
+	internalBlock, _, err := preprocessProtoBlock(nil, func(string, []byte) error { return nil }, blk, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, peer.TxValidationCode_INVALID_WRITESET, txfilter.Flag(0))
+	require.True(t, txfilter.IsValid(1))
+	require.Len(t, internalBlock.txs, 1)
+	require.Equal(t, internalBlock.txs[0].indexInBlock, 1)
+}
+
 func TestIncrementPvtdataVersionIfNeeded(t *testing.T) {
 	testDBEnv := &privacyenabledstate.LevelDBTestEnv{}
 	testDBEnv.Init(t)
@@ -291,6 +318,150 @@ func TestIncrementPvtdataVersionIfNeeded(t *testing.T) {
 	)
 }
 
+func TestAddPurgesToPvtUpdateBatch(t *testing.T) {
+	testDBEnv := &privacyenabledstate.LevelDBTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	testDB := testDBEnv.GetDBHandle("testdb")
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.PvtUpdates.Put("ns", "coll", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updateBatch.PvtUpdates.Put("ns", "coll", "key2", []byte("value2"), version.NewHeight(1, 1))
+	updateBatch.PvtUpdates.Put("ns", "coll", "key3", []byte("value3"), version.NewHeight(1, 1))
+	require.NoError(t, testDB.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 1)))
+
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge("ns", "coll", "key1")
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge("ns", "coll", "key2")
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge("ns", "coll", "key4")
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	require.NoError(t, err)
+	require.True(t, txRWSet.ContainsPurgeMarker())
+
+	t.Run("pvt data of the transaction is present", func(t *testing.T) {
+		pvtRWSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(simRes.PvtSimulationResults)
+		require.NoError(t, err)
+		pvtUpdateBatch := privacyenabledstate.NewPvtUpdateBatch()
+		addPvtRWSetToPvtUpdateBatch(pvtRWSet, pvtUpdateBatch, version.NewHeight(2, 1))
+		require.NoError(t, addPurgesToPvtUpdateBatch(txRWSet, pvtRWSet, pvtUpdateBatch, testDB, version.NewHeight(2, 1)))
+		require.Equal(t, 3, len(pvtUpdateBatch.ToCompositeKeyMap()))
+	})
+
+	t.Run("pvt data of the transaction is missing", func(t *testing.T) {
+		pvtUpdateBatch := privacyenabledstate.NewPvtUpdateBatch()
+		// key4 is set by a previous transaction in the block
+		pvtUpdateBatch.Put("ns", "coll", "key4", []byte("value4"), version.NewHeight(2, 0))
+		require.NoError(t, addPurgesToPvtUpdateBatch(txRWSet, nil, pvtUpdateBatch, testDB, version.NewHeight(2, 1)))
+		require.Equal(t,
+			privacyenabledstate.PvtdataCompositeKeyMap{
+				{Namespace: "ns", CollectionName: "coll", Key: "key1"}: {Value: nil, Version: version.NewHeight(2, 1)},
+				{Namespace: "ns", CollectionName: "coll", Key: "key2"}: {Value: nil, Version: version.NewHeight(2, 1)},
+				{Namespace: "ns", CollectionName: "coll", Key: "key4"}: {Value: nil, Version: version.NewHeight(2, 1)},
+			},
+			pvtUpdateBatch.ToCompositeKeyMap(),
+		)
+	})
+}
+
+func TestValidatePvtdataWithPurgedKeys(t *testing.T) {
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key1", []byte("value1"))
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key2", []byte("value2"))
+	rwsetBuilder.AddToHashedMetadataWriteSet("ns", "coll", "key2", map[string][]byte{"metadata": []byte("value")})
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	require.NoError(t, err)
+	tx := &transaction{indexInBlock: 1, rwset: txRWSet}
+	key2Hash := string(lutils.ComputeStringHash("key2"))
+
+	pvtdataWithKVRWSet := func(kvRWSet *kvrwset.KVRWSet) *ledger.TxPvtData {
+		rwsetBytes, err := proto.Marshal(kvRWSet)
+		require.NoError(t, err)
+		return &ledger.TxPvtData{
+			WriteSet: &rwset.TxPvtReadWriteSet{
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+					{
+						Namespace: "ns",
+						CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+							{CollectionName: "coll", Rwset: rwsetBytes},
+						},
+					},
+				},
+			},
+		}
+	}
+	key1Only := pvtdataWithKVRWSet(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: "key1", Value: []byte("value1")}},
+	})
+
+	require.NoError(t, validatePvtdata(tx, &ledger.TxPvtData{WriteSet: simRes.PvtSimulationResults}, nil))
+
+	// the writes of key2 have been purged by a later transaction in the block
+	purgedByLaterTx := purgesInBlock{{"ns", "coll", key2Hash}: 2}
+	require.NoError(t, validatePvtdata(tx, key1Only, purgedByLaterTx))
+
+	// the writes of key2 are missing while key2 is not purged
+	require.Error(t, validatePvtdata(tx, key1Only, nil))
+	require.Error(t, validatePvtdata(tx, key1Only, purgesInBlock{{"ns", "coll-other", key2Hash}: 2}))
+
+	// the writes of key2 are missing while key2 is purged by a previous transaction in the block
+	require.Error(t, validatePvtdata(tx, key1Only, purgesInBlock{{"ns", "coll", key2Hash}: 0}))
+
+	// the metadata write of key2 is missing while its write is present
+	require.Error(t, validatePvtdata(tx, pvtdataWithKVRWSet(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			{Key: "key1", Value: []byte("value1")},
+			{Key: "key2", Value: []byte("value2")},
+		},
+	}), purgedByLaterTx))
+
+	// the value of key1 does not match with the hash
+	require.Error(t, validatePvtdata(tx, pvtdataWithKVRWSet(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: "key1", Value: []byte("tampered-value1")}},
+	}), purgedByLaterTx))
+
+	// key3 is not written by the transaction
+	require.Error(t, validatePvtdata(tx, pvtdataWithKVRWSet(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: "key3", Value: []byte("value3")}},
+	}), purgedByLaterTx))
+
+	// all the writes are present but in a different order
+	require.Error(t, validatePvtdata(tx, pvtdataWithKVRWSet(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			{Key: "key2", Value: []byte("value2")},
+			{Key: "key1", Value: []byte("value1")},
+		},
+		MetadataWrites: []*kvrwset.KVMetadataWrite{{Key: "key2"}},
+	}), purgedByLaterTx))
+}
+
+func TestFindPurgesInBlock(t *testing.T) {
+	purgeTx := func(indexInBlock int, validationCode peer.TxValidationCode, keys ...string) *transaction {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		for _, key := range keys {
+			rwsetBuilder.AddToPvtAndHashedWriteSetForPurge("ns", "coll", key)
+		}
+		rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key3", []byte("value3"))
+		return &transaction{indexInBlock: indexInBlock, validationCode: validationCode, rwset: rwsetBuilder.GetTxReadWriteSet()}
+	}
+	blk := &block{
+		txs: []*transaction{
+			purgeTx(0, peer.TxValidationCode_VALID, "key1", "key2"),
+			purgeTx(2, peer.TxValidationCode_VALID, "key1"),
+			purgeTx(3, peer.TxValidationCode_MVCC_READ_CONFLICT, "key2", "key4"),
+		},
+	}
+	require.Equal(t,
+		purgesInBlock{
+			{"ns", "coll", string(lutils.ComputeStringHash("key1"))}: 2,
+			{"ns", "coll", string(lutils.ComputeStringHash("key2"))}: 0,
+		},
+		findPurgesInBlock(blk),
+	)
+}
+
 func TestTxStatsInfoWithConfigTx(t *testing.T) {
 	testDBEnv := &privacyenabledstate.LevelDBTestEnv{}
 	testDBEnv.Init(t)
@@ -41,6 +41,15 @@ func UpgradeDBs(config *ledger.Config) error {
 	if err := blkstorage.DeleteBlockStoreIndex(BlockStorePath(rootFSPath)); err != nil {
 		return err
 	}
+	// the block files are converted after dropping the block store index, as the conversion
+	// changes the locations of the blocks in the block files
+	compression := blkstorage.BlockfileCompressionNone
+	if config.BlockStoreConfig != nil && config.BlockStoreConfig.Compression != "" {
+		compression = config.BlockStoreConfig.Compression
+	}
+	if err := blkstorage.ConvertBlockfiles(BlockStorePath(rootFSPath), compression); err != nil {
+		return err
+	}
 
 	dbPath := LedgerProviderPath(rootFSPath)
 	db := leveldbhelper.CreateDB(&leveldbhelper.Conf{DBPath: dbPath})
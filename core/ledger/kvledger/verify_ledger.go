@@ -0,0 +1,517 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// These are the names of the checks performed by VerifyLedgers, in addition to the checks
+// on the block store (see blkstorage.VerifyBlockStore)
+const (
+	CheckState       = "state"
+	CheckPrivateData = "private_data"
+)
+
+// VerificationReport is the outcome of the verification of a ledger
+type VerificationReport struct {
+	LedgerID string `json:"ledger_id"`
+	// Height is the height of the ledger as found in the block files
+	Height uint64 `json:"height"`
+	// FirstBadBlock is the lowest block number for which an issue is reported
+	FirstBadBlock *uint64              `json:"first_bad_block,omitempty"`
+	Issues        []*VerificationIssue `json:"issues"`
+	// Notes lists the checks that could not be performed
+	Notes []string `json:"notes,omitempty"`
+}
+
+// VerificationIssue describes an inconsistency found in a ledger
+type VerificationIssue struct {
+	Check      string  `json:"check"`
+	BlockNum   uint64  `json:"block_num"`
+	TxNum      *uint64 `json:"tx_num,omitempty"`
+	Namespace  string  `json:"namespace,omitempty"`
+	Collection string  `json:"collection,omitempty"`
+	Key        string  `json:"key,omitempty"`
+	KeyHash    string  `json:"key_hash,omitempty"`
+	Details    string  `json:"details"`
+}
+
+// VerifyLedgers verifies the integrity of the given ledgers, or of all the active ledgers if none is
+// specified. The block files are checked for the hash chain and the data hashes and are cross-checked
+// against the block index. The write-sets of the valid transactions are replayed and compared with the
+// state in the state database and the private data is compared against the hashes present in the blocks.
+// This function is intended to be invoked on a stopped peer
+func VerifyLedgers(config *ledger.Config, ledgerIDs []string) ([]*VerificationReport, error) {
+	rootFSPath := config.RootFSPath
+	fileLockPath := fileLockPath(rootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	if len(ledgerIDs) == 0 {
+		idStore, err := openIDStore(LedgerProviderPath(rootFSPath))
+		if err != nil {
+			return nil, err
+		}
+		ledgerIDs, err = idStore.getActiveLedgerIDs()
+		idStore.close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var reports []*VerificationReport
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Verifying the ledger [%s]", ledgerID)
+		report, err := verifyLedger(config, ledgerID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while verifying the ledger [%s]", ledgerID)
+		}
+		logger.Infof("Verified the ledger [%s], number of issues found = %d", ledgerID, len(report.Issues))
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func verifyLedger(config *ledger.Config, ledgerID string) (*VerificationReport, error) {
+	v := &ledgerVerifier{
+		report: &VerificationReport{
+			LedgerID: ledgerID,
+			Issues:   []*VerificationIssue{},
+		},
+	}
+
+	if config.StateDBConfig.StateDatabase == "CouchDB" {
+		v.addNote("the state is not verified as the verification is supported only for the state stored in goleveldb")
+	} else {
+		closeStateDB, err := v.openStateDB(config, ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		defer closeStateDB()
+		closeConfigHistory, err := v.openConfigHistory(config, ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		defer closeConfigHistory()
+	}
+
+	closePvtdataStore, err := v.openPvtdataStore(config, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer closePvtdataStore()
+
+	blkStoreConf, err := blockStoreConf(config)
+	if err != nil {
+		return nil, err
+	}
+	result, err := blkstorage.VerifyBlockStore(
+		blkStoreConf,
+		ledgerID,
+		blockStoreIndexConfig(config),
+		v.processBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	v.report.Height = result.Height
+	for _, issue := range result.Issues {
+		v.addIssue(&VerificationIssue{Check: issue.Check, BlockNum: issue.BlockNum, Details: issue.Details})
+	}
+
+	if err := v.verifyState(result); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(v.report.Issues, func(i, j int) bool {
+		return v.report.Issues[i].BlockNum < v.report.Issues[j].BlockNum
+	})
+	if len(v.report.Issues) > 0 {
+		firstBadBlock := v.report.Issues[0].BlockNum
+		v.report.FirstBadBlock = &firstBadBlock
+	}
+	return v.report, nil
+}
+
+type ledgerVerifier struct {
+	report *VerificationReport
+
+	stateDB        *privacyenabledstate.DB
+	stateSavepoint *version.Height
+	expectedState  *privacyenabledstate.ExpectedState
+	// nonEndorserTxs contains the heights of the valid transactions other than the endorser transactions
+	// (e.g., the config transactions), for which the write-sets are computed by the peer at commit time
+	nonEndorserTxs map[version.Height]struct{}
+	// stateReplayed is set to false if the write-sets of the blocks up to the state savepoint cannot be replayed
+	stateReplayed bool
+	// configHistory provides the block-to-live of the collections
+	configHistory *confighistory.Retriever
+
+	pvtdataStore       *pvtdatastorage.Store
+	pvtdataStoreHeight uint64
+
+	blockProcessed bool
+}
+
+func (v *ledgerVerifier) openStateDB(config *ledger.Config, ledgerID string) (func(), error) {
+	var closeFunc func()
+	var err error
+	if v.stateDB, closeFunc, err = openStateDBForLedger(config, ledgerID); err != nil {
+		return nil, err
+	}
+	if v.stateSavepoint, err = v.stateDB.GetLatestSavePoint(); err != nil {
+		closeFunc()
+		return nil, err
+	}
+	v.expectedState = privacyenabledstate.NewExpectedState()
+	v.nonEndorserTxs = map[version.Height]struct{}{}
+	v.stateReplayed = true
+	return closeFunc, nil
+}
+
+func (v *ledgerVerifier) openConfigHistory(config *ledger.Config, ledgerID string) (func(), error) {
+	// the deployed chaincode info provider is used only for the implicit collections, which never expire
+	configHistoryMgr, err := confighistory.NewMgr(ConfigHistoryDBPath(config.RootFSPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	v.configHistory = configHistoryMgr.GetRetriever(ledgerID, nil)
+	return configHistoryMgr.Close, nil
+}
+
+func (v *ledgerVerifier) openPvtdataStore(config *ledger.Config, ledgerID string) (func(), error) {
+	var closeFunc func()
+	var err error
+	if v.pvtdataStore, closeFunc, err = openPvtdataStoreForLedger(config, ledgerID); err != nil {
+		return nil, err
+	}
+	if v.pvtdataStoreHeight, err = v.pvtdataStore.LastCommittedBlockHeight(); err != nil {
+		closeFunc()
+		return nil, err
+	}
+	return closeFunc, nil
+}
+
+// processBlock is invoked for the blocks in the block files, in order, until the first issue is found in the block store
+func (v *ledgerVerifier) processBlock(block *common.Block) error {
+	blockNum := block.Header.Number
+	if !v.blockProcessed && blockNum != 0 && v.stateReplayed {
+		// the ledger is bootstrapped from a snapshot and the state of the blocks in the snapshot cannot be replayed
+		v.stateReplayed = false
+		v.addNote("the state is not verified as the ledger is bootstrapped from a snapshot")
+	}
+	v.blockProcessed = true
+	replayState := v.stateReplayed && v.stateSavepoint != nil && blockNum <= v.stateSavepoint.BlockNum
+	verifyPvtdata := blockNum < v.pvtdataStoreHeight
+	if !replayState && !verifyPvtdata {
+		return nil
+	}
+
+	var pvtdata map[uint64]*ledger.TxPvtData
+	if verifyPvtdata {
+		pvtdataOfBlock, err := v.pvtdataStore.GetPvtDataByBlockNum(blockNum, nil)
+		if err != nil {
+			return err
+		}
+		pvtdata = map[uint64]*ledger.TxPvtData{}
+		for _, txPvtdata := range pvtdataOfBlock {
+			pvtdata[txPvtdata.SeqInBlock] = txPvtdata
+		}
+	}
+
+	txsFilter := txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txNum := range block.Data.Data {
+		if !txsFilter.IsValid(txNum) {
+			// the private data of an invalid transaction may be stored, as the transaction
+			// may become valid if the block is reprocessed
+			continue
+		}
+		txRWSet, isEndorserTx, err := extractTxRWSet(block, txNum)
+		if err != nil {
+			v.addIssue(&VerificationIssue{
+				Check:    CheckState,
+				BlockNum: blockNum,
+				TxNum:    txNumPtr(txNum),
+				Details:  fmt.Sprintf("error while extracting the write-set of the valid transaction: %s", err),
+			})
+			continue
+		}
+
+		txHeight := version.NewHeight(blockNum, uint64(txNum))
+		if replayState {
+			if isEndorserTx {
+				v.applyTxRWSet(txRWSet, txHeight)
+			} else {
+				v.nonEndorserTxs[*txHeight] = struct{}{}
+			}
+		}
+		if txPvtdata, ok := pvtdata[uint64(txNum)]; ok {
+			if err := v.verifyTxPvtdata(txPvtdata, txRWSet, txHeight); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ledgerVerifier) applyTxRWSet(txRWSet *rwsetutil.TxRwSet, txHeight *version.Height) {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+			v.expectedState.ApplyWrite(ns, kvWrite.Key, kvWrite.Value, kvWrite.IsDelete, txHeight)
+		}
+		for _, kvMetadataWrite := range nsRWSet.KvRwSet.MetadataWrites {
+			v.expectedState.ApplyMetadataWrite(ns, kvMetadataWrite.Key, txHeight)
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			coll := collHashedRWSet.CollectionName
+			for _, hashedWrite := range collHashedRWSet.HashedRwSet.HashedWrites {
+				// a purge of a key is a delete of the key as well
+				v.expectedState.ApplyHashedWrite(ns, coll, hashedWrite.KeyHash, hashedWrite.ValueHash, hashedWrite.IsDelete, txHeight)
+			}
+			for _, metadataWrite := range collHashedRWSet.HashedRwSet.MetadataWrites {
+				v.expectedState.ApplyHashedMetadataWrite(ns, coll, metadataWrite.KeyHash, txHeight)
+			}
+		}
+	}
+}
+
+func (v *ledgerVerifier) verifyTxPvtdata(txPvtdata *ledger.TxPvtData, txRWSet *rwsetutil.TxRwSet, txHeight *version.Height) error {
+	for _, nsPvtRWSet := range txPvtdata.WriteSet.NsPvtRwset {
+		for _, collPvtRWSet := range nsPvtRWSet.CollectionPvtRwset {
+			issue := &VerificationIssue{
+				Check:      CheckPrivateData,
+				BlockNum:   txHeight.BlockNum,
+				TxNum:      &txHeight.TxNum,
+				Namespace:  nsPvtRWSet.Namespace,
+				Collection: collPvtRWSet.CollectionName,
+			}
+			var expectedHash []byte
+			if txRWSet != nil {
+				expectedHash = txRWSet.GetPvtDataHash(nsPvtRWSet.Namespace, collPvtRWSet.CollectionName)
+			}
+			actualHash := util.ComputeHash(collPvtRWSet.Rwset)
+			switch {
+			case expectedHash == nil:
+				issue.Details = "the private data is stored for a collection that is not present in the transaction"
+			case !bytes.Equal(expectedHash, actualHash):
+				isPartial, err := v.isPartialPvtRWSet(collPvtRWSet, txRWSet, nsPvtRWSet.Namespace, txHeight)
+				if err != nil {
+					return err
+				}
+				if isPartial {
+					// the private data store has removed the writes of the keys purged by the later transactions
+					continue
+				}
+				issue.Details = fmt.Sprintf("private data hash mismatch. Hash in the transaction = [%x], hash of the stored private data = [%x]",
+					expectedHash, actualHash)
+			default:
+				continue
+			}
+			v.addIssue(issue)
+		}
+	}
+	return nil
+}
+
+func (v *ledgerVerifier) isPartialPvtRWSet(collPvtRWSet *rwset.CollectionPvtReadWriteSet, txRWSet *rwsetutil.TxRwSet,
+	ns string, txHeight *version.Height) (bool, error) {
+	coll := collPvtRWSet.CollectionName
+	isPurged := func(keyHash []byte) (bool, error) {
+		return v.pvtdataStore.IsPurged(ns, coll, keyHash, txHeight.BlockNum, txHeight.TxNum)
+	}
+	return rwsetutil.IsPartialPvtRwSet(collPvtRWSet.Rwset, txRWSet.GetCollHashedRwSet(ns, coll), isPurged)
+}
+
+// verifyState compares the state in the state database with the state computed by replaying the blocks up to the
+// state savepoint. The verification is skipped if any of the blocks up to the state savepoint could not be replayed
+func (v *ledgerVerifier) verifyState(result *blkstorage.VerificationResult) error {
+	if v.stateDB == nil || v.stateSavepoint == nil || !v.stateReplayed {
+		return nil
+	}
+	if len(result.Issues) > 0 && result.Issues[0].BlockNum <= v.stateSavepoint.BlockNum {
+		v.addNote(fmt.Sprintf("the state is not verified as the block store has issues at or below the state savepoint [%d]",
+			v.stateSavepoint.BlockNum))
+		return nil
+	}
+	if v.stateSavepoint.BlockNum >= result.Height {
+		v.addIssue(&VerificationIssue{
+			Check:    CheckState,
+			BlockNum: result.Height,
+			Details: fmt.Sprintf("the state savepoint [%s] is beyond the last block in the block files [%d]",
+				v.stateSavepoint, int64(result.Height)-1),
+		})
+		return nil
+	}
+
+	if err := v.expireHashedKeys(); err != nil {
+		return err
+	}
+	divergences, err := v.stateDB.VerifyPubAndHashedState(v.expectedState, func(h *version.Height) bool {
+		_, ok := v.nonEndorserTxs[*h]
+		return ok
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range divergences {
+		issue := &VerificationIssue{
+			Check:      CheckState,
+			Namespace:  d.Namespace,
+			Collection: d.Collection,
+			Key:        d.Key,
+			Details: fmt.Sprintf("%s. Expected version = [%s], version in the state = [%s]",
+				d.Reason, heightString(d.ExpectedVersion), heightString(d.ActualVersion)),
+		}
+		if d.KeyHash != nil {
+			issue.KeyHash = hex.EncodeToString(d.KeyHash)
+		}
+		// the divergence is attributed to the lower of the two versions
+		h := d.ExpectedVersion
+		if h == nil || (d.ActualVersion != nil && d.ActualVersion.Compare(h) < 0) {
+			h = d.ActualVersion
+		}
+		if h != nil {
+			issue.BlockNum, issue.TxNum = h.BlockNum, &h.TxNum
+		}
+		v.addIssue(issue)
+	}
+	return nil
+}
+
+// expireHashedKeys marks the private keys that the ledger is expected to have purged from the state up to the
+// state savepoint, on the expiry of the block-to-live of their collections. The block-to-live of a collection is
+// loaded from the collection config history, as the collection configs in the state are interpreted by the
+// chaincode lifecycle of a running peer
+func (v *ledgerVerifier) expireHashedKeys() error {
+	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collConfigHistoryInfoProvider{
+		configHistory: v.configHistory,
+		blockNum:      v.stateSavepoint.BlockNum,
+	})
+	return v.expectedState.ExpireHashedKeys(func(ns, coll string, committingBlock uint64) (bool, error) {
+		expiringBlk, err := btlPolicy.GetExpiringBlock(ns, coll, committingBlock)
+		if _, ok := err.(privdata.NoSuchCollectionError); ok {
+			// the implicit collections are not present in the collection config history and never expire
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return expiringBlk <= v.stateSavepoint.BlockNum, nil
+	})
+}
+
+func (v *ledgerVerifier) addIssue(issue *VerificationIssue) {
+	v.report.Issues = append(v.report.Issues, issue)
+}
+
+func (v *ledgerVerifier) addNote(note string) {
+	v.report.Notes = append(v.report.Notes, note)
+}
+
+// extractTxRWSet returns the write-set of the transaction at the given index in the block. The returned bool is
+// false if the transaction is not an endorser transaction, in which case the returned write-set is nil
+func extractTxRWSet(block *common.Block, txNum int) (*rwsetutil.TxRwSet, bool, error) {
+	env, err := protoutil.ExtractEnvelope(block, txNum)
+	if err != nil {
+		return nil, false, err
+	}
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, false, err
+	}
+	if payload.Header == nil {
+		return nil, false, errors.New("the transaction payload does not contain a header")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, false, err
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, false, nil
+	}
+	respPayload, err := protoutil.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return nil, false, err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, false, err
+	}
+	return txRWSet, true, nil
+}
+
+func txNumPtr(txNum int) *uint64 {
+	n := uint64(txNum)
+	return &n
+}
+
+func heightString(h *version.Height) string {
+	if h == nil {
+		return "none"
+	}
+	return h.String()
+}
+
+// collConfigHistoryInfoProvider provides the collection configs, as of the given block, from the collection
+// config history
+type collConfigHistoryInfoProvider struct {
+	configHistory *confighistory.Retriever
+	blockNum      uint64
+}
+
+func (p *collConfigHistoryInfoProvider) CollectionInfo(chaincodeName, collectionName string) (*peer.StaticCollectionConfig, error) {
+	collConfigInfo, err := p.configHistory.MostRecentExplicitCollectionConfigBelow(p.blockNum+1, chaincodeName)
+	if err != nil || collConfigInfo == nil {
+		return nil, err
+	}
+	for _, collConfig := range collConfigInfo.CollectionConfig.Config {
+		if staticCollConfig := collConfig.GetStaticCollectionConfig(); staticCollConfig.GetName() == collectionName {
+			return staticCollConfig, nil
+		}
+	}
+	return nil, nil
+}
+
+// noExpiryBTLPolicy is used for retrieving the private data irrespective of the block-to-live
+// of the collections, including the expired private data that is not yet purged
+type noExpiryBTLPolicy struct{}
+
+func (p *noExpiryBTLPolicy) GetBTL(ns string, coll string) (uint64, error) {
+	return 0, nil
+}
+
+func (p *noExpiryBTLPolicy) GetExpiringBlock(ns string, coll string, committingBlock uint64) (uint64, error) {
+	return math.MaxUint64, nil
+}
@@ -0,0 +1,305 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLedgers(t *testing.T) {
+	setup := func(t *testing.T) (*lgr.Config, func()) {
+		conf, cleanup := testConfig(t)
+		provider := testutilNewProviderWithCollectionConfig(
+			t,
+			[]*nsCollBtlConfig{
+				{
+					namespace: "ns",
+					btlConfig: map[string]uint64{"coll": 0},
+				},
+			},
+			conf,
+		)
+		defer provider.Close()
+
+		bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+		l, err := provider.Create(gb)
+		require.NoError(t, err)
+		defer l.Close()
+
+		blkAndPvtdata1 := prepareNextBlockForTest(t, l, bg, "SimulateForBlk1",
+			map[string]string{"key1": "value1.1", "key2": "value2.1"},
+			map[string]string{"key1": "pvtValue1.1"},
+		)
+		require.NoError(t, l.CommitLegacy(blkAndPvtdata1, &lgr.CommitOptions{}))
+		blkAndPvtdata2 := prepareNextBlockForTest(t, l, bg, "SimulateForBlk2",
+			map[string]string{"key1": "value1.2"},
+			map[string]string{"key1": "pvtValue1.2", "key2": "pvtValue2.2"},
+		)
+		require.NoError(t, l.CommitLegacy(blkAndPvtdata2, &lgr.CommitOptions{}))
+		return conf, cleanup
+	}
+
+	t.Run("intact-ledger", func(t *testing.T) {
+		conf, cleanup := setup(t)
+		defer cleanup()
+
+		reports, err := VerifyLedgers(conf, nil)
+		require.NoError(t, err)
+		require.Equal(t, []*VerificationReport{
+			{
+				LedgerID: "testLedger",
+				Height:   3,
+				Issues:   []*VerificationIssue{},
+			},
+		}, reports)
+	})
+
+	t.Run("another-command-is-executing", func(t *testing.T) {
+		conf, cleanup := setup(t)
+		defer cleanup()
+
+		provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+		defer provider.Close()
+		_, err := VerifyLedgers(conf, []string{"testLedger"})
+		require.EqualError(t, err, "as another peer node command is executing, wait for that command to complete its execution or terminate it before retrying: lock is already acquired on file "+fileLockPath(conf.RootFSPath))
+	})
+
+	t.Run("non-existent-ledger", func(t *testing.T) {
+		conf, cleanup := setup(t)
+		defer cleanup()
+
+		_, err := VerifyLedgers(conf, []string{"non-existent-ledger"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while verifying the ledger [non-existent-ledger]")
+	})
+
+	t.Run("state-divergence", func(t *testing.T) {
+		conf, cleanup := setup(t)
+		defer cleanup()
+
+		dbProvider, err := stateleveldb.NewVersionedDBProvider(StateDBPath(conf.RootFSPath))
+		require.NoError(t, err)
+		db, err := dbProvider.GetDBHandle("testLedger", nil)
+		require.NoError(t, err)
+		savepoint, err := db.GetLatestSavePoint()
+		require.NoError(t, err)
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns", "key2", []byte("tampered-value"), version.NewHeight(1, 0))
+		batch.Put("ns", "key3", []byte("unexpected-value"), version.NewHeight(2, 0))
+		batch.Delete("ns", "key1", version.NewHeight(2, 0))
+		require.NoError(t, db.ApplyUpdates(batch, savepoint))
+		dbProvider.Close()
+
+		reports, err := VerifyLedgers(conf, nil)
+		require.NoError(t, err)
+		firstBadBlock := uint64(1)
+		require.Equal(t, &VerificationReport{
+			LedgerID:      "testLedger",
+			Height:        3,
+			FirstBadBlock: &firstBadBlock,
+			Issues: []*VerificationIssue{
+				{
+					Check:     CheckState,
+					BlockNum:  1,
+					TxNum:     new(uint64),
+					Namespace: "ns",
+					Key:       "key2",
+					Details:   "the value of the key does not match. Expected version = [{BlockNum: 1, TxNum: 0}], version in the state = [{BlockNum: 1, TxNum: 0}]",
+				},
+				{
+					Check:     CheckState,
+					BlockNum:  2,
+					TxNum:     new(uint64),
+					Namespace: "ns",
+					Key:       "key1",
+					Details:   "the key is missing. Expected version = [{BlockNum: 2, TxNum: 0}], version in the state = [none]",
+				},
+				{
+					Check:     CheckState,
+					BlockNum:  2,
+					TxNum:     new(uint64),
+					Namespace: "ns",
+					Key:       "key3",
+					Details:   "the key is not written by any valid transaction. Expected version = [none], version in the state = [{BlockNum: 2, TxNum: 0}]",
+				},
+			},
+		}, reports[0])
+	})
+
+	t.Run("truncated-block-file", func(t *testing.T) {
+		conf, cleanup := setup(t)
+		defer cleanup()
+
+		blockfile := filepath.Join(BlockStorePath(conf.RootFSPath), "chains", "testLedger", "blockfile_000000")
+		fileInfo, err := os.Stat(blockfile)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(blockfile, fileInfo.Size()-10))
+
+		reports, err := VerifyLedgers(conf, nil)
+		require.NoError(t, err)
+		report := reports[0]
+		require.Equal(t, uint64(2), report.Height)
+		require.Equal(t, uint64(2), *report.FirstBadBlock)
+		require.Equal(t, blkstorage.CheckBlockFiles, report.Issues[0].Check)
+		require.Equal(t, []string{"the state is not verified as the block store has issues at or below the state savepoint [2]"}, report.Notes)
+	})
+
+	t.Run("couchdb", func(t *testing.T) {
+		conf, cleanup := setup(t)
+		defer cleanup()
+
+		conf.StateDBConfig.StateDatabase = "CouchDB"
+		reports, err := VerifyLedgers(conf, nil)
+		require.NoError(t, err)
+		require.Empty(t, reports[0].Issues)
+		require.Equal(t, []string{"the state is not verified as the verification is supported only for the state stored in goleveldb"}, reports[0].Notes)
+	})
+}
+
+func TestVerifyLedgersWithBTLAndPurge(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProviderWithCollectionConfig(
+		t,
+		[]*nsCollBtlConfig{
+			{
+				namespace: "ns",
+				btlConfig: map[string]uint64{"coll1": 1, "coll2": 0},
+			},
+		},
+		conf,
+	)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// record the collection configs in the collection config history
+	mockCCInfoProvider := provider.initializer.DeployedChaincodeInfoProvider.(*mock.DeployedChaincodeInfoProvider)
+	mockCCInfoProvider.UpdatedChaincodesReturns([]*lgr.ChaincodeLifecycleInfo{{Name: "ns"}}, nil)
+	require.NoError(t, provider.configHistoryMgr.HandleStateUpdates(
+		&lgr.StateUpdateTrigger{
+			LedgerID:     "testLedger",
+			StateUpdates: map[string]*lgr.KVStateUpdates{"ns": {}},
+		},
+	))
+
+	commitTx := func(txid string, simulate func(s lgr.TxSimulator)) {
+		s, err := l.NewTxSimulator(txid)
+		require.NoError(t, err)
+		simulate(s)
+		s.Done()
+		simRes, err := s.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		blkAndPvtdata := &lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}
+		if simRes.PvtSimulationResults != nil {
+			blkAndPvtdata.PvtData = lgr.TxPvtDataMap{
+				0: {SeqInBlock: 0, WriteSet: simRes.PvtSimulationResults},
+			}
+		}
+		require.NoError(t, l.CommitLegacy(blkAndPvtdata, &lgr.CommitOptions{}))
+	}
+
+	commitTx("tx1", func(s lgr.TxSimulator) {
+		require.NoError(t, s.SetPrivateData("ns", "coll1", "key1", []byte("pvtValue1")))
+		require.NoError(t, s.SetPrivateData("ns", "coll2", "key1", []byte("pvtValue1")))
+		require.NoError(t, s.SetPrivateData("ns", "coll2", "key2", []byte("pvtValue2")))
+	})
+	commitTx("tx2", func(s lgr.TxSimulator) {
+		require.NoError(t, s.SetState("ns", "key1", []byte("value1")))
+	})
+	// the key of coll1 expires at the block 3 and the purge of the key of coll2
+	// removes the write of the key from the private data of the block 1
+	commitTx("tx3", func(s lgr.TxSimulator) {
+		require.NoError(t, s.PurgePrivateData("ns", "coll2", "key1"))
+	})
+	l.Close()
+	provider.Close()
+
+	reports, err := VerifyLedgers(conf, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*VerificationReport{
+		{
+			LedgerID: "testLedger",
+			Height:   4,
+			Issues:   []*VerificationIssue{},
+		},
+	}, reports)
+}
+
+func TestVerifyTxPvtdata(t *testing.T) {
+	collPvtRWSet := []byte("private-rwset")
+	txRWSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{
+				NameSpace: "ns",
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{
+					{CollectionName: "coll1", PvtRwSetHash: util.ComputeHash(collPvtRWSet)},
+					{CollectionName: "coll2", PvtRwSetHash: util.ComputeHash([]byte("another-private-rwset"))},
+				},
+			},
+		},
+	}
+	txPvtdata := &lgr.TxPvtData{
+		SeqInBlock: 1,
+		WriteSet: &rwset.TxPvtReadWriteSet{
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace: "ns",
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+						{CollectionName: "coll1", Rwset: collPvtRWSet},
+						{CollectionName: "coll2", Rwset: collPvtRWSet},
+						{CollectionName: "coll3", Rwset: collPvtRWSet},
+					},
+				},
+			},
+		},
+	}
+
+	v := &ledgerVerifier{report: &VerificationReport{}}
+	require.NoError(t, v.verifyTxPvtdata(txPvtdata, txRWSet, version.NewHeight(5, 1)))
+	txNum := uint64(1)
+	require.Equal(t, []*VerificationIssue{
+		{
+			Check:      CheckPrivateData,
+			BlockNum:   5,
+			TxNum:      &txNum,
+			Namespace:  "ns",
+			Collection: "coll2",
+			Details: "private data hash mismatch. Hash in the transaction = [" +
+				hex.EncodeToString(util.ComputeHash([]byte("another-private-rwset"))) + "], hash of the stored private data = [" +
+				hex.EncodeToString(util.ComputeHash(collPvtRWSet)) + "]",
+		},
+		{
+			Check:      CheckPrivateData,
+			BlockNum:   5,
+			TxNum:      &txNum,
+			Namespace:  "ns",
+			Collection: "coll3",
+			Details:    "the private data is stored for a collection that is not present in the transaction",
+		},
+	}, v.report.Issues)
+}
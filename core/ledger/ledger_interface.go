@@ -47,12 +47,16 @@ type Config struct {
 	HistoryDBConfig *HistoryDBConfig
 	// SnapshotsConfig holds the configuration parameters for the snapshots.
 	SnapshotsConfig *SnapshotsConfig
+	// BlockStoreConfig holds the configuration parameters for the block store.
+	BlockStoreConfig *BlockStoreConfig
+	// BlockArchiveConfig holds the configuration parameters for archiving the block files.
+	BlockArchiveConfig *BlockArchiveConfig
 }
 
 // StateDBConfig is a structure used to configure the state parameters for the ledger.
 type StateDBConfig struct {
 	// StateDatabase is the database to use for storing last known state.  The
-	// two supported options are "goleveldb" and "CouchDB".
+	// supported options are "goleveldb", "CouchDB", and "JSONDB".
 	StateDatabase string
 	// CouchDB is the configuration for CouchDB.  It is used when StateDatabase
 	// is set to "CouchDB".
@@ -129,12 +133,40 @@ type SnapshotsConfig struct {
 	RootDir string
 }
 
+// BlockStoreConfig is a structure used to configure the block store
+type BlockStoreConfig struct {
+	// Compression is the compression applied to the blocks in the new block files.
+	// The supported values are "none" and "snappy".
+	Compression string
+	// IndexChaincodeEvents enables indexing the transactions by the chaincode events emitted.
+	IndexChaincodeEvents bool
+	// IndexBlockTimestamps enables indexing the blocks by the block timestamp.
+	IndexBlockTimestamps bool
+}
+
+// BlockArchiveConfig is a structure used to configure archiving of the block files
+type BlockArchiveConfig struct {
+	// Enabled enables moving the block files that contain only older blocks to the archive directory.
+	Enabled bool
+	// ArchiveDir is the directory where the block files are archived.
+	ArchiveDir string
+	// RetentionBlocks is the number of the most recent blocks that are retained in the local block storage.
+	RetentionBlocks uint64
+	// Compress specifies whether the block files are compressed when archived.
+	Compress bool
+}
+
 // PeerLedgerProvider provides handle to ledger instances
 type PeerLedgerProvider interface {
 	// Create creates a new ledger with the given genesis block.
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from a snapshot and returns the ledger and channel id.
+	// The snapshot metadata and the hashes of the snapshot files are verified before importing the data.
+	// As the blocks before the snapshot are not available, the block store of the ledger starts at the
+	// height of the snapshot
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, string, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -195,6 +227,28 @@ type PeerLedger interface {
 	//     missing info is recorded in the ledger (or)
 	// (3) the block is committed and does not contain any pvtData.
 	DoesPvtDataInfoExist(blockNum uint64) (bool, error)
+	// SubmitSnapshotRequest submits a snapshot request for the specified block number.
+	// The snapshot is generated once the block with the given number is committed.
+	// When blockNumber is 0, the snapshot is generated for the last committed block.
+	SubmitSnapshotRequest(blockNumber uint64) error
+	// CancelSnapshotRequest cancels a previously submitted snapshot request
+	// for the specified block number.
+	CancelSnapshotRequest(blockNumber uint64) error
+	// PendingSnapshotRequests returns the block numbers of the pending snapshot requests
+	// in ascending order.
+	PendingSnapshotRequests() ([]uint64, error)
+	// GetTransactionsByChaincodeEvent returns at most pageSize transactions that emitted the event with
+	// the given name from the given chaincode, in the order of their commit, starting at the given bookmark.
+	// An empty bookmark starts at the first such transaction. The returned bookmark is used for retrieving
+	// the next page and is empty when there are no more transactions.
+	// This requires the chaincode events to be indexed.
+	GetTransactionsByChaincodeEvent(chaincodeName, eventName, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error)
+	// GetBlocksByTimestamp returns at most pageSize blocks with a timestamp in the range [startTime, endTime),
+	// in the order of their timestamps, starting at the given bookmark. The timestamp of a block is the timestamp
+	// in the channel header of its first transaction. An empty bookmark starts at the first block in the range.
+	// The returned bookmark is used for retrieving the next page and is empty when there are no more blocks.
+	// This requires the block timestamps to be indexed.
+	GetBlocksByTimestamp(startTime, endTime time.Time, bookmark string, pageSize int) ([]*common.Block, string, error)
 }
 
 // SimpleQueryExecutor encapsulates basic functions
@@ -295,6 +349,9 @@ type TxSimulator interface {
 	SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error
 	// DeletePrivateData deletes the given tuple <namespace, collection, key> from private data
 	DeletePrivateData(namespace, collection, key string) error
+	// PurgePrivateData purges the given tuple <namespace, collection, key> from private data. In addition to deleting
+	// the current value, the committed purge removes all the historical values of the key from the private data store
+	PurgePrivateData(namespace, collection, key string) error
 	// SetPrivateDataMetadata sets the metadata associated with an existing key-tuple <namespace, collection, key>
 	SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error
 	// DeletePrivateDataMetadata deletes the metadata associated with an existing key-tuple <namespace, collection, key>
@@ -569,6 +626,15 @@ func (NotFoundInIndexErr) Error() string {
 	return "Entry not found in index"
 }
 
+// TxDetailsNotAvailableErr is used to indicate that a transaction with the given ID is present in the ledger
+// but its details are not available. This is the case for the transactions included in the snapshot from
+// which the ledger is bootstrapped
+type TxDetailsNotAvailableErr string
+
+func (e TxDetailsNotAvailableErr) Error() string {
+	return string(e)
+}
+
 // CollConfigNotDefinedError is returned whenever an operation
 // is requested on a collection whose config has not been defined
 type CollConfigNotDefinedError struct {
@@ -112,6 +112,26 @@ func (m *LedgerMgr) CreateLedger(id string, genesisBlock *common.Block) (ledger.
 	}, nil
 }
 
+// CreateLedgerFromSnapshot creates a new ledger from the snapshot present in the snapshotDir and
+// returns the ledger along with the ledger id. The ledger id is the channel name recorded in the
+// snapshot metadata
+func (m *LedgerMgr) CreateLedgerFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	logger.Infof("Creating ledger from snapshot at %s", snapshotDir)
+	l, id, err := m.ledgerProvider.CreateFromSnapshot(snapshotDir)
+	if err != nil {
+		return nil, "", err
+	}
+	m.openedLedgers[id] = l
+	logger.Infof("Created ledger [%s] from snapshot", id)
+	return &closableLedger{
+		ledgerMgr:  m,
+		id:         id,
+		PeerLedger: l,
+	}, id, nil
+}
+
 // OpenLedger returns a ledger for the given id
 func (m *LedgerMgr) OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -78,6 +79,44 @@ func TestLedgerMgmt(t *testing.T) {
 	ledgerMgr.Close()
 }
 
+func TestCreateLedgerFromSnapshot(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ledgermgmt")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	// create a ledger and generate a snapshot
+	initializer, err := constructDefaultInitializer(filepath.Join(testDir, "source"))
+	require.NoError(t, err)
+	ledgerMgr := NewLedgerMgr(initializer)
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := ledgerMgr.CreateLedger("ledger1", gb)
+	require.NoError(t, err)
+	require.NoError(t, l.SubmitSnapshotRequest(0))
+	ledgerMgr.Close()
+	snapshotDir := kvledger.SnapshotDirForLedgerHeight(initializer.Config.SnapshotsConfig.RootDir, "ledger1", 1)
+
+	// create a ledger from the snapshot
+	initializer, err = constructDefaultInitializer(filepath.Join(testDir, "dest"))
+	require.NoError(t, err)
+	ledgerMgr = NewLedgerMgr(initializer)
+	defer ledgerMgr.Close()
+	l, ledgerID, err := ledgerMgr.CreateLedgerFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	require.Equal(t, "ledger1", ledgerID)
+	bcInfo, err := l.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), bcInfo.Height)
+
+	_, err = ledgerMgr.OpenLedger(ledgerID)
+	require.Equal(t, ErrLedgerAlreadyOpened, err)
+	ids, err := ledgerMgr.GetLedgerIDs()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ledger1"}, ids)
+
+	_, _, err = ledgerMgr.CreateLedgerFromSnapshot(filepath.Join(testDir, "non-existing-dir"))
+	require.Contains(t, err.Error(), "error while reading the snapshot metadata file")
+}
+
 func TestChaincodeInfoProvider(t *testing.T) {
 	testDir, err := ioutil.TempDir("", "ledgermgmt")
 	if err != nil {
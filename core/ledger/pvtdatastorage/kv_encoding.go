@@ -28,6 +28,8 @@ var (
 	collElgKeyPrefix                 = []byte{6}
 	lastUpdatedOldBlocksKey          = []byte{7}
 	elgDeprioritizedMissingDataGroup = []byte{8}
+	hashedIndexKeyPrefix             = []byte{9}
+	purgeMarkerKeyPrefix             = []byte{10}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -108,6 +110,70 @@ func decodeDataValue(datavalueBytes []byte) (*rwset.CollectionPvtReadWriteSet, e
 	return collPvtdata, err
 }
 
+// encodeHashedIndexKey returns the key of the entry of the hashed index, which maps the hash of a private data
+// key to the data entries that contain a write of the key. The entries for a key hash are ordered by the height
+func encodeHashedIndexKey(key *hashedIndexKey) []byte {
+	return append(hashedIndexKeyPrefixForKeyHash(key.ns, key.coll, key.pvtkeyHash), version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
+}
+
+func decodeHashedIndexKey(keyBytes []byte) (*hashedIndexKey, error) {
+	splittedKey := bytes.SplitN(keyBytes[1:], []byte{nilByte}, 3)
+	if len(splittedKey) != 3 {
+		return nil, errors.Errorf("unexpected format of the hashed index key [%x]", keyBytes)
+	}
+	remainingBytes := splittedKey[2]
+	keyHashLen, n := proto.DecodeVarint(remainingBytes)
+	if n == 0 || uint64(len(remainingBytes)) < uint64(n)+keyHashLen {
+		return nil, errors.Errorf("unexpected format of the hashed index key [%x]", keyBytes)
+	}
+	keyHash := remainingBytes[n : uint64(n)+keyHashLen]
+	height, _, err := version.NewHeightFromBytes(remainingBytes[uint64(n)+keyHashLen:])
+	if err != nil {
+		return nil, err
+	}
+	return &hashedIndexKey{
+		ns:         string(splittedKey[0]),
+		coll:       string(splittedKey[1]),
+		pvtkeyHash: append([]byte{}, keyHash...),
+		blkNum:     height.BlockNum,
+		txNum:      height.TxNum,
+	}, nil
+}
+
+func hashedIndexKeyPrefixForKeyHash(ns, coll string, keyHash []byte) []byte {
+	encKey := append(hashedIndexKeyPrefix, []byte(ns)...)
+	encKey = append(encKey, nilByte)
+	encKey = append(encKey, []byte(coll)...)
+	encKey = append(encKey, nilByte)
+	encKey = append(encKey, proto.EncodeVarint(uint64(len(keyHash)))...)
+	return append(encKey, keyHash...)
+}
+
+// hashedIndexKeyRangeBelowBlock returns the range of the hashed index entries for the key hash with
+// a block number lower than `blkNum`
+func hashedIndexKeyRangeBelowBlock(ns, coll string, keyHash []byte, blkNum uint64) ([]byte, []byte) {
+	startKey := hashedIndexKeyPrefixForKeyHash(ns, coll, keyHash)
+	endKey := append(hashedIndexKeyPrefixForKeyHash(ns, coll, keyHash), version.NewHeight(blkNum, 0).ToBytes()...)
+	return startKey, endKey
+}
+
+func encodePurgeMarkerKey(ns, coll string, keyHash []byte) []byte {
+	encKey := append(purgeMarkerKeyPrefix, []byte(ns)...)
+	encKey = append(encKey, nilByte)
+	encKey = append(encKey, []byte(coll)...)
+	encKey = append(encKey, nilByte)
+	return append(encKey, keyHash...)
+}
+
+func encodePurgeMarkerValue(blkNum, txNum uint64) []byte {
+	return version.NewHeight(blkNum, txNum).ToBytes()
+}
+
+func decodePurgeMarkerValue(b []byte) (*version.Height, error) {
+	height, _, err := version.NewHeightFromBytes(b)
+	return height, err
+}
+
 func encodeElgPrioMissingDataKey(key *missingDataKey) []byte {
 	// When missing pvtData reconciler asks for missing data info,
 	// it is necessary to pass the missing pvtdata info associated with
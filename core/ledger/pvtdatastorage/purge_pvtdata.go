@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/pkg/errors"
+)
+
+// PurgeMarker denotes the purge of a private data key by the transaction `TxNum` in the block `BlkNum`.
+// The purge removes the writes of the key from the private data of all the transactions that precede
+// the purging transaction, while the private data of the purging transaction contains a delete of the key
+type PurgeMarker struct {
+	Namespace  string
+	Collection string
+	KeyHash    []byte
+	BlkNum     uint64
+	TxNum      uint64
+}
+
+type hashedIndexKey struct {
+	ns, coll      string
+	pvtkeyHash    []byte
+	blkNum, txNum uint64
+}
+
+// addHashedIndexEntriesTo adds to the batch an entry of the hashed index for each key that is written in the data entry
+func addHashedIndexEntriesTo(batch *leveldbhelper.UpdateBatch, key *dataKey, value *rwset.CollectionPvtReadWriteSet) error {
+	kvRWSet, err := decodeKVRWSet(value)
+	if err != nil {
+		// a private rwset that cannot be decoded cannot be purged either, hence it is not indexed
+		logger.Debugf("Skipping the hashed index entries for the private data of the tx [%d:%d]: %s", key.blkNum, key.txNum, err)
+		return nil
+	}
+	for _, keyHash := range writtenKeyHashes(kvRWSet) {
+		batch.Put(encodeHashedIndexKey(&hashedIndexKey{key.ns, key.coll, keyHash, key.blkNum, key.txNum}), emptyValue)
+	}
+	return nil
+}
+
+// deleteHashedIndexEntriesOf adds to the batch a delete of the entries of the hashed index that are added for the data entry
+func deleteHashedIndexEntriesOf(batch *leveldbhelper.UpdateBatch, key *dataKey, value *rwset.CollectionPvtReadWriteSet) error {
+	kvRWSet, err := decodeKVRWSet(value)
+	if err != nil {
+		// a private rwset that cannot be decoded cannot be purged either, hence it is not indexed
+		logger.Debugf("Skipping the hashed index entries for the private data of the tx [%d:%d]: %s", key.blkNum, key.txNum, err)
+		return nil
+	}
+	for _, keyHash := range writtenKeyHashes(kvRWSet) {
+		batch.Delete(encodeHashedIndexKey(&hashedIndexKey{key.ns, key.coll, keyHash, key.blkNum, key.txNum}))
+	}
+	return nil
+}
+
+// preparePurgeEntries adds the purge markers to the batch and removes the writes of the purged keys from the data
+// entries of the transactions that precede the purging transactions. The data entries of the block being committed
+// are updated in place, while the data entries of the previous blocks are located through the hashed index
+func (s *Store) preparePurgeEntries(batch *leveldbhelper.UpdateBatch, dataEntries []*dataEntry, purgeMarkers []*PurgeMarker) error {
+	updatedEntries := map[dataKey]*rwset.CollectionPvtReadWriteSet{}
+	for _, m := range purgeMarkers {
+		batch.Put(encodePurgeMarkerKey(m.Namespace, m.Collection, m.KeyHash), encodePurgeMarkerValue(m.BlkNum, m.TxNum))
+		isPurged := func(keyHash []byte) bool { return bytes.Equal(keyHash, m.KeyHash) }
+
+		for _, e := range dataEntries {
+			if e.key.ns != m.Namespace || e.key.coll != m.Collection || e.key.txNum >= m.TxNum {
+				continue
+			}
+			var err error
+			if e.value, err = removeWrites(e.value, isPurged); err != nil {
+				return err
+			}
+		}
+
+		startKey, endKey := hashedIndexKeyRangeBelowBlock(m.Namespace, m.Collection, m.KeyHash, m.BlkNum)
+		itr, err := s.db.GetIterator(startKey, endKey)
+		if err != nil {
+			return err
+		}
+		var dataKeys []dataKey
+		for itr.Next() {
+			indexKey, err := decodeHashedIndexKey(itr.Key())
+			if err != nil {
+				itr.Release()
+				return err
+			}
+			batch.Delete(itr.Key())
+			dataKeys = append(dataKeys, dataKey{nsCollBlk{indexKey.ns, indexKey.coll, indexKey.blkNum}, indexKey.txNum})
+		}
+		err = itr.Error()
+		itr.Release()
+		if err != nil {
+			return errors.Wrap(err, "error while scanning the hashed index")
+		}
+
+		for _, k := range dataKeys {
+			value, ok := updatedEntries[k]
+			if !ok {
+				encValue, err := s.db.Get(encodeDataKey(&k))
+				if err != nil {
+					return err
+				}
+				if encValue == nil {
+					// the data entry has expired
+					continue
+				}
+				if value, err = decodeDataValue(encValue); err != nil {
+					return err
+				}
+			}
+			if updatedEntries[k], err = removeWrites(value, isPurged); err != nil {
+				return err
+			}
+		}
+	}
+
+	for k, value := range updatedEntries {
+		encValue, err := encodeDataValue(value)
+		if err != nil {
+			return err
+		}
+		batch.Put(encodeDataKey(&k), encValue)
+	}
+	logger.Debugf("[%s] - [%d] keys purged from the private data of [%d] transactions of the previous blocks",
+		s.ledgerid, len(purgeMarkers), len(updatedEntries))
+	return nil
+}
+
+// IsPurged returns true if the private data key of the collection with the hash `keyHash` has been purged by
+// a transaction that follows the transaction `txNum` in the block `blkNum`
+func (s *Store) IsPurged(ns, coll string, keyHash []byte, blkNum, txNum uint64) (bool, error) {
+	encValue, err := s.db.Get(encodePurgeMarkerKey(ns, coll, keyHash))
+	if err != nil {
+		return false, errors.Wrap(err, "error while retrieving the purge marker")
+	}
+	if encValue == nil {
+		return false, nil
+	}
+	purgeHeight, err := decodePurgeMarkerValue(encValue)
+	if err != nil {
+		return false, errors.Wrap(err, "error while retrieving the purge marker")
+	}
+	return purgeHeight.Compare(version.NewHeight(blkNum, txNum)) > 0, nil
+}
+
+// removePurgedWrites removes from the data entry of an old block the writes of the keys that have been purged
+// after the transaction of the data entry
+func (s *Store) removePurgedWrites(key *dataKey, value *rwset.CollectionPvtReadWriteSet) (*rwset.CollectionPvtReadWriteSet, error) {
+	var lookupErr error
+	isPurged := func(keyHash []byte) bool {
+		purged, err := s.IsPurged(key.ns, key.coll, keyHash, key.blkNum, key.txNum)
+		if err != nil {
+			lookupErr = err
+		}
+		return purged
+	}
+	value, err := removeWrites(value, isPurged)
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	return value, err
+}
+
+// removeWrites returns the private rwset of the collection without the writes and the metadata writes of the keys
+// for which the function `isRemoved` returns true. The private rwset is returned as is if no key is removed or if
+// the private rwset cannot be decoded, in which case it is not indexed either
+func removeWrites(value *rwset.CollectionPvtReadWriteSet, isRemoved func(keyHash []byte) bool) (*rwset.CollectionPvtReadWriteSet, error) {
+	kvRWSet, err := decodeKVRWSet(value)
+	if err != nil {
+		return value, nil
+	}
+	var writes []*kvrwset.KVWrite
+	for _, w := range kvRWSet.Writes {
+		if !isRemoved(util.ComputeStringHash(w.Key)) {
+			writes = append(writes, w)
+		}
+	}
+	var metadataWrites []*kvrwset.KVMetadataWrite
+	for _, w := range kvRWSet.MetadataWrites {
+		if !isRemoved(util.ComputeStringHash(w.Key)) {
+			metadataWrites = append(metadataWrites, w)
+		}
+	}
+	if len(writes) == len(kvRWSet.Writes) && len(metadataWrites) == len(kvRWSet.MetadataWrites) {
+		return value, nil
+	}
+	kvRWSet.Writes, kvRWSet.MetadataWrites = writes, metadataWrites
+	rwsetBytes, err := proto.Marshal(kvRWSet)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshalling the private rwset")
+	}
+	return &rwset.CollectionPvtReadWriteSet{
+		CollectionName: value.CollectionName,
+		Rwset:          rwsetBytes,
+	}, nil
+}
+
+func decodeKVRWSet(value *rwset.CollectionPvtReadWriteSet) (*kvrwset.KVRWSet, error) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(value.Rwset, kvRWSet); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshalling the private rwset of the collection [%s]", value.CollectionName)
+	}
+	return kvRWSet, nil
+}
+
+func writtenKeyHashes(kvRWSet *kvrwset.KVRWSet) [][]byte {
+	var keyHashes [][]byte
+	keys := map[string]bool{}
+	for _, w := range kvRWSet.Writes {
+		keys[w.Key] = true
+	}
+	for _, w := range kvRWSet.MetadataWrites {
+		keys[w.Key] = true
+	}
+	for key := range keys {
+		keyHashes = append(keyHashes, util.ComputeStringHash(key))
+	}
+	return keyHashes
+}
@@ -0,0 +1,199 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorePurgeMarkers(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestStorePurgeMarkers", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	store := env.TestStore
+
+	purgedKeyHash := util.ComputeStringHash("key-ns-1-coll-1")
+	require.NoError(t, store.Commit(0, nil, nil, nil))
+
+	// block 1 - tx 2 and tx 4 write the key that is purged later, pvtdata of tx 5 is missing
+	blk1MissingData := make(ledger.TxMissingPvtDataMap)
+	blk1MissingData.Add(5, "ns-1", "coll-1", true)
+	require.NoError(t, store.Commit(1,
+		[]*ledger.TxPvtData{
+			produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+			produceSamplePvtdata(t, 4, []string{"ns-1:coll-1"}),
+		},
+		blk1MissingData,
+		nil,
+	))
+	require.Len(t, testHashedIndexEntries(t, store, "ns-1", "coll-1", purgedKeyHash), 2)
+
+	// block 2 - tx 1 writes the key, tx 2 purges the key and tx 3 writes the key again
+	require.NoError(t, store.Commit(2,
+		[]*ledger.TxPvtData{
+			produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"}),
+			producePurgePvtdata(t, 2, "ns-1", "coll-1", "key-ns-1-coll-1"),
+			produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"}),
+		},
+		nil,
+		[]*PurgeMarker{
+			{Namespace: "ns-1", Collection: "coll-1", KeyHash: purgedKeyHash, BlkNum: 2, TxNum: 2},
+		},
+	))
+
+	// the writes of the purged key in the transactions that precede the purge are removed
+	// while the writes of the other keys and the writes that follow the purge are retained
+	blk1Data, err := store.GetPvtDataByBlockNum(1, nil)
+	require.NoError(t, err)
+	require.Len(t, blk1Data, 2)
+	require.Empty(t, testPvtWrites(t, blk1Data[0], "ns-1", "coll-1"))
+	require.Len(t, testPvtWrites(t, blk1Data[0], "ns-1", "coll-2"), 1)
+	require.Empty(t, testPvtWrites(t, blk1Data[1], "ns-1", "coll-1"))
+
+	blk2Data, err := store.GetPvtDataByBlockNum(2, nil)
+	require.NoError(t, err)
+	require.Len(t, blk2Data, 3)
+	require.Empty(t, testPvtWrites(t, blk2Data[0], "ns-1", "coll-1"))
+	purgeWrites := testPvtWrites(t, blk2Data[1], "ns-1", "coll-1")
+	require.Len(t, purgeWrites, 1)
+	require.True(t, purgeWrites[0].IsDelete)
+	require.True(t, proto.Equal(
+		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"}).WriteSet,
+		blk2Data[2].WriteSet,
+	))
+
+	// the index entries of the purged writes are removed
+	require.Equal(t,
+		[]*hashedIndexKey{
+			{"ns-1", "coll-1", purgedKeyHash, 2, 2},
+			{"ns-1", "coll-1", purgedKeyHash, 2, 3},
+		},
+		testHashedIndexEntries(t, store, "ns-1", "coll-1", purgedKeyHash),
+	)
+
+	// the key is purged for the transactions that precede the purge only
+	for _, testCase := range []struct {
+		ns, coll      string
+		blkNum, txNum uint64
+		purged        bool
+	}{
+		{"ns-1", "coll-1", 1, 5, true},
+		{"ns-1", "coll-1", 2, 1, true},
+		{"ns-1", "coll-1", 2, 2, false},
+		{"ns-1", "coll-1", 2, 3, false},
+		{"ns-1", "coll-2", 1, 2, false},
+	} {
+		purged, err := store.IsPurged(testCase.ns, testCase.coll, purgedKeyHash, testCase.blkNum, testCase.txNum)
+		require.NoError(t, err)
+		require.Equal(t, testCase.purged, purged)
+	}
+
+	// the missing pvtdata of a transaction that precedes the purge is committed without the purged key
+	require.NoError(t, store.CommitPvtDataOfOldBlocks(
+		map[uint64][]*ledger.TxPvtData{
+			1: {produceSamplePvtdata(t, 5, []string{"ns-1:coll-1"})},
+		},
+		nil,
+	))
+	blk1Data, err = store.GetPvtDataByBlockNum(1, nil)
+	require.NoError(t, err)
+	require.Len(t, blk1Data, 3)
+	require.Equal(t, uint64(5), blk1Data[2].SeqInBlock)
+	require.Empty(t, testPvtWrites(t, blk1Data[2], "ns-1", "coll-1"))
+	missingDataInfo, err := store.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	require.NoError(t, err)
+	require.Empty(t, missingDataInfo)
+}
+
+func TestStorePurgeMarkersWithExpiry(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 1,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestStorePurgeMarkersWithExpiry", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	store := env.TestStore
+	store.purgeInterval = 1
+
+	keyHash := util.ComputeStringHash("key-ns-1-coll-1")
+	require.NoError(t, store.Commit(0, nil, nil, nil))
+	require.NoError(t, store.Commit(1, []*ledger.TxPvtData{produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"})}, nil, nil))
+	require.Len(t, testHashedIndexEntries(t, store, "ns-1", "coll-1", keyHash), 1)
+
+	// the expiry of the pvtdata removes the index entries of the pvtdata
+	require.NoError(t, store.Commit(2, nil, nil, nil))
+	require.NoError(t, store.Commit(3, nil, nil, nil))
+	testWaitForPurgerRoutineToFinish(store)
+	require.False(t, testDataKeyExists(t, store, &dataKey{nsCollBlk{"ns-1", "coll-1", 1}, 1}))
+	require.Empty(t, testHashedIndexEntries(t, store, "ns-1", "coll-1", keyHash))
+
+	// a purge of the expired key finds nothing to remove
+	require.NoError(t, store.Commit(4,
+		[]*ledger.TxPvtData{producePurgePvtdata(t, 0, "ns-1", "coll-1", "key-ns-1-coll-1")},
+		nil,
+		[]*PurgeMarker{
+			{Namespace: "ns-1", Collection: "coll-1", KeyHash: keyHash, BlkNum: 4, TxNum: 0},
+		},
+	))
+	blk4Data, err := store.GetPvtDataByBlockNum(4, nil)
+	require.NoError(t, err)
+	require.Len(t, blk4Data, 1)
+}
+
+func producePurgePvtdata(t *testing.T, txNum uint64, ns, coll, key string) *ledger.TxPvtData {
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToPvtAndHashedWriteSetForPurge(ns, coll, key)
+	simRes, err := builder.GetTxSimulationResults()
+	require.NoError(t, err)
+	return &ledger.TxPvtData{SeqInBlock: txNum, WriteSet: simRes.PvtSimulationResults}
+}
+
+func testPvtWrites(t *testing.T, txPvtData *ledger.TxPvtData, ns, coll string) []*kvrwset.KVWrite {
+	for _, nsPvtRwset := range txPvtData.WriteSet.NsPvtRwset {
+		if nsPvtRwset.Namespace != ns {
+			continue
+		}
+		for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+			if collPvtRwset.CollectionName != coll {
+				continue
+			}
+			kvRWSet := &kvrwset.KVRWSet{}
+			require.NoError(t, proto.Unmarshal(collPvtRwset.Rwset, kvRWSet))
+			return kvRWSet.Writes
+		}
+	}
+	require.FailNowf(t, "collection not found", "[%s:%s] not found in the pvtdata of the tx [%d]", ns, coll, txPvtData.SeqInBlock)
+	return nil
+}
+
+func testHashedIndexEntries(t *testing.T, s *Store, ns, coll string, keyHash []byte) []*hashedIndexKey {
+	startKey, endKey := hashedIndexKeyRangeBelowBlock(ns, coll, keyHash, 1<<63)
+	itr, err := s.db.GetIterator(startKey, endKey)
+	require.NoError(t, err)
+	defer itr.Release()
+	var entries []*hashedIndexKey
+	for itr.Next() {
+		entry, err := decodeHashedIndexKey(itr.Key())
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	return entries
+}
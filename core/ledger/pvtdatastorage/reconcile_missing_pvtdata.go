@@ -82,6 +82,13 @@ func (p *oldBlockDataProcessor) prepareDataAndExpiryEntries(blocksPvtData map[ui
 		nsCollBlk := dataEntry.key.nsCollBlk
 		txNum := dataEntry.key.txNum
 
+		// the data entry is retained even if all its writes have been purged, so that
+		// the missing data entry reflects that the data of the transaction is reconciled
+		var err error
+		if dataEntry.value, err = p.removePurgedWrites(dataEntry.key, dataEntry.value); err != nil {
+			return err
+		}
+
 		expKey, err := p.constructExpiryKey(dataEntry)
 		if err != nil {
 			return err
@@ -292,6 +299,9 @@ func (e *entriesForPvtDataOfOldBlocks) addDataEntriesTo(batch *leveldbhelper.Upd
 			return errors.Wrap(err, "error while encoding data value")
 		}
 		batch.Put(key, val)
+		if err := addHashedIndexEntriesTo(batch, &dataKey, pvtData); err != nil {
+			return err
+		}
 	}
 	return nil
 }
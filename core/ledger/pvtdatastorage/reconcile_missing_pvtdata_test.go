@@ -88,9 +88,9 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 
 	blocksPvtData, missingDataSummary := constructPvtDataForTest(t, blockTxPvtDataInfo)
 
-	require.NoError(t, store.Commit(0, nil, nil))
-	require.NoError(t, store.Commit(1, blocksPvtData[1].pvtData, blocksPvtData[1].missingDataInfo))
-	require.NoError(t, store.Commit(2, blocksPvtData[2].pvtData, blocksPvtData[2].missingDataInfo))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
+	require.NoError(t, store.Commit(1, blocksPvtData[1].pvtData, blocksPvtData[1].missingDataInfo, nil))
+	require.NoError(t, store.Commit(2, blocksPvtData[2].pvtData, blocksPvtData[2].missingDataInfo, nil))
 
 	assertMissingDataInfo(t, store, missingDataSummary, 2)
 
@@ -180,14 +180,14 @@ func TestCommitPvtDataOfOldBlocksWithBTL(t *testing.T) {
 
 		blocksPvtData, missingDataSummary := constructPvtDataForTest(t, blockTxPvtDataInfo)
 
-		require.NoError(t, store.Commit(0, nil, nil))
-		require.NoError(t, store.Commit(1, blocksPvtData[1].pvtData, blocksPvtData[1].missingDataInfo))
+		require.NoError(t, store.Commit(0, nil, nil, nil))
+		require.NoError(t, store.Commit(1, blocksPvtData[1].pvtData, blocksPvtData[1].missingDataInfo, nil))
 
 		assertMissingDataInfo(t, store, missingDataSummary, 1)
 
 		// COMMIT BLOCK 2 & 3 WITH NO PVTDATA
-		require.NoError(t, store.Commit(2, nil, nil))
-		require.NoError(t, store.Commit(3, nil, nil))
+		require.NoError(t, store.Commit(2, nil, nil, nil))
+		require.NoError(t, store.Commit(3, nil, nil, nil))
 	}
 
 	t.Run("expired but not purged", func(t *testing.T) {
@@ -291,7 +291,7 @@ func TestCommitPvtDataOfOldBlocksWithBTL(t *testing.T) {
 		store := env.TestStore
 
 		setup(store)
-		require.NoError(t, store.Commit(4, nil, nil))
+		require.NoError(t, store.Commit(4, nil, nil, nil))
 
 		testWaitForPurgerRoutineToFinish(store)
 
@@ -479,9 +479,9 @@ func TestCommitPvtDataOfOldBlocksWithDeprioritization(t *testing.T) {
 			store := env.TestStore
 
 			// COMMIT BLOCK 0 WITH NO DATA
-			require.NoError(t, store.Commit(0, nil, nil))
-			require.NoError(t, store.Commit(1, blocksPvtData[1].pvtData, blocksPvtData[1].missingDataInfo))
-			require.NoError(t, store.Commit(2, blocksPvtData[2].pvtData, blocksPvtData[2].missingDataInfo))
+			require.NoError(t, store.Commit(0, nil, nil, nil))
+			require.NoError(t, store.Commit(1, blocksPvtData[1].pvtData, blocksPvtData[1].missingDataInfo, nil))
+			require.NoError(t, store.Commit(2, blocksPvtData[2].pvtData, blocksPvtData[2].missingDataInfo, nil))
 
 			assertMissingDataInfo(t, store, missingDataSummary, 2)
 
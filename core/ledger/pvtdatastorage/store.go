@@ -197,6 +197,23 @@ func (s *Store) initState() error {
 	return nil
 }
 
+// InitLastCommittedBlock sets the last committed block num to the given value. This is used when the
+// ledger is created from a snapshot, as the private data of the blocks in the snapshot is not part of
+// the snapshot and the store is expected to receive the private data of the blocks committed afterwards
+func (s *Store) InitLastCommittedBlock(blockNum uint64) error {
+	if !s.isEmpty {
+		return &ErrIllegalCall{"The private data store is not empty. InitLastCommittedBlock() function call is not allowed"}
+	}
+	batch := s.db.NewUpdateBatch()
+	batch.Put(lastCommittedBlkkey, encodeLastCommittedBlockVal(blockNum))
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	s.isEmpty = false
+	atomic.StoreUint64(&s.lastCommittedBlock, blockNum)
+	return nil
+}
+
 // Init initializes the store. This function is expected to be invoked before using the store
 func (s *Store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 	s.btlPolicy = btlPolicy
@@ -205,8 +222,9 @@ func (s *Store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 // Commit commits the pvt data as well as both the eligible and ineligible
 // missing private data --- `eligible` denotes that the missing private data belongs to a collection
 // for which this peer is a member; `ineligible` denotes that the missing private data belong to a
-// collection for which this peer is not a member.
-func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap) error {
+// collection for which this peer is not a member. The `purgeMarkers` denote the keys purged by the
+// valid transactions in the block, whose writes are removed from the pvt data of the preceding transactions
+func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap, purgeMarkers []*PurgeMarker) error {
 	expectedBlockNum := s.nextBlockNum()
 	if expectedBlockNum != blockNum {
 		return &ErrIllegalArgs{fmt.Sprintf("Expected block number=%d, received block number=%d", expectedBlockNum, blockNum)}
@@ -221,12 +239,24 @@ func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtD
 		return err
 	}
 
+	if len(purgeMarkers) > 0 {
+		// the purger must not delete the data entries that are being updated
+		s.purgerLock.Lock()
+		defer s.purgerLock.Unlock()
+		if err := s.preparePurgeEntries(batch, storeEntries.dataEntries, purgeMarkers); err != nil {
+			return err
+		}
+	}
+
 	for _, dataEntry := range storeEntries.dataEntries {
 		key = encodeDataKey(dataEntry.key)
 		if val, err = encodeDataValue(dataEntry.value); err != nil {
 			return err
 		}
 		batch.Put(key, val)
+		if err := addHashedIndexEntriesTo(batch, dataEntry.key, dataEntry.value); err != nil {
+			return err
+		}
 	}
 
 	for _, expiryEntry := range storeEntries.expiryEntries {
@@ -547,7 +577,22 @@ func (s *Store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 		dataKeys, missingDataKeys := deriveKeys(expiryEntry)
 
 		for _, dataKey := range dataKeys {
-			batch.Delete(encodeDataKey(dataKey))
+			encDataKey := encodeDataKey(dataKey)
+			encDataValue, err := s.db.Get(encDataKey)
+			if err != nil {
+				return err
+			}
+			if encDataValue == nil {
+				continue
+			}
+			dataValue, err := decodeDataValue(encDataValue)
+			if err != nil {
+				return err
+			}
+			if err := deleteHashedIndexEntriesOf(batch, dataKey, dataValue); err != nil {
+				return err
+			}
+			batch.Delete(encDataKey)
 		}
 
 		for _, missingDataKey := range missingDataKeys {
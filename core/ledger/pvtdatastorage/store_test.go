@@ -78,10 +78,10 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// no pvt data with block 0
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 
 	// pvt data with block 1 - commit
-	require.NoError(t, store.Commit(1, testData, blk1MissingData))
+	require.NoError(t, store.Commit(1, testData, blk1MissingData, nil))
 
 	// pvt data retrieval for block 0 should return nil
 	var nilFilter ledger.PvtNsCollFilter
@@ -119,7 +119,7 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	require.Nil(t, retrievedData)
 
 	// pvt data with block 2 - commit
-	require.NoError(t, store.Commit(2, testData, blk2MissingData))
+	require.NoError(t, store.Commit(2, testData, blk2MissingData, nil))
 
 	// retrieve the stored missing entries using GetMissingPvtDataInfoForMostRecentBlocks
 	// Only the code path of eligible entries would be covered in this unit-test. For
@@ -157,7 +157,7 @@ func TestStoreIteratorError(t *testing.T) {
 	env := NewTestStoreEnv(t, "TestStoreIteratorError", nil, pvtDataConf())
 	defer env.Cleanup()
 	store := env.TestStore
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 	env.TestStoreProvider.Close()
 	errStr := "internal leveldb error while obtaining db iterator: leveldb: closed"
 
@@ -213,8 +213,8 @@ func TestGetMissingDataInfo(t *testing.T) {
 		blk1MissingData.Add(1, "ns-1", "coll-1", true)
 		blk1MissingData.Add(1, "ns-1", "coll-2", true)
 
-		require.NoError(t, store.Commit(0, nil, nil))
-		require.NoError(t, store.Commit(1, nil, blk1MissingData))
+		require.NoError(t, store.Commit(0, nil, nil, nil))
+		require.NoError(t, store.Commit(1, nil, blk1MissingData, nil))
 
 		deprioritizedList := ledger.MissingPvtDataInfo{
 			1: ledger.MissingBlockPvtdataInfo{
@@ -329,21 +329,21 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	blk2MissingData.Add(1, "ns-1", "coll-2", true)
 
 	// no pvt data with block 0
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 
 	// write pvt data for block 1
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(t, store.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(t, store.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// write pvt data for block 2
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 5, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(t, store.Commit(2, testDataForBlk2, blk2MissingData))
+	require.NoError(t, store.Commit(2, testDataForBlk2, blk2MissingData, nil))
 
 	retrievedData, _ := store.GetPvtDataByBlockNum(1, nil)
 	// block 1 data should still be not expired
@@ -367,7 +367,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	require.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 3 with no pvtdata
-	require.NoError(t, store.Commit(3, nil, nil))
+	require.NoError(t, store.Commit(3, nil, nil, nil))
 
 	// After committing block 3, the data for "ns-1:coll1" of block 1 should have expired and should not be returned by the store
 	expectedPvtdataFromBlock1 := []*ledger.TxPvtData{
@@ -390,7 +390,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	require.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 4 with no pvtdata
-	require.NoError(t, store.Commit(4, nil, nil))
+	require.NoError(t, store.Commit(4, nil, nil, nil))
 
 	// After committing block 4, the data for "ns-2:coll2" of block 1 should also have expired and should not be returned by the store
 	expectedPvtdataFromBlock1 = []*ledger.TxPvtData{
@@ -438,7 +438,7 @@ func TestStorePurge(t *testing.T) {
 	s := env.TestStore
 
 	// no pvt data with block 0
-	require.NoError(t, s.Commit(0, nil, nil))
+	require.NoError(t, s.Commit(0, nil, nil, nil))
 
 	// construct missing data for block 1
 	blk1MissingData := make(ledger.TxMissingPvtDataMap)
@@ -457,10 +457,10 @@ func TestStorePurge(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(t, s.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(t, s.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// write pvt data for block 2
-	require.NoError(t, s.Commit(2, nil, nil))
+	require.NoError(t, s.Commit(2, nil, nil, nil))
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store
 	ns1Coll1 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 2}
 	ns2Coll2 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-2", coll: "coll-2", blkNum: 1}, txNum: 2}
@@ -500,7 +500,7 @@ func TestStorePurge(t *testing.T) {
 	require.NoError(t, s.CommitPvtDataOfOldBlocks(nil, deprioritizedList))
 
 	// write pvt data for block 3
-	require.NoError(t, s.Commit(3, nil, nil))
+	require.NoError(t, s.Commit(3, nil, nil, nil))
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store (because purger should not be launched at block 3)
 	testWaitForPurgerRoutineToFinish(s)
 	require.True(t, testDataKeyExists(t, s, ns1Coll1))
@@ -516,7 +516,7 @@ func TestStorePurge(t *testing.T) {
 	require.True(t, testInelgMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 4
-	require.NoError(t, s.Commit(4, nil, nil))
+	require.NoError(t, s.Commit(4, nil, nil, nil))
 	// data for ns-1:coll-1 should not exist in store (because purger should be launched at block 4)
 	// but ns-2:coll-2 should exist because it expires at block 5
 	testWaitForPurgerRoutineToFinish(s)
@@ -532,14 +532,14 @@ func TestStorePurge(t *testing.T) {
 	require.True(t, testInelgMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 5
-	require.NoError(t, s.Commit(5, nil, nil))
+	require.NoError(t, s.Commit(5, nil, nil, nil))
 	// ns-2:coll-2 should exist because though the data expires at block 5 but purger is launched every second block
 	testWaitForPurgerRoutineToFinish(s)
 	require.False(t, testDataKeyExists(t, s, ns1Coll1))
 	require.True(t, testDataKeyExists(t, s, ns2Coll2))
 
 	// write pvt data for block 6
-	require.NoError(t, s.Commit(6, nil, nil))
+	require.NoError(t, s.Commit(6, nil, nil, nil))
 	// ns-2:coll-2 should not exists now (because purger should be launched at block 6)
 	testWaitForPurgerRoutineToFinish(s)
 	require.False(t, testDataKeyExists(t, s, ns1Coll1))
@@ -562,10 +562,33 @@ func TestStoreState(t *testing.T) {
 	testData := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 0, []string{"ns-1:coll-1", "ns-1:coll-2"}),
 	}
-	_, ok := store.Commit(1, testData, nil).(*ErrIllegalArgs)
+	_, ok := store.Commit(1, testData, nil, nil).(*ErrIllegalArgs)
 	require.True(t, ok)
 }
 
+func TestInitLastCommittedBlock(t *testing.T) {
+	env := NewTestStoreEnv(t, "TestInitLastCommittedBlock", btltestutil.SampleBTLPolicy(nil), pvtDataConf())
+	defer env.Cleanup()
+	store := env.TestStore
+
+	require.NoError(t, store.InitLastCommittedBlock(5))
+	height, err := store.LastCommittedBlockHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), height)
+
+	_, ok := store.InitLastCommittedBlock(6).(*ErrIllegalCall)
+	require.True(t, ok)
+	_, ok = store.Commit(5, nil, nil, nil).(*ErrIllegalArgs)
+	require.True(t, ok)
+	require.NoError(t, store.Commit(6, nil, nil, nil))
+
+	// the last committed block should be retained after a restart
+	env.CloseAndReopen()
+	height, err = env.TestStore.LastCommittedBlockHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), height)
+}
+
 func TestPendingBatch(t *testing.T) {
 	btlPolicy := btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
@@ -665,7 +688,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	// Initial state: eligible for {ns-1:coll-1 and ns-2:coll-1 }
 
 	// no pvt data with block 0
-	require.NoError(t, testStore.Commit(0, nil, nil))
+	require.NoError(t, testStore.Commit(0, nil, nil, nil))
 
 	// construct and commit block 1
 	blk1MissingData := make(ledger.TxMissingPvtDataMap)
@@ -676,7 +699,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
 	}
-	require.NoError(t, testStore.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(t, testStore.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// construct and commit block 2
 	blk2MissingData := make(ledger.TxMissingPvtDataMap)
@@ -686,7 +709,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"}),
 	}
-	require.NoError(t, testStore.Commit(2, testDataForBlk2, blk2MissingData))
+	require.NoError(t, testStore.Commit(2, testDataForBlk2, blk2MissingData, nil))
 
 	// Retrieve and verify missing data reported
 	// Expected missing data should be only blk1-tx1 (because, the other missing data is marked as ineliigible)
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("snapshotgrpc")
+
+// LedgerGetter gets the PeerLedger associated with a channel.
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ACLProvider checks the ACL of a peer wide resource.
+type ACLProvider interface {
+	CheckACLNoChannel(resName string, idinfo interface{}) error
+}
+
+// Snapshot implements the pb.SnapshotServer service. The requests are served
+// only if the signer of the request satisfies the ACL of the resource.
+type Snapshot struct {
+	LedgerGetter LedgerGetter
+	ACLProvider  ACLProvider
+}
+
+// Generate submits a request to generate a snapshot of the ledger at the
+// block number specified in the request.
+func (s *Snapshot) Generate(ctx context.Context, signedRequest *pb.SignedSnapshotRequest) (*empty.Empty, error) {
+	request := &pb.SnapshotRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot request")
+	}
+
+	if err := s.checkACL(resources.Snapshot_submitrequest, request.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	lgr, err := s.getLedger(request.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lgr.SubmitSnapshotRequest(request.BlockNumber); err != nil {
+		return nil, errors.WithMessagef(err, "failed to submit snapshot request for block number %d", request.BlockNumber)
+	}
+	logger.Infof("Submitted snapshot request for channel %s at block number %d", request.ChannelId, request.BlockNumber)
+	return &empty.Empty{}, nil
+}
+
+// Cancel cancels the pending snapshot request for the block number
+// specified in the request.
+func (s *Snapshot) Cancel(ctx context.Context, signedRequest *pb.SignedSnapshotRequest) (*empty.Empty, error) {
+	request := &pb.SnapshotRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot request")
+	}
+
+	if err := s.checkACL(resources.Snapshot_cancelrequest, request.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	lgr, err := s.getLedger(request.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lgr.CancelSnapshotRequest(request.BlockNumber); err != nil {
+		return nil, errors.WithMessagef(err, "failed to cancel snapshot request for block number %d", request.BlockNumber)
+	}
+	logger.Infof("Cancelled snapshot request for channel %s at block number %d", request.ChannelId, request.BlockNumber)
+	return &empty.Empty{}, nil
+}
+
+// QueryPendings returns the block numbers of the pending snapshot requests
+// of the channel specified in the query.
+func (s *Snapshot) QueryPendings(ctx context.Context, signedRequest *pb.SignedSnapshotRequest) (*pb.QueryPendingSnapshotsResponse, error) {
+	query := &pb.SnapshotQuery{}
+	if err := proto.Unmarshal(signedRequest.Request, query); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot query")
+	}
+
+	if err := s.checkACL(resources.Snapshot_listpending, query.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	lgr, err := s.getLedger(query.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumbers, err := lgr.PendingSnapshotRequests()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to query pending snapshot requests")
+	}
+	return &pb.QueryPendingSnapshotsResponse{BlockNumbers: blockNumbers}, nil
+}
+
+func (s *Snapshot) getLedger(channelID string) (ledger.PeerLedger, error) {
+	if channelID == "" {
+		return nil, errors.New("missing channel ID")
+	}
+	lgr := s.LedgerGetter.GetLedger(channelID)
+	if lgr == nil {
+		return nil, errors.Errorf("cannot find ledger for channel %s", channelID)
+	}
+	return lgr, nil
+}
+
+func (s *Snapshot) checkACL(resName string, signatureHeader *common.SignatureHeader, signedRequest *pb.SignedSnapshotRequest) error {
+	if signatureHeader == nil {
+		return errors.New("missing signature header")
+	}
+
+	expirationTime := crypto.ExpiresAt(signatureHeader.Creator)
+	if !expirationTime.IsZero() && time.Now().After(expirationTime) {
+		return errors.New("client identity expired")
+	}
+
+	return s.ACLProvider.CheckACLNoChannel(
+		resName,
+		[]*protoutil.SignedData{{
+			Identity:  signatureHeader.Creator,
+			Data:      signedRequest.Request,
+			Signature: signedRequest.Signature,
+		}},
+	)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/mock"
+	peermock "github.com/hyperledger/fabric/core/peer/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/ledger_getter.go -fake-name LedgerGetter . ledgerGetter
+type ledgerGetter interface {
+	LedgerGetter
+}
+
+//go:generate counterfeiter -o mock/acl_provider.go -fake-name ACLProvider . aclProvider
+type aclProvider interface {
+	ACLProvider
+}
+
+func TestSnapshot(t *testing.T) {
+	fakeLedger := &peermock.PeerLedger{}
+	fakeLedger.PendingSnapshotRequestsReturns([]uint64{10, 20}, nil)
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeLedgerGetter.GetLedgerReturns(fakeLedger)
+	fakeACLProvider := &mock.ACLProvider{}
+	snapshotSvc := &Snapshot{LedgerGetter: fakeLedgerGetter, ACLProvider: fakeACLProvider}
+
+	signatureHdr := &common.SignatureHeader{Creator: []byte("creator"), Nonce: []byte("nonce")}
+	request := &pb.SnapshotRequest{SignatureHeader: signatureHdr, ChannelId: "testchannel", BlockNumber: 100}
+	signedRequest := &pb.SignedSnapshotRequest{Request: protoutil.MarshalOrPanic(request), Signature: []byte("signature")}
+	query := &pb.SnapshotQuery{SignatureHeader: signatureHdr, ChannelId: "testchannel"}
+	signedQuery := &pb.SignedSnapshotRequest{Request: protoutil.MarshalOrPanic(query), Signature: []byte("signature")}
+
+	_, err := snapshotSvc.Generate(context.Background(), signedRequest)
+	require.NoError(t, err)
+	require.Equal(t, 1, fakeLedger.SubmitSnapshotRequestCallCount())
+	require.Equal(t, uint64(100), fakeLedger.SubmitSnapshotRequestArgsForCall(0))
+	require.Equal(t, "testchannel", fakeLedgerGetter.GetLedgerArgsForCall(0))
+
+	_, err = snapshotSvc.Cancel(context.Background(), signedRequest)
+	require.NoError(t, err)
+	require.Equal(t, 1, fakeLedger.CancelSnapshotRequestCallCount())
+	require.Equal(t, uint64(100), fakeLedger.CancelSnapshotRequestArgsForCall(0))
+
+	resp, err := snapshotSvc.QueryPendings(context.Background(), signedQuery)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 20}, resp.BlockNumbers)
+
+	require.Equal(t, 3, fakeACLProvider.CheckACLNoChannelCallCount())
+	expectedSignedData := []*protoutil.SignedData{{
+		Identity:  []byte("creator"),
+		Data:      signedRequest.Request,
+		Signature: []byte("signature"),
+	}}
+	for i, resName := range []string{resources.Snapshot_submitrequest, resources.Snapshot_cancelrequest, resources.Snapshot_listpending} {
+		actualResName, idinfo := fakeACLProvider.CheckACLNoChannelArgsForCall(i)
+		require.Equal(t, resName, actualResName)
+		if resName == resources.Snapshot_listpending {
+			expectedSignedData[0].Data = signedQuery.Request
+		}
+		require.Equal(t, expectedSignedData, idinfo)
+	}
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	fakeLedger := &peermock.PeerLedger{}
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeLedgerGetter.GetLedgerReturns(fakeLedger)
+	fakeACLProvider := &mock.ACLProvider{}
+	snapshotSvc := &Snapshot{LedgerGetter: fakeLedgerGetter, ACLProvider: fakeACLProvider}
+
+	signatureHdr := &common.SignatureHeader{Creator: []byte("creator"), Nonce: []byte("nonce")}
+	newSignedRequest := func(msg proto.Message) *pb.SignedSnapshotRequest {
+		return &pb.SignedSnapshotRequest{Request: protoutil.MarshalOrPanic(msg), Signature: []byte("signature")}
+	}
+	signedRequest := newSignedRequest(&pb.SnapshotRequest{SignatureHeader: signatureHdr, ChannelId: "testchannel", BlockNumber: 100})
+	signedQuery := newSignedRequest(&pb.SnapshotQuery{SignatureHeader: signatureHdr, ChannelId: "testchannel"})
+
+	generate := func(r *pb.SignedSnapshotRequest) error {
+		_, err := snapshotSvc.Generate(context.Background(), r)
+		return err
+	}
+	cancel := func(r *pb.SignedSnapshotRequest) error {
+		_, err := snapshotSvc.Cancel(context.Background(), r)
+		return err
+	}
+	queryPendings := func(r *pb.SignedSnapshotRequest) error {
+		_, err := snapshotSvc.QueryPendings(context.Background(), r)
+		return err
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		badRequest := &pb.SignedSnapshotRequest{Request: []byte("garbage")}
+		require.Contains(t, generate(badRequest).Error(), "failed to unmarshal snapshot request")
+		require.Contains(t, cancel(badRequest).Error(), "failed to unmarshal snapshot request")
+		require.Contains(t, queryPendings(badRequest).Error(), "failed to unmarshal snapshot query")
+	})
+
+	t.Run("missing signature header", func(t *testing.T) {
+		r := newSignedRequest(&pb.SnapshotRequest{ChannelId: "testchannel"})
+		require.EqualError(t, generate(r), "missing signature header")
+		require.EqualError(t, cancel(r), "missing signature header")
+		require.EqualError(t, queryPendings(newSignedRequest(&pb.SnapshotQuery{ChannelId: "testchannel"})), "missing signature header")
+	})
+
+	t.Run("access denied", func(t *testing.T) {
+		fakeACLProvider.CheckACLNoChannelReturns(errors.New("access denied"))
+		defer fakeACLProvider.CheckACLNoChannelReturns(nil)
+		require.EqualError(t, generate(signedRequest), "access denied")
+		require.EqualError(t, cancel(signedRequest), "access denied")
+		require.EqualError(t, queryPendings(signedQuery), "access denied")
+		require.Equal(t, 0, fakeLedgerGetter.GetLedgerCallCount())
+	})
+
+	t.Run("missing channel ID", func(t *testing.T) {
+		r := newSignedRequest(&pb.SnapshotRequest{SignatureHeader: signatureHdr})
+		require.EqualError(t, generate(r), "missing channel ID")
+		require.EqualError(t, cancel(r), "missing channel ID")
+		require.EqualError(t, queryPendings(newSignedRequest(&pb.SnapshotQuery{SignatureHeader: signatureHdr})), "missing channel ID")
+	})
+
+	t.Run("ledger not found", func(t *testing.T) {
+		fakeLedgerGetter.GetLedgerReturns(nil)
+		defer fakeLedgerGetter.GetLedgerReturns(fakeLedger)
+		require.EqualError(t, generate(signedRequest), "cannot find ledger for channel testchannel")
+		require.EqualError(t, cancel(signedRequest), "cannot find ledger for channel testchannel")
+		require.EqualError(t, queryPendings(signedQuery), "cannot find ledger for channel testchannel")
+	})
+
+	t.Run("ledger returns error", func(t *testing.T) {
+		fakeLedger.SubmitSnapshotRequestReturns(errors.New("submit failed"))
+		fakeLedger.CancelSnapshotRequestReturns(errors.New("cancel failed"))
+		fakeLedger.PendingSnapshotRequestsReturns(nil, errors.New("query failed"))
+		require.EqualError(t, generate(signedRequest), "failed to submit snapshot request for block number 100: submit failed")
+		require.EqualError(t, cancel(signedRequest), "failed to cancel snapshot request for block number 100: cancel failed")
+		require.EqualError(t, queryPendings(signedQuery), "failed to query pending snapshot requests: query failed")
+	})
+}
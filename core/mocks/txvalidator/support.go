@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
 )
@@ -30,6 +31,7 @@ type Support struct {
 	MSPManagerVal msp.MSPManager
 	ApplyVal      error
 	ACVal         channelconfig.ApplicationCapabilities
+	HashVal       func([]byte) []byte
 
 	sync.Mutex
 	capabilitiesInvokeCount int
@@ -65,6 +67,14 @@ func (ms *Support) GetMSPIDs() []string {
 	return []string{"SampleOrg"}
 }
 
+// BlockHashingAlgorithm returns HashVal, or SHA-256 if HashVal is nil
+func (ms *Support) BlockHashingAlgorithm() func([]byte) []byte {
+	if ms.HashVal == nil {
+		return util.ComputeSHA256
+	}
+	return ms.HashVal
+}
+
 func (ms *Support) CapabilitiesInvokeCount() int {
 	ms.Lock()
 	defer ms.Unlock()
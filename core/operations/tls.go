@@ -18,6 +18,8 @@ type TLS struct {
 	Enabled            bool
 	CertFile           string
 	KeyFile            string
+	EncCertFile        string
+	EncKeyFile         string
 	ClientCertRequired bool
 	ClientCACertFiles  []string
 }
@@ -30,6 +32,17 @@ func (t TLS) Config() (*tls.Config, error) {
 		if err != nil {
 			return nil, err
 		}
+		certs := []tls.Certificate{cert}
+		var gmSupport *tls.GMSupport
+		// a dedicated encryption key pair switches the endpoint to GM TLS
+		if t.EncCertFile != "" || t.EncKeyFile != "" {
+			encCert, err := tls.LoadX509KeyPair(t.EncCertFile, t.EncKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, encCert)
+			gmSupport = &tls.GMSupport{}
+		}
 		caCertPool := gmx509.NewCertPool()
 		for _, caPath := range t.ClientCACertFiles {
 			caPem, err := ioutil.ReadFile(caPath)
@@ -39,7 +52,8 @@ func (t TLS) Config() (*tls.Config, error) {
 			caCertPool.AppendCertsFromPEM(caPem)
 		}
 		tlsConfig = &tls.Config{
-			Certificates: []tls.Certificate{cert},
+			Certificates: certs,
+			GMSupport:    gmSupport,
 			CipherSuites: comm.DefaultTLSCipherSuites,
 			ClientCAs:    caCertPool,
 		}
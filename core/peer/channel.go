@@ -104,6 +104,12 @@ func (c *Channel) Capabilities() channelconfig.ApplicationCapabilities {
 	return ac.Capabilities()
 }
 
+// BlockHashingAlgorithm returns the hash function used for the block hashes and
+// transaction IDs of the channel.
+func (c *Channel) BlockHashingAlgorithm() func([]byte) []byte {
+	return channelconfig.BlockHashingAlgorithm(c.Resources().ChannelConfig())
+}
+
 // GetMSPIDs retrieves the MSP IDs of the organizations in the current channel
 // configuration.
 func (c *Channel) GetMSPIDs() []string {
@@ -175,6 +175,12 @@ type Config struct {
 	// OperationsTLSKeyFile provides the path to PEM encoded server key for the
 	// operations server.
 	OperationsTLSKeyFile string
+	// OperationsTLSEncCertFile provides the path to the PEM encoded GM TLS
+	// encryption certificate for the operations server.
+	OperationsTLSEncCertFile string
+	// OperationsTLSEncKeyFile provides the path to the PEM encoded GM TLS
+	// encryption key for the operations server.
+	OperationsTLSEncKeyFile string
 	// OperationsTLSClientAuthRequired enables/disables the requirements for client
 	// certificate authentication at the TLS layer to access all resource.
 	OperationsTLSClientAuthRequired bool
@@ -298,6 +304,8 @@ func (c *Config) load() error {
 	c.OperationsTLSEnabled = viper.GetBool("operations.tls.enabled")
 	c.OperationsTLSCertFile = config.GetPath("operations.tls.cert.file")
 	c.OperationsTLSKeyFile = config.GetPath("operations.tls.key.file")
+	c.OperationsTLSEncCertFile = config.GetPath("operations.tls.encCert.file")
+	c.OperationsTLSEncKeyFile = config.GetPath("operations.tls.encKey.file")
 	c.OperationsTLSClientAuthRequired = viper.GetBool("operations.tls.clientAuthRequired")
 
 	for _, rca := range viper.GetStringSlice("operations.tls.clientRootCAs.files") {
@@ -371,6 +379,23 @@ func GetServerConfig() (comm.ServerConfig, error) {
 		}
 		serverConfig.SecOpts.Certificate = serverCert
 		serverConfig.SecOpts.Key = serverKey
+		// the GM TLS encryption key pair is optional
+		encKeyPath := config.GetPath("peer.tls.encKey.file")
+		encCertPath := config.GetPath("peer.tls.encCert.file")
+		if encKeyPath != "" || encCertPath != "" {
+			if encKeyPath == "" || encCertPath == "" {
+				return serverConfig, errors.New("peer.tls.encKey.file and " +
+					"peer.tls.encCert.file must both be set or must both be empty")
+			}
+			serverConfig.SecOpts.EncKey, err = ioutil.ReadFile(encKeyPath)
+			if err != nil {
+				return serverConfig, fmt.Errorf("error loading TLS encryption key (%s)", err)
+			}
+			serverConfig.SecOpts.EncCertificate, err = ioutil.ReadFile(encCertPath)
+			if err != nil {
+				return serverConfig, fmt.Errorf("error loading TLS encryption certificate (%s)", err)
+			}
+		}
 		serverConfig.SecOpts.RequireClientCert = viper.GetBool("peer.tls.clientAuthRequired")
 		if serverConfig.SecOpts.RequireClientCert {
 			var clientRoots [][]byte
@@ -466,3 +491,43 @@ func GetClientCertificate() (tls.Certificate, error) {
 	}
 	return cert, nil
 }
+
+// GetClientEncCertificate returns the TLS certificate to use for the
+// encryption role of GM TLS client connections. It returns nil when
+// neither [peer.tls.clientEncKey.file and peer.tls.clientEncCert.file]
+// nor [peer.tls.encKey.file and peer.tls.encCert.file] are set, in which
+// case the client certificate is used for both roles.
+func GetClientEncCertificate() (*tls.Certificate, error) {
+	keyPath := viper.GetString("peer.tls.clientEncKey.file")
+	certPath := viper.GetString("peer.tls.clientEncCert.file")
+	keyKey, certKey := "peer.tls.clientEncKey.file", "peer.tls.clientEncCert.file"
+
+	if keyPath == "" && certPath == "" {
+		keyPath = viper.GetString("peer.tls.encKey.file")
+		certPath = viper.GetString("peer.tls.encCert.file")
+		keyKey, certKey = "peer.tls.encKey.file", "peer.tls.encCert.file"
+	}
+	if keyPath == "" && certPath == "" {
+		return nil, nil
+	}
+	if keyPath == "" || certPath == "" {
+		return nil, errors.Errorf("%s and %s must both be set or must both be empty", keyKey, certKey)
+	}
+
+	clientKey, err := ioutil.ReadFile(config.GetPath(keyKey))
+	if err != nil {
+		return nil, errors.WithMessage(err,
+			"error loading client TLS encryption key")
+	}
+	clientCert, err := ioutil.ReadFile(config.GetPath(certKey))
+	if err != nil {
+		return nil, errors.WithMessage(err,
+			"error loading client TLS encryption certificate")
+	}
+	cert, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, errors.WithMessage(err,
+			"error parsing client TLS encryption key pair")
+	}
+	return &cert, nil
+}
@@ -3,6 +3,7 @@ package mock
 
 import (
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	peera "github.com/hyperledger/fabric-protos-go/peer"
@@ -11,6 +12,17 @@ import (
 )
 
 type PeerLedger struct {
+	CancelSnapshotRequestStub        func(uint64) error
+	cancelSnapshotRequestMutex       sync.RWMutex
+	cancelSnapshotRequestArgsForCall []struct {
+		arg1 uint64
+	}
+	cancelSnapshotRequestReturns struct {
+		result1 error
+	}
+	cancelSnapshotRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CloseStub        func()
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct {
@@ -105,6 +117,24 @@ type PeerLedger struct {
 		result1 *common.BlockchainInfo
 		result2 error
 	}
+	GetBlocksByTimestampStub        func(time.Time, time.Time, string, int) ([]*common.Block, string, error)
+	getBlocksByTimestampMutex       sync.RWMutex
+	getBlocksByTimestampArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 string
+		arg4 int
+	}
+	getBlocksByTimestampReturns struct {
+		result1 []*common.Block
+		result2 string
+		result3 error
+	}
+	getBlocksByTimestampReturnsOnCall map[int]struct {
+		result1 []*common.Block
+		result2 string
+		result3 error
+	}
 	GetBlocksIteratorStub        func(uint64) (ledgera.ResultsIterator, error)
 	getBlocksIteratorMutex       sync.RWMutex
 	getBlocksIteratorArgsForCall []struct {
@@ -183,6 +213,24 @@ type PeerLedger struct {
 		result1 *peera.ProcessedTransaction
 		result2 error
 	}
+	GetTransactionsByChaincodeEventStub        func(string, string, string, int) ([]*peera.ProcessedTransaction, string, error)
+	getTransactionsByChaincodeEventMutex       sync.RWMutex
+	getTransactionsByChaincodeEventArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int
+	}
+	getTransactionsByChaincodeEventReturns struct {
+		result1 []*peera.ProcessedTransaction
+		result2 string
+		result3 error
+	}
+	getTransactionsByChaincodeEventReturnsOnCall map[int]struct {
+		result1 []*peera.ProcessedTransaction
+		result2 string
+		result3 error
+	}
 	GetTxValidationCodeByTxIDStub        func(string) (peera.TxValidationCode, error)
 	getTxValidationCodeByTxIDMutex       sync.RWMutex
 	getTxValidationCodeByTxIDArgsForCall []struct {
@@ -233,10 +281,93 @@ type PeerLedger struct {
 		result1 ledger.TxSimulator
 		result2 error
 	}
+	PendingSnapshotRequestsStub        func() ([]uint64, error)
+	pendingSnapshotRequestsMutex       sync.RWMutex
+	pendingSnapshotRequestsArgsForCall []struct {
+	}
+	pendingSnapshotRequestsReturns struct {
+		result1 []uint64
+		result2 error
+	}
+	pendingSnapshotRequestsReturnsOnCall map[int]struct {
+		result1 []uint64
+		result2 error
+	}
+	SubmitSnapshotRequestStub        func(uint64) error
+	submitSnapshotRequestMutex       sync.RWMutex
+	submitSnapshotRequestArgsForCall []struct {
+		arg1 uint64
+	}
+	submitSnapshotRequestReturns struct {
+		result1 error
+	}
+	submitSnapshotRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *PeerLedger) CancelSnapshotRequest(arg1 uint64) error {
+	fake.cancelSnapshotRequestMutex.Lock()
+	ret, specificReturn := fake.cancelSnapshotRequestReturnsOnCall[len(fake.cancelSnapshotRequestArgsForCall)]
+	fake.cancelSnapshotRequestArgsForCall = append(fake.cancelSnapshotRequestArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	fake.recordInvocation("CancelSnapshotRequest", []interface{}{arg1})
+	fake.cancelSnapshotRequestMutex.Unlock()
+	if fake.CancelSnapshotRequestStub != nil {
+		return fake.CancelSnapshotRequestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.cancelSnapshotRequestReturns
+	return fakeReturns.result1
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestCallCount() int {
+	fake.cancelSnapshotRequestMutex.RLock()
+	defer fake.cancelSnapshotRequestMutex.RUnlock()
+	return len(fake.cancelSnapshotRequestArgsForCall)
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestCalls(stub func(uint64) error) {
+	fake.cancelSnapshotRequestMutex.Lock()
+	defer fake.cancelSnapshotRequestMutex.Unlock()
+	fake.CancelSnapshotRequestStub = stub
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestArgsForCall(i int) uint64 {
+	fake.cancelSnapshotRequestMutex.RLock()
+	defer fake.cancelSnapshotRequestMutex.RUnlock()
+	argsForCall := fake.cancelSnapshotRequestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestReturns(result1 error) {
+	fake.cancelSnapshotRequestMutex.Lock()
+	defer fake.cancelSnapshotRequestMutex.Unlock()
+	fake.CancelSnapshotRequestStub = nil
+	fake.cancelSnapshotRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestReturnsOnCall(i int, result1 error) {
+	fake.cancelSnapshotRequestMutex.Lock()
+	defer fake.cancelSnapshotRequestMutex.Unlock()
+	fake.CancelSnapshotRequestStub = nil
+	if fake.cancelSnapshotRequestReturnsOnCall == nil {
+		fake.cancelSnapshotRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cancelSnapshotRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PeerLedger) Close() {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
@@ -702,6 +833,75 @@ func (fake *PeerLedger) GetBlockchainInfoReturnsOnCall(i int, result1 *common.Bl
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetBlocksByTimestamp(arg1 time.Time, arg2 time.Time, arg3 string, arg4 int) ([]*common.Block, string, error) {
+	fake.getBlocksByTimestampMutex.Lock()
+	ret, specificReturn := fake.getBlocksByTimestampReturnsOnCall[len(fake.getBlocksByTimestampArgsForCall)]
+	fake.getBlocksByTimestampArgsForCall = append(fake.getBlocksByTimestampArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetBlocksByTimestamp", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getBlocksByTimestampMutex.Unlock()
+	if fake.GetBlocksByTimestampStub != nil {
+		return fake.GetBlocksByTimestampStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getBlocksByTimestampReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *PeerLedger) GetBlocksByTimestampCallCount() int {
+	fake.getBlocksByTimestampMutex.RLock()
+	defer fake.getBlocksByTimestampMutex.RUnlock()
+	return len(fake.getBlocksByTimestampArgsForCall)
+}
+
+func (fake *PeerLedger) GetBlocksByTimestampCalls(stub func(time.Time, time.Time, string, int) ([]*common.Block, string, error)) {
+	fake.getBlocksByTimestampMutex.Lock()
+	defer fake.getBlocksByTimestampMutex.Unlock()
+	fake.GetBlocksByTimestampStub = stub
+}
+
+func (fake *PeerLedger) GetBlocksByTimestampArgsForCall(i int) (time.Time, time.Time, string, int) {
+	fake.getBlocksByTimestampMutex.RLock()
+	defer fake.getBlocksByTimestampMutex.RUnlock()
+	argsForCall := fake.getBlocksByTimestampArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PeerLedger) GetBlocksByTimestampReturns(result1 []*common.Block, result2 string, result3 error) {
+	fake.getBlocksByTimestampMutex.Lock()
+	defer fake.getBlocksByTimestampMutex.Unlock()
+	fake.GetBlocksByTimestampStub = nil
+	fake.getBlocksByTimestampReturns = struct {
+		result1 []*common.Block
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *PeerLedger) GetBlocksByTimestampReturnsOnCall(i int, result1 []*common.Block, result2 string, result3 error) {
+	fake.getBlocksByTimestampMutex.Lock()
+	defer fake.getBlocksByTimestampMutex.Unlock()
+	fake.GetBlocksByTimestampStub = nil
+	if fake.getBlocksByTimestampReturnsOnCall == nil {
+		fake.getBlocksByTimestampReturnsOnCall = make(map[int]struct {
+			result1 []*common.Block
+			result2 string
+			result3 error
+		})
+	}
+	fake.getBlocksByTimestampReturnsOnCall[i] = struct {
+		result1 []*common.Block
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *PeerLedger) GetBlocksIterator(arg1 uint64) (ledgera.ResultsIterator, error) {
 	fake.getBlocksIteratorMutex.Lock()
 	ret, specificReturn := fake.getBlocksIteratorReturnsOnCall[len(fake.getBlocksIteratorArgsForCall)]
@@ -1066,6 +1266,75 @@ func (fake *PeerLedger) GetTransactionByIDReturnsOnCall(i int, result1 *peera.Pr
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetTransactionsByChaincodeEvent(arg1 string, arg2 string, arg3 string, arg4 int) ([]*peera.ProcessedTransaction, string, error) {
+	fake.getTransactionsByChaincodeEventMutex.Lock()
+	ret, specificReturn := fake.getTransactionsByChaincodeEventReturnsOnCall[len(fake.getTransactionsByChaincodeEventArgsForCall)]
+	fake.getTransactionsByChaincodeEventArgsForCall = append(fake.getTransactionsByChaincodeEventArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetTransactionsByChaincodeEvent", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getTransactionsByChaincodeEventMutex.Unlock()
+	if fake.GetTransactionsByChaincodeEventStub != nil {
+		return fake.GetTransactionsByChaincodeEventStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getTransactionsByChaincodeEventReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeEventCallCount() int {
+	fake.getTransactionsByChaincodeEventMutex.RLock()
+	defer fake.getTransactionsByChaincodeEventMutex.RUnlock()
+	return len(fake.getTransactionsByChaincodeEventArgsForCall)
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeEventCalls(stub func(string, string, string, int) ([]*peera.ProcessedTransaction, string, error)) {
+	fake.getTransactionsByChaincodeEventMutex.Lock()
+	defer fake.getTransactionsByChaincodeEventMutex.Unlock()
+	fake.GetTransactionsByChaincodeEventStub = stub
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeEventArgsForCall(i int) (string, string, string, int) {
+	fake.getTransactionsByChaincodeEventMutex.RLock()
+	defer fake.getTransactionsByChaincodeEventMutex.RUnlock()
+	argsForCall := fake.getTransactionsByChaincodeEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeEventReturns(result1 []*peera.ProcessedTransaction, result2 string, result3 error) {
+	fake.getTransactionsByChaincodeEventMutex.Lock()
+	defer fake.getTransactionsByChaincodeEventMutex.Unlock()
+	fake.GetTransactionsByChaincodeEventStub = nil
+	fake.getTransactionsByChaincodeEventReturns = struct {
+		result1 []*peera.ProcessedTransaction
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeEventReturnsOnCall(i int, result1 []*peera.ProcessedTransaction, result2 string, result3 error) {
+	fake.getTransactionsByChaincodeEventMutex.Lock()
+	defer fake.getTransactionsByChaincodeEventMutex.Unlock()
+	fake.GetTransactionsByChaincodeEventStub = nil
+	if fake.getTransactionsByChaincodeEventReturnsOnCall == nil {
+		fake.getTransactionsByChaincodeEventReturnsOnCall = make(map[int]struct {
+			result1 []*peera.ProcessedTransaction
+			result2 string
+			result3 error
+		})
+	}
+	fake.getTransactionsByChaincodeEventReturnsOnCall[i] = struct {
+		result1 []*peera.ProcessedTransaction
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *PeerLedger) GetTxValidationCodeByTxID(arg1 string) (peera.TxValidationCode, error) {
 	fake.getTxValidationCodeByTxIDMutex.Lock()
 	ret, specificReturn := fake.getTxValidationCodeByTxIDReturnsOnCall[len(fake.getTxValidationCodeByTxIDArgsForCall)]
@@ -1302,9 +1571,126 @@ func (fake *PeerLedger) NewTxSimulatorReturnsOnCall(i int, result1 ledger.TxSimu
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) PendingSnapshotRequests() ([]uint64, error) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	ret, specificReturn := fake.pendingSnapshotRequestsReturnsOnCall[len(fake.pendingSnapshotRequestsArgsForCall)]
+	fake.pendingSnapshotRequestsArgsForCall = append(fake.pendingSnapshotRequestsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PendingSnapshotRequests", []interface{}{})
+	fake.pendingSnapshotRequestsMutex.Unlock()
+	if fake.PendingSnapshotRequestsStub != nil {
+		return fake.PendingSnapshotRequestsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pendingSnapshotRequestsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsCallCount() int {
+	fake.pendingSnapshotRequestsMutex.RLock()
+	defer fake.pendingSnapshotRequestsMutex.RUnlock()
+	return len(fake.pendingSnapshotRequestsArgsForCall)
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsCalls(stub func() ([]uint64, error)) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	defer fake.pendingSnapshotRequestsMutex.Unlock()
+	fake.PendingSnapshotRequestsStub = stub
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsReturns(result1 []uint64, result2 error) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	defer fake.pendingSnapshotRequestsMutex.Unlock()
+	fake.PendingSnapshotRequestsStub = nil
+	fake.pendingSnapshotRequestsReturns = struct {
+		result1 []uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsReturnsOnCall(i int, result1 []uint64, result2 error) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	defer fake.pendingSnapshotRequestsMutex.Unlock()
+	fake.PendingSnapshotRequestsStub = nil
+	if fake.pendingSnapshotRequestsReturnsOnCall == nil {
+		fake.pendingSnapshotRequestsReturnsOnCall = make(map[int]struct {
+			result1 []uint64
+			result2 error
+		})
+	}
+	fake.pendingSnapshotRequestsReturnsOnCall[i] = struct {
+		result1 []uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequest(arg1 uint64) error {
+	fake.submitSnapshotRequestMutex.Lock()
+	ret, specificReturn := fake.submitSnapshotRequestReturnsOnCall[len(fake.submitSnapshotRequestArgsForCall)]
+	fake.submitSnapshotRequestArgsForCall = append(fake.submitSnapshotRequestArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	fake.recordInvocation("SubmitSnapshotRequest", []interface{}{arg1})
+	fake.submitSnapshotRequestMutex.Unlock()
+	if fake.SubmitSnapshotRequestStub != nil {
+		return fake.SubmitSnapshotRequestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.submitSnapshotRequestReturns
+	return fakeReturns.result1
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestCallCount() int {
+	fake.submitSnapshotRequestMutex.RLock()
+	defer fake.submitSnapshotRequestMutex.RUnlock()
+	return len(fake.submitSnapshotRequestArgsForCall)
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestCalls(stub func(uint64) error) {
+	fake.submitSnapshotRequestMutex.Lock()
+	defer fake.submitSnapshotRequestMutex.Unlock()
+	fake.SubmitSnapshotRequestStub = stub
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestArgsForCall(i int) uint64 {
+	fake.submitSnapshotRequestMutex.RLock()
+	defer fake.submitSnapshotRequestMutex.RUnlock()
+	argsForCall := fake.submitSnapshotRequestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestReturns(result1 error) {
+	fake.submitSnapshotRequestMutex.Lock()
+	defer fake.submitSnapshotRequestMutex.Unlock()
+	fake.SubmitSnapshotRequestStub = nil
+	fake.submitSnapshotRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestReturnsOnCall(i int, result1 error) {
+	fake.submitSnapshotRequestMutex.Lock()
+	defer fake.submitSnapshotRequestMutex.Unlock()
+	fake.SubmitSnapshotRequestStub = nil
+	if fake.submitSnapshotRequestReturnsOnCall == nil {
+		fake.submitSnapshotRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.submitSnapshotRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.cancelSnapshotRequestMutex.RLock()
+	defer fake.cancelSnapshotRequestMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
 	fake.commitLegacyMutex.RLock()
@@ -1321,6 +1707,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getBlockByTxIDMutex.RUnlock()
 	fake.getBlockchainInfoMutex.RLock()
 	defer fake.getBlockchainInfoMutex.RUnlock()
+	fake.getBlocksByTimestampMutex.RLock()
+	defer fake.getBlocksByTimestampMutex.RUnlock()
 	fake.getBlocksIteratorMutex.RLock()
 	defer fake.getBlocksIteratorMutex.RUnlock()
 	fake.getConfigHistoryRetrieverMutex.RLock()
@@ -1333,6 +1721,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getPvtDataByNumMutex.RUnlock()
 	fake.getTransactionByIDMutex.RLock()
 	defer fake.getTransactionByIDMutex.RUnlock()
+	fake.getTransactionsByChaincodeEventMutex.RLock()
+	defer fake.getTransactionsByChaincodeEventMutex.RUnlock()
 	fake.getTxValidationCodeByTxIDMutex.RLock()
 	defer fake.getTxValidationCodeByTxIDMutex.RUnlock()
 	fake.newHistoryQueryExecutorMutex.RLock()
@@ -1341,6 +1731,10 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.newQueryExecutorMutex.RUnlock()
 	fake.newTxSimulatorMutex.RLock()
 	defer fake.newTxSimulatorMutex.RUnlock()
+	fake.pendingSnapshotRequestsMutex.RLock()
+	defer fake.pendingSnapshotRequestsMutex.RUnlock()
+	fake.submitSnapshotRequestMutex.RLock()
+	defer fake.submitSnapshotRequestMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
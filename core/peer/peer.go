@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
+	commonbft "github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	cc "github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -21,6 +22,7 @@ import (
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/semaphore"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/committer/txvalidator/plugin"
@@ -224,6 +226,28 @@ func (p *Peer) CreateChannel(
 	return nil
 }
 
+// CreateChannelFromSnapshot creates a channel from the snapshot present in the snapshotDir. The channel
+// is named after the channel name recorded in the snapshot metadata and the channel config is loaded
+// from the state imported from the snapshot.
+func (p *Peer) CreateChannelFromSnapshot(
+	snapshotDir string,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	legacyLifecycleValidation plugindispatcher.LifecycleResources,
+	newLifecycleValidation plugindispatcher.CollectionAndLifecycleResources,
+) error {
+	l, cid, err := p.LedgerMgr.CreateLedgerFromSnapshot(snapshotDir)
+	if err != nil {
+		return errors.WithMessagef(err, "cannot create ledger from snapshot %s", snapshotDir)
+	}
+
+	if err := p.createChannel(cid, l, deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation); err != nil {
+		return err
+	}
+
+	p.initChannel(cid)
+	return nil
+}
+
 // retrievePersistedChannelConfig retrieves the persisted channel config from statedb
 func retrievePersistedChannelConfig(ledger ledger.PeerLedger) (*common.Config, error) {
 	qe, err := ledger.NewQueryExecutor()
@@ -455,6 +479,34 @@ func (p *Peer) GetPolicyManager(cid string) policies.Manager {
 	return nil
 }
 
+// GetBlockHashingAlgorithm returns the block hashing algorithm of the channel with channel ID.
+// SHA-256 is returned if channel cid has not been created.
+func (p *Peer) GetBlockHashingAlgorithm(cid string) func([]byte) []byte {
+	if c := p.Channel(cid); c != nil {
+		return c.BlockHashingAlgorithm()
+	}
+	return util.ComputeSHA256
+}
+
+// GetBFTConsenters returns the consenters of the channel with channel ID if the channel
+// is ordered by the BFT consensus. nil is returned if the channel is ordered by another
+// consensus type, or if channel cid has not been created.
+func (p *Peer) GetBFTConsenters(cid string) ([]*commonbft.Consenter, error) {
+	c := p.Channel(cid)
+	if c == nil {
+		return nil, nil
+	}
+	oc, ok := c.Resources().OrdererConfig()
+	if !ok {
+		return nil, nil
+	}
+	m, err := commonbft.MetadataFromOrdererConfig(oc)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return m.Consenters, nil
+}
+
 // initChannel takes care to initialize channel after peer joined, for example deploys system CCs
 func (p *Peer) initChannel(cid string) {
 	if p.channelInitializer != nil {
@@ -189,4 +189,35 @@ func TestPolicyChecker(t *testing.T) {
 	err = pc.CheckPolicyNoChannel(mgmt.Members, sProp)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Failed deserializing proposal creator during channelless check policy with policy [Members]: [Invalid Identity]")
+
+	// Signed data from Alice, a member of the local MSP, must satisfy the channelless check
+	identityDeserializer.Msg = []byte("request")
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*protoutil.SignedData{{
+		Identity:  []byte("Alice"),
+		Data:      []byte("request"),
+		Signature: []byte("request"),
+	}})
+	assert.NoError(t, err)
+
+	// A bad signature must fail the channelless check
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*protoutil.SignedData{{
+		Identity:  []byte("Alice"),
+		Data:      []byte("request"),
+		Signature: []byte("forged"),
+	}})
+	assert.EqualError(t, err, "Failed verifying signature during channelless check policy with policy [Members]: [Invalid Signature]")
+
+	// Bob is not a member of the local MSP, the channelless check must fail
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*protoutil.SignedData{{
+		Identity:  []byte("Bob"),
+		Data:      []byte("request"),
+		Signature: []byte("request"),
+	}})
+	assert.EqualError(t, err, "Failed deserializing signed data identity during channelless check policy with policy [Members]: [Invalid Identity]")
+
+	err = pc.CheckPolicyNoChannelBySignedData("", nil)
+	assert.EqualError(t, err, "Invalid policy name during channelless check policy. Name must be different from nil.")
+
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, nil)
+	assert.EqualError(t, err, "Invalid signed data during channelless check policy with policy [Members]")
 }
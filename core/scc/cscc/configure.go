@@ -77,9 +77,10 @@ var cnflogger = flogging.MustGetLogger("cscc")
 
 // These are function names from Invoke first parameter
 const (
-	JoinChain      string = "JoinChain"
-	GetConfigBlock string = "GetConfigBlock"
-	GetChannels    string = "GetChannels"
+	JoinChain           string = "JoinChain"
+	JoinChainBySnapshot string = "JoinChainBySnapshot"
+	GetConfigBlock      string = "GetConfigBlock"
+	GetChannels         string = "GetChannels"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -93,10 +94,11 @@ func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # to get the current configuration block (called by app)
 // # to update the configuration block (called by committer)
 // Peer calls this function with 2 arguments:
-// # args[0] is the function name, which must be JoinChain, GetConfigBlock or
-// UpdateConfigBlock
+// # args[0] is the function name, which must be JoinChain, JoinChainBySnapshot,
+// GetConfigBlock or UpdateConfigBlock
 // # args[1] is a configuration Block if args[0] is JoinChain or
-// UpdateConfigBlock; otherwise it is the chain id
+// UpdateConfigBlock, the path of the snapshot dir on the peer if args[0] is
+// JoinChainBySnapshot; otherwise it is the chain id
 // TODO: Improve the scc interface to avoid marshal/unmarshal args
 func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
@@ -174,6 +176,16 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 
 		return e.joinChain(cid, block, e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
+	case JoinChainBySnapshot:
+		if len(args[1]) == 0 {
+			return shim.Error("Cannot join the channel, no snapshot directory provided")
+		}
+		// check join policy.
+		if err = e.aclProvider.CheckACL(resources.Cscc_JoinChainBySnapshot, "", sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s]: [%s]", fname, err))
+		}
+
+		return e.joinChainBySnapshot(string(args[1]), e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
 	case GetConfigBlock:
 		// 2. check policy
 		if err = e.aclProvider.CheckACL(resources.Cscc_GetConfigBlock, string(args[1]), sp); err != nil {
@@ -249,6 +261,21 @@ func (e *PeerConfiger) joinChain(
 	return shim.Success(nil)
 }
 
+// joinChainBySnapshot will join the channel by the specified snapshot.
+// The channel name and the channel config are loaded from the snapshot.
+func (e *PeerConfiger) joinChainBySnapshot(
+	snapshotDir string,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	lr plugindispatcher.LifecycleResources,
+	nr plugindispatcher.CollectionAndLifecycleResources,
+) pb.Response {
+	if err := e.peer.CreateChannelFromSnapshot(snapshotDir, deployedCCInfoProvider, lr, nr); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified channelID. If the
 // peer doesn't belong to the channel, return error
 func (e *PeerConfiger) getConfigBlock(channelID []byte) pb.Response {
@@ -11,6 +11,7 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/core/deliverservice"
@@ -250,6 +252,44 @@ func TestConfigerInvokeJoinChainWrongParams(t *testing.T) {
 	)
 }
 
+func TestConfigerInvokeJoinChainBySnapshot(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "cscc_test")
+	require.NoError(t, err, "error in creating test dir")
+	defer os.RemoveAll(testDir)
+
+	ledgerMgr := ledgermgmt.NewLedgerMgr(ledgermgmttest.NewInitializer(testDir))
+	defer ledgerMgr.Close()
+
+	mockACLProvider := &mocks.ACLProvider{}
+	cscc := &PeerConfiger{
+		aclProvider: mockACLProvider,
+		peer: &peer.Peer{
+			LedgerMgr: ledgerMgr,
+		},
+	}
+	mockStub := &mocks.ChaincodeStub{}
+	mockStub.GetSignedProposalReturns(validSignedProposal(), nil)
+
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshot"), nil})
+	res := cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Cannot join the channel, no snapshot directory provided", res.Message)
+
+	snapshotDir := filepath.Join(testDir, "non-existing-snapshot")
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshot"), []byte(snapshotDir)})
+	mockACLProvider.CheckACLReturns(errors.New("Failed authorization"))
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "access denied for [JoinChainBySnapshot]: [Failed authorization]", res.Message)
+	resName, _, _ := mockACLProvider.CheckACLArgsForCall(0)
+	require.Equal(t, resources.Cscc_JoinChainBySnapshot, resName)
+
+	mockACLProvider.CheckACLReturns(nil)
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "cannot create ledger from snapshot "+snapshotDir)
+}
+
 func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	viper.Set("chaincode.executetimeout", "3s")
 
@@ -278,7 +318,7 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 
 	signer := mgmt.GetLocalSigningIdentityOrPanic(cryptoProvider)
 
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, nil, nil, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager(cryptoProvider))
 	var defaultSecureDialOpts = func() []grpc.DialOption {
 		return []grpc.DialOption{grpc.WithInsecure()}
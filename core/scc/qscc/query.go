@@ -9,8 +9,11 @@ package qscc
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/littlegirlpppp/fabric-chaincode-go/shim"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -40,6 +43,9 @@ func (e *LedgerQuerier) Chaincode() shim.Chaincode { return e }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetCommitHash returns the commit hash of a block
+// - GetTransactionsByEvent returns a page of the transactions that emitted a chaincode event
+// - GetBlockByTimestamp returns a page of the blocks in a time range
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 	ledgers     LedgerGetter
@@ -54,8 +60,16 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+	GetCommitHash      string = "GetCommitHash"
+
+	GetTransactionsByEvent string = "GetTransactionsByEvent"
+	GetBlockByTimestamp    string = "GetBlockByTimestamp"
 )
 
+// defaultPageSize is the number of the results returned by the paginated
+// functions when the page size is not specified
+const defaultPageSize = 100
+
 // Init is called once per chain when the chain is created.
 // This allows the chaincode to initialize any variables on the ledger prior
 // to any transaction execution on the chain.
@@ -72,6 +86,11 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetCommitHash: Return the commit hash of the block specified by block number in args[2]
+// # GetTransactionsByEvent: Return a QueryResponse with the transactions that emitted the event named in args[3]
+// from the chaincode in args[2], paged by the optional page size in args[4] and bookmark in args[5]
+// # GetBlockByTimestamp: Return a QueryResponse with the blocks timestamped in the RFC 3339 range [args[2], args[3]),
+// paged by the optional page size in args[4] and bookmark in args[5]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -124,6 +143,12 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetCommitHash:
+		return getCommitHash(targetLedger, args[2])
+	case GetTransactionsByEvent:
+		return getTransactionsByEvent(targetLedger, args[2:])
+	case GetBlockByTimestamp:
+		return getBlockByTimestamp(targetLedger, args[2:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -198,6 +223,10 @@ func getChainInfo(vledger ledger.PeerLedger) pb.Response {
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Failed to get block info with error %s", err))
 	}
+	binfo.CurrentBlockCommitHash, err = currentBlockCommitHash(vledger, binfo)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get commit hash of the current block with error %s", err))
+	}
 	bytes, err := protoutil.Marshal(binfo)
 	if err != nil {
 		return shim.Error(err.Error())
@@ -206,6 +235,33 @@ func getChainInfo(vledger ledger.PeerLedger) pb.Response {
 	return shim.Success(bytes)
 }
 
+// currentBlockCommitHash returns the commit hash recorded in the metadata of the
+// last block of the ledger. It returns nil when the block carries no commit hash,
+// when the ledger has no blocks yet, or when the last block is the last block of
+// the snapshot the ledger was bootstrapped from, as such a block is not available
+// in the ledger.
+func currentBlockCommitHash(vledger ledger.PeerLedger, binfo *common.BlockchainInfo) ([]byte, error) {
+	if binfo.Height == 0 {
+		return nil, nil
+	}
+	lastBlockNum := binfo.Height - 1
+	if snapshotInfo := binfo.BootstrappingSnapshotInfo; snapshotInfo != nil && snapshotInfo.LastBlockInSnapshot == lastBlockNum {
+		return nil, nil
+	}
+	block, err := vledger.GetBlockByNumber(lastBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	if len(block.GetMetadata().GetMetadata()) <= int(common.BlockMetadataIndex_COMMIT_HASH) {
+		return nil, nil
+	}
+	md, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_COMMIT_HASH)
+	if err != nil {
+		return nil, err
+	}
+	return md.Value, nil
+}
+
 func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	txID := string(rawTxID)
 	block, err := vledger.GetBlockByTxID(txID)
@@ -223,6 +279,125 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getCommitHash(vledger ledger.PeerLedger, number []byte) pb.Response {
+	if number == nil {
+		return shim.Error("Block number must not be nil.")
+	}
+	bnum, err := strconv.ParseUint(string(number), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+	block, err := vledger.GetBlockByNumber(bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get block number %d, error %s", bnum, err))
+	}
+	md, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_COMMIT_HASH)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get commit hash of block number %d, error %s", bnum, err))
+	}
+	// the commit hash is absent for the genesis block and for the blocks
+	// committed before the commit hash was enabled on this ledger
+	if len(md.Value) == 0 {
+		return shim.Error(fmt.Sprintf("Commit hash is not available for block number %d", bnum))
+	}
+
+	return shim.Success(md.Value)
+}
+
+func getTransactionsByEvent(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return shim.Error("Chaincode name and event name must not be empty.")
+	}
+	chaincodeName, eventName := string(args[0]), string(args[1])
+	pageSize, bookmark, err := pagination(args[2:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	txs, nextBookmark, err := vledger.GetTransactionsByChaincodeEvent(chaincodeName, eventName, bookmark, pageSize)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions for event %s of chaincode %s, error %s", eventName, chaincodeName, err))
+	}
+	results := make([]proto.Message, len(txs))
+	for i, tx := range txs {
+		results[i] = tx
+	}
+	return queryResponse(results, nextBookmark)
+}
+
+func getBlockByTimestamp(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 2 {
+		return shim.Error("Start time and end time must not be nil.")
+	}
+	startTime, err := time.Parse(time.RFC3339Nano, string(args[0]))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start time with error %s", err))
+	}
+	endTime, err := time.Parse(time.RFC3339Nano, string(args[1]))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end time with error %s", err))
+	}
+	pageSize, bookmark, err := pagination(args[2:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	blocks, nextBookmark, err := vledger.GetBlocksByTimestamp(startTime, endTime, bookmark, pageSize)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get blocks between %s and %s, error %s", args[0], args[1], err))
+	}
+	results := make([]proto.Message, len(blocks))
+	for i, block := range blocks {
+		results[i] = block
+	}
+	return queryResponse(results, nextBookmark)
+}
+
+// pagination returns the page size and the bookmark from the optional arguments of a paginated function
+func pagination(args [][]byte) (int, string, error) {
+	pageSize := defaultPageSize
+	if len(args) > 0 && len(args[0]) > 0 {
+		size, err := strconv.ParseInt(string(args[0]), 10, 32)
+		if err != nil || size <= 0 {
+			return 0, "", fmt.Errorf("Invalid page size %s, the page size must be a positive integer", args[0])
+		}
+		pageSize = int(size)
+	}
+	var bookmark string
+	if len(args) > 1 {
+		bookmark = string(args[1])
+	}
+	return pageSize, bookmark, nil
+}
+
+// queryResponse returns a QueryResponse that carries the given results and, in its
+// metadata, the number of the results and the bookmark for the next page
+func queryResponse(results []proto.Message, bookmark string) pb.Response {
+	queryResponse := &pb.QueryResponse{
+		Results: make([]*pb.QueryResultBytes, len(results)),
+		HasMore: bookmark != "",
+	}
+	for i, result := range results {
+		resultBytes, err := protoutil.Marshal(result)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		queryResponse.Results[i] = &pb.QueryResultBytes{ResultBytes: resultBytes}
+	}
+	metadataBytes, err := protoutil.Marshal(&pb.QueryResponseMetadata{
+		FetchedRecordsCount: int32(len(results)),
+		Bookmark:            bookmark,
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	queryResponse.Metadata = metadataBytes
+
+	bytes, err := protoutil.Marshal(queryResponse)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
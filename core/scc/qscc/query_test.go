@@ -10,18 +10,23 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/littlegirlpppp/fabric-chaincode-go/shim"
 	"github.com/littlegirlpppp/fabric-chaincode-go/shimtest"
 	"github.com/hyperledger/fabric-protos-go/common"
 	peer2 "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
 	"github.com/hyperledger/fabric/core/peer"
@@ -47,6 +52,10 @@ func setupTestLedger(chainid string, path string) (*shimtest.MockStub, *peer.Pee
 	}
 
 	initializer := ledgermgmttest.NewInitializer(testDir)
+	initializer.Config.BlockStoreConfig = &ledger2.BlockStoreConfig{
+		IndexChaincodeEvents: true,
+		IndexBlockTimestamps: true,
+	}
 
 	ledgerMgr := ledgermgmt.NewLedgerMgr(initializer)
 
@@ -121,6 +130,41 @@ func TestQueryGetChainInfo(t *testing.T) {
 	assert.Equal(t, int32(shim.ERROR), res.Status, "GetChainInfo should have failed because the channel id does not exist")
 }
 
+func TestQueryGetChainInfoCommitHash(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	getChainInfo := func(txID string) *common.BlockchainInfo {
+		args := [][]byte{[]byte(GetChainInfo), []byte(chainid)}
+		prop := resetProvider(resources.Qscc_GetChainInfo, chainid, nil, nil)
+		res := stub.MockInvokeWithSignedProposal(txID, args, prop)
+		require.Equal(t, int32(shim.OK), res.Status, "GetChainInfo failed with err: %s", res.Message)
+		bcInfo := &common.BlockchainInfo{}
+		require.NoError(t, proto.Unmarshal(res.Payload, bcInfo))
+		return bcInfo
+	}
+
+	// the genesis block does not carry a commit hash
+	bcInfo := getChainInfo("1")
+	require.Equal(t, uint64(1), bcInfo.Height)
+	require.Empty(t, bcInfo.CurrentBlockCommitHash)
+
+	block1 := addBlockForTesting(t, chainid, p)
+	expectedCommitHash := protoutil.GetMetadataFromBlockOrPanic(block1, common.BlockMetadataIndex_COMMIT_HASH).Value
+	require.NotEmpty(t, expectedCommitHash)
+
+	bcInfo = getChainInfo("2")
+	require.Equal(t, uint64(2), bcInfo.Height)
+	require.Equal(t, expectedCommitHash, bcInfo.CurrentBlockCommitHash)
+}
+
 func TestQueryGetTransactionByID(t *testing.T) {
 	chainid := "mytestchainid2"
 	path := tempDir(t, "test2")
@@ -446,6 +490,285 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryOnLedgerBootstrappedFromSnapshot(t *testing.T) {
+	chainid := "mytestchainid-snapshot"
+	testDir := tempDir(t, "snapshot")
+	defer os.RemoveAll(testDir)
+
+	// generate a snapshot of a ledger with two blocks
+	sourceInitializer := ledgermgmttest.NewInitializer(filepath.Join(testDir, "source"))
+	sourceLedgerMgr := ledgermgmt.NewLedgerMgr(sourceInitializer)
+	gb, err := configtxtest.MakeGenesisBlock(chainid)
+	require.NoError(t, err)
+	sourceLedger, err := sourceLedgerMgr.CreateLedger(chainid, gb)
+	require.NoError(t, err)
+	block1 := commitBlockForTesting(t, sourceLedger)
+	txID, err := protoutil.GetOrComputeTxIDFromEnvelope(block1.Data.Data[0])
+	require.NoError(t, err)
+	require.NoError(t, sourceLedger.SubmitSnapshotRequest(0))
+	sourceLedgerMgr.Close()
+
+	// create a ledger from the snapshot
+	ledgerMgr := ledgermgmt.NewLedgerMgr(ledgermgmttest.NewInitializer(filepath.Join(testDir, "dest")))
+	defer ledgerMgr.Close()
+	lgr, _, err := ledgerMgr.CreateLedgerFromSnapshot(
+		kvledger.SnapshotDirForLedgerHeight(sourceInitializer.Config.SnapshotsConfig.RootDir, chainid, 2),
+	)
+	require.NoError(t, err)
+
+	mockAclProvider.Reset()
+	stub := shimtest.NewMockStub("LedgerQuerier", &LedgerQuerier{
+		aclProvider: mockAclProvider,
+		ledgers:     testLedgerGetter{chainid: lgr},
+	})
+
+	// the chain info reports the last block in the snapshot
+	args := [][]byte{[]byte(GetChainInfo), []byte(chainid)}
+	prop := resetProvider(resources.Qscc_GetChainInfo, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	bcInfo := &common.BlockchainInfo{}
+	require.NoError(t, proto.Unmarshal(res.Payload, bcInfo))
+	require.Equal(t, uint64(2), bcInfo.Height)
+	require.Equal(t, uint64(1), bcInfo.BootstrappingSnapshotInfo.GetLastBlockInSnapshot())
+	// the last block in the snapshot is not available, nor is its commit hash
+	require.Empty(t, bcInfo.CurrentBlockCommitHash)
+
+	// the blocks and the transactions in the snapshot are reported as not available
+	args = [][]byte{[]byte(GetBlockByNumber), []byte(chainid), []byte("1")}
+	prop = resetProvider(resources.Qscc_GetBlockByNumber, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Failed to get block number 1, error cannot serve block [1]. The ledger is bootstrapped from a snapshot. First available block = [2]", res.Message)
+
+	args = [][]byte{[]byte(GetTransactionByID), []byte(chainid), []byte(txID)}
+	prop = resetProvider(resources.Qscc_GetTransactionByID, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Ledger bootstrapped from a snapshot. First available block = [2]")
+
+	args = [][]byte{[]byte(GetBlockByTxID), []byte(chainid), []byte(txID)}
+	prop = resetProvider(resources.Qscc_GetBlockByTxID, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Ledger bootstrapped from a snapshot. First available block = [2]")
+
+	// the blocks committed after the snapshot are served
+	block2 := commitBlockForTesting(t, lgr)
+	args = [][]byte{[]byte(GetBlockByNumber), []byte(chainid), []byte("2")}
+	prop = resetProvider(resources.Qscc_GetBlockByNumber, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	block := &common.Block{}
+	require.NoError(t, proto.Unmarshal(res.Payload, block))
+	require.True(t, proto.Equal(block2, block))
+
+	args = [][]byte{[]byte(GetChainInfo), []byte(chainid)}
+	prop = resetProvider(resources.Qscc_GetChainInfo, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("6", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	bcInfo = &common.BlockchainInfo{}
+	require.NoError(t, proto.Unmarshal(res.Payload, bcInfo))
+	require.Equal(t, uint64(3), bcInfo.Height)
+	require.Equal(t, protoutil.GetMetadataFromBlockOrPanic(block2, common.BlockMetadataIndex_COMMIT_HASH).Value, bcInfo.CurrentBlockCommitHash)
+}
+
+func TestQueryGetCommitHash(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	block1 := addBlockForTesting(t, chainid, p)
+	expectedCommitHash := protoutil.GetMetadataFromBlockOrPanic(block1, common.BlockMetadataIndex_COMMIT_HASH).Value
+	require.NotEmpty(t, expectedCommitHash)
+
+	args := [][]byte{[]byte(GetCommitHash), []byte(chainid), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetCommitHash, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetCommitHash should have succeeded for block number 1: %s", res.Message)
+	require.Equal(t, expectedCommitHash, res.Payload)
+
+	// the genesis block does not carry a commit hash
+	args = [][]byte{[]byte(GetCommitHash), []byte(chainid), []byte("0")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Commit hash is not available for block number 0", res.Message)
+
+	// block number 2 should not be present in the ledger
+	args = [][]byte{[]byte(GetCommitHash), []byte(chainid), []byte("2")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Failed to get block number 2")
+
+	args = [][]byte{[]byte(GetCommitHash), []byte(chainid), []byte("abc")}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Failed to parse block number")
+
+	args = [][]byte{[]byte(GetCommitHash), []byte(chainid), []byte(nil)}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Block number must not be nil.", res.Message)
+}
+
+func TestQueryGetTransactionsByEvent(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	ledger := p.GetLedger(chainid)
+	defer ledger.Close()
+	bcInfo, err := ledger.GetBlockchainInfo()
+	require.NoError(t, err)
+	event := protoutil.MarshalOrPanic(&peer2.ChaincodeEvent{ChaincodeId: "cc1", EventName: "event1"})
+	block1 := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+		BlockNum:     1,
+		PreviousHash: bcInfo.CurrentBlockHash,
+		Txs: []*testutil.TxDetails{
+			{TxID: "tx1", ChaincodeName: "cc1", Events: event, Type: common.HeaderType_ENDORSER_TRANSACTION},
+			{TxID: "tx2", ChaincodeName: "cc1", Type: common.HeaderType_ENDORSER_TRANSACTION},
+			{TxID: "tx3", ChaincodeName: "cc1", Events: event, Type: common.HeaderType_ENDORSER_TRANSACTION},
+			{TxID: "tx4", ChaincodeName: "cc1", Events: event, Type: common.HeaderType_ENDORSER_TRANSACTION},
+		},
+	}, false)
+	require.NoError(t, ledger.CommitLegacy(&ledger2.BlockAndPvtData{Block: block1}, &ledger2.CommitOptions{}))
+
+	prop := resetProvider(resources.Qscc_GetTransactionsByEvent, chainid, nil, nil)
+	args := [][]byte{[]byte(GetTransactionsByEvent), []byte(chainid), []byte("cc1"), []byte("event1"), []byte("2")}
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetTransactionsByEvent should have succeeded: %s", res.Message)
+	txIDs, bookmark := unmarshalTransactionsPageForTesting(t, res.Payload)
+	require.Equal(t, []string{"tx1", "tx3"}, txIDs)
+	require.NotEmpty(t, bookmark)
+
+	args = [][]byte{[]byte(GetTransactionsByEvent), []byte(chainid), []byte("cc1"), []byte("event1"), []byte("2"), []byte(bookmark)}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetTransactionsByEvent should have succeeded: %s", res.Message)
+	txIDs, bookmark = unmarshalTransactionsPageForTesting(t, res.Payload)
+	require.Equal(t, []string{"tx4"}, txIDs)
+	require.Empty(t, bookmark)
+
+	args = [][]byte{[]byte(GetTransactionsByEvent), []byte(chainid), []byte("cc1"), []byte("event2")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetTransactionsByEvent should have succeeded: %s", res.Message)
+	txIDs, bookmark = unmarshalTransactionsPageForTesting(t, res.Payload)
+	require.Empty(t, txIDs)
+	require.Empty(t, bookmark)
+
+	args = [][]byte{[]byte(GetTransactionsByEvent), []byte(chainid), []byte("cc1"), []byte("event1"), []byte("0")}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Invalid page size 0, the page size must be a positive integer", res.Message)
+
+	args = [][]byte{[]byte(GetTransactionsByEvent), []byte(chainid), []byte("cc1"), []byte("event1"), []byte("2"), []byte("abc")}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "invalid bookmark [abc]")
+
+	args = [][]byte{[]byte(GetTransactionsByEvent), []byte(chainid), []byte("cc1")}
+	res = stub.MockInvokeWithSignedProposal("6", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Chaincode name and event name must not be empty.", res.Message)
+}
+
+func TestQueryGetBlockByTimestamp(t *testing.T) {
+	chainid := "mytestchainid11"
+	path := tempDir(t, "test11")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	block1 := addBlockForTesting(t, chainid, p)
+	startTime := time.Now().Add(-time.Hour).Format(time.RFC3339Nano)
+	endTime := time.Now().Add(time.Hour).Format(time.RFC3339Nano)
+
+	prop := resetProvider(resources.Qscc_GetBlockByTimestamp, chainid, nil, nil)
+	args := [][]byte{[]byte(GetBlockByTimestamp), []byte(chainid), []byte(startTime), []byte(endTime), []byte("1")}
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetBlockByTimestamp should have succeeded: %s", res.Message)
+	blockNums, bookmark := unmarshalBlocksPageForTesting(t, res.Payload)
+	require.Equal(t, []uint64{0}, blockNums)
+	require.NotEmpty(t, bookmark)
+
+	args = [][]byte{[]byte(GetBlockByTimestamp), []byte(chainid), []byte(startTime), []byte(endTime), []byte("1"), []byte(bookmark)}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetBlockByTimestamp should have succeeded: %s", res.Message)
+	blockNums, _ = unmarshalBlocksPageForTesting(t, res.Payload)
+	require.Equal(t, []uint64{block1.Header.Number}, blockNums)
+
+	args = [][]byte{[]byte(GetBlockByTimestamp), []byte(chainid), []byte(endTime), []byte(endTime)}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetBlockByTimestamp should have succeeded: %s", res.Message)
+	blockNums, bookmark = unmarshalBlocksPageForTesting(t, res.Payload)
+	require.Empty(t, blockNums)
+	require.Empty(t, bookmark)
+
+	args = [][]byte{[]byte(GetBlockByTimestamp), []byte(chainid), []byte("abc"), []byte(endTime)}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Failed to parse start time")
+
+	args = [][]byte{[]byte(GetBlockByTimestamp), []byte(chainid), []byte(startTime), []byte("abc")}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "Failed to parse end time")
+
+	args = [][]byte{[]byte(GetBlockByTimestamp), []byte(chainid), []byte(startTime)}
+	res = stub.MockInvokeWithSignedProposal("6", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Start time and end time must not be nil.", res.Message)
+}
+
+func unmarshalQueryResponseForTesting(t *testing.T, payload []byte) (*peer2.QueryResponse, string) {
+	queryResponse := &peer2.QueryResponse{}
+	require.NoError(t, proto.Unmarshal(payload, queryResponse))
+	metadata := &peer2.QueryResponseMetadata{}
+	require.NoError(t, proto.Unmarshal(queryResponse.Metadata, metadata))
+	require.Equal(t, int32(len(queryResponse.Results)), metadata.FetchedRecordsCount)
+	require.Equal(t, metadata.Bookmark != "", queryResponse.HasMore)
+	return queryResponse, metadata.Bookmark
+}
+
+func unmarshalTransactionsPageForTesting(t *testing.T, payload []byte) ([]string, string) {
+	queryResponse, bookmark := unmarshalQueryResponseForTesting(t, payload)
+	var txIDs []string
+	for _, result := range queryResponse.Results {
+		processedTx := &peer2.ProcessedTransaction{}
+		require.NoError(t, proto.Unmarshal(result.ResultBytes, processedTx))
+		chdr, err := protoutil.ChannelHeader(processedTx.TransactionEnvelope)
+		require.NoError(t, err)
+		txIDs = append(txIDs, chdr.TxId)
+	}
+	return txIDs, bookmark
+}
+
+func unmarshalBlocksPageForTesting(t *testing.T, payload []byte) ([]uint64, string) {
+	queryResponse, bookmark := unmarshalQueryResponseForTesting(t, payload)
+	var blockNums []uint64
+	for _, result := range queryResponse.Results {
+		block := &common.Block{}
+		require.NoError(t, proto.Unmarshal(result.ResultBytes, block))
+		blockNums = append(blockNums, block.Header.Number)
+	}
+	return blockNums, bookmark
+}
+
 func addBlockForTesting(t *testing.T, chainid string, p *peer.Peer) *common.Block {
 	ledger := p.GetLedger(chainid)
 	defer ledger.Close()
@@ -475,6 +798,30 @@ func addBlockForTesting(t *testing.T, chainid string, p *peer.Peer) *common.Bloc
 	return block1
 }
 
+func commitBlockForTesting(t *testing.T, ledger ledger2.PeerLedger) *common.Block {
+	txid := util.GenerateUUID()
+	simulator, err := ledger.NewTxSimulator(txid)
+	require.NoError(t, err)
+	require.NoError(t, simulator.SetState("ns1", "key1", []byte(txid)))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimResBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	bcInfo, err := ledger.GetBlockchainInfo()
+	require.NoError(t, err)
+	block := testutil.ConstructBlock(t, bcInfo.Height, bcInfo.CurrentBlockHash, [][]byte{pubSimResBytes}, false)
+	require.NoError(t, ledger.CommitLegacy(&ledger2.BlockAndPvtData{Block: block}, &ledger2.CommitOptions{}))
+	return block
+}
+
+type testLedgerGetter map[string]ledger2.PeerLedger
+
+func (g testLedgerGetter) GetLedger(cid string) ledger2.PeerLedger {
+	return g[cid]
+}
+
 var mockAclProvider *mocks.MockACLProvider
 
 func TestMain(m *testing.M) {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package transientstore
 
 import (
+	"bytes"
 	"errors"
 
 	"github.com/golang/protobuf/proto"
@@ -265,6 +266,70 @@ func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 	return s.db.WriteBatch(dbBatch, true)
 }
 
+// PurgedKey identifies a private data key that is purged by a committed transaction
+type PurgedKey struct {
+	Namespace  string
+	Collection string
+	KeyHash    []byte
+}
+
+// PurgePvtDataKeys removes the writes of the given purged keys from the private write sets that were
+// persisted at a block height lesser than or equal to maxBlockNum, i.e., from the private write sets of the
+// transactions that were simulated before the block that contains the purges was committed. PurgePvtDataKeys()
+// is expected to be called by coordinator after committing a block that contains purges of private data keys,
+// so that the transient store does not retain a value of a purged key until the private write set is removed
+func (s *Store) PurgePvtDataKeys(purgedKeys []*PurgedKey, maxBlockNum uint64) error {
+	if len(purgedKeys) == 0 {
+		return nil
+	}
+	logger.Debugf("Purging [%d] private data keys from transient store for private data received till block [%d]", len(purgedKeys), maxBlockNum)
+
+	isPurged := func(ns, coll string, keyHash []byte) bool {
+		for _, k := range purgedKeys {
+			if k.Namespace == ns && k.Collection == coll && bytes.Equal(k.KeyHash, keyHash) {
+				return true
+			}
+		}
+		return false
+	}
+
+	startKey := createPurgeIndexByHeightRangeStartKey(0)
+	endKey := createPurgeIndexByHeightRangeEndKey(maxBlockNum)
+	iter, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	dbBatch := s.db.NewUpdateBatch()
+	for iter.Next() {
+		txid, uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByHeight(iter.Key())
+		if err != nil {
+			return err
+		}
+		compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
+		dbVal, err := s.db.Get(compositeKeyPvtRWSet)
+		if err != nil {
+			return err
+		}
+		if dbVal == nil {
+			continue
+		}
+		updatedVal, err := removeWritesOfPurgedKeys(dbVal, isPurged)
+		if err != nil {
+			return err
+		}
+		if updatedVal != nil {
+			logger.Debugf("Purging keys from transient store private data simulated at block [%d]: txid [%s] uuid [%s]", blockHeight, txid, uuid)
+			dbBatch.Put(compositeKeyPvtRWSet, updatedVal)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return s.db.WriteBatch(dbBatch, true)
+}
+
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
 func (s *Store) GetMinTransientBlkHt() (uint64, error) {
 	// Current approach performs a range query on purgeIndex with startKey
@@ -10,10 +10,14 @@ import (
 	"bytes"
 	"errors"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 )
 
 var (
@@ -235,3 +239,71 @@ func trimPvtCollectionConfigs(configs map[string]*peer.CollectionConfigPackage,
 	}
 	return result, nil
 }
+
+// removeWritesOfPurgedKeys returns the stored value of a private write set without the writes and the metadata
+// writes of the keys for which the function `isPurged` returns true. A nil value is returned if the private write
+// set does not contain any write of such a key
+func removeWritesOfPurgedKeys(dbVal []byte, isPurged func(ns, coll string, keyHash []byte) bool) ([]byte, error) {
+	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
+	txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+	isNewProto := len(dbVal) > 0 && dbVal[0] == nilByte
+	if isNewProto {
+		if err := proto.Unmarshal(dbVal[1:], txPvtRWSetWithConfig); err != nil {
+			return nil, err
+		}
+		txPvtRWSet = txPvtRWSetWithConfig.GetPvtRwset()
+	} else {
+		if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
+			return nil, err
+		}
+	}
+
+	modified := false
+	for _, nsPvtRwset := range txPvtRWSet.GetNsPvtRwset() {
+		for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+			kvRWSet := &kvrwset.KVRWSet{}
+			if err := proto.Unmarshal(collPvtRwset.Rwset, kvRWSet); err != nil {
+				// a write set that cannot be decoded does not contain any write of a purged key
+				// that a transaction could commit, hence it is left to be removed as it is
+				continue
+			}
+			isRemoved := func(key string) bool {
+				return isPurged(nsPvtRwset.Namespace, collPvtRwset.CollectionName, ledgerutil.ComputeStringHash(key))
+			}
+			var writes []*kvrwset.KVWrite
+			for _, w := range kvRWSet.Writes {
+				if !isRemoved(w.Key) {
+					writes = append(writes, w)
+				}
+			}
+			var metadataWrites []*kvrwset.KVMetadataWrite
+			for _, w := range kvRWSet.MetadataWrites {
+				if !isRemoved(w.Key) {
+					metadataWrites = append(metadataWrites, w)
+				}
+			}
+			if len(writes) == len(kvRWSet.Writes) && len(metadataWrites) == len(kvRWSet.MetadataWrites) {
+				continue
+			}
+			kvRWSet.Writes, kvRWSet.MetadataWrites = writes, metadataWrites
+			rwsetBytes, err := proto.Marshal(kvRWSet)
+			if err != nil {
+				return nil, err
+			}
+			collPvtRwset.Rwset = rwsetBytes
+			modified = true
+		}
+	}
+	if !modified {
+		return nil, nil
+	}
+
+	if !isNewProto {
+		return proto.Marshal(txPvtRWSet)
+	}
+	txPvtRWSetWithConfigBytes, err := proto.Marshal(txPvtRWSetWithConfig)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{nilByte}, txPvtRWSetWithConfigBytes...), nil
+}
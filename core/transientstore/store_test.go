@@ -16,11 +16,13 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/policydsl"
 	commonutil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -498,6 +500,57 @@ func TestTransientStorePurgeBelowHeight(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestTransientStorePurgePvtDataKeys(t *testing.T) {
+	env.initTestEnv(t)
+	defer env.cleanup()
+	testStore := env.store
+
+	pvtRWSetWithConfig := func(keys ...string) *transientstore.TxPvtReadWriteSetWithConfigInfo {
+		builder := rwsetutil.NewRWSetBuilder()
+		for _, key := range keys {
+			builder.AddToPvtAndHashedWriteSet("ns-1", "coll-1", key, []byte("value-"+key))
+			builder.AddToPvtAndHashedWriteSet("ns-1", "coll-2", key, []byte("value-"+key))
+		}
+		simRes, err := builder.GetTxSimulationResults()
+		require.NoError(t, err)
+		pvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+		pvtRWSetWithConfig.PvtRwset = simRes.PvtSimulationResults
+		return pvtRWSetWithConfig
+	}
+	require.NoError(t, testStore.Persist("txid-1", 5, pvtRWSetWithConfig("key-1", "key-2")))
+	require.NoError(t, testStore.Persist("txid-2", 7, pvtRWSetWithConfig("key-1", "key-2")))
+	require.NoError(t, testStore.persistOldProto("txid-3", 5, pvtRWSetWithConfig("key-1").PvtRwset))
+	require.NoError(t, testStore.Persist("txid-4", 6, samplePvtDataWithConfigInfo(t)))
+
+	purgedKeys := []*PurgedKey{{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key-1")}}
+	require.NoError(t, testStore.PurgePvtDataKeys(purgedKeys, 6))
+
+	retrieve := func(txid string) *rwset.TxPvtReadWriteSet {
+		iter, err := testStore.GetTxPvtRWSetByTxid(txid, nil)
+		require.NoError(t, err)
+		defer iter.Close()
+		result, err := iter.Next()
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		return result.PvtSimulationResultsWithConfig.PvtRwset
+	}
+	expectedPvtRWSet := pvtRWSetWithConfig("key-2").PvtRwset
+	expectedPvtRWSet.NsPvtRwset[0].CollectionPvtRwset[1] = pvtRWSetWithConfig("key-1", "key-2").PvtRwset.NsPvtRwset[0].CollectionPvtRwset[1]
+	require.True(t, proto.Equal(expectedPvtRWSet, retrieve("txid-1")))
+
+	// the private write sets received after the purge are retained as is
+	require.True(t, proto.Equal(pvtRWSetWithConfig("key-1", "key-2").PvtRwset, retrieve("txid-2")))
+	require.True(t, proto.Equal(samplePvtData(t), retrieve("txid-4")))
+
+	// the write set of the old proto is purged too
+	oldProtoPvtRWSet := retrieve("txid-3")
+	coll1RWSet := &kvrwset.KVRWSet{}
+	require.NoError(t, proto.Unmarshal(oldProtoPvtRWSet.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, coll1RWSet))
+	require.Empty(t, coll1RWSet.Writes)
+
+	require.NoError(t, testStore.PurgePvtDataKeys(nil, 6))
+}
+
 func TestTransientStoreRetrievalWithFilter(t *testing.T) {
 	env.initTestEnv(t)
 	defer env.cleanup()
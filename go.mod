@@ -9,6 +9,11 @@ replace github.com/golang/protobuf => github.com/golang/protobuf v1.3.3
 
 replace github.com/littlegirlpppp/gmsm => /Users/sunbo/Desktop/FabricGM/tjfoc-gm
 
+// Private data purge, see third_party/README.md
+replace github.com/hyperledger/fabric-protos-go => ./third_party/fabric-protos-go
+
+replace github.com/littlegirlpppp/fabric-chaincode-go => ./third_party/fabric-chaincode-go
+
 require (
 	code.cloudfoundry.org/clock v1.0.0
 	github.com/Hyperledger-TWGC/tjfoc-gm v1.4.0 // indirect
@@ -27,7 +32,7 @@ require (
 	github.com/fsouza/go-dockerclient v1.4.1
 	github.com/go-kit/kit v0.8.0
 	github.com/golang/protobuf v1.4.3
-	github.com/golang/snappy v0.0.2 // indirect
+	github.com/golang/snappy v0.0.2
 	github.com/gorilla/handlers v1.4.0
 	github.com/gorilla/mux v1.7.2
 	github.com/grpc-ecosystem/go-grpc-middleware v1.1.0
@@ -227,7 +227,9 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		return errors.Wrap(err, "commit failed")
 	}
 
-	// Purge transactions
+	// Purge transactions and the private data keys purged by the valid transactions,
+	// as per the validation flags of the committed block
+	retrievedPvtdata.purgedKeys = getPurgedKeysFromBlock(block)
 	go retrievedPvtdata.Purge()
 
 	return nil
@@ -347,6 +349,36 @@ func (c *coordinator) getTxPvtdataInfoFromBlock(block *common.Block) ([]*ledger.
 	return txPvtdataItemsFromBlock, nil
 }
 
+// getPurgedKeysFromBlock returns the private data keys that are purged by the valid transactions in the block
+func getPurgedKeysFromBlock(block *common.Block) []*transientstore.PurgedKey {
+	var purgedKeys []*transientstore.PurgedKey
+	txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for seqInBlock, txEnvBytes := range block.Data.Data {
+		if seqInBlock >= len(txsFilter) || txsFilter[seqInBlock] != uint8(peer.TxValidationCode_VALID) {
+			continue
+		}
+		txInfo, err := getTxInfoFromTransactionBytes(txEnvBytes)
+		if err != nil {
+			continue
+		}
+		for _, ns := range txInfo.txRWSet.NsRwSets {
+			for _, hashedCollection := range ns.CollHashedRwSets {
+				for _, hashedWrite := range hashedCollection.HashedRwSet.GetHashedWrites() {
+					if !hashedWrite.IsPurge {
+						continue
+					}
+					purgedKeys = append(purgedKeys, &transientstore.PurgedKey{
+						Namespace:  ns.NameSpace,
+						Collection: hashedCollection.CollectionName,
+						KeyHash:    hashedWrite.KeyHash,
+					})
+				}
+			}
+		}
+	}
+	return purgedKeys
+}
+
 func (c *coordinator) reportValidationDuration(time time.Duration) {
 	c.metrics.ValidationDuration.With("channel", c.ChainID).Observe(time.Seconds())
 }
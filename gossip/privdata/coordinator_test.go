@@ -1812,6 +1812,19 @@ func TestContainsWrites(t *testing.T) {
 	assert.True(t, containsWrites("tx", "ns", col))
 }
 
+func TestGetPurgedKeysFromBlock(t *testing.T) {
+	bf := &blockFactory{channelID: "testchannelid"}
+	block := bf.AddTxn("tx1", "ns1", []byte{1, 2, 3}, "c1").
+		AddPurgeTxn("tx2", "ns1", []byte{4, 5, 6}, "c1", "c2").
+		AddPurgeTxn("tx3", "ns2", []byte{7, 8, 9}, "c1").
+		withInvalidTxns(2).create()
+
+	assert.Equal(t, []*transientstore.PurgedKey{
+		{Namespace: "ns1", Collection: "c1", KeyHash: []byte("Key-4-hash")},
+		{Namespace: "ns1", Collection: "c2", KeyHash: []byte("Key-4-hash")},
+	}, getPurgedKeysFromBlock(block))
+}
+
 func TestIgnoreReadOnlyColRWSets(t *testing.T) {
 	// Scenario: The transaction has some ColRWSets that have only reads and no writes,
 	// These should be ignored and not considered as missing private data that needs to be retrieved
@@ -47,6 +47,7 @@ type RetrievedPvtdata struct {
 	purgeDurationHistogram  metrics.Histogram
 	blockNum                uint64
 	transientBlockRetention uint64
+	purgedKeys              []*transientstore.PurgedKey
 }
 
 // GetBlockPvtdata returns the BlockPvtdata
@@ -56,9 +57,15 @@ func (r *RetrievedPvtdata) GetBlockPvtdata() *ledger.BlockPvtdata {
 
 // Purge purges private data for transactions in the block from the transient store.
 // Transactions older than the retention period are considered orphaned and also purged.
+// The private data keys purged by the transactions in the block are removed from the private
+// data of the transactions that were simulated before the block was committed.
 func (r *RetrievedPvtdata) Purge() {
 	purgeStart := time.Now()
 
+	if err := r.transientStore.PurgePvtDataKeys(r.purgedKeys, r.blockNum); err != nil {
+		r.logger.Errorf("Purging private data keys purged in block [%d] failed: %s", r.blockNum, err)
+	}
+
 	if len(r.blockPvtdata.PvtData) > 0 {
 		// Finally, purge all transactions in block - valid or not valid.
 		if err := r.transientStore.PurgeByTxids(r.pvtdataRetrievalInfo.txns); err != nil {
@@ -39,15 +39,30 @@ func (bf *blockFactory) AddReadOnlyTxn(txID string, nsName string, hash []byte,
 }
 
 func (bf *blockFactory) AddTxnWithEndorsement(txID string, nsName string, hash []byte, org string, hasWrites bool, collections ...string) *blockFactory {
+	nsRWSet := sampleNsRwSet(nsName, hash, collections...)
+	if !hasWrites {
+		nsRWSet = sampleReadOnlyNsRwSet(nsName, hash, collections...)
+	}
+	return bf.addTxnWithNsRwSet(txID, nsRWSet, org)
+}
+
+// AddPurgeTxn adds a transaction that purges the key of the deleted hashed write in each of the collections
+func (bf *blockFactory) AddPurgeTxn(txID string, nsName string, hash []byte, collections ...string) *blockFactory {
+	nsRWSet := sampleNsRwSet(nsName, hash, collections...)
+	for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+		for _, hashedWrite := range collHashedRwSet.HashedRwSet.HashedWrites {
+			hashedWrite.IsPurge = hashedWrite.IsDelete
+		}
+	}
+	return bf.addTxnWithNsRwSet(txID, nsRWSet, "")
+}
+
+func (bf *blockFactory) addTxnWithNsRwSet(txID string, nsRWSet *rwsetutil.NsRwSet, org string) *blockFactory {
 	txn := &peer.Transaction{
 		Actions: []*peer.TransactionAction{
 			{},
 		},
 	}
-	nsRWSet := sampleNsRwSet(nsName, hash, collections...)
-	if !hasWrites {
-		nsRWSet = sampleReadOnlyNsRwSet(nsName, hash, collections...)
-	}
 	txrws := rwsetutil.TxRwSet{
 		NsRwSets: []*rwsetutil.NsRwSet{nsRWSet},
 	}
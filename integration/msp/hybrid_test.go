@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/integration/nwo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("MSP identity test on a network with ECDSA and SM2 organizations", func() {
+	var (
+		client  *docker.Client
+		tempDir string
+		network *nwo.Network
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "msp-hybrid")
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err = docker.NewClientFromEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		network = nwo.New(nwo.BasicSolo(), tempDir, client, StartPort(), components)
+	})
+
+	AfterEach(func() {
+		if process != nil {
+			process.Signal(syscall.SIGTERM)
+			Eventually(process.Wait(), network.EventuallyTimeout).Should(Receive())
+		}
+		if network != nil {
+			network.Cleanup()
+		}
+		os.RemoveAll(tempDir)
+	})
+
+	It("validates endorsements signed with both ECDSA and SM2 identities", func() {
+		By("switching org1 to ECDSA P-256 identities")
+		network.Organization("Org1").KeyAlgorithm = nwo.KeyAlgorithmECDSA
+
+		network.GenerateConfigTree()
+		network.Bootstrap()
+
+		org1Peer0 := network.Peer("Org1", "peer0")
+		org2Peer0 := network.Peer("Org2", "peer0")
+		orderer := network.Orderer("orderer")
+
+		Expect(signCertAlgorithm(network.PeerCert(org1Peer0))).To(Equal(x509.ECDSA))
+
+		By("starting all processes for fabric")
+		process = ifrit.Invoke(network.NetworkGroupRunner())
+		Eventually(process.Ready(), network.EventuallyTimeout).Should(BeClosed())
+
+		By("creating and joining channels")
+		network.CreateAndJoinChannels(orderer)
+		nwo.EnableCapabilities(network, "testchannel", "Application", "V2_0", orderer, org1Peer0, org2Peer0)
+
+		chaincode := nwo.Chaincode{
+			Name:            "mycc",
+			Version:         "0.0",
+			Path:            "github.com/hyperledger/fabric/integration/chaincode/simple/cmd",
+			Lang:            "golang",
+			PackageFile:     filepath.Join(tempDir, "simplecc.tar.gz"),
+			Ctor:            `{"Args":["init","a","100","b","200"]}`,
+			SignaturePolicy: `AND ('Org1MSP.peer', 'Org2MSP.peer')`,
+			Sequence:        "1",
+			InitRequired:    true,
+			Label:           "my_simple_chaincode",
+		}
+
+		By("deploying the chaincode with approvals from both organizations")
+		nwo.DeployChaincode(network, "testchannel", orderer, chaincode)
+
+		By("invoking the chaincode as an ECDSA client with endorsements from both organizations")
+		RunQueryInvokeQuery(network, orderer, org1Peer0, 100)
+
+		By("invoking the chaincode as an SM2 client with endorsements from both organizations")
+		RunQueryInvokeQuery(network, orderer, org2Peer0, 90)
+	})
+})
+
+func signCertAlgorithm(certFile string) x509.PublicKeyAlgorithm {
+	certPEM, err := ioutil.ReadFile(certFile)
+	Expect(err).NotTo(HaveOccurred())
+	block, _ := pem.Decode(certPEM)
+	Expect(block).NotTo(BeNil())
+	cert, err := x509.ParseCertificate(block.Bytes)
+	Expect(err).NotTo(HaveOccurred())
+	return cert.PublicKeyAlgorithm
+}
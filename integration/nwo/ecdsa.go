@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	. "github.com/onsi/gomega"
+)
+
+// KeyAlgorithmECDSA is the Organization.KeyAlgorithm value that requests
+// ECDSA P-256 identities instead of the SM2 identities emitted by cryptogen.
+const KeyAlgorithmECDSA = "ecdsa"
+
+// reissueECDSAIdentities replaces the MSP identity material generated by
+// cryptogen for org with ECDSA P-256 certificates and keys. The file layout
+// and the certificate contents are preserved; only the keys and signatures
+// change. TLS material is left untouched as the GM TLS transport requires
+// SM2 certificates.
+func (n *Network) reissueECDSAIdentities(org *Organization) {
+	orgType := "peerOrganizations"
+	if len(n.PeersInOrg(org.Name)) == 0 && len(n.OrderersInOrg(org.Name)) > 0 {
+		orgType = "ordererOrganizations"
+	}
+	orgDir := filepath.Join(n.RootDir, "crypto", orgType, org.Domain)
+	caDir := filepath.Join(orgDir, "ca")
+
+	caCertFiles, err := filepath.Glob(filepath.Join(caDir, "*-cert.pem"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(caCertFiles).To(HaveLen(1))
+	oldCAPEM, err := ioutil.ReadFile(caCertFiles[0])
+	Expect(err).NotTo(HaveOccurred())
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	caTemplate := ecdsaTemplate(oldCAPEM, &caKey.PublicKey)
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	Expect(err).NotTo(HaveOccurred())
+	caCert, err := x509.ParseCertificate(caDER)
+	Expect(err).NotTo(HaveOccurred())
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+
+	writeECDSAKey(caDir, caKey)
+	err = ioutil.WriteFile(caCertFiles[0], caPEM, 0644)
+	Expect(err).NotTo(HaveOccurred())
+
+	type reissued struct {
+		certPEM []byte
+		key     *ecdsa.PrivateKey
+	}
+	issued := map[string]reissued{}
+
+	err = filepath.Walk(orgDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case "ca", "tlsca", "tls", "tlscacerts", "keystore":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".pem") {
+			return nil
+		}
+
+		certPEM, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(certPEM, oldCAPEM) {
+			return ioutil.WriteFile(path, caPEM, 0644)
+		}
+
+		dir := filepath.Base(filepath.Dir(path))
+		if dir != "signcerts" && dir != "admincerts" {
+			return nil
+		}
+
+		r, ok := issued[string(certPEM)]
+		if !ok {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				return err
+			}
+			der, err := x509.CreateCertificate(rand.Reader, ecdsaTemplate(certPEM, &key.PublicKey), caCert, &key.PublicKey, caKey)
+			if err != nil {
+				return err
+			}
+			r = reissued{certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), key: key}
+			issued[string(certPEM)] = r
+		}
+		if dir == "signcerts" {
+			writeECDSAKey(filepath.Join(filepath.Dir(filepath.Dir(path)), "keystore"), r.key)
+		}
+		return ioutil.WriteFile(path, r.certPEM, 0644)
+	})
+	Expect(err).NotTo(HaveOccurred())
+}
+
+// ecdsaTemplate returns a template carrying the identity information of the
+// PEM encoded certificate.
+func ecdsaTemplate(certPEM []byte, pub *ecdsa.PublicKey) *x509.Certificate {
+	block, _ := pem.Decode(certPEM)
+	Expect(block).NotTo(BeNil())
+	cert, err := gmx509.ParseCertificate(block.Bytes)
+	Expect(err).NotTo(HaveOccurred())
+
+	var eku []x509.ExtKeyUsage
+	for _, u := range cert.ExtKeyUsage {
+		eku = append(eku, x509.ExtKeyUsage(u))
+	}
+	return &x509.Certificate{
+		SerialNumber:          cert.SerialNumber,
+		Subject:               cert.Subject,
+		NotBefore:             cert.NotBefore,
+		NotAfter:              cert.NotAfter,
+		KeyUsage:              x509.KeyUsage(cert.KeyUsage),
+		ExtKeyUsage:           eku,
+		BasicConstraintsValid: cert.BasicConstraintsValid,
+		IsCA:                  cert.IsCA,
+		DNSNames:              cert.DNSNames,
+		SubjectKeyId:          ecdsaSKI(pub),
+	}
+}
+
+// writeECDSAKey replaces the content of keystore with key, named after its
+// SKI like the keys written by cryptogen.
+func writeECDSAKey(keystore string, key *ecdsa.PrivateKey) {
+	files, err := filepath.Glob(filepath.Join(keystore, "*_sk"))
+	Expect(err).NotTo(HaveOccurred())
+	for _, f := range files {
+		Expect(os.Remove(f)).To(Succeed())
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	keyFile := filepath.Join(keystore, hex.EncodeToString(ecdsaSKI(&key.PublicKey))+"_sk")
+	err = ioutil.WriteFile(keyFile, keyPEM, 0600)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func ecdsaSKI(pub *ecdsa.PublicKey) []byte {
+	hash := sha256.Sum256(elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+	return hash[:]
+}
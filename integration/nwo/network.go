@@ -50,6 +50,9 @@ type Organization struct {
 	EnableNodeOUs bool   `yaml:"enable_node_organizational_units"`
 	Users         int    `yaml:"users,omitempty"`
 	CA            *CA    `yaml:"ca,omitempty"`
+	// KeyAlgorithm selects the algorithm of the MSP identities. SM2 is used
+	// when empty; KeyAlgorithmECDSA selects ECDSA P-256.
+	KeyAlgorithm string `yaml:"key_algorithm,omitempty"`
 }
 
 type CA struct {
@@ -678,6 +681,12 @@ func (n *Network) Bootstrap() {
 	Expect(err).NotTo(HaveOccurred())
 	Eventually(sess, n.EventuallyTimeout).Should(gexec.Exit(0))
 
+	for _, o := range n.Organizations {
+		if o.KeyAlgorithm == KeyAlgorithmECDSA {
+			n.reissueECDSAIdentities(o)
+		}
+	}
+
 	n.bootstrapIdemix()
 
 	sess, err = n.ConfigTxGen(commands.OutputBlock{
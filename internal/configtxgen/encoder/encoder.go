@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package encoder
 
 import (
+	"io/ioutil"
+
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/genesis"
@@ -40,6 +44,8 @@ const (
 	ConsensusTypeKafka = "kafka"
 	// ConsensusTypeKafka identifies the Kafka-based consensus implementation.
 	ConsensusTypeEtcdRaft = "etcdraft"
+	// ConsensusTypeBFT identifies the BFT consensus implementation.
+	ConsensusTypeBFT = "BFT"
 
 	// BlockValidationPolicyKey TODO
 	BlockValidationPolicyKey = "BlockValidation"
@@ -135,7 +141,14 @@ func NewChannelGroup(conf *genesisconfig.Profile) (*cb.ConfigGroup, error) {
 		return nil, errors.Wrapf(err, "error adding policies to channel group")
 	}
 
-	addValue(channelGroup, channelconfig.HashingAlgorithmValue(), channelconfig.AdminsPolicyKey)
+	switch conf.HashingAlgorithm {
+	case "":
+		addValue(channelGroup, channelconfig.HashingAlgorithmValue(), channelconfig.AdminsPolicyKey)
+	case bccsp.SHA256, bccsp.SHA3_256, bccsp.GMSM3:
+		addValue(channelGroup, channelconfig.NamedHashingAlgorithmValue(conf.HashingAlgorithm), channelconfig.AdminsPolicyKey)
+	default:
+		return nil, errors.Errorf("unknown hashing algorithm: %s", conf.HashingAlgorithm)
+	}
 	addValue(channelGroup, channelconfig.BlockDataHashingStructureValue(), channelconfig.AdminsPolicyKey)
 	if conf.Orderer != nil && len(conf.Orderer.Addresses) > 0 {
 		addValue(channelGroup, channelconfig.OrdererAddressesValue(conf.Orderer.Addresses), ordererAdminsPolicyName)
@@ -183,12 +196,30 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 	if err := AddOrdererPolicies(ordererGroup, conf.Policies, channelconfig.AdminsPolicyKey); err != nil {
 		return nil, errors.Wrapf(err, "error adding policies to orderer group")
 	}
-	addValue(ordererGroup, channelconfig.BatchSizeValue(
-		conf.BatchSize.MaxMessageCount,
-		conf.BatchSize.AbsoluteMaxBytes,
-		conf.BatchSize.PreferredMaxBytes,
-	), channelconfig.AdminsPolicyKey)
-	addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
+	if conf.AdaptiveBatching.TargetLatency == 0 {
+		addValue(ordererGroup, channelconfig.BatchSizeValue(
+			conf.BatchSize.MaxMessageCount,
+			conf.BatchSize.AbsoluteMaxBytes,
+			conf.BatchSize.PreferredMaxBytes,
+		), channelconfig.AdminsPolicyKey)
+		addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
+	} else {
+		var minTimeout string
+		if conf.AdaptiveBatching.MinTimeout != 0 {
+			minTimeout = conf.AdaptiveBatching.MinTimeout.String()
+		}
+		addValue(ordererGroup, channelconfig.AdaptiveBatchSizeValue(
+			conf.BatchSize.MaxMessageCount,
+			conf.BatchSize.AbsoluteMaxBytes,
+			conf.BatchSize.PreferredMaxBytes,
+			conf.AdaptiveBatching.MinMessageCount,
+		), channelconfig.AdminsPolicyKey)
+		addValue(ordererGroup, channelconfig.AdaptiveBatchTimeoutValue(
+			conf.BatchTimeout.String(),
+			minTimeout,
+			conf.AdaptiveBatching.TargetLatency.String(),
+		), channelconfig.AdminsPolicyKey)
+	}
 	addValue(ordererGroup, channelconfig.ChannelRestrictionsValue(conf.MaxChannels), channelconfig.AdminsPolicyKey)
 
 	if len(conf.Capabilities) > 0 {
@@ -206,6 +237,10 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		if consensusMetadata, err = channelconfig.MarshalEtcdRaftMetadata(conf.EtcdRaft); err != nil {
 			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", ConsensusTypeEtcdRaft, err)
 		}
+	case ConsensusTypeBFT:
+		if consensusMetadata, err = marshalBFTMetadata(conf.BFT); err != nil {
+			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", ConsensusTypeBFT, err)
+		}
 	default:
 		return nil, errors.Errorf("unknown orderer type: %s", conf.OrdererType)
 	}
@@ -224,6 +259,29 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 	return ordererGroup, nil
 }
 
+// marshalBFTMetadata serializes the BFT metadata, after loading the certificates
+// of the consenters from the paths that are set in their place.
+func marshalBFTMetadata(md *bft.ConfigMetadata) ([]byte, error) {
+	copyMd := proto.Clone(md).(*bft.ConfigMetadata)
+	for _, c := range copyMd.Consenters {
+		for _, cert := range []struct {
+			name  string
+			value *[]byte
+		}{
+			{"client cert", &c.ClientTlsCert},
+			{"server cert", &c.ServerTlsCert},
+			{"identity", &c.Identity},
+		} {
+			pem, err := ioutil.ReadFile(string(*cert.value))
+			if err != nil {
+				return nil, errors.Errorf("cannot load %s for consenter %s:%d: %s", cert.name, c.GetHost(), c.GetPort(), err)
+			}
+			*cert.value = pem
+		}
+	}
+	return proto.Marshal(copyMd)
+}
+
 // NewConsortiumsGroup returns an org component of the channel configuration.  It defines the crypto material for the
 // organization (its MSP).  It sets the mod_policy of all elements to "Admins".
 func NewConsortiumOrgGroup(conf *genesisconfig.Organization) (*cb.ConfigGroup, error) {
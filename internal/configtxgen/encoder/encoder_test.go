@@ -8,6 +8,7 @@ package encoder_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -16,6 +17,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder/fakes"
@@ -275,6 +277,32 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the hashing algorithm is set", func() {
+			BeforeEach(func() {
+				conf.HashingAlgorithm = "GMSM3"
+			})
+
+			It("creates the named hashing algorithm value", func() {
+				cg, err := encoder.NewChannelGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				ha := &cb.HashingAlgorithm{}
+				err = proto.Unmarshal(cg.Values["HashingAlgorithm"].Value, ha)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ha.Name).To(Equal("GMSM3"))
+			})
+		})
+
+		Context("when the hashing algorithm is unknown", func() {
+			BeforeEach(func() {
+				conf.HashingAlgorithm = "MD5"
+			})
+
+			It("returns an error", func() {
+				_, err := encoder.NewChannelGroup(conf)
+				Expect(err).To(MatchError("unknown hashing algorithm: MD5"))
+			})
+		})
+
 		Context("when the orderer addresses are omitted", func() {
 			BeforeEach(func() {
 				conf.Orderer.Addresses = []string{}
@@ -394,6 +422,39 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when adaptive batching is enabled", func() {
+			BeforeEach(func() {
+				conf.BatchTimeout = 2 * time.Second
+				conf.BatchSize.MaxMessageCount = 500
+				conf.AdaptiveBatching = genesisconfig.AdaptiveBatching{
+					TargetLatency:   500 * time.Millisecond,
+					MinTimeout:      100 * time.Millisecond,
+					MinMessageCount: 10,
+				}
+			})
+
+			It("adds the adaptive batching bounds", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(5))
+
+				batchSize := &ab.BatchSize{}
+				err = proto.Unmarshal(cg.Values["BatchSize"].Value, batchSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(batchSize.MaxMessageCount).To(Equal(uint32(500)))
+				Expect(batchSize.MinMessageCount).To(Equal(uint32(10)))
+
+				batchTimeout := &ab.BatchTimeout{}
+				err = proto.Unmarshal(cg.Values["BatchTimeout"].Value, batchTimeout)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(batchTimeout, &ab.BatchTimeout{
+					Timeout:       "2s",
+					MinTimeout:    "100ms",
+					TargetLatency: "500ms",
+				})).To(BeTrue())
+			})
+		})
+
 		Context("when the consensus type is Kafka", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "kafka"
@@ -447,6 +508,46 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the consensus type is BFT", func() {
+			BeforeEach(func() {
+				conf.OrdererType = "BFT"
+				conf.BFT = &bft.ConfigMetadata{
+					Options: &bft.Options{
+						ViewChangeTimeout: "10s",
+					},
+				}
+			})
+
+			It("adds the BFT metadata", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(5))
+				consensusType := &ab.ConsensusType{}
+				err = proto.Unmarshal(cg.Values["ConsensusType"].Value, consensusType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(consensusType.Type).To(Equal("BFT"))
+				metadata := &bft.ConfigMetadata{}
+				err = proto.Unmarshal(consensusType.Metadata, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata.Options.ViewChangeTimeout).To(Equal("10s"))
+			})
+
+			Context("when the BFT configuration is bad", func() {
+				BeforeEach(func() {
+					conf.BFT = &bft.ConfigMetadata{
+						Consenters: []*bft.Consenter{
+							{},
+						},
+					}
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := encoder.NewOrdererGroup(conf)
+					Expect(err).To(MatchError("cannot marshal metadata for orderer type BFT: cannot load client cert for consenter :0: open : no such file or directory"))
+				})
+			})
+		})
+
 		Context("when the consensus type is unknown", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "bad-type"
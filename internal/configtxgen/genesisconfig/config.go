@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/viperutil"
 	cf "github.com/hyperledger/fabric/core/config"
@@ -24,6 +25,8 @@ import (
 const (
 	// The type key for etcd based RAFT consensus.
 	EtcdRaft = "etcdraft"
+	// The type key for BFT consensus.
+	BFT = "BFT"
 )
 
 var logger = flogging.MustGetLogger("common.tools.configtxgen.localconfig")
@@ -81,12 +84,13 @@ type TopLevel struct {
 // Profile encodes orderer/application configuration combinations for the
 // configtxgen tool.
 type Profile struct {
-	Consortium   string                 `yaml:"Consortium"`
-	Application  *Application           `yaml:"Application"`
-	Orderer      *Orderer               `yaml:"Orderer"`
-	Consortiums  map[string]*Consortium `yaml:"Consortiums"`
-	Capabilities map[string]bool        `yaml:"Capabilities"`
-	Policies     map[string]*Policy     `yaml:"Policies"`
+	Consortium       string                 `yaml:"Consortium"`
+	Application      *Application           `yaml:"Application"`
+	Orderer          *Orderer               `yaml:"Orderer"`
+	Consortiums      map[string]*Consortium `yaml:"Consortiums"`
+	Capabilities     map[string]bool        `yaml:"Capabilities"`
+	Policies         map[string]*Policy     `yaml:"Policies"`
+	HashingAlgorithm string                 `yaml:"HashingAlgorithm"`
 }
 
 // Policy encodes a channel config policy
@@ -143,16 +147,18 @@ type AnchorPeer struct {
 
 // Orderer contains configuration associated to a channel.
 type Orderer struct {
-	OrdererType   string                   `yaml:"OrdererType"`
-	Addresses     []string                 `yaml:"Addresses"`
-	BatchTimeout  time.Duration            `yaml:"BatchTimeout"`
-	BatchSize     BatchSize                `yaml:"BatchSize"`
-	Kafka         Kafka                    `yaml:"Kafka"`
-	EtcdRaft      *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
-	Organizations []*Organization          `yaml:"Organizations"`
-	MaxChannels   uint64                   `yaml:"MaxChannels"`
-	Capabilities  map[string]bool          `yaml:"Capabilities"`
-	Policies      map[string]*Policy       `yaml:"Policies"`
+	OrdererType      string                   `yaml:"OrdererType"`
+	Addresses        []string                 `yaml:"Addresses"`
+	BatchTimeout     time.Duration            `yaml:"BatchTimeout"`
+	BatchSize        BatchSize                `yaml:"BatchSize"`
+	AdaptiveBatching AdaptiveBatching         `yaml:"AdaptiveBatching"`
+	Kafka            Kafka                    `yaml:"Kafka"`
+	EtcdRaft         *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
+	BFT              *bft.ConfigMetadata      `yaml:"BFT"`
+	Organizations    []*Organization          `yaml:"Organizations"`
+	MaxChannels      uint64                   `yaml:"MaxChannels"`
+	Capabilities     map[string]bool          `yaml:"Capabilities"`
+	Policies         map[string]*Policy       `yaml:"Policies"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
@@ -162,6 +168,14 @@ type BatchSize struct {
 	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
 }
 
+// AdaptiveBatching contains configuration for tuning the batch size and timeout
+// to the arrival rate of messages. It is enabled when TargetLatency is set.
+type AdaptiveBatching struct {
+	TargetLatency   time.Duration `yaml:"TargetLatency"`
+	MinTimeout      time.Duration `yaml:"MinTimeout"`
+	MinMessageCount uint32        `yaml:"MinMessageCount"`
+}
+
 // Kafka contains configuration for the Kafka-based orderer.
 type Kafka struct {
 	Brokers []string `yaml:"Brokers"`
@@ -188,6 +202,9 @@ var genesisDefaults = TopLevel{
 				SnapshotIntervalSize: 16 * 1024 * 1024, // 16 MB
 			},
 		},
+		BFT: &bft.ConfigMetadata{
+			Options: bft.DefaultOptions(),
+		},
 	},
 }
 
@@ -410,6 +427,46 @@ loop:
 			cf.TranslatePathInPlace(configDir, &serverCertPath)
 			c.ServerTlsCert = []byte(serverCertPath)
 		}
+	case BFT:
+		if ord.BFT == nil {
+			logger.Panicf("%s configuration missing", BFT)
+		}
+		if ord.BFT.Options == nil {
+			logger.Infof("Orderer.BFT.Options unset, setting to %v", genesisDefaults.Orderer.BFT.Options)
+		}
+		ord.BFT.Options = bft.OptionsWithDefaults(ord.BFT.Options)
+		if len(ord.BFT.Consenters) == 0 {
+			logger.Panicf("%s configuration did not specify any consenter", BFT)
+		}
+
+		for _, c := range ord.BFT.GetConsenters() {
+			if c.Host == "" {
+				logger.Panicf("consenter info in %s configuration did not specify host", BFT)
+			}
+			if c.Port == 0 {
+				logger.Panicf("consenter info in %s configuration did not specify port", BFT)
+			}
+			if c.Id == 0 {
+				logger.Panicf("consenter info in %s configuration did not specify id", BFT)
+			}
+			if c.MspId == "" {
+				logger.Panicf("consenter info in %s configuration did not specify MSP ID", BFT)
+			}
+			if c.ClientTlsCert == nil {
+				logger.Panicf("consenter info in %s configuration did not specify client TLS cert", BFT)
+			}
+			if c.ServerTlsCert == nil {
+				logger.Panicf("consenter info in %s configuration did not specify server TLS cert", BFT)
+			}
+			if c.Identity == nil {
+				logger.Panicf("consenter info in %s configuration did not specify identity", BFT)
+			}
+			for _, certPath := range []*[]byte{&c.ClientTlsCert, &c.ServerTlsCert, &c.Identity} {
+				p := string(*certPath)
+				cf.TranslatePathInPlace(configDir, &p)
+				*certPath = []byte(p)
+			}
+		}
 	default:
 		logger.Panicf("unknown orderer type: %s", ord.OrdererType)
 	}
@@ -7,6 +7,7 @@ package ca
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"fmt"
 
 	// "crypto/elliptic"
@@ -28,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	"github.com/littlegirlpppp/gmsm/sm2"
 	gmx509 "github.com/littlegirlpppp/gmsm/x509"
+	"github.com/pkg/errors"
 )
 
 type CA struct {
@@ -42,6 +44,9 @@ type CA struct {
 	SignCert           *x509.Certificate
 	SignSm2Cert        *gmx509.Certificate
 	Sm2Key             bccsp.Key
+	// KeyAlgorithm is the algorithm of the CA key, see the csp package.
+	// SM2 CAs use SignSm2Cert and Sm2Key, ECDSA CAs use SignCert and Signer.
+	KeyAlgorithm string
 }
 
 // NewCA creates an instance of CA and saves the signing key pair in
@@ -55,15 +60,26 @@ func NewCA(
 	locality,
 	orgUnit,
 	streetAddress,
-	postalCode string,
+	postalCode,
+	keyAlgorithm string,
 ) (*CA, error) {
 
 	var ca *CA
 
-	err := os.MkdirAll(baseDir, 0755)
+	err := csp.ValidateKeyAlgorithm(keyAlgorithm)
 	if err != nil {
 		return nil, err
 	}
+
+	err = os.MkdirAll(baseDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	if !csp.IsSM2(keyAlgorithm) {
+		return newECDSACA(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode, keyAlgorithm)
+	}
+
 	fmt.Println("GeneratePrivateKey start ...")
 	priv, err := csp.GeneratePrivateKey(baseDir)
 	fmt.Println("GeneratePrivateKey end ...")
@@ -98,7 +114,7 @@ func NewCA(
 		return nil, err
 	}
 	ca = &CA{
-		Name: name,
+		Name:               name,
 		SignSm2Cert:        x509Cert,
 		Sm2Key:             priv,
 		Country:            country,
@@ -107,19 +123,94 @@ func NewCA(
 		OrganizationalUnit: orgUnit,
 		StreetAddress:      streetAddress,
 		PostalCode:         postalCode,
+		KeyAlgorithm:       keyAlgorithm,
 	}
 
 	return ca, err
 }
 
+// newECDSACA creates an instance of CA with an ECDSA key pair and a
+// standard X509 certificate
+func newECDSACA(
+	baseDir,
+	org,
+	name,
+	country,
+	province,
+	locality,
+	orgUnit,
+	streetAddress,
+	postalCode,
+	keyAlgorithm string,
+) (*CA, error) {
+	curve, err := csp.ECDSACurve(keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := csp.GenerateECDSAPrivateKey(baseDir, curve)
+	if err != nil {
+		return nil, err
+	}
+
+	template := x509Template()
+	//this is a CA
+	template.IsCA = true
+	template.KeyUsage |= x509.KeyUsageDigitalSignature |
+		x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign |
+		x509.KeyUsageCRLSign
+	template.ExtKeyUsage = []x509.ExtKeyUsage{
+		x509.ExtKeyUsageClientAuth,
+		x509.ExtKeyUsageServerAuth,
+	}
+
+	//set the organization for the subject
+	subject := subjectTemplateAdditional(country, province, locality, orgUnit, streetAddress, postalCode)
+	subject.Organization = []string{org}
+	subject.CommonName = name
+
+	template.Subject = subject
+	template.SubjectKeyId = csp.ECDSASKI(&priv.PublicKey)
+
+	signer := &csp.ECDSASigner{PrivateKey: priv}
+	x509Cert, err := genCertificateECDSA(baseDir, name, &template, &template, &priv.PublicKey, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		Name:               name,
+		Signer:             signer,
+		SignCert:           x509Cert,
+		Country:            country,
+		Province:           province,
+		Locality:           locality,
+		OrganizationalUnit: orgUnit,
+		StreetAddress:      streetAddress,
+		PostalCode:         postalCode,
+		KeyAlgorithm:       keyAlgorithm,
+	}, nil
+}
+
+// Certificate returns the DER encoded certificate of the CA
+func (ca *CA) Certificate() []byte {
+	if ca.SignSm2Cert != nil {
+		return ca.SignSm2Cert.Raw
+	}
+	if ca.SignCert != nil {
+		return ca.SignCert.Raw
+	}
+	return nil
+}
+
 // SignCertificate creates a signed certificate based on a built-in template
-// and saves it in baseDir/name
+// and saves it in baseDir/name. pub must be an *sm2.PublicKey for SM2 CAs
+// and an *ecdsa.PublicKey for ECDSA CAs.
 func (ca *CA) SignCertificate(
 	baseDir,
 	name string,
 	orgUnits,
 	alternateNames []string,
-	pub *sm2.PublicKey,
+	pub crypto.PublicKey,
 	ku x509.KeyUsage,
 	eku []x509.ExtKeyUsage,
 ) (*gmx509.Certificate, error) {
@@ -152,10 +243,30 @@ func (ca *CA) SignCertificate(
 		}
 	}
 
-	template.PublicKey = pub
+	if !csp.IsSM2(ca.KeyAlgorithm) {
+		ecPub, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, errors.Errorf("CA %s can only sign ECDSA public keys", ca.Name)
+		}
+		cert, err := genCertificateECDSA(baseDir, name, &template, ca.SignCert, ecPub, ca.Signer)
+		if err != nil {
+			return nil, err
+		}
+		return gmx509.ParseCertificate(cert.Raw)
+	}
+
+	sm2Pub, ok := pub.(*sm2.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("CA %s can only sign SM2 public keys", ca.Name)
+	}
+	if ca.Sm2Key == nil || ca.SignSm2Cert == nil {
+		return nil, errors.Errorf("CA %s has no SM2 signing key pair", ca.Name)
+	}
+
+	template.PublicKey = sm2Pub
 	sm2Tpl := gm.ParseX509Certificate2Sm2(&template)
-	sm2Tpl.SignatureAlgorithm =gmx509.SM2WithSM3
-	cert, err := genCertificateGMSM2(baseDir, name, sm2Tpl, ca.SignSm2Cert, pub, ca.Sm2Key)
+	sm2Tpl.SignatureAlgorithm = gmx509.SM2WithSM3
+	cert, err := genCertificateGMSM2(baseDir, name, sm2Tpl, ca.SignSm2Cert, sm2Pub, ca.Sm2Key)
 
 	if err != nil {
 		return nil, err
@@ -255,6 +366,73 @@ func genCertificateGMSM2(baseDir, name string, template, parent *gmx509.Certific
 
 }
 
+// generate a signed X509 certificate using ECDSA
+func genCertificateECDSA(
+	baseDir,
+	name string,
+	template,
+	parent *x509.Certificate,
+	pub *ecdsa.PublicKey,
+	priv interface{},
+) (*x509.Certificate, error) {
+
+	//create the x509 public cert
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	if err != nil {
+		return nil, err
+	}
+
+	//write cert out to file
+	fileName := filepath.Join(baseDir, name+"-cert.pem")
+	certFile, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	//pem encode the cert
+	err = pem.Encode(certFile, &pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	certFile.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	x509Cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, err
+	}
+	return x509Cert, nil
+}
+
+// LoadCertificateECDSA load a ecdsa cert from a file in cert path
+func LoadCertificateECDSA(certPath string) (*x509.Certificate, error) {
+	var cert *x509.Certificate
+	var err error
+
+	walkFunc := func(path string, info os.FileInfo, err error) error {
+		if strings.HasSuffix(path, ".pem") {
+			rawCert, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			block, _ := pem.Decode(rawCert)
+			if block == nil || block.Type != "CERTIFICATE" {
+				return errors.Errorf("%s: wrong PEM encoding", path)
+			}
+			cert, err = x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return errors.Errorf("%s: wrong DER encoding", path)
+			}
+		}
+		return nil
+	}
+
+	err = filepath.Walk(certPath, walkFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert, err
+}
+
 // LoadCertificateGMSM2 load a ecdsa cert from a file in cert path
 func LoadCertificateGMSM2(certPath string) (*gmx509.Certificate, error) {
 	var cert *gmx509.Certificate
@@ -7,6 +7,7 @@ package ca_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/x509"
 	"io/ioutil"
 	"net"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/hyperledger/fabric/internal/cryptogen/ca"
 	"github.com/hyperledger/fabric/internal/cryptogen/csp"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,7 +50,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GenerateECDSAPrivateKey(certDir, elliptic.P256())
 	assert.NoError(t, err, "Failed to generate signed certificate")
 
 	// create our CA
@@ -63,6 +65,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSAP256,
 	)
 	assert.NoError(t, err, "Error generating CA")
 
@@ -77,9 +80,9 @@ func TestLoadCertificateECDSA(t *testing.T) {
 	)
 	assert.NoError(t, err, "Failed to generate signed certificate")
 	// KeyUsage should be x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
-	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
+	assert.Equal(t, gmx509.KeyUsageDigitalSignature|gmx509.KeyUsageKeyEncipherment,
 		cert.KeyUsage)
-	assert.Contains(t, cert.ExtKeyUsage, x509.ExtKeyUsageAny)
+	assert.Contains(t, cert.ExtKeyUsage, gmx509.ExtKeyUsageAny)
 
 	loadedCert, err := ca.LoadCertificateECDSA(certDir)
 	assert.NoError(t, err)
@@ -136,6 +139,7 @@ func TestNewCA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSAP256,
 	)
 	assert.NoError(t, err, "Error generating CA")
 	assert.NotNil(t, rootCA, "Failed to return CA")
@@ -175,7 +179,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GenerateECDSAPrivateKey(certDir, elliptic.P256())
 	assert.NoError(t, err, "Failed to generate signed certificate")
 
 	// create our CA
@@ -190,6 +194,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSAP256,
 	)
 	assert.NoError(t, err, "Error generating CA")
 
@@ -204,9 +209,9 @@ func TestGenerateSignCertificate(t *testing.T) {
 	)
 	assert.NoError(t, err, "Failed to generate signed certificate")
 	// KeyUsage should be x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
-	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
+	assert.Equal(t, gmx509.KeyUsageDigitalSignature|gmx509.KeyUsageKeyEncipherment,
 		cert.KeyUsage)
-	assert.Contains(t, cert.ExtKeyUsage, x509.ExtKeyUsageAny)
+	assert.Contains(t, cert.ExtKeyUsage, gmx509.ExtKeyUsageAny)
 
 	cert, err = rootCA.SignCertificate(
 		certDir,
@@ -249,13 +254,82 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 	// use an empty CA to test error path
 	badCA := &ca.CA{
-		Name:     "badCA",
-		SignCert: &x509.Certificate{},
+		Name:         "badCA",
+		SignCert:     &x509.Certificate{},
+		KeyAlgorithm: csp.ECDSAP256,
 	}
 	_, err = badCA.SignCertificate(certDir, testName, nil, nil, &ecdsa.PublicKey{},
 		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
 	assert.Error(t, err, "Empty CA should not be able to sign")
 
+	// an ECDSA CA does not sign SM2 keys
+	_, sm2Pub, err := csp.GenerateKey(certDir, csp.SM2)
+	assert.NoError(t, err)
+	_, err = rootCA.SignCertificate(certDir, testName, nil, nil, sm2Pub,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.EqualError(t, err, "CA "+testCA2Name+" can only sign ECDSA public keys")
+}
+
+func TestGenerateSignCertificateSM2(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ca-test")
+	require.NoError(t, err, "failed to create test directory")
+	defer os.RemoveAll(testDir)
+
+	certDir := filepath.Join(testDir, "certs")
+	require.NoError(t, os.MkdirAll(certDir, 0755))
+
+	rootCA, err := ca.NewCA(
+		filepath.Join(testDir, "ca"),
+		testCAName,
+		testCAName,
+		testCountry,
+		testProvince,
+		testLocality,
+		testOrganizationalUnit,
+		testStreetAddress,
+		testPostalCode,
+		csp.SM2,
+	)
+	require.NoError(t, err, "Error generating CA")
+	assert.NotNil(t, rootCA.Sm2Key)
+	assert.Nil(t, rootCA.SignCert)
+	assert.Equal(t, gmx509.SM2WithSM3, rootCA.SignSm2Cert.SignatureAlgorithm)
+	assert.Equal(t, rootCA.SignSm2Cert.Raw, rootCA.Certificate())
+
+	_, pub, err := csp.GenerateKey(certDir, csp.SM2)
+	require.NoError(t, err)
+	cert, err := rootCA.SignCertificate(certDir, testName, nil, nil, pub,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	require.NoError(t, err)
+	assert.Equal(t, gmx509.SM2WithSM3, cert.SignatureAlgorithm)
+	assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignSm2Cert))
+
+	ecdsaPriv, err := csp.GenerateECDSAPrivateKey(certDir, elliptic.P256())
+	require.NoError(t, err)
+	_, err = rootCA.SignCertificate(certDir, testName, nil, nil, &ecdsaPriv.PublicKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.EqualError(t, err, "CA "+testCAName+" can only sign SM2 public keys")
+}
+
+func TestNewCAKeyAlgorithm(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ca-test")
+	require.NoError(t, err, "failed to create test directory")
+	defer os.RemoveAll(testDir)
+
+	rootCA, err := ca.NewCA(filepath.Join(testDir, "p384"), testCAName, testCAName,
+		"", "", "", "", "", "", csp.ECDSAP384)
+	require.NoError(t, err)
+	assert.Equal(t, x509.ECDSAWithSHA384, rootCA.SignCert.SignatureAlgorithm)
+	assert.Equal(t, elliptic.P384(), rootCA.SignCert.PublicKey.(*ecdsa.PublicKey).Curve)
+	assert.Equal(t, rootCA.SignCert.Raw, rootCA.Certificate())
+
+	_, err = ca.NewCA(filepath.Join(testDir, "ed25519"), testCAName, testCAName,
+		"", "", "", "", "", "", csp.ED25519)
+	assert.EqualError(t, err, "key algorithm ed25519 is not supported by the MSP")
+
+	_, err = ca.NewCA(filepath.Join(testDir, "rsa"), testCAName, testCAName,
+		"", "", "", "", "", "", "rsa")
+	assert.EqualError(t, err, "unknown key algorithm rsa")
 }
 
 func checkForFile(file string) bool {
@@ -8,9 +8,12 @@ package csp
 import (
 	"crypto"
 	"crypto/ecdsa"
-
-	// "crypto/x509"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/asn1"
+	"encoding/hex"
 	"encoding/pem"
 	"io"
 	"io/ioutil"
@@ -28,6 +31,74 @@ import (
 	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 )
 
+// Key algorithms supported by cryptogen. An empty key algorithm selects SM2.
+const (
+	SM2       = "sm2"
+	ECDSAP256 = "ecdsa-p256"
+	ECDSAP384 = "ecdsa-p384"
+	ED25519   = "ed25519"
+)
+
+// ValidateKeyAlgorithm returns an error if keyAlgorithm cannot be used to
+// generate MSP and TLS material.
+func ValidateKeyAlgorithm(keyAlgorithm string) error {
+	switch keyAlgorithm {
+	case "", SM2, ECDSAP256, ECDSAP384:
+		return nil
+	case ED25519:
+		return errors.Errorf("key algorithm %s is not supported by the MSP", keyAlgorithm)
+	default:
+		return errors.Errorf("unknown key algorithm %s", keyAlgorithm)
+	}
+}
+
+// IsSM2 returns true if keyAlgorithm selects SM2 keys and GM certificates.
+func IsSM2(keyAlgorithm string) bool {
+	return keyAlgorithm == "" || keyAlgorithm == SM2
+}
+
+// ECDSACurve returns the curve of an ECDSA key algorithm.
+func ECDSACurve(keyAlgorithm string) (elliptic.Curve, error) {
+	switch keyAlgorithm {
+	case ECDSAP256:
+		return elliptic.P256(), nil
+	case ECDSAP384:
+		return elliptic.P384(), nil
+	default:
+		return nil, errors.Errorf("%s is not an ECDSA key algorithm", keyAlgorithm)
+	}
+}
+
+// GenerateKey creates a private key for keyAlgorithm and stores it in
+// keystorePath. It returns the SKI and the public key of the new key.
+func GenerateKey(keystorePath, keyAlgorithm string) ([]byte, crypto.PublicKey, error) {
+	if err := ValidateKeyAlgorithm(keyAlgorithm); err != nil {
+		return nil, nil, err
+	}
+
+	if IsSM2(keyAlgorithm) {
+		priv, err := GeneratePrivateKey(keystorePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		pub, err := GetSM2PublicKey(priv)
+		if err != nil {
+			return nil, nil, err
+		}
+		return priv.SKI(), pub, nil
+	}
+
+	curve, err := ECDSACurve(keyAlgorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	priv, err := GenerateECDSAPrivateKey(keystorePath, curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ECDSASKI(&priv.PublicKey), &priv.PublicKey, nil
+}
+
 // LoadPrivateKey loads a private key from a file in keystorePath.  It looks
 // for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC private key.
 // func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
@@ -129,6 +200,80 @@ func GeneratePrivateKey(keystorePath string) (bccsp.Key, error) {
 	return priv, err
 }
 
+// GenerateECDSAPrivateKey creates an EC private key on curve and stores it
+// in keystorePath as a PKCS8 encoded file named after its SKI.
+func GenerateECDSAPrivateKey(keystorePath string, curve elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to generate private key")
+	}
+
+	pkcs8Encoded, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to marshal private key")
+	}
+
+	pemEncoded := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Encoded})
+
+	keyFile := filepath.Join(keystorePath, hex.EncodeToString(ECDSASKI(&priv.PublicKey))+"_sk")
+	err = ioutil.WriteFile(keyFile, pemEncoded, 0600)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to save private key to file %s", keyFile)
+	}
+
+	return priv, err
+}
+
+// LoadECDSAPrivateKey loads an EC private key from a file in keystorePath.
+// It looks for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC
+// private key.
+func LoadECDSAPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
+	var priv *ecdsa.PrivateKey
+
+	walkFunc := func(path string, info os.FileInfo, pathErr error) error {
+		if !strings.HasSuffix(path, "_sk") {
+			return nil
+		}
+
+		rawKey, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		block, _ := pem.Decode(rawKey)
+		if block == nil {
+			return errors.Errorf("%s: bytes are not PEM encoded", path)
+		}
+
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return errors.WithMessagef(err, "%s: pem bytes are not PKCS8 encoded", path)
+		}
+
+		var ok bool
+		priv, ok = key.(*ecdsa.PrivateKey)
+		if !ok {
+			return errors.Errorf("%s: pem bytes do not contain an EC private key", path)
+		}
+
+		return nil
+	}
+
+	err := filepath.Walk(keystorePath, walkFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return priv, err
+}
+
+// ECDSASKI returns the subject key identifier of an EC public key, computed
+// the same way as the BCCSP does.
+func ECDSASKI(pub *ecdsa.PublicKey) []byte {
+	hash := sha256.Sum256(elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+	return hash[:]
+}
+
 /**
 ECDSA signer implements the crypto.Signer interface for ECDSA keys.  The
 Sign method ensures signatures are created with Low S values since Fabric
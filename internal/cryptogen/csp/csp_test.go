@@ -12,6 +12,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/asn1"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
@@ -113,17 +114,80 @@ func TestGeneratePrivateKey(t *testing.T) {
 	}
 	defer os.RemoveAll(testDir)
 
-	expectedFile := filepath.Join(testDir, "priv_sk")
 	priv, err := csp.GeneratePrivateKey(testDir)
 	assert.NoError(t, err, "Failed to generate private key")
-	assert.NotNil(t, priv, "Should have returned an *ecdsa.Key")
+	assert.NotNil(t, priv, "Should have returned a bccsp.Key")
+	expectedFile := filepath.Join(testDir, hex.EncodeToString(priv.SKI())+"_sk")
 	assert.Equal(t, true, checkForFile(expectedFile),
 		"Expected to find private key file")
 
-	_, err = csp.GeneratePrivateKey("notExist")
+	// the keystore directory cannot be created under the private key file
+	_, err = csp.GeneratePrivateKey(filepath.Join(expectedFile, "notExist"))
+	assert.Error(t, err)
+}
+
+func TestGenerateECDSAPrivateKey(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "csp-test")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %s", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	priv, err := csp.GenerateECDSAPrivateKey(testDir, elliptic.P384())
+	assert.NoError(t, err, "Failed to generate private key")
+	assert.Equal(t, elliptic.P384(), priv.Curve)
+	expectedFile := filepath.Join(testDir, hex.EncodeToString(csp.ECDSASKI(&priv.PublicKey))+"_sk")
+	assert.Equal(t, true, checkForFile(expectedFile),
+		"Expected to find private key file")
+
+	loadedPriv, err := csp.LoadECDSAPrivateKey(testDir)
+	assert.NoError(t, err, "Failed to load private key")
+	assert.Equal(t, priv, loadedPriv, "Expected private keys to match")
+
+	_, err = csp.GenerateECDSAPrivateKey("notExist", elliptic.P256())
 	assert.Contains(t, err.Error(), "no such file or directory")
 }
 
+func TestLoadECDSAPrivateKey_BadPEM(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "csp-test")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %s", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	badPEMFile := filepath.Join(testDir, "badpem_sk")
+	err = ioutil.WriteFile(badPEMFile, []byte("wrong_encoding"), 0600)
+	if err != nil {
+		t.Fatalf("failed to write to wrong encoding file: %s", err)
+	}
+
+	_, err = csp.LoadECDSAPrivateKey(testDir)
+	assert.EqualError(t, err, fmt.Sprintf("%s: bytes are not PEM encoded", badPEMFile))
+}
+
+func TestGenerateKey(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "csp-test")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %s", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	for _, keyAlgorithm := range []string{"", csp.SM2, csp.ECDSAP256, csp.ECDSAP384} {
+		t.Run(keyAlgorithm, func(t *testing.T) {
+			ski, pub, err := csp.GenerateKey(testDir, keyAlgorithm)
+			assert.NoError(t, err)
+			assert.NotNil(t, pub)
+			assert.Equal(t, true, checkForFile(filepath.Join(testDir, hex.EncodeToString(ski)+"_sk")),
+				"Expected to find private key file")
+		})
+	}
+
+	_, _, err = csp.GenerateKey(testDir, csp.ED25519)
+	assert.EqualError(t, err, "key algorithm ed25519 is not supported by the MSP")
+	_, _, err = csp.GenerateKey(testDir, "rsa")
+	assert.EqualError(t, err, "unknown key algorithm rsa")
+}
+
 func TestECDSASigner(t *testing.T) {
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
@@ -13,7 +13,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/internal/cryptogen/ca"
 	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
@@ -74,8 +73,7 @@ func GenerateLocalMSP(
 	keystore := filepath.Join(mspDir, "keystore")
 
 	// generate private key
-	priv, err := csp.GeneratePrivateKey(keystore)
-	sm2PubKey, err := csp.GetSM2PublicKey(priv)
+	_, pubKey, err := csp.GenerateKey(keystore, signCA.KeyAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -90,8 +88,7 @@ func GenerateLocalMSP(
 		name,
 		ous,
 		nil,
-		// &priv.PublicKey,
-		sm2PubKey,
+		pubKey,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -102,19 +99,17 @@ func GenerateLocalMSP(
 	// write artifacts to MSP folders
 
 	// the signing CA certificate goes into cacerts
-	err = x509Export(
+	err = caExport(
 		filepath.Join(mspDir, "cacerts", x509Filename(signCA.Name)),
-		signCA.SignSm2Cert,
-		// signCA.SignCert,
+		signCA,
 	)
 	if err != nil {
 		return err
 	}
 	// the TLS CA certificate goes into tlscacerts
-	err = x509Export(
+	err = caExport(
 		filepath.Join(mspDir, "tlscacerts", x509Filename(tlsCA.Name)),
-		tlsCA.SignSm2Cert,
-		// tlsCA.SignCert,
+		tlsCA,
 	)
 	if err != nil {
 		return err
@@ -144,8 +139,7 @@ func GenerateLocalMSP(
 	*/
 
 	// generate private key
-	tlsPrivKey, err := csp.GeneratePrivateKey(tlsDir)
-	tlsPubKey, err := csp.GetSM2PublicKey(tlsPrivKey)
+	tlsSKI, tlsPubKey, err := csp.GenerateKey(tlsDir, tlsCA.KeyAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -156,7 +150,6 @@ func GenerateLocalMSP(
 		name,
 		nil,
 		sans,
-		// &tlsPrivKey.PublicKey,
 		tlsPubKey,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth,
@@ -166,8 +159,7 @@ func GenerateLocalMSP(
 		return err
 	}
 
-	// err = x509Export(filepath.Join(tlsDir, "ca.crt"), tlsCA.SignCert)
-	err = x509Export(filepath.Join(tlsDir, "ca.crt"), tlsCA.SignSm2Cert)
+	err = caExport(filepath.Join(tlsDir, "ca.crt"), tlsCA)
 	if err != nil {
 		return err
 	}
@@ -184,7 +176,48 @@ func GenerateLocalMSP(
 	}
 	fmt.Println("tlsDir=>", tlsDir)
 	fmt.Println("tlsFilePrefix=>", tlsFilePrefix)
-	err = keyExport(tlsDir, filepath.Join(tlsDir, tlsFilePrefix+".key"), tlsPrivKey)
+	err = keyExport(tlsDir, filepath.Join(tlsDir, tlsFilePrefix+".key"), tlsSKI)
+	if err != nil {
+		return err
+	}
+
+	/*
+		Generate the GM TLS encryption key pair in the TLS folder
+	*/
+
+	// only GM TLS uses a separate encryption key pair
+	if !csp.IsSM2(tlsCA.KeyAlgorithm) {
+		return nil
+	}
+
+	// generate private key
+	tlsEncSKI, tlsEncPubKey, err := csp.GenerateKey(tlsDir, tlsCA.KeyAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	// generate X509 certificate using TLS CA
+	_, err = tlsCA.SignCertificate(
+		filepath.Join(tlsDir),
+		name,
+		nil,
+		sans,
+		tlsEncPubKey,
+		x509.KeyUsageKeyEncipherment|x509.KeyUsageDataEncipherment|x509.KeyUsageKeyAgreement,
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth},
+	)
+	if err != nil {
+		return err
+	}
+
+	// rename the generated TLS encryption X509 cert
+	err = os.Rename(filepath.Join(tlsDir, x509Filename(name)),
+		filepath.Join(tlsDir, tlsFilePrefix+"-enc.crt"))
+	if err != nil {
+		return err
+	}
+	err = keyExport(tlsDir, filepath.Join(tlsDir, tlsFilePrefix+"-enc.key"), tlsEncSKI)
 	if err != nil {
 		return err
 	}
@@ -205,19 +238,17 @@ func GenerateVerifyingMSP(
 		return err
 	}
 	// the signing CA certificate goes into cacerts
-	err = x509Export(
+	err = caExport(
 		filepath.Join(baseDir, "cacerts", x509Filename(signCA.Name)),
-		signCA.SignSm2Cert,
-		// signCA.SignCert,
+		signCA,
 	)
 	if err != nil {
 		return err
 	}
 	// the TLS CA certificate goes into tlscacerts
-	err = x509Export(
+	err = caExport(
 		filepath.Join(baseDir, "tlscacerts", x509Filename(tlsCA.Name)),
-		tlsCA.SignSm2Cert,
-		// tlsCA.SignCert,
+		tlsCA,
 	)
 	if err != nil {
 		return err
@@ -243,8 +274,7 @@ func GenerateVerifyingMSP(
 	if err != nil {
 		return errors.WithMessage(err, "failed to create keystore directory")
 	}
-	priv, err := csp.GeneratePrivateKey(ksDir)
-	sm2PubKey, err := csp.GetSM2PublicKey(priv)
+	_, pubKey, err := csp.GenerateKey(ksDir, signCA.KeyAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -253,7 +283,7 @@ func GenerateVerifyingMSP(
 		signCA.Name,
 		nil,
 		nil,
-		sm2PubKey,
+		pubKey,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -296,9 +326,17 @@ func x509Export(path string, cert *gmx509.Certificate) error {
 	return pemExport(path, "CERTIFICATE", cert.Raw)
 }
 
-func keyExport(keystore, output string, key bccsp.Key) error {
+func caExport(path string, ca *ca.CA) error {
+	raw := ca.Certificate()
+	if raw == nil {
+		return errors.Errorf("CA %s has no certificate", ca.Name)
+	}
+	return pemExport(path, "CERTIFICATE", raw)
+}
+
+func keyExport(keystore, output string, ski []byte) error {
 	// return os.Rename(filepath.Join(keystore, "priv_sk"), output)
-	id := hex.EncodeToString(key.SKI())
+	id := hex.EncodeToString(ski)
 	return os.Rename(filepath.Join(keystore, id+"_sk"), output)
 }
 
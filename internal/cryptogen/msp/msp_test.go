@@ -12,8 +12,10 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	"github.com/hyperledger/fabric/internal/cryptogen/msp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 )
@@ -32,7 +34,7 @@ const (
 
 var testDir = filepath.Join(os.TempDir(), "msp-test")
 
-func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
+func testGenerateLocalMSP(t *testing.T, nodeOUs bool, keyAlgorithm string) {
 	cleanup(testDir)
 
 	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, msp.PEER, nodeOUs)
@@ -44,24 +46,26 @@ func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 	tlsDir := filepath.Join(testDir, "tls")
 
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, keyAlgorithm)
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, keyAlgorithm)
 	assert.NoError(t, err, "Error generating CA")
 
-	assert.NotEmpty(t, signCA.SignCert.Subject.Country, "country cannot be empty.")
-	assert.Equal(t, testCountry, signCA.SignCert.Subject.Country[0], "Failed to match country")
-	assert.NotEmpty(t, signCA.SignCert.Subject.Province, "province cannot be empty.")
-	assert.Equal(t, testProvince, signCA.SignCert.Subject.Province[0], "Failed to match province")
-	assert.NotEmpty(t, signCA.SignCert.Subject.Locality, "locality cannot be empty.")
-	assert.Equal(t, testLocality, signCA.SignCert.Subject.Locality[0], "Failed to match locality")
-	assert.NotEmpty(t, signCA.SignCert.Subject.OrganizationalUnit, "organizationalUnit cannot be empty.")
-	assert.Equal(t, testOrganizationalUnit, signCA.SignCert.Subject.OrganizationalUnit[0], "Failed to match organizationalUnit")
-	assert.NotEmpty(t, signCA.SignCert.Subject.StreetAddress, "streetAddress cannot be empty.")
-	assert.Equal(t, testStreetAddress, signCA.SignCert.Subject.StreetAddress[0], "Failed to match streetAddress")
-	assert.NotEmpty(t, signCA.SignCert.Subject.PostalCode, "postalCode cannot be empty.")
-	assert.Equal(t, testPostalCode, signCA.SignCert.Subject.PostalCode[0], "Failed to match postalCode")
+	signCert, err := gmx509.ParseCertificate(signCA.Certificate())
+	assert.NoError(t, err, "Error parsing CA certificate")
+	assert.NotEmpty(t, signCert.Subject.Country, "country cannot be empty.")
+	assert.Equal(t, testCountry, signCert.Subject.Country[0], "Failed to match country")
+	assert.NotEmpty(t, signCert.Subject.Province, "province cannot be empty.")
+	assert.Equal(t, testProvince, signCert.Subject.Province[0], "Failed to match province")
+	assert.NotEmpty(t, signCert.Subject.Locality, "locality cannot be empty.")
+	assert.Equal(t, testLocality, signCert.Subject.Locality[0], "Failed to match locality")
+	assert.NotEmpty(t, signCert.Subject.OrganizationalUnit, "organizationalUnit cannot be empty.")
+	assert.Equal(t, testOrganizationalUnit, signCert.Subject.OrganizationalUnit[0], "Failed to match organizationalUnit")
+	assert.NotEmpty(t, signCert.Subject.StreetAddress, "streetAddress cannot be empty.")
+	assert.Equal(t, testStreetAddress, signCert.Subject.StreetAddress[0], "Failed to match streetAddress")
+	assert.NotEmpty(t, signCert.Subject.PostalCode, "postalCode cannot be empty.")
+	assert.Equal(t, testPostalCode, signCert.Subject.PostalCode[0], "Failed to match postalCode")
 
 	// generate local MSP for nodeType=PEER
 	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, nodeOUs)
@@ -85,6 +89,18 @@ func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 		filepath.Join(tlsDir, "server.key"),
 		filepath.Join(tlsDir, "server.crt"),
 	}
+	encFiles := []string{
+		filepath.Join(tlsDir, "server-enc.key"),
+		filepath.Join(tlsDir, "server-enc.crt"),
+	}
+	if csp.IsSM2(keyAlgorithm) {
+		tlsFiles = append(tlsFiles, encFiles...)
+	} else {
+		for _, file := range encFiles {
+			assert.Equal(t, false, checkForFile(file),
+				"Expected not to find file "+file)
+		}
+	}
 
 	for _, file := range mspFiles {
 		assert.Equal(t, true, checkForFile(file),
@@ -120,22 +136,27 @@ func testGenerateLocalMSP(t *testing.T, nodeOUs bool) {
 }
 
 func TestGenerateLocalMSPWithNodeOU(t *testing.T) {
-	testGenerateLocalMSP(t, true)
+	testGenerateLocalMSP(t, true, csp.SM2)
 }
 
 func TestGenerateLocalMSPWithoutNodeOU(t *testing.T) {
-	testGenerateLocalMSP(t, false)
+	testGenerateLocalMSP(t, false, csp.SM2)
 }
 
-func testGenerateVerifyingMSP(t *testing.T, nodeOUs bool) {
+func TestGenerateLocalMSPECDSA(t *testing.T) {
+	testGenerateLocalMSP(t, true, csp.ECDSAP256)
+	testGenerateLocalMSP(t, false, csp.ECDSAP384)
+}
+
+func testGenerateVerifyingMSP(t *testing.T, nodeOUs bool, keyAlgorithm string) {
 	caDir := filepath.Join(testDir, "ca")
 	tlsCADir := filepath.Join(testDir, "tlsca")
 	mspDir := filepath.Join(testDir, "msp")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, keyAlgorithm)
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, keyAlgorithm)
 	assert.NoError(t, err, "Error generating CA")
 
 	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, nodeOUs)
@@ -170,11 +191,16 @@ func testGenerateVerifyingMSP(t *testing.T, nodeOUs bool) {
 }
 
 func TestGenerateVerifyingMSPWithNodeOU(t *testing.T) {
-	testGenerateVerifyingMSP(t, true)
+	testGenerateVerifyingMSP(t, true, csp.SM2)
 }
 
 func TestGenerateVerifyingMSPWithoutNodeOU(t *testing.T) {
-	testGenerateVerifyingMSP(t, true)
+	testGenerateVerifyingMSP(t, true, csp.SM2)
+}
+
+func TestGenerateVerifyingMSPECDSA(t *testing.T) {
+	testGenerateVerifyingMSP(t, true, csp.ECDSAP256)
+	testGenerateVerifyingMSP(t, false, csp.ECDSAP256)
 }
 
 func TestExportConfig(t *testing.T) {
@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"fmt"
+	"net/http"
+
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+)
+
+// Fetch fetches a block of a channel from an OSN, as a marshaled protobuf. The block
+// is a block number, "newest" or "config".
+func Fetch(osnURL, channelID, block string, tlsConfig *tls.Config) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/blocks/%s", osnURL, channelID, block)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+
+	return httpDo(req, tlsConfig)
+}
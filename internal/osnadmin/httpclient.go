@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+)
+
+const dialTimeout = 10 * time.Second
+
+// httpClient returns a client for the admin endpoint of an orderer. The TLS connections
+// are established by the gmtls package, so that the client handshakes with admin
+// endpoints that serve SM2 certificates. A nil tlsConfig disables TLS.
+func httpClient(tlsConfig *tls.Config) *http.Client {
+	transport := &http.Transport{}
+	if tlsConfig != nil {
+		transport.DialTLS = func(network, addr string) (net.Conn, error) {
+			return tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, network, addr, tlsConfig.Clone())
+		}
+	}
+	return &http.Client{Transport: transport}
+}
+
+func httpDo(req *http.Request, tlsConfig *tls.Config) (*http.Response, error) {
+	client := httpClient(tlsConfig)
+	return client.Do(req)
+}
+
+func httpGet(url string, tlsConfig *tls.Config) (*http.Response, error) {
+	client := httpClient(tlsConfig)
+	return client.Get(url)
+}
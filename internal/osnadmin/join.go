@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+)
+
+// Join joins an OSN to the channel of the given config block.
+func Join(osnURL, channelID string, blockBytes []byte, tlsConfig *tls.Config) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s", osnURL, channelID)
+	req, err := createMultipartRequest(url, "config-block", "config.block", blockBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, tlsConfig)
+}
+
+// createMultipartRequest creates a POST request with a multipart/form-data body that carries a single file part.
+func createMultipartRequest(url, key, fileName string, fileBytes []byte) (*http.Request, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(key, fileName)
+	if err != nil {
+		return nil, err
+	}
+	part.Write(fileBytes)
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req, nil
+}
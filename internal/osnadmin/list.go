@@ -0,0 +1,28 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"fmt"
+	"net/http"
+
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+)
+
+// ListAllChannels lists all the channels that an OSN is a member of.
+func ListAllChannels(osnURL string, tlsConfig *tls.Config) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels", osnURL)
+
+	return httpGet(url, tlsConfig)
+}
+
+// ListSingleChannel lists the details of a single channel that an OSN is a member of.
+func ListSingleChannel(osnURL, channelID string, tlsConfig *tls.Config) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s", osnURL, channelID)
+
+	return httpGet(url, tlsConfig)
+}
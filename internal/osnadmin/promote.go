@@ -0,0 +1,28 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+)
+
+// Promote promotes a non-voting consenter (learner) of a channel, identified by
+// its endpoint (host:port), to a voting consenter.
+func Promote(osnURL, channelID, consenter string, tlsConfig *tls.Config) (*http.Response, error) {
+	query := url.Values{"consenter": []string{consenter}}
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/promote?%s", osnURL, channelID, query.Encode())
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, tlsConfig)
+}
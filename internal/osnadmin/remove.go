@@ -0,0 +1,25 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"fmt"
+	"net/http"
+
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+)
+
+// Remove removes an OSN from an existing channel.
+func Remove(osnURL, channelID string, tlsConfig *tls.Config) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s", osnURL, channelID)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, tlsConfig)
+}
@@ -0,0 +1,25 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"fmt"
+	"net/http"
+
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
+)
+
+// Update submits a signed config update envelope of a channel to an OSN.
+func Update(osnURL, channelID string, envelopeBytes []byte, tlsConfig *tls.Config) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/config-update", osnURL, channelID)
+	req, err := createMultipartRequest(url, "config-update", "config-update.pb", envelopeBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, tlsConfig)
+}
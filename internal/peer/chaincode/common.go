@@ -585,7 +585,21 @@ func ChaincodeInvokeOrQuery(
 		}
 	}
 
-	prop, txid, err := protoutil.CreateChaincodeProposalWithTxIDAndTransient(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator, txID, tMap)
+	// compute the txid with the hashing algorithm of the channel unless
+	// provided by tests
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error creating nonce for %s", funcName)
+	}
+	if txID == "" {
+		hashingAlgorithm, err := common.GetHashingAlgorithmOfChainFnc(cID, signer, endorserClients[0])
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error getting hashing algorithm of channel %s", cID)
+		}
+		txID = protoutil.ComputeTxIDWith(nonce, creator, hashingAlgorithm)
+	}
+
+	prop, txid, err := protoutil.CreateChaincodeProposalWithTxIDNonceAndTransient(txID, pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, nonce, creator, tMap)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "error creating proposal for %s", funcName)
 	}
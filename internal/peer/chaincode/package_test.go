@@ -16,6 +16,7 @@ import (
 	pcommon "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/hyperledger/fabric/msp"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
@@ -27,6 +28,9 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		panic(fmt.Sprintf("Fatal error when reading MSP config: %s", err))
 	}
+	common.GetHashingAlgorithmOfChainFnc = func(string, common.Signer, pb.EndorserClient) (func([]byte) []byte, error) {
+		return util.ComputeSHA256, nil
+	}
 
 	os.Exit(m.Run())
 }
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
@@ -25,26 +26,46 @@ func getinfoCmd(cf *ChannelCmdFactory) *cobra.Command {
 	getinfoCmd := &cobra.Command{
 		Use:   "getinfo",
 		Short: "get blockchain information of a specified channel.",
-		Long:  "get blockchain information of a specified channel. Requires '-c'.",
+		Long:  "get blockchain information of a specified channel. Requires '-c'. Use '--commit-hash' to also get the commit hash of the last committed block.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return getinfo(cmd, cf)
 		},
 	}
 	flagList := []string{
 		"channelID",
+		"commit-hash",
 	}
 	attachFlags(getinfoCmd, flagList)
 
 	return getinfoCmd
 }
 func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
+	payload, err := cc.queryQSCC([]byte(qscc.GetChainInfo), []byte(channelID))
+	if err != nil {
+		return nil, err
+	}
+
+	blockChainInfo := &cb.BlockchainInfo{}
+	err = proto.Unmarshal(payload, blockChainInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return blockChainInfo, nil
+}
+
+func (cc *endorserClient) getCommitHash(blockNum uint64) ([]byte, error) {
+	return cc.queryQSCC([]byte(qscc.GetCommitHash), []byte(channelID), []byte(strconv.FormatUint(blockNum, 10)))
+}
+
+func (cc *endorserClient) queryQSCC(args ...[]byte) ([]byte, error) {
 	var err error
 
 	invocation := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
 			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
 			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
-			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(qscc.GetChainInfo), []byte(channelID)}},
+			Input:       &pb.ChaincodeInput{Args: args},
 		},
 	}
 
@@ -70,14 +91,7 @@ func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
 		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
 	}
 
-	blockChainInfo := &cb.BlockchainInfo{}
-	err = proto.Unmarshal(proposalResp.Response.Payload, blockChainInfo)
-	if err != nil {
-		return nil, errors.Wrap(err, "cannot read qscc response")
-	}
-
-	return blockChainInfo, nil
-
+	return proposalResp.Response.Payload, nil
 }
 
 func getinfo(cmd *cobra.Command, cf *ChannelCmdFactory) error {
@@ -109,5 +123,16 @@ func getinfo(cmd *cobra.Command, cf *ChannelCmdFactory) error {
 
 	fmt.Printf("Blockchain info: %s\n", string(jsonBytes))
 
+	if !showCommitHash || blockChainInfo.Height == 0 {
+		return nil
+	}
+
+	lastBlockNum := blockChainInfo.Height - 1
+	commitHash, err := client.getCommitHash(lastBlockNum)
+	if err != nil {
+		return errors.WithMessagef(err, "cannot get the commit hash of block %d", lastBlockNum)
+	}
+	fmt.Printf("Commit hash of block %d: %x\n", lastBlockNum, commitHash)
+
 	return nil
 }
@@ -7,13 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestGetChannelInfo(t *testing.T) {
@@ -75,3 +79,80 @@ func TestGetChannelInfoMissingChannelID(t *testing.T) {
 
 	assert.Error(t, cmd.Execute())
 }
+
+// sequenceEndorserClient returns the responses in the order of the proposals received
+type sequenceEndorserClient struct {
+	responses []*pb.ProposalResponse
+	proposals []*pb.SignedProposal
+}
+
+func (s *sequenceEndorserClient) ProcessProposal(ctx context.Context, in *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	response := s.responses[len(s.proposals)]
+	s.proposals = append(s.proposals, in)
+	return response, nil
+}
+
+func TestGetChannelInfoWithCommitHash(t *testing.T) {
+	defer resetFlags()
+	InitMSP()
+	resetFlags()
+
+	mockPayload, err := proto.Marshal(&cb.BlockchainInfo{Height: 5})
+	require.NoError(t, err)
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	qsccArgs := func(signedProp *pb.SignedProposal) [][]byte {
+		prop, err := protoutil.UnmarshalProposal(signedProp.ProposalBytes)
+		require.NoError(t, err)
+		cpp, err := protoutil.UnmarshalChaincodeProposalPayload(prop.Payload)
+		require.NoError(t, err)
+		cis, err := protoutil.UnmarshalChaincodeInvocationSpec(cpp.Input)
+		require.NoError(t, err)
+		return cis.ChaincodeSpec.Input.Args
+	}
+
+	t.Run("success", func(t *testing.T) {
+		defer resetFlags()
+		endorserClient := &sequenceEndorserClient{
+			responses: []*pb.ProposalResponse{
+				{Response: &pb.Response{Status: 200, Payload: mockPayload}},
+				{Response: &pb.Response{Status: 200, Payload: []byte("commit-hash")}},
+			},
+		}
+		mockCF := &ChannelCmdFactory{
+			EndorserClient: endorserClient,
+			Signer:         signer,
+		}
+
+		cmd := getinfoCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", mockChannel, "--commit-hash"})
+		require.NoError(t, cmd.Execute())
+
+		require.Len(t, endorserClient.proposals, 2)
+		require.Equal(t, [][]byte{[]byte("GetChainInfo"), []byte(mockChannel)}, qsccArgs(endorserClient.proposals[0]))
+		require.Equal(t, [][]byte{[]byte("GetCommitHash"), []byte(mockChannel), []byte("4")}, qsccArgs(endorserClient.proposals[1]))
+	})
+
+	t.Run("commit hash not available", func(t *testing.T) {
+		defer resetFlags()
+		endorserClient := &sequenceEndorserClient{
+			responses: []*pb.ProposalResponse{
+				{Response: &pb.Response{Status: 200, Payload: mockPayload}},
+				{Response: &pb.Response{Status: 500, Message: "Commit hash is not available for block number 4"}},
+			},
+		}
+		mockCF := &ChannelCmdFactory{
+			EndorserClient: endorserClient,
+			Signer:         signer,
+		}
+
+		cmd := getinfoCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", mockChannel, "--commit-hash"})
+		cmd.SilenceErrors = true
+		require.EqualError(t, cmd.Execute(), "cannot get the commit hash of block 4: received bad response, status 500: Commit hash is not available for block number 4")
+	})
+}
@@ -80,7 +80,11 @@ func executeJoin(cf *ChannelCmdFactory) (err error) {
 	if err != nil {
 		return err
 	}
+	return executeJoinProposal(cf, spec)
+}
 
+// executeJoinProposal sends a proposal for the cscc join function in the spec to the peer
+func executeJoinProposal(cf *ChannelCmdFactory, spec *pb.ChaincodeSpec) (err error) {
 	// Build the ChaincodeInvocationSpec message
 	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
 
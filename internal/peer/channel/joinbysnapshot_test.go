@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingSnapshotPath(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+
+	cmd := joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+
+	require.EqualError(t, cmd.Execute(), "Must supply snapshot path")
+}
+
+func TestJoinBySnapshot(t *testing.T) {
+	defer resetFlags()
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/snapshots/completed/mychannel/1000"})
+	require.NoError(t, cmd.Execute())
+
+	spec := getJoinBySnapshotCCSpec()
+	require.Equal(t, "cscc", spec.ChaincodeId.Name)
+	require.Equal(t, [][]byte{[]byte("JoinChainBySnapshot"), []byte("/snapshots/completed/mychannel/1000")}, spec.Input.Args)
+}
+
+func TestJoinBySnapshotBadResponse(t *testing.T) {
+	defer resetFlags()
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 500, Message: "cannot create ledger from snapshot"},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/snapshots/completed/mychannel/1000"})
+
+	err = cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot create ledger from snapshot")
+}
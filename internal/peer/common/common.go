@@ -74,6 +74,12 @@ var (
 	GetOrdererEndpointOfChainFnc func(chainID string, signer Signer,
 		endorserClient pb.EndorserClient, cryptoProvider bccsp.BCCSP) ([]string, error)
 
+	// GetHashingAlgorithmOfChainFnc returns the hashing algorithm used for
+	// the transaction IDs of given chain
+	// by default it is set to GetHashingAlgorithmOfChain function
+	GetHashingAlgorithmOfChainFnc func(chainID string, signer Signer,
+		endorserClient pb.EndorserClient) (func(input []byte) []byte, error)
+
 	// GetCertificateFnc is a function that returns the client TLS certificate
 	GetCertificateFnc func() (tls.Certificate, error)
 )
@@ -89,6 +95,7 @@ func init() {
 	GetDefaultSignerFnc = GetDefaultSigner
 	GetBroadcastClientFnc = GetBroadcastClient
 	GetOrdererEndpointOfChainFnc = GetOrdererEndpointOfChain
+	GetHashingAlgorithmOfChainFnc = GetHashingAlgorithmOfChain
 	GetDeliverClientFnc = GetDeliverClient
 	GetPeerDeliverClientFnc = GetPeerDeliverClient
 	GetCertificateFnc = GetCertificate
@@ -175,6 +182,41 @@ type Signer interface {
 
 // GetOrdererEndpointOfChain returns orderer endpoints of given chain
 func GetOrdererEndpointOfChain(chainID string, signer Signer, endorserClient pb.EndorserClient, cryptoProvider bccsp.BCCSP) ([]string, error) {
+	block, err := getConfigBlock(chainID, signer, endorserClient)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeConfig, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error extracting config block envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(envelopeConfig, cryptoProvider)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error loading config block")
+	}
+
+	return bundle.ChannelConfig().OrdererAddresses(), nil
+}
+
+// GetHashingAlgorithmOfChain returns the hashing algorithm used for the
+// transaction IDs of given chain
+func GetHashingAlgorithmOfChain(chainID string, signer Signer, endorserClient pb.EndorserClient) (func(input []byte) []byte, error) {
+	block, err := getConfigBlock(chainID, signer, endorserClient)
+	if err != nil {
+		return nil, err
+	}
+
+	hashingAlgorithm, err := channelconfig.BlockHashingAlgorithmFromConfigBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error loading config block")
+	}
+
+	return hashingAlgorithm, nil
+}
+
+// getConfigBlock queries cscc for the config block of given chain
+func getConfigBlock(chainID string, signer Signer, endorserClient pb.EndorserClient) (*pcommon.Block, error) {
 	// query cscc for chain config block
 	invocation := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
@@ -218,16 +260,7 @@ func GetOrdererEndpointOfChain(chainID string, signer Signer, endorserClient pb.
 		return nil, errors.WithMessage(err, "error unmarshaling config block")
 	}
 
-	envelopeConfig, err := protoutil.ExtractEnvelope(block, 0)
-	if err != nil {
-		return nil, errors.WithMessage(err, "error extracting config block envelope")
-	}
-	bundle, err := channelconfig.NewBundleFromEnvelope(envelopeConfig, cryptoProvider)
-	if err != nil {
-		return nil, errors.WithMessage(err, "error loading config block")
-	}
-
-	return bundle.ChannelConfig().OrdererAddresses(), nil
+	return block, nil
 }
 
 // CheckLogLevel checks that a given log level string is valid
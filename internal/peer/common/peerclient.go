@@ -124,6 +124,15 @@ func (pc *PeerClient) PeerDeliver() (pb.DeliverClient, error) {
 	return pb.NewDeliverClient(conn), nil
 }
 
+// Snapshot returns a client for the Snapshot service
+func (pc *PeerClient) Snapshot() (pb.SnapshotClient, error) {
+	conn, err := pc.CommonClient.NewConnection(pc.Address, comm.ServerNameOverride(pc.sn))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "snapshot client failed to connect to %s", pc.Address)
+	}
+	return pb.NewSnapshotClient(conn), nil
+}
+
 // Certificate returns the TLS client certificate (if available)
 func (pc *PeerClient) Certificate() tls.Certificate {
 	return pc.CommonClient.Certificate()
@@ -191,3 +200,21 @@ func GetPeerDeliverClient(address, tlsRootCertFile string) (pb.DeliverClient, er
 	}
 	return peerClient.PeerDeliver()
 }
+
+// GetSnapshotClient returns a new snapshot client. If both the address and
+// tlsRootCertFile are not provided, the target values for the client are taken
+// from the configuration settings for "peer.address" and
+// "peer.tls.rootcert.file"
+func GetSnapshotClient(address, tlsRootCertFile string) (pb.SnapshotClient, error) {
+	var peerClient *PeerClient
+	var err error
+	if address != "" {
+		peerClient, err = NewPeerClientForAddress(address, tlsRootCertFile)
+	} else {
+		peerClient, err = NewPeerClientFromEnv()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.Snapshot()
+}
@@ -112,6 +112,13 @@ func TestPeerClient(t *testing.T) {
 	dClient, err = common.GetDeliverClient("", "")
 	assert.NoError(t, err)
 	assert.NotNil(t, dClient)
+
+	sClient, err := pClient1.Snapshot()
+	assert.NoError(t, err)
+	assert.NotNil(t, sClient)
+	sClient, err = common.GetSnapshotClient("", "")
+	assert.NoError(t, err)
+	assert.NotNil(t, sClient)
 }
 
 func TestPeerClientTimeout(t *testing.T) {
@@ -237,4 +244,8 @@ func TestGetClients_AddressError(t *testing.T) {
 	dClient, err := common.GetDeliverClient("peer0", "")
 	assert.Contains(t, err.Error(), "tls root cert file must be set")
 	assert.Nil(t, dClient)
+
+	sClient, err := common.GetSnapshotClient("peer0", "")
+	assert.Contains(t, err.Error(), "tls root cert file must be set")
+	assert.Nil(t, sClient)
 }
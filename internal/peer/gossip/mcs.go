@@ -13,6 +13,7 @@ import (
 
 	pcommon "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp"
+	commonbft "github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
@@ -32,6 +33,14 @@ type Hasher interface {
 	Hash(msg []byte, opts bccsp.HashOpts) (hash []byte, err error)
 }
 
+// BlockHashingAlgorithmGetter returns the hash function used for the block
+// hashes of the given channel.
+type BlockHashingAlgorithmGetter func(channelID string) func(input []byte) []byte
+
+// BFTConsentersGetter returns the consenters of the given channel if the
+// channel is ordered by the BFT consensus, and nil otherwise.
+type BFTConsentersGetter func(channelID string) ([]*commonbft.Consenter, error)
+
 // MSPMessageCryptoService implements the MessageCryptoService interface
 // using the peer MSPs (local and channel-related)
 //
@@ -42,29 +51,37 @@ type Hasher interface {
 // A similar mechanism needs to be in place to update the local MSP, as well.
 // This implementation assumes that these mechanisms are all in place and working.
 type MSPMessageCryptoService struct {
-	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
-	localSigner                identity.SignerSerializer
-	deserializer               mgmt.DeserializersManager
-	hasher                     Hasher
+	channelPolicyManagerGetter  policies.ChannelPolicyManagerGetter
+	blockHashingAlgorithmGetter BlockHashingAlgorithmGetter
+	bftConsentersGetter         BFTConsentersGetter
+	localSigner                 identity.SignerSerializer
+	deserializer                mgmt.DeserializersManager
+	hasher                      Hasher
 }
 
 // NewMCS creates a new instance of MSPMessageCryptoService
 // that implements MessageCryptoService.
 // The method takes in input:
 // 1. a policies.ChannelPolicyManagerGetter that gives access to the policy manager of a given channel via the Manager method.
-// 2. an instance of identity.SignerSerializer
-// 3. an identity deserializer manager
+// 2. a BlockHashingAlgorithmGetter, if nil SHA-256 is used for the block hashes of all channels
+// 3. a BFTConsentersGetter, if nil the blocks are verified against the block validation policy only
+// 4. an instance of identity.SignerSerializer
+// 5. an identity deserializer manager
 func NewMCS(
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter,
+	blockHashingAlgorithmGetter BlockHashingAlgorithmGetter,
+	bftConsentersGetter BFTConsentersGetter,
 	localSigner identity.SignerSerializer,
 	deserializer mgmt.DeserializersManager,
 	hasher Hasher,
 ) *MSPMessageCryptoService {
 	return &MSPMessageCryptoService{
-		channelPolicyManagerGetter: channelPolicyManagerGetter,
-		localSigner:                localSigner,
-		deserializer:               deserializer,
-		hasher:                     hasher,
+		channelPolicyManagerGetter:  channelPolicyManagerGetter,
+		blockHashingAlgorithmGetter: blockHashingAlgorithmGetter,
+		bftConsentersGetter:         bftConsentersGetter,
+		localSigner:                 localSigner,
+		deserializer:                deserializer,
+		hasher:                      hasher,
 	}
 }
 
@@ -153,7 +170,7 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 
 	// - Verify that Header.DataHash is equal to the hash of block.Data
 	// This is to ensure that the header is consistent with the data carried by this block
-	if !bytes.Equal(protoutil.BlockDataHash(block.Data), block.Header.DataHash) {
+	if !bytes.Equal(protoutil.BlockDataHashWith(block.Data, s.blockHashingAlgorithm(channelID)), block.Header.DataHash) {
 		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
 	}
 
@@ -189,7 +206,47 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 	}
 
 	// - Evaluate policy
-	return policy.EvaluateSignedData(signatureSet)
+	if err := policy.EvaluateSignedData(signatureSet); err != nil {
+		return err
+	}
+
+	// - Verify that a quorum of the consenters signed the block if the channel is ordered by BFT
+	return s.verifyBFTQuorum(channelID, block)
+}
+
+func (s *MSPMessageCryptoService) verifyBFTQuorum(channelID string, block *pcommon.Block) error {
+	if s.bftConsentersGetter == nil {
+		return nil
+	}
+	consenters, err := s.bftConsentersGetter(channelID)
+	if err != nil {
+		return fmt.Errorf("Failed getting the BFT consenters of channel [%s]: [%s]", channelID, err)
+	}
+	if consenters == nil {
+		return nil
+	}
+
+	deserializer, exists := s.deserializer.GetChannelDeserializers()[channelID]
+	if !exists {
+		return fmt.Errorf("Could not acquire the identity deserializer of channel [%s]", channelID)
+	}
+	return commonbft.VerifyBlockSignatures(block, consenters, func(sd *protoutil.SignedData) error {
+		identity, err := deserializer.DeserializeIdentity(sd.Identity)
+		if err != nil {
+			return err
+		}
+		if err := identity.Validate(); err != nil {
+			return err
+		}
+		return identity.Verify(sd.Data, sd.Signature)
+	})
+}
+
+func (s *MSPMessageCryptoService) blockHashingAlgorithm(channelID string) func(input []byte) []byte {
+	if s.blockHashingAlgorithmGetter == nil {
+		return util.ComputeSHA256
+	}
+	return s.blockHashingAlgorithmGetter(channelID)
 }
 
 // Sign signs msg with this peer's signing key and outputs
@@ -19,6 +19,7 @@ import (
 	pmsp "github.com/hyperledger/fabric-protos-go/msp"
 	protospeer "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	commonbft "github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -29,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 //go:generate counterfeiter -o mocks/policy_manager.go -fake-name PolicyManager . policyManager
@@ -53,6 +55,8 @@ func TestPKIidOfCert(t *testing.T) {
 	assert.NoError(t, err)
 	msgCryptoService := NewMCS(
 		&mocks.ChannelPolicyManagerGetterWithManager{},
+		nil,
+		nil,
 		signer,
 		deserializersManager,
 		cryptoProvider,
@@ -89,7 +93,7 @@ func TestPKIidOfNil(t *testing.T) {
 	signer := &mocks.SignerSerializer{}
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, nil, nil, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
 
 	pkid := msgCryptoService.GetPKIidOfCert(nil)
 	// Check pkid is not nil
@@ -109,6 +113,8 @@ func TestValidateIdentity(t *testing.T) {
 	assert.NoError(t, err)
 	msgCryptoService := NewMCS(
 		&mocks.ChannelPolicyManagerGetterWithManager{},
+		nil,
+		nil,
 		signer,
 		deserializersManager,
 		cryptoProvider,
@@ -146,6 +152,8 @@ func TestSign(t *testing.T) {
 
 	msgCryptoService := NewMCS(
 		&mocks.ChannelPolicyManagerGetter{},
+		nil,
+		nil,
 		signer,
 		mgmt.NewDeserializersManager(cryptoProvider),
 		cryptoProvider,
@@ -175,6 +183,8 @@ func TestVerify(t *testing.T) {
 				"C": nil,
 			},
 		},
+		nil,
+		nil,
 		signer,
 		&mocks.DeserializersManager{
 			LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
@@ -232,8 +242,18 @@ func TestVerifyBlock(t *testing.T) {
 
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
+	hashingAlgorithms := map[string]func([]byte) []byte{}
+	blockHashingAlgorithmGetter := func(channelID string) func([]byte) []byte {
+		if hashingAlgorithm, ok := hashingAlgorithms[channelID]; ok {
+			return hashingAlgorithm
+		}
+		return util.ComputeSHA256
+	}
+
 	msgCryptoService := NewMCS(
 		policyManagerGetter,
+		blockHashingAlgorithmGetter,
+		nil,
 		aliceSigner,
 		&mocks.DeserializersManager{
 			LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
@@ -271,10 +291,69 @@ func TestVerifyBlock(t *testing.T) {
 	// - Verify block
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw))
 
+	// - Prepare testing block hashed with SHA-256 on a channel hashing with SM3, Alice signs it.
+	blockRaw, msg = mockBlock(t, "C", 42, aliceSigner, nil)
+	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw))
+	hashingAlgorithms["C"] = util.ComputeGMSM3
+
+	// - Verify block
+	err = msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Header.DataHash is different from Hash(block.Data)")
+
 	// Check invalid args
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, &common.Block{}))
 }
 
+func TestVerifyBlockBFT(t *testing.T) {
+	aliceSigner := &mocks.SignerSerializer{}
+	aliceSigner.SerializeReturns([]byte("Alice"), nil)
+	policyManagerGetter := &mocks.ChannelPolicyManagerGetterWithManager{
+		Managers: map[string]policies.Manager{
+			"C": &mocks.ChannelPolicyManager{
+				Policy: &mocks.Policy{Deserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}}},
+			},
+		},
+	}
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	var consenters []*commonbft.Consenter
+	var consentersErr error
+	bftConsentersGetter := func(channelID string) ([]*commonbft.Consenter, error) {
+		return consenters, consentersErr
+	}
+	msgCryptoService := NewMCS(
+		policyManagerGetter,
+		nil,
+		bftConsentersGetter,
+		aliceSigner,
+		&mocks.DeserializersManager{
+			LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
+			ChannelDeserializers: map[string]msp.IdentityDeserializer{
+				"C": &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
+			},
+		},
+		cryptoProvider,
+	)
+
+	blockRaw, msg := mockBlock(t, "C", 42, aliceSigner, nil)
+	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
+
+	// The channel is not ordered by BFT
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw))
+
+	// The block is not signed by a quorum of the consenters
+	consenters = []*commonbft.Consenter{{Id: 1, MspId: "OrdererMSP", Identity: []byte("-----BEGIN CERTIFICATE-----\nAQID\n-----END CERTIFICATE-----\n")}}
+	err = msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw)
+	assert.EqualError(t, err, "block [42] is signed by 0 of the 1 consenters of the channel, while a quorum of 1 is required")
+
+	consentersErr = errors.New("bad metadata")
+	err = msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw)
+	assert.EqualError(t, err, "Failed getting the BFT consenters of channel [C]: [bad metadata]")
+}
+
 func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner *mocks.SignerSerializer, dataHash []byte) (*common.Block, []byte) {
 	block := protoutil.NewBlock(seqNum, nil)
 
@@ -357,6 +436,8 @@ func TestExpiration(t *testing.T) {
 	assert.NoError(t, err)
 	msgCryptoService := NewMCS(
 		&mocks.ChannelPolicyManagerGetterWithManager{},
+		nil,
+		nil,
 		&mocks.SignerSerializer{},
 		deserializersManager,
 		cryptoProvider,
@@ -46,11 +46,24 @@ func ledgerConfig() *ledger.Config {
 		deprioritizedDataReconcilerInterval = viper.GetDuration("ledger.pvtdataStore.deprioritizedDataReconcilerInterval")
 	}
 
+	blockfileCompression := "none"
+	if viper.IsSet("ledger.blockchain.compression") {
+		blockfileCompression = viper.GetString("ledger.blockchain.compression")
+	}
+	archiveRetentionBlocks := uint64(10000)
+	if viper.IsSet("ledger.blockchain.archive.retentionBlocks") {
+		archiveRetentionBlocks = uint64(viper.GetInt("ledger.blockchain.archive.retentionBlocks"))
+	}
+
 	rootFSPath := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "ledgersData")
 	snapshotsRootDir := viper.GetString("ledger.snapshots.rootDir")
 	if snapshotsRootDir == "" {
 		snapshotsRootDir = filepath.Join(rootFSPath, "snapshots")
 	}
+	archiveDir := viper.GetString("ledger.blockchain.archive.archiveDir")
+	if archiveDir == "" {
+		archiveDir = filepath.Join(rootFSPath, "archive")
+	}
 	conf := &ledger.Config{
 		RootFSPath: rootFSPath,
 		StateDBConfig: &ledger.StateDBConfig{
@@ -69,6 +82,17 @@ func ledgerConfig() *ledger.Config {
 		SnapshotsConfig: &ledger.SnapshotsConfig{
 			RootDir: snapshotsRootDir,
 		},
+		BlockStoreConfig: &ledger.BlockStoreConfig{
+			Compression:          blockfileCompression,
+			IndexChaincodeEvents: viper.GetBool("ledger.blockchain.index.chaincodeEvents"),
+			IndexBlockTimestamps: viper.GetBool("ledger.blockchain.index.blockTimestamps"),
+		},
+		BlockArchiveConfig: &ledger.BlockArchiveConfig{
+			Enabled:         viper.GetBool("ledger.blockchain.archive.enabled"),
+			ArchiveDir:      archiveDir,
+			RetentionBlocks: archiveRetentionBlocks,
+			Compress:        viper.GetBool("ledger.blockchain.archive.compress"),
+		},
 	}
 
 	if conf.StateDBConfig.StateDatabase == "CouchDB" {
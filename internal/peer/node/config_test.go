@@ -46,6 +46,17 @@ func TestLedgerConfig(t *testing.T) {
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/ledgersData/snapshots",
 				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					Compression:          "none",
+					IndexChaincodeEvents: false,
+					IndexBlockTimestamps: false,
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled:         false,
+					ArchiveDir:      "/peerfs/ledgersData/archive",
+					RetentionBlocks: 10000,
+					Compress:        false,
+				},
 			},
 		},
 		{
@@ -93,6 +104,17 @@ func TestLedgerConfig(t *testing.T) {
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/ledgersData/snapshots",
 				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					Compression:          "none",
+					IndexChaincodeEvents: false,
+					IndexBlockTimestamps: false,
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled:         false,
+					ArchiveDir:      "/peerfs/ledgersData/archive",
+					RetentionBlocks: 10000,
+					Compress:        false,
+				},
 			},
 		},
 		{
@@ -117,6 +139,13 @@ func TestLedgerConfig(t *testing.T) {
 				"ledger.pvtdataStore.deprioritizedDataReconcilerInterval": "180m",
 				"ledger.history.enableHistoryDatabase":                    true,
 				"ledger.snapshots.rootDir":                                "/peerfs/snapshots",
+				"ledger.blockchain.compression":                           "snappy",
+				"ledger.blockchain.index.chaincodeEvents":                 true,
+				"ledger.blockchain.index.blockTimestamps":                 true,
+				"ledger.blockchain.archive.enabled":                       true,
+				"ledger.blockchain.archive.archiveDir":                    "/archive",
+				"ledger.blockchain.archive.retentionBlocks":               500,
+				"ledger.blockchain.archive.compress":                      true,
 			},
 			expected: &ledger.Config{
 				RootFSPath: "/peerfs/ledgersData",
@@ -149,6 +178,17 @@ func TestLedgerConfig(t *testing.T) {
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/snapshots",
 				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					Compression:          "snappy",
+					IndexChaincodeEvents: true,
+					IndexBlockTimestamps: true,
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled:         true,
+					ArchiveDir:      "/archive",
+					RetentionBlocks: 500,
+					Compress:        true,
+				},
 			},
 		},
 	}
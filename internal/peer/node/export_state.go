@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateNamespaces  []string
+	stateArchiveFile string
+)
+
+func exportStateCmd() *cobra.Command {
+	nodeExportStateCmd.ResetFlags()
+	flags := nodeExportStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel whose state is exported.")
+	flags.StringSliceVarP(&stateNamespaces, "namespace", "n", nil, "Namespace whose state is exported. If not specified, the state of all the namespaces is exported. An archive of selected namespaces cannot be imported.")
+	flags.StringVarP(&stateArchiveFile, "output", "o", "", "File to which the state archive is written. If not specified, the archive is written to the standard output.")
+
+	return nodeExportStateCmd
+}
+
+var nodeExportStateCmd = &cobra.Command{
+	Use:   "export-state",
+	Short: "Exports the state of a channel.",
+	Long:  `Exports the public state and the hashes of the private state of a channel as a state archive, which is a stream of JSON lines that starts with a header including the savepoint of the state and ends with a trailer including the number of the entries and a SHA-256 checksum. The archive can be imported by a peer with either type of state database. The private data itself is not exported. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+
+		var w io.Writer = cmd.OutOrStdout()
+		if stateArchiveFile != "" {
+			f, err := os.Create(stateArchiveFile)
+			if err != nil {
+				return errors.Wrap(err, "error while creating the state archive file")
+			}
+			defer f.Close()
+			w = f
+		}
+
+		config := ledgerConfig()
+		numRecords, err := kvledger.ExportState(config, channelID, stateNamespaces, w)
+		if err != nil {
+			return err
+		}
+		logger.Infof("Exported [%d] entries of the state of the channel [%s]", numRecords, channelID)
+		return nil
+	},
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportStateCmd(t *testing.T) {
+	testPath := "/tmp/hyperledger/test"
+	os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer os.RemoveAll(testPath)
+
+	t.Run("when the channelID is not specified", func(t *testing.T) {
+		cmd := exportStateCmd()
+		cmd.SetArgs([]string{})
+		require.EqualError(t, cmd.Execute(), "Must supply channel ID")
+	})
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		cmd := exportStateCmd()
+		cmd.SetArgs([]string{"-c", "ch_e", "-n", "ns1", "-n", "ns2"})
+		require.EqualError(t, cmd.Execute(), "ledger [ch_e] does not exist")
+		require.Equal(t, []string{"ns1", "ns2"}, stateNamespaces)
+	})
+}
+
+func TestImportStateCmd(t *testing.T) {
+	testPath := "/tmp/hyperledger/test"
+	os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer os.RemoveAll(testPath)
+
+	t.Run("when the channelID is not specified", func(t *testing.T) {
+		cmd := importStateCmd()
+		cmd.SetArgs([]string{})
+		require.EqualError(t, cmd.Execute(), "Must supply channel ID")
+	})
+
+	t.Run("when the input file does not exist", func(t *testing.T) {
+		cmd := importStateCmd()
+		cmd.SetArgs([]string{"-c", "ch_i", "-i", "/tmp/hyperledger/test/non-existent-file"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while opening the state archive file")
+	})
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		archiveFile := "/tmp/hyperledger/test/archive.jsonl"
+		require.NoError(t, os.MkdirAll("/tmp/hyperledger/test", 0755))
+		require.NoError(t, ioutil.WriteFile(archiveFile, []byte{}, 0644))
+		cmd := importStateCmd()
+		cmd.SetArgs([]string{"-c", "ch_i", "-i", archiveFile})
+		require.EqualError(t, cmd.Execute(), "ledger [ch_i] does not exist")
+	})
+}
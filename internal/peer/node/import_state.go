@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func importStateCmd() *cobra.Command {
+	nodeImportStateCmd.ResetFlags()
+	flags := nodeImportStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel whose state is imported.")
+	flags.StringVarP(&stateArchiveFile, "input", "i", "", "File from which the state archive is read. If not specified, the archive is read from the standard input.")
+
+	return nodeImportStateCmd
+}
+
+var nodeImportStateCmd = &cobra.Command{
+	Use:   "import-state",
+	Short: "Imports the state of a channel.",
+	Long:  `Imports a state archive, produced by the export-state command, into the state database of a channel, of the type configured for the peer. The state database of the channel must be empty, e.g., after the rebuild-dbs command or after the state database type is switched. The private state is restored from the private data store of the peer. When the peer starts after the import, it applies the blocks committed after the savepoint of the archive. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+
+		var r io.Reader = os.Stdin
+		if stateArchiveFile != "" {
+			f, err := os.Open(stateArchiveFile)
+			if err != nil {
+				return errors.Wrap(err, "error while opening the state archive file")
+			}
+			defer f.Close()
+			r = f
+		}
+
+		config := ledgerConfig()
+		numRecords, err := kvledger.ImportState(config, channelID, r)
+		if err != nil {
+			return err
+		}
+		logger.Infof("Imported [%d] entries into the state of the channel [%s]", numRecords, channelID)
+		return nil
+	},
+}
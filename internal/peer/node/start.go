@@ -67,6 +67,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -165,6 +166,7 @@ func (e endorserChannelAdapter) Channel(channelID string) *endorser.Channel {
 	if peerChannel := e.peer.Channel(channelID); peerChannel != nil {
 		return &endorser.Channel{
 			IdentityDeserializer: peerChannel.MSPManager(),
+			HashingAlgorithm:     peerChannel.BlockHashingAlgorithm(),
 		}
 	}
 
@@ -277,6 +279,15 @@ func serve(args []string) error {
 			logger.Fatalf("Failed to set TLS client certificate (%s)", err)
 		}
 		cs.SetClientCertificate(clientCert)
+
+		// set the GM TLS encryption cert if one is configured
+		clientEncCert, err := peer.GetClientEncCertificate()
+		if err != nil {
+			logger.Fatalf("Failed to set TLS client encryption certificate (%s)", err)
+		}
+		if clientEncCert != nil {
+			cs.SetClientEncCertificate(*clientEncCert)
+		}
 	}
 
 	transientStoreProvider, err := transientstore.NewStoreProvider(
@@ -452,6 +463,8 @@ func serve(args []string) error {
 	// of go routines and registration with the grpc server.
 	gossipService, err := initGossipService(
 		policyMgr,
+		peerInstance.GetBlockHashingAlgorithm,
+		peerInstance.GetBFTConsenters,
 		metricsProvider,
 		peerServer,
 		signingIdentity,
@@ -874,6 +887,10 @@ func serve(args []string) error {
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
 
+	// Register the snapshot server
+	snapshotSvc := &snapshotgrpc.Snapshot{LedgerGetter: peerInstance, ACLProvider: aclProvider}
+	pb.RegisterSnapshotServer(peerServer.Server(), snapshotSvc)
+
 	go func() {
 		var grpcErr error
 		if grpcErr = peerServer.Start(); grpcErr != nil {
@@ -1157,6 +1174,8 @@ func secureDialOpts(credSupport *comm.CredentialSupport) func() []grpc.DialOptio
 // 4. Init gossip related struct.
 func initGossipService(
 	policyMgr policies.ChannelPolicyManagerGetter,
+	blockHashingAlgorithmGetter peergossip.BlockHashingAlgorithmGetter,
+	bftConsentersGetter peergossip.BFTConsentersGetter,
 	metricsProvider metrics.Provider,
 	peerServer *comm.GRPCServer,
 	signer msp.SigningIdentity,
@@ -1181,6 +1200,8 @@ func initGossipService(
 
 	messageCryptoService := peergossip.NewMCS(
 		policyMgr,
+		blockHashingAlgorithmGetter,
+		bftConsentersGetter,
 		signer,
 		mgmt.NewDeserializersManager(factory.GetDefault()),
 		factory.GetDefault(),
@@ -1231,6 +1252,8 @@ func newOperationsSystem(coreConfig *peer.Config) *operations.System {
 			Enabled:            coreConfig.OperationsTLSEnabled,
 			CertFile:           coreConfig.OperationsTLSCertFile,
 			KeyFile:            coreConfig.OperationsTLSKeyFile,
+			EncCertFile:        coreConfig.OperationsTLSEncCertFile,
+			EncKeyFile:         coreConfig.OperationsTLSEncKeyFile,
 			ClientCertRequired: coreConfig.OperationsTLSClientAuthRequired,
 			ClientCACertFiles:  coreConfig.OperationsTLSClientRootCAs,
 		},
@@ -18,7 +18,7 @@ func upgradeDBsCmd() *cobra.Command {
 var nodeUpgradeDBsCmd = &cobra.Command{
 	Use:   "upgrade-dbs",
 	Short: "Upgrades databases.",
-	Long:  "Upgrades databases by directly updating the database format or dropping the databases. Dropped databases will be rebuilt with new format upon peer restart. When the command is executed, the peer must be offline.",
+	Long:  "Upgrades databases by directly updating the database format or dropping the databases. Dropped databases will be rebuilt with new format upon peer restart. The block files are rewritten with the compression configured in ledger.blockchain.compression. When the command is executed, the peer must be offline.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		config := ledgerConfig()
 		return kvledger.UpgradeDBs(config)
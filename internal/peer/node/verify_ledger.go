@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func verifyLedgerCmd() *cobra.Command {
+	nodeVerifyLedgerCmd.ResetFlags()
+	flags := nodeVerifyLedgerCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to verify. If not specified, all the channels on the peer are verified.")
+
+	return nodeVerifyLedgerCmd
+}
+
+var nodeVerifyLedgerCmd = &cobra.Command{
+	Use:   "verify-ledger",
+	Short: "Verifies the integrity of the ledgers.",
+	Long:  `Verifies the integrity of the ledger of a channel, or of all the channels on the peer. The hash chain and the data hashes of the blocks are checked and the block index, the state database, and the private data are cross-checked against the blocks. A report of the issues found, including the first bad block, is printed in JSON format. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var ledgerIDs []string
+		if channelID != common.UndefinedParamValue {
+			ledgerIDs = []string{channelID}
+		}
+
+		config := ledgerConfig()
+		reports, err := kvledger.VerifyLedgers(config, ledgerIDs)
+		if err != nil {
+			return err
+		}
+		if reports == nil {
+			reports = []*kvledger.VerificationReport{}
+		}
+		reportJSON, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "error while marshaling the verification report")
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(reportJSON))
+
+		var failedLedgerIDs []string
+		for _, r := range reports {
+			if len(r.Issues) > 0 {
+				failedLedgerIDs = append(failedLedgerIDs, r.LedgerID)
+			}
+		}
+		if len(failedLedgerIDs) > 0 {
+			// the usage is not relevant to the failure
+			cmd.SilenceUsage = true
+			return errors.Errorf("issues found in the ledgers %v", failedLedgerIDs)
+		}
+		return nil
+	},
+}
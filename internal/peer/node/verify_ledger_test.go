@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLedgerCmd(t *testing.T) {
+	testPath := "/tmp/hyperledger/test"
+	os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer os.RemoveAll(testPath)
+
+	t.Run("when the peer has no channels", func(t *testing.T) {
+		cmd := verifyLedgerCmd()
+		buffer := &bytes.Buffer{}
+		cmd.SetOutput(buffer)
+		cmd.SetArgs([]string{})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "[]\n", buffer.String())
+	})
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		cmd := verifyLedgerCmd()
+		cmd.SetArgs([]string{"-c", "ch_v"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while verifying the ledger [ch_v]")
+	})
+}
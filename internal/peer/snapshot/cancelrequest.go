@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// cancelRequestCmd returns the cobra command for snapshot cancelrequest command
+func cancelRequestCmd(cl *client) *cobra.Command {
+	snapshotCancelRequestCmd := &cobra.Command{
+		Use:   "cancelrequest",
+		Short: "Cancel a pending request for a snapshot at the specified block.",
+		Long:  "Cancel a pending request for a snapshot at the specified block.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cancelRequest(cmd, cl)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"blockNumber",
+		"peerAddress",
+		"tlsRootCertFile",
+	}
+	attachFlags(snapshotCancelRequestCmd, flagList)
+
+	return snapshotCancelRequestCmd
+}
+
+func cancelRequest(cmd *cobra.Command, cl *client) error {
+	if err := validatePeerConnectionParameters(); err != nil {
+		return err
+	}
+	if blockNumber == 0 {
+		return errors.New("the required parameter 'blockNumber' is empty or set to 0. Rerun the command with -b flag")
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cl == nil {
+		var err error
+		cl, err = newClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	signatureHdr, err := cl.signatureHeader()
+	if err != nil {
+		return err
+	}
+
+	request := &pb.SnapshotRequest{
+		SignatureHeader: signatureHdr,
+		ChannelId:       channelID,
+		BlockNumber:     blockNumber,
+	}
+	signedRequest, err := cl.signRequest(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = cl.snapshotClient.Cancel(context.Background(), signedRequest)
+	if err != nil {
+		return errors.WithMessage(err, "failed to cancel the request")
+	}
+
+	fmt.Fprint(cl.writer, "Snapshot request cancelled successfully\n")
+	return nil
+}
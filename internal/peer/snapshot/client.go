@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// client holds the dependencies needed to send a request to the
+// snapshot service of a peer
+type client struct {
+	snapshotClient pb.SnapshotClient
+	signer         identity.SignerSerializer
+	writer         io.Writer
+}
+
+func newClient() (*client, error) {
+	snapshotClient, err := common.GetSnapshotClient(peerAddress, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve snapshot client")
+	}
+
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve default signer")
+	}
+
+	return &client{
+		snapshotClient: snapshotClient,
+		signer:         signer,
+		writer:         os.Stdout,
+	}, nil
+}
+
+func (c *client) signatureHeader() (*cb.SignatureHeader, error) {
+	creator, err := c.signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create nonce")
+	}
+
+	return &cb.SignatureHeader{
+		Creator: creator,
+		Nonce:   nonce,
+	}, nil
+}
+
+// signRequest marshals and signs a SnapshotRequest or a SnapshotQuery
+func (c *client) signRequest(request proto.Message) (*pb.SignedSnapshotRequest, error) {
+	requestBytes, err := proto.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal snapshot request")
+	}
+
+	signature, err := c.signer.Sign(requestBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign snapshot request")
+	}
+
+	return &pb.SignedSnapshotRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	}, nil
+}
+
+func validatePeerConnectionParameters() error {
+	if channelID == "" {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	}
+	if peerAddress == "" {
+		return errors.New("the required parameter 'peerAddress' is empty. Rerun the command with --peerAddress flag")
+	}
+	return nil
+}
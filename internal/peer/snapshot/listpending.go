@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// listPendingCmd returns the cobra command for snapshot listpending command
+func listPendingCmd(cl *client) *cobra.Command {
+	snapshotListPendingCmd := &cobra.Command{
+		Use:   "listpending",
+		Short: "List the block numbers of the pending snapshot requests.",
+		Long:  "List the block numbers of the pending snapshot requests.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listPending(cmd, cl)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+	}
+	attachFlags(snapshotListPendingCmd, flagList)
+
+	return snapshotListPendingCmd
+}
+
+func listPending(cmd *cobra.Command, cl *client) error {
+	if err := validatePeerConnectionParameters(); err != nil {
+		return err
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cl == nil {
+		var err error
+		cl, err = newClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	signatureHdr, err := cl.signatureHeader()
+	if err != nil {
+		return err
+	}
+
+	query := &pb.SnapshotQuery{
+		SignatureHeader: signatureHdr,
+		ChannelId:       channelID,
+	}
+	signedRequest, err := cl.signRequest(query)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cl.snapshotClient.QueryPendings(context.Background(), signedRequest)
+	if err != nil {
+		return errors.WithMessage(err, "failed to list pending snapshot requests")
+	}
+
+	fmt.Fprintf(cl.writer, "Successfully got pending snapshot requests: %v\n", resp.BlockNumbers)
+	return nil
+}
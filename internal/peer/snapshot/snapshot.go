@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	snapshotFuncName = "snapshot"
+	snapshotCmdDes   = "Manage the snapshot requests of a peer: submitrequest|cancelrequest|listpending."
+)
+
+var logger = flogging.MustGetLogger("cli.snapshot")
+
+// Cmd returns the cobra command for Snapshot
+func Cmd() *cobra.Command {
+	snapshotCmd.AddCommand(submitRequestCmd(nil))
+	snapshotCmd.AddCommand(cancelRequestCmd(nil))
+	snapshotCmd.AddCommand(listPendingCmd(nil))
+	return snapshotCmd
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:              snapshotFuncName,
+	Short:            fmt.Sprint(snapshotCmdDes),
+	Long:             fmt.Sprint(snapshotCmdDes),
+	PersistentPreRun: common.InitCmd,
+}
+
+// Snapshot-related variables.
+var (
+	channelID       string
+	blockNumber     uint64
+	peerAddress     string
+	tlsRootCertFile string
+)
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// resetFlags resets the values of these flags to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channelID", "c", "", "The channel on which this command should be executed")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "The block number for which a snapshot will be generated. 0 means the last committed block")
+	flags.StringVarP(&peerAddress, "peerAddress", "", "", "The address of the peer to connect to")
+	flags.StringVarP(&tlsRootCertFile, "tlsRootCertFile", "", "",
+		"The path to the TLS root cert file of the peer to connect to, required if TLS is enabled and ignored if TLS is disabled")
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/peer/snapshot/mock"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/snapshot_client.go -fake-name SnapshotClient . snapshotClient
+type snapshotClient interface {
+	pb.SnapshotClient
+}
+
+//go:generate counterfeiter -o mock/signer_serializer.go -fake-name SignerSerializer . signerSerializer
+type signerSerializer interface {
+	identity.SignerSerializer
+}
+
+func newTestClient() (*client, *mock.SnapshotClient, *mock.SignerSerializer, *bytes.Buffer) {
+	fakeSnapshotClient := &mock.SnapshotClient{}
+	fakeSigner := &mock.SignerSerializer{}
+	fakeSigner.SerializeReturns([]byte("creator"), nil)
+	fakeSigner.SignReturns([]byte("signature"), nil)
+	buffer := &bytes.Buffer{}
+	return &client{snapshotClient: fakeSnapshotClient, signer: fakeSigner, writer: buffer}, fakeSnapshotClient, fakeSigner, buffer
+}
+
+func TestSubmitRequest(t *testing.T) {
+	defer resetFlags()
+	cl, fakeSnapshotClient, _, buffer := newTestClient()
+
+	cmd := submitRequestCmd(cl)
+	cmd.SetArgs([]string{"-c", "mychannel", "-b", "100", "--peerAddress", "localhost:7051"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Snapshot request submitted successfully\n", buffer.String())
+
+	require.Equal(t, 1, fakeSnapshotClient.GenerateCallCount())
+	_, signedRequest, _ := fakeSnapshotClient.GenerateArgsForCall(0)
+	require.Equal(t, []byte("signature"), signedRequest.Signature)
+	request := &pb.SnapshotRequest{}
+	require.NoError(t, proto.Unmarshal(signedRequest.Request, request))
+	require.Equal(t, "mychannel", request.ChannelId)
+	require.Equal(t, uint64(100), request.BlockNumber)
+	require.Equal(t, []byte("creator"), request.SignatureHeader.Creator)
+	require.NotEmpty(t, request.SignatureHeader.Nonce)
+}
+
+func TestCancelRequest(t *testing.T) {
+	defer resetFlags()
+	cl, fakeSnapshotClient, _, buffer := newTestClient()
+
+	cmd := cancelRequestCmd(cl)
+	cmd.SetArgs([]string{"-c", "mychannel", "-b", "100", "--peerAddress", "localhost:7051"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Snapshot request cancelled successfully\n", buffer.String())
+
+	require.Equal(t, 1, fakeSnapshotClient.CancelCallCount())
+	_, signedRequest, _ := fakeSnapshotClient.CancelArgsForCall(0)
+	request := &pb.SnapshotRequest{}
+	require.NoError(t, proto.Unmarshal(signedRequest.Request, request))
+	require.Equal(t, "mychannel", request.ChannelId)
+	require.Equal(t, uint64(100), request.BlockNumber)
+}
+
+func TestListPending(t *testing.T) {
+	defer resetFlags()
+	cl, fakeSnapshotClient, _, buffer := newTestClient()
+	fakeSnapshotClient.QueryPendingsReturns(&pb.QueryPendingSnapshotsResponse{BlockNumbers: []uint64{100, 1000}}, nil)
+
+	cmd := listPendingCmd(cl)
+	cmd.SetArgs([]string{"-c", "mychannel", "--peerAddress", "localhost:7051"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Successfully got pending snapshot requests: [100 1000]\n", buffer.String())
+
+	require.Equal(t, 1, fakeSnapshotClient.QueryPendingsCallCount())
+	_, signedRequest, _ := fakeSnapshotClient.QueryPendingsArgsForCall(0)
+	query := &pb.SnapshotQuery{}
+	require.NoError(t, proto.Unmarshal(signedRequest.Request, query))
+	require.Equal(t, "mychannel", query.ChannelId)
+}
+
+func TestSnapshotCmdErrors(t *testing.T) {
+	cl, fakeSnapshotClient, fakeSigner, _ := newTestClient()
+	fakeSnapshotClient.GenerateReturns(nil, errors.New("generate failed"))
+	fakeSnapshotClient.CancelReturns(nil, errors.New("cancel failed"))
+	fakeSnapshotClient.QueryPendingsReturns(nil, errors.New("query failed"))
+
+	tests := []struct {
+		name        string
+		cmd         func(*client) *cobra.Command
+		args        []string
+		expectedErr string
+	}{
+		{"submitrequest without channel", submitRequestCmd, []string{"--peerAddress", "localhost:7051"}, "the required parameter 'channelID' is empty. Rerun the command with -c flag"},
+		{"submitrequest without peer address", submitRequestCmd, []string{"-c", "mychannel"}, "the required parameter 'peerAddress' is empty. Rerun the command with --peerAddress flag"},
+		{"submitrequest fails", submitRequestCmd, []string{"-c", "mychannel", "--peerAddress", "localhost:7051"}, "failed to submit snapshot request: generate failed"},
+		{"cancelrequest without block number", cancelRequestCmd, []string{"-c", "mychannel", "--peerAddress", "localhost:7051"}, "the required parameter 'blockNumber' is empty or set to 0. Rerun the command with -b flag"},
+		{"cancelrequest fails", cancelRequestCmd, []string{"-c", "mychannel", "-b", "10", "--peerAddress", "localhost:7051"}, "failed to cancel the request: cancel failed"},
+		{"listpending without channel", listPendingCmd, []string{"--peerAddress", "localhost:7051"}, "the required parameter 'channelID' is empty. Rerun the command with -c flag"},
+		{"listpending fails", listPendingCmd, []string{"-c", "mychannel", "--peerAddress", "localhost:7051"}, "failed to list pending snapshot requests: query failed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer resetFlags()
+			cmd := test.cmd(cl)
+			cmd.SetArgs(test.args)
+			cmd.SilenceErrors = true
+			require.EqualError(t, cmd.Execute(), test.expectedErr)
+		})
+	}
+
+	t.Run("signer fails", func(t *testing.T) {
+		defer resetFlags()
+		fakeSigner.SignReturns(nil, errors.New("sign failed"))
+		cmd := submitRequestCmd(cl)
+		cmd.SetArgs([]string{"-c", "mychannel", "--peerAddress", "localhost:7051"})
+		cmd.SilenceErrors = true
+		require.EqualError(t, cmd.Execute(), "failed to sign snapshot request: sign failed")
+
+		fakeSigner.SerializeReturns(nil, errors.New("serialize failed"))
+		cmd = listPendingCmd(cl)
+		cmd.SetArgs([]string{"-c", "mychannel", "--peerAddress", "localhost:7051"})
+		cmd.SilenceErrors = true
+		require.EqualError(t, cmd.Execute(), "failed to serialize identity: serialize failed")
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// submitRequestCmd returns the cobra command for snapshot submitrequest command
+func submitRequestCmd(cl *client) *cobra.Command {
+	snapshotSubmitRequestCmd := &cobra.Command{
+		Use:   "submitrequest",
+		Short: "Submit a request for a snapshot to be generated at the specified block.",
+		Long:  "Submit a request for a snapshot to be generated at the specified block. When the block number is 0 or omitted, the snapshot is generated at the last committed block.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitRequest(cmd, cl)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"blockNumber",
+		"peerAddress",
+		"tlsRootCertFile",
+	}
+	attachFlags(snapshotSubmitRequestCmd, flagList)
+
+	return snapshotSubmitRequestCmd
+}
+
+func submitRequest(cmd *cobra.Command, cl *client) error {
+	if err := validatePeerConnectionParameters(); err != nil {
+		return err
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cl == nil {
+		var err error
+		cl, err = newClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	signatureHdr, err := cl.signatureHeader()
+	if err != nil {
+		return err
+	}
+
+	request := &pb.SnapshotRequest{
+		SignatureHeader: signatureHdr,
+		ChannelId:       channelID,
+		BlockNumber:     blockNumber,
+	}
+	signedRequest, err := cl.signRequest(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = cl.snapshotClient.Generate(context.Background(), signedRequest)
+	if err != nil {
+		return errors.WithMessage(err, "failed to submit snapshot request")
+	}
+
+	fmt.Fprint(cl.writer, "Snapshot request submitted successfully\n")
+	return nil
+}
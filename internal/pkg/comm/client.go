@@ -92,13 +92,13 @@ func (client *GRPCClient) parseSecureOptions(opts SecureOptions) error {
 		// make sure we have both Key and Certificate
 		if opts.Key != nil &&
 			opts.Certificate != nil {
-			cert, err := tls.X509KeyPair(opts.Certificate,
-				opts.Key)
+			certs, err := GMKeyPairs(opts.Certificate, opts.Key,
+				opts.EncCertificate, opts.EncKey)
 			if err != nil {
 				return errors.WithMessage(err, "failed to load client certificate")
 			}
 			client.tlsConfig.Certificates = append(
-				client.tlsConfig.Certificates, cert)
+				client.tlsConfig.Certificates, certs...)
 		} else {
 			return errors.New("both Key and Certificate are required when using mutual TLS")
 		}
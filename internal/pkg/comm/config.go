@@ -100,6 +100,11 @@ type SecureOptions struct {
 	Certificate []byte
 	// PEM-encoded private key to be used for TLS communication
 	Key []byte
+	// PEM-encoded X509 public key to be used for the encryption role of
+	// GM TLS. When nil, Certificate is used for both signing and encryption
+	EncCertificate []byte
+	// PEM-encoded private key matching EncCertificate
+	EncKey []byte
 	// Set of PEM-encoded X509 certificate authorities used by clients to
 	// verify server certificates
 	ServerRootCAs [][]byte
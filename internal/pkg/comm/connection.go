@@ -28,6 +28,7 @@ type CredentialSupport struct {
 	appRootCAsByChain map[string][][]byte
 	serverRootCAs     [][]byte
 	clientCert        tls.Certificate
+	clientEncCert     *tls.Certificate
 }
 
 // NewCredentialSupport creates a CredentialSupport instance.
@@ -46,6 +47,15 @@ func (cs *CredentialSupport) SetClientCertificate(cert tls.Certificate) {
 	cs.mutex.Unlock()
 }
 
+// SetClientEncCertificate sets the tls.Certificate to use for the
+// encryption role of GM TLS client connections. When it is not set, the
+// client certificate is used for both roles.
+func (cs *CredentialSupport) SetClientEncCertificate(cert tls.Certificate) {
+	cs.mutex.Lock()
+	cs.clientEncCert = &cert
+	cs.mutex.Unlock()
+}
+
 // GetClientCertificate returns the client certificate of the CredentialSupport
 func (cs *CredentialSupport) GetClientCertificate() tls.Certificate {
 	cs.mutex.RLock()
@@ -73,9 +83,14 @@ func (cs *CredentialSupport) GetPeerCredentials() credentials.TransportCredentia
 		}
 	}
 
+	encCert := cs.clientCert
+	if cs.clientEncCert != nil {
+		encCert = *cs.clientEncCert
+	}
+
 	return gmcredentials.NewTLS(&tls.Config{
-		GMSupport : &tls.GMSupport{},
-		Certificates: []tls.Certificate{cs.clientCert,cs.clientCert},
+		GMSupport:    &tls.GMSupport{},
+		Certificates: []tls.Certificate{cs.clientCert, encCert},
 		RootCAs:      certPool,
 	})
 }
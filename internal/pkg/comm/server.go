@@ -72,13 +72,14 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 	if secureConfig.UseTLS {
 		//both key and cert are required
 		if secureConfig.Key != nil && secureConfig.Certificate != nil {
-			//load server public and private keys
-			cert, err := tls.X509KeyPair(secureConfig.Certificate, secureConfig.Key)
+			//load server signing and encryption key pairs
+			certs, err := GMKeyPairs(secureConfig.Certificate, secureConfig.Key,
+				secureConfig.EncCertificate, secureConfig.EncKey)
 			if err != nil {
 				return nil, err
 			}
 
-			grpcServer.serverCertificate.Store(cert)
+			grpcServer.serverCertificate.Store(certs[0])
 
 			//set up our TLS config
 			if len(secureConfig.CipherSuites) == 0 {
@@ -90,7 +91,7 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 			}
 
 			grpcServer.tls = NewTLSConfig(&tls.Config{
-				Certificates: []tls.Certificate{cert,cert},
+				Certificates:           certs,
 				VerifyPeerCertificate:  secureConfig.VerifyCertificate,
 				GetCertificate:         getCert,
 				SessionTicketsDisabled: true,
@@ -17,6 +17,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
+	tls "github.com/littlegirlpppp/gmsm/gmtls"
 	credentials "github.com/littlegirlpppp/gmsm/gmtls/gmcredentials"
 	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 	// "google.golang.org/grpc/credentials"
@@ -40,6 +41,28 @@ func AddPemToCertPool(pemCerts []byte, pool *gmx509.CertPool) error {
 	}
 	return nil
 }
+
+// GMKeyPairs parses the signing and encryption key pairs used by the
+// GM TLS (GM/T 0024) handshake and returns them in that order. When no
+// encryption key pair is provided, the signing key pair fills both roles.
+func GMKeyPairs(certPEM, keyPEM, encCertPEM, encKeyPEM []byte) ([]tls.Certificate, error) {
+	signCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(encCertPEM) == 0 && len(encKeyPEM) == 0 {
+		return []tls.Certificate{signCert, signCert}, nil
+	}
+	if len(encCertPEM) == 0 || len(encKeyPEM) == 0 {
+		return nil, errors.New("both EncKey and EncCertificate are required when either is set")
+	}
+	encCert, err := tls.X509KeyPair(encCertPEM, encKeyPEM)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to load encryption certificate")
+	}
+	return []tls.Certificate{signCert, encCert}, nil
+}
+
 // parse PEM-encoded certs
 func pemToGMSM2Certs(pemCerts []byte) ([]*gmx509.Certificate, error) {
 	var certs []*gmx509.Certificate
@@ -30,6 +30,7 @@ import (
 
 	// "github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/hyperledger/fabric/bccsp/gm"
+	"github.com/littlegirlpppp/gmsm/sm2"
 	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 	"github.com/pkg/errors"
 )
@@ -72,6 +73,19 @@ func isECDSASignedCert(cert *gmx509.Certificate) bool {
 		cert.SignatureAlgorithm == gmx509.ECDSAWithSHA512
 }
 
+// isSM2PublicKey returns true if pk is an SM2 public key. gmx509 returns
+// SM2 keys as *ecdsa.PublicKey on the SM2 curve.
+func isSM2PublicKey(pk interface{}) bool {
+	switch pk := pk.(type) {
+	case *sm2.PublicKey:
+		return true
+	case *ecdsa.PublicKey:
+		return pk.Curve == sm2.P256Sm2()
+	default:
+		return false
+	}
+}
+
 // sanitizeECDSASignedCert checks that the signatures signing a cert
 // is in low-S. This is checked against the public key of parentCert.
 // If the signature is not in low-S, then a new certificate is generated
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
@@ -143,10 +144,13 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 		}
 
 		// Only override the KeyStorePath if it was left empty
-		if bccspConfig.SwOpts.FileKeystore == nil ||
-			bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
+		if bccspConfig.SwOpts.FileKeystore == nil {
 			bccspConfig.SwOpts.Ephemeral = false
 			bccspConfig.SwOpts.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: keystoreDir}
+		} else if bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
+			// keep the password options of the key store
+			bccspConfig.SwOpts.Ephemeral = false
+			bccspConfig.SwOpts.FileKeystore.KeyStorePath = keystoreDir
 		}
 	}
 
@@ -335,9 +339,9 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	}
 
 	// Set FabricCryptoConfig
-	cryptoConfig := &msp.FabricCryptoConfig{
-		SignatureHashFamily:            bccsp.GMSM3,
-		IdentityIdentifierHashFunction: bccsp.GMSM3,
+	cryptoConfig, err := cryptoConfigForRootCerts(cacerts)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not determine the crypto config from directory %s", cacertDir)
 	}
 
 	// Compose FabricMSPConfig
@@ -362,6 +366,44 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	return mspconf, nil
 }
 
+// cryptoConfigForRootCerts picks the hash functions matching the key
+// algorithm of the root CAs, so that organizations that still use ECDSA
+// can share a channel with SM2 organizations. SM2 roots select SM3 and
+// ECDSA roots select SHA-256.
+func cryptoConfigForRootCerts(rootCerts [][]byte) (*msp.FabricCryptoConfig, error) {
+	var sm2Roots, ecdsaRoots int
+	for _, raw := range rootCerts {
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			return nil, errors.New("failed decoding root certificate PEM")
+		}
+		cert, err := gmx509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed parsing root certificate")
+		}
+		if isSM2PublicKey(cert.PublicKey) {
+			sm2Roots++
+		} else {
+			ecdsaRoots++
+		}
+	}
+
+	switch {
+	case sm2Roots != 0 && ecdsaRoots != 0:
+		return nil, errors.New("root certificates mix SM2 and non-SM2 keys")
+	case ecdsaRoots != 0:
+		return &msp.FabricCryptoConfig{
+			SignatureHashFamily:            bccsp.SHA2,
+			IdentityIdentifierHashFunction: bccsp.SHA256,
+		}, nil
+	default:
+		return &msp.FabricCryptoConfig{
+			SignatureHashFamily:            bccsp.GMSM3,
+			IdentityIdentifierHashFunction: bccsp.GMSM3,
+		}, nil
+	}
+}
+
 func loadCertificateAt(dir, certificatePath string, ouType string) []byte {
 
 	if certificatePath == "" {
@@ -18,6 +18,9 @@ const (
 	MSPv1_1
 	MSPv1_3
 	MSPv1_4_3
+	// MSPv2_0_SM2ZA signs and verifies SM2 signatures over SM3(Z_A || M)
+	// as required by GM/T 0009, instead of over SM3(Z_A || SM3(M)).
+	MSPv2_0_SM2ZA
 )
 
 // NewOpts represent
@@ -58,11 +61,15 @@ func New(opts NewOpts, cryptoProvider bccsp.BCCSP) (MSP, error) {
 			return newBccspMsp(MSPv1_3, cryptoProvider)
 		case MSPv1_4_3:
 			return newBccspMsp(MSPv1_4_3, cryptoProvider)
+		case MSPv2_0_SM2ZA:
+			return newBccspMsp(MSPv2_0_SM2ZA, cryptoProvider)
 		default:
 			return nil, errors.Errorf("Invalid *BCCSPNewOpts. Version not recognized [%v]", opts.GetVersion())
 		}
 	case *IdemixNewOpts:
 		switch opts.GetVersion() {
+		case MSPv2_0_SM2ZA:
+			fallthrough
 		case MSPv1_4_3:
 			fallthrough
 		case MSPv1_3:
@@ -170,6 +170,16 @@ func NewSerializedIdentity(mspID string, certPEM []byte) ([]byte, error) {
 func (id *identity) Verify(msg []byte, sig []byte) error {
 	// mspIdentityLogger.Infof("Verifying signature")
 
+	if id.usesSM2UserID() {
+		// The message is preprocessed with Z_A inside the BCCSP.
+		// Signatures produced by signers that have not been switched
+		// to the standard format yet are still accepted below.
+		valid, err := id.msp.bccsp.Verify(id.pk, sig, msg, &bccsp.SM2SignerOpts{})
+		if err == nil && valid {
+			return nil
+		}
+	}
+
 	// Compute Hash
 	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
 	if err != nil {
@@ -196,6 +206,15 @@ func (id *identity) Verify(msg []byte, sig []byte) error {
 	return nil
 }
 
+// usesSM2UserID returns true if signatures of this identity are computed over
+// SM3(Z_A || M) as specified by GM/T 0009
+func (id *identity) usesSM2UserID() bool {
+	if id.msp.version < MSPv2_0_SM2ZA {
+		return false
+	}
+	return isSM2PublicKey(id.cert.PublicKey)
+}
+
 // Serialize returns a byte array representation of this identity
 func (id *identity) Serialize() ([]byte, error) {
 	pb := &pem.Block{Bytes: id.cert.Raw, Type: "CERTIFICATE"}
@@ -255,6 +274,11 @@ func newSigningIdentity(cert *gmx509.Certificate, pk bccsp.Key, signer crypto.Si
 func (id *signingidentity) Sign(msg []byte) ([]byte, error) {
 	//mspIdentityLogger.Infof("Signing message")
 
+	if id.usesSM2UserID() {
+		// SM3 and the Z_A preprocessing are applied by the BCCSP
+		return id.signer.Sign(rand.Reader, msg, &bccsp.SM2SignerOpts{})
+	}
+
 	// Compute Hash
 	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
 	if err != nil {
@@ -155,6 +155,9 @@ func loadLocalMSP(bccsp bccsp.BCCSP) msp.MSP {
 	if !found {
 		mspLogger.Panicf("msp type " + mspType + " unknown")
 	}
+	if mspType == msp.ProviderTypeToString(msp.FABRIC) && viper.GetBool("peer.localMspSM2ZA") {
+		newOpts = &msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv2_0_SM2ZA}}
+	}
 
 	mspInst, err := msp.New(newOpts, bccsp)
 	if err != nil {
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/gm"
+	"github.com/littlegirlpppp/gmsm/sm2"
+	x509 "github.com/littlegirlpppp/gmsm/x509"
+	"github.com/stretchr/testify/require"
+)
+
+// generateSM2MSPDir writes a local MSP with an SM2 CA and an SM2 signing
+// certificate, keeping the signing key in the GM keystore under dir
+func generateSM2MSPDir(t *testing.T, dir string) {
+	for _, d := range []string{"cacerts", "signcerts", "admincerts", "keystore"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+
+	caKey, err := sm2.GenerateKey(nil)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.example.com", Organization: []string{"example.com"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+		SignatureAlgorithm:    x509.SM2WithSM3,
+	}
+	caPEM, err := x509.CreateCertificateToMem(caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cacerts", "ca-cert.pem"), caPEM, 0644))
+	caCert, err := x509.ReadCertificateFromMem(caPEM)
+	require.NoError(t, err)
+
+	csp, err := gm.NewDefaultSecurityLevel(filepath.Join(dir, "keystore"))
+	require.NoError(t, err)
+	key, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	pubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	raw, err := pubKey.Bytes()
+	require.NoError(t, err)
+	sm2PubKey, err := x509.ParseSm2PublicKey(raw)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:       big.NewInt(2),
+		Subject:            pkix.Name{CommonName: "peer0.example.com", Organization: []string{"example.com"}},
+		NotBefore:          time.Now().Add(-time.Hour),
+		NotAfter:           time.Now().Add(time.Hour),
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		SubjectKeyId:       key.SKI(),
+		SignatureAlgorithm: x509.SM2WithSM3,
+	}
+	certPEM, err := x509.CreateCertificateToMem(template, caCert, sm2PubKey, caKey)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "signcerts", "peer0-cert.pem"), certPEM, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "admincerts", "peer0-cert.pem"), certPEM, 0644))
+}
+
+func getLocalSM2MSPWithVersion(t *testing.T, dir string, version MSPVersion) MSP {
+	conf, err := GetLocalMspConfig(dir, nil, "SampleOrg")
+	require.NoError(t, err)
+
+	cryptoProvider, err := gm.NewDefaultSecurityLevel(filepath.Join(dir, "keystore"))
+	require.NoError(t, err)
+	thisMSP, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: version}}, cryptoProvider)
+	require.NoError(t, err)
+	require.NoError(t, thisMSP.Setup(conf))
+
+	return thisMSP
+}
+
+func TestSM2ZASignVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sm2za")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	generateSM2MSPDir(t, dir)
+
+	msg := []byte("hello, SM2")
+
+	zaMSP := getLocalSM2MSPWithVersion(t, dir, MSPv2_0_SM2ZA)
+	zaID, err := zaMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	require.True(t, zaID.(*signingidentity).usesSM2UserID())
+
+	sig, err := zaID.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, zaID.Verify(msg, sig))
+	require.Error(t, zaID.Verify([]byte("tampered"), sig))
+
+	// The signature follows GM/T 0003.2 with the default user ID, so that
+	// any conforming implementation can verify it
+	sm2Pub := toSM2PublicKey(t, zaID.(*signingidentity).cert.PublicKey)
+	r, s, err := gm.UnmarshalSM2Signature(sig)
+	require.NoError(t, err)
+	require.True(t, sm2.Sm2Verify(sm2Pub, msg, bccsp.DefaultSM2UserID, r, s))
+
+	// Identities deserialized by another MSP instance verify it as well
+	serialized, err := zaID.Serialize()
+	require.NoError(t, err)
+	otherID, err := getLocalSM2MSPWithVersion(t, dir, MSPv2_0_SM2ZA).DeserializeIdentity(serialized)
+	require.NoError(t, err)
+	require.NoError(t, otherID.Verify(msg, sig))
+
+	// Signatures of MSPs predating the capability do not cover Z_A, but are
+	// still accepted
+	legacyID, err := getLocalSM2MSPWithVersion(t, dir, MSPv1_4_3).GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	require.False(t, legacyID.(*signingidentity).usesSM2UserID())
+
+	legacySig, err := legacyID.Sign(msg)
+	require.NoError(t, err)
+	r, s, err = gm.UnmarshalSM2Signature(legacySig)
+	require.NoError(t, err)
+	require.False(t, sm2.Sm2Verify(sm2Pub, msg, bccsp.DefaultSM2UserID, r, s))
+	require.NoError(t, legacyID.Verify(msg, legacySig))
+	require.NoError(t, zaID.Verify(msg, legacySig))
+
+	// while MSPs predating the capability reject Z_A signatures
+	require.Error(t, legacyID.Verify(msg, sig))
+}
+
+func toSM2PublicKey(t *testing.T, pub interface{}) *sm2.PublicKey {
+	require.True(t, isSM2PublicKey(pub))
+	if sm2Pub, ok := pub.(*sm2.PublicKey); ok {
+		return sm2Pub
+	}
+	raw, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	sm2Pub, err := x509.ParseSm2PublicKey(raw)
+	require.NoError(t, err)
+	return sm2Pub
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"math"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+)
+
+// arrivalSmoothing is the weight of the latest interval in the moving average
+// of the intervals between ordered messages.
+const arrivalSmoothing = 0.2
+
+// arrivalRate estimates the rate at which messages are ordered as an
+// exponentially weighted moving average of the intervals between them.
+type arrivalRate struct {
+	last     time.Time
+	interval float64 // seconds
+	samples  int
+}
+
+// observe records the arrival of a message at the given time.
+func (a *arrivalRate) observe(now time.Time) {
+	if !a.last.IsZero() {
+		interval := now.Sub(a.last).Seconds()
+		if interval < 0 {
+			interval = 0
+		}
+		if a.samples == 0 {
+			a.interval = interval
+		} else {
+			a.interval = arrivalSmoothing*interval + (1-arrivalSmoothing)*a.interval
+		}
+		a.samples++
+	}
+	a.last = now
+}
+
+// batch returns the message count and the timeout at which the pending batch
+// should be cut, so that a batch filled at the estimated arrival rate is cut
+// close to the target latency. The message count is the number of messages
+// expected within the target latency, and the timeout the time expected to
+// fill a batch of that many messages, never exceeding the target latency.
+// Both are kept within the given bounds. Until the rate is known, or while
+// it is beyond the resolution of the clock, the upper bound of the message
+// count and the target latency are used.
+func (a *arrivalRate) batch(bounds channelconfig.AdaptiveBatching) (uint32, time.Duration) {
+	if a.samples == 0 || a.interval == 0 {
+		return bounds.MaxMessageCount, clampDuration(bounds.TargetLatency, bounds.MinTimeout, bounds.MaxTimeout)
+	}
+
+	count := uint64(math.Min(bounds.TargetLatency.Seconds()/a.interval, float64(bounds.MaxMessageCount)))
+	if count < uint64(bounds.MinMessageCount) {
+		count = uint64(bounds.MinMessageCount)
+	}
+
+	fill := time.Duration(float64(count) * a.interval * float64(time.Second))
+	if fill > bounds.TargetLatency {
+		fill = bounds.TargetLatency
+	}
+
+	return uint32(count), clampDuration(fill, bounds.MinTimeout, bounds.MaxTimeout)
+}
+
+func clampDuration(d, min, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrivalRate(t *testing.T) {
+	bounds := channelconfig.AdaptiveBatching{
+		MinMessageCount: 5,
+		MaxMessageCount: 100,
+		MinTimeout:      50 * time.Millisecond,
+		MaxTimeout:      2 * time.Second,
+		TargetLatency:   time.Second,
+	}
+	start := time.Unix(0, 0)
+
+	arrivalsEvery := func(interval time.Duration, count int) *arrivalRate {
+		a := &arrivalRate{}
+		for i := 0; i < count; i++ {
+			a.observe(start.Add(time.Duration(i) * interval))
+		}
+		return a
+	}
+
+	for _, tc := range []struct {
+		name            string
+		arrivals        *arrivalRate
+		expectedCount   uint32
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "unknown rate",
+			arrivals:        arrivalsEvery(time.Millisecond, 1),
+			expectedCount:   100,
+			expectedTimeout: time.Second,
+		},
+		{
+			name:            "rate beyond the clock resolution",
+			arrivals:        arrivalsEvery(0, 10),
+			expectedCount:   100,
+			expectedTimeout: time.Second,
+		},
+		{
+			name:            "batch filled within the target latency",
+			arrivals:        arrivalsEvery(20*time.Millisecond, 10),
+			expectedCount:   50,
+			expectedTimeout: time.Second,
+		},
+		{
+			name:            "high rate",
+			arrivals:        arrivalsEvery(time.Millisecond, 10),
+			expectedCount:   100,
+			expectedTimeout: 100 * time.Millisecond,
+		},
+		{
+			name:            "very high rate",
+			arrivals:        arrivalsEvery(100*time.Microsecond, 10),
+			expectedCount:   100,
+			expectedTimeout: 50 * time.Millisecond,
+		},
+		{
+			name:            "low rate",
+			arrivals:        arrivalsEvery(500*time.Millisecond, 10),
+			expectedCount:   5,
+			expectedTimeout: time.Second,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			count, timeout := tc.arrivals.batch(bounds)
+			assert.Equal(t, tc.expectedCount, count)
+			assert.InDelta(t, tc.expectedTimeout, timeout, float64(time.Microsecond))
+		})
+	}
+
+	t.Run("rate change", func(t *testing.T) {
+		a := arrivalsEvery(500*time.Millisecond, 10)
+		count, _ := a.batch(bounds)
+		assert.Equal(t, uint32(5), count)
+
+		// a burst is picked up within a few dozen messages
+		now := start.Add(5 * time.Second)
+		for i := 0; i < 30; i++ {
+			now = now.Add(5 * time.Millisecond)
+			a.observe(now)
+		}
+		count, _ = a.batch(bounds)
+		assert.Equal(t, uint32(100), count)
+	})
+}
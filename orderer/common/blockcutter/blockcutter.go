@@ -31,17 +31,39 @@ type Receiver interface {
 	Cut() []*cb.Envelope
 }
 
+// AdaptiveReceiver is a Receiver which tunes the message count and the timeout
+// of batches to the arrival rate of messages, when adaptive batching is enabled
+// for the channel.
+type AdaptiveReceiver interface {
+	Receiver
+
+	// BatchTimeout returns the time to wait before cutting the pending batch
+	BatchTimeout() time.Duration
+}
+
+// BatchTimeout returns the time to wait before cutting the pending batch of the
+// receiver. It is the timeout tuned to the arrival rate for an AdaptiveReceiver,
+// and the batch timeout of the channel configuration otherwise.
+func BatchTimeout(r Receiver, ordererConfig channelconfig.Orderer) time.Duration {
+	if ar, ok := r.(AdaptiveReceiver); ok {
+		return ar.BatchTimeout()
+	}
+	return ordererConfig.BatchTimeout()
+}
+
 type receiver struct {
 	sharedConfigFetcher   OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	arrivals              arrivalRate
 
 	PendingBatchStartTime time.Time
 	ChannelID             string
 	Metrics               *Metrics
 }
 
-// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
+// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager.
+// The Receiver is an AdaptiveReceiver.
 func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics) Receiver {
 	return &receiver{
 		sharedConfigFetcher: sharedConfigFetcher,
@@ -57,7 +79,8 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 // messageBatches length: 0, pending: true
 //   - no batch is cut and there are messages pending
 // messageBatches length: 1, pending: false
-//   - the message count reaches BatchSize.MaxMessageCount
+//   - the message count reaches BatchSize.MaxMessageCount, or the count tuned to
+//     the arrival rate if adaptive batching is enabled
 // messageBatches length: 1, pending: true
 //   - the current message will cause the pending batch size in bytes to exceed BatchSize.PreferredMaxBytes.
 // messageBatches length: 2, pending: false
@@ -79,6 +102,12 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 	batchSize := ordererConfig.BatchSize()
 
+	r.arrivals.observe(time.Now())
+	maxMessageCount := batchSize.MaxMessageCount
+	if bounds, ok := ordererConfig.AdaptiveBatching(); ok {
+		maxMessageCount, _ = r.adaptiveBatch(bounds)
+	}
+
 	messageSizeBytes := messageSizeBytes(msg)
 	if messageSizeBytes > batchSize.PreferredMaxBytes {
 		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, batchSize.PreferredMaxBytes)
@@ -113,7 +142,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	r.pendingBatchSizeBytes += messageSizeBytes
 	pending = true
 
-	if uint32(len(r.pendingBatch)) >= batchSize.MaxMessageCount {
+	if uint32(len(r.pendingBatch)) >= maxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
@@ -123,6 +152,31 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	return
 }
 
+// BatchTimeout returns the time to wait before cutting the pending batch
+func (r *receiver) BatchTimeout() time.Duration {
+	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
+	}
+
+	bounds, ok := ordererConfig.AdaptiveBatching()
+	if !ok {
+		return ordererConfig.BatchTimeout()
+	}
+
+	_, timeout := r.adaptiveBatch(bounds)
+	return timeout
+}
+
+// adaptiveBatch returns the message count and the timeout tuned to the arrival
+// rate, and records them in the metrics.
+func (r *receiver) adaptiveBatch(bounds channelconfig.AdaptiveBatching) (uint32, time.Duration) {
+	count, timeout := r.arrivals.batch(bounds)
+	r.Metrics.AdaptiveMaxMessageCount.With("channel", r.ChannelID).Set(float64(count))
+	r.Metrics.AdaptiveBatchTimeout.With("channel", r.ChannelID).Set(timeout.Seconds())
+	return count, timeout
+}
+
 // Cut returns the current batch and starts a new one
 func (r *receiver) Cut() []*cb.Envelope {
 	if r.pendingBatch != nil {
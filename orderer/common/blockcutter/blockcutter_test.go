@@ -7,13 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
 )
 
 var _ = Describe("Blockcutter", func() {
@@ -22,8 +26,10 @@ var _ = Describe("Blockcutter", func() {
 		fakeConfig        *mock.OrdererConfig
 		fakeConfigFetcher *mock.OrdererConfigFetcher
 
-		metrics               *blockcutter.Metrics
-		fakeBlockFillDuration *mock.MetricsHistogram
+		metrics                     *blockcutter.Metrics
+		fakeBlockFillDuration       *mock.MetricsHistogram
+		fakeAdaptiveMaxMessageCount *mock.MetricsGauge
+		fakeAdaptiveBatchTimeout    *mock.MetricsGauge
 	)
 
 	BeforeEach(func() {
@@ -33,8 +39,14 @@ var _ = Describe("Blockcutter", func() {
 
 		fakeBlockFillDuration = &mock.MetricsHistogram{}
 		fakeBlockFillDuration.WithReturns(fakeBlockFillDuration)
+		fakeAdaptiveMaxMessageCount = &mock.MetricsGauge{}
+		fakeAdaptiveMaxMessageCount.WithReturns(fakeAdaptiveMaxMessageCount)
+		fakeAdaptiveBatchTimeout = &mock.MetricsGauge{}
+		fakeAdaptiveBatchTimeout.WithReturns(fakeAdaptiveBatchTimeout)
 		metrics = &blockcutter.Metrics{
-			BlockFillDuration: fakeBlockFillDuration,
+			BlockFillDuration:       fakeBlockFillDuration,
+			AdaptiveMaxMessageCount: fakeAdaptiveMaxMessageCount,
+			AdaptiveBatchTimeout:    fakeAdaptiveBatchTimeout,
 		}
 
 		bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
@@ -173,6 +185,53 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 
+		Context("when adaptive batching is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   3,
+					PreferredMaxBytes: 100,
+					MinMessageCount:   2,
+				})
+				fakeConfig.AdaptiveBatchingReturns(channelconfig.AdaptiveBatching{
+					MinMessageCount: 2,
+					MaxMessageCount: 3,
+					MinTimeout:      time.Millisecond,
+					MaxTimeout:      time.Second,
+					TargetLatency:   2 * time.Millisecond,
+				}, true)
+			})
+
+			It("cuts at the max message count until the arrival rate is known", func() {
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+
+				Expect(fakeAdaptiveMaxMessageCount.WithCallCount()).To(Equal(1))
+				Expect(fakeAdaptiveMaxMessageCount.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+				Expect(fakeAdaptiveMaxMessageCount.SetArgsForCall(0)).To(Equal(float64(3)))
+				Expect(fakeAdaptiveBatchTimeout.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+				Expect(fakeAdaptiveBatchTimeout.SetArgsForCall(0)).To(Equal(0.002))
+			})
+
+			Context("when messages arrive slower than the target latency", func() {
+				It("cuts the batch at the min message count", func() {
+					batches, pending := bc.Ordered(message)
+					Expect(batches).To(BeEmpty())
+					Expect(pending).To(BeTrue())
+
+					time.Sleep(10 * time.Millisecond)
+					batches, pending = bc.Ordered(message)
+					Expect(len(batches)).To(Equal(1))
+					Expect(len(batches[0])).To(Equal(2))
+					Expect(pending).To(BeFalse())
+
+					Expect(fakeAdaptiveMaxMessageCount.SetCallCount()).To(Equal(2))
+					Expect(fakeAdaptiveMaxMessageCount.SetArgsForCall(1)).To(Equal(float64(2)))
+					Expect(fakeAdaptiveBatchTimeout.SetArgsForCall(1)).To(Equal(0.002))
+				})
+			})
+		})
+
 		Context("when the orderer config cannot be retrieved", func() {
 			BeforeEach(func() {
 				fakeConfigFetcher.OrdererConfigReturns(nil, false)
@@ -184,6 +243,51 @@ var _ = Describe("Blockcutter", func() {
 		})
 	})
 
+	Describe("BatchTimeout", func() {
+		BeforeEach(func() {
+			fakeConfig.BatchTimeoutReturns(time.Second)
+		})
+
+		It("returns the batch timeout of the channel", func() {
+			Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(time.Second))
+			Expect(fakeAdaptiveBatchTimeout.SetCallCount()).To(Equal(0))
+		})
+
+		Context("when adaptive batching is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig.AdaptiveBatchingReturns(channelconfig.AdaptiveBatching{
+					MinMessageCount: 1,
+					MaxMessageCount: 10,
+					MinTimeout:      100 * time.Millisecond,
+					MaxTimeout:      time.Second,
+					TargetLatency:   500 * time.Millisecond,
+				}, true)
+			})
+
+			It("returns the tuned timeout", func() {
+				Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(500 * time.Millisecond))
+				Expect(fakeAdaptiveBatchTimeout.SetCallCount()).To(Equal(1))
+				Expect(fakeAdaptiveBatchTimeout.SetArgsForCall(0)).To(Equal(0.5))
+			})
+		})
+
+		Context("when the receiver does not batch adaptively", func() {
+			It("returns the batch timeout of the channel", func() {
+				Expect(blockcutter.BatchTimeout(&mockblockcutter.Receiver{}, fakeConfig)).To(Equal(time.Second))
+			})
+		})
+
+		Context("when the orderer config cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeConfigFetcher.OrdererConfigReturns(nil, false)
+			})
+
+			It("panics", func() {
+				Expect(func() { bc.(blockcutter.AdaptiveReceiver).BatchTimeout() }).To(Panic())
+			})
+		})
+	})
+
 	Describe("Cut", func() {
 		It("cuts an empty batch", func() {
 			batch := bc.Cut()
@@ -16,14 +16,32 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	adaptiveMaxMessageCount = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "adaptive_max_message_count",
+		Help:         "The message count at which blocks are cut, as tuned by adaptive batching.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	adaptiveBatchTimeout = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "adaptive_batch_timeout",
+		Help:         "The batch timeout in seconds, as tuned by adaptive batching.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 type Metrics struct {
-	BlockFillDuration metrics.Histogram
+	BlockFillDuration       metrics.Histogram
+	AdaptiveMaxMessageCount metrics.Gauge
+	AdaptiveBatchTimeout    metrics.Gauge
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
-		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		BlockFillDuration:       p.NewHistogram(blockFillDuration),
+		AdaptiveMaxMessageCount: p.NewGauge(adaptiveMaxMessageCount),
+		AdaptiveBatchTimeout:    p.NewGauge(adaptiveBatchTimeout),
 	}
 }
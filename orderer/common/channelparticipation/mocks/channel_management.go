@@ -10,6 +10,20 @@ import (
 )
 
 type ChannelManagement struct {
+	ChannelBlockStub        func(string, uint64) (*common.Block, error)
+	channelBlockMutex       sync.RWMutex
+	channelBlockArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+	}
+	channelBlockReturns struct {
+		result1 *common.Block
+		result2 error
+	}
+	channelBlockReturnsOnCall map[int]struct {
+		result1 *common.Block
+		result2 error
+	}
 	ChannelInfoStub        func(string) (types.ChannelInfo, error)
 	channelInfoMutex       sync.RWMutex
 	channelInfoArgsForCall []struct {
@@ -48,6 +62,18 @@ type ChannelManagement struct {
 		result1 types.ChannelInfo
 		result2 error
 	}
+	PromoteLearnerStub        func(string, string) error
+	promoteLearnerMutex       sync.RWMutex
+	promoteLearnerArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	promoteLearnerReturns struct {
+		result1 error
+	}
+	promoteLearnerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoveChannelStub        func(string, bool) error
 	removeChannelMutex       sync.RWMutex
 	removeChannelArgsForCall []struct {
@@ -60,10 +86,86 @@ type ChannelManagement struct {
 	removeChannelReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SubmitConfigUpdateStub        func(string, *common.Envelope) error
+	submitConfigUpdateMutex       sync.RWMutex
+	submitConfigUpdateArgsForCall []struct {
+		arg1 string
+		arg2 *common.Envelope
+	}
+	submitConfigUpdateReturns struct {
+		result1 error
+	}
+	submitConfigUpdateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ChannelManagement) ChannelBlock(arg1 string, arg2 uint64) (*common.Block, error) {
+	fake.channelBlockMutex.Lock()
+	ret, specificReturn := fake.channelBlockReturnsOnCall[len(fake.channelBlockArgsForCall)]
+	fake.channelBlockArgsForCall = append(fake.channelBlockArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("ChannelBlock", []interface{}{arg1, arg2})
+	fake.channelBlockMutex.Unlock()
+	if fake.ChannelBlockStub != nil {
+		return fake.ChannelBlockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.channelBlockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelManagement) ChannelBlockCallCount() int {
+	fake.channelBlockMutex.RLock()
+	defer fake.channelBlockMutex.RUnlock()
+	return len(fake.channelBlockArgsForCall)
+}
+
+func (fake *ChannelManagement) ChannelBlockCalls(stub func(string, uint64) (*common.Block, error)) {
+	fake.channelBlockMutex.Lock()
+	defer fake.channelBlockMutex.Unlock()
+	fake.ChannelBlockStub = stub
+}
+
+func (fake *ChannelManagement) ChannelBlockArgsForCall(i int) (string, uint64) {
+	fake.channelBlockMutex.RLock()
+	defer fake.channelBlockMutex.RUnlock()
+	argsForCall := fake.channelBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChannelManagement) ChannelBlockReturns(result1 *common.Block, result2 error) {
+	fake.channelBlockMutex.Lock()
+	defer fake.channelBlockMutex.Unlock()
+	fake.ChannelBlockStub = nil
+	fake.channelBlockReturns = struct {
+		result1 *common.Block
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) ChannelBlockReturnsOnCall(i int, result1 *common.Block, result2 error) {
+	fake.channelBlockMutex.Lock()
+	defer fake.channelBlockMutex.Unlock()
+	fake.ChannelBlockStub = nil
+	if fake.channelBlockReturnsOnCall == nil {
+		fake.channelBlockReturnsOnCall = make(map[int]struct {
+			result1 *common.Block
+			result2 error
+		})
+	}
+	fake.channelBlockReturnsOnCall[i] = struct {
+		result1 *common.Block
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChannelManagement) ChannelInfo(arg1 string) (types.ChannelInfo, error) {
 	fake.channelInfoMutex.Lock()
 	ret, specificReturn := fake.channelInfoReturnsOnCall[len(fake.channelInfoArgsForCall)]
@@ -244,6 +346,67 @@ func (fake *ChannelManagement) JoinChannelReturnsOnCall(i int, result1 types.Cha
 	}{result1, result2}
 }
 
+func (fake *ChannelManagement) PromoteLearner(arg1 string, arg2 string) error {
+	fake.promoteLearnerMutex.Lock()
+	ret, specificReturn := fake.promoteLearnerReturnsOnCall[len(fake.promoteLearnerArgsForCall)]
+	fake.promoteLearnerArgsForCall = append(fake.promoteLearnerArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("PromoteLearner", []interface{}{arg1, arg2})
+	fake.promoteLearnerMutex.Unlock()
+	if fake.PromoteLearnerStub != nil {
+		return fake.PromoteLearnerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.promoteLearnerReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelManagement) PromoteLearnerCallCount() int {
+	fake.promoteLearnerMutex.RLock()
+	defer fake.promoteLearnerMutex.RUnlock()
+	return len(fake.promoteLearnerArgsForCall)
+}
+
+func (fake *ChannelManagement) PromoteLearnerCalls(stub func(string, string) error) {
+	fake.promoteLearnerMutex.Lock()
+	defer fake.promoteLearnerMutex.Unlock()
+	fake.PromoteLearnerStub = stub
+}
+
+func (fake *ChannelManagement) PromoteLearnerArgsForCall(i int) (string, string) {
+	fake.promoteLearnerMutex.RLock()
+	defer fake.promoteLearnerMutex.RUnlock()
+	argsForCall := fake.promoteLearnerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChannelManagement) PromoteLearnerReturns(result1 error) {
+	fake.promoteLearnerMutex.Lock()
+	defer fake.promoteLearnerMutex.Unlock()
+	fake.PromoteLearnerStub = nil
+	fake.promoteLearnerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChannelManagement) PromoteLearnerReturnsOnCall(i int, result1 error) {
+	fake.promoteLearnerMutex.Lock()
+	defer fake.promoteLearnerMutex.Unlock()
+	fake.PromoteLearnerStub = nil
+	if fake.promoteLearnerReturnsOnCall == nil {
+		fake.promoteLearnerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.promoteLearnerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChannelManagement) RemoveChannel(arg1 string, arg2 bool) error {
 	fake.removeChannelMutex.Lock()
 	ret, specificReturn := fake.removeChannelReturnsOnCall[len(fake.removeChannelArgsForCall)]
@@ -305,17 +468,84 @@ func (fake *ChannelManagement) RemoveChannelReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *ChannelManagement) SubmitConfigUpdate(arg1 string, arg2 *common.Envelope) error {
+	fake.submitConfigUpdateMutex.Lock()
+	ret, specificReturn := fake.submitConfigUpdateReturnsOnCall[len(fake.submitConfigUpdateArgsForCall)]
+	fake.submitConfigUpdateArgsForCall = append(fake.submitConfigUpdateArgsForCall, struct {
+		arg1 string
+		arg2 *common.Envelope
+	}{arg1, arg2})
+	fake.recordInvocation("SubmitConfigUpdate", []interface{}{arg1, arg2})
+	fake.submitConfigUpdateMutex.Unlock()
+	if fake.SubmitConfigUpdateStub != nil {
+		return fake.SubmitConfigUpdateStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.submitConfigUpdateReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelManagement) SubmitConfigUpdateCallCount() int {
+	fake.submitConfigUpdateMutex.RLock()
+	defer fake.submitConfigUpdateMutex.RUnlock()
+	return len(fake.submitConfigUpdateArgsForCall)
+}
+
+func (fake *ChannelManagement) SubmitConfigUpdateCalls(stub func(string, *common.Envelope) error) {
+	fake.submitConfigUpdateMutex.Lock()
+	defer fake.submitConfigUpdateMutex.Unlock()
+	fake.SubmitConfigUpdateStub = stub
+}
+
+func (fake *ChannelManagement) SubmitConfigUpdateArgsForCall(i int) (string, *common.Envelope) {
+	fake.submitConfigUpdateMutex.RLock()
+	defer fake.submitConfigUpdateMutex.RUnlock()
+	argsForCall := fake.submitConfigUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChannelManagement) SubmitConfigUpdateReturns(result1 error) {
+	fake.submitConfigUpdateMutex.Lock()
+	defer fake.submitConfigUpdateMutex.Unlock()
+	fake.SubmitConfigUpdateStub = nil
+	fake.submitConfigUpdateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChannelManagement) SubmitConfigUpdateReturnsOnCall(i int, result1 error) {
+	fake.submitConfigUpdateMutex.Lock()
+	defer fake.submitConfigUpdateMutex.Unlock()
+	fake.SubmitConfigUpdateStub = nil
+	if fake.submitConfigUpdateReturnsOnCall == nil {
+		fake.submitConfigUpdateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.submitConfigUpdateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChannelManagement) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.channelBlockMutex.RLock()
+	defer fake.channelBlockMutex.RUnlock()
 	fake.channelInfoMutex.RLock()
 	defer fake.channelInfoMutex.RUnlock()
 	fake.channelListMutex.RLock()
 	defer fake.channelListMutex.RUnlock()
 	fake.joinChannelMutex.RLock()
 	defer fake.joinChannelMutex.RUnlock()
+	fake.promoteLearnerMutex.RLock()
+	defer fake.promoteLearnerMutex.RUnlock()
 	fake.removeChannelMutex.RLock()
 	defer fake.removeChannelMutex.RUnlock()
+	fake.submitConfigUpdateMutex.RLock()
+	defer fake.submitConfigUpdateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
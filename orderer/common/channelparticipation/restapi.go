@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package channelparticipation
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"mime"
@@ -18,22 +19,38 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric-config/protolator"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
 const (
-	URLBaseV1              = "/participation/v1/"
-	URLBaseV1Channels      = URLBaseV1 + "channels"
-	FormDataConfigBlockKey = "config-block"
-	RemoveStorageQueryKey  = "removeStorage"
-
-	channelIDKey        = "channelID"
-	urlWithChannelIDKey = URLBaseV1Channels + "/{" + channelIDKey + "}"
+	URLBaseV1               = "/participation/v1/"
+	URLBaseV1Channels       = URLBaseV1 + "channels"
+	FormDataConfigBlockKey  = "config-block"
+	FormDataConfigUpdateKey = "config-update"
+	RemoveStorageQueryKey   = "removeStorage"
+	ConsenterQueryKey       = "consenter"
+
+	// BlockNewest and BlockConfig select the newest block and the last config block of a channel,
+	// in place of a block number.
+	BlockNewest = "newest"
+	BlockConfig = "config"
+
+	channelIDKey             = "channelID"
+	blockKey                 = "block"
+	urlWithChannelIDKey      = URLBaseV1Channels + "/{" + channelIDKey + "}"
+	urlWithBlockKey          = urlWithChannelIDKey + "/blocks/{" + blockKey + "}"
+	urlWithConfigUpdateKey   = urlWithChannelIDKey + "/config-update"
+	urlWithPromoteKey        = urlWithChannelIDKey + "/promote"
+	contentTypeJSON          = "application/json"
+	contentTypeProtobufBytes = "application/octet-stream"
 )
 
 //go:generate counterfeiter -o mocks/channel_management.go -fake-name ChannelManagement . ChannelManagement
@@ -54,6 +71,17 @@ type ChannelManagement interface {
 	// RemoveChannel instructs the orderer to remove a channel.
 	// Depending on the removeStorage parameter, the storage resources are either removed or archived.
 	RemoveChannel(channelID string, removeStorage bool) error
+
+	// ChannelBlock returns the block with the given number from the ledger of a channel.
+	ChannelBlock(channelID string, number uint64) (*cb.Block, error)
+
+	// SubmitConfigUpdate instructs the orderer to validate a signed config update of a channel, and to order the
+	// resulting config transaction.
+	SubmitConfigUpdate(channelID string, configUpdate *cb.Envelope) error
+
+	// PromoteLearner instructs the orderer to submit a config update that promotes a non-voting consenter of a
+	// channel, identified by its endpoint (host:port), to a voting consenter.
+	PromoteLearner(channelID string, consenter string) error
 }
 
 // HTTPHandler handles all the HTTP requests to the channel participation API.
@@ -73,6 +101,17 @@ func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelMa
 		router:    mux.NewRouter(),
 	}
 
+	handler.router.HandleFunc(urlWithBlockKey, handler.serveBlock).Methods(http.MethodGet)
+	handler.router.HandleFunc(urlWithBlockKey, handler.serveNotAllowed)
+
+	handler.router.HandleFunc(urlWithConfigUpdateKey, handler.serveConfigUpdate).Methods(http.MethodPost).HeadersRegexp(
+		"Content-Type", "multipart/form-data*")
+	handler.router.HandleFunc(urlWithConfigUpdateKey, handler.serveBadContentType).Methods(http.MethodPost)
+	handler.router.HandleFunc(urlWithConfigUpdateKey, handler.serveNotAllowed)
+
+	handler.router.HandleFunc(urlWithPromoteKey, handler.servePromote).Methods(http.MethodPost)
+	handler.router.HandleFunc(urlWithPromoteKey, handler.serveNotAllowed)
+
 	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveListOne).Methods(http.MethodGet)
 
 	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveJoin).Methods(http.MethodPost).HeadersRegexp(
@@ -188,6 +227,24 @@ func (h *HTTPHandler) serveJoin(resp http.ResponseWriter, req *http.Request) {
 
 // Expect a multipart/form-data with a single part, of type file, with key FormDataConfigBlockKey.
 func (h *HTTPHandler) multipartFormDataBodyToBlock(params map[string]string, req *http.Request, resp http.ResponseWriter) *cb.Block {
+	blockBytes := h.multipartFormDataBodyToBytes(FormDataConfigBlockKey, params, req, resp)
+	if blockBytes == nil {
+		return nil
+	}
+
+	block := &cb.Block{}
+	err := proto.Unmarshal(blockBytes, block)
+	if err != nil {
+		h.logger.Debugf("Failed to unmarshal blockBytes: %s", err)
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot unmarshal file part %s into a block", FormDataConfigBlockKey))
+		return nil
+	}
+
+	return block
+}
+
+// Expect a multipart/form-data with a single part, of type file, with the given key.
+func (h *HTTPHandler) multipartFormDataBodyToBytes(key string, params map[string]string, req *http.Request, resp http.ResponseWriter) []byte {
 	boundary := params["boundary"]
 	reader := multipart.NewReader(req.Body, boundary)
 	form, err := reader.ReadForm(100 * 1024 * 1024)
@@ -196,8 +253,8 @@ func (h *HTTPHandler) multipartFormDataBodyToBlock(params map[string]string, req
 		return nil
 	}
 
-	if _, exist := form.File[FormDataConfigBlockKey]; !exist {
-		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Errorf("form does not contains part key: %s", FormDataConfigBlockKey))
+	if _, exist := form.File[key]; !exist {
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Errorf("form does not contains part key: %s", key))
 		return nil
 	}
 
@@ -206,28 +263,20 @@ func (h *HTTPHandler) multipartFormDataBodyToBlock(params map[string]string, req
 		return nil
 	}
 
-	fileHeader := form.File[FormDataConfigBlockKey][0]
+	fileHeader := form.File[key][0]
 	file, err := fileHeader.Open()
 	if err != nil {
-		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot open file part %s from request body", FormDataConfigBlockKey))
-		return nil
-	}
-
-	blockBytes, err := ioutil.ReadAll(file)
-	if err != nil {
-		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot read file part %s from request body", FormDataConfigBlockKey))
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot open file part %s from request body", key))
 		return nil
 	}
 
-	block := &cb.Block{}
-	err = proto.Unmarshal(blockBytes, block)
+	fileBytes, err := ioutil.ReadAll(file)
 	if err != nil {
-		h.logger.Debugf("Failed to unmarshal blockBytes: %s", err)
-		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot unmarshal file part %s into a block", FormDataConfigBlockKey))
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot read file part %s from request body", key))
 		return nil
 	}
 
-	return block
+	return fileBytes
 }
 
 func (h *HTTPHandler) extractChannelID(req *http.Request, resp http.ResponseWriter) (string, error) {
@@ -331,6 +380,162 @@ func (h *HTTPHandler) extractRemoveStorageQuery(req *http.Request, resp http.Res
 	return removeStorage, nil
 }
 
+// Fetch a block of a channel.
+// The block is selected by number, by BlockNewest, or by BlockConfig. It is returned as JSON, or as a marshaled
+// protobuf when the request accepts "application/octet-stream".
+func (h *HTTPHandler) serveBlock(resp http.ResponseWriter, req *http.Request) {
+	contentType, err := negotiateBlockContentType(req)
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	block, err := h.fetchBlock(channelID, mux.Vars(req)[blockKey])
+	if err != nil {
+		h.logger.Debugf("Failed to fetch block of channel: %s, err: %s", channelID, err)
+		switch errors.Cause(err) {
+		case types.ErrChannelNotExist, types.ErrBlockNotExist:
+			h.sendResponseJsonError(resp, http.StatusNotFound, errors.Wrap(err, "cannot fetch block"))
+		default:
+			h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot fetch block"))
+		}
+		return
+	}
+
+	resp.Header().Set("Cache-Control", "no-store")
+	if contentType == contentTypeProtobufBytes {
+		h.sendResponseBlockBytes(resp, block)
+		return
+	}
+	h.sendResponseBlockJSON(resp, block)
+}
+
+// fetchBlock resolves the block selector of a request into a block number, and returns the block.
+// The last config block is found through the last config index in the metadata of the newest block.
+func (h *HTTPHandler) fetchBlock(channelID, selector string) (*cb.Block, error) {
+	switch selector {
+	case BlockNewest, BlockConfig:
+		info, err := h.registrar.ChannelInfo(channelID)
+		if err != nil {
+			return nil, err
+		}
+		if info.Height == 0 {
+			return nil, errors.WithMessage(types.ErrBlockNotExist, "the ledger is empty")
+		}
+		newest, err := h.registrar.ChannelBlock(channelID, info.Height-1)
+		if err != nil || selector == BlockNewest || protoutil.IsConfigBlock(newest) {
+			return newest, err
+		}
+		lastConfig, err := protoutil.GetLastConfigIndexFromBlock(newest)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "cannot find the last config index of block [%d]", newest.Header.Number)
+		}
+		return h.registrar.ChannelBlock(channelID, lastConfig)
+	default:
+		number, err := strconv.ParseUint(selector, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid block: %s, expected a block number, %s or %s", selector, BlockNewest, BlockConfig)
+		}
+		return h.registrar.ChannelBlock(channelID, number)
+	}
+}
+
+// Submit a config update to a channel.
+// Expect multipart/form-data with a single part, of type file, with key FormDataConfigUpdateKey, that carries a
+// signed config update envelope. The config update is ordered asynchronously.
+func (h *HTTPHandler) serveConfigUpdate(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot parse Mime media type"))
+		return
+	}
+
+	envBytes := h.multipartFormDataBodyToBytes(FormDataConfigUpdateKey, params, req, resp)
+	if envBytes == nil {
+		return
+	}
+
+	env := &cb.Envelope{}
+	if err := proto.Unmarshal(envBytes, env); err != nil {
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot unmarshal file part %s into an envelope", FormDataConfigUpdateKey))
+		return
+	}
+
+	err = h.registrar.SubmitConfigUpdate(channelID, env)
+	if err == nil {
+		h.logger.Debugf("Successfully submitted config update of channel: %s", channelID)
+		resp.Header().Set("Location", path.Join(URLBaseV1Channels, channelID))
+		resp.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	h.logger.Debugf("Failed to submit config update of channel: %s, err: %s", channelID, err)
+	h.sendConfigUpdateError(err, "cannot update config", resp)
+}
+
+// Promote a non-voting consenter (learner) of a channel to a voting consenter.
+// Expecting a query: "consenter=host:port". The resulting config update is ordered asynchronously.
+func (h *HTTPHandler) servePromote(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	queryVal := req.URL.Query()
+	values, ok := queryVal[ConsenterQueryKey]
+	if !ok || len(queryVal) != 1 || len(values) != 1 || values[0] == "" {
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Errorf("cannot promote: expected a single query parameter: %s", ConsenterQueryKey))
+		return
+	}
+	consenter := values[0]
+
+	err = h.registrar.PromoteLearner(channelID, consenter)
+	if err == nil {
+		h.logger.Debugf("Successfully submitted promotion of learner %s of channel: %s", consenter, channelID)
+		resp.Header().Set("Location", path.Join(URLBaseV1Channels, channelID))
+		resp.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	h.logger.Debugf("Failed to promote learner %s of channel: %s, err: %s", consenter, channelID, err)
+	h.sendConfigUpdateError(err, "cannot promote", resp)
+}
+
+func (h *HTTPHandler) sendConfigUpdateError(err error, msg string, resp http.ResponseWriter) {
+	switch errors.Cause(err) {
+	case types.ErrChannelNotExist, types.ErrLearnerNotExist:
+		h.sendResponseJsonError(resp, http.StatusNotFound, errors.Wrap(err, msg))
+	case msgprocessor.ErrPermissionDenied:
+		h.sendResponseJsonError(resp, http.StatusForbidden, errors.Wrap(err, msg))
+	case msgprocessor.ErrMaintenanceMode, types.ErrConsenterUnavailable, types.ErrLearnerNotCaughtUp:
+		h.sendResponseJsonError(resp, http.StatusServiceUnavailable, errors.Wrap(err, msg))
+	default:
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Wrap(err, msg))
+	}
+}
+
 func (h *HTTPHandler) serveBadContentType(resp http.ResponseWriter, req *http.Request) {
 	err := errors.Errorf("unsupported Content-Type: %s", req.Header.Values("Content-Type"))
 	h.sendResponseJsonError(resp, http.StatusBadRequest, err)
@@ -339,7 +544,18 @@ func (h *HTTPHandler) serveBadContentType(resp http.ResponseWriter, req *http.Re
 func (h *HTTPHandler) serveNotAllowed(resp http.ResponseWriter, req *http.Request) {
 	err := errors.Errorf("invalid request method: %s", req.Method)
 
-	if _, ok := mux.Vars(req)[channelIDKey]; ok {
+	vars := mux.Vars(req)
+	if _, ok := vars[blockKey]; ok {
+		h.sendResponseNotAllowed(resp, err, http.MethodGet)
+		return
+	}
+
+	if strings.HasSuffix(req.URL.Path, "/config-update") || strings.HasSuffix(req.URL.Path, "/promote") {
+		h.sendResponseNotAllowed(resp, err, http.MethodPost)
+		return
+	}
+
+	if _, ok := vars[channelIDKey]; ok {
 		h.sendResponseNotAllowed(resp, err, http.MethodGet, http.MethodPost, http.MethodDelete)
 		return
 	}
@@ -365,6 +581,28 @@ func negotiateContentType(req *http.Request) (string, error) {
 	return "", errors.New("response Content-Type is application/json only")
 }
 
+// negotiateBlockContentType allows, in addition to JSON, blocks to be returned as marshaled protobuf.
+func negotiateBlockContentType(req *http.Request) (string, error) {
+	acceptReq := req.Header.Get("Accept")
+	if len(acceptReq) == 0 {
+		return contentTypeJSON, nil
+	}
+
+	options := strings.Split(acceptReq, ",")
+	for _, opt := range options {
+		if strings.Contains(opt, contentTypeProtobufBytes) {
+			return contentTypeProtobufBytes, nil
+		}
+		if strings.Contains(opt, "application/json") ||
+			strings.Contains(opt, "application/*") ||
+			strings.Contains(opt, "*/*") {
+			return contentTypeJSON, nil
+		}
+	}
+
+	return "", errors.New("response Content-Type is application/json or application/octet-stream only")
+}
+
 func (h *HTTPHandler) sendResponseJsonError(resp http.ResponseWriter, code int, err error) {
 	encoder := json.NewEncoder(resp)
 	resp.Header().Set("Content-Type", "application/json")
@@ -403,3 +641,29 @@ func (h *HTTPHandler) sendResponseNotAllowed(resp http.ResponseWriter, err error
 		h.logger.Errorf("failed to encode error, err: %s", err)
 	}
 }
+
+func (h *HTTPHandler) sendResponseBlockJSON(resp http.ResponseWriter, block *cb.Block) {
+	buff := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buff, block); err != nil {
+		h.sendResponseJsonError(resp, http.StatusInternalServerError, errors.Wrap(err, "cannot encode block"))
+		return
+	}
+	resp.Header().Set("Content-Type", contentTypeJSON)
+	resp.WriteHeader(http.StatusOK)
+	if _, err := resp.Write(buff.Bytes()); err != nil {
+		h.logger.Errorf("failed to write block, err: %s", err)
+	}
+}
+
+func (h *HTTPHandler) sendResponseBlockBytes(resp http.ResponseWriter, block *cb.Block) {
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusInternalServerError, errors.Wrap(err, "cannot encode block"))
+		return
+	}
+	resp.Header().Set("Content-Type", contentTypeProtobufBytes)
+	resp.WriteHeader(http.StatusOK)
+	if _, err := resp.Write(blockBytes); err != nil {
+		h.logger.Errorf("failed to write block, err: %s", err)
+	}
+}
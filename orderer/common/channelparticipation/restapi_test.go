@@ -17,10 +17,12 @@ import (
 	"path"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/channelparticipation/mocks"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -62,6 +64,39 @@ func TestHTTPHandler_ServeHTTP_InvalidMethods(t *testing.T) {
 		}
 	})
 
+	t.Run("on /channels/ch-id/blocks/newest", func(t *testing.T) {
+		invalidMethodsExt := append(invalidMethods, http.MethodDelete, http.MethodPost)
+		for _, method := range invalidMethodsExt {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(method, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "newest"), nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, http.StatusMethodNotAllowed, fmt.Sprintf("invalid request method: %s", method), resp)
+			assert.Equal(t, "GET", resp.Result().Header.Get("Allow"), "%s", method)
+		}
+	})
+
+	t.Run("on /channels/ch-id/config-update", func(t *testing.T) {
+		invalidMethodsExt := append(invalidMethods, http.MethodDelete, http.MethodGet)
+		for _, method := range invalidMethodsExt {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(method, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "config-update"), nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, http.StatusMethodNotAllowed, fmt.Sprintf("invalid request method: %s", method), resp)
+			assert.Equal(t, "POST", resp.Result().Header.Get("Allow"), "%s", method)
+		}
+	})
+
+	t.Run("on /channels/ch-id/promote", func(t *testing.T) {
+		invalidMethodsExt := append(invalidMethods, http.MethodDelete, http.MethodGet)
+		for _, method := range invalidMethodsExt {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(method, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "promote"), nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, http.StatusMethodNotAllowed, fmt.Sprintf("invalid request method: %s", method), resp)
+			assert.Equal(t, "POST", resp.Result().Header.Get("Allow"), "%s", method)
+		}
+	})
+
 	t.Run("on /channels", func(t *testing.T) {
 		invalidMethodsExt := append(invalidMethods, http.MethodDelete, http.MethodPost)
 		for _, method := range invalidMethodsExt {
@@ -511,6 +546,296 @@ func TestHTTPHandler_ServeHTTP_Remove(t *testing.T) {
 	})
 }
 
+func TestHTTPHandler_ServeHTTP_Block(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true, RemoveStorage: false}
+
+	configBlock := blockWithGroups(map[string]*common.ConfigGroup{"Application": {}}, "ch-id")
+	configBlock.Header = &common.BlockHeader{Number: 0}
+	dataBlock := nonConfigBlock()
+	dataBlock.Header = &common.BlockHeader{Number: 1, PreviousHash: protoutil.BlockHeaderHash(configBlock.Header)}
+	dataBlock.Metadata = &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))}
+	dataBlock.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&common.Metadata{
+		Value: protoutil.MarshalOrPanic(&common.OrdererBlockMetadata{
+			LastConfig: &common.LastConfig{Index: 0},
+		}),
+	})
+	blocks := []*common.Block{configBlock, dataBlock}
+
+	setupBlocks := func(t *testing.T) (*mocks.ChannelManagement, *channelparticipation.HTTPHandler) {
+		fakeManager, h := setup(config, t)
+		fakeManager.ChannelInfoReturns(types.ChannelInfo{Name: "ch-id", Height: 2}, nil)
+		fakeManager.ChannelBlockStub = func(channelID string, number uint64) (*common.Block, error) {
+			if number >= uint64(len(blocks)) {
+				return nil, errors.WithMessagef(types.ErrBlockNotExist, "block [%d] is not in the ledger of height %d", number, len(blocks))
+			}
+			return blocks[number], nil
+		}
+		return fakeManager, h
+	}
+
+	t.Run("by number, JSON", func(t *testing.T) {
+		fakeManager, h := setupBlocks(t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "1"), nil)
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+		assert.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+		require.Equal(t, 1, fakeManager.ChannelBlockCallCount())
+		channelID, number := fakeManager.ChannelBlockArgsForCall(0)
+		assert.Equal(t, "ch-id", channelID)
+		assert.Equal(t, uint64(1), number)
+
+		block := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &block))
+		assert.Equal(t, "1", block["header"].(map[string]interface{})["number"])
+	})
+
+	t.Run("newest, protobuf", func(t *testing.T) {
+		fakeManager, h := setupBlocks(t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "newest"), nil)
+		req.Header.Set("Accept", "application/octet-stream")
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Equal(t, "application/octet-stream", resp.Result().Header.Get("Content-Type"))
+		assert.Equal(t, protoutil.MarshalOrPanic(dataBlock), resp.Body.Bytes())
+		assert.Equal(t, 1, fakeManager.ChannelInfoCallCount())
+	})
+
+	t.Run("config, protobuf", func(t *testing.T) {
+		fakeManager, h := setupBlocks(t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "config"), nil)
+		req.Header.Set("Accept", "application/octet-stream")
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Equal(t, protoutil.MarshalOrPanic(configBlock), resp.Body.Bytes())
+		require.Equal(t, 2, fakeManager.ChannelBlockCallCount())
+		_, number := fakeManager.ChannelBlockArgsForCall(1)
+		assert.Equal(t, uint64(0), number)
+	})
+
+	t.Run("Error: bad block", func(t *testing.T) {
+		_, h := setupBlocks(t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "oldest"), nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusBadRequest, "cannot fetch block: invalid block: oldest, expected a block number, newest or config", resp)
+	})
+
+	t.Run("Error: block does not exist", func(t *testing.T) {
+		_, h := setupBlocks(t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "7"), nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotFound, "cannot fetch block: block [7] is not in the ledger of height 2: block does not exist", resp)
+	})
+
+	t.Run("Error: channel does not exist", func(t *testing.T) {
+		fakeManager, h := setupBlocks(t)
+		fakeManager.ChannelInfoReturns(types.ChannelInfo{}, types.ErrChannelNotExist)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "newest"), nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotFound, "cannot fetch block: channel does not exist", resp)
+	})
+
+	t.Run("Error: empty ledger", func(t *testing.T) {
+		fakeManager, h := setupBlocks(t)
+		fakeManager.ChannelInfoReturns(types.ChannelInfo{Name: "ch-id", Height: 0}, nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "config"), nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotFound, "cannot fetch block: the ledger is empty: block does not exist", resp)
+	})
+
+	t.Run("Error: bad Accept header", func(t *testing.T) {
+		_, h := setupBlocks(t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "blocks", "0"), nil)
+		req.Header.Set("Accept", "text/html")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotAcceptable, "response Content-Type is application/json or application/octet-stream only", resp)
+	})
+}
+
+func TestHTTPHandler_ServeHTTP_ConfigUpdate(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true, RemoveStorage: false}
+	env := &common.Envelope{Payload: []byte("payload"), Signature: []byte("signature")}
+
+	t.Run("accepted", func(t *testing.T) {
+		fakeManager, h := setup(config, t)
+		resp := httptest.NewRecorder()
+		req := genConfigUpdateRequestFormData(t, protoutil.MarshalOrPanic(env))
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusAccepted, resp.Result().StatusCode)
+		assert.Equal(t, "/participation/v1/channels/ch-id", resp.Result().Header.Get("Location"))
+		require.Equal(t, 1, fakeManager.SubmitConfigUpdateCallCount())
+		channelID, configUpdate := fakeManager.SubmitConfigUpdateArgsForCall(0)
+		assert.Equal(t, "ch-id", channelID)
+		assert.True(t, proto.Equal(env, configUpdate))
+	})
+
+	type testDef struct {
+		name         string
+		submitErr    error
+		expectedCode int
+		expectedErr  string
+	}
+
+	testCases := []testDef{
+		{
+			name:         "Error: channel does not exist",
+			submitErr:    types.ErrChannelNotExist,
+			expectedCode: http.StatusNotFound,
+			expectedErr:  "cannot update config: channel does not exist",
+		},
+		{
+			name:         "Error: permission denied",
+			submitErr:    errors.WithMessage(msgprocessor.ErrPermissionDenied, "config update rejected"),
+			expectedCode: http.StatusForbidden,
+			expectedErr:  "cannot update config: config update rejected: permission denied",
+		},
+		{
+			name:         "Error: maintenance mode",
+			submitErr:    errors.WithMessage(msgprocessor.ErrMaintenanceMode, "config update rejected"),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedErr:  "cannot update config: config update rejected: maintenance mode",
+		},
+		{
+			name:         "Error: consenter unavailable",
+			submitErr:    errors.WithMessage(types.ErrConsenterUnavailable, "not a leader"),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedErr:  "cannot update config: not a leader: consenter unavailable",
+		},
+		{
+			name:         "Error: invalid config update",
+			submitErr:    errors.New("config update rejected: oops"),
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "cannot update config: config update rejected: oops",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeManager, h := setup(config, t)
+			fakeManager.SubmitConfigUpdateReturns(testCase.submitErr)
+			resp := httptest.NewRecorder()
+			req := genConfigUpdateRequestFormData(t, protoutil.MarshalOrPanic(env))
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, testCase.expectedCode, testCase.expectedErr, resp)
+		})
+	}
+
+	t.Run("bad body - not an envelope", func(t *testing.T) {
+		fakeManager, h := setup(config, t)
+		resp := httptest.NewRecorder()
+		req := genConfigUpdateRequestFormData(t, []byte{1, 2, 3, 4})
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusBadRequest, "cannot unmarshal file part config-update into an envelope: proto: common.Envelope: illegal tag 0 (wire type 1)", resp)
+		assert.Equal(t, 0, fakeManager.SubmitConfigUpdateCallCount())
+	})
+
+	t.Run("form-data: bad form - wrong key", func(t *testing.T) {
+		_, h := setup(config, t)
+		resp := httptest.NewRecorder()
+		req := genJoinRequestFormData(t, protoutil.MarshalOrPanic(env))
+		req.URL.Path = path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "config-update")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusBadRequest, "form does not contains part key: config-update", resp)
+	})
+
+	t.Run("content type mismatch", func(t *testing.T) {
+		_, h := setup(config, t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "config-update"), nil)
+		req.Header.Set("Content-Type", "text/plain")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusBadRequest, "unsupported Content-Type: [text/plain]", resp)
+	})
+}
+
+func TestHTTPHandler_ServeHTTP_Promote(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true, RemoveStorage: false}
+	promoteURL := path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "promote") + "?consenter=orderer4.example.com:7050"
+
+	t.Run("accepted", func(t *testing.T) {
+		fakeManager, h := setup(config, t)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, promoteURL, nil)
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusAccepted, resp.Result().StatusCode)
+		assert.Equal(t, "/participation/v1/channels/ch-id", resp.Result().Header.Get("Location"))
+		require.Equal(t, 1, fakeManager.PromoteLearnerCallCount())
+		channelID, consenter := fakeManager.PromoteLearnerArgsForCall(0)
+		assert.Equal(t, "ch-id", channelID)
+		assert.Equal(t, "orderer4.example.com:7050", consenter)
+	})
+
+	type testDef struct {
+		name         string
+		promoteErr   error
+		expectedCode int
+		expectedErr  string
+	}
+
+	testCases := []testDef{
+		{
+			name:         "Error: channel does not exist",
+			promoteErr:   types.ErrChannelNotExist,
+			expectedCode: http.StatusNotFound,
+			expectedErr:  "cannot promote: channel does not exist",
+		},
+		{
+			name:         "Error: learner does not exist",
+			promoteErr:   errors.WithMessage(types.ErrLearnerNotExist, "consenter orderer4.example.com:7050 is not a learner of channel ch-id"),
+			expectedCode: http.StatusNotFound,
+			expectedErr:  "cannot promote: consenter orderer4.example.com:7050 is not a learner of channel ch-id: learner does not exist",
+		},
+		{
+			name:         "Error: learner has not caught up",
+			promoteErr:   errors.WithMessage(types.ErrLearnerNotCaughtUp, "learner 4 (orderer4.example.com:7050) lags behind the leader"),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedErr:  "cannot promote: learner 4 (orderer4.example.com:7050) lags behind the leader: learner has not caught up",
+		},
+		{
+			name:         "Error: learners not supported",
+			promoteErr:   types.ErrLearnersNotSupported,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "cannot promote: learners are not supported",
+		},
+		{
+			name:         "Error: permission denied",
+			promoteErr:   errors.WithMessage(msgprocessor.ErrPermissionDenied, "config update rejected"),
+			expectedCode: http.StatusForbidden,
+			expectedErr:  "cannot promote: config update rejected: permission denied",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeManager, h := setup(config, t)
+			fakeManager.PromoteLearnerReturns(testCase.promoteErr)
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, promoteURL, nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, testCase.expectedCode, testCase.expectedErr, resp)
+		})
+	}
+
+	for _, query := range []string{"", "?consenter=", "?consenter=a:1&consenter=b:2", "?consenter=a:1&other=x", "?other=x"} {
+		t.Run("bad query: "+query, func(t *testing.T) {
+			fakeManager, h := setup(config, t)
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "promote")+query, nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, http.StatusBadRequest, "cannot promote: expected a single query parameter: consenter", resp)
+			assert.Equal(t, 0, fakeManager.PromoteLearnerCallCount())
+		})
+	}
+}
+
 func setup(config localconfig.ChannelParticipation, t *testing.T) (*mocks.ChannelManagement, *channelparticipation.HTTPHandler) {
 	fakeManager := &mocks.ChannelManagement{}
 	h := channelparticipation.NewHTTPHandler(config, fakeManager)
@@ -548,6 +873,21 @@ func genJoinRequestFormData(t *testing.T, blockBytes []byte) *http.Request {
 	return req
 }
 
+func genConfigUpdateRequestFormData(t *testing.T, envBytes []byte) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(channelparticipation.FormDataConfigUpdateKey, "config-update.pb")
+	require.NoError(t, err)
+	part.Write(envBytes)
+	err = writer.Close()
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, path.Join(channelparticipation.URLBaseV1Channels, "ch-id", "config-update"), body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
 func validBlockBytes(channelID string) []byte {
 	blockBytes := protoutil.MarshalOrPanic(blockWithGroups(map[string]*common.ConfigGroup{
 		"Application": {},
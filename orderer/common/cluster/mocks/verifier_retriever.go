@@ -12,6 +12,22 @@ type VerifierRetriever struct {
 	mock.Mock
 }
 
+// RetrieveHashingAlgorithm provides a mock function with given fields: channel
+func (_m *VerifierRetriever) RetrieveHashingAlgorithm(channel string) func([]byte) []byte {
+	ret := _m.Called(channel)
+
+	var r0 func([]byte) []byte
+	if rf, ok := ret.Get(0).(func(string) func([]byte) []byte); ok {
+		r0 = rf(channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func([]byte) []byte)
+		}
+	}
+
+	return r0
+}
+
 // RetrieveVerifier provides a mock function with given fields: channel
 func (_m *VerifierRetriever) RetrieveVerifier(channel string) cluster.BlockVerifier {
 	ret := _m.Called(channel)
@@ -15,6 +15,8 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
@@ -97,6 +99,7 @@ type Replicator struct {
 	BootBlock                       *common.Block
 	AmIPartOfChannel                SelfMembershipPredicate
 	LedgerFactory                   LedgerFactory
+	VerifierRetriever               VerifierRetriever
 }
 
 // IsReplicationNeeded returns whether replication is needed,
@@ -221,7 +224,11 @@ func (r *Replicator) pullChannelBlocks(channel string, puller *BlockPuller, late
 		return ErrRetryCountExhausted
 	}
 	r.appendBlock(nextBlock, ledger, channel)
-	actualPrevHash := protoutil.BlockHeaderHash(nextBlock.Header)
+	hashingAlgorithm := r.VerifierRetriever.RetrieveHashingAlgorithm(channel)
+	if hashingAlgorithm == nil {
+		return errors.Errorf("couldn't acquire block hashing algorithm for channel %s", channel)
+	}
+	actualPrevHash := protoutil.BlockHeaderHashWith(nextBlock.Header, hashingAlgorithm)
 
 	for seq := uint64(nextBlockToPull + 1); seq < latestHeight; seq++ {
 		block := puller.PullBlock(seq)
@@ -233,9 +240,9 @@ func (r *Replicator) pullChannelBlocks(channel string, puller *BlockPuller, late
 			return errors.Errorf("block header mismatch on sequence %d, expected %x, got %x",
 				block.Header.Number, actualPrevHash, reportedPrevHash)
 		}
-		actualPrevHash = protoutil.BlockHeaderHash(block.Header)
+		actualPrevHash = protoutil.BlockHeaderHashWith(block.Header, hashingAlgorithm)
 		if channel == r.SystemChannel && block.Header.Number == r.BootBlock.Header.Number {
-			r.compareBootBlockWithSystemChannelLastConfigBlock(block)
+			r.compareBootBlockWithSystemChannelLastConfigBlock(block, hashingAlgorithm)
 			r.appendBlock(block, ledger, channel)
 			// No need to pull further blocks from the system channel
 			return nil
@@ -257,12 +264,12 @@ func (r *Replicator) appendBlock(block *common.Block, ledger LedgerWriter, chann
 	r.Logger.Infof("Committed block [%d] for channel %s", block.Header.Number, channel)
 }
 
-func (r *Replicator) compareBootBlockWithSystemChannelLastConfigBlock(block *common.Block) {
+func (r *Replicator) compareBootBlockWithSystemChannelLastConfigBlock(block *common.Block, hashingAlgorithm func([]byte) []byte) {
 	// Overwrite the received block's data hash
-	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	block.Header.DataHash = protoutil.BlockDataHashWith(block.Data, hashingAlgorithm)
 
-	bootBlockHash := protoutil.BlockHeaderHash(r.BootBlock.Header)
-	retrievedBlockHash := protoutil.BlockHeaderHash(block.Header)
+	bootBlockHash := protoutil.BlockHeaderHashWith(r.BootBlock.Header, hashingAlgorithm)
+	retrievedBlockHash := protoutil.BlockHeaderHashWith(block.Header, hashingAlgorithm)
 	if bytes.Equal(bootBlockHash, retrievedBlockHash) {
 		return
 	}
@@ -347,10 +354,12 @@ type PullerConfig struct {
 
 //go:generate mockery -dir . -name VerifierRetriever -case underscore -output mocks/
 
-// VerifierRetriever retrieves BlockVerifiers for channels.
+// VerifierRetriever retrieves BlockVerifiers and block hashing algorithms for channels.
 type VerifierRetriever interface {
 	// RetrieveVerifier retrieves a BlockVerifier for the given channel.
 	RetrieveVerifier(channel string) BlockVerifier
+	// RetrieveHashingAlgorithm retrieves the block hashing algorithm of the given channel.
+	RetrieveHashingAlgorithm(channel string) func([]byte) []byte
 }
 
 // BlockPullerFromConfigBlock returns a BlockPuller that doesn't verify signatures on blocks.
@@ -392,7 +401,11 @@ func BlockPullerFromConfigBlock(conf PullerConfig, block *common.Block, verifier
 			if verifier == nil {
 				return errors.Errorf("couldn't acquire verifier for channel %s", channel)
 			}
-			return VerifyBlocks(blocks, verifier)
+			hashingAlgorithm := verifierRetriever.RetrieveHashingAlgorithm(channel)
+			if hashingAlgorithm == nil {
+				return errors.Errorf("couldn't acquire block hashing algorithm for channel %s", channel)
+			}
+			return VerifyBlocks(blocks, verifier, hashingAlgorithm)
 		},
 		MaxTotalBufferBytes: conf.MaxTotalBufferBytes,
 		Endpoints:           endpoints,
@@ -537,6 +550,10 @@ func (gbs GenesisBlocks) Names() []string {
 // for all channels. Each such ChannelGenesisBlock contains
 // the genesis block of the channel.
 func (ci *ChainInspector) Channels() []ChannelGenesisBlock {
+	hashingAlgorithm, err := channelconfig.BlockHashingAlgorithmFromConfigBlock(ci.LastConfigBlock)
+	if err != nil {
+		ci.Logger.Panicf("Failed extracting block hashing algorithm from the last config block: %v", err)
+	}
 	channels := make(map[string]ChannelGenesisBlock)
 	lastConfigBlockNum := ci.LastConfigBlock.Header.Number
 	var block *common.Block
@@ -548,7 +565,7 @@ func (ci *ChainInspector) Channels() []ChannelGenesisBlock {
 		}
 		ci.validateHashPointer(block, prevHash)
 		// Set the previous hash for the next iteration
-		prevHash = protoutil.BlockHeaderHash(block.Header)
+		prevHash = protoutil.BlockHeaderHashWith(block.Header, hashingAlgorithm)
 
 		channel, gb, err := ExtractGenesisBlock(ci.Logger, block)
 		if err != nil {
@@ -574,7 +591,7 @@ func (ci *ChainInspector) Channels() []ChannelGenesisBlock {
 	// We don't need to verify the entire chain of all blocks we pulled,
 	// because the block puller calls VerifyBlockHash on all blocks it pulls.
 	last2Blocks := []*common.Block{block, ci.LastConfigBlock}
-	if err := VerifyBlockHash(1, last2Blocks); err != nil {
+	if err := VerifyBlockHash(1, last2Blocks, hashingAlgorithm); err != nil {
 		ci.Logger.Panic("System channel pulled doesn't match the boot last config block:", err)
 	}
 
@@ -652,6 +669,14 @@ func ExtractGenesisBlock(logger *flogging.FabricLogger, block *common.Block) (st
 			"channel other than system channel '%s'", common.HeaderType_ORDERER_TRANSACTION, systemChannelName)
 		return "", nil, nil
 	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(innerPayload.Data)
+	if err != nil {
+		return "", nil, err
+	}
+	hashingAlgorithm, err := channelconfig.BlockHashingAlgorithmFromChannelGroup(configEnvelope.GetConfig().GetChannelGroup())
+	if err != nil {
+		return "", nil, err
+	}
 
 	metadata := &common.BlockMetadata{
 		Metadata: make([][]byte, 4),
@@ -669,7 +694,7 @@ func ExtractGenesisBlock(logger *flogging.FabricLogger, block *common.Block) (st
 
 	blockdata := &common.BlockData{Data: [][]byte{payload.Data}}
 	b := &common.Block{
-		Header:   &common.BlockHeader{DataHash: protoutil.BlockDataHash(blockdata)},
+		Header:   &common.BlockHeader{DataHash: protoutil.BlockDataHashWith(blockdata, hashingAlgorithm)},
 		Data:     blockdata,
 		Metadata: metadata,
 	}
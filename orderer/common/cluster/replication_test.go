@@ -20,7 +20,9 @@ import (
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/cluster/mocks"
@@ -207,12 +209,13 @@ func TestReplicateChainsFailures(t *testing.T) {
 				AmIPartOfChannel: func(configBlock *common.Block) error {
 					return cluster.ErrNotInChannel
 				},
-				Logger:        flogging.MustGetLogger("test"),
-				BootBlock:     systemChannelBlocks[21],
-				SystemChannel: "system",
-				LedgerFactory: lf,
-				Puller:        bp,
-				ChannelLister: cl,
+				Logger:            flogging.MustGetLogger("test"),
+				BootBlock:         systemChannelBlocks[21],
+				SystemChannel:     "system",
+				LedgerFactory:     lf,
+				Puller:            bp,
+				ChannelLister:     cl,
+				VerifierRetriever: sha256VerifierRetriever(),
 			}
 
 			if len(testCase.channelsReturns) > 0 {
@@ -302,10 +305,11 @@ func TestPullChannelFailure(t *testing.T) {
 				AmIPartOfChannel: func(configBlock *common.Block) error {
 					return nil
 				},
-				Logger:        flogging.MustGetLogger("test"),
-				SystemChannel: "system",
-				LedgerFactory: lf,
-				Puller:        bp,
+				Logger:            flogging.MustGetLogger("test"),
+				SystemChannel:     "system",
+				LedgerFactory:     lf,
+				Puller:            bp,
+				VerifierRetriever: sha256VerifierRetriever(),
 			}
 
 			osn.addExpectProbeAssert()
@@ -532,11 +536,12 @@ func TestReplicateChainsGreenPath(t *testing.T) {
 		AmIPartOfChannel: func(configBlock *common.Block) error {
 			return amIPartOfChannelMock.Called().Error(0)
 		},
-		Logger:        flogging.MustGetLogger("test"),
-		SystemChannel: "system",
-		ChannelLister: channelLister,
-		Puller:        bp,
-		BootBlock:     systemChannelBlocks[21],
+		Logger:            flogging.MustGetLogger("test"),
+		SystemChannel:     "system",
+		ChannelLister:     channelLister,
+		Puller:            bp,
+		BootBlock:         systemChannelBlocks[21],
+		VerifierRetriever: sha256VerifierRetriever(),
 	}
 
 	// The first thing the orderer gets is a seek to channel E.
@@ -902,6 +907,7 @@ func testBlockPullerFromConfig(t *testing.T, blockVerifiers []cluster.BlockVerif
 	for _, blockVerifier := range blockVerifiers {
 		verifierRetriever.On("RetrieveVerifier", mock.Anything).Return(blockVerifier).Once()
 	}
+	verifierRetriever.On("RetrieveHashingAlgorithm", mock.Anything).Return(util.ComputeSHA256)
 
 	caCert, err := ioutil.ReadFile(filepath.Join("testdata", "ca.crt"))
 	assert.NoError(t, err)
@@ -1018,10 +1024,11 @@ func TestSkipPullingPulledChannels(t *testing.T) {
 		AmIPartOfChannel: func(configBlock *common.Block) error {
 			return nil
 		},
-		Logger:        flogging.MustGetLogger("test"),
-		SystemChannel: "system",
-		LedgerFactory: lf,
-		Puller:        bp,
+		Logger:            flogging.MustGetLogger("test"),
+		SystemChannel:     "system",
+		LedgerFactory:     lf,
+		Puller:            bp,
+		VerifierRetriever: sha256VerifierRetriever(),
 	}
 
 	var detectedChannelPulled bool
@@ -1524,6 +1531,10 @@ func TestChannels(t *testing.T) {
 				makeBlock("systemChannel", "mychannel2"),
 				makeBlock("systemChannel", "systemChannel"),
 			}
+			// The last config block carries the block hashing algorithm of the system channel
+			lastConfigBlock, err := test.MakeGenesisBlock("systemChannel")
+			assert.NoError(t, err)
+			systemChain[len(systemChain)-1].Data = lastConfigBlock.Data
 
 			for i := 0; i < len(systemChain); i++ {
 				systemChain[i].Header.DataHash = protoutil.BlockDataHash(systemChain[i].Data)
@@ -1617,3 +1628,9 @@ func TestFilter(t *testing.T) {
 	}
 	assert.Equal(t, cluster.ErrSkipped, r.PullChannel("foo"))
 }
+
+func sha256VerifierRetriever() *mocks.VerifierRetriever {
+	verifierRetriever := &mocks.VerifierRetriever{}
+	verifierRetriever.On("RetrieveHashingAlgorithm", mock.Anything).Return(util.ComputeSHA256)
+	return verifierRetriever
+}
@@ -220,8 +220,9 @@ type Dialer interface {
 }
 
 // VerifyBlocks verifies the given consecutive sequence of blocks is valid,
+// with the block hashing algorithm of their channel,
 // and returns nil if it's valid, else an error.
-func VerifyBlocks(blockBuff []*common.Block, signatureVerifier BlockVerifier) error {
+func VerifyBlocks(blockBuff []*common.Block, signatureVerifier BlockVerifier, hashingAlgorithm func([]byte) []byte) error {
 	if len(blockBuff) == 0 {
 		return errors.New("buffer is empty")
 	}
@@ -229,7 +230,7 @@ func VerifyBlocks(blockBuff []*common.Block, signatureVerifier BlockVerifier) er
 	// Equal to the hash in the header
 	// Equal to the previous hash in the succeeding block
 	for i := range blockBuff {
-		if err := VerifyBlockHash(i, blockBuff); err != nil {
+		if err := VerifyBlockHash(i, blockBuff, hashingAlgorithm); err != nil {
 			return err
 		}
 	}
@@ -309,8 +310,8 @@ func ConfigFromBlock(block *common.Block) (*common.ConfigEnvelope, error) {
 }
 
 // VerifyBlockHash verifies the hash chain of the block with the given index
-// among the blocks of the given block buffer.
-func VerifyBlockHash(indexInBuffer int, blockBuff []*common.Block) error {
+// among the blocks of the given block buffer, with the given block hashing algorithm.
+func VerifyBlockHash(indexInBuffer int, blockBuff []*common.Block, hashingAlgorithm func([]byte) []byte) error {
 	if len(blockBuff) <= indexInBuffer {
 		return errors.Errorf("index %d out of bounds (total %d blocks)", indexInBuffer, len(blockBuff))
 	}
@@ -319,7 +320,7 @@ func VerifyBlockHash(indexInBuffer int, blockBuff []*common.Block) error {
 		return errors.New("missing block header")
 	}
 	seq := block.Header.Number
-	dataHash := protoutil.BlockDataHash(block.Data)
+	dataHash := protoutil.BlockDataHashWith(block.Data, hashingAlgorithm)
 	// Verify data hash matches the hash in the header
 	if !bytes.Equal(dataHash, block.Header.DataHash) {
 		computedHash := hex.EncodeToString(dataHash)
@@ -338,9 +339,9 @@ func VerifyBlockHash(indexInBuffer int, blockBuff []*common.Block) error {
 		if prevSeq+1 != currSeq {
 			return errors.Errorf("sequences %d and %d were received consecutively", prevSeq, currSeq)
 		}
-		if !bytes.Equal(block.Header.PreviousHash, protoutil.BlockHeaderHash(prevBlock.Header)) {
+		if !bytes.Equal(block.Header.PreviousHash, protoutil.BlockHeaderHashWith(prevBlock.Header, hashingAlgorithm)) {
 			claimedPrevHash := hex.EncodeToString(block.Header.PreviousHash)
-			actualPrevHash := hex.EncodeToString(protoutil.BlockHeaderHash(prevBlock.Header))
+			actualPrevHash := hex.EncodeToString(protoutil.BlockHeaderHashWith(prevBlock.Header, hashingAlgorithm))
 			return errors.Errorf("block [%d]'s hash (%s) mismatches block [%d]'s prev block hash (%s)",
 				prevSeq, actualPrevHash, currSeq, claimedPrevHash)
 		}
@@ -517,16 +518,20 @@ type VerifierFactory interface {
 	VerifierFromConfig(configuration *common.ConfigEnvelope, channel string) (BlockVerifier, error)
 }
 
-// VerificationRegistry registers verifiers and retrieves them.
+// VerificationRegistry registers verifiers and block hashing algorithms and retrieves them.
 type VerificationRegistry struct {
-	LoadVerifier       func(chain string) BlockVerifier
-	Logger             *flogging.FabricLogger
-	VerifierFactory    VerifierFactory
-	VerifiersByChannel map[string]BlockVerifier
+	LoadVerifier               func(chain string) BlockVerifier
+	LoadHashingAlgorithm       func(chain string) func([]byte) []byte
+	Logger                     *flogging.FabricLogger
+	VerifierFactory            VerifierFactory
+	VerifiersByChannel         map[string]BlockVerifier
+	HashingAlgorithmsByChannel map[string]func([]byte) []byte
 }
 
-// RegisterVerifier adds a verifier into the registry if applicable.
+// RegisterVerifier adds a verifier and a block hashing algorithm into the registry if applicable.
 func (vr *VerificationRegistry) RegisterVerifier(chain string) {
+	vr.registerHashingAlgorithm(chain)
+
 	if _, exists := vr.VerifiersByChannel[chain]; exists {
 		vr.Logger.Debugf("No need to register verifier for chain %s", chain)
 		return
@@ -542,6 +547,30 @@ func (vr *VerificationRegistry) RegisterVerifier(chain string) {
 	vr.Logger.Infof("Registered verifier for chain %s", chain)
 }
 
+func (vr *VerificationRegistry) registerHashingAlgorithm(chain string) {
+	if _, exists := vr.HashingAlgorithmsByChannel[chain]; exists {
+		return
+	}
+
+	hashingAlgorithm := vr.LoadHashingAlgorithm(chain)
+	if hashingAlgorithm == nil {
+		vr.Logger.Errorf("Failed loading block hashing algorithm for chain %s", chain)
+		return
+	}
+
+	vr.HashingAlgorithmsByChannel[chain] = hashingAlgorithm
+}
+
+// RetrieveHashingAlgorithm returns the block hashing algorithm of the given channel, or nil if not found.
+func (vr *VerificationRegistry) RetrieveHashingAlgorithm(channel string) func([]byte) []byte {
+	hashingAlgorithm, exists := vr.HashingAlgorithmsByChannel[channel]
+	if exists {
+		return hashingAlgorithm
+	}
+	vr.Logger.Errorf("No block hashing algorithm for channel %s exists", channel)
+	return nil
+}
+
 // RetrieveVerifier returns a BlockVerifier for the given channel, or nil if not found.
 func (vr *VerificationRegistry) RetrieveVerifier(channel string) BlockVerifier {
 	verifier, exists := vr.VerifiersByChannel[channel]
@@ -579,6 +608,17 @@ func (vr *VerificationRegistry) BlockCommitted(block *common.Block, channel stri
 
 	vr.VerifiersByChannel[channel] = verifier
 
+	// The block hashing algorithm is fixed by the genesis block of the channel
+	if _, exists := vr.HashingAlgorithmsByChannel[channel]; !exists {
+		hashingAlgorithm, err := channelconfig.BlockHashingAlgorithmFromChannelGroup(conf.GetConfig().GetChannelGroup())
+		if err != nil {
+			vr.Logger.Errorf("Failed extracting the block hashing algorithm from a config block for channel %s: %v, content: %s",
+				channel, err, BlockToString(block))
+			return
+		}
+		vr.HashingAlgorithmsByChannel[channel] = hashingAlgorithm
+	}
+
 	vr.Logger.Debugf("Committed config block [%d] for channel %s", block.Header.Number, channel)
 }
 
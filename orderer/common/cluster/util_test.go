@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
@@ -253,7 +254,7 @@ func TestVerifyBlockHash(t *testing.T) {
 
 	verify := func(blockchain []*common.Block) error {
 		for i := 0; i < len(blockchain); i++ {
-			err := cluster.VerifyBlockHash(i, blockchain)
+			err := cluster.VerifyBlockHash(i, blockchain, util.ComputeSHA256)
 			if err != nil {
 				return err
 			}
@@ -266,11 +267,11 @@ func TestVerifyBlockHash(t *testing.T) {
 
 	twoBlocks := createBlockChain(2, 3)
 	twoBlocks[0].Header = nil
-	assert.EqualError(t, cluster.VerifyBlockHash(1, twoBlocks), "previous block header is nil")
+	assert.EqualError(t, cluster.VerifyBlockHash(1, twoBlocks, util.ComputeSHA256), "previous block header is nil")
 
 	// Index out of bounds
 	blockchain := createBlockChain(start, end)
-	err := cluster.VerifyBlockHash(100, blockchain)
+	err := cluster.VerifyBlockHash(100, blockchain, util.ComputeSHA256)
 	assert.EqualError(t, err, "index 100 out of bounds (total 21 blocks)")
 
 	for _, testCase := range []struct {
@@ -325,6 +326,25 @@ func TestVerifyBlockHash(t *testing.T) {
 	}
 }
 
+func TestVerifyBlockHashWithHashingAlgorithm(t *testing.T) {
+	blockchain := createBlockChain(3, 23)
+	for i, block := range blockchain {
+		block.Header.DataHash = protoutil.BlockDataHashWith(block.Data, util.ComputeGMSM3)
+		if i > 0 {
+			block.Header.PreviousHash = protoutil.BlockHeaderHashWith(blockchain[i-1].Header, util.ComputeGMSM3)
+		}
+	}
+
+	for i := range blockchain {
+		assert.NoError(t, cluster.VerifyBlockHash(i, blockchain, util.ComputeGMSM3))
+	}
+
+	// The blocks aren't verified with any other hashing algorithm
+	err := cluster.VerifyBlockHash(0, blockchain, util.ComputeSHA256)
+	assert.Contains(t, err.Error(), "computed hash of block (3)")
+	assert.Contains(t, err.Error(), "doesn't match claimed hash")
+}
+
 func TestVerifyBlocks(t *testing.T) {
 	var sigSet1 []*protoutil.SignedData
 	var sigSet2 []*protoutil.SignedData
@@ -516,7 +536,7 @@ func TestVerifyBlocks(t *testing.T) {
 			if testCase.configureVerifier != nil {
 				testCase.configureVerifier(verifier)
 			}
-			err := cluster.VerifyBlocks(blockchain, verifier)
+			err := cluster.VerifyBlocks(blockchain, verifier, util.ComputeSHA256)
 			if testCase.expectedError != "" {
 				assert.EqualError(t, err, testCase.expectedError)
 			} else {
@@ -941,9 +961,10 @@ func TestVerificationRegistryRegisterVerifier(t *testing.T) {
 		mock.Anything, "mychannel").Return(verifier, nil)
 
 	registry := &cluster.VerificationRegistry{
-		Logger:             flogging.MustGetLogger("test"),
-		VerifiersByChannel: make(map[string]cluster.BlockVerifier),
-		VerifierFactory:    verifierFactory,
+		Logger:                     flogging.MustGetLogger("test"),
+		VerifiersByChannel:         make(map[string]cluster.BlockVerifier),
+		HashingAlgorithmsByChannel: make(map[string]func([]byte) []byte),
+		VerifierFactory:            verifierFactory,
 	}
 
 	var loadCount int
@@ -953,17 +974,29 @@ func TestVerificationRegistryRegisterVerifier(t *testing.T) {
 		return verifier
 	}
 
+	var hashingAlgorithmLoadCount int
+	registry.LoadHashingAlgorithm = func(chain string) func([]byte) []byte {
+		assert.Equal(t, "mychannel", chain)
+		hashingAlgorithmLoadCount++
+		return util.ComputeGMSM3
+	}
+
 	v := registry.RetrieveVerifier("mychannel")
 	assert.Nil(t, v)
+	assert.Nil(t, registry.RetrieveHashingAlgorithm("mychannel"))
 
 	registry.RegisterVerifier("mychannel")
 	v = registry.RetrieveVerifier("mychannel")
 	assert.Equal(t, verifier, v)
 	assert.Equal(t, 1, loadCount)
+	hashingAlgorithm := registry.RetrieveHashingAlgorithm("mychannel")
+	assert.Equal(t, util.ComputeGMSM3([]byte("data")), hashingAlgorithm([]byte("data")))
+	assert.Equal(t, 1, hashingAlgorithmLoadCount)
 
 	// If the verifier exists, this is a no-op
 	registry.RegisterVerifier("mychannel")
 	assert.Equal(t, 1, loadCount)
+	assert.Equal(t, 1, hashingAlgorithmLoadCount)
 }
 
 func TestVerificationRegistry(t *testing.T) {
@@ -1058,9 +1091,10 @@ func TestVerificationRegistry(t *testing.T) {
 				mock.Anything, testCase.channelCommitted).Return(testCase.verifierFromConfig, testCase.verifierFromConfigErr)
 
 			registry := &cluster.VerificationRegistry{
-				Logger:             flogging.MustGetLogger("test"),
-				VerifiersByChannel: testCase.verifiersByChannel,
-				VerifierFactory:    verifierFactory,
+				Logger:                     flogging.MustGetLogger("test"),
+				VerifiersByChannel:         testCase.verifiersByChannel,
+				HashingAlgorithmsByChannel: make(map[string]func([]byte) []byte),
+				VerifierFactory:            verifierFactory,
 			}
 
 			loggedEntriesByMethods := make(map[string]struct{})
@@ -1075,6 +1109,9 @@ func TestVerificationRegistry(t *testing.T) {
 
 			assert.Equal(t, testCase.loggedMessages, loggedEntriesByMethods)
 			assert.Equal(t, testCase.expectedVerifier, verifier)
+			// The block hashing algorithm is registered along with the verifier
+			_, exists := registry.HashingAlgorithmsByChannel[testCase.channelRetrieved]
+			assert.Equal(t, testCase.expectedVerifier != nil, exists)
 		})
 	}
 }
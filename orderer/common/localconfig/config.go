@@ -54,6 +54,7 @@ type General struct {
 	Profile           Profile
 	LocalMSPDir       string
 	LocalMSPID        string
+	LocalMSPSM2ZA     bool
 	BCCSP             *bccsp.FactoryOpts
 	Authentication    Authentication
 }
@@ -63,8 +64,12 @@ type Cluster struct {
 	ListenPort                           uint16
 	ServerCertificate                    string
 	ServerPrivateKey                     string
+	ServerEncCertificate                 string
+	ServerEncPrivateKey                  string
 	ClientCertificate                    string
 	ClientPrivateKey                     string
+	ClientEncCertificate                 string
+	ClientEncPrivateKey                  string
 	RootCAs                              []string
 	DialTimeout                          time.Duration
 	RPCTimeout                           time.Duration
@@ -90,6 +95,8 @@ type TLS struct {
 	Enabled               bool
 	PrivateKey            string
 	Certificate           string
+	EncPrivateKey         string
+	EncCertificate        string
 	RootCAs               []string
 	ClientAuthRequired    bool
 	ClientRootCAs         []string
@@ -363,12 +370,24 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		if c.General.Cluster.ClientCertificate != "" {
 			coreconfig.TranslatePathInPlace(configDir, &c.General.Cluster.ClientCertificate)
 		}
+		if c.General.Cluster.ClientEncPrivateKey != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.Cluster.ClientEncPrivateKey)
+		}
+		if c.General.Cluster.ClientEncCertificate != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.Cluster.ClientEncCertificate)
+		}
 		c.General.Cluster.RootCAs = translateCAs(configDir, c.General.Cluster.RootCAs)
 		// Translate any paths for general TLS configuration
 		c.General.TLS.RootCAs = translateCAs(configDir, c.General.TLS.RootCAs)
 		c.General.TLS.ClientRootCAs = translateCAs(configDir, c.General.TLS.ClientRootCAs)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.PrivateKey)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
+		if c.General.TLS.EncPrivateKey != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.EncPrivateKey)
+		}
+		if c.General.TLS.EncCertificate != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.EncCertificate)
+		}
 		coreconfig.TranslatePathInPlace(configDir, &c.General.BootstrapFile)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
 		// Translate file ledger location
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	protoetcdraft "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/bccsp"
+	commonbft "github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/protoutil"
@@ -48,6 +49,7 @@ func NewMaintenanceFilter(support MaintenanceFilterSupport, bccsp bccsp.BCCSP) *
 	mf.permittedTargetConsensusTypes["etcdraft"] = true
 	mf.permittedTargetConsensusTypes["solo"] = true
 	mf.permittedTargetConsensusTypes["kafka"] = true
+	mf.permittedTargetConsensusTypes[commonbft.ConsensusType] = true
 	return mf
 }
 
@@ -118,7 +120,7 @@ func (mf *MaintenanceFilter) inspect(configEnvelope *cb.ConfigEnvelope, ordererC
 	}
 
 	// ConsensusType.Type can only change in maintenance-mode, and only within the set of permitted types.
-	// Note: only kafka to etcdraft or solo to etcdraft transitions are actually supported.
+	// Note: only kafka to etcdraft, solo to etcdraft and etcdraft to BFT transitions are actually supported.
 	if ordererConfig.ConsensusType() != nextOrdererConfig.ConsensusType() {
 		if ordererConfig.ConsensusState() == orderer.ConsensusType_STATE_NORMAL {
 			return errors.Errorf("attempted to change consensus type from %s to %s, but current config ConsensusType.State is not in maintenance mode",
@@ -134,6 +136,11 @@ func (mf *MaintenanceFilter) inspect(configEnvelope *cb.ConfigEnvelope, ordererC
 				ordererConfig.ConsensusType(), nextOrdererConfig.ConsensusType())
 		}
 
+		if ordererConfig.ConsensusType() == commonbft.ConsensusType {
+			return errors.Errorf("attempted to change consensus type from %s to %s, transition not supported",
+				ordererConfig.ConsensusType(), nextOrdererConfig.ConsensusType())
+		}
+
 		if nextOrdererConfig.ConsensusType() == "etcdraft" {
 			updatedMetadata := &protoetcdraft.ConfigMetadata{}
 			if err := proto.Unmarshal(nextOrdererConfig.ConsensusMetadata(), updatedMetadata); err != nil {
@@ -141,6 +148,20 @@ func (mf *MaintenanceFilter) inspect(configEnvelope *cb.ConfigEnvelope, ordererC
 			}
 		}
 
+		if nextOrdererConfig.ConsensusType() == commonbft.ConsensusType {
+			if ordererConfig.ConsensusType() != "etcdraft" {
+				return errors.Errorf("attempted to change consensus type from %s to %s, transition not supported",
+					ordererConfig.ConsensusType(), nextOrdererConfig.ConsensusType())
+			}
+			updatedMetadata := &commonbft.ConfigMetadata{}
+			if err := proto.Unmarshal(nextOrdererConfig.ConsensusMetadata(), updatedMetadata); err != nil {
+				return errors.Wrap(err, "failed to unmarshal BFT metadata configuration")
+			}
+			if err := commonbft.ValidateConfigMetadata(updatedMetadata); err != nil {
+				return errors.Wrap(err, "invalid BFT metadata configuration")
+			}
+		}
+
 		logger.Infof("[channel: %s] consensus-type migration: about to change from %s to %s",
 			mf.support.ChannelID(), ordererConfig.ConsensusType(), nextOrdererConfig.ConsensusType())
 	}
@@ -245,6 +245,44 @@ func TestMaintenanceInspectChange(t *testing.T) {
 	})
 }
 
+func TestMaintenanceInspectChangeToBFT(t *testing.T) {
+	mockOrderer := newMockOrdererConfig(true, orderer.ConsensusType_STATE_MAINTENANCE)
+	msActive := &mockSystemChannelFilterSupport{OrdererConfigVal: mockOrderer}
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	mf := NewMaintenanceFilter(msActive, cryptoProvider)
+	require.NotNil(t, mf)
+	raftMetadata := protoutil.MarshalOrPanic(&etcdraft.ConfigMetadata{})
+
+	t.Run("Bad: from kafka", func(t *testing.T) {
+		mockOrderer.ConsensusTypeReturns("kafka")
+		current := consensusTypeInfo{ordererType: "kafka", metadata: []byte{}, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		next := consensusTypeInfo{ordererType: "BFT", metadata: []byte{}, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		err := mf.Apply(makeConfigEnvelope(t, current, next))
+		assert.EqualError(t, err,
+			"config transaction inspection failed: attempted to change consensus type from kafka to BFT, transition not supported")
+	})
+
+	t.Run("Bad: BFT metadata", func(t *testing.T) {
+		mockOrderer.ConsensusTypeReturns("etcdraft")
+		current := consensusTypeInfo{ordererType: "etcdraft", metadata: raftMetadata, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		next := consensusTypeInfo{ordererType: "BFT", metadata: []byte{}, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		err := mf.Apply(makeConfigEnvelope(t, current, next))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"config transaction inspection failed: invalid BFT metadata configuration")
+	})
+
+	t.Run("Bad: from BFT", func(t *testing.T) {
+		mockOrderer.ConsensusTypeReturns("BFT")
+		current := consensusTypeInfo{ordererType: "BFT", metadata: []byte{}, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		next := consensusTypeInfo{ordererType: "etcdraft", metadata: raftMetadata, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		err := mf.Apply(makeConfigEnvelope(t, current, next))
+		assert.EqualError(t, err,
+			"config transaction inspection failed: attempted to change consensus type from BFT to etcdraft, transition not supported")
+	})
+}
+
 func TestMaintenanceInspectExit(t *testing.T) {
 	validMetadata := protoutil.MarshalOrPanic(&etcdraft.ConfigMetadata{})
 	mockOrderer := newMockOrdererConfig(true, orderer.ConsensusType_STATE_MAINTENANCE)
@@ -10,6 +10,18 @@ import (
 )
 
 type OrdererConfig struct {
+	AdaptiveBatchingStub        func() (channelconfig.AdaptiveBatching, bool)
+	adaptiveBatchingMutex       sync.RWMutex
+	adaptiveBatchingArgsForCall []struct {
+	}
+	adaptiveBatchingReturns struct {
+		result1 channelconfig.AdaptiveBatching
+		result2 bool
+	}
+	adaptiveBatchingReturnsOnCall map[int]struct {
+		result1 channelconfig.AdaptiveBatching
+		result2 bool
+	}
 	BatchSizeStub        func() *orderer.BatchSize
 	batchSizeMutex       sync.RWMutex
 	batchSizeArgsForCall []struct {
@@ -104,6 +116,61 @@ type OrdererConfig struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *OrdererConfig) AdaptiveBatching() (channelconfig.AdaptiveBatching, bool) {
+	fake.adaptiveBatchingMutex.Lock()
+	ret, specificReturn := fake.adaptiveBatchingReturnsOnCall[len(fake.adaptiveBatchingArgsForCall)]
+	fake.adaptiveBatchingArgsForCall = append(fake.adaptiveBatchingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AdaptiveBatching", []interface{}{})
+	fake.adaptiveBatchingMutex.Unlock()
+	if fake.AdaptiveBatchingStub != nil {
+		return fake.AdaptiveBatchingStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.adaptiveBatchingReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingCallCount() int {
+	fake.adaptiveBatchingMutex.RLock()
+	defer fake.adaptiveBatchingMutex.RUnlock()
+	return len(fake.adaptiveBatchingArgsForCall)
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingCalls(stub func() (channelconfig.AdaptiveBatching, bool)) {
+	fake.adaptiveBatchingMutex.Lock()
+	defer fake.adaptiveBatchingMutex.Unlock()
+	fake.AdaptiveBatchingStub = stub
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingReturns(result1 channelconfig.AdaptiveBatching, result2 bool) {
+	fake.adaptiveBatchingMutex.Lock()
+	defer fake.adaptiveBatchingMutex.Unlock()
+	fake.AdaptiveBatchingStub = nil
+	fake.adaptiveBatchingReturns = struct {
+		result1 channelconfig.AdaptiveBatching
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingReturnsOnCall(i int, result1 channelconfig.AdaptiveBatching, result2 bool) {
+	fake.adaptiveBatchingMutex.Lock()
+	defer fake.adaptiveBatchingMutex.Unlock()
+	fake.AdaptiveBatchingStub = nil
+	if fake.adaptiveBatchingReturnsOnCall == nil {
+		fake.adaptiveBatchingReturnsOnCall = make(map[int]struct {
+			result1 channelconfig.AdaptiveBatching
+			result2 bool
+		})
+	}
+	fake.adaptiveBatchingReturnsOnCall[i] = struct {
+		result1 channelconfig.AdaptiveBatching
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *OrdererConfig) BatchSize() *orderer.BatchSize {
 	fake.batchSizeMutex.Lock()
 	ret, specificReturn := fake.batchSizeReturnsOnCall[len(fake.batchSizeArgsForCall)]
@@ -575,6 +642,8 @@ func (fake *OrdererConfig) OrganizationsReturnsOnCall(i int, result1 map[string]
 func (fake *OrdererConfig) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.adaptiveBatchingMutex.RLock()
+	defer fake.adaptiveBatchingMutex.RUnlock()
 	fake.batchSizeMutex.RLock()
 	defer fake.batchSizeMutex.RUnlock()
 	fake.batchTimeoutMutex.RLock()
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	commonbft "github.com/hyperledger/fabric/common/bft"
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
@@ -38,6 +39,7 @@ type BlockWriter struct {
 	lastConfigBlockNum uint64
 	lastConfigSeq      uint64
 	lastBlock          *cb.Block
+	hashingAlgorithm   func([]byte) []byte
 	committingBlock    sync.Mutex
 }
 
@@ -49,10 +51,17 @@ func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSuppor
 		registrar:     r,
 	}
 
+	// The block hashing algorithm is fixed at channel creation, so it is resolved once
+	// from the current config rather than on every block.
+	hashingAlgorithm, err := newchannelconfig.BlockHashingAlgorithmFromChannelGroup(support.ConfigProto().GetChannelGroup())
+	if err != nil {
+		logger.Panicf("[channel: %s] Error resolving the block hashing algorithm: %s", support.ChannelID(), err)
+	}
+	bw.hashingAlgorithm = hashingAlgorithm
+
 	// If this is the genesis block, the lastconfig field may be empty, and, the last config block is necessarily block 0
 	// so no need to initialize lastConfig
 	if lastBlock.Header.Number != 0 {
-		var err error
 		bw.lastConfigBlockNum, err = protoutil.GetLastConfigIndexFromBlock(lastBlock)
 		if err != nil {
 			logger.Panicf("[channel: %s] Error extracting last config block from block metadata: %s", support.ChannelID(), err)
@@ -65,7 +74,7 @@ func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSuppor
 
 // CreateNextBlock creates a new block with the next block number, and the given contents.
 func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
-	previousBlockHash := protoutil.BlockHeaderHash(bw.lastBlock.Header)
+	previousBlockHash := protoutil.BlockHeaderHashWith(bw.lastBlock.Header, bw.hashingAlgorithm)
 
 	data := &cb.BlockData{
 		Data: make([][]byte, len(messages)),
@@ -80,7 +89,7 @@ func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
 	}
 
 	block := protoutil.NewBlock(bw.lastBlock.Header.Number+1, previousBlockHash)
-	block.Header.DataHash = protoutil.BlockDataHash(data)
+	block.Header.DataHash = protoutil.BlockDataHashWith(data, bw.hashingAlgorithm)
 	block.Data = data
 
 	return block
@@ -179,7 +188,10 @@ func (bw *BlockWriter) WriteBlock(block *cb.Block, encodedMetadataValue []byte)
 // this ensures that the encoded config sequence numbers stay in sync
 func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 	bw.addLastConfig(bw.lastBlock)
-	bw.addBlockSignature(bw.lastBlock, encodedMetadataValue)
+	// The blocks ordered by the BFT consensus already carry the signatures of a quorum of the consenters
+	if !bw.hasConsenterSignatures(bw.lastBlock) {
+		bw.addBlockSignature(bw.lastBlock, encodedMetadataValue)
+	}
 
 	err := bw.support.Append(bw.lastBlock)
 	if err != nil {
@@ -211,6 +223,14 @@ func (bw *BlockWriter) addBlockSignature(block *cb.Block, consenterMetadata []by
 	})
 }
 
+func (bw *BlockWriter) hasConsenterSignatures(block *cb.Block) bool {
+	md := &cb.Metadata{}
+	if err := proto.Unmarshal(block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES], md); err != nil || len(md.Signatures) == 0 {
+		return false
+	}
+	return bw.support.SharedConfig().ConsensusType() == commonbft.ConsensusType
+}
+
 func (bw *BlockWriter) addLastConfig(block *cb.Block) {
 	configSeq := bw.support.Sequence()
 	if configSeq > bw.lastConfigSeq {
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/ledger/blockledger/fileledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
@@ -61,7 +62,7 @@ func TestCreateBlock(t *testing.T) {
 	seedBlock := protoutil.NewBlock(7, []byte("lasthash"))
 	seedBlock.Data.Data = [][]byte{[]byte("somebytes")}
 
-	bw := &BlockWriter{lastBlock: seedBlock}
+	bw := &BlockWriter{lastBlock: seedBlock, hashingAlgorithm: util.ComputeSHA256}
 	block := bw.CreateNextBlock([]*cb.Envelope{
 		{Payload: []byte("some other bytes")},
 	})
@@ -69,6 +70,13 @@ func TestCreateBlock(t *testing.T) {
 	assert.Equal(t, seedBlock.Header.Number+1, block.Header.Number)
 	assert.Equal(t, protoutil.BlockDataHash(block.Data), block.Header.DataHash)
 	assert.Equal(t, protoutil.BlockHeaderHash(seedBlock.Header), block.Header.PreviousHash)
+
+	bw.hashingAlgorithm = util.ComputeGMSM3
+	block = bw.CreateNextBlock([]*cb.Envelope{
+		{Payload: []byte("some other bytes")},
+	})
+	assert.Equal(t, protoutil.BlockDataHashWith(block.Data, util.ComputeGMSM3), block.Header.DataHash)
+	assert.Equal(t, protoutil.BlockHeaderHashWith(seedBlock.Header, util.ComputeGMSM3), block.Header.PreviousHash)
 }
 
 func TestBlockSignature(t *testing.T) {
@@ -113,6 +121,47 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockSignatureBFT(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-ledger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rlf, err := fileledger.New(dir, &disabled.Provider{})
+	require.NoError(t, err)
+
+	l, err := rlf.GetOrCreate("mychannel")
+	require.NoError(t, err)
+	lastBlock := protoutil.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	fakeConfig := &mock.OrdererConfig{}
+	fakeConfig.ConsensusTypeReturns("BFT")
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			SignerSerializer:  mockCrypto(),
+			ConfigTXValidator: &mocks.ConfigTXValidator{},
+			ReadWriter:        l,
+			fakeConfig:        fakeConfig,
+		},
+		lastBlock: protoutil.NewBlock(1, protoutil.BlockHeaderHash(lastBlock.Header)),
+	}
+
+	// The signatures of the consenters are kept as they are
+	quorumSignatures := protoutil.MarshalOrPanic(&cb.Metadata{
+		Value:      []byte("value"),
+		Signatures: []*cb.MetadataSignature{{SignatureHeader: []byte("header"), Signature: []byte("signature")}},
+	})
+	bw.lastBlock.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = quorumSignatures
+	bw.commitBlock([]byte("bar"))
+
+	it, seq := l.Iterator(&orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{}})
+	defer it.Close()
+	require.Equal(t, uint64(1), seq)
+	committedBlock, status := it.Next()
+	require.Equal(t, cb.Status_SUCCESS, status)
+	require.Equal(t, quorumSignatures, committedBlock.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES])
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
@@ -21,6 +21,8 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/internal/configtxlator/update"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
@@ -206,7 +208,7 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 				logger.Panicf("Error reading genesis block of system channel '%s'", channelID)
 			}
 			logger.Infof("Starting system channel '%s' with genesis block hash %x and orderer type %s",
-				channelID, protoutil.BlockHeaderHash(genesisBlock.Header), chain.SharedConfig().ConsensusType())
+				channelID, protoutil.BlockHeaderHashWith(genesisBlock.Header, channelconfig.BlockHashingAlgorithm(chain.ChannelConfig())), chain.SharedConfig().ConsensusType())
 
 			r.chains[channelID] = chain
 			r.systemChannelID = channelID
@@ -424,17 +426,131 @@ func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
-	info := types.ChannelInfo{}
 	cs, ok := r.chains[channelID]
 	if !ok {
-		return info, types.ErrChannelNotExist
+		return types.ChannelInfo{}, types.ErrChannelNotExist
 	}
 
-	info.Name = channelID
-	info.Height = cs.Height()
+	return channelInfo(channelID, cs), nil
+}
+
+// channelInfo reports the height and status of a channel, and the progress of the orderer
+// when it follows the cluster of the channel.
+func channelInfo(channelID string, cs *ChainSupport) types.ChannelInfo {
+	info := types.ChannelInfo{
+		Name:   channelID,
+		Height: cs.Height(),
+	}
 	info.ClusterRelation, info.Status = cs.StatusReport()
+	if reporter, ok := cs.Chain.(consensus.FollowerReporter); ok {
+		report := reporter.FollowerReport()
+		info.Follower = &report
+	}
 
-	return info, nil
+	return info
+}
+
+// ChannelBlock returns the block with the given number from the ledger of a channel.
+func (r *Registrar) ChannelBlock(channelID string, number uint64) (*cb.Block, error) {
+	cs := r.GetChain(channelID)
+	if cs == nil {
+		return nil, types.ErrChannelNotExist
+	}
+
+	block := cs.Block(number)
+	if block == nil {
+		return nil, errors.WithMessagef(types.ErrBlockNotExist, "block [%d] is not in the ledger of height %d", number, cs.Height())
+	}
+
+	return block, nil
+}
+
+// SubmitConfigUpdate validates a signed config update of a channel through the msgprocessor of the channel,
+// and submits the resulting config transaction for ordering. The config update is ordered asynchronously,
+// as with a broadcast by a client.
+func (r *Registrar) SubmitConfigUpdate(channelID string, configUpdate *cb.Envelope) error {
+	cs := r.GetChain(channelID)
+	if cs == nil {
+		return types.ErrChannelNotExist
+	}
+
+	chdr, err := protoutil.ChannelHeader(configUpdate)
+	if err != nil {
+		return errors.WithMessage(err, "could not determine channel ID")
+	}
+	if chdr.ChannelId != channelID {
+		return errors.Errorf("config update is for channel %s", chdr.ChannelId)
+	}
+	if cs.ClassifyMsg(chdr) != msgprocessor.ConfigUpdateMsg {
+		return errors.Errorf("message of type %s is not a config update", cb.HeaderType(chdr.Type))
+	}
+
+	config, configSeq, err := cs.ProcessConfigUpdateMsg(configUpdate)
+	if err != nil {
+		return errors.WithMessage(err, "config update rejected")
+	}
+
+	if err := cs.WaitReady(); err != nil {
+		return errors.WithMessage(types.ErrConsenterUnavailable, err.Error())
+	}
+	if err := cs.Configure(config, configSeq); err != nil {
+		return errors.WithMessage(types.ErrConsenterUnavailable, err.Error())
+	}
+
+	logger.Infof("Submitted config update of channel %s with config sequence %d", channelID, configSeq)
+
+	return nil
+}
+
+// PromoteLearner promotes a non-voting consenter (learner) of a channel to a voting consenter, by submitting
+// a config update signed by this orderer. The consenter is identified by its endpoint (host:port).
+func (r *Registrar) PromoteLearner(channelID string, consenter string) error {
+	cs := r.GetChain(channelID)
+	if cs == nil {
+		return types.ErrChannelNotExist
+	}
+
+	promoter, ok := cs.Chain.(consensus.LearnerPromoter)
+	if !ok {
+		return types.ErrLearnersNotSupported
+	}
+
+	original := cs.ConfigtxValidator().ConfigProto()
+	updated, err := promoter.PromoteLearner(original, consenter)
+	if err != nil {
+		return err
+	}
+
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return errors.WithMessage(err, "could not compute config update")
+	}
+	configUpdate.ChannelId = channelID
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: protoutil.MarshalOrPanic(configUpdate),
+	}
+	sigHeader, err := protoutil.NewSignatureHeader(r.signer)
+	if err != nil {
+		return errors.WithMessage(err, "creating signature header failed")
+	}
+	configSig := &cb.ConfigSignature{
+		SignatureHeader: protoutil.MarshalOrPanic(sigHeader),
+	}
+	configSig.Signature, err = r.signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+	if err != nil {
+		return errors.WithMessage(err, "signature failure over config update")
+	}
+	configUpdateEnv.Signatures = []*cb.ConfigSignature{configSig}
+
+	env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, r.signer, configUpdateEnv, msgVersion, epoch)
+	if err != nil {
+		return errors.WithMessage(err, "could not create config update envelope")
+	}
+
+	logger.Infof("Promoting learner %s of channel %s", consenter, channelID)
+
+	return r.SubmitConfigUpdate(channelID, env)
 }
 
 func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block, isAppChannel bool) (types.ChannelInfo, error) {
@@ -473,12 +589,7 @@ func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block, isAppCh
 		return types.ChannelInfo{}, errors.Wrap(err, "failed creating chain support for join")
 	}
 
-	info := types.ChannelInfo{
-		Name:   channelID,
-		URL:    "",
-		Height: ledgerResources.Height(),
-	}
-	info.ClusterRelation, info.Status = joinSupport.StatusReport()
+	info := channelInfo(channelID, joinSupport)
 
 	logger.Infof("Joining new channel %s", channelID)
 
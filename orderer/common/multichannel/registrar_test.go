@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
@@ -26,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxlator/update"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
@@ -665,3 +667,157 @@ func generateCertificates(t *testing.T, confAppRaft *genesisconfig.Profile, tlsC
 		c.ClientTlsCert = []byte(clnP)
 	}
 }
+
+func TestRegistrar_ChannelBlockAndConfigUpdate(t *testing.T) {
+	// system channel
+	confSys := genesisconfig.Load(genesisconfig.SampleInsecureSoloProfile, configtest.GetDevConfigDir())
+	genesisBlockSys := encoder.New(confSys).GenesisBlock()
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	setup := func(t *testing.T) (*Registrar, func()) {
+		tmpdir, err := ioutil.TempDir("", "registrar_test-")
+		require.NoError(t, err)
+
+		ledgerFactory, _ := newLedgerAndFactory(tmpdir, "testchannelid", genesisBlockSys)
+		mockConsenters := map[string]consensus.Consenter{confSys.Orderer.OrdererType: &mockConsenter{}}
+		registrar := NewRegistrar(localconfig.TopLevel{}, ledgerFactory, mockCrypto(), &disabled.Provider{}, cryptoProvider)
+		registrar.Initialize(mockConsenters)
+		return registrar, func() {
+			registrar.GetChain("testchannelid").Halt()
+			os.RemoveAll(tmpdir)
+		}
+	}
+
+	configUpdate := func(t *testing.T, registrar *Registrar, channelID string) *cb.Envelope {
+		original := registrar.GetChain("testchannelid").ConfigtxValidator().ConfigProto()
+		updated := proto.Clone(original).(*cb.Config)
+		updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.BatchTimeoutKey].Value = protoutil.MarshalOrPanic(&ab.BatchTimeout{Timeout: "3s"})
+		delta, err := update.Compute(original, updated)
+		require.NoError(t, err)
+		delta.ChannelId = channelID
+		env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, mockCrypto(), &cb.ConfigUpdateEnvelope{
+			ConfigUpdate: protoutil.MarshalOrPanic(delta),
+		}, msgVersion, epoch)
+		require.NoError(t, err)
+		return env
+	}
+
+	t.Run("ChannelBlock", func(t *testing.T) {
+		registrar, cleanup := setup(t)
+		defer cleanup()
+
+		block, err := registrar.ChannelBlock("testchannelid", 0)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(genesisBlockSys, block))
+
+		_, err = registrar.ChannelBlock("testchannelid", 1)
+		assert.EqualError(t, err, "block [1] is not in the ledger of height 1: block does not exist")
+		assert.Equal(t, types.ErrBlockNotExist, errors.Cause(err))
+
+		_, err = registrar.ChannelBlock("not-there", 0)
+		assert.Equal(t, types.ErrChannelNotExist, err)
+	})
+
+	t.Run("SubmitConfigUpdate", func(t *testing.T) {
+		registrar, cleanup := setup(t)
+		defer cleanup()
+
+		err := registrar.SubmitConfigUpdate("testchannelid", configUpdate(t, registrar, "testchannelid"))
+		require.NoError(t, err)
+
+		cs := registrar.GetChain("testchannelid")
+		require.Eventually(t, func() bool { return cs.Height() == 2 }, 10*time.Second, 10*time.Millisecond)
+		block, err := registrar.ChannelBlock("testchannelid", 1)
+		require.NoError(t, err)
+		assert.True(t, protoutil.IsConfigBlock(block))
+		assert.Equal(t, 3*time.Second, cs.SharedConfig().BatchTimeout())
+	})
+
+	t.Run("SubmitConfigUpdate errors", func(t *testing.T) {
+		registrar, cleanup := setup(t)
+		defer cleanup()
+
+		err := registrar.SubmitConfigUpdate("not-there", configUpdate(t, registrar, "not-there"))
+		assert.Equal(t, types.ErrChannelNotExist, err)
+
+		err = registrar.SubmitConfigUpdate("testchannelid", configUpdate(t, registrar, "other-channel"))
+		assert.EqualError(t, err, "config update is for channel other-channel")
+
+		err = registrar.SubmitConfigUpdate("testchannelid", makeNormalTx("testchannelid", 1))
+		assert.EqualError(t, err, "message of type ENDORSER_TRANSACTION is not a config update")
+
+		err = registrar.SubmitConfigUpdate("testchannelid", &cb.Envelope{Payload: []byte("garbage")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "could not determine channel ID")
+
+		env := configUpdate(t, registrar, "testchannelid")
+		payload := protoutil.UnmarshalPayloadOrPanic(env.Payload)
+		payload.Data = protoutil.MarshalOrPanic(&cb.ConfigUpdateEnvelope{ConfigUpdate: protoutil.MarshalOrPanic(&cb.ConfigUpdate{ChannelId: "testchannelid"})})
+		env.Payload = protoutil.MarshalOrPanic(payload)
+		err = registrar.SubmitConfigUpdate("testchannelid", env)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "config update rejected")
+	})
+}
+
+func TestRegistrar_PromoteLearner(t *testing.T) {
+	confSys := genesisconfig.Load(genesisconfig.SampleInsecureSoloProfile, configtest.GetDevConfigDir())
+	genesisBlockSys := encoder.New(confSys).GenesisBlock()
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	setup := func(t *testing.T, consenter consensus.Consenter) (*Registrar, func()) {
+		tmpdir, err := ioutil.TempDir("", "registrar_test-")
+		require.NoError(t, err)
+
+		ledgerFactory, _ := newLedgerAndFactory(tmpdir, "testchannelid", genesisBlockSys)
+		registrar := NewRegistrar(localconfig.TopLevel{}, ledgerFactory, mockCrypto(), &disabled.Provider{}, cryptoProvider)
+		registrar.Initialize(map[string]consensus.Consenter{confSys.Orderer.OrdererType: consenter})
+		return registrar, func() {
+			registrar.GetChain("testchannelid").Halt()
+			os.RemoveAll(tmpdir)
+		}
+	}
+
+	t.Run("learners not supported", func(t *testing.T) {
+		registrar, cleanup := setup(t, &mockConsenter{})
+		defer cleanup()
+
+		err := registrar.PromoteLearner("testchannelid", "learner:7050")
+		assert.Equal(t, types.ErrLearnersNotSupported, err)
+	})
+
+	t.Run("channel does not exist", func(t *testing.T) {
+		registrar, cleanup := setup(t, &mockConsenter{promoter: true})
+		defer cleanup()
+
+		err := registrar.PromoteLearner("not-there", "learner:7050")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+	})
+
+	t.Run("learner does not exist", func(t *testing.T) {
+		registrar, cleanup := setup(t, &mockConsenter{promoter: true})
+		defer cleanup()
+
+		err := registrar.PromoteLearner("testchannelid", "other:7050")
+		assert.Equal(t, types.ErrLearnerNotExist, err)
+	})
+
+	t.Run("promoted", func(t *testing.T) {
+		registrar, cleanup := setup(t, &mockConsenter{promoter: true})
+		defer cleanup()
+
+		err := registrar.PromoteLearner("testchannelid", "learner:7050")
+		require.NoError(t, err)
+
+		cs := registrar.GetChain("testchannelid")
+		require.Eventually(t, func() bool { return cs.Height() == 2 }, 10*time.Second, 10*time.Millisecond)
+		block, err := registrar.ChannelBlock("testchannelid", 1)
+		require.NoError(t, err)
+		assert.True(t, protoutil.IsConfigBlock(block))
+		assert.Equal(t, 3*time.Second, cs.SharedConfig().BatchTimeout())
+	})
+}
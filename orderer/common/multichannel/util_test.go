@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -25,7 +27,8 @@ import (
 )
 
 type mockConsenter struct {
-	cluster bool
+	cluster  bool
+	promoter bool
 }
 
 func (mc *mockConsenter) HandleChain(support consensus.ConsenterSupport, metadata *cb.Metadata) (consensus.Chain, error) {
@@ -43,6 +46,10 @@ func (mc *mockConsenter) HandleChain(support consensus.ConsenterSupport, metadat
 		return clusterChain, nil
 	}
 
+	if mc.promoter {
+		return &mockChainPromoter{mockChain: chain}, nil
+	}
+
 	return chain, nil
 }
 
@@ -59,6 +66,20 @@ func (c *mockChainCluster) StatusReport() (types.ClusterRelation, types.Status)
 	return types.ClusterRelationMember, types.StatusActive
 }
 
+// mockChainPromoter promotes the learner "learner:7050" by changing the batch timeout of the channel.
+type mockChainPromoter struct {
+	*mockChain
+}
+
+func (c *mockChainPromoter) PromoteLearner(config *cb.Config, endpoint string) (*cb.Config, error) {
+	if endpoint != "learner:7050" {
+		return nil, types.ErrLearnerNotExist
+	}
+	updated := proto.Clone(config).(*cb.Config)
+	updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.BatchTimeoutKey].Value = protoutil.MarshalOrPanic(&ab.BatchTimeout{Timeout: "3s"})
+	return updated, nil
+}
+
 type mockChain struct {
 	queue    chan *cb.Envelope
 	cutter   blockcutter.Receiver
@@ -77,11 +77,19 @@ func NewReplicationInitiator(
 	verifiersByChannel := vl.loadVerifiers()
 	verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
 
+	hashingAlgorithmsByChannel := vl.loadHashingAlgorithms()
+	hashingAlgorithmsByChannel[systemChannelName], err = channelconfig.BlockHashingAlgorithmFromConfigBlock(bootstrapBlock)
+	if err != nil {
+		logger.Panicf("Failed extracting block hashing algorithm from bootstrap block: %v", err)
+	}
+
 	vr := &cluster.VerificationRegistry{
-		LoadVerifier:       vl.loadVerifier,
-		Logger:             logger,
-		VerifiersByChannel: verifiersByChannel,
-		VerifierFactory:    &cluster.BlockVerifierAssembler{Logger: logger, BCCSP: bccsp},
+		LoadVerifier:               vl.loadVerifier,
+		LoadHashingAlgorithm:       vl.loadHashingAlgorithm,
+		Logger:                     logger,
+		VerifiersByChannel:         verifiersByChannel,
+		HashingAlgorithmsByChannel: hashingAlgorithmsByChannel,
+		VerifierFactory:            &cluster.BlockVerifierAssembler{Logger: logger, BCCSP: bccsp},
 	}
 
 	ledgerFactory := &ledgerFactory{
@@ -128,13 +136,14 @@ func (ri *ReplicationInitiator) createReplicator(bootstrapBlock *common.Block, f
 	puller.RetryTimeout = ri.conf.General.Cluster.ReplicationRetryTimeout
 
 	replicator := &cluster.Replicator{
-		Filter:           filter,
-		LedgerFactory:    ri.lf,
-		SystemChannel:    systemChannelName,
-		BootBlock:        bootstrapBlock,
-		Logger:           ri.logger,
-		AmIPartOfChannel: consenterCert.IsConsenterOfChannel,
-		Puller:           puller,
+		Filter:            filter,
+		LedgerFactory:     ri.lf,
+		SystemChannel:     systemChannelName,
+		BootBlock:         bootstrapBlock,
+		Logger:            ri.logger,
+		AmIPartOfChannel:  consenterCert.IsConsenterOfChannel,
+		Puller:            puller,
+		VerifierRetriever: ri.verifierRetriever,
 		ChannelLister: &cluster.ChainInspector{
 			Logger:          ri.logger,
 			Puller:          puller,
@@ -366,6 +375,8 @@ type verifierLoader struct {
 
 type verifiersByChannel map[string]cluster.BlockVerifier
 
+type hashingAlgorithmsByChannel map[string]func([]byte) []byte
+
 func (vl *verifierLoader) loadVerifiers() verifiersByChannel {
 	res := make(verifiersByChannel)
 
@@ -381,6 +392,57 @@ func (vl *verifierLoader) loadVerifiers() verifiersByChannel {
 }
 
 func (vl *verifierLoader) loadVerifier(chain string) cluster.BlockVerifier {
+	lastConfigBlock, lastBlockIndex := vl.lastConfigBlock(chain)
+	if lastConfigBlock == nil {
+		return nil
+	}
+	conf, err := cluster.ConfigFromBlock(lastConfigBlock)
+	if err != nil {
+		vl.onFailure(lastConfigBlock)
+		vl.logger.Panicf("Failed extracting configuration for channel %s from block [%d]: %v",
+			chain, lastConfigBlock.Header.Number, err)
+	}
+
+	verifier, err := vl.verifierFactory.VerifierFromConfig(conf, chain)
+	if err != nil {
+		vl.onFailure(lastConfigBlock)
+		vl.logger.Panicf("Failed creating verifier for channel %s from block [%d]: %v", chain, lastBlockIndex, err)
+	}
+	vl.logger.Infof("Loaded verifier for channel %s from config block at index %d", chain, lastBlockIndex)
+	return verifier
+}
+
+func (vl *verifierLoader) loadHashingAlgorithms() hashingAlgorithmsByChannel {
+	res := make(hashingAlgorithmsByChannel)
+
+	for _, channel := range vl.ledgerFactory.ChannelIDs() {
+		hashingAlgorithm := vl.loadHashingAlgorithm(channel)
+		if hashingAlgorithm == nil {
+			continue
+		}
+		res[channel] = hashingAlgorithm
+	}
+
+	return res
+}
+
+func (vl *verifierLoader) loadHashingAlgorithm(chain string) func([]byte) []byte {
+	lastConfigBlock, _ := vl.lastConfigBlock(chain)
+	if lastConfigBlock == nil {
+		return nil
+	}
+	hashingAlgorithm, err := channelconfig.BlockHashingAlgorithmFromConfigBlock(lastConfigBlock)
+	if err != nil {
+		vl.onFailure(lastConfigBlock)
+		vl.logger.Panicf("Failed extracting block hashing algorithm for channel %s from block [%d]: %v",
+			chain, lastConfigBlock.Header.Number, err)
+	}
+	return hashingAlgorithm
+}
+
+// lastConfigBlock returns the last config block of the given chain along with the index of
+// the last block of the chain, or nil if the chain has no blocks.
+func (vl *verifierLoader) lastConfigBlock(chain string) (*common.Block, uint64) {
 	ledger, err := vl.ledgerFactory.GetOrCreate(chain)
 	if err != nil {
 		vl.logger.Panicf("Failed obtaining ledger for channel %s", chain)
@@ -390,7 +452,7 @@ func (vl *verifierLoader) loadVerifier(chain string) cluster.BlockVerifier {
 	height := ledger.Height()
 	if height == 0 {
 		vl.logger.Errorf("Channel %s has no blocks, skipping it", chain)
-		return nil
+		return nil, 0
 	}
 	lastBlockIndex := height - 1
 	lastBlock := blockRetriever.Block(lastBlockIndex)
@@ -402,20 +464,7 @@ func (vl *verifierLoader) loadVerifier(chain string) cluster.BlockVerifier {
 	if err != nil {
 		vl.logger.Panicf("Failed retrieving config block [%d] for channel %s", lastBlockIndex, chain)
 	}
-	conf, err := cluster.ConfigFromBlock(lastConfigBlock)
-	if err != nil {
-		vl.onFailure(lastConfigBlock)
-		vl.logger.Panicf("Failed extracting configuration for channel %s from block [%d]: %v",
-			chain, lastConfigBlock.Header.Number, err)
-	}
-
-	verifier, err := vl.verifierFactory.VerifierFromConfig(conf, chain)
-	if err != nil {
-		vl.onFailure(lastConfigBlock)
-		vl.logger.Panicf("Failed creating verifier for channel %s from block [%d]: %v", chain, lastBlockIndex, err)
-	}
-	vl.logger.Infof("Loaded verifier for channel %s from config block at index %d", chain, lastBlockIndex)
-	return verifier
+	return lastConfigBlock, lastBlockIndex
 }
 
 // ValidateBootstrapBlock returns whether this block can be used as a bootstrap block.
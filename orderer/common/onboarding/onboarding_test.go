@@ -28,6 +28,7 @@ import (
 	deliver_mocks "github.com/hyperledger/fabric/common/deliver/mock"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
@@ -325,6 +326,7 @@ func TestOnboardingChannelUnavailable(t *testing.T) {
 	verifier.On("VerifyBlockSignature", mock.Anything, mock.Anything).Return(nil)
 	vr := &cluster_mocks.VerifierRetriever{}
 	vr.On("RetrieveVerifier", mock.Anything).Return(verifier)
+	vr.On("RetrieveHashingAlgorithm", mock.Anything).Return(util.ComputeSHA256)
 
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
@@ -745,6 +747,7 @@ func TestReplicate(t *testing.T) {
 			verifier.On("VerifyBlockSignature", mock.Anything, mock.Anything).Return(nil)
 			vr := &cluster_mocks.VerifierRetriever{}
 			vr.On("RetrieveVerifier", mock.Anything).Return(verifier)
+			vr.On("RetrieveHashingAlgorithm", mock.Anything).Return(util.ComputeSHA256)
 
 			cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 			assert.NoError(t, err)
@@ -1220,6 +1223,8 @@ func TestCreateReplicator(t *testing.T) {
 	iterator := &deliver_mocks.BlockIterator{}
 	iterator.NextReturnsOnCall(0, bootBlock, common.Status_SUCCESS)
 	iterator.NextReturnsOnCall(1, bootBlock, common.Status_SUCCESS)
+	iterator.NextReturnsOnCall(2, bootBlock, common.Status_SUCCESS)
+	iterator.NextReturnsOnCall(3, bootBlock, common.Status_SUCCESS)
 
 	ledger := &onboarding_mocks.ReadWriter{}
 	ledger.HeightReturns(1)
@@ -1240,4 +1245,10 @@ func TestCreateReplicator(t *testing.T) {
 
 	err = r.verifierRetriever.RetrieveVerifier("system").VerifyBlockSignature(nil, nil)
 	assert.NoError(t, err)
+
+	for _, channel := range []string{"mychannel", "system"} {
+		hashingAlgorithm := r.verifierRetriever.RetrieveHashingAlgorithm(channel)
+		assert.NotNil(t, hashingAlgorithm)
+		assert.Equal(t, util.ComputeSHA256([]byte("data")), hashingAlgorithm([]byte("data")))
+	}
 }
@@ -26,6 +26,7 @@ import (
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	commonbft "github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
@@ -40,12 +41,14 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/common/onboarding"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
@@ -64,7 +67,7 @@ var (
 	_       = app.Command("start", "Start the orderer node").Default() // preserved for cli compatibility
 	version = app.Command("version", "Show version information")
 
-	clusterTypes = map[string]struct{}{"etcdraft": {}}
+	clusterTypes = map[string]struct{}{"etcdraft": {}, commonbft.ConsensusType: {}}
 )
 
 // Main is the entry point of orderer process
@@ -237,6 +240,11 @@ func Main() {
 		tlsCallback,
 	)
 
+	opsSystem.RegisterHandler(
+		channelparticipation.URLBaseV1,
+		channelparticipation.NewHTTPHandler(conf.ChannelParticipation, manager),
+	)
+
 	if err = opsSystem.Start(); err != nil {
 		logger.Panicf("failed to start operations subsystem: %s", err)
 	}
@@ -417,6 +425,19 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 		logger.Panicf("Failed to load cluster server key from '%s' (%s)", clusterConf.ServerPrivateKey, err)
 	}
 
+	var encCert, encKey []byte
+	if clusterConf.ServerEncCertificate != "" || clusterConf.ServerEncPrivateKey != "" {
+		encCert, err = loadPEM(clusterConf.ServerEncCertificate)
+		if err != nil {
+			logger.Panicf("Failed to load cluster server encryption certificate from '%s' (%s)", clusterConf.ServerEncCertificate, err)
+		}
+
+		encKey, err = loadPEM(clusterConf.ServerEncPrivateKey)
+		if err != nil {
+			logger.Panicf("Failed to load cluster server encryption key from '%s' (%s)", clusterConf.ServerEncPrivateKey, err)
+		}
+	}
+
 	port := fmt.Sprintf("%d", clusterConf.ListenPort)
 	bindAddr := net.JoinHostPort(clusterConf.ListenAddress, port)
 
@@ -444,6 +465,8 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 			Certificate:       cert,
 			UseTLS:            true,
 			Key:               key,
+			EncCertificate:    encCert,
+			EncKey:            encKey,
 		},
 	}
 
@@ -479,6 +502,20 @@ func initializeClusterClientConfig(conf *localconfig.TopLevel) comm.ClientConfig
 		logger.Fatalf("Failed to load client TLS key file '%s' (%s)", keyFile, err)
 	}
 
+	var encCertBytes, encKeyBytes []byte
+	if encCertFile := conf.General.Cluster.ClientEncCertificate; encCertFile != "" {
+		encCertBytes, err = ioutil.ReadFile(encCertFile)
+		if err != nil {
+			logger.Fatalf("Failed to load client TLS encryption certificate file '%s' (%s)", encCertFile, err)
+		}
+	}
+	if encKeyFile := conf.General.Cluster.ClientEncPrivateKey; encKeyFile != "" {
+		encKeyBytes, err = ioutil.ReadFile(encKeyFile)
+		if err != nil {
+			logger.Fatalf("Failed to load client TLS encryption key file '%s' (%s)", encKeyFile, err)
+		}
+	}
+
 	var serverRootCAs [][]byte
 	for _, serverRoot := range conf.General.Cluster.RootCAs {
 		rootCACert, err := ioutil.ReadFile(serverRoot)
@@ -500,6 +537,8 @@ func initializeClusterClientConfig(conf *localconfig.TopLevel) comm.ClientConfig
 		ServerRootCAs:     serverRootCAs,
 		Certificate:       certBytes,
 		Key:               keyBytes,
+		EncCertificate:    encCertBytes,
+		EncKey:            encKeyBytes,
 		UseTLS:            true,
 	}
 
@@ -527,6 +566,19 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 			logger.Fatalf("Failed to load PrivateKey file '%s' (%s)",
 				conf.General.TLS.PrivateKey, err)
 		}
+		var encCertificate, encKey []byte
+		if conf.General.TLS.EncCertificate != "" || conf.General.TLS.EncPrivateKey != "" {
+			encCertificate, err = ioutil.ReadFile(conf.General.TLS.EncCertificate)
+			if err != nil {
+				logger.Fatalf("Failed to load server EncCertificate file '%s' (%s)",
+					conf.General.TLS.EncCertificate, err)
+			}
+			encKey, err = ioutil.ReadFile(conf.General.TLS.EncPrivateKey)
+			if err != nil {
+				logger.Fatalf("Failed to load EncPrivateKey file '%s' (%s)",
+					conf.General.TLS.EncPrivateKey, err)
+			}
+		}
 		var serverRootCAs, clientRootCAs [][]byte
 		for _, serverRoot := range conf.General.TLS.RootCAs {
 			root, err := ioutil.ReadFile(serverRoot)
@@ -549,6 +601,8 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 		}
 		secureOpts.Key = serverKey
 		secureOpts.Certificate = serverCertificate
+		secureOpts.EncKey = encKey
+		secureOpts.EncCertificate = encCertificate
 		secureOpts.ServerRootCAs = serverRootCAs
 		secureOpts.ClientRootCAs = clientRootCAs
 		logger.Infof("Starting orderer with %s enabled", msg)
@@ -680,6 +734,9 @@ func loadLocalMSP(conf *localconfig.TopLevel) msp.MSP {
 	if !found {
 		logger.Panicf("MSP option for type %s is not found", typ)
 	}
+	if conf.General.LocalMSPSM2ZA {
+		opts = &msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv2_0_SM2ZA}}
+	}
 
 	localmsp, err := msp.New(opts, factory.GetDefault())
 	if err != nil {
@@ -719,17 +776,24 @@ func initializeMultichannelRegistrar(
 	consenters := map[string]consensus.Consenter{}
 
 	var icr etcdraft.InactiveChainRegistry
+	var raftConsenter *etcdraft.Consenter
 	if conf.General.BootstrapMethod == "file" || conf.General.BootstrapMethod == "none" {
 		if bootstrapBlock != nil && isClusterType(bootstrapBlock, bccsp) {
 			// with a system channel
-			etcdConsenter := initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, repInitiator, srvConf, srv, registrar, metricsProvider, bccsp)
-			icr = etcdConsenter.InactiveChainRegistry
+			raftConsenter = initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, repInitiator, srvConf, srv, registrar, metricsProvider, bccsp)
+			icr = raftConsenter.InactiveChainRegistry
 		} else if bootstrapBlock == nil {
 			// without a system channel: assume cluster type, InactiveChainRegistry == nil, no go-routine.
-			consenters["etcdraft"] = etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, nil, metricsProvider, bccsp)
+			raftConsenter = etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, nil, metricsProvider, bccsp)
+			consenters["etcdraft"] = raftConsenter
 		}
 	}
 
+	// The BFT consenter shares the cluster communication of the etcdraft consenter
+	if raftConsenter != nil {
+		consenters[commonbft.ConsensusType] = bft.New(clusterDialer, raftConsenter.Communication, conf, srvConf, registrar, icr, metricsProvider, bccsp)
+	}
+
 	consenters["solo"] = solo.New()
 	var kafkaMetrics *kafka.Metrics
 	consenters["kafka"], kafkaMetrics = kafka.New(conf.Kafka, metricsProvider, healthChecker, icr, registrar.CreateChain)
@@ -797,6 +861,8 @@ func newOperationsSystem(ops localconfig.Operations, metrics localconfig.Metrics
 			Enabled:            ops.TLS.Enabled,
 			CertFile:           ops.TLS.Certificate,
 			KeyFile:            ops.TLS.PrivateKey,
+			EncCertFile:        ops.TLS.EncCertificate,
+			EncKeyFile:         ops.TLS.EncPrivateKey,
 			ClientCertRequired: ops.TLS.ClientAuthRequired,
 			ClientCACertFiles:  ops.TLS.ClientRootCAs,
 		},
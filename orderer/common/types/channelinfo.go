@@ -78,4 +78,20 @@ type ChannelInfo struct {
 	Status Status `json:"status"`
 	// Current block height.
 	Height uint64 `json:"height"`
+	// The progress of the orderer when it is a ”follower” of the cluster, nil otherwise.
+	Follower *FollowerInfo `json:"follower,omitempty"`
+}
+
+// FollowerInfo carries the progress of an orderer that follows a cluster consensus protocol by pulling blocks from
+// other orderers. It allows an operator to tell how far an onboarding orderer is from becoming active, and why it is
+// stuck.
+type FollowerInfo struct {
+	// Current block height.
+	Height uint64 `json:"height"`
+	// The block height at which the orderer becomes ”active”, that is, the join-block number +1.
+	// Zero when the orderer did not join the channel with a join-block, e.g. when it was removed from the consenters
+	// set of the channel.
+	TargetHeight uint64 `json:"targetHeight"`
+	// The last error encountered by the follower, empty if none.
+	LastError string `json:"lastError,omitempty"`
 }
@@ -89,3 +89,27 @@ func TestChannelInfo(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, info.Height, info2.Height)
 }
+
+func TestChannelInfoFollower(t *testing.T) {
+	info := types.ChannelInfo{
+		Name:            "a",
+		URL:             "/api/channels/a",
+		ClusterRelation: types.ClusterRelationFollower,
+		Status:          types.StatusOnBoarding,
+		Height:          5,
+		Follower: &types.FollowerInfo{
+			Height:       5,
+			TargetHeight: 11,
+			LastError:    "oops",
+		},
+	}
+
+	buff, err := json.Marshal(info)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"a","url":"/api/channels/a","clusterRelation":"follower","status":"onboarding","height":5,"follower":{"height":5,"targetHeight":11,"lastError":"oops"}}`, string(buff))
+
+	var info2 types.ChannelInfo
+	err = json.Unmarshal(buff, &info2)
+	assert.NoError(t, err)
+	assert.Equal(t, info, info2)
+}
@@ -21,3 +21,19 @@ var ErrAppChannelsAlreadyExists = errors.New("application channels already exist
 
 // This error is returned when trying to remove or list a channel that does not exist
 var ErrChannelNotExist = errors.New("channel does not exist")
+
+// This error is returned when trying to fetch a block that is not in the ledger of a channel
+var ErrBlockNotExist = errors.New("block does not exist")
+
+// This error is returned when the consensus of a channel cannot accept a transaction for ordering, e.g. when the
+// orderer is a follower of the channel.
+var ErrConsenterUnavailable = errors.New("consenter unavailable")
+
+// This error is returned when trying to promote a consenter that is not a non-voting member (learner) of a channel
+var ErrLearnerNotExist = errors.New("learner does not exist")
+
+// This error is returned when trying to promote a learner that lags behind the leader of the cluster
+var ErrLearnerNotCaughtUp = errors.New("learner has not caught up")
+
+// This error is returned when the consensus type of a channel has no non-voting members (learners)
+var ErrLearnersNotSupported = errors.New("learners are not supported")
@@ -0,0 +1,817 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	commonbft "github.com/hyperledger/fabric/common/bft"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxFutureMessagesPerSender is the number of messages of the next sequence that are
+	// buffered for each consenter until the current sequence is decided
+	maxFutureMessagesPerSender = 10
+
+	// egressBufferSize is the number of messages that are queued for each consenter
+	egressBufferSize = 200
+
+	// decidedKeysPerPoolSlot is the number of keys of ordered requests that are remembered
+	// for each request that the pool can hold
+	decidedKeysPerPoolSlot = 10
+
+	// minTickInterval is the lower bound of the interval at which the timeouts are checked
+	minTickInterval = 10 * time.Millisecond
+)
+
+// RPC is used to send messages to the other consenters of the channel
+type RPC interface {
+	// SendConsensus sends the consensus request to the given consenter
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+
+	// SendSubmit sends the transaction to the given consenter
+	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+}
+
+// Configurator is used to configure the communication layer
+// when the consenters of the channel change
+type Configurator interface {
+	Configure(channel string, newNodes []cluster.RemoteNode)
+}
+
+// SignatureVerifier verifies the signature of an identity of the channel
+type SignatureVerifier func(*protoutil.SignedData) error
+
+// Options contains the configuration of the chain of a channel
+type Options struct {
+	// SelfID is the id of this node among the consenters
+	SelfID uint64
+	// ConfigMetadata is the BFT config metadata of the channel
+	ConfigMetadata *commonbft.ConfigMetadata
+	// ViewMetadata is the view metadata of the last block in the ledger, nil if the last
+	// block was not decided by the BFT consensus
+	ViewMetadata *commonbft.ViewMetadata
+	// StateDir is the directory in which the consensus state of the channel is persisted
+	StateDir string
+
+	Clock   clock.Clock
+	Logger  *flogging.FabricLogger
+	Metrics *Metrics
+}
+
+// config is the parsed form of the BFT options of the channel
+type config struct {
+	requestForwardTimeout    time.Duration
+	requestComplainTimeout   time.Duration
+	requestAutoRemoveTimeout time.Duration
+	viewChangeResendInterval time.Duration
+	viewChangeTimeout        time.Duration
+	leaderHeartbeatTimeout   time.Duration
+	heartbeatInterval        time.Duration
+	decisionsPerLeader       uint64
+	requestPoolSize          uint64
+}
+
+func parseConfig(options *commonbft.Options) (config, error) {
+	o := commonbft.OptionsWithDefaults(options)
+	var conf config
+	for _, d := range []struct {
+		name   string
+		value  string
+		target *time.Duration
+	}{
+		{"RequestForwardTimeout", o.RequestForwardTimeout, &conf.requestForwardTimeout},
+		{"RequestComplainTimeout", o.RequestComplainTimeout, &conf.requestComplainTimeout},
+		{"RequestAutoRemoveTimeout", o.RequestAutoRemoveTimeout, &conf.requestAutoRemoveTimeout},
+		{"ViewChangeResendInterval", o.ViewChangeResendInterval, &conf.viewChangeResendInterval},
+		{"ViewChangeTimeout", o.ViewChangeTimeout, &conf.viewChangeTimeout},
+		{"LeaderHeartbeatTimeout", o.LeaderHeartbeatTimeout, &conf.leaderHeartbeatTimeout},
+	} {
+		value, err := time.ParseDuration(d.value)
+		if err != nil {
+			return config{}, errors.Errorf("failed to parse %s (%s) to time duration", d.name, d.value)
+		}
+		*d.target = value
+	}
+	conf.heartbeatInterval = conf.leaderHeartbeatTimeout / time.Duration(o.LeaderHeartbeatCount)
+	conf.decisionsPerLeader = o.DecisionsPerLeader
+	conf.requestPoolSize = o.RequestPoolSize
+	return conf, nil
+}
+
+type submission struct {
+	env       *cb.Envelope
+	configSeq uint64
+	sender    uint64
+	errC      chan error
+}
+
+type incoming struct {
+	sender uint64
+	msg    *Message
+}
+
+// Chain implements a consensus.Chain that orders the blocks of a channel by a byzantine fault tolerant
+// protocol among the consenters of the channel. The leader of a view proposes each block, which is
+// decided once a quorum of the consenters has prepared it and has signed it, and the signatures of the
+// quorum are stored in the block metadata. The consenters replace a leader that they suspect of being
+// faulty by a view change, in which the leader of the next view collects the state of a quorum of the
+// consenters so that a block which might have been decided in the previous view is decided again
+type Chain struct {
+	support      consensus.ConsenterSupport
+	rpc          RPC
+	configurator Configurator
+	verify       SignatureVerifier
+	createPuller etcdraft.CreateBlockPuller
+	store        *stateStore
+
+	channelID string
+	selfID    uint64
+	clock     clock.Clock
+	logger    *flogging.FabricLogger
+	metrics   *Metrics
+
+	submitC chan *submission
+	msgC    chan *incoming
+	haltC   chan struct{}
+	doneC   chan struct{}
+	startC  chan struct{}
+
+	errorCLock sync.RWMutex
+	errorC     chan struct{}
+
+	egressLock sync.Mutex
+	egress     map[uint64]chan []byte
+	egressWG   sync.WaitGroup
+
+	// The fields below are accessed only by the run loop
+
+	conf       config
+	consenters []*commonbft.Consenter
+	nodes      []uint64
+
+	view               uint64
+	decisionsInView    uint64
+	lastBlock          *cb.Block
+	lastConfigBlockNum uint64
+
+	pool       *requestPool
+	decided    *recentKeys
+	batchTimer clock.Timer
+
+	slot           *slot
+	futureMessages map[uint64][]*Message
+	laggingBeats   int
+
+	lastHeartbeat     time.Time
+	lastHeartbeatSent time.Time
+
+	viewChange      *viewChange
+	viewChangeVotes map[uint64]uint64
+	newView         *NewView
+}
+
+// NewChain creates a chain for the channel of the given support
+func NewChain(
+	support consensus.ConsenterSupport,
+	opts Options,
+	rpc RPC,
+	configurator Configurator,
+	verify SignatureVerifier,
+	createPuller etcdraft.CreateBlockPuller,
+) (*Chain, error) {
+	conf, err := parseConfig(opts.ConfigMetadata.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := newStateStore(opts.StateDir)
+	if err != nil {
+		return nil, err
+	}
+	savedState, err := store.load()
+	if err != nil {
+		return nil, err
+	}
+
+	lastBlock := support.Block(support.Height() - 1)
+	if lastBlock == nil {
+		return nil, errors.Errorf("failed to retrieve the last block of channel %s", support.ChannelID())
+	}
+	var lastConfigBlockNum uint64
+	if lastBlock.Header.Number > 0 {
+		lastConfigBlockNum, err = protoutil.GetLastConfigIndexFromBlock(lastBlock)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to retrieve the last config block index")
+		}
+	}
+
+	c := &Chain{
+		support:            support,
+		rpc:                rpc,
+		configurator:       configurator,
+		verify:             verify,
+		createPuller:       createPuller,
+		store:              store,
+		channelID:          support.ChannelID(),
+		selfID:             opts.SelfID,
+		clock:              opts.Clock,
+		logger:             opts.Logger.With("channel", support.ChannelID(), "node", opts.SelfID),
+		metrics:            opts.Metrics,
+		submitC:            make(chan *submission),
+		msgC:               make(chan *incoming, 1000),
+		haltC:              make(chan struct{}),
+		doneC:              make(chan struct{}),
+		startC:             make(chan struct{}),
+		errorC:             make(chan struct{}),
+		egress:             make(map[uint64]chan []byte),
+		conf:               conf,
+		lastBlock:          lastBlock,
+		lastConfigBlockNum: lastConfigBlockNum,
+		pool:               newRequestPool(conf.requestPoolSize),
+		decided:            newRecentKeys(int(conf.requestPoolSize) * decidedKeysPerPoolSlot),
+		futureMessages:     make(map[uint64][]*Message),
+		viewChangeVotes:    make(map[uint64]uint64),
+	}
+	c.setConsenters(opts.ConfigMetadata.Consenters)
+
+	// The view is restored from the metadata of the last block, unless the saved
+	// state shows that the node has moved to a later view since then
+	if md := opts.ViewMetadata; md != nil && md.LatestSequence == lastBlock.Header.Number {
+		c.view = md.ViewId
+		c.decisionsInView = md.DecisionsInView + 1
+	}
+	if savedState.View > c.view {
+		c.view = savedState.View
+		c.decisionsInView = 0
+	}
+	if savedState.InFlightProposal != nil && savedState.InFlightView == c.view {
+		if md, err := viewMetadataOf(savedState.InFlightProposal); err == nil && md.LatestSequence == lastBlock.Header.Number+1 {
+			c.restoreInFlight(savedState)
+		}
+	}
+	if savedState.PendingView > c.view {
+		c.viewChange = newViewChange(savedState.PendingView, c.clock.Now())
+	}
+
+	c.metrics.ClusterSize.With("channel", c.channelID).Set(float64(len(c.nodes)))
+	c.metrics.CommittedBlockNumber.With("channel", c.channelID).Set(float64(lastBlock.Header.Number))
+	c.reportLeader()
+
+	return c, nil
+}
+
+// Start instructs the orderer to begin serving the chain and keep it current.
+func (c *Chain) Start() {
+	c.logger.Infof("Starting BFT node in view %d, the last block is [%d]", c.view, c.lastBlock.Header.Number)
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Errorf("Failed to start chain, aborting: +%v", err)
+		close(c.doneC)
+		return
+	}
+
+	close(c.startC)
+	go c.run()
+}
+
+// Order submits normal type transactions for ordering.
+func (c *Chain) Order(env *cb.Envelope, configSeq uint64) error {
+	return c.submit(env, configSeq, c.selfID)
+}
+
+// Configure submits config type transactions for ordering.
+func (c *Chain) Configure(env *cb.Envelope, configSeq uint64) error {
+	return c.submit(env, configSeq, c.selfID)
+}
+
+// WaitReady blocks when the chain cannot accept new transactions.
+func (c *Chain) WaitReady() error {
+	return c.isRunning()
+}
+
+// Errored returns a channel that closes when the chain stops.
+func (c *Chain) Errored() <-chan struct{} {
+	c.errorCLock.RLock()
+	defer c.errorCLock.RUnlock()
+	return c.errorC
+}
+
+// Halt stops the chain.
+func (c *Chain) Halt() {
+	select {
+	case <-c.startC:
+	default:
+		c.logger.Warnf("Attempted to halt a chain that has not started")
+		return
+	}
+
+	select {
+	case c.haltC <- struct{}{}:
+	case <-c.doneC:
+		return
+	}
+	<-c.doneC
+}
+
+// Consensus passes the given ConsensusRequest message to the chain
+func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	msg := &Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return errors.Errorf("failed to unmarshal consensus message from %d: %s", sender, err)
+	}
+
+	select {
+	case c.msgC <- &incoming{sender: sender, msg: msg}:
+		return nil
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+}
+
+// Submit passes the transaction that is forwarded by another consenter to the chain
+func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
+	if req.Payload == nil {
+		return errors.Errorf("empty transaction forwarded by %d", sender)
+	}
+	return c.submit(req.Payload, req.LastValidationSeq, sender)
+}
+
+// StatusReport returns the ClusterRelation & Status
+func (c *Chain) StatusReport() (types.ClusterRelation, types.Status) {
+	return types.ClusterRelationMember, types.StatusActive
+}
+
+// ValidateConsensusMetadata determines the validity of a
+// ConsensusMetadata update during config updates on the channel.
+func (c *Chain) ValidateConsensusMetadata(oldMetadataBytes, newMetadataBytes []byte, newChannel bool) error {
+	return validateConsensusMetadata(oldMetadataBytes, newMetadataBytes, newChannel)
+}
+
+func validateConsensusMetadata(oldMetadataBytes, newMetadataBytes []byte, newChannel bool) error {
+	if newMetadataBytes == nil {
+		return errors.New("new BFT config metadata is nil")
+	}
+	newMetadata := &commonbft.ConfigMetadata{}
+	if err := proto.Unmarshal(newMetadataBytes, newMetadata); err != nil {
+		return errors.Wrap(err, "failed to unmarshal new BFT config metadata")
+	}
+	if err := commonbft.ValidateConfigMetadata(newMetadata); err != nil {
+		return errors.Wrap(err, "invalid new BFT config metadata")
+	}
+	if newChannel {
+		return nil
+	}
+
+	if oldMetadataBytes == nil {
+		return errors.New("old BFT config metadata is nil")
+	}
+	oldMetadata := &commonbft.ConfigMetadata{}
+	if err := proto.Unmarshal(oldMetadataBytes, oldMetadata); err != nil {
+		return errors.Wrap(err, "failed to unmarshal old BFT config metadata")
+	}
+
+	// A single consenter may join or leave the channel at a time, and the
+	// identifier of a consenter cannot be reassigned to another consenter
+	oldConsenters := make(map[uint64]*commonbft.Consenter)
+	for _, consenter := range oldMetadata.Consenters {
+		oldConsenters[consenter.Id] = consenter
+	}
+	changes := 0
+	for _, consenter := range newMetadata.Consenters {
+		oldConsenter, exists := oldConsenters[consenter.Id]
+		if !exists {
+			changes++
+			continue
+		}
+		if oldConsenter.MspId != consenter.MspId {
+			return errors.Errorf("the MSP ID of consenter %d cannot change from %s to %s", consenter.Id, oldConsenter.MspId, consenter.MspId)
+		}
+		delete(oldConsenters, consenter.Id)
+	}
+	changes += len(oldConsenters)
+	if changes > 1 {
+		return errors.Errorf("update of more than one consenter at a time is not supported, requested changes: %d", changes)
+	}
+	return nil
+}
+
+func (c *Chain) isRunning() error {
+	select {
+	case <-c.startC:
+	default:
+		return errors.Errorf("chain is not started")
+	}
+
+	select {
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	default:
+	}
+
+	return nil
+}
+
+func (c *Chain) submit(env *cb.Envelope, configSeq uint64, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	s := &submission{env: env, configSeq: configSeq, sender: sender, errC: make(chan error, 1)}
+	select {
+	case c.submitC <- s:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+
+	select {
+	case err := <-s.errC:
+		return err
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+}
+
+func (c *Chain) run() {
+	defer func() {
+		c.stopEgress()
+		c.errorCLock.Lock()
+		close(c.errorC)
+		c.errorCLock.Unlock()
+		close(c.doneC)
+	}()
+
+	ticker := c.clock.NewTicker(c.tickInterval())
+	defer ticker.Stop()
+
+	now := c.clock.Now()
+	c.lastHeartbeat = now
+	if c.viewChange != nil {
+		c.logger.Infof("Resuming the view change to view %d", c.viewChange.nextView)
+		c.broadcast(&Message{Content: &Message_ViewChange{ViewChange: &ViewChange{NextView: c.viewChange.nextView, Reason: "restart"}}})
+	} else if s := c.slot; s != nil {
+		c.resendVotes(s)
+	}
+
+	for {
+		var batchTimeoutC <-chan time.Time
+		if c.batchTimer != nil {
+			batchTimeoutC = c.batchTimer.C()
+		}
+
+		select {
+		case s := <-c.submitC:
+			s.errC <- c.onSubmission(s)
+		case m := <-c.msgC:
+			c.onMessage(m.sender, m.msg)
+		case <-batchTimeoutC:
+			c.batchTimer = nil
+			c.maybePropose(true)
+		case <-ticker.C():
+			c.onTick(c.clock.Now())
+		case <-c.haltC:
+			c.logger.Infof("Stopping BFT node")
+			return
+		}
+
+		if c.isEvicted() {
+			c.logger.Warningf("This node is no longer a consenter of the channel, stopping BFT node")
+			return
+		}
+	}
+}
+
+// tickInterval returns the interval at which the timeouts of the chain are checked
+func (c *Chain) tickInterval() time.Duration {
+	tick := c.conf.heartbeatInterval
+	for _, d := range []time.Duration{c.conf.requestForwardTimeout, c.conf.viewChangeResendInterval} {
+		if d < tick {
+			tick = d
+		}
+	}
+	tick = tick / 4
+	if tick < minTickInterval {
+		tick = minTickInterval
+	}
+	return tick
+}
+
+func (c *Chain) onSubmission(s *submission) error {
+	r, err := newRequest(s.env, s.configSeq, c.clock.Now())
+	if err != nil {
+		return errors.WithMessage(err, "invalid transaction")
+	}
+	if c.decided.contains(r.key) {
+		c.logger.Debugf("Transaction %s has already been ordered", r.key)
+		return nil
+	}
+	if err := c.pool.add(r); err != nil {
+		return err
+	}
+	c.metrics.RequestPoolSize.With("channel", c.channelID).Set(float64(c.pool.size()))
+
+	// A transaction submitted to a follower is forwarded to the leader, while
+	// the followers watch over the transactions forwarded by others as well
+	if leader := c.leader(); leader != c.selfID && s.sender == c.selfID && c.viewChange == nil {
+		c.forward(leader, r)
+	}
+	c.maybePropose(false)
+	return nil
+}
+
+func (c *Chain) forward(leader uint64, r *request) {
+	r.forwarded = c.clock.Now()
+	err := c.rpc.SendSubmit(leader, &orderer.SubmitRequest{
+		Channel:           c.channelID,
+		LastValidationSeq: r.configSeq,
+		Payload:           r.env,
+	})
+	if err != nil {
+		c.logger.Debugf("Failed to forward transaction to leader %d: %s", leader, err)
+	}
+}
+
+func (c *Chain) onTick(now time.Time) {
+	if c.viewChange != nil {
+		c.onViewChangeTick(now)
+		return
+	}
+
+	if c.leader() == c.selfID {
+		if now.Sub(c.lastHeartbeatSent) >= c.conf.heartbeatInterval {
+			c.lastHeartbeatSent = now
+			c.broadcast(&Message{Content: &Message_HeartBeat{HeartBeat: &HeartBeat{View: c.view, Seq: c.lastBlock.Header.Number}}})
+		}
+	} else if now.Sub(c.lastHeartbeat) >= c.conf.leaderHeartbeatTimeout {
+		c.complain("leader heartbeat timeout")
+		return
+	}
+
+	if s := c.slot; s != nil && now.Sub(s.lastVotes) >= c.conf.requestForwardTimeout {
+		c.resendVotes(s)
+	}
+
+	// The followers forward again the transactions which are not ordered, and
+	// suspect the leader of censoring the transactions which are not ordered
+	// for too long, while the transactions that cannot be ordered are dropped
+	leader := c.leader()
+	for _, r := range c.pool.all() {
+		age := now.Sub(r.arrival)
+		switch {
+		case age >= c.conf.requestAutoRemoveTimeout:
+			c.logger.Warningf("Removing transaction %s that has not been ordered for %v", r.key, age)
+			c.pool.remove(r.key)
+		case leader == c.selfID:
+		case now.Sub(r.watched) >= c.conf.requestComplainTimeout:
+			c.complain("transaction " + r.key + " has not been ordered in time")
+			return
+		case now.Sub(r.forwarded) >= c.conf.requestForwardTimeout:
+			c.forward(leader, r)
+		}
+	}
+	c.metrics.RequestPoolSize.With("channel", c.channelID).Set(float64(c.pool.size()))
+}
+
+func (c *Chain) onMessage(sender uint64, msg *Message) {
+	if !c.isConsenter(sender) {
+		c.logger.Warningf("Ignoring message from %d which is not a consenter of the channel", sender)
+		return
+	}
+
+	switch m := msg.Content.(type) {
+	case *Message_PrePrepare:
+		c.onPrePrepare(sender, m.PrePrepare)
+	case *Message_Prepare:
+		c.onPrepare(sender, m.Prepare)
+	case *Message_Commit:
+		c.onCommit(sender, m.Commit)
+	case *Message_HeartBeat:
+		c.onHeartBeat(sender, m.HeartBeat)
+	case *Message_ViewChange:
+		c.onViewChange(sender, m.ViewChange)
+	case *Message_ViewData:
+		c.onViewData(sender, m.ViewData)
+	case *Message_NewView:
+		c.onNewView(sender, m.NewView)
+	default:
+		c.logger.Warningf("Ignoring message of unknown type from %d", sender)
+	}
+}
+
+func (c *Chain) onHeartBeat(sender uint64, hb *HeartBeat) {
+	if hb.View > c.view && c.viewChange == nil {
+		// This node might have missed the view change of the other consenters,
+		// and its complaint makes the leader of the later view send it the new view
+		c.complain("heartbeat of a later view")
+		return
+	}
+	if hb.View != c.view {
+		return
+	}
+	if sender == c.leader() {
+		c.lastHeartbeat = c.clock.Now()
+	}
+
+	// A node that lags behind the leader for two heartbeats in a row pulls the blocks it misses
+	if hb.Seq <= c.lastBlock.Header.Number {
+		c.laggingBeats = 0
+		return
+	}
+	c.laggingBeats++
+	if c.laggingBeats >= 2 {
+		c.laggingBeats = 0
+		c.sync(hb.Seq)
+	}
+}
+
+// leader returns the id of the leader of the current view
+func (c *Chain) leader() uint64 {
+	return c.leaderOf(c.view, c.decisionsInView)
+}
+
+// leaderOf returns the id of the leader of the given view, which rotates to the next
+// consenter every DecisionsPerLeader decisions in the view
+func (c *Chain) leaderOf(view, decisionsInView uint64) uint64 {
+	rotation := uint64(0)
+	if c.conf.decisionsPerLeader > 0 {
+		rotation = decisionsInView / c.conf.decisionsPerLeader
+	}
+	return c.nodes[(view+rotation)%uint64(len(c.nodes))]
+}
+
+func (c *Chain) quorum() int {
+	return commonbft.Quorum(len(c.nodes))
+}
+
+func (c *Chain) maxFaults() int {
+	return commonbft.MaxFaults(len(c.nodes))
+}
+
+func (c *Chain) isConsenter(id uint64) bool {
+	return c.consenterByID(id) != nil
+}
+
+func (c *Chain) consenterByID(id uint64) *commonbft.Consenter {
+	for _, consenter := range c.consenters {
+		if consenter.Id == id {
+			return consenter
+		}
+	}
+	return nil
+}
+
+func (c *Chain) isEvicted() bool {
+	return !c.isConsenter(c.selfID)
+}
+
+func (c *Chain) setConsenters(consenters []*commonbft.Consenter) {
+	c.consenters = make([]*commonbft.Consenter, len(consenters))
+	copy(c.consenters, consenters)
+	sort.Slice(c.consenters, func(i, j int) bool { return c.consenters[i].Id < c.consenters[j].Id })
+	c.nodes = make([]uint64, len(c.consenters))
+	for i, consenter := range c.consenters {
+		c.nodes[i] = consenter.Id
+	}
+}
+
+func (c *Chain) reportLeader() {
+	leader := c.leader()
+	isLeader := 0.0
+	if leader == c.selfID {
+		isLeader = 1.0
+	}
+	c.metrics.IsLeader.With("channel", c.channelID).Set(isLeader)
+	c.metrics.LeaderID.With("channel", c.channelID).Set(float64(leader))
+	c.metrics.ViewNumber.With("channel", c.channelID).Set(float64(c.view))
+}
+
+// applyConfig applies the BFT config metadata of the channel after a config block is
+// written, and reconfigures the communication if the consenters have changed
+func (c *Chain) applyConfig() {
+	metadata, err := commonbft.MetadataFromOrdererConfig(c.support.SharedConfig())
+	if err != nil {
+		c.logger.Panicf("Failed to read the BFT config metadata of the channel: %s", err)
+	}
+	if metadata == nil {
+		c.logger.Infof("The consensus type of the channel is changing to %s, the node keeps on ordering "+
+			"the config transactions of the maintenance mode until it is restarted", c.support.SharedConfig().ConsensusType())
+		return
+	}
+
+	conf, err := parseConfig(metadata.Options)
+	if err != nil {
+		c.logger.Panicf("Failed to parse the BFT options of the channel: %s", err)
+	}
+	c.conf = conf
+	c.pool.maxSize = int(conf.requestPoolSize)
+
+	c.setConsenters(metadata.Consenters)
+	c.metrics.ClusterSize.With("channel", c.channelID).Set(float64(len(c.nodes)))
+	if c.isEvicted() {
+		return
+	}
+	if err := c.configureComm(); err != nil {
+		c.logger.Panicf("Failed to configure the communication with the consenters: %s", err)
+	}
+}
+
+func (c *Chain) configureComm() error {
+	var nodes []cluster.RemoteNode
+	for _, consenter := range c.consenters {
+		if consenter.Id == c.selfID {
+			continue
+		}
+		serverCertAsDER, err := pemToDER(consenter.ServerTlsCert, consenter.Id, "server", c.logger)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		clientCertAsDER, err := pemToDER(consenter.ClientTlsCert, consenter.Id, "client", c.logger)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            consenter.Id,
+			Endpoint:      endpointOf(consenter),
+			ServerTLSCert: serverCertAsDER,
+			ClientTLSCert: clientCertAsDER,
+		})
+	}
+	c.configurator.Configure(c.channelID, nodes)
+	return nil
+}
+
+// broadcast sends the message to all the other consenters
+func (c *Chain) broadcast(msg *Message) {
+	payload := protoutil.MarshalOrPanic(msg)
+	for _, id := range c.nodes {
+		if id != c.selfID {
+			c.enqueue(id, payload)
+		}
+	}
+}
+
+// send sends the message to the given consenter
+func (c *Chain) send(dest uint64, msg *Message) {
+	c.enqueue(dest, protoutil.MarshalOrPanic(msg))
+}
+
+// enqueue queues the message for the given consenter, so that the run loop is not blocked
+// by a consenter that is slow or unreachable. The message is dropped if the queue is full,
+// since the protocol recovers from lost messages
+func (c *Chain) enqueue(dest uint64, payload []byte) {
+	c.egressLock.Lock()
+	defer c.egressLock.Unlock()
+
+	queue, exists := c.egress[dest]
+	if !exists {
+		queue = make(chan []byte, egressBufferSize)
+		c.egress[dest] = queue
+		c.egressWG.Add(1)
+		go func() {
+			defer c.egressWG.Done()
+			for payload := range queue {
+				err := c.rpc.SendConsensus(dest, &orderer.ConsensusRequest{Channel: c.channelID, Payload: payload})
+				if err != nil {
+					c.logger.Debugf("Failed to send message to %d: %s", dest, err)
+				}
+			}
+		}()
+	}
+
+	select {
+	case queue <- payload:
+	default:
+		c.logger.Debugf("Dropping message to %d since its queue is full", dest)
+	}
+}
+
+func (c *Chain) stopEgress() {
+	c.egressLock.Lock()
+	for id, queue := range c.egress {
+		close(queue)
+		delete(c.egress, id)
+	}
+	c.egressLock.Unlock()
+	c.egressWG.Wait()
+}
@@ -0,0 +1,459 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	commonbft "github.com/hyperledger/fabric/common/bft"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const eventuallyTimeout = 10 * time.Second
+
+// ledgerSupport is a ConsenterSupport that keeps the blocks of the channel in memory
+type ledgerSupport struct {
+	consensus.ConsenterSupport
+
+	identity []byte
+	config   *mocks.OrdererConfig
+
+	lock   sync.Mutex
+	blocks []*cb.Block
+}
+
+func newLedgerSupport(consenter *commonbft.Consenter, metadata *commonbft.ConfigMetadata) *ledgerSupport {
+	config := &mocks.OrdererConfig{}
+	config.ConsensusTypeReturns(commonbft.ConsensusType)
+	config.ConsensusMetadataReturns(protoutil.MarshalOrPanic(metadata))
+	config.BatchSizeReturns(&orderer.BatchSize{MaxMessageCount: 10, PreferredMaxBytes: 1 << 20, AbsoluteMaxBytes: 1 << 20})
+	config.BatchTimeoutReturns(time.Second)
+
+	genesis := protoutil.NewBlock(0, nil)
+	genesis.Data.Data = [][]byte{[]byte("genesis")}
+	genesis.Header.DataHash = protoutil.BlockDataHash(genesis.Data)
+
+	return &ledgerSupport{
+		identity: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: consenter.MspId, IdBytes: consenter.Identity}),
+		config:   config,
+		blocks:   []*cb.Block{genesis},
+	}
+}
+
+func (s *ledgerSupport) ChannelID() string          { return "mychannel" }
+func (s *ledgerSupport) Sequence() uint64           { return 0 }
+func (s *ledgerSupport) Serialize() ([]byte, error) { return s.identity, nil }
+func (s *ledgerSupport) Sign(message []byte) ([]byte, error) {
+	return signatureOf(s.identity, message), nil
+}
+
+func (s *ledgerSupport) SharedConfig() channelconfig.Orderer { return s.config }
+
+func (s *ledgerSupport) ProcessNormalMsg(env *cb.Envelope) (uint64, error) {
+	if bytes.Contains(env.Payload, []byte("invalid")) {
+		return 0, errors.New("invalid transaction")
+	}
+	return 0, nil
+}
+
+func (s *ledgerSupport) Height() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return uint64(len(s.blocks))
+}
+
+func (s *ledgerSupport) Block(number uint64) *cb.Block {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if number >= uint64(len(s.blocks)) {
+		return nil
+	}
+	return s.blocks[number]
+}
+
+func (s *ledgerSupport) CreateNextBlock(envs []*cb.Envelope) *cb.Block {
+	last := s.Block(s.Height() - 1)
+	block := protoutil.NewBlock(last.Header.Number+1, protoutil.BlockHeaderHash(last.Header))
+	for _, env := range envs {
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(env))
+	}
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	return block
+}
+
+func (s *ledgerSupport) WriteBlock(block *cb.Block, encodedMetadataValue []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.blocks = append(s.blocks, block)
+}
+
+func (s *ledgerSupport) WriteConfigBlock(block *cb.Block, encodedMetadataValue []byte) {
+	s.WriteBlock(block, encodedMetadataValue)
+}
+
+func signatureOf(identity, message []byte) []byte {
+	return util.ComputeSHA256(util.ConcatenateBytes(identity, message))
+}
+
+func verifySignature(sd *protoutil.SignedData) error {
+	if !bytes.Equal(sd.Signature, signatureOf(sd.Identity, sd.Data)) {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+// network routes the messages among the chains of the test
+type network struct {
+	lock         sync.Mutex
+	chains       map[uint64]*Chain
+	disconnected map[uint64]bool
+}
+
+func (n *network) chain(from, to uint64) (*Chain, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.disconnected[from] || n.disconnected[to] {
+		return nil, errors.Errorf("%d is unreachable from %d", to, from)
+	}
+	return n.chains[to], nil
+}
+
+func (n *network) setDisconnected(id uint64, disconnected bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.disconnected[id] = disconnected
+}
+
+type nodeRPC struct {
+	self    uint64
+	network *network
+}
+
+func (r *nodeRPC) SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error {
+	chain, err := r.network.chain(r.self, dest)
+	if err != nil {
+		return err
+	}
+	return chain.Consensus(msg, r.self)
+}
+
+func (r *nodeRPC) SendSubmit(dest uint64, request *orderer.SubmitRequest) error {
+	chain, err := r.network.chain(r.self, dest)
+	if err != nil {
+		return err
+	}
+	return chain.Submit(request, r.self)
+}
+
+type noopConfigurator struct{}
+
+func (noopConfigurator) Configure(channel string, newNodes []cluster.RemoteNode) {}
+
+// ledgerPuller pulls the blocks from the ledger of another node
+type ledgerPuller struct {
+	support *ledgerSupport
+}
+
+func (p *ledgerPuller) PullBlock(seq uint64) *cb.Block {
+	block := p.support.Block(seq)
+	if block == nil {
+		return nil
+	}
+	return proto.Clone(block).(*cb.Block)
+}
+
+func (p *ledgerPuller) HeightsByEndpoints() (map[string]uint64, error) {
+	return map[string]uint64{"source": p.support.Height()}, nil
+}
+
+func (p *ledgerPuller) Close() {}
+
+func newConsenters(t *testing.T, n int) []*commonbft.Consenter {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+
+	var consenters []*commonbft.Consenter
+	for i := 1; i <= n; i++ {
+		server, err := ca.NewServerCertKeyPair(fmt.Sprintf("orderer%d", i))
+		require.NoError(t, err)
+		client, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		identity, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		consenters = append(consenters, &commonbft.Consenter{
+			Host:          fmt.Sprintf("orderer%d", i),
+			Port:          7050,
+			ServerTlsCert: server.Cert,
+			ClientTlsCert: client.Cert,
+			Id:            uint64(i),
+			MspId:         "OrdererMSP",
+			Identity:      identity.Cert,
+		})
+	}
+	return consenters
+}
+
+// testCluster runs the chains of the consenters of a channel in process
+type testCluster struct {
+	t          *testing.T
+	dir        string
+	clock      *fakeclock.FakeClock
+	network    *network
+	metadata   *commonbft.ConfigMetadata
+	supports   map[uint64]*ledgerSupport
+	chains     map[uint64]*Chain
+	pullSource uint64
+}
+
+func newCluster(t *testing.T, n int) *testCluster {
+	dir, err := ioutil.TempDir("", "bft-chain")
+	require.NoError(t, err)
+
+	metadata := &commonbft.ConfigMetadata{Consenters: newConsenters(t, n)}
+	c := &testCluster{
+		t:        t,
+		dir:      dir,
+		clock:    fakeclock.NewFakeClock(time.Now()),
+		network:  &network{chains: make(map[uint64]*Chain), disconnected: make(map[uint64]bool)},
+		metadata: metadata,
+		supports: make(map[uint64]*ledgerSupport),
+		chains:   make(map[uint64]*Chain),
+	}
+	for _, consenter := range metadata.Consenters {
+		c.supports[consenter.Id] = newLedgerSupport(consenter, metadata)
+		c.start(consenter.Id)
+	}
+	return c
+}
+
+// start creates the chain of the given node from its ledger and its saved state, and starts it
+func (c *testCluster) start(id uint64) {
+	support := c.supports[id]
+	var viewMetadata *commonbft.ViewMetadata
+	if md, err := protoutil.GetConsenterMetadataFromBlock(support.Block(support.Height() - 1)); err == nil && len(md.Value) > 0 {
+		viewMetadata = &commonbft.ViewMetadata{}
+		require.NoError(c.t, proto.Unmarshal(md.Value, viewMetadata))
+	}
+
+	chain, err := NewChain(
+		support,
+		Options{
+			SelfID:         id,
+			ConfigMetadata: c.metadata,
+			ViewMetadata:   viewMetadata,
+			StateDir:       filepath.Join(c.dir, fmt.Sprintf("orderer%d", id)),
+			Clock:          c.clock,
+			Logger:         flogging.MustGetLogger("orderer.consensus.bft.test"),
+			Metrics:        NewMetrics(&disabled.Provider{}),
+		},
+		&nodeRPC{self: id, network: c.network},
+		noopConfigurator{},
+		verifySignature,
+		func() (etcdraft.BlockPuller, error) {
+			return &ledgerPuller{support: c.supports[c.pullSource]}, nil
+		},
+	)
+	require.NoError(c.t, err)
+
+	c.network.lock.Lock()
+	c.network.chains[id] = chain
+	c.network.lock.Unlock()
+	c.chains[id] = chain
+	chain.Start()
+}
+
+func (c *testCluster) halt(id uint64) {
+	c.chains[id].Halt()
+}
+
+func (c *testCluster) stop() {
+	for _, chain := range c.chains {
+		chain.Halt()
+	}
+	os.RemoveAll(c.dir)
+}
+
+// waitForHeight advances the clock until all the given nodes reach the given height
+func (c *testCluster) waitForHeight(height uint64, ids ...uint64) {
+	require.Eventually(c.t, func() bool {
+		c.clock.Increment(250 * time.Millisecond)
+		for _, id := range ids {
+			if c.supports[id].Height() < height {
+				return false
+			}
+		}
+		return true
+	}, eventuallyTimeout, 10*time.Millisecond)
+}
+
+// requireConsistent checks that the given nodes have the same blocks, each signed by a quorum of the consenters
+func (c *testCluster) requireConsistent(ids ...uint64) {
+	reference := c.supports[ids[0]]
+	for number := uint64(1); number < reference.Height(); number++ {
+		block := reference.Block(number)
+		require.NoError(c.t, commonbft.VerifyBlockSignatures(block, c.metadata.Consenters, verifySignature))
+		for _, id := range ids[1:] {
+			other := c.supports[id].Block(number)
+			require.NotNil(c.t, other, "node %d misses block [%d]", id, number)
+			require.True(c.t, proto.Equal(block.Header, other.Header), "node %d has a different block [%d]", id, number)
+		}
+	}
+}
+
+func envelope(data string) *cb.Envelope {
+	return makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, []byte(data))
+}
+
+func TestChainOrdersTransactions(t *testing.T) {
+	c := newCluster(t, 4)
+	defer c.stop()
+
+	// A transaction submitted to a follower is forwarded to the leader
+	require.NoError(t, c.chains[3].Order(envelope("tx1"), 0))
+	c.waitForHeight(2, 1, 2, 3, 4)
+
+	// A transaction that is submitted to several nodes is ordered once
+	for _, id := range []uint64{1, 2, 4} {
+		require.NoError(t, c.chains[id].Order(envelope("tx2"), 0))
+	}
+	c.waitForHeight(3, 1, 2, 3, 4)
+	for i := 0; i < 20; i++ {
+		c.clock.Increment(250 * time.Millisecond)
+	}
+	require.Equal(t, uint64(3), c.supports[1].Height())
+	c.requireConsistent(1, 2, 3, 4)
+
+	// The leader rotates every DecisionsPerLeader decisions
+	for i := 3; i <= 5; i++ {
+		require.NoError(t, c.chains[uint64(i%4+1)].Order(envelope(fmt.Sprintf("tx%d", i)), 0))
+		c.waitForHeight(uint64(i+1), 1, 2, 3, 4)
+	}
+	c.requireConsistent(1, 2, 3, 4)
+	for _, id := range []uint64{1, 2, 3, 4} {
+		require.Equal(t, uint64(2), c.chains[id].leaderOf(0, 3))
+	}
+
+	// An invalid transaction is not ordered
+	require.NoError(t, c.chains[1].Order(envelope("invalid"), 0))
+	require.NoError(t, c.chains[1].Order(envelope("tx6"), 0))
+	c.waitForHeight(7, 1, 2, 3, 4)
+	block := c.supports[4].Block(6)
+	require.Len(t, block.Data.Data, 1)
+}
+
+func TestChainViewChangeOnLeaderFailure(t *testing.T) {
+	c := newCluster(t, 4)
+	defer c.stop()
+
+	require.NoError(t, c.chains[2].Order(envelope("tx1"), 0))
+	c.waitForHeight(2, 1, 2, 3, 4)
+
+	// The leader of view 0 crashes, and the followers replace it once the
+	// transactions submitted to them are not ordered in time
+	c.network.setDisconnected(1, true)
+	for _, id := range []uint64{2, 3, 4} {
+		require.NoError(t, c.chains[id].Order(envelope("tx2"), 0))
+	}
+	c.waitForHeight(3, 2, 3, 4)
+	c.requireConsistent(2, 3, 4)
+
+	for _, id := range []uint64{2, 3, 4} {
+		require.Equal(t, uint64(1), c.chains[id].view)
+	}
+	metadata, err := protoutil.GetConsenterMetadataFromBlock(c.supports[3].Block(2))
+	require.NoError(t, err)
+	viewMetadata := &commonbft.ViewMetadata{}
+	require.NoError(t, proto.Unmarshal(metadata.Value, viewMetadata))
+	require.Equal(t, uint64(1), viewMetadata.ViewId)
+
+	// The former leader reconnects, and catches up with the blocks decided in its absence
+	c.pullSource = 2
+	c.network.setDisconnected(1, false)
+	require.NoError(t, c.chains[3].Order(envelope("tx3"), 0))
+	c.waitForHeight(4, 1, 2, 3, 4)
+	c.requireConsistent(1, 2, 3, 4)
+}
+
+func TestChainRestart(t *testing.T) {
+	c := newCluster(t, 4)
+	defer c.stop()
+
+	require.NoError(t, c.chains[1].Order(envelope("tx1"), 0))
+	c.waitForHeight(2, 1, 2, 3, 4)
+
+	// A follower restarts and keeps on ordering
+	c.halt(4)
+	c.start(4)
+	require.NoError(t, c.chains[4].Order(envelope("tx2"), 0))
+	c.waitForHeight(3, 1, 2, 3, 4)
+
+	// The cluster tolerates a follower that is down
+	c.halt(3)
+	require.NoError(t, c.chains[2].Order(envelope("tx3"), 0))
+	c.waitForHeight(4, 1, 2, 4)
+	c.requireConsistent(1, 2, 4)
+
+	// The follower that is restarted pulls the blocks that it missed
+	c.pullSource = 1
+	c.start(3)
+	require.NoError(t, c.chains[1].Order(envelope("tx4"), 0))
+	c.waitForHeight(5, 1, 2, 3, 4)
+	c.requireConsistent(1, 2, 3, 4)
+}
+
+func TestValidateConsensusMetadata(t *testing.T) {
+	consenters := newConsenters(t, 5)
+	metadataOf := func(consenters ...*commonbft.Consenter) []byte {
+		return protoutil.MarshalOrPanic(&commonbft.ConfigMetadata{Consenters: consenters})
+	}
+	four := metadataOf(consenters[:4]...)
+
+	require.NoError(t, validateConsensusMetadata(nil, four, true))
+	require.NoError(t, validateConsensusMetadata(four, four, false))
+	require.NoError(t, validateConsensusMetadata(four, metadataOf(consenters...), false))
+	require.NoError(t, validateConsensusMetadata(four, metadataOf(consenters[1:4]...), false))
+
+	err := validateConsensusMetadata(four, metadataOf(consenters[2:]...), false)
+	require.EqualError(t, err, "update of more than one consenter at a time is not supported, requested changes: 3")
+
+	changedMSP := proto.Clone(consenters[0]).(*commonbft.Consenter)
+	changedMSP.MspId = "OtherMSP"
+	err = validateConsensusMetadata(four, metadataOf(changedMSP, consenters[1], consenters[2], consenters[3]), false)
+	require.EqualError(t, err, "the MSP ID of consenter 1 cannot change from OrdererMSP to OtherMSP")
+
+	err = validateConsensusMetadata(four, metadataOf(), false)
+	require.EqualError(t, err, "invalid new BFT config metadata: empty consenter set")
+
+	err = validateConsensusMetadata(nil, four, false)
+	require.EqualError(t, err, "old BFT config metadata is nil")
+
+	err = validateConsensusMetadata(four, nil, false)
+	require.EqualError(t, err, "new BFT config metadata is nil")
+}
@@ -0,0 +1,622 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	commonbft "github.com/hyperledger/fabric/common/bft"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// slot holds the progress of the agreement on the proposal of the next sequence
+type slot struct {
+	view     uint64
+	seq      uint64
+	proposal *Proposal
+	digest   string
+	header   *cb.BlockHeader
+	data     *cb.BlockData
+	keys     []string
+	isConfig bool
+
+	// signatureValue is the value of the signatures metadata of the block,
+	// over which the consenters sign the block
+	signatureValue     []byte
+	lastConfigBlockNum uint64
+
+	prepares  map[uint64]string
+	commits   map[uint64]*Signature
+	prepared  bool
+	recovery  bool
+	proposed  time.Time
+	lastVotes time.Time
+}
+
+func digestOf(proposal *Proposal) string {
+	return hex.EncodeToString(util.ComputeSHA256(protoutil.MarshalOrPanic(proposal)))
+}
+
+func viewMetadataOf(proposal *Proposal) (*commonbft.ViewMetadata, error) {
+	md := &commonbft.ViewMetadata{}
+	if err := proto.Unmarshal(proposal.Metadata, md); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the view metadata of the proposal")
+	}
+	return md, nil
+}
+
+// newSlot creates a slot in the given view for the proposal of the sequence
+// that follows the last block
+func (c *Chain) newSlot(view uint64, proposal *Proposal) (*slot, error) {
+	if proposal == nil {
+		return nil, errors.New("nil proposal")
+	}
+	header := &cb.BlockHeader{}
+	if err := proto.Unmarshal(proposal.Header, header); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the block header of the proposal")
+	}
+	data := &cb.BlockData{}
+	if err := proto.Unmarshal(proposal.Payload, data); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the block data of the proposal")
+	}
+	md, err := viewMetadataOf(proposal)
+	if err != nil {
+		return nil, err
+	}
+	if md.LatestSequence != header.Number {
+		return nil, errors.Errorf("the view metadata of the proposal is of sequence %d while the block is [%d]", md.LatestSequence, header.Number)
+	}
+	if len(data.Data) == 0 {
+		return nil, errors.Errorf("the proposal of block [%d] is empty", header.Number)
+	}
+
+	now := c.clock.Now()
+	s := &slot{
+		view:               view,
+		seq:                header.Number,
+		proposal:           proposal,
+		digest:             digestOf(proposal),
+		header:             header,
+		data:               data,
+		lastConfigBlockNum: c.lastConfigBlockNum,
+		prepares:           make(map[uint64]string),
+		commits:            make(map[uint64]*Signature),
+		proposed:           now,
+		lastVotes:          now,
+	}
+
+	for _, envBytes := range data.Data {
+		env, err := protoutil.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		key, isConfig, err := requestKey(env)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid transaction in the proposal of block [%d]", header.Number)
+		}
+		if isConfig {
+			if len(data.Data) != 1 {
+				return nil, errors.Errorf("the proposal of block [%d] contains a config transaction that is not alone in the block", header.Number)
+			}
+			s.isConfig = true
+			chdr, err := protoutil.ChannelHeader(env)
+			if err != nil {
+				return nil, err
+			}
+			// The last config of a block is updated only by blocks that change the config
+			// of the channel, and not by the blocks that create a new channel
+			if cb.HeaderType(chdr.Type) == cb.HeaderType_CONFIG {
+				s.lastConfigBlockNum = header.Number
+			}
+		}
+		s.keys = append(s.keys, key)
+	}
+
+	s.signatureValue = protoutil.MarshalOrPanic(&cb.OrdererBlockMetadata{
+		LastConfig:        &cb.LastConfig{Index: s.lastConfigBlockNum},
+		ConsenterMetadata: protoutil.MarshalOrPanic(&cb.Metadata{Value: proposal.Metadata}),
+	})
+
+	return s, nil
+}
+
+// maybePropose proposes the next block if this node is the leader and the pool holds enough
+// requests to fill a batch, or any request once the batch timeout expired
+func (c *Chain) maybePropose(timeout bool) {
+	if c.viewChange != nil || c.slot != nil || c.leader() != c.selfID {
+		return
+	}
+	if c.pool.size() == 0 {
+		c.stopBatchTimer()
+		return
+	}
+
+	batchSize := c.support.SharedConfig().BatchSize()
+	if !timeout && !c.pool.batchReady(batchSize.MaxMessageCount, batchSize.PreferredMaxBytes) {
+		if c.batchTimer == nil {
+			c.batchTimer = c.clock.NewTimer(c.support.SharedConfig().BatchTimeout())
+		}
+		return
+	}
+	c.stopBatchTimer()
+
+	var envs []*cb.Envelope
+	for len(envs) == 0 {
+		batch := c.pool.nextBatch(batchSize.MaxMessageCount, batchSize.PreferredMaxBytes)
+		if len(batch) == 0 {
+			return
+		}
+		envs = c.revalidate(batch)
+	}
+
+	block := c.support.CreateNextBlock(envs)
+	proposal := &Proposal{
+		Header:  protoutil.MarshalOrPanic(block.Header),
+		Payload: protoutil.MarshalOrPanic(block.Data),
+		Metadata: protoutil.MarshalOrPanic(&commonbft.ViewMetadata{
+			ViewId:          c.view,
+			LatestSequence:  block.Header.Number,
+			DecisionsInView: c.decisionsInView,
+		}),
+		VerificationSequence: c.support.Sequence(),
+	}
+
+	s, err := c.newSlot(c.view, proposal)
+	if err != nil {
+		c.logger.Panicf("Failed to create the proposal of block [%d]: %s", block.Header.Number, err)
+	}
+	c.logger.Debugf("Proposing block [%d] with %d transactions in view %d", s.seq, len(envs), c.view)
+
+	c.slot = s
+	c.persist()
+	c.broadcast(&Message{Content: &Message_PrePrepare{PrePrepare: &PrePrepare{View: s.view, Seq: s.seq, Proposal: proposal}}})
+	c.prepare(s)
+}
+
+// revalidate validates the requests of the batch against the current config of the channel, and
+// returns their envelopes. The requests that are no longer valid are removed from the pool
+func (c *Chain) revalidate(batch []*request) []*cb.Envelope {
+	var envs []*cb.Envelope
+	for _, r := range batch {
+		env := r.env
+		var err error
+		if r.isConfig {
+			env, _, err = c.support.ProcessConfigMsg(r.env)
+		} else {
+			_, err = c.support.ProcessNormalMsg(r.env)
+		}
+		if err != nil {
+			c.logger.Warningf("Discarding transaction %s that is no longer valid: %s", r.key, err)
+			c.pool.remove(r.key)
+			continue
+		}
+		envs = append(envs, env)
+	}
+	return envs
+}
+
+func (c *Chain) stopBatchTimer() {
+	if c.batchTimer != nil {
+		c.batchTimer.Stop()
+		c.batchTimer = nil
+	}
+}
+
+func (c *Chain) onPrePrepare(sender uint64, pp *PrePrepare) {
+	if !c.acceptVote(sender, pp.View, pp.Seq, &Message{Content: &Message_PrePrepare{PrePrepare: pp}}) {
+		return
+	}
+	if leader := c.leader(); sender != leader {
+		c.logger.Warningf("Ignoring proposal of block [%d] from %d while the leader is %d", pp.Seq, sender, leader)
+		return
+	}
+
+	if s := c.slot; s != nil {
+		if s.view == pp.View && pp.Proposal != nil && s.digest != digestOf(pp.Proposal) {
+			c.complain("the leader proposed two different blocks for the same sequence")
+		}
+		return
+	}
+
+	s, err := c.verifyPrePrepare(pp)
+	if err != nil {
+		c.logger.Warningf("Rejecting proposal of block [%d] from %d: %s", pp.Seq, sender, err)
+		c.metrics.ProposalFailures.With("channel", c.channelID).Add(1)
+		c.complain("invalid proposal")
+		return
+	}
+
+	c.slot = s
+	c.persist()
+	c.prepare(s)
+	c.replayFutureMessages()
+}
+
+func (c *Chain) verifyPrePrepare(pp *PrePrepare) (*slot, error) {
+	s, err := c.newSlot(pp.View, pp.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	md, err := viewMetadataOf(pp.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	if md.ViewId != pp.View || md.LatestSequence != pp.Seq || md.DecisionsInView != c.decisionsInView {
+		return nil, errors.Errorf("expected view metadata [view: %d, sequence: %d, decisions in view: %d], got [%d, %d, %d]",
+			pp.View, pp.Seq, c.decisionsInView, md.ViewId, md.LatestSequence, md.DecisionsInView)
+	}
+	if err := c.verifyProposalContent(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// verifyProposalContent checks that the block of the proposal is the next block of the
+// ledger, and that its transactions are valid under the current config of the channel
+func (c *Chain) verifyProposalContent(s *slot) error {
+	if seq := c.support.Sequence(); s.proposal.VerificationSequence != seq {
+		return errors.Errorf("the proposal is verified at config sequence %d while the config sequence is %d", s.proposal.VerificationSequence, seq)
+	}
+
+	envs := make([]*cb.Envelope, len(s.data.Data))
+	for i, envBytes := range s.data.Data {
+		env, err := protoutil.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			return err
+		}
+		envs[i] = env
+	}
+
+	if s.isConfig {
+		if err := c.verifyConfigTransaction(envs[0]); err != nil {
+			return err
+		}
+	} else {
+		for i, env := range envs {
+			if _, err := c.support.ProcessNormalMsg(env); err != nil {
+				return errors.WithMessagef(err, "invalid transaction %d", i)
+			}
+		}
+	}
+
+	expected := c.support.CreateNextBlock(envs)
+	if s.header.Number != expected.Header.Number {
+		return errors.Errorf("the proposal is of block [%d] while the next block is [%d]", s.header.Number, expected.Header.Number)
+	}
+	if !bytes.Equal(s.header.PreviousHash, expected.Header.PreviousHash) {
+		return errors.Errorf("the previous hash of block [%d] does not match the last block", s.header.Number)
+	}
+	if !bytes.Equal(s.header.DataHash, expected.Header.DataHash) {
+		return errors.Errorf("the data hash of block [%d] does not match its data", s.header.Number)
+	}
+	for i := range expected.Data.Data {
+		if !bytes.Equal(expected.Data.Data[i], s.data.Data[i]) {
+			return errors.Errorf("transaction %d of block [%d] is not encoded canonically", i, s.header.Number)
+		}
+	}
+	return nil
+}
+
+// verifyConfigTransaction checks that the config transaction carries the config that
+// this node computes from the config update that is embedded in it
+func (c *Chain) verifyConfigTransaction(env *cb.Envelope) error {
+	proposed, err := configOf(env)
+	if err != nil {
+		return err
+	}
+	processed, _, err := c.support.ProcessConfigMsg(env)
+	if err != nil {
+		return errors.WithMessage(err, "invalid config transaction")
+	}
+	expected, err := configOf(processed)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(proposed, expected) {
+		return errors.New("the config of the config transaction does not match the config update")
+	}
+	return nil
+}
+
+func configOf(env *cb.Envelope) (*cb.Config, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header in payload")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if cb.HeaderType(chdr.Type) == cb.HeaderType_ORDERER_TRANSACTION {
+		newChannelEnv, err := protoutil.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+		return configOf(newChannelEnv)
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	return configEnv.Config, nil
+}
+
+// prepare broadcasts the prepare of this node for the slot and counts it
+func (c *Chain) prepare(s *slot) {
+	s.prepares[c.selfID] = s.digest
+	c.broadcast(&Message{Content: &Message_Prepare{Prepare: &Prepare{View: s.view, Seq: s.seq, Digest: s.digest}}})
+	c.checkPrepared(s)
+}
+
+func (c *Chain) onPrepare(sender uint64, p *Prepare) {
+	if !c.acceptVote(sender, p.View, p.Seq, &Message{Content: &Message_Prepare{Prepare: p}}) {
+		return
+	}
+	s := c.slot
+	s.prepares[sender] = p.Digest
+	c.checkPrepared(s)
+}
+
+func (c *Chain) checkPrepared(s *slot) {
+	if s.prepared {
+		return
+	}
+	count := 0
+	for _, digest := range s.prepares {
+		if digest == s.digest {
+			count++
+		}
+	}
+	if count < c.quorum() {
+		return
+	}
+
+	s.prepared = true
+	s.commits[c.selfID] = c.sign(s)
+	c.persist()
+	c.broadcast(&Message{Content: &Message_Commit{Commit: &Commit{View: s.view, Seq: s.seq, Digest: s.digest, Signature: s.commits[c.selfID]}}})
+	c.checkCommitted(s)
+}
+
+func (c *Chain) sign(s *slot) *Signature {
+	signatureHeader := protoutil.MarshalOrPanic(protoutil.NewSignatureHeaderOrPanic(c.support))
+	return &Signature{
+		Signer:          c.selfID,
+		SignatureHeader: signatureHeader,
+		Signature: protoutil.SignOrPanic(
+			c.support,
+			util.ConcatenateBytes(s.signatureValue, signatureHeader, protoutil.BlockHeaderBytes(s.header)),
+		),
+	}
+}
+
+func (c *Chain) onCommit(sender uint64, cm *Commit) {
+	if !c.acceptVote(sender, cm.View, cm.Seq, &Message{Content: &Message_Commit{Commit: cm}}) {
+		return
+	}
+	s := c.slot
+	if cm.Digest != s.digest {
+		c.logger.Warningf("Ignoring commit of %d for block [%d] with digest %s while the proposal has digest %s", sender, s.seq, cm.Digest, s.digest)
+		return
+	}
+	if _, exists := s.commits[sender]; exists {
+		return
+	}
+	if cm.Signature == nil || cm.Signature.Signer != sender {
+		c.logger.Warningf("Ignoring commit of %d for block [%d] without a signature of %d", sender, s.seq, sender)
+		return
+	}
+	data := util.ConcatenateBytes(s.signatureValue, cm.Signature.SignatureHeader, protoutil.BlockHeaderBytes(s.header))
+	if err := c.verifyConsenterSignature(sender, cm.Signature.SignatureHeader, data, cm.Signature.Signature); err != nil {
+		c.logger.Warningf("Ignoring commit of %d for block [%d]: %s", sender, s.seq, err)
+		return
+	}
+	s.commits[sender] = cm.Signature
+	c.checkCommitted(s)
+}
+
+// verifyConsenterSignature checks that the signature is a valid signature of the consenter
+// with the given id, whose identity is the creator of the signature header
+func (c *Chain) verifyConsenterSignature(signer uint64, signatureHeader, data, signature []byte) error {
+	shdr, err := protoutil.UnmarshalSignatureHeader(signatureHeader)
+	if err != nil {
+		return err
+	}
+	consenter := commonbft.ConsenterOfIdentity(c.consenters, shdr.Creator)
+	if consenter == nil || consenter.Id != signer {
+		return errors.Errorf("the signature is not created by the identity of consenter %d", signer)
+	}
+	return c.verify(&protoutil.SignedData{
+		Identity:  shdr.Creator,
+		Data:      data,
+		Signature: signature,
+	})
+}
+
+func (c *Chain) checkCommitted(s *slot) {
+	if len(s.commits) >= c.quorum() {
+		c.decide(s)
+	}
+}
+
+// acceptVote returns whether a message of the agreement on the given view and sequence
+// can be processed now. A message that can be processed once the proposal of the
+// current sequence is known or the current sequence is decided is kept for later
+func (c *Chain) acceptVote(sender uint64, view, seq uint64, msg *Message) bool {
+	if c.viewChange != nil || view != c.view {
+		return false
+	}
+	next := c.lastBlock.Header.Number + 1
+	_, isPrePrepare := msg.Content.(*Message_PrePrepare)
+	switch {
+	case seq == next && (c.slot != nil || isPrePrepare):
+		return true
+	case seq == next || seq == next+1:
+		if len(c.futureMessages[sender]) < maxFutureMessagesPerSender {
+			c.futureMessages[sender] = append(c.futureMessages[sender], msg)
+		}
+	}
+	return false
+}
+
+func (c *Chain) replayFutureMessages() {
+	messages := c.futureMessages
+	c.futureMessages = make(map[uint64][]*Message)
+	for sender, msgs := range messages {
+		for _, msg := range msgs {
+			c.onMessage(sender, msg)
+		}
+	}
+}
+
+// resendVotes sends again the messages of this node for the slot, in case they were lost
+func (c *Chain) resendVotes(s *slot) {
+	s.lastVotes = c.clock.Now()
+	if !s.recovery && c.leader() == c.selfID {
+		c.broadcast(&Message{Content: &Message_PrePrepare{PrePrepare: &PrePrepare{View: s.view, Seq: s.seq, Proposal: s.proposal}}})
+	}
+	c.broadcast(&Message{Content: &Message_Prepare{Prepare: &Prepare{View: s.view, Seq: s.seq, Digest: s.digest}}})
+	if s.prepared {
+		c.broadcast(&Message{Content: &Message_Commit{Commit: &Commit{View: s.view, Seq: s.seq, Digest: s.digest, Signature: s.commits[c.selfID]}}})
+	}
+}
+
+// restoreInFlight restores the slot of the proposal that this node accepted before it restarted
+func (c *Chain) restoreInFlight(state *SavedState) {
+	s, err := c.newSlot(state.InFlightView, state.InFlightProposal)
+	if err != nil {
+		c.logger.Warningf("Discarding the saved in flight proposal: %s", err)
+		return
+	}
+	md, _ := viewMetadataOf(state.InFlightProposal)
+	s.recovery = md.ViewId != s.view
+	s.prepares[c.selfID] = s.digest
+	if state.InFlightPrepared {
+		s.prepared = true
+		s.commits[c.selfID] = c.sign(s)
+	}
+	c.slot = s
+	c.logger.Infof("Restored the in flight proposal of block [%d] in view %d", s.seq, s.view)
+}
+
+// decide writes the block of the slot with the signatures of the quorum that committed it
+func (c *Chain) decide(s *slot) {
+	signatures := make([]*Signature, 0, len(s.commits))
+	for _, signature := range s.commits {
+		signatures = append(signatures, signature)
+	}
+	sort.Slice(signatures, func(i, j int) bool { return signatures[i].Signer < signatures[j].Signer })
+
+	metadataSignatures := make([]*cb.MetadataSignature, len(signatures))
+	for i, signature := range signatures {
+		metadataSignatures[i] = &cb.MetadataSignature{
+			SignatureHeader: signature.SignatureHeader,
+			Signature:       signature.Signature,
+		}
+	}
+
+	block := protoutil.NewBlock(s.header.Number, s.header.PreviousHash)
+	block.Header.DataHash = s.header.DataHash
+	block.Data = s.data
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&cb.Metadata{
+		Value:      s.signatureValue,
+		Signatures: metadataSignatures,
+	})
+
+	c.logger.Infof("Writing block [%d] decided in view %d with %d signatures", s.seq, s.view, len(signatures))
+	c.metrics.DecisionLatency.With("channel", c.channelID).Observe(c.clock.Since(s.proposed).Seconds())
+
+	// The ledger keeps the block and annotates it asynchronously, hence the chain keeps a copy of it
+	lastBlock := proto.Clone(block).(*cb.Block)
+	if s.isConfig {
+		c.support.WriteConfigBlock(block, s.proposal.Metadata)
+	} else {
+		c.support.WriteBlock(block, s.proposal.Metadata)
+	}
+
+	leader := c.leader()
+	c.lastBlock = lastBlock
+	c.lastConfigBlockNum = s.lastConfigBlockNum
+	if !s.recovery {
+		c.decisionsInView++
+	}
+	c.slot = nil
+	c.lastHeartbeat = c.clock.Now()
+	c.laggingBeats = 0
+	c.onDecided(s.keys, s.isConfig, leader)
+}
+
+// onDecided updates the state of the chain after a block is written to the ledger
+func (c *Chain) onDecided(keys []string, isConfig bool, previousLeader uint64) {
+	for _, key := range keys {
+		c.pool.remove(key)
+		c.decided.add(key)
+	}
+	c.persist()
+
+	c.metrics.CommittedBlockNumber.With("channel", c.channelID).Set(float64(c.lastBlock.Header.Number))
+	c.metrics.RequestPoolSize.With("channel", c.channelID).Set(float64(c.pool.size()))
+
+	if isConfig {
+		c.applyConfig()
+		if c.isEvicted() {
+			return
+		}
+	}
+
+	c.reportLeader()
+	if leader := c.leader(); leader != previousLeader {
+		c.onLeaderChange(leader)
+	}
+
+	c.replayFutureMessages()
+	c.maybePropose(false)
+}
+
+// onLeaderChange forwards the pending requests to the new leader, which is
+// given the full complain timeout to order each of them
+func (c *Chain) onLeaderChange(leader uint64) {
+	c.logger.Infof("The leader of view %d is now %d", c.view, leader)
+	now := c.clock.Now()
+	for _, r := range c.pool.all() {
+		r.watched = now
+	}
+	if leader == c.selfID {
+		return
+	}
+	c.stopBatchTimer()
+	for _, r := range c.pool.all() {
+		c.forward(leader, r)
+	}
+}
+
+// persist saves the consensus state of this node, before it sends messages that depend on it
+func (c *Chain) persist() {
+	state := &SavedState{View: c.view}
+	if s := c.slot; s != nil {
+		state.InFlightProposal = s.proposal
+		state.InFlightPrepared = s.prepared
+		state.InFlightView = s.view
+	}
+	if c.viewChange != nil {
+		state.PendingView = c.viewChange.nextView
+	}
+	if err := c.store.save(state); err != nil {
+		c.logger.Panicf("Failed to persist the consensus state: %s", err)
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"encoding/pem"
+	"fmt"
+	"path"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	commonbft "github.com/hyperledger/fabric/common/bft"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/follower"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// maxPullBlockRetries is the number of attempts to pull a block from the cluster while
+// synchronizing, after which the chain resumes and synchronizes again later if needed
+const maxPullBlockRetries = 10
+
+// Config contains the local configuration of the BFT consenter
+type Config struct {
+	BFTStateDir string // The consensus state of <my-channel> is stored in BFTStateDir/<my-channel>
+}
+
+// Consenter implements the BFT consenter. It shares the cluster communication of
+// the etcdraft consenter, which dispatches the messages of the BFT chains as well
+type Consenter struct {
+	CreateChain           func(chainName string)
+	InactiveChainRegistry etcdraft.InactiveChainRegistry
+	Dialer                *cluster.PredicateDialer
+	Communication         cluster.Communicator
+	Logger                *flogging.FabricLogger
+	BFTConfig             Config
+	OrdererConfig         localconfig.TopLevel
+	Cert                  []byte
+	Metrics               *Metrics
+	BCCSP                 bccsp.BCCSP
+}
+
+// New creates a BFT Consenter
+func New(
+	clusterDialer *cluster.PredicateDialer,
+	communication cluster.Communicator,
+	conf *localconfig.TopLevel,
+	srvConf comm.ServerConfig,
+	r *multichannel.Registrar,
+	icr etcdraft.InactiveChainRegistry,
+	metricsProvider metrics.Provider,
+	bccsp bccsp.BCCSP,
+) *Consenter {
+	logger := flogging.MustGetLogger("orderer.consensus.bft")
+
+	var cfg Config
+	err := mapstructure.Decode(conf.Consensus, &cfg)
+	if err != nil {
+		logger.Panicf("Failed to decode BFT configuration: %s", err)
+	}
+	if cfg.BFTStateDir == "" {
+		cfg.BFTStateDir = path.Join(conf.FileLedger.Location, "bftstate")
+		logger.Infof("BFTStateDir not set, defaulting to %s", cfg.BFTStateDir)
+	}
+
+	return &Consenter{
+		CreateChain:           r.CreateChain,
+		InactiveChainRegistry: icr,
+		Dialer:                clusterDialer,
+		Communication:         communication,
+		Logger:                logger,
+		BFTConfig:             cfg,
+		OrdererConfig:         *conf,
+		Cert:                  srvConf.SecOpts.Certificate,
+		Metrics:               NewMetrics(metricsProvider),
+		BCCSP:                 bccsp,
+	}
+}
+
+// HandleChain returns a new Chain instance or an error upon failure
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m, err := commonbft.MetadataFromOrdererConfig(support.SharedConfig())
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, errors.Errorf("consensus type of channel %s is not %s", support.ChannelID(), commonbft.ConsensusType)
+	}
+	if err := commonbft.ValidateConfigMetadata(m); err != nil {
+		return nil, errors.WithMessage(err, "invalid BFT config metadata")
+	}
+
+	// The view metadata is read from the last block, unless the channel has
+	// just migrated to the BFT consensus and starts from the first view
+	var viewMetadata *commonbft.ViewMetadata
+	if metadata != nil && len(metadata.Value) != 0 {
+		viewMetadata = &commonbft.ViewMetadata{}
+		if err := proto.Unmarshal(metadata.Value, viewMetadata); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal the view metadata of the last block")
+		}
+	} else if support.Height() > 1 {
+		c.Logger.Debugf("Block metadata is nil at block height=%d, it is consensus-type migration", support.Height())
+	}
+
+	id, err := c.detectSelfID(m.Consenters)
+	if err != nil {
+		if c.InactiveChainRegistry != nil {
+			c.InactiveChainRegistry.TrackChain(support.ChannelID(), support.Block(0), func() {
+				c.CreateChain(support.ChannelID())
+			})
+			return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChannelID())}, nil
+		}
+		return &follower.Chain{Err: errors.Errorf("orderer is a follower of channel %s", support.ChannelID()), Ledger: support}, nil
+	}
+
+	opts := Options{
+		SelfID:         id,
+		ConfigMetadata: m,
+		ViewMetadata:   viewMetadata,
+		StateDir:       path.Join(c.BFTConfig.BFTStateDir, support.ChannelID()),
+		Clock:          clock.NewClock(),
+		Logger:         c.Logger,
+		Metrics:        c.Metrics,
+	}
+
+	rpc := &cluster.RPC{
+		Timeout:       c.OrdererConfig.General.Cluster.RPCTimeout,
+		Logger:        c.Logger,
+		Channel:       support.ChannelID(),
+		Comm:          c.Communication,
+		StreamsByType: cluster.NewStreamsByType(),
+	}
+
+	return NewChain(
+		support,
+		opts,
+		rpc,
+		c.Communication,
+		signatureVerifier(support),
+		func() (etcdraft.BlockPuller, error) {
+			puller, err := etcdraft.NewBlockPuller(support, c.Dialer, c.OrdererConfig.General.Cluster, c.BCCSP)
+			if err != nil {
+				return nil, err
+			}
+			if lp, ok := puller.(*etcdraft.LedgerBlockPuller); ok {
+				if bp, ok := lp.BlockPuller.(*cluster.BlockPuller); ok {
+					bp.MaxPullBlockRetries = maxPullBlockRetries
+				}
+			}
+			return puller, nil
+		},
+	)
+}
+
+// JoinChain returns a new Chain instance for a channel that the orderer joins
+func (c *Consenter) JoinChain(support consensus.ConsenterSupport, joinBlock *common.Block) (consensus.Chain, error) {
+	//TODO fully construct a follower.Chain
+	return nil, errors.New("not implemented")
+}
+
+func (c *Consenter) detectSelfID(consenters []*commonbft.Consenter) (uint64, error) {
+	thisNodeCertAsDER, err := pemToDER(c.Cert, 0, "server", c.Logger)
+	if err != nil {
+		return 0, err
+	}
+
+	var serverCertificates []string
+	for _, cst := range consenters {
+		serverCertificates = append(serverCertificates, string(cst.ServerTlsCert))
+
+		certAsDER, err := pemToDER(cst.ServerTlsCert, cst.Id, "server", c.Logger)
+		if err != nil {
+			return 0, err
+		}
+
+		if crypto.CertificatesWithSamePublicKey(thisNodeCertAsDER, certAsDER) == nil {
+			return cst.Id, nil
+		}
+	}
+
+	c.Logger.Warning("Could not find", string(c.Cert), "among", serverCertificates)
+	return 0, cluster.ErrNotInChannel
+}
+
+// signatureVerifier verifies signatures against the MSPs of the current config of the channel
+func signatureVerifier(support consensus.ConsenterSupport) SignatureVerifier {
+	return func(sd *protoutil.SignedData) error {
+		mspSupport, ok := support.(interface{ MSPManager() msp.MSPManager })
+		if !ok {
+			return errors.New("the channel does not provide an MSP manager")
+		}
+		identity, err := mspSupport.MSPManager().DeserializeIdentity(sd.Identity)
+		if err != nil {
+			return errors.WithMessage(err, "failed to deserialize the identity")
+		}
+		if err := identity.Validate(); err != nil {
+			return errors.WithMessage(err, "invalid identity")
+		}
+		return identity.Verify(sd.Data, sd.Signature)
+	}
+}
+
+func endpointOf(consenter *commonbft.Consenter) string {
+	return fmt.Sprintf("%s:%d", consenter.Host, consenter.Port)
+}
+
+func pemToDER(pemBytes []byte, id uint64, certType string, logger *flogging.FabricLogger) ([]byte, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		logger.Errorf("Rejecting PEM block of %s TLS cert for node %d, offending PEM is: %s", certType, id, string(pemBytes))
+		return nil, errors.Errorf("invalid PEM block")
+	}
+	return bl.Bytes, nil
+}
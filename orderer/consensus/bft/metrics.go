@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	clusterSizeOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "cluster_size",
+		Help:         "Number of consenters in this channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	isLeaderOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "is_leader",
+		Help:         "The leadership status of the current node: 1 if it is the leader else 0.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	leaderIDOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "leader_id",
+		Help:         "The id of the current leader.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	viewNumberOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "view_number",
+		Help:         "The number of the current view.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	committedBlockNumberOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "committed_block_number",
+		Help:         "The block number of the latest block committed.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	viewChangesOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "view_changes",
+		Help:         "The number of view changes that the node started since process start.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	proposalFailuresOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "proposal_failures",
+		Help:         "The number of proposals that failed the verification.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	requestPoolSizeOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "request_pool_size",
+		Help:         "The number of requests in the request pool that have not been ordered yet.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	decisionLatencyOpts = metrics.HistogramOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "decision_latency",
+		Help:         "The time taken from the proposal of a block to its decision (in seconds).",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+type Metrics struct {
+	ClusterSize          metrics.Gauge
+	IsLeader             metrics.Gauge
+	LeaderID             metrics.Gauge
+	ViewNumber           metrics.Gauge
+	CommittedBlockNumber metrics.Gauge
+	ViewChanges          metrics.Counter
+	ProposalFailures     metrics.Counter
+	RequestPoolSize      metrics.Gauge
+	DecisionLatency      metrics.Histogram
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		ClusterSize:          p.NewGauge(clusterSizeOpts),
+		IsLeader:             p.NewGauge(isLeaderOpts),
+		LeaderID:             p.NewGauge(leaderIDOpts),
+		ViewNumber:           p.NewGauge(viewNumberOpts),
+		CommittedBlockNumber: p.NewGauge(committedBlockNumberOpts),
+		ViewChanges:          p.NewCounter(viewChangesOpts),
+		ProposalFailures:     p.NewCounter(proposalFailuresOpts),
+		RequestPoolSize:      p.NewGauge(requestPoolSizeOpts),
+		DecisionLatency:      p.NewHistogram(decisionLatencyOpts),
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"container/list"
+	"encoding/hex"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// request is a transaction that is waiting to be ordered
+type request struct {
+	key       string
+	env       *cb.Envelope
+	configSeq uint64
+	isConfig  bool
+	size      int
+	arrival   time.Time
+	forwarded time.Time
+	// watched is the time since which the current leader is expected to order the request
+	watched time.Time
+}
+
+// newRequest creates a request for the given envelope. The key of a config request is
+// derived from the config update embedded in it, since the config envelope is signed by
+// the orderer that processes the config update and is therefore different at each orderer
+func newRequest(env *cb.Envelope, configSeq uint64, now time.Time) (*request, error) {
+	key, isConfig, err := requestKey(env)
+	if err != nil {
+		return nil, err
+	}
+	return &request{
+		key:       key,
+		env:       env,
+		configSeq: configSeq,
+		isConfig:  isConfig,
+		size:      len(env.Payload) + len(env.Signature),
+		arrival:   now,
+		forwarded: now,
+		watched:   now,
+	}, nil
+}
+
+func requestKey(env *cb.Envelope) (string, bool, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return "", false, err
+	}
+	if payload.Header == nil {
+		return "", false, errors.New("missing header in payload")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG:
+		configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+		if err != nil {
+			return "", false, err
+		}
+		if configEnv.LastUpdate == nil {
+			return "", false, errors.New("config envelope is missing the last update")
+		}
+		return hex.EncodeToString(util.ComputeSHA256(protoutil.MarshalOrPanic(configEnv.LastUpdate))), true, nil
+	case cb.HeaderType_ORDERER_TRANSACTION:
+		newChannelEnv, err := protoutil.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return "", false, err
+		}
+		key, _, err := requestKey(newChannelEnv)
+		return key, true, err
+	default:
+		return hex.EncodeToString(util.ComputeSHA256(protoutil.MarshalOrPanic(env))), false, nil
+	}
+}
+
+// requestPool holds the requests that have not been ordered yet, in the order of their arrival
+type requestPool struct {
+	maxSize  int
+	requests *list.List
+	byKey    map[string]*list.Element
+}
+
+func newRequestPool(maxSize uint64) *requestPool {
+	return &requestPool{
+		maxSize:  int(maxSize),
+		requests: list.New(),
+		byKey:    make(map[string]*list.Element),
+	}
+}
+
+// add adds the request to the pool, unless a request with the same key is already in the pool
+func (p *requestPool) add(r *request) error {
+	if _, exists := p.byKey[r.key]; exists {
+		return nil
+	}
+	if p.requests.Len() >= p.maxSize {
+		return errors.Errorf("request pool is full (%d requests)", p.maxSize)
+	}
+	p.byKey[r.key] = p.requests.PushBack(r)
+	return nil
+}
+
+// remove removes the request with the given key from the pool and returns whether it was in the pool
+func (p *requestPool) remove(key string) bool {
+	e, exists := p.byKey[key]
+	if !exists {
+		return false
+	}
+	p.requests.Remove(e)
+	delete(p.byKey, key)
+	return true
+}
+
+func (p *requestPool) size() int {
+	return p.requests.Len()
+}
+
+// all returns the requests in the pool in the order of their arrival
+func (p *requestPool) all() []*request {
+	requests := make([]*request, 0, p.requests.Len())
+	for e := p.requests.Front(); e != nil; e = e.Next() {
+		requests = append(requests, e.Value.(*request))
+	}
+	return requests
+}
+
+// batchReady returns whether the pool holds enough requests to fill a batch
+func (p *requestPool) batchReady(maxCount, preferredMaxBytes uint32) bool {
+	count, bytes := 0, 0
+	for e := p.requests.Front(); e != nil; e = e.Next() {
+		r := e.Value.(*request)
+		if r.isConfig {
+			return true
+		}
+		count++
+		bytes += r.size
+		if count >= int(maxCount) || bytes >= int(preferredMaxBytes) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBatch returns the requests of the next batch, in the order of their arrival. A config
+// request is always batched alone, and the batch is closed when it reaches the maximal count
+// of requests or the preferred size in bytes
+func (p *requestPool) nextBatch(maxCount, preferredMaxBytes uint32) []*request {
+	var batch []*request
+	bytes := 0
+	for e := p.requests.Front(); e != nil; e = e.Next() {
+		r := e.Value.(*request)
+		if r.isConfig {
+			if len(batch) == 0 {
+				batch = append(batch, r)
+			}
+			return batch
+		}
+		if len(batch) > 0 && bytes+r.size > int(preferredMaxBytes) {
+			return batch
+		}
+		batch = append(batch, r)
+		bytes += r.size
+		if len(batch) >= int(maxCount) {
+			return batch
+		}
+	}
+	return batch
+}
+
+// recentKeys remembers the keys of the latest ordered requests, so that a request that
+// reaches this node after it was ordered is not added to the pool again
+type recentKeys struct {
+	maxSize int
+	keys    *list.List
+	set     map[string]struct{}
+}
+
+func newRecentKeys(maxSize int) *recentKeys {
+	return &recentKeys{
+		maxSize: maxSize,
+		keys:    list.New(),
+		set:     make(map[string]struct{}),
+	}
+}
+
+func (r *recentKeys) add(key string) {
+	if _, exists := r.set[key]; exists {
+		return
+	}
+	r.set[key] = struct{}{}
+	r.keys.PushBack(key)
+	for r.keys.Len() > r.maxSize {
+		oldest := r.keys.Remove(r.keys.Front()).(string)
+		delete(r.set, oldest)
+	}
+}
+
+func (r *recentKeys) contains(key string) bool {
+	_, exists := r.set[key]
+	return exists
+}
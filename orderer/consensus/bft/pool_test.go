@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func makeEnvelope(headerType cb.HeaderType, data []byte) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(headerType),
+					ChannelId: "mychannel",
+				}),
+			},
+			Data: data,
+		}),
+	}
+}
+
+func makeConfigEnvelope(lastUpdate *cb.Envelope, signature []byte) *cb.Envelope {
+	env := makeEnvelope(cb.HeaderType_CONFIG, protoutil.MarshalOrPanic(&cb.ConfigEnvelope{
+		Config:     &cb.Config{Sequence: 1},
+		LastUpdate: lastUpdate,
+	}))
+	env.Signature = signature
+	return env
+}
+
+func makeRequest(t *testing.T, env *cb.Envelope) *request {
+	r, err := newRequest(env, 0, time.Now())
+	require.NoError(t, err)
+	return r
+}
+
+func TestRequestKey(t *testing.T) {
+	normal := makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, []byte("tx"))
+	key, isConfig, err := requestKey(normal)
+	require.NoError(t, err)
+	require.False(t, isConfig)
+	otherKey, _, err := requestKey(makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, []byte("other tx")))
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherKey)
+
+	// The config envelopes that different orderers create for the same config update have the same key
+	lastUpdate := makeEnvelope(cb.HeaderType_CONFIG_UPDATE, []byte("config update"))
+	configKey1, isConfig, err := requestKey(makeConfigEnvelope(lastUpdate, []byte("signed by orderer1")))
+	require.NoError(t, err)
+	require.True(t, isConfig)
+	configKey2, _, err := requestKey(makeConfigEnvelope(lastUpdate, []byte("signed by orderer2")))
+	require.NoError(t, err)
+	require.Equal(t, configKey1, configKey2)
+
+	ordererTx := makeEnvelope(cb.HeaderType_ORDERER_TRANSACTION, protoutil.MarshalOrPanic(makeConfigEnvelope(lastUpdate, nil)))
+	ordererTxKey, isConfig, err := requestKey(ordererTx)
+	require.NoError(t, err)
+	require.True(t, isConfig)
+	require.Equal(t, configKey1, ordererTxKey)
+
+	_, _, err = requestKey(makeEnvelope(cb.HeaderType_CONFIG, protoutil.MarshalOrPanic(&cb.ConfigEnvelope{})))
+	require.EqualError(t, err, "config envelope is missing the last update")
+
+	_, _, err = requestKey(&cb.Envelope{Payload: protoutil.MarshalOrPanic(&cb.Payload{})})
+	require.EqualError(t, err, "missing header in payload")
+}
+
+func TestRequestPool(t *testing.T) {
+	pool := newRequestPool(3)
+
+	var requests []*request
+	for i := 0; i < 3; i++ {
+		r := makeRequest(t, makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, []byte(fmt.Sprintf("tx%d", i))))
+		requests = append(requests, r)
+		require.NoError(t, pool.add(r))
+	}
+	// A request that is already in the pool is not added again
+	require.NoError(t, pool.add(requests[0]))
+	require.Equal(t, 3, pool.size())
+
+	extra := makeRequest(t, makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, []byte("tx3")))
+	require.EqualError(t, pool.add(extra), "request pool is full (3 requests)")
+
+	require.True(t, pool.remove(requests[1].key))
+	require.False(t, pool.remove(requests[1].key))
+	require.Equal(t, []*request{requests[0], requests[2]}, pool.all())
+
+	require.NoError(t, pool.add(extra))
+	require.Equal(t, []*request{requests[0], requests[2], extra}, pool.all())
+}
+
+func TestRequestPoolNextBatch(t *testing.T) {
+	pool := newRequestPool(10)
+	var txs []*request
+	for i := 0; i < 4; i++ {
+		r := makeRequest(t, makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, []byte(fmt.Sprintf("tx%d", i))))
+		txs = append(txs, r)
+		require.NoError(t, pool.add(r))
+	}
+	config := makeRequest(t, makeConfigEnvelope(makeEnvelope(cb.HeaderType_CONFIG_UPDATE, []byte("update")), nil))
+	require.NoError(t, pool.add(config))
+	last := makeRequest(t, makeEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, []byte("tx4")))
+	require.NoError(t, pool.add(last))
+
+	require.False(t, newRequestPool(10).batchReady(1, 1))
+	require.True(t, pool.batchReady(4, 1<<20))
+	require.True(t, pool.batchReady(10, 1<<20), "a config request fills a batch")
+
+	// The batch is cut by the count of requests
+	require.Equal(t, txs[:2], pool.nextBatch(2, 1<<20))
+	// The batch is cut before the config request
+	require.Equal(t, txs, pool.nextBatch(10, 1<<20))
+	// The batch is cut by the preferred size
+	require.Equal(t, txs[:2], pool.nextBatch(10, uint32(txs[0].size+txs[1].size)))
+	// A request larger than the preferred size is batched alone
+	require.Equal(t, txs[:1], pool.nextBatch(10, 1))
+
+	for _, r := range txs {
+		pool.remove(r.key)
+	}
+	// The config request is batched alone
+	require.Equal(t, []*request{config}, pool.nextBatch(10, 1<<20))
+	pool.remove(config.key)
+	require.Equal(t, []*request{last}, pool.nextBatch(10, 1<<20))
+	require.False(t, pool.batchReady(10, 1<<20))
+}
+
+func TestRecentKeys(t *testing.T) {
+	keys := newRecentKeys(2)
+	keys.add("a")
+	keys.add("b")
+	keys.add("a")
+	require.True(t, keys.contains("a"))
+	require.True(t, keys.contains("b"))
+
+	keys.add("c")
+	require.False(t, keys.contains("a"))
+	require.True(t, keys.contains("b"))
+	require.True(t, keys.contains("c"))
+}
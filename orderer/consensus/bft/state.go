@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	stateFileName    = "state"
+	tmpStateFileName = "state.tmp"
+)
+
+// stateStore persists the SavedState of a chain in a file of the state directory
+// of the chain, which is replaced atomically at each save
+type stateStore struct {
+	dir string
+}
+
+func newStateStore(dir string) (*stateStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create state directory %s", dir)
+	}
+	return &stateStore{dir: dir}, nil
+}
+
+// load returns the saved state, or an empty state if no state has been saved yet
+func (s *stateStore) load() (*SavedState, error) {
+	stateBytes, err := ioutil.ReadFile(filepath.Join(s.dir, stateFileName))
+	if os.IsNotExist(err) {
+		return &SavedState{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the saved state")
+	}
+	state := &SavedState{}
+	if err := proto.Unmarshal(stateBytes, state); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the saved state")
+	}
+	return state, nil
+}
+
+// save persists the given state, replacing the previously saved state
+func (s *stateStore) save(state *SavedState) error {
+	stateBytes, err := proto.Marshal(state)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the state")
+	}
+
+	tmpPath := filepath.Join(s.dir, tmpStateFileName)
+	file, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", tmpPath)
+	}
+	if _, err := file.Write(stateBytes); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to sync %s", tmpPath)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(s.dir, stateFileName)); err != nil {
+		return errors.Wrapf(err, "failed to replace the saved state")
+	}
+
+	dir, err := os.Open(s.dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", s.dir)
+	}
+	defer dir.Close()
+	return errors.Wrapf(dir.Sync(), "failed to sync %s", s.dir)
+}
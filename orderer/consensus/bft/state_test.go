@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bft-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := newStateStore(filepath.Join(dir, "mychannel"))
+	require.NoError(t, err)
+
+	state, err := store.load()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&SavedState{}, state))
+
+	saved := &SavedState{
+		View:             3,
+		InFlightProposal: &Proposal{Header: []byte("header"), Payload: []byte("payload")},
+		InFlightPrepared: true,
+		InFlightView:     3,
+	}
+	require.NoError(t, store.save(saved))
+	require.NoError(t, store.save(&SavedState{View: 4, PendingView: 5}))
+
+	store, err = newStateStore(filepath.Join(dir, "mychannel"))
+	require.NoError(t, err)
+	state, err = store.load()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&SavedState{View: 4, PendingView: 5}, state))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "mychannel", stateFileName), []byte{1, 2, 3}, 0644))
+	_, err = store.load()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to unmarshal the saved state")
+}
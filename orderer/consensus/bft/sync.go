@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	commonbft "github.com/hyperledger/fabric/common/bft"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// sync pulls the blocks up to the target block from the other orderers of the channel
+func (c *Chain) sync(target uint64) {
+	if target <= c.lastBlock.Header.Number {
+		return
+	}
+
+	puller, err := c.createPuller()
+	if err != nil {
+		c.logger.Errorf("Failed to create block puller: %s", err)
+		return
+	}
+	defer puller.Close()
+
+	c.logger.Infof("Synchronizing with the cluster from block [%d] to block [%d]", c.lastBlock.Header.Number+1, target)
+	// Writing a pulled block may decide the following block from the buffered messages
+	for c.lastBlock.Header.Number < target {
+		next := c.lastBlock.Header.Number + 1
+		block := puller.PullBlock(next)
+		if block == nil {
+			c.logger.Warningf("Failed to fetch block [%d] from the cluster", next)
+			return
+		}
+		if err := c.writePulledBlock(block); err != nil {
+			c.logger.Warningf("Failed to write block [%d] pulled from the cluster: %s", next, err)
+			return
+		}
+		if c.isEvicted() {
+			return
+		}
+	}
+}
+
+// writePulledBlock verifies and writes a block that was decided by the other consenters
+func (c *Chain) writePulledBlock(block *cb.Block) error {
+	if block.Header == nil || block.Metadata == nil {
+		return errors.New("the block has no header or no metadata")
+	}
+	expectedHeader := c.support.CreateNextBlock(nil).Header
+	if block.Header.Number != expectedHeader.Number {
+		return errors.Errorf("got block [%d] while the next block is [%d]", block.Header.Number, expectedHeader.Number)
+	}
+	if !bytes.Equal(block.Header.PreviousHash, expectedHeader.PreviousHash) {
+		return errors.Errorf("the previous hash of block [%d] does not match the last block", block.Header.Number)
+	}
+	// The blocks that were ordered before the channel migrated to the BFT consensus carry the signature
+	// of a single orderer, and they are verified by the block puller against the policy of the channel
+	if c.support.SharedConfig().ConsensusType() == commonbft.ConsensusType {
+		if err := commonbft.VerifyBlockSignatures(block, c.consenters, c.verify); err != nil {
+			return err
+		}
+	}
+
+	var viewMetadataBytes []byte
+	if md, err := protoutil.GetConsenterMetadataFromBlock(block); err == nil {
+		viewMetadataBytes = md.Value
+	}
+	keys := c.keysOf(block)
+	isConfig := protoutil.IsConfigBlock(block)
+
+	lastBlock := proto.Clone(block).(*cb.Block)
+	if isConfig {
+		c.support.WriteConfigBlock(block, viewMetadataBytes)
+	} else {
+		c.support.WriteBlock(block, viewMetadataBytes)
+	}
+
+	leader := c.leader()
+	c.lastBlock = lastBlock
+	if lastConfigBlockNum, err := protoutil.GetLastConfigIndexFromBlock(lastBlock); err == nil {
+		c.lastConfigBlockNum = lastConfigBlockNum
+	}
+	if c.slot != nil && c.slot.seq <= lastBlock.Header.Number {
+		c.slot = nil
+	}
+
+	// The view of the cluster is adopted from the blocks decided in it
+	vm := &commonbft.ViewMetadata{}
+	if err := proto.Unmarshal(viewMetadataBytes, vm); err == nil && vm.LatestSequence == lastBlock.Header.Number && vm.ViewId >= c.view {
+		if vm.ViewId > c.view {
+			c.logger.Infof("Moving from view %d to view %d of block [%d]", c.view, vm.ViewId, lastBlock.Header.Number)
+			c.view = vm.ViewId
+			c.newView = nil
+		}
+		c.decisionsInView = vm.DecisionsInView + 1
+		if c.viewChange != nil && c.viewChange.nextView <= c.view {
+			c.viewChange = nil
+		}
+	}
+
+	c.onDecided(keys, isConfig, leader)
+	return nil
+}
+
+// keysOf returns the keys of the requests of the block
+func (c *Chain) keysOf(block *cb.Block) []string {
+	var keys []string
+	for _, envBytes := range block.Data.GetData() {
+		env, err := protoutil.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		key, _, err := requestKey(env)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
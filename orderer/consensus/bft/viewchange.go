@@ -0,0 +1,435 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	commonbft "github.com/hyperledger/fabric/common/bft"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// viewChange holds the progress of the change of this node to the next view
+type viewChange struct {
+	nextView   uint64
+	started    time.Time
+	lastResend time.Time
+
+	// viewData is the signed view data that this node sent to the leader of the next view
+	viewData *SignedViewData
+
+	// collected is the view data that the leader of the next view received from the consenters
+	collected   map[uint64]*viewDataInfo
+	newViewSent bool
+}
+
+func newViewChange(nextView uint64, now time.Time) *viewChange {
+	return &viewChange{
+		nextView:   nextView,
+		started:    now,
+		lastResend: now,
+		collected:  make(map[uint64]*viewDataInfo),
+	}
+}
+
+// viewDataInfo is a view data whose signature and last decision are verified
+type viewDataInfo struct {
+	signed       *SignedViewData
+	viewData     *ViewData
+	lastDecision *cb.Block
+}
+
+// complain makes this node suspect the leader of the current view, and start a view change
+func (c *Chain) complain(reason string) {
+	if c.viewChange != nil {
+		return
+	}
+	c.logger.Warningf("Suspecting leader %d of view %d: %s", c.leader(), c.view, reason)
+	c.startViewChange(c.view+1, reason)
+}
+
+func (c *Chain) startViewChange(nextView uint64, reason string) {
+	c.logger.Infof("Changing from view %d to view %d", c.view, nextView)
+	c.viewChange = newViewChange(nextView, c.clock.Now())
+	c.metrics.ViewChanges.With("channel", c.channelID).Add(1)
+	c.stopBatchTimer()
+	c.persist()
+
+	c.viewChangeVotes[c.selfID] = nextView
+	c.broadcast(&Message{Content: &Message_ViewChange{ViewChange: &ViewChange{NextView: nextView, Reason: reason}}})
+	c.checkViewChangeQuorum()
+}
+
+func (c *Chain) onViewChange(sender uint64, vc *ViewChange) {
+	if vc.NextView <= c.view {
+		// The sender has missed the installation of a view that this node installed as its leader
+		if c.viewChange == nil && c.newView != nil {
+			c.send(sender, &Message{Content: &Message_NewView{NewView: c.newView}})
+		}
+		return
+	}
+
+	if vc.NextView > c.viewChangeVotes[sender] {
+		c.viewChangeVotes[sender] = vc.NextView
+	}
+
+	// A node joins the view change once more consenters than the number of faulty
+	// consenters want to change the view, since at least one of them is correct
+	current := c.view
+	if c.viewChange != nil {
+		current = c.viewChange.nextView
+	}
+	var votes []uint64
+	for _, nextView := range c.viewChangeVotes {
+		if nextView > current {
+			votes = append(votes, nextView)
+		}
+	}
+	if f := c.maxFaults(); len(votes) > f {
+		sort.Slice(votes, func(i, j int) bool { return votes[i] > votes[j] })
+		c.startViewChange(votes[f], "joining the view change of other consenters")
+		return
+	}
+
+	c.checkViewChangeQuorum()
+}
+
+// checkViewChangeQuorum sends the view data of this node to the leader of the next
+// view once a quorum of the consenters wants to change to the next view
+func (c *Chain) checkViewChangeQuorum() {
+	vc := c.viewChange
+	if vc == nil || vc.viewData != nil {
+		return
+	}
+	count := 0
+	for _, nextView := range c.viewChangeVotes {
+		if nextView >= vc.nextView {
+			count++
+		}
+	}
+	if count < c.quorum() {
+		return
+	}
+
+	viewData := &ViewData{
+		NextView:     vc.nextView,
+		LastDecision: protoutil.MarshalOrPanic(c.lastBlock),
+	}
+	if s := c.slot; s != nil {
+		viewData.InFlightProposal = s.proposal
+		viewData.InFlightPrepared = s.prepared
+		viewData.InFlightView = s.view
+	}
+	raw := protoutil.MarshalOrPanic(viewData)
+	signatureHeader := protoutil.MarshalOrPanic(protoutil.NewSignatureHeaderOrPanic(c.support))
+	vc.viewData = &SignedViewData{
+		RawViewData:     raw,
+		Signer:          c.selfID,
+		SignatureHeader: signatureHeader,
+		Signature:       protoutil.SignOrPanic(c.support, util.ConcatenateBytes(raw, signatureHeader)),
+	}
+	c.sendViewData()
+}
+
+func (c *Chain) sendViewData() {
+	vc := c.viewChange
+	leader := c.leaderOf(vc.nextView, 0)
+	c.logger.Debugf("Sending view data for view %d to %d", vc.nextView, leader)
+	if leader == c.selfID {
+		c.onViewData(c.selfID, vc.viewData)
+		return
+	}
+	c.send(leader, &Message{Content: &Message_ViewData{ViewData: vc.viewData}})
+}
+
+func (c *Chain) onViewChangeTick(now time.Time) {
+	vc := c.viewChange
+	if now.Sub(vc.started) >= c.conf.viewChangeTimeout {
+		c.logger.Warningf("The view change to view %d timed out", vc.nextView)
+		c.startViewChange(vc.nextView+1, "view change timeout")
+		return
+	}
+	if now.Sub(vc.lastResend) < c.conf.viewChangeResendInterval {
+		return
+	}
+	vc.lastResend = now
+	c.broadcast(&Message{Content: &Message_ViewChange{ViewChange: &ViewChange{NextView: vc.nextView, Reason: "resend"}}})
+	if vc.viewData != nil {
+		c.sendViewData()
+	}
+}
+
+func (c *Chain) onViewData(sender uint64, svd *SignedViewData) {
+	vc := c.viewChange
+	if vc == nil || vc.newViewSent || c.leaderOf(vc.nextView, 0) != c.selfID {
+		return
+	}
+	if svd.Signer != sender {
+		c.logger.Warningf("Ignoring view data of %d sent by %d", svd.Signer, sender)
+		return
+	}
+	info, err := c.verifyViewData(svd)
+	if err != nil {
+		c.logger.Warningf("Ignoring view data of %d: %s", sender, err)
+		return
+	}
+	if info.viewData.NextView != vc.nextView {
+		return
+	}
+	vc.collected[sender] = info
+	if len(vc.collected) < c.quorum() {
+		return
+	}
+
+	infos := make([]*viewDataInfo, 0, len(vc.collected))
+	for _, info := range vc.collected {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].signed.Signer < infos[j].signed.Signer })
+
+	if err := c.catchUpWith(infos); err != nil {
+		c.logger.Warningf("Failed to catch up with the view data of the consenters: %s", err)
+		return
+	}
+	if _, err := c.selectInFlight(infos); err != nil {
+		c.logger.Infof("Waiting for more view data for view %d: %s", vc.nextView, err)
+		return
+	}
+
+	newView := &NewView{}
+	for _, info := range infos {
+		newView.SignedViewData = append(newView.SignedViewData, info.signed)
+	}
+	vc.newViewSent = true
+	c.logger.Infof("Sending new view %d with the view data of %d consenters", vc.nextView, len(infos))
+	c.broadcast(&Message{Content: &Message_NewView{NewView: newView}})
+	c.onNewView(c.selfID, newView)
+}
+
+// verifyViewData verifies the signature of the view data, and that its last decision is either a block
+// of the ledger of this node, or a block that is signed by a quorum of the consenters of the channel
+func (c *Chain) verifyViewData(svd *SignedViewData) (*viewDataInfo, error) {
+	if svd == nil {
+		return nil, errors.New("nil view data")
+	}
+	data := util.ConcatenateBytes(svd.RawViewData, svd.SignatureHeader)
+	if err := c.verifyConsenterSignature(svd.Signer, svd.SignatureHeader, data, svd.Signature); err != nil {
+		return nil, err
+	}
+	viewData := &ViewData{}
+	if err := proto.Unmarshal(svd.RawViewData, viewData); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal view data")
+	}
+	lastDecision, err := protoutil.UnmarshalBlock(viewData.LastDecision)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to unmarshal the last decision")
+	}
+	if lastDecision.Header == nil {
+		return nil, errors.New("the last decision has no header")
+	}
+
+	if lastDecision.Header.Number <= c.lastBlock.Header.Number {
+		block := c.support.Block(lastDecision.Header.Number)
+		if block == nil || !bytes.Equal(protoutil.BlockHeaderBytes(block.Header), protoutil.BlockHeaderBytes(lastDecision.Header)) {
+			return nil, errors.Errorf("the last decision is not block [%d] of the ledger", lastDecision.Header.Number)
+		}
+	} else if err := commonbft.VerifyBlockSignatures(lastDecision, c.consenters, c.verify); err != nil {
+		return nil, err
+	}
+
+	return &viewDataInfo{signed: svd, viewData: viewData, lastDecision: lastDecision}, nil
+}
+
+// catchUpWith brings the ledger of this node up to the latest last decision of the view data
+func (c *Chain) catchUpWith(infos []*viewDataInfo) error {
+	var latest *cb.Block
+	for _, info := range infos {
+		if latest == nil || info.lastDecision.Header.Number > latest.Header.Number {
+			latest = info.lastDecision
+		}
+	}
+	if latest.Header.Number <= c.lastBlock.Header.Number {
+		return nil
+	}
+	if latest.Header.Number == c.lastBlock.Header.Number+1 {
+		if err := c.writePulledBlock(latest); err != nil {
+			return err
+		}
+	} else {
+		c.sync(latest.Header.Number)
+	}
+	if c.lastBlock.Header.Number < latest.Header.Number {
+		return errors.Errorf("the ledger is at block [%d] while the view data shows block [%d]", c.lastBlock.Header.Number, latest.Header.Number)
+	}
+	return nil
+}
+
+// selectInFlight selects the proposal that the new view has to decide before the leader of the new
+// view proposes new blocks, if some consenters might have decided it in a previous view. A proposal
+// that is prepared by a consenter is selected if a quorum of the view data does not contradict it,
+// while no proposal is selected if a quorum of the consenters has not prepared any proposal. The view
+// data is expected to be verified and the ledger to be caught up with the view data
+func (c *Chain) selectInFlight(infos []*viewDataInfo) (*slot, error) {
+	next := c.lastBlock.Header.Number + 1
+
+	// The in flight proposals of earlier sequences are already decided, and
+	// the prepared proposals which are not valid cannot have been decided
+	type candidate struct {
+		slot     *slot
+		view     uint64
+		prepared bool
+	}
+	inFlight := make([]*candidate, len(infos))
+	for i, info := range infos {
+		vd := info.viewData
+		if vd.InFlightProposal == nil {
+			continue
+		}
+		s, err := c.newSlot(c.view, vd.InFlightProposal)
+		if err != nil || s.seq != next {
+			continue
+		}
+		if vd.InFlightPrepared {
+			if err := c.verifyProposalContent(s); err != nil {
+				c.logger.Warningf("Ignoring the prepared proposal of block [%d] of %d: %s", s.seq, info.signed.Signer, err)
+				continue
+			}
+		}
+		inFlight[i] = &candidate{slot: s, view: vd.InFlightView, prepared: vd.InFlightPrepared}
+	}
+
+	var selected *candidate
+	for _, cand := range inFlight {
+		if cand == nil || !cand.prepared {
+			continue
+		}
+		noContradiction := 0
+		for _, other := range inFlight {
+			if other == nil || !other.prepared || other.slot.digest == cand.slot.digest {
+				noContradiction++
+			}
+		}
+		if noContradiction >= c.quorum() && (selected == nil || cand.view > selected.view) {
+			selected = cand
+		}
+	}
+	if selected != nil {
+		return selected.slot, nil
+	}
+
+	notPrepared := 0
+	for _, cand := range inFlight {
+		if cand == nil || !cand.prepared {
+			notPrepared++
+		}
+	}
+	if notPrepared >= c.quorum() {
+		return nil, nil
+	}
+	return nil, errors.Errorf("the view data of %d consenters neither agree on a prepared proposal nor show that no proposal is prepared", len(infos))
+}
+
+func (c *Chain) onNewView(sender uint64, nv *NewView) {
+	infos, nextView, err := c.verifyNewView(nv)
+	if err != nil {
+		c.logger.Warningf("Ignoring new view from %d: %s", sender, err)
+		return
+	}
+	if nextView <= c.view || (c.viewChange != nil && nextView < c.viewChange.nextView) {
+		return
+	}
+	if leader := c.leaderOf(nextView, 0); sender != leader {
+		c.logger.Warningf("Ignoring new view %d from %d while its leader is %d", nextView, sender, leader)
+		return
+	}
+
+	if err := c.catchUpWith(infos); err != nil {
+		c.logger.Warningf("Failed to catch up with new view %d: %s", nextView, err)
+		return
+	}
+	if nextView <= c.view {
+		return
+	}
+	// The proposal that is decided in the new view is prepared again in the new view
+	previousView := c.view
+	c.view = nextView
+	s, err := c.selectInFlight(infos)
+	if err != nil {
+		c.view = previousView
+		c.logger.Warningf("Ignoring new view %d: %s", nextView, err)
+		return
+	}
+
+	c.logger.Infof("Installing view %d", nextView)
+	c.decisionsInView = 0
+	c.viewChange = nil
+	for id, vote := range c.viewChangeVotes {
+		if vote <= nextView {
+			delete(c.viewChangeVotes, id)
+		}
+	}
+	c.newView = nil
+	if sender == c.selfID {
+		c.newView = nv
+	}
+	c.slot = nil
+	c.futureMessages = make(map[uint64][]*Message)
+	c.lastHeartbeat = c.clock.Now()
+	c.laggingBeats = 0
+	c.reportLeader()
+
+	if s != nil {
+		c.logger.Infof("Deciding again the proposal of block [%d] in view %d", s.seq, nextView)
+		s.recovery = true
+		c.slot = s
+	}
+	c.persist()
+	if s != nil {
+		c.prepare(s)
+	}
+
+	c.onLeaderChange(c.leader())
+	c.maybePropose(false)
+}
+
+// verifyNewView verifies that the new view carries valid view data of a quorum of the consenters
+// for the same view, and returns the view data and the view
+func (c *Chain) verifyNewView(nv *NewView) ([]*viewDataInfo, uint64, error) {
+	if nv == nil {
+		return nil, 0, errors.New("nil new view")
+	}
+	var infos []*viewDataInfo
+	var nextView uint64
+	signers := make(map[uint64]struct{})
+	for _, svd := range nv.SignedViewData {
+		if _, exists := signers[svd.GetSigner()]; exists {
+			return nil, 0, errors.Errorf("duplicate view data of %d", svd.GetSigner())
+		}
+		info, err := c.verifyViewData(svd)
+		if err != nil {
+			return nil, 0, errors.WithMessagef(err, "invalid view data of %d", svd.Signer)
+		}
+		if len(infos) == 0 {
+			nextView = info.viewData.NextView
+		} else if info.viewData.NextView != nextView {
+			return nil, 0, errors.Errorf("view data of %d is for view %d while view data of %d is for view %d",
+				svd.Signer, info.viewData.NextView, infos[0].signed.Signer, nextView)
+		}
+		signers[svd.Signer] = struct{}{}
+		infos = append(infos, info)
+	}
+	if quorum := c.quorum(); len(infos) < quorum {
+		return nil, 0, errors.Errorf("new view carries view data of %d consenters while a quorum of %d is required", len(infos), quorum)
+	}
+	return infos, nextView, nil
+}
@@ -30,3 +30,11 @@ type StaticStatusReporter struct {
 func (s StaticStatusReporter) StatusReport() (types.ClusterRelation, types.Status) {
 	return s.ClusterRelation, s.Status
 }
+
+// FollowerReporter is implemented by Chain implementations that follow a cluster by pulling blocks from other
+// orderers. The report details the progress of the follower in the channelparticipation.ChannelInfo.
+type FollowerReporter interface {
+	// FollowerReport provides the progress of the follower.
+	// See: types.FollowerInfo for more details.
+	FollowerReport() types.FollowerInfo
+}
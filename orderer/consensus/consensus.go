@@ -43,6 +43,15 @@ type MetadataValidator interface {
 	ValidateConsensusMetadata(oldMetadata, newMetadata []byte, newChannel bool) error
 }
 
+// LearnerPromoter is implemented by Chain implementations whose cluster distinguishes voting consenters from
+// non-voting consenters (learners), which replicate the chain before they are promoted to voting consenters.
+// NOTE: The promotion is a config update to the channel, which the caller is expected to sign and submit.
+type LearnerPromoter interface {
+	// PromoteLearner returns a copy of the given channel config in which the learner with the given endpoint
+	// (host:port) is a voting consenter. It fails if the learner lags behind the cluster.
+	PromoteLearner(config *cb.Config, endpoint string) (*cb.Config, error)
+}
+
 // Chain defines a way to inject messages for ordering.
 // Note, that in order to allow flexibility in the implementation, it is the responsibility of the implementer
 // to take the ordered messages, send them through the blockcutter.Receiver supplied via HandleChain to cut blocks,
@@ -16,8 +16,9 @@ import (
 // blockCreator holds number and hash of latest block
 // so that next block will be created based on it.
 type blockCreator struct {
-	hash   []byte
-	number uint64
+	hash             []byte
+	number           uint64
+	hashingAlgorithm func([]byte) []byte
 
 	logger *flogging.FabricLogger
 }
@@ -38,9 +39,9 @@ func (bc *blockCreator) createNextBlock(envs []*cb.Envelope) *cb.Block {
 	bc.number++
 
 	block := protoutil.NewBlock(bc.number, bc.hash)
-	block.Header.DataHash = protoutil.BlockDataHash(data)
+	block.Header.DataHash = protoutil.BlockDataHashWith(data, bc.hashingAlgorithm)
 	block.Data = data
 
-	bc.hash = protoutil.BlockHeaderHash(block.Header)
+	bc.hash = protoutil.BlockHeaderHashWith(block.Header, bc.hashingAlgorithm)
 	return block
 }
@@ -11,6 +11,7 @@ import (
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -19,9 +20,10 @@ import (
 func TestCreateNextBlock(t *testing.T) {
 	first := protoutil.NewBlock(0, []byte("firsthash"))
 	bc := &blockCreator{
-		hash:   protoutil.BlockHeaderHash(first.Header),
-		number: first.Header.Number,
-		logger: flogging.NewFabricLogger(zap.NewNop()),
+		hash:             protoutil.BlockHeaderHash(first.Header),
+		number:           first.Header.Number,
+		hashingAlgorithm: util.ComputeSHA256,
+		logger:           flogging.NewFabricLogger(zap.NewNop()),
 	}
 
 	second := bc.createNextBlock([]*cb.Envelope{{Payload: []byte("some other bytes")}})
@@ -33,4 +35,12 @@ func TestCreateNextBlock(t *testing.T) {
 	assert.Equal(t, second.Header.Number+1, third.Header.Number)
 	assert.Equal(t, protoutil.BlockDataHash(third.Data), third.Header.DataHash)
 	assert.Equal(t, protoutil.BlockHeaderHash(second.Header), third.Header.PreviousHash)
+
+	bc.hashingAlgorithm = util.ComputeGMSM3
+	fourth := bc.createNextBlock([]*cb.Envelope{{Payload: []byte("some other bytes")}})
+	assert.Equal(t, protoutil.BlockDataHashWith(fourth.Data, util.ComputeGMSM3), fourth.Header.DataHash)
+	assert.Equal(t, protoutil.BlockHeaderHash(third.Header), fourth.Header.PreviousHash)
+
+	fifth := bc.createNextBlock([]*cb.Envelope{{Payload: []byte("some other bytes")}})
+	assert.Equal(t, protoutil.BlockHeaderHashWith(fourth.Header, util.ComputeGMSM3), fifth.Header.PreviousHash)
 }
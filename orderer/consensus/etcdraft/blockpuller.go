@@ -11,7 +11,9 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/consensus"
@@ -66,8 +68,13 @@ func NewBlockPuller(support consensus.ConsenterSupport,
 	bccsp bccsp.BCCSP,
 ) (BlockPuller, error) {
 
+	hashingAlgorithm := util.ComputeSHA256
+	if channelConfig := support.ChannelConfig(); channelConfig != nil {
+		hashingAlgorithm = channelconfig.BlockHashingAlgorithm(channelConfig)
+	}
+
 	verifyBlockSequence := func(blocks []*common.Block, _ string) error {
-		return cluster.VerifyBlocks(blocks, support)
+		return cluster.VerifyBlocks(blocks, support, hashingAlgorithm)
 	}
 
 	stdDialer := &cluster.StandardDialer{
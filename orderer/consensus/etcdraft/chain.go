@@ -22,7 +22,10 @@ import (
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protoutil"
@@ -59,6 +62,11 @@ const (
 	// DefaultLeaderlessCheckInterval is the interval that a chain checks
 	// its own leadership status.
 	DefaultLeaderlessCheckInterval = time.Second * 10
+
+	// DefaultLearnerMaxLag is the default number of Raft entries a learner
+	// may lag behind the commit index of the leader, and still be considered
+	// caught up, and thereby ready to be promoted.
+	DefaultLearnerMaxLag = uint64(10)
 )
 
 //go:generate counterfeiter -o mocks/configurator.go . Configurator
@@ -126,6 +134,10 @@ type Options struct {
 
 	EvictionSuspicion   time.Duration
 	LeaderCheckInterval time.Duration
+
+	// LearnerMaxLag is the number of Raft entries a learner may lag behind
+	// the leader to be promoted. If it is 0, DefaultLearnerMaxLag is used.
+	LearnerMaxLag uint64
 }
 
 type submit struct {
@@ -176,6 +188,8 @@ type Chain struct {
 	lastBlock    *common.Block
 	appliedIndex uint64
 
+	hashingAlgorithm func([]byte) []byte // block hashing algorithm, fixed at channel creation
+
 	// needed by snapshotting
 	sizeLimit        uint32 // SnapshotIntervalSize in bytes
 	accDataSize      uint32 // accumulative data size since last snapshot
@@ -186,6 +200,9 @@ type Chain struct {
 
 	fresh bool // indicate if this is a fresh raft node
 
+	// CaughtUpLearners are the learners that caught up with the leader, and may be promoted
+	CaughtUpLearners atomic.Value
+
 	// this is exported so that test can use `Node.Status()` to get raft node status.
 	Node *node
 	opts Options
@@ -245,6 +262,11 @@ func NewChain(
 		return nil, errors.Errorf("failed to get last block")
 	}
 
+	hashingAlgorithm := util.ComputeSHA256
+	if channelConfig := support.ChannelConfig(); channelConfig != nil {
+		hashingAlgorithm = channelconfig.BlockHashingAlgorithm(channelConfig)
+	}
+
 	c := &Chain{
 		configurator:     conf,
 		rpc:              rpc,
@@ -263,6 +285,7 @@ func NewChain(
 		fresh:            fresh,
 		appliedIndex:     opts.BlockMetadata.RaftIndex,
 		lastBlock:        b,
+		hashingAlgorithm: hashingAlgorithm,
 		sizeLimit:        sizeLimit,
 		lastSnapBlockNum: snapBlkNum,
 		confState:        cc,
@@ -271,6 +294,8 @@ func NewChain(
 		haltCallback:     haltCallback,
 		Metrics: &Metrics{
 			ClusterSize:             opts.Metrics.ClusterSize.With("channel", support.ChannelID()),
+			Learners:                opts.Metrics.Learners.With("channel", support.ChannelID()),
+			LearnerLag:              opts.Metrics.LearnerLag.With("channel", support.ChannelID()),
 			IsLeader:                opts.Metrics.IsLeader.With("channel", support.ChannelID()),
 			ActiveNodes:             opts.Metrics.ActiveNodes.With("channel", support.ChannelID()),
 			CommittedBlockNumber:    opts.Metrics.CommittedBlockNumber.With("channel", support.ChannelID()),
@@ -288,6 +313,7 @@ func NewChain(
 
 	// Sets initial values for metrics
 	c.Metrics.ClusterSize.Set(float64(len(c.opts.BlockMetadata.ConsenterIds)))
+	c.Metrics.Learners.Set(float64(len(Learners(c.opts.Consenters))))
 	c.Metrics.IsLeader.Set(float64(0)) // all nodes start out as followers
 	c.Metrics.ActiveNodes.Set(float64(0))
 	c.Metrics.CommittedBlockNumber.Set(float64(c.lastBlock.Header.Number))
@@ -313,6 +339,12 @@ func NewChain(
 	disseminator := &Disseminator{RPC: c.rpc}
 	disseminator.UpdateMetadata(nil) // initialize
 	c.ActiveNodes.Store([]uint64{})
+	c.CaughtUpLearners.Store([]uint64{})
+
+	learnerMaxLag := c.opts.LearnerMaxLag
+	if learnerMaxLag == 0 {
+		learnerMaxLag = DefaultLearnerMaxLag
+	}
 
 	c.Node = &node{
 		chainID:      c.channelID,
@@ -330,6 +362,11 @@ func NewChain(
 			sender: disseminator,
 			gauge:  c.Metrics.ActiveNodes,
 			active: &c.ActiveNodes,
+
+			learnerLag:    c.Metrics.LearnerLag,
+			caughtUp:      &c.CaughtUpLearners,
+			maxLearnerLag: learnerMaxLag,
+
 			logger: c.logger,
 		},
 	}
@@ -483,6 +520,7 @@ func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
 
 	c.Metrics.ActiveNodes.Set(float64(len(clusterMetadata.ActiveNodes)))
 	c.ActiveNodes.Store(clusterMetadata.ActiveNodes)
+	c.CaughtUpLearners.Store(clusterMetadata.CaughtUpLearners)
 
 	return nil
 }
@@ -543,7 +581,7 @@ func (c *Chain) run() {
 	startTimer := func() {
 		if !ticking {
 			ticking = true
-			timer.Reset(c.support.SharedConfig().BatchTimeout())
+			timer.Reset(blockcutter.BatchTimeout(c.support.BlockCutter(), c.support.SharedConfig()))
 		}
 	}
 
@@ -728,9 +766,10 @@ func (c *Chain) run() {
 
 				c.logger.Infof("Start accepting requests as Raft leader at block [%d]", c.lastBlock.Header.Number)
 				bc = &blockCreator{
-					hash:   protoutil.BlockHeaderHash(c.lastBlock.Header),
-					number: c.lastBlock.Header.Number,
-					logger: c.logger,
+					hash:             protoutil.BlockHeaderHashWith(c.lastBlock.Header, c.hashingAlgorithm),
+					number:           c.lastBlock.Header.Number,
+					hashingAlgorithm: c.hashingAlgorithm,
+					logger:           c.logger,
 				}
 				submitC = c.submitC
 				c.justElected = false
@@ -1003,9 +1042,11 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 
 			switch cc.Type {
 			case raftpb.ConfChangeAddNode:
-				c.logger.Infof("Applied config change to add node %d, current nodes in channel: %+v", cc.NodeID, c.confState.Nodes)
+				c.logger.Infof("Applied config change to add node %d, current nodes in channel: %+v, learners: %+v", cc.NodeID, c.confState.Nodes, c.confState.Learners)
+			case raftpb.ConfChangeAddLearnerNode:
+				c.logger.Infof("Applied config change to add learner %d, current nodes in channel: %+v, learners: %+v", cc.NodeID, c.confState.Nodes, c.confState.Learners)
 			case raftpb.ConfChangeRemoveNode:
-				c.logger.Infof("Applied config change to remove node %d, current nodes in channel: %+v", cc.NodeID, c.confState.Nodes)
+				c.logger.Infof("Applied config change to remove node %d, current nodes in channel: %+v, learners: %+v", cc.NodeID, c.confState.Nodes, c.confState.Learners)
 			default:
 				c.logger.Panic("Programming error, encountered unsupported raft config change")
 			}
@@ -1022,6 +1063,7 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 				c.configInflight = false
 				// report the new cluster size
 				c.Metrics.ClusterSize.Set(float64(len(c.opts.BlockMetadata.ConsenterIds)))
+				c.Metrics.Learners.Set(float64(len(Learners(c.opts.Consenters))))
 			}
 
 			lead := atomic.LoadUint64(&c.lastKnownLeader)
@@ -1191,10 +1233,14 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 
 			c.confChangeInProgress = configMembership.ConfChange
 
-			switch configMembership.ConfChange.Type {
-			case raftpb.ConfChangeAddNode:
+			switch {
+			case configMembership.Promoted():
+				c.logger.Infof("Config block just committed promotes learner %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+			case configMembership.ConfChange.Type == raftpb.ConfChangeAddNode:
 				c.logger.Infof("Config block just committed adds node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
-			case raftpb.ConfChangeRemoveNode:
+			case configMembership.ConfChange.Type == raftpb.ConfChangeAddLearnerNode:
+				c.logger.Infof("Config block just committed adds learner %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+			case configMembership.ConfChange.Type == raftpb.ConfChangeRemoveNode:
 				c.logger.Infof("Config block just committed removes node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
 			default:
 				c.logger.Panic("Programming error, encountered unsupported raft config change")
@@ -1251,16 +1297,11 @@ func (c *Chain) getInFlightConfChange() *raftpb.ConfChange {
 	// extracting current Raft configuration state
 	confState := c.Node.ApplyConfChange(raftpb.ConfChange{})
 
-	if len(confState.Nodes) == len(c.opts.BlockMetadata.ConsenterIds) {
-		// Raft configuration change could only add one node or
-		// remove one node at a time, if raft conf state size is
-		// equal to membership stored in block metadata field,
-		// that means everything is in sync and no need to propose
-		// config update.
-		return nil
-	}
-
-	return ConfChange(c.opts.BlockMetadata, confState)
+	// Raft configuration change could only add, promote or remove
+	// one node at a time. If raft conf state is in sync with the
+	// membership stored in block metadata field, there is no need
+	// to propose config update.
+	return ConfChange(c.opts.BlockMetadata, c.opts.Consenters, confState)
 }
 
 // newMetadata extract config metadata from the configuration block
@@ -1304,6 +1345,9 @@ func (c *Chain) ValidateConsensusMetadata(oldMetadataBytes, newMetadataBytes []b
 			if _, exits := set[string(c.ClientTlsCert)]; !exits {
 				return errors.New("new channel has consenter that is not part of system consenter set")
 			}
+			if c.NonVoting {
+				return errors.New("new channel has non-voting consenter, learners may only be added to an existing channel")
+			}
 		}
 		return nil
 	}
@@ -1319,14 +1363,66 @@ func (c *Chain) ValidateConsensusMetadata(oldMetadataBytes, newMetadataBytes []b
 		return err
 	}
 
+	if changes.Promoted() && !NodeExists(changes.PromotedNode, c.CaughtUpLearners.Load().([]uint64)) {
+		return errors.WithMessagef(types.ErrLearnerNotCaughtUp, "learner %d cannot be promoted", changes.PromotedNode)
+	}
+
 	active := c.ActiveNodes.Load().([]uint64)
 	if changes.UnacceptableQuorumLoss(active) {
-		return errors.Errorf("%d out of %d nodes are alive, configuration will result in quorum loss", len(active), len(dummyOldConsentersMap))
+		return errors.Errorf("%d out of %d nodes are alive, configuration will result in quorum loss", len(active), len(Voters(dummyOldConsentersMap)))
 	}
 
 	return nil
 }
 
+// PromoteLearner returns a copy of the given channel config in which the learner with the given
+// endpoint is a voting consenter. The learner must have caught up with the leader.
+func (c *Chain) PromoteLearner(config *common.Config, endpoint string) (*common.Config, error) {
+	updated := proto.Clone(config).(*common.Config)
+	ordererGroup, exists := updated.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]
+	if !exists {
+		return nil, errors.New("config has no orderer group")
+	}
+	consensusTypeValue, exists := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !exists {
+		return nil, errors.New("config has no consensus type")
+	}
+	consensusType := &orderer.ConsensusType{}
+	if err := proto.Unmarshal(consensusTypeValue.Value, consensusType); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus type")
+	}
+	metadata := &etcdraft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal etcdraft metadata configuration")
+	}
+
+	var learner *etcdraft.Consenter
+	for _, consenter := range metadata.Consenters {
+		if fmt.Sprintf("%s:%d", consenter.Host, consenter.Port) == endpoint && consenter.NonVoting {
+			learner = consenter
+		}
+	}
+	if learner == nil {
+		return nil, errors.WithMessagef(types.ErrLearnerNotExist, "consenter %s is not a learner of channel %s", endpoint, c.channelID)
+	}
+
+	c.raftMetadataLock.RLock()
+	nodeID, exists := MembershipByCert(c.opts.Consenters)[string(learner.ClientTlsCert)]
+	c.raftMetadataLock.RUnlock()
+	if !exists {
+		return nil, errors.WithMessagef(types.ErrLearnerNotExist, "learner %s has not been added to the cluster yet", endpoint)
+	}
+	if !NodeExists(nodeID, c.CaughtUpLearners.Load().([]uint64)) {
+		return nil, errors.WithMessagef(types.ErrLearnerNotCaughtUp, "learner %d (%s) lags behind the leader", nodeID, endpoint)
+	}
+
+	learner.NonVoting = false
+	consensusType.Metadata = protoutil.MarshalOrPanic(metadata)
+	consensusTypeValue.Value = protoutil.MarshalOrPanic(consensusType)
+
+	return updated, nil
+}
+
 // StatusReport returns the ClusterRelation & Status
 func (c *Chain) StatusReport() (types.ClusterRelation, types.Status) {
 	return types.ClusterRelationMember, types.StatusActive
@@ -1649,6 +1649,22 @@ var _ = Describe("Chain", func() {
 					metadata.Consenters = append(metadata.Consenters, newConsenter)
 					return updateRaftConfigValue(metadata)
 				}
+				addLearnerConfigValue = func() map[string]*common.ConfigValue {
+					metadata := &raftprotos.ConfigMetadata{Options: options}
+					for _, consenter := range consenters {
+						metadata.Consenters = append(metadata.Consenters, consenter)
+					}
+
+					newConsenter := &raftprotos.Consenter{
+						Host:          "localhost",
+						Port:          7050,
+						ServerTlsCert: serverTLSCert(tlsCA),
+						ClientTlsCert: clientTLSCert(tlsCA),
+						NonVoting:     true,
+					}
+					metadata.Consenters = append(metadata.Consenters, newConsenter)
+					return updateRaftConfigValue(metadata)
+				}
 				removeConsenterConfigValue = func(id uint64) map[string]*common.ConfigValue {
 					metadata := &raftprotos.ConfigMetadata{Options: options}
 					for nodeID, consenter := range consenters {
@@ -1992,6 +2008,98 @@ var _ = Describe("Chain", func() {
 					})
 				})
 
+				It("adding a learner to the cluster and promoting it once caught up", func() {
+					learnerUpdate := addLearnerConfigValue()
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, learnerUpdate))
+					c1.cutter.CutNext = true
+
+					By("sending config transaction adding a learner")
+					Expect(c1.Configure(configEnv, 0)).To(Succeed())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(1))
+						Eventually(c.fakeFields.fakeLearners.SetCallCount, LongEventualTimeout).Should(Equal(2))
+						Expect(c.fakeFields.fakeLearners.SetArgsForCall(1)).To(Equal(float64(1)))
+					})
+
+					config := &common.Config{ChannelGroup: &common.ConfigGroup{Groups: map[string]*common.ConfigGroup{
+						channelconfig.OrdererGroupKey: {Values: learnerUpdate},
+					}}}
+
+					By("refusing to promote the learner before it caught up")
+					_, err := c1.PromoteLearner(config, "localhost:7050")
+					Expect(errors.Cause(err)).To(Equal(orderer_types.ErrLearnerNotCaughtUp))
+					_, err = c1.PromoteLearner(config, "localhost:7051")
+					Expect(errors.Cause(err)).To(Equal(orderer_types.ErrLearnerNotExist))
+
+					_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(0)
+					meta := &common.Metadata{Value: raftmetabytes}
+					raftmeta, err := etcdraft.ReadBlockMetadata(meta, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					// the learner knows itself from the config block that adds it
+					consensusType := &orderer.ConsensusType{}
+					Expect(proto.Unmarshal(learnerUpdate["ConsensusType"].Value, consensusType)).To(Succeed())
+					learnerMetadata := &raftprotos.ConfigMetadata{}
+					Expect(proto.Unmarshal(consensusType.Metadata, learnerMetadata)).To(Succeed())
+					learnerConsenters := map[uint64]*raftprotos.Consenter{4: learnerMetadata.Consenters[3]}
+					for id, consenter := range consenters {
+						learnerConsenters[id] = consenter
+					}
+
+					c4 := newChain(timeout, channelID, dataDir, 4, raftmeta, learnerConsenters, cryptoProvider, nil)
+					// if we join a node to existing network, it MUST already obtained blocks
+					// till the config block that adds this node to cluster.
+					c4.support.WriteBlock(c1.support.WriteBlockArgsForCall(0))
+					c4.support.WriteConfigBlock(c1.support.WriteConfigBlockArgsForCall(0))
+					c4.init()
+
+					network.addChain(c4)
+					c4.Start()
+
+					Eventually(func() <-chan raft.SoftState {
+						c1.clock.Increment(interval)
+						return c4.observe
+					}, defaultTimeout).Should(Receive(Equal(raft.SoftState{Lead: 1, RaftState: raft.StateFollower})))
+
+					By("ordering a transaction, which the learner replicates")
+					c1.cutter.CutNext = true
+					Expect(c1.Order(env, 0)).To(Succeed())
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(2))
+					})
+
+					By("waiting for the leader to report the learner as caught up")
+					Eventually(func() []uint64 {
+						c1.clock.Increment(interval)
+						return c1.CaughtUpLearners.Load().([]uint64)
+					}, LongEventualTimeout).Should(Equal([]uint64{4}))
+					lastWith := c1.fakeFields.fakeLearnerLag.WithCallCount() - 1
+					Expect(c1.fakeFields.fakeLearnerLag.WithArgsForCall(lastWith)).To(Equal([]string{"learner", "4"}))
+					Expect(c1.ActiveNodes.Load().([]uint64)).NotTo(ContainElement(uint64(4)))
+
+					By("promoting the learner")
+					updated, err := c1.PromoteLearner(config, "localhost:7050")
+					Expect(err).NotTo(HaveOccurred())
+					promoteUpdate := updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values
+					Expect(proto.Equal(config, updated)).To(BeFalse())
+
+					c1.cutter.CutNext = true
+					configEnv = newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, promoteUpdate))
+					Expect(c1.Configure(configEnv, 0)).To(Succeed())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(2))
+						Eventually(c.fakeFields.fakeLearners.SetCallCount, LongEventualTimeout).Should(Equal(3))
+						Expect(c.fakeFields.fakeLearners.SetArgsForCall(2)).To(Equal(float64(0)))
+					}, 1, 2, 3)
+
+					Eventually(func() []uint64 {
+						c1.clock.Increment(interval)
+						return c1.ActiveNodes.Load().([]uint64)
+					}, LongEventualTimeout).Should(ContainElement(uint64(4)))
+				})
+
 				It("does not reconfigure raft cluster if it's a channel creation tx", func() {
 					configEnv := newConfigEnv("another-channel",
 						common.HeaderType_CONFIG,
@@ -56,6 +56,7 @@ type Config struct {
 	WALDir            string // WAL data of <my-channel> is stored in WALDir/<my-channel>
 	SnapDir           string // Snapshots of <my-channel> are stored in SnapDir/<my-channel>
 	EvictionSuspicion string // Duration threshold that the node samples in order to suspect its eviction from the channel.
+	LearnerMaxLag     uint64 // Number of Raft entries a learner may lag behind the leader and still be eligible for promotion.
 }
 
 // Consenter implements etcdraft consenter
@@ -97,10 +98,11 @@ func (c *Consenter) ReceiverByChain(channelID string) MessageReceiver {
 	if cs.Chain == nil {
 		c.Logger.Panicf("Programming error - Chain %s is nil although it exists in the mapping", channelID)
 	}
-	if etcdRaftChain, isEtcdRaftChain := cs.Chain.(*Chain); isEtcdRaftChain {
-		return etcdRaftChain
+	// The chains of other cluster consensus types share the communication of the etcdraft consenter
+	if receiver, isMessageReceiver := cs.Chain.(MessageReceiver); isMessageReceiver {
+		return receiver
 	}
-	c.Logger.Warningf("Chain %s is of type %v and not etcdraft.Chain", channelID, reflect.TypeOf(cs.Chain))
+	c.Logger.Warningf("Chain %s is of type %v and does not receive cluster messages", channelID, reflect.TypeOf(cs.Chain))
 	return nil
 }
 
@@ -155,6 +157,15 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		return nil, errors.Wrapf(err, "failed to read Raft metadata")
 	}
 
+	if !isMigration && (metadata == nil || len(metadata.Value) == 0) {
+		// a learner joins an existing cluster through a config update, it cannot be part of the initial cluster
+		for _, consenter := range m.Consenters {
+			if consenter.NonVoting {
+				return nil, errors.Errorf("consenter %s:%d is non-voting, learners cannot bootstrap a cluster", consenter.Host, consenter.Port)
+			}
+		}
+	}
+
 	consenters := CreateConsentersMap(blockMetadata, m)
 
 	id, err := c.detectSelfID(consenters)
@@ -166,7 +177,7 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 			return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChannelID())}, nil
 		} else {
 			//TODO fully construct a follower chain
-			return &follower.Chain{Err: errors.Errorf("orderer is a follower of channel %s", support.ChannelID())}, nil
+			return &follower.Chain{Err: errors.Errorf("orderer is a follower of channel %s", support.ChannelID()), Ledger: support}, nil
 		}
 	}
 
@@ -207,6 +218,7 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		WALDir:            path.Join(c.EtcdRaftConfig.WALDir, support.ChannelID()),
 		SnapDir:           path.Join(c.EtcdRaftConfig.SnapDir, support.ChannelID()),
 		EvictionSuspicion: evictionSuspicion,
+		LearnerMaxLag:     c.EtcdRaftConfig.LearnerMaxLag,
 		Cert:              c.Cert,
 		Metrics:           c.Metrics,
 	}
@@ -349,6 +349,31 @@ var _ = Describe("Consenter", func() {
 		Expect(err).To(MatchError("failed to parse TickInterval (500) to time duration"))
 	})
 
+	It("fails to handle chain if a consenter of a new channel is non-voting", func() {
+		m := &etcdraftproto.ConfigMetadata{
+			Consenters: []*etcdraftproto.Consenter{
+				{ServerTlsCert: certAsPEM},
+				{Host: "orderer2", Port: 7050, ServerTlsCert: []byte("cert.orderer2.org1"), NonVoting: true},
+			},
+			Options: &etcdraftproto.Options{
+				TickInterval:      "500ms",
+				ElectionTick:      10,
+				HeartbeatTick:     1,
+				MaxInflightBlocks: 5,
+			},
+		}
+		metadata := protoutil.MarshalOrPanic(m)
+		mockOrderer := &mocks.OrdererConfig{}
+		mockOrderer.ConsensusMetadataReturns(metadata)
+		support.SharedConfigReturns(mockOrderer)
+
+		consenter := newConsenter(chainGetter)
+
+		chain, err := consenter.HandleChain(support, nil)
+		Expect(chain).To(BeNil())
+		Expect(err).To(MatchError("consenter orderer2:7050 is non-voting, learners cannot bootstrap a cluster"))
+	})
+
 	It("constructs a follower chain if no matching cert found", func() {
 		m := &etcdraftproto.ConfigMetadata{
 			Consenters: []*etcdraftproto.Consenter{
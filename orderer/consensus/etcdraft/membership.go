@@ -9,6 +9,8 @@ package etcdraft
 import (
 	"encoding/pem"
 	"fmt"
+	"sort"
+
 	gmx509 "github.com/littlegirlpppp/gmsm/x509"
 
 	"github.com/golang/protobuf/proto"
@@ -29,6 +31,30 @@ func MembershipByCert(consenters map[uint64]*etcdraft.Consenter) map[string]uint
 	return set
 }
 
+// Voters returns the IDs of the voting consenters, in ascending order.
+func Voters(consenters map[uint64]*etcdraft.Consenter) []uint64 {
+	var voters []uint64
+	for nodeID, c := range consenters {
+		if !c.GetNonVoting() {
+			voters = append(voters, nodeID)
+		}
+	}
+	sort.Slice(voters, func(i, j int) bool { return voters[i] < voters[j] })
+	return voters
+}
+
+// Learners returns the IDs of the non-voting consenters, in ascending order.
+func Learners(consenters map[uint64]*etcdraft.Consenter) []uint64 {
+	var learners []uint64
+	for nodeID, c := range consenters {
+		if c.GetNonVoting() {
+			learners = append(learners, nodeID)
+		}
+	}
+	sort.Slice(learners, func(i, j int) bool { return learners[i] < learners[j] })
+	return learners
+}
+
 // MembershipChanges keeps information about membership
 // changes introduced during configuration update
 type MembershipChanges struct {
@@ -38,10 +64,13 @@ type MembershipChanges struct {
 	RemovedNodes     []*etcdraft.Consenter
 	ConfChange       *raftpb.ConfChange
 	RotatedNode      uint64
+	PromotedNode     uint64
 }
 
 // ComputeMembershipChanges computes membership update based on information about new consenters, returns
-// two slices: a slice of added consenters and a slice of consenters to be removed
+// two slices: a slice of added consenters and a slice of consenters to be removed.
+// A consenter marked as non-voting is added as a Raft learner, and promoted to a voting member
+// once a later update clears the mark.
 func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters map[uint64]*etcdraft.Consenter, newConsenters []*etcdraft.Consenter, ordererConfig channelconfig.Orderer) (mc *MembershipChanges, err error) {
 	result := &MembershipChanges{
 		NewConsenters:    map[uint64]*etcdraft.Consenter{},
@@ -53,11 +82,18 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 	result.NewBlockMetadata.ConsenterIds = make([]uint64, len(newConsenters))
 
 	var addedNodeIndex int
+	var promotedNodes []uint64
 	currentConsentersSet := MembershipByCert(oldConsenters)
 	for i, c := range newConsenters {
 		if nodeID, exists := currentConsentersSet[string(c.ClientTlsCert)]; exists {
 			result.NewBlockMetadata.ConsenterIds[i] = nodeID
 			result.NewConsenters[nodeID] = c
+			switch {
+			case oldConsenters[nodeID].NonVoting && !c.NonVoting:
+				promotedNodes = append(promotedNodes, nodeID)
+			case !oldConsenters[nodeID].NonVoting && c.NonVoting:
+				return nil, errors.Errorf("consenter %s:%d is a voting member, it cannot become non-voting", c.Host, c.Port)
+			}
 			continue
 		}
 		err := validateConsenterTLSCerts(c, ordererConfig)
@@ -78,9 +114,21 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 	}
 
 	switch {
+	case len(promotedNodes) == 1 && len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 0:
+		// promoted learner
+		result.PromotedNode = promotedNodes[0]
+		result.ConfChange = &raftpb.ConfChange{
+			NodeID: promotedNodes[0],
+			Type:   raftpb.ConfChangeAddNode,
+		}
+	case len(promotedNodes) > 0:
+		return nil, errors.Errorf("promotion of a non-voting consenter must be the only membership change, requested changes: %s, promote %d node(s)", result, len(promotedNodes))
 	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 1:
 		// A cert is considered being rotated, iff exact one new node is being added
 		// AND exact one existing node is being removed
+		if result.AddedNodes[0].NonVoting != result.RemovedNodes[0].NonVoting {
+			return nil, errors.Errorf("rotated consenter %s:%d must keep the voting status of the consenter it replaces", result.AddedNodes[0].Host, result.AddedNodes[0].Port)
+		}
 		result.RotatedNode = deletedNodeID
 		result.NewBlockMetadata.ConsenterIds[addedNodeIndex] = deletedNodeID
 		result.NewConsenters[deletedNodeID] = result.AddedNodes[0]
@@ -94,6 +142,9 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 			NodeID: nodeID,
 			Type:   raftpb.ConfChangeAddNode,
 		}
+		if result.AddedNodes[0].NonVoting {
+			result.ConfChange.Type = raftpb.ConfChangeAddLearnerNode
+		}
 	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 1:
 		// removed node
 		nodeID := deletedNodeID
@@ -207,7 +258,7 @@ func (mc *MembershipChanges) String() string {
 
 // Changed indicates whether these changes actually do anything
 func (mc *MembershipChanges) Changed() bool {
-	return len(mc.AddedNodes) > 0 || len(mc.RemovedNodes) > 0
+	return len(mc.AddedNodes) > 0 || len(mc.RemovedNodes) > 0 || mc.Promoted()
 }
 
 // Rotated indicates whether the change was a rotation
@@ -215,27 +266,46 @@ func (mc *MembershipChanges) Rotated() bool {
 	return len(mc.AddedNodes) == 1 && len(mc.RemovedNodes) == 1
 }
 
+// Promoted indicates whether the change was a promotion of a learner
+func (mc *MembershipChanges) Promoted() bool {
+	return mc.PromotedNode != raft.None
+}
+
 // UnacceptableQuorumLoss returns true if membership change will result in avoidable quorum loss,
-// given current number of active nodes in cluster. Avoidable means that more nodes can be started
+// given current number of active voting nodes in cluster. Avoidable means that more nodes can be started
 // to prevent quorum loss. Sometimes, quorum loss is inevitable, for example expanding 1-node cluster.
+// Learners do not vote, hence adding, rotating or removing a learner never costs quorum.
 func (mc *MembershipChanges) UnacceptableQuorumLoss(active []uint64) bool {
 	activeMap := make(map[uint64]struct{})
 	for _, i := range active {
 		activeMap[i] = struct{}{}
 	}
 
-	isCFT := len(mc.NewConsenters) > 2 // if resulting cluster cannot tolerate any fault, quorum loss is inevitable
-	quorum := len(mc.NewConsenters)/2 + 1
+	voters := len(Voters(mc.NewConsenters))
+	isCFT := voters > 2 // if resulting cluster cannot tolerate any fault, quorum loss is inevitable
+	quorum := voters/2 + 1
 
 	switch {
+	case mc.Promoted(): // Promote, the learner is expected to be active
+		return isCFT && len(active)+1 < quorum
+
+	case mc.ConfChange != nil && mc.ConfChange.Type == raftpb.ConfChangeAddLearnerNode: // Add learner
+		return false
+
 	case mc.ConfChange != nil && mc.ConfChange.Type == raftpb.ConfChangeAddNode: // Add
 		return isCFT && len(active) < quorum
 
 	case mc.RotatedNode != raft.None: // Rotate
+		if mc.NewConsenters[mc.RotatedNode].GetNonVoting() {
+			return false
+		}
 		delete(activeMap, mc.RotatedNode)
 		return isCFT && len(activeMap) < quorum
 
 	case mc.ConfChange != nil && mc.ConfChange.Type == raftpb.ConfChangeRemoveNode: // Remove
+		if len(mc.RemovedNodes) == 1 && mc.RemovedNodes[0].GetNonVoting() {
+			return false
+		}
 		delete(activeMap, mc.ConfChange.NodeID)
 		return len(activeMap) < quorum
 
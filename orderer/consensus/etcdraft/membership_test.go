@@ -25,6 +25,7 @@ func TestQuorumCheck(t *testing.T) {
 		NewConsenters map[uint64]*etcdraftproto.Consenter
 		ConfChange    *raftpb.ConfChange
 		RotateNode    uint64
+		PromoteNode   uint64
 		ActiveNodes   []uint64
 		QuorumLoss    bool
 	}{
@@ -113,6 +114,38 @@ func TestQuorumCheck(t *testing.T) {
 			ActiveNodes:   []uint64{1, 2},
 			QuorumLoss:    false,
 		},
+		// Learners, a learner is never active
+		{
+			Name:          "[1,2,(3)]->[1,2,(3),4L]",
+			NewConsenters: map[uint64]*etcdraftproto.Consenter{1: nil, 2: nil, 3: nil, 4: {NonVoting: true}},
+			ConfChange:    &raftpb.ConfChange{NodeID: 4, Type: raftpb.ConfChangeAddLearnerNode},
+			ActiveNodes:   []uint64{1, 2},
+			QuorumLoss:    false,
+		},
+		{
+			Name:          "[1,2,(3),4L]->[1,2,(3),4]",
+			NewConsenters: map[uint64]*etcdraftproto.Consenter{1: nil, 2: nil, 3: nil, 4: {}},
+			ConfChange:    &raftpb.ConfChange{NodeID: 4, Type: raftpb.ConfChangeAddNode},
+			PromoteNode:   4,
+			ActiveNodes:   []uint64{1, 2},
+			QuorumLoss:    false,
+		},
+		{
+			Name:          "[1,(2),(3),4L]->[1,(2),(3),4]",
+			NewConsenters: map[uint64]*etcdraftproto.Consenter{1: nil, 2: nil, 3: nil, 4: {}},
+			ConfChange:    &raftpb.ConfChange{NodeID: 4, Type: raftpb.ConfChangeAddNode},
+			PromoteNode:   4,
+			ActiveNodes:   []uint64{1},
+			QuorumLoss:    true,
+		},
+		{
+			Name:          "[1,2,(3),4L]->[1,2,(3),4L']",
+			NewConsenters: map[uint64]*etcdraftproto.Consenter{1: nil, 2: nil, 3: nil, 4: {NonVoting: true}},
+			ConfChange:    &raftpb.ConfChange{NodeID: 4, Type: raftpb.ConfChangeRemoveNode},
+			RotateNode:    4,
+			ActiveNodes:   []uint64{1, 2},
+			QuorumLoss:    false,
+		},
 	}
 
 	for _, test := range tests {
@@ -121,6 +154,7 @@ func TestQuorumCheck(t *testing.T) {
 				NewConsenters: test.NewConsenters,
 				ConfChange:    test.ConfChange,
 				RotatedNode:   test.RotateNode,
+				PromotedNode:  test.PromoteNode,
 			}
 
 			require.Equal(t, test.QuorumLoss, changes.UnacceptableQuorumLoss(test.ActiveNodes))
@@ -168,6 +202,12 @@ func TestMembershipChanges(t *testing.T) {
 		{ClientTlsCert: client4.Cert, ServerTlsCert: client4.Cert},
 	}
 
+	// non-voting consenters (learners)
+	l := []*etcdraftproto.Consenter{
+		{Host: "orderer2", Port: 7050, ClientTlsCert: client2.Cert, ServerTlsCert: client2.Cert, NonVoting: true},
+		{Host: "orderer3", Port: 7050, ClientTlsCert: client3.Cert, ServerTlsCert: client3.Cert, NonVoting: true},
+	}
+
 	mockOrdererConfig := &mocks.OrdererConfig{}
 	mockOrg := &mocks.OrdererOrg{}
 	mockMSP := &mocks.MSP{}
@@ -352,6 +392,104 @@ func TestMembershipChanges(t *testing.T) {
 			Rotated:     false,
 			ExpectedErr: "",
 		},
+		{
+			Name: "Add a learner",
+			OldConsenters: map[uint64]*etcdraftproto.Consenter{
+				1: c[0],
+				2: c[1],
+			},
+			NewConsenters: []*etcdraftproto.Consenter{
+				c[0],
+				c[1],
+				l[1],
+			},
+			Changes: &etcdraft.MembershipChanges{
+				NewBlockMetadata: &etcdraftproto.BlockMetadata{
+					ConsenterIds:    []uint64{1, 2, 3},
+					NextConsenterId: 4,
+				},
+				NewConsenters: map[uint64]*etcdraftproto.Consenter{1: c[0], 2: c[1], 3: l[1]},
+				AddedNodes:    []*etcdraftproto.Consenter{l[1]},
+				RemovedNodes:  []*etcdraftproto.Consenter{},
+				ConfChange: &raftpb.ConfChange{
+					NodeID: 3,
+					Type:   raftpb.ConfChangeAddLearnerNode,
+				},
+			},
+			Changed:     true,
+			Rotated:     false,
+			ExpectedErr: "",
+		},
+		{
+			Name: "Promote a learner",
+			OldConsenters: map[uint64]*etcdraftproto.Consenter{
+				1: c[0],
+				2: c[1],
+				3: l[1],
+			},
+			NewConsenters: []*etcdraftproto.Consenter{
+				c[0],
+				c[1],
+				c[2],
+			},
+			Changes: &etcdraft.MembershipChanges{
+				NewBlockMetadata: &etcdraftproto.BlockMetadata{
+					ConsenterIds:    []uint64{1, 2, 3},
+					NextConsenterId: 3,
+				},
+				NewConsenters: map[uint64]*etcdraftproto.Consenter{1: c[0], 2: c[1], 3: c[2]},
+				AddedNodes:    []*etcdraftproto.Consenter{},
+				RemovedNodes:  []*etcdraftproto.Consenter{},
+				PromotedNode:  3,
+				ConfChange: &raftpb.ConfChange{
+					NodeID: 3,
+					Type:   raftpb.ConfChangeAddNode,
+				},
+			},
+			Changed:     true,
+			Rotated:     false,
+			ExpectedErr: "",
+		},
+		{
+			Name: "Promote a learner and remove a node",
+			OldConsenters: map[uint64]*etcdraftproto.Consenter{
+				1: c[0],
+				2: c[1],
+				3: l[1],
+			},
+			NewConsenters: []*etcdraftproto.Consenter{
+				c[0],
+				c[2],
+			},
+			Changes:     nil,
+			ExpectedErr: "promotion of a non-voting consenter must be the only membership change, requested changes: add 0 node(s), remove 1 node(s), promote 1 node(s)",
+		},
+		{
+			Name: "Demote a voting consenter",
+			OldConsenters: map[uint64]*etcdraftproto.Consenter{
+				1: c[0],
+				2: c[1],
+			},
+			NewConsenters: []*etcdraftproto.Consenter{
+				c[0],
+				l[0],
+			},
+			Changes:     nil,
+			ExpectedErr: "consenter orderer2:7050 is a voting member, it cannot become non-voting",
+		},
+		{
+			Name: "Rotate a learner into a voting consenter",
+			OldConsenters: map[uint64]*etcdraftproto.Consenter{
+				1: c[0],
+				2: l[0],
+			},
+			NewConsenters: []*etcdraftproto.Consenter{
+				c[0],
+				c[2],
+			},
+			Changes:     nil,
+			ExpectedErr: "rotated consenter :0 must keep the voting status of the consenter it replaces",
+		},
 		{
 			Name: "More than one consenter added",
 			OldConsenters: map[uint64]*etcdraftproto.Consenter{
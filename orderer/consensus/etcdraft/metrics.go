@@ -17,6 +17,22 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	learnersOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "learners",
+		Help:         "Number of non-voting learner nodes in this channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	learnerLagOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "learner_lag",
+		Help:         "The number of Raft entries a learner lags behind the leader, as observed by the leader.",
+		LabelNames:   []string{"channel", "learner"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{learner}",
+	}
 	isLeaderOpts = metrics.GaugeOpts{
 		Namespace:    "consensus",
 		Subsystem:    "etcdraft",
@@ -93,6 +109,8 @@ var (
 
 type Metrics struct {
 	ClusterSize             metrics.Gauge
+	Learners                metrics.Gauge
+	LearnerLag              metrics.Gauge
 	IsLeader                metrics.Gauge
 	ActiveNodes             metrics.Gauge
 	CommittedBlockNumber    metrics.Gauge
@@ -107,6 +125,8 @@ type Metrics struct {
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
 		ClusterSize:             p.NewGauge(clusterSizeOpts),
+		Learners:                p.NewGauge(learnersOpts),
+		LearnerLag:              p.NewGauge(learnerLagOpts),
 		IsLeader:                p.NewGauge(isLeaderOpts),
 		ActiveNodes:             p.NewGauge(ActiveNodesOpts),
 		CommittedBlockNumber:    p.NewGauge(committedBlockNumberOpts),
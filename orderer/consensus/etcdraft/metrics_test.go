@@ -37,11 +37,13 @@ var _ = Describe("Metrics", func() {
 			metrics := etcdraft.NewMetrics(fakeProvider)
 
 			Expect(metrics).NotTo(BeNil())
-			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(5))
+			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(7))
 			Expect(fakeProvider.NewCounterCallCount()).To(Equal(4))
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
 
 			Expect(metrics.ClusterSize).To(Equal(fakeGauge))
+			Expect(metrics.Learners).To(Equal(fakeGauge))
+			Expect(metrics.LearnerLag).To(Equal(fakeGauge))
 			Expect(metrics.IsLeader).To(Equal(fakeGauge))
 			Expect(metrics.CommittedBlockNumber).To(Equal(fakeGauge))
 			Expect(metrics.SnapshotBlockNumber).To(Equal(fakeGauge))
@@ -57,6 +59,8 @@ var _ = Describe("Metrics", func() {
 func newFakeMetrics(fakeFields *fakeMetricsFields) *etcdraft.Metrics {
 	return &etcdraft.Metrics{
 		ClusterSize:             fakeFields.fakeClusterSize,
+		Learners:                fakeFields.fakeLearners,
+		LearnerLag:              fakeFields.fakeLearnerLag,
 		IsLeader:                fakeFields.fakeIsLeader,
 		ActiveNodes:             fakeFields.fakeActiveNodes,
 		CommittedBlockNumber:    fakeFields.fakeCommittedBlockNumber,
@@ -71,6 +75,8 @@ func newFakeMetrics(fakeFields *fakeMetricsFields) *etcdraft.Metrics {
 
 type fakeMetricsFields struct {
 	fakeClusterSize             *metricsfakes.Gauge
+	fakeLearners                *metricsfakes.Gauge
+	fakeLearnerLag              *metricsfakes.Gauge
 	fakeIsLeader                *metricsfakes.Gauge
 	fakeActiveNodes             *metricsfakes.Gauge
 	fakeCommittedBlockNumber    *metricsfakes.Gauge
@@ -85,6 +91,8 @@ type fakeMetricsFields struct {
 func newFakeMetricsFields() *fakeMetricsFields {
 	return &fakeMetricsFields{
 		fakeClusterSize:             newFakeGauge(),
+		fakeLearners:                newFakeGauge(),
+		fakeLearnerLag:              newFakeGauge(),
 		fakeIsLeader:                newFakeGauge(),
 		fakeActiveNodes:             newFakeGauge(),
 		fakeCommittedBlockNumber:    newFakeGauge(),
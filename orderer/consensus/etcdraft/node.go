@@ -247,6 +247,10 @@ func (n *node) abdicateLeader(currentLead uint64) {
 				continue // skip self
 			}
 
+			if pr.IsLearner {
+				continue // learners cannot become leader
+			}
+
 			if pr.RecentActive && !pr.Paused {
 				transferee = id
 				break
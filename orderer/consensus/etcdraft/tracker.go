@@ -7,6 +7,9 @@
 package etcdraft
 
 import (
+	"reflect"
+	"sort"
+	"strconv"
 	"sync/atomic"
 
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
@@ -18,12 +21,19 @@ import (
 
 // Tracker periodically poll Raft Status, and update disseminator
 // so that status is populated to followers.
+// Learners are not counted as active nodes, since they do not vote. Instead, the
+// leader reports how far each learner lags behind, and which learners caught up.
 type Tracker struct {
 	id     uint64
 	sender *Disseminator
 	gauge  metrics.Gauge
 	active *atomic.Value
 
+	learnerLag    metrics.Gauge
+	caughtUp      *atomic.Value
+	maxLearnerLag uint64
+	learners      map[uint64]struct{}
+
 	counter int
 
 	logger *flogging.FabricLogger
@@ -34,6 +44,7 @@ func (t *Tracker) Check(status *raft.Status) {
 	if status.Lead == raft.None {
 		t.gauge.Set(0)
 		t.active.Store([]uint64{})
+		t.caughtUp.Store([]uint64{})
 		return
 	}
 
@@ -44,6 +55,8 @@ func (t *Tracker) Check(status *raft.Status) {
 
 	// leader
 	current := []uint64{t.id}
+	caughtUp := []uint64{}
+	learners := map[uint64]struct{}{}
 	for id, progress := range status.Progress {
 
 		if id == t.id {
@@ -53,15 +66,40 @@ func (t *Tracker) Check(status *raft.Status) {
 			continue
 		}
 
+		if progress.IsLearner {
+			learners[id] = struct{}{}
+			var lag uint64
+			if progress.Match < status.Commit {
+				lag = status.Commit - progress.Match
+			}
+			t.learnerLag.With("learner", strconv.FormatUint(id, 10)).Set(float64(lag))
+			if progress.RecentActive && lag <= t.maxLearnerLag {
+				caughtUp = append(caughtUp, id)
+			}
+			continue
+		}
+
 		if progress.RecentActive {
 			current = append(current, id)
 		}
 	}
 
+	// learners that were promoted or removed no longer lag behind
+	for id := range t.learners {
+		if _, exists := learners[id]; !exists {
+			t.learnerLag.With("learner", strconv.FormatUint(id, 10)).Set(0)
+		}
+	}
+	t.learners = learners
+
+	sort.Slice(caughtUp, func(i, j int) bool { return caughtUp[i] < caughtUp[j] })
+	lastCaughtUp := t.caughtUp.Load().([]uint64)
+	t.caughtUp.Store(caughtUp)
+
 	last := t.active.Load().([]uint64)
 	t.active.Store(current)
 
-	if len(current) != len(last) {
+	if len(current) != len(last) || !reflect.DeepEqual(caughtUp, lastCaughtUp) {
 		t.counter = 0
 		return
 	}
@@ -74,9 +112,9 @@ func (t *Tracker) Check(status *raft.Status) {
 	}
 
 	t.counter = 0
-	t.logger.Debugf("Current active nodes in cluster are: %+v", current)
+	t.logger.Debugf("Current active nodes in cluster are: %+v, caught up learners are: %+v", current, caughtUp)
 
 	t.gauge.Set(float64(len(current)))
-	metadata := protoutil.MarshalOrPanic(&etcdraft.ClusterMetadata{ActiveNodes: current})
+	metadata := protoutil.MarshalOrPanic(&etcdraft.ClusterMetadata{ActiveNodes: current, CaughtUpLearners: caughtUp})
 	t.sender.UpdateMetadata(metadata)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/bccsp"
+	commonbft "github.com/hyperledger/fabric/common/bft"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -266,6 +267,33 @@ func validateCert(pemData []byte, certRole string) error {
 	return nil
 }
 
+// consenterMetadataOf returns the consenters of the orderer config, whose consensus type
+// is either etcdraft or BFT, in the form of the etcdraft config metadata
+func consenterMetadataOf(oc channelconfig.Orderer) (*etcdraft.ConfigMetadata, error) {
+	if oc.ConsensusType() != commonbft.ConsensusType {
+		m := &etcdraft.ConfigMetadata{}
+		if err := proto.Unmarshal(oc.ConsensusMetadata(), m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	bftMetadata := &commonbft.ConfigMetadata{}
+	if err := proto.Unmarshal(oc.ConsensusMetadata(), bftMetadata); err != nil {
+		return nil, err
+	}
+	m := &etcdraft.ConfigMetadata{}
+	for _, consenter := range bftMetadata.Consenters {
+		m.Consenters = append(m.Consenters, &etcdraft.Consenter{
+			Host:          consenter.Host,
+			Port:          consenter.Port,
+			ClientTlsCert: consenter.ClientTlsCert,
+			ServerTlsCert: consenter.ServerTlsCert,
+		})
+	}
+	return m, nil
+}
+
 // ConsenterCertificate denotes a TLS certificate of a consenter
 type ConsenterCertificate struct {
 	ConsenterCertificate []byte
@@ -294,8 +322,8 @@ func (conCert ConsenterCertificate) IsConsenterOfChannel(configBlock *common.Blo
 	if !exists {
 		return errors.New("no orderer config in bundle")
 	}
-	m := &etcdraft.ConfigMetadata{}
-	if err := proto.Unmarshal(oc.ConsensusMetadata(), m); err != nil {
+	m, err := consenterMetadataOf(oc)
+	if err != nil {
 		return err
 	}
 
@@ -346,32 +374,40 @@ func NodeExists(id uint64, nodes []uint64) bool {
 }
 
 // ConfChange computes Raft configuration changes based on current Raft
-// configuration state and consenters IDs stored in RaftMetadata.
-func ConfChange(blockMetadata *etcdraft.BlockMetadata, confState *raftpb.ConfState) *raftpb.ConfChange {
-	raftConfChange := &raftpb.ConfChange{}
-
-	// need to compute conf changes to propose
-	if len(confState.Nodes) < len(blockMetadata.ConsenterIds) {
-		// adding new node
-		raftConfChange.Type = raftpb.ConfChangeAddNode
-		for _, consenterID := range blockMetadata.ConsenterIds {
-			if NodeExists(consenterID, confState.Nodes) {
+// configuration state and consenters IDs stored in RaftMetadata. Non-voting
+// consenters are expected to be learners in the Raft configuration state.
+// It returns nil if the Raft configuration state is in sync with the consenters.
+func ConfChange(blockMetadata *etcdraft.BlockMetadata, consenters map[uint64]*etcdraft.Consenter, confState *raftpb.ConfState) *raftpb.ConfChange {
+	for _, consenterID := range blockMetadata.ConsenterIds {
+		nonVoting := consenters[consenterID].GetNonVoting()
+		switch {
+		case NodeExists(consenterID, confState.Nodes):
+			continue
+		case NodeExists(consenterID, confState.Learners):
+			if nonVoting {
 				continue
 			}
-			raftConfChange.NodeID = consenterID
+			// promoting learner
+			return &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: consenterID}
+		case nonVoting:
+			// adding new learner
+			return &raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: consenterID}
+		default:
+			// adding new node
+			return &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: consenterID}
 		}
-	} else {
-		// removing node
-		raftConfChange.Type = raftpb.ConfChangeRemoveNode
-		for _, nodeID := range confState.Nodes {
-			if NodeExists(nodeID, blockMetadata.ConsenterIds) {
-				continue
+	}
+
+	// removing node
+	for _, nodes := range [][]uint64{confState.Nodes, confState.Learners} {
+		for _, nodeID := range nodes {
+			if !NodeExists(nodeID, blockMetadata.ConsenterIds) {
+				return &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: nodeID}
 			}
-			raftConfChange.NodeID = nodeID
 		}
 	}
 
-	return raftConfChange
+	return nil
 }
 
 // CreateConsentersMap creates a map of Raft Node IDs to Consenter given the block metadata and the config metadata.
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/raftpb"
 )
 
 func TestIsConsenterOfChannel(t *testing.T) {
@@ -298,3 +299,66 @@ func TestCheckConfigMetadata(t *testing.T) {
 		assert.Regexp(t, testCase.errRegex, err)
 	}
 }
+
+func TestConfChange(t *testing.T) {
+	voter := &etcdraftproto.Consenter{}
+	learner := &etcdraftproto.Consenter{NonVoting: true}
+
+	tests := []struct {
+		name         string
+		consenterIDs []uint64
+		consenters   map[uint64]*etcdraftproto.Consenter
+		confState    *raftpb.ConfState
+		expected     *raftpb.ConfChange
+	}{
+		{
+			name:         "in sync",
+			consenterIDs: []uint64{1, 2, 3},
+			consenters:   map[uint64]*etcdraftproto.Consenter{1: voter, 2: voter, 3: learner},
+			confState:    &raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}},
+			expected:     nil,
+		},
+		{
+			name:         "add node",
+			consenterIDs: []uint64{1, 2, 3},
+			consenters:   map[uint64]*etcdraftproto.Consenter{1: voter, 2: voter, 3: voter},
+			confState:    &raftpb.ConfState{Nodes: []uint64{1, 2}},
+			expected:     &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 3},
+		},
+		{
+			name:         "add learner",
+			consenterIDs: []uint64{1, 2, 3},
+			consenters:   map[uint64]*etcdraftproto.Consenter{1: voter, 2: voter, 3: learner},
+			confState:    &raftpb.ConfState{Nodes: []uint64{1, 2}},
+			expected:     &raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 3},
+		},
+		{
+			name:         "promote learner",
+			consenterIDs: []uint64{1, 2, 3},
+			consenters:   map[uint64]*etcdraftproto.Consenter{1: voter, 2: voter, 3: voter},
+			confState:    &raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}},
+			expected:     &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 3},
+		},
+		{
+			name:         "remove node",
+			consenterIDs: []uint64{1, 2},
+			consenters:   map[uint64]*etcdraftproto.Consenter{1: voter, 2: voter},
+			confState:    &raftpb.ConfState{Nodes: []uint64{1, 2, 3}},
+			expected:     &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: 3},
+		},
+		{
+			name:         "remove learner",
+			consenterIDs: []uint64{1, 2},
+			consenters:   map[uint64]*etcdraftproto.Consenter{1: voter, 2: voter},
+			confState:    &raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}},
+			expected:     &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blockMetadata := &etcdraftproto.BlockMetadata{ConsenterIds: test.consenterIDs}
+			assert.Equal(t, test.expected, ConfChange(blockMetadata, test.consenters, test.confState))
+		})
+	}
+}
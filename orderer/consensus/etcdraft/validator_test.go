@@ -177,6 +177,13 @@ var _ = Describe("Metadata Validation", func() {
 				newBytes, _ := proto.Marshal(newMetadata)
 				Expect(chain.ValidateConsensusMetadata(oldBytes, newBytes, newChannel)).NotTo(Succeed())
 			})
+
+			It("fails when a new consenter is non-voting", func() {
+				newMetadata.Consenters[2].NonVoting = true
+				newBytes, _ := proto.Marshal(newMetadata)
+				Expect(chain.ValidateConsensusMetadata(oldBytes, newBytes, newChannel)).To(
+					MatchError("new channel has non-voting consenter, learners may only be added to an existing channel"))
+			})
 		})
 
 		Context("config update on a channel", func() {
@@ -208,6 +215,49 @@ var _ = Describe("Metadata Validation", func() {
 				Expect(chain.ValidateConsensusMetadata(oldBytes, newBytes, newChannel)).To(Succeed())
 			})
 
+			It("succeeds on addition of a single learner", func() {
+				newMetadata.Consenters = append(newMetadata.Consenters, &etcdraftproto.Consenter{
+					Host:          "host4",
+					Port:          10004,
+					ClientTlsCert: clientTLSCert(tlsCA),
+					ServerTlsCert: serverTLSCert(tlsCA),
+					NonVoting:     true,
+				})
+				newBytes, _ := proto.Marshal(newMetadata)
+				Expect(chain.ValidateConsensusMetadata(oldBytes, newBytes, newChannel)).To(Succeed())
+			})
+
+			It("fails on demotion of a voting consenter", func() {
+				newMetadata.Consenters[2].NonVoting = true
+				newBytes, _ := proto.Marshal(newMetadata)
+				Expect(chain.ValidateConsensusMetadata(oldBytes, newBytes, newChannel)).To(
+					MatchError("consenter host3:10003 is a voting member, it cannot become non-voting"))
+			})
+
+			Context("promotion of a learner", func() {
+				var learnerBytes []byte
+
+				BeforeEach(func() {
+					learnerMetadata := proto.Clone(oldMetadata).(*etcdraftproto.ConfigMetadata)
+					learnerMetadata.Consenters[2].NonVoting = true
+					learnerBytes, _ = proto.Marshal(learnerMetadata)
+				})
+
+				It("fails when the learner has not caught up", func() {
+					chain.ActiveNodes.Store([]uint64{1, 2})
+					newBytes, _ := proto.Marshal(newMetadata)
+					Expect(chain.ValidateConsensusMetadata(learnerBytes, newBytes, newChannel)).To(
+						MatchError("learner 3 cannot be promoted: learner has not caught up"))
+				})
+
+				It("succeeds when the learner has caught up", func() {
+					chain.ActiveNodes.Store([]uint64{1, 2})
+					chain.CaughtUpLearners.Store([]uint64{3})
+					newBytes, _ := proto.Marshal(newMetadata)
+					Expect(chain.ValidateConsensusMetadata(learnerBytes, newBytes, newChannel)).To(Succeed())
+				})
+			})
+
 			It("fails on addition of more than one consenter", func() {
 				newMetadata.Consenters = append(newMetadata.Consenters,
 					&etcdraftproto.Consenter{
@@ -29,9 +29,21 @@ import (
 // pulls blocks equal or above the join-block number.
 type Chain struct {
 	Err error
+
+	// Ledger provides the block height of the channel; nil if unknown.
+	Ledger LedgerResources
+	// JoinBlock is the block the orderer joined the channel with; nil when the orderer became a follower after it was
+	// removed from the consenters set of the channel.
+	JoinBlock *common.Block
 	//TODO skeleton
 }
 
+// LedgerResources provides the ledger height of the channel that the follower pulls blocks for.
+type LedgerResources interface {
+	// Height returns the number of blocks in the ledger of the channel.
+	Height() uint64
+}
+
 func (c *Chain) Order(_ *common.Envelope, _ uint64) error {
 	return c.Err
 }
@@ -61,6 +73,24 @@ func (c *Chain) Halt() {
 // StatusReport returns the ClusterRelation & Status
 func (c *Chain) StatusReport() (types.ClusterRelation, types.Status) {
 	status := types.StatusActive
-	//TODO if (height is >= join-block.height) return "active"; else return "onboarding"
+	if report := c.FollowerReport(); report.Height < report.TargetHeight {
+		status = types.StatusOnBoarding
+	}
 	return types.ClusterRelationFollower, status
 }
+
+// FollowerReport returns the progress of the follower: the block height of the channel, the height at which the
+// follower becomes active, and the last error it encountered.
+func (c *Chain) FollowerReport() types.FollowerInfo {
+	info := types.FollowerInfo{}
+	if c.Ledger != nil {
+		info.Height = c.Ledger.Height()
+	}
+	if c.JoinBlock != nil {
+		info.TargetHeight = c.JoinBlock.Header.Number + 1
+	}
+	if c.Err != nil {
+		info.LastError = c.Err.Error()
+	}
+	return info
+}
@@ -7,9 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package follower_test
 
 import (
-	"github.com/hyperledger/fabric/orderer/common/types"
 	"testing"
 
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus/follower"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -33,3 +34,45 @@ func TestFollowerChain(t *testing.T) {
 	assert.Equal(t, types.ClusterRelationFollower, cRel)
 	assert.True(t, status == types.StatusActive || status == types.StatusOnBoarding)
 }
+
+type ledgerHeight uint64
+
+func (h ledgerHeight) Height() uint64 {
+	return uint64(h)
+}
+
+func TestFollowerChainReport(t *testing.T) {
+	joinBlock := &common.Block{Header: &common.BlockHeader{Number: 10}}
+
+	t.Run("without join block", func(t *testing.T) {
+		chain := &follower.Chain{Err: errors.New("bar"), Ledger: ledgerHeight(5)}
+
+		_, status := chain.StatusReport()
+		assert.Equal(t, types.StatusActive, status)
+		assert.Equal(t, types.FollowerInfo{Height: 5, LastError: "bar"}, chain.FollowerReport())
+	})
+
+	t.Run("onboarding", func(t *testing.T) {
+		chain := &follower.Chain{Ledger: ledgerHeight(10), JoinBlock: joinBlock}
+
+		_, status := chain.StatusReport()
+		assert.Equal(t, types.StatusOnBoarding, status)
+		assert.Equal(t, types.FollowerInfo{Height: 10, TargetHeight: 11}, chain.FollowerReport())
+	})
+
+	t.Run("active", func(t *testing.T) {
+		chain := &follower.Chain{Ledger: ledgerHeight(11), JoinBlock: joinBlock}
+
+		_, status := chain.StatusReport()
+		assert.Equal(t, types.StatusActive, status)
+		assert.Equal(t, types.FollowerInfo{Height: 11, TargetHeight: 11}, chain.FollowerReport())
+	})
+
+	t.Run("without ledger", func(t *testing.T) {
+		chain := &follower.Chain{JoinBlock: joinBlock}
+
+		_, status := chain.StatusReport()
+		assert.Equal(t, types.StatusOnBoarding, status)
+		assert.Equal(t, types.FollowerInfo{TargetHeight: 11}, chain.FollowerReport())
+	})
+}
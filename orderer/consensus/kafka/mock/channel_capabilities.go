@@ -8,6 +8,16 @@ import (
 )
 
 type ChannelCapabilities struct {
+	ConfigurableHashingAlgorithmStub        func() bool
+	configurableHashingAlgorithmMutex       sync.RWMutex
+	configurableHashingAlgorithmArgsForCall []struct {
+	}
+	configurableHashingAlgorithmReturns struct {
+		result1 bool
+	}
+	configurableHashingAlgorithmReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ConsensusTypeMigrationStub        func() bool
 	consensusTypeMigrationMutex       sync.RWMutex
 	consensusTypeMigrationArgsForCall []struct {
@@ -52,6 +62,58 @@ type ChannelCapabilities struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ChannelCapabilities) ConfigurableHashingAlgorithm() bool {
+	fake.configurableHashingAlgorithmMutex.Lock()
+	ret, specificReturn := fake.configurableHashingAlgorithmReturnsOnCall[len(fake.configurableHashingAlgorithmArgsForCall)]
+	fake.configurableHashingAlgorithmArgsForCall = append(fake.configurableHashingAlgorithmArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConfigurableHashingAlgorithm", []interface{}{})
+	fake.configurableHashingAlgorithmMutex.Unlock()
+	if fake.ConfigurableHashingAlgorithmStub != nil {
+		return fake.ConfigurableHashingAlgorithmStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.configurableHashingAlgorithmReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelCapabilities) ConfigurableHashingAlgorithmCallCount() int {
+	fake.configurableHashingAlgorithmMutex.RLock()
+	defer fake.configurableHashingAlgorithmMutex.RUnlock()
+	return len(fake.configurableHashingAlgorithmArgsForCall)
+}
+
+func (fake *ChannelCapabilities) ConfigurableHashingAlgorithmCalls(stub func() bool) {
+	fake.configurableHashingAlgorithmMutex.Lock()
+	defer fake.configurableHashingAlgorithmMutex.Unlock()
+	fake.ConfigurableHashingAlgorithmStub = stub
+}
+
+func (fake *ChannelCapabilities) ConfigurableHashingAlgorithmReturns(result1 bool) {
+	fake.configurableHashingAlgorithmMutex.Lock()
+	defer fake.configurableHashingAlgorithmMutex.Unlock()
+	fake.ConfigurableHashingAlgorithmStub = nil
+	fake.configurableHashingAlgorithmReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ChannelCapabilities) ConfigurableHashingAlgorithmReturnsOnCall(i int, result1 bool) {
+	fake.configurableHashingAlgorithmMutex.Lock()
+	defer fake.configurableHashingAlgorithmMutex.Unlock()
+	fake.ConfigurableHashingAlgorithmStub = nil
+	if fake.configurableHashingAlgorithmReturnsOnCall == nil {
+		fake.configurableHashingAlgorithmReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.configurableHashingAlgorithmReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ChannelCapabilities) ConsensusTypeMigration() bool {
 	fake.consensusTypeMigrationMutex.Lock()
 	ret, specificReturn := fake.consensusTypeMigrationReturnsOnCall[len(fake.consensusTypeMigrationArgsForCall)]
@@ -263,6 +325,8 @@ func (fake *ChannelCapabilities) SupportedReturnsOnCall(i int, result1 error) {
 func (fake *ChannelCapabilities) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.configurableHashingAlgorithmMutex.RLock()
+	defer fake.configurableHashingAlgorithmMutex.RUnlock()
 	fake.consensusTypeMigrationMutex.RLock()
 	defer fake.consensusTypeMigrationMutex.RUnlock()
 	fake.mSPVersionMutex.RLock()
@@ -67,6 +67,18 @@ func BlockDataHash(b *cb.BlockData) []byte {
 	return sum[:]
 }
 
+// BlockHeaderHashWith returns the hash of the block header computed with the
+// given hashing algorithm rather than with SHA-256.
+func BlockHeaderHashWith(b *cb.BlockHeader, hash func([]byte) []byte) []byte {
+	return hash(BlockHeaderBytes(b))
+}
+
+// BlockDataHashWith returns the hash of the block data computed with the
+// given hashing algorithm rather than with SHA-256.
+func BlockDataHashWith(b *cb.BlockData, hash func([]byte) []byte) []byte {
+	return hash(bytes.Join(b.Data, nil))
+}
+
 // GetChannelIDFromBlockBytes returns channel ID given byte array which represents
 // the block
 func GetChannelIDFromBlockBytes(bytes []byte) (string, error) {
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,6 +54,19 @@ func TestNewBlock(t *testing.T) {
 	assert.Equal(t, headerHash[:], protoutil.BlockHeaderHash(block.Header), "Incorrect blockheader hash")
 }
 
+func TestBlockHashWith(t *testing.T) {
+	data := &cb.BlockData{
+		Data: [][]byte{{0, 1, 2}, {3, 4}},
+	}
+	block := protoutil.NewBlock(uint64(1), []byte("previoushash"))
+	block.Header.DataHash = protoutil.BlockDataHashWith(data, util.ComputeGMSM3)
+
+	assert.Equal(t, util.ComputeGMSM3([]byte{0, 1, 2, 3, 4}), block.Header.DataHash, "Incorrect block data hash")
+	assert.Equal(t, protoutil.BlockDataHash(data), protoutil.BlockDataHashWith(data, util.ComputeSHA256))
+	assert.Equal(t, util.ComputeGMSM3(protoutil.BlockHeaderBytes(block.Header)), protoutil.BlockHeaderHashWith(block.Header, util.ComputeGMSM3), "Incorrect blockheader hash")
+	assert.Equal(t, protoutil.BlockHeaderHash(block.Header), protoutil.BlockHeaderHashWith(block.Header, util.ComputeSHA256))
+}
+
 func TestGoodBlockHeaderBytes(t *testing.T) {
 	goodBlockHeader := &common.BlockHeader{
 		Number:       1,
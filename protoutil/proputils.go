@@ -352,22 +352,37 @@ func createProposalFromCDS(channelID string, msg proto.Message, creator []byte,
 	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, channelID, lsccSpec, creator)
 }
 
-// ComputeTxID computes TxID as the Hash computed
+// ComputeTxID computes TxID as the SHA-256 Hash computed
 // over the concatenation of nonce and creator.
 func ComputeTxID(nonce, creator []byte) string {
-	// TODO: Get the Hash function to be used from
-	// channel configuration
 	hasher := sha256.New()
 	hasher.Write(nonce)
 	hasher.Write(creator)
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
+// ComputeTxIDWith computes TxID as the Hash computed with the given
+// hashing algorithm over the concatenation of nonce and creator.
+func ComputeTxIDWith(nonce, creator []byte, hash func([]byte) []byte) string {
+	data := make([]byte, 0, len(nonce)+len(creator))
+	data = append(data, nonce...)
+	data = append(data, creator...)
+	return hex.EncodeToString(hash(data))
+}
+
 // CheckTxID checks that txid is equal to the Hash computed
 // over the concatenation of nonce and creator.
 func CheckTxID(txid string, nonce, creator []byte) error {
-	computedTxID := ComputeTxID(nonce, creator)
+	return checkTxID(txid, ComputeTxID(nonce, creator))
+}
+
+// CheckTxIDWith checks that txid is equal to the Hash computed with the
+// given hashing algorithm over the concatenation of nonce and creator.
+func CheckTxIDWith(txid string, nonce, creator []byte, hash func([]byte) []byte) error {
+	return checkTxID(txid, ComputeTxIDWith(nonce, creator, hash))
+}
 
+func checkTxID(txid, computedTxID string) error {
 	if txid != computedTxID {
 		return errors.Errorf("invalid txid. got [%s], expected [%s]", txid, computedTxID)
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
@@ -468,6 +469,17 @@ func TestComputeProposalTxID(t *testing.T) {
 	assert.Equal(t, txid, txid2)
 }
 
+func TestComputeTxIDWith(t *testing.T) {
+	nonce, creator := []byte{1}, []byte{2}
+	assert.Equal(t, protoutil.ComputeTxID(nonce, creator), protoutil.ComputeTxIDWith(nonce, creator, util.ComputeSHA256))
+
+	txid := protoutil.ComputeTxIDWith(nonce, creator, util.ComputeGMSM3)
+	assert.Equal(t, hex.EncodeToString(util.ComputeGMSM3([]byte{1, 2})), txid)
+	assert.NoError(t, protoutil.CheckTxIDWith(txid, nonce, creator, util.ComputeGMSM3))
+	assert.Error(t, protoutil.CheckTxID(txid, nonce, creator))
+	assert.EqualError(t, protoutil.CheckTxIDWith("", nonce, creator, util.ComputeGMSM3), "invalid txid. got [], expected ["+txid+"]")
+}
+
 var signer msp.SigningIdentity
 var signerSerialized []byte
 
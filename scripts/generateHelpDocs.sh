@@ -72,6 +72,13 @@ generateHelpText \
         docs/wrappers/peer_node_postscript.md \
         "${commands[@]}"
 
+commands=("peer snapshot submitrequest" "peer snapshot cancelrequest" "peer snapshot listpending")
+generateHelpText \
+        docs/source/commands/peersnapshot.md \
+        docs/wrappers/peer_snapshot_preamble.md \
+        docs/wrappers/peer_snapshot_postscript.md \
+        "${commands[@]}"
+
 commands=("configtxgen")
 generateHelpText \
         docs/source/commands/configtxgen.md \
@@ -93,4 +100,11 @@ generateHelpText \
         docs/wrappers/configtxlator_postscript.md \
         "${commands[@]}"
 
+commands=("osnadmin channel" "osnadmin channel join" "osnadmin channel list" "osnadmin channel remove" "osnadmin channel fetch" "osnadmin channel update" "osnadmin channel promote")
+generateHelpText \
+        docs/source/commands/osnadminchannel.md \
+        docs/wrappers/osnadmin_channel_preamble.md \
+        docs/wrappers/license_postscript.md \
+        "${commands[@]}"
+
 exit
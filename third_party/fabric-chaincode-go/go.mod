@@ -0,0 +1,13 @@
+module github.com/littlegirlpppp/fabric-chaincode-go
+
+go 1.14
+
+require (
+	github.com/golang/protobuf v1.3.3
+	github.com/hyperledger/fabric-protos-go v0.0.0-20201028172056-a3136dde2354
+	github.com/littlegirlpppp/gmsm v0.0.0-20210125031919-ab0c0a65837c
+	github.com/stretchr/testify v1.6.1
+	google.golang.org/grpc v1.33.1
+)
+
+replace github.com/hyperledger/fabric-protos-go => ../fabric-protos-go
@@ -0,0 +1,8 @@
+module github.com/hyperledger/fabric-protos-go
+
+go 1.12
+
+require (
+	github.com/golang/protobuf v1.3.2
+	google.golang.org/grpc v1.23.0
+)